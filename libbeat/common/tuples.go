@@ -171,6 +171,8 @@ type Process struct {
 	Exe       string    // Absolute path to exe.
 	CWD       string    // Current working directory.
 	StartTime time.Time // Start time of process.
+
+	ContainerID string // ID of the container the process runs in, if any.
 }
 
 // Reverse returns a copy of the receiver with the source and destination fields
@@ -67,13 +67,13 @@ func init() {
 
 func initialConfig() config.Config {
 	return config.Config{
-		Interfaces: config.InterfacesConfig{
+		Interfaces: config.InterfaceList{{
 			File:       *cmdLineArgs.file,
 			Loop:       *cmdLineArgs.loop,
 			TopSpeed:   *cmdLineArgs.topSpeed,
 			OneAtATime: *cmdLineArgs.oneAtAtime,
 			Dumpfile:   *cmdLineArgs.dumpfile,
-		},
+		}},
 	}
 }
 
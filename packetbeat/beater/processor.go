@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
 
 	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/dump"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
@@ -40,16 +41,18 @@ type processor struct {
 	wg              sync.WaitGroup
 	publisher       *publish.TransactionPublisher
 	flows           *flows.Flows
-	sniffer         *sniffer.Sniffer
+	trigger         *dump.Trigger
+	sniffers        []*sniffer.Sniffer
 	shutdownTimeout time.Duration
 	err             chan error
 }
 
-func newProcessor(shutdownTimeout time.Duration, publisher *publish.TransactionPublisher, flows *flows.Flows, sniffer *sniffer.Sniffer, err chan error) *processor {
+func newProcessor(shutdownTimeout time.Duration, publisher *publish.TransactionPublisher, flows *flows.Flows, trigger *dump.Trigger, sniffers []*sniffer.Sniffer, err chan error) *processor {
 	return &processor{
 		publisher:       publisher,
 		flows:           flows,
-		sniffer:         sniffer,
+		trigger:         trigger,
+		sniffers:        sniffers,
 		err:             err,
 		shutdownTimeout: shutdownTimeout,
 	}
@@ -63,21 +66,28 @@ func (p *processor) Start() {
 	if p.flows != nil {
 		p.flows.Start()
 	}
-	p.wg.Add(1)
-	go func() {
-		defer p.wg.Done()
-
-		err := p.sniffer.Run()
-		if err != nil {
-			p.err <- fmt.Errorf("sniffer loop failed: %v", err)
-			return
-		}
-		p.err <- nil
-	}()
+	if p.trigger != nil {
+		p.trigger.Start()
+	}
+	for _, s := range p.sniffers {
+		p.wg.Add(1)
+		go func(s *sniffer.Sniffer) {
+			defer p.wg.Done()
+
+			err := s.Run()
+			if err != nil {
+				p.err <- fmt.Errorf("sniffer loop failed on device '%s': %v", s.Device(), err)
+				return
+			}
+			p.err <- nil
+		}(s)
+	}
 }
 
 func (p *processor) Stop() {
-	p.sniffer.Stop()
+	for _, s := range p.sniffers {
+		s.Stop()
+	}
 	if p.flows != nil {
 		p.flows.Stop()
 	}
@@ -88,6 +98,9 @@ func (p *processor) Stop() {
 		time.Sleep(p.shutdownTimeout)
 	}
 	p.publisher.Stop()
+	if p.trigger != nil {
+		p.trigger.Stop()
+	}
 }
 
 type processorFactory struct {
@@ -117,16 +130,24 @@ func (p *processorFactory) Create(pipeline beat.PipelineConnector, cfg *common.C
 		p.beat.Info.Name,
 		p.beat.Publisher,
 		config.IgnoreOutgoing,
-		config.Interfaces.File == "",
-		config.Interfaces.InternalNetworks,
+		config.Interfaces.File() == "",
+		config.Interfaces.InternalNetworks(),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	trigger, err := dump.NewTrigger(config.DumpTrigger, config.Tunnels)
+	if err != nil {
+		return nil, fmt.Errorf("Initializing dump trigger failed: %v", err)
+	}
+	if trigger != nil {
+		publisher.SetEventHook(trigger.OnEvent)
+	}
+
 	watcher := procs.ProcessesWatcher{}
 	// Enable the process watcher only if capturing live traffic
-	if config.Interfaces.File == "" {
+	if config.Interfaces.File() == "" {
 		err = watcher.Init(config.Procs)
 		if err != nil {
 			logp.Critical(err.Error())
@@ -146,12 +167,19 @@ func (p *processorFactory) Create(pipeline beat.PipelineConnector, cfg *common.C
 	if err != nil {
 		return nil, err
 	}
-	sniffer, err := setupSniffer(config, protocols, workerFactory(publisher, protocols, watcher, flows, config))
+	workers := workerFactory(publisher, protocols, watcher, flows, config)
+	fanoutWorkers := fanoutWorkerFactory(publisher, watcher, flows, config)
+	if trigger != nil {
+		workers = recordingWorkerFactory(trigger, workers)
+		fanoutWorkers = recordingWorkerFactory(trigger, fanoutWorkers)
+	}
+
+	sniffers, err := setupSniffers(config, protocols, workers, fanoutWorkers)
 	if err != nil {
 		return nil, err
 	}
 
-	return newProcessor(config.ShutdownTimeout, publisher, flows, sniffer, p.err), nil
+	return newProcessor(config.ShutdownTimeout, publisher, flows, trigger, sniffers, p.err), nil
 }
 
 func (p *processorFactory) CheckConfig(config *common.Config) error {
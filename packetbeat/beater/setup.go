@@ -18,6 +18,10 @@
 package beater
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/processors"
@@ -28,18 +32,90 @@ import (
 	"github.com/elastic/beats/v7/packetbeat/sniffer"
 )
 
-func setupSniffer(cfg config.Config, protocols *protos.ProtocolsStruct, workerFactory sniffer.WorkerFactory) (*sniffer.Sniffer, error) {
+// setupSniffers creates a sniffer per interface. Sniffers in fanout mode use
+// fanoutFactory to create workers with their own protocol analyzers, all
+// other sniffers share the workers created by workerFactory.
+func setupSniffers(cfg config.Config, protocols *protos.ProtocolsStruct, workerFactory, fanoutFactory sniffer.WorkerFactory) ([]*sniffer.Sniffer, error) {
 	icmp, err := cfg.ICMP()
 	if err != nil {
 		return nil, err
 	}
 
-	filter := cfg.Interfaces.BpfFilter
-	if filter == "" && !cfg.Flows.IsEnabled() {
-		filter = protocols.BpfFilter(cfg.Interfaces.WithVlans, icmp.Enabled())
+	interfaces := cfg.Interfaces
+	switch {
+	case len(interfaces) == 0:
+		interfaces = config.InterfaceList{{}}
+	case interfaces.File() != "":
+		// all packets are read from the file, the devices are ignored
+		interfaces = interfaces[:1]
+	case len(interfaces) > maxSniffers:
+		return nil, fmt.Errorf("too many interfaces configured: %d, at most %d are supported", len(interfaces), maxSniffers)
+	}
+
+	var sniffers []*sniffer.Sniffer
+	for _, iface := range interfaces {
+		if iface.Dumpfile != "" && len(interfaces) > 1 {
+			return nil, errors.New("dumping packets to a file is only supported with a single interface")
+		}
+
+		// flows and protocol detection need all packets, not only the ones
+		// of the protocols' ports
+		filter := iface.BpfFilter
+		if filter == "" && !cfg.Flows.IsEnabled() && !cfg.Detection.Enabled {
+			if cfg.Tunnels.Enabled() {
+				filter = tunnelBpfFilter(protocols.BpfFilter(false, icmp.Enabled()), iface.WithVlans, cfg.Tunnels)
+			} else {
+				filter = protocols.BpfFilter(iface.WithVlans, icmp.Enabled())
+			}
+		}
+
+		factory := workerFactory
+		if iface.Fanout.Workers > 1 {
+			factory = fanoutFactory
+		}
+
+		s, err := sniffer.New(false, filter, factory, iface)
+		if err != nil {
+			return nil, err
+		}
+		sniffers = append(sniffers, s)
 	}
+	return sniffers, nil
+}
 
-	return sniffer.New(false, filter, workerFactory, cfg.Interfaces)
+// tunnelBpfFilter extends the generated BPF filter to also capture the
+// tunnels to be decapsulated. The port filters of the protocol analyzers
+// can not be applied to the encapsulated packets, so all tunnel traffic
+// is captured.
+func tunnelBpfFilter(filter string, withVlans bool, tunnels config.TunnelsConfig) string {
+	var expressions []string
+	if filter != "" {
+		expressions = append(expressions, filter)
+	}
+	for _, port := range tunnels.VXLANPorts {
+		expressions = append(expressions, fmt.Sprintf("udp dst port %d", port))
+	}
+	for _, port := range tunnels.GenevePorts {
+		expressions = append(expressions, fmt.Sprintf("udp dst port %d", port))
+	}
+	if tunnels.GRE {
+		expressions = append(expressions, "ip proto 47", "ip6 proto 47")
+	}
+
+	filter = strings.Join(expressions, " or ")
+	if withVlans && filter != "" {
+		filter = fmt.Sprintf("%s or (vlan and (%s))", filter, filter)
+	}
+
+	// The vlan and mpls primitives change the offsets used by all following
+	// expressions, so mpls must come last.
+	if tunnels.MPLS {
+		if filter == "" {
+			return "mpls"
+		}
+		filter += " or mpls"
+	}
+	return filter
 }
 
 func setupFlows(pipeline beat.Pipeline, watcher procs.ProcessesWatcher, cfg config.Config) (*flows.Flows, error) {
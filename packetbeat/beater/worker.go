@@ -18,10 +18,15 @@
 package beater
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/tsg/gopacket"
 	"github.com/tsg/gopacket/layers"
 
 	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/decoder"
+	"github.com/elastic/beats/v7/packetbeat/dump"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
@@ -32,44 +37,139 @@ import (
 	"github.com/elastic/beats/v7/packetbeat/sniffer"
 )
 
-func workerFactory(publisher *publish.TransactionPublisher, protocols *protos.ProtocolsStruct, watcher procs.ProcessesWatcher, flows *flows.Flows, cfg config.Config) func(dl layers.LinkType) (sniffer.Worker, error) {
-	return func(dl layers.LinkType) (sniffer.Worker, error) {
-		var icmp4 icmp.ICMPv4Processor
-		var icmp6 icmp.ICMPv6Processor
-		config, err := cfg.ICMP()
+func workerFactory(publisher *publish.TransactionPublisher, protocols *protos.ProtocolsStruct, watcher procs.ProcessesWatcher, flows *flows.Flows, cfg config.Config) sniffer.WorkerFactory {
+	// protocol analyzers and flows are shared by the workers of all sniffers
+	var mu sync.Mutex
+
+	return func(device string, dl layers.LinkType) (sniffer.Worker, error) {
+		worker, err := newWorker(publisher, protocols, watcher, flows, cfg, device, dl)
 		if err != nil {
 			return nil, err
 		}
-		if config.Enabled() {
-			reporter, err := publisher.CreateReporter(config)
-			if err != nil {
-				return nil, err
-			}
-
-			icmp, err := icmp.New(false, reporter, watcher, config)
-			if err != nil {
-				return nil, err
-			}
-
-			icmp4 = icmp
-			icmp6 = icmp
+
+		return &lockedWorker{
+			mu:     &mu,
+			worker: worker,
+		}, nil
+	}
+}
+
+// fanoutWorkerFactory creates workers with their own protocol analyzers, so
+// that the workers of a fanout group can process packets concurrently.
+func fanoutWorkerFactory(publisher *publish.TransactionPublisher, watcher procs.ProcessesWatcher, flows *flows.Flows, cfg config.Config) sniffer.WorkerFactory {
+	return func(device string, dl layers.LinkType) (sniffer.Worker, error) {
+		protocols := protos.NewProtocols()
+		err := protocols.Init(false, publisher, watcher, cfg.Protocols, cfg.ProtocolsList)
+		if err != nil {
+			return nil, fmt.Errorf("Initializing protocol analyzers failed: %v", err)
 		}
 
-		tcp, err := tcp.NewTCP(protocols)
+		return newWorker(publisher, protocols, watcher, flows, cfg, device, dl)
+	}
+}
+
+func newWorker(publisher *publish.TransactionPublisher, protocols *protos.ProtocolsStruct, watcher procs.ProcessesWatcher, flows *flows.Flows, cfg config.Config, device string, dl layers.LinkType) (*decoder.Decoder, error) {
+	var icmp4 icmp.ICMPv4Processor
+	var icmp6 icmp.ICMPv6Processor
+	config, err := cfg.ICMP()
+	if err != nil {
+		return nil, err
+	}
+	if config.Enabled() {
+		reporter, err := publisher.CreateReporter(config)
 		if err != nil {
 			return nil, err
 		}
 
-		udp, err := udp.NewUDP(protocols)
+		icmp, err := icmp.New(false, reporter, watcher, config)
 		if err != nil {
 			return nil, err
 		}
 
-		worker, err := decoder.New(flows, dl, icmp4, icmp6, tcp, udp)
+		icmp4 = icmp
+		icmp6 = icmp
+	}
+
+	tcp, err := tcp.NewTCP(protocols, cfg.TCP, cfg.Detection)
+	if err != nil {
+		return nil, err
+	}
+	if flows != nil {
+		if err := tcp.EnableFlowMetrics(flows); err != nil {
+			return nil, err
+		}
+	}
+
+	udp, err := udp.NewUDP(protocols, cfg.Detection)
+	if err != nil {
+		return nil, err
+	}
+
+	worker, err := decoder.New(flows, dl, icmp4, icmp6, tcp, udp, cfg.IPFragments, cfg.Tunnels)
+	if err != nil {
+		return nil, err
+	}
+	worker.SetIngressInterface(device)
+	return worker, nil
+}
+
+// lockedWorker serializes the packet processing of all sniffers, as the
+// protocol analyzers are not safe for concurrent use.
+type lockedWorker struct {
+	mu     *sync.Mutex
+	worker sniffer.Worker
+}
+
+func (w *lockedWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.worker.OnPacket(data, ci)
+}
+
+func (w *lockedWorker) OnAnnotatedPacket(data []byte, ci *gopacket.CaptureInfo, comments []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if aw, ok := w.worker.(sniffer.AnnotatedWorker); ok {
+		aw.OnAnnotatedPacket(data, ci, comments)
+		return
+	}
+	w.worker.OnPacket(data, ci)
+}
+
+// recordingWorkerFactory keeps the recent packets of every worker in memory,
+// for the dump trigger to save the packets of a transaction. Each worker has
+// its own window, so that fanout workers record packets concurrently.
+func recordingWorkerFactory(trigger *dump.Trigger, factory sniffer.WorkerFactory) sniffer.WorkerFactory {
+	return func(device string, dl layers.LinkType) (sniffer.Worker, error) {
+		worker, err := factory(device, dl)
 		if err != nil {
 			return nil, err
 		}
 
-		return worker, nil
+		return &recordingWorker{
+			window: trigger.Window(device, dl),
+			worker: worker,
+		}, nil
+	}
+}
+
+type recordingWorker struct {
+	window *dump.Window
+	worker sniffer.Worker
+}
+
+func (w *recordingWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	w.window.Add(data, ci)
+	w.worker.OnPacket(data, ci)
+}
+
+func (w *recordingWorker) OnAnnotatedPacket(data []byte, ci *gopacket.CaptureInfo, comments []string) {
+	w.window.Add(data, ci)
+	if aw, ok := w.worker.(sniffer.AnnotatedWorker); ok {
+		aw.OnAnnotatedPacket(data, ci, comments)
+		return
 	}
+	w.worker.OnPacket(data, ci)
 }
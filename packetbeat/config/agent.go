@@ -106,8 +106,14 @@ func mergeProcsConfig(one, two procs.ProcsConfig) procs.ProcsConfig {
 		refreshPidsFreq = two.RefreshPidsFreq
 	}
 
+	backend := one.Backend
+	if backend == "" {
+		backend = two.Backend
+	}
+
 	return procs.ProcsConfig{
 		Enabled:         true,
+		Backend:         backend,
 		MaxProcReadFreq: maxProcReadFreq,
 		RefreshPidsFreq: refreshPidsFreq,
 		Monitored:       append(one.Monitored, two.Monitored...),
@@ -120,10 +126,10 @@ func NewAgentConfig(cfg *common.Config) (Config, error) {
 	logp.Debug("agent", "Normalizing agent configuration")
 	var input agentInput
 	config := Config{
-		Interfaces: InterfacesConfig{
+		Interfaces: InterfaceList{{
 			// TODO: make this configurable rather than just using the default device
 			Device: defaultDevice(),
-		},
+		}},
 	}
 	if err := cfg.Unpack(&input); err != nil {
 		return config, err
@@ -136,7 +142,7 @@ func NewAgentConfig(cfg *common.Config) (Config, error) {
 			if err != nil {
 				return config, err
 			}
-			if err := cfg.Unpack(&config.Interfaces); err != nil {
+			if err := cfg.Unpack(&config.Interfaces[0]); err != nil {
 				return config, err
 			}
 		}
@@ -81,6 +81,6 @@ streams:
 	var protocol map[string]interface{}
 	require.NoError(t, config.ProtocolsList[0].Unpack(&protocol))
 	require.Len(t, protocol["processors"].([]interface{}), 3)
-	require.Equal(t, config.Interfaces.Device, "en1")
+	require.Equal(t, config.Interfaces[0].Device, "en1")
 	require.Len(t, config.Procs.Monitored, 2)
 }
@@ -22,16 +22,22 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/conditions"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 )
 
 type Config struct {
-	Interfaces      InterfacesConfig          `config:"interfaces"`
+	Interfaces      InterfaceList             `config:"interfaces"`
 	Flows           *Flows                    `config:"flows"`
 	Protocols       map[string]*common.Config `config:"protocols"`
 	ProtocolsList   []*common.Config          `config:"protocols"`
 	Procs           procs.ProcsConfig         `config:"procs"`
+	TCP             TCPConfig                 `config:"tcp"`
+	Detection       DetectionConfig           `config:"protocol_detection"`
+	IPFragments     IPFragmentsConfig         `config:"ip_fragments"`
+	Tunnels         TunnelsConfig             `config:"tunnels"`
+	DumpTrigger     DumpTriggerConfig         `config:"dump_trigger"`
 	IgnoreOutgoing  bool                      `config:"ignore_outgoing"`
 	ShutdownTimeout time.Duration             `config:"shutdown_timeout"`
 }
@@ -74,22 +80,188 @@ func (c Config) ICMP() (*common.Config, error) {
 	return icmp, nil
 }
 
+// InterfaceList holds the capture interfaces. The interfaces setting accepts
+// a single interface object, or a list of interfaces to capture from at the
+// same time.
+type InterfaceList []InterfacesConfig
+
+// Unpack reads a single interface or a list of interfaces. Settings already
+// present in the first entry, like the command line flags, are used as
+// defaults for all interfaces.
+func (l *InterfaceList) Unpack(cfg *common.Config) error {
+	var defaults InterfacesConfig
+	if len(*l) > 0 {
+		defaults = (*l)[0]
+	}
+
+	if !cfg.IsArray() {
+		iface := defaults
+		if err := cfg.Unpack(&iface); err != nil {
+			return err
+		}
+		*l = InterfaceList{iface}
+		return nil
+	}
+
+	var configs []*common.Config
+	if err := cfg.Unpack(&configs); err != nil {
+		return err
+	}
+	list := make(InterfaceList, len(configs))
+	for i, sub := range configs {
+		list[i] = defaults
+		if err := sub.Unpack(&list[i]); err != nil {
+			return err
+		}
+	}
+	if len(list) == 0 {
+		return errors.New("no capture interface configured")
+	}
+	*l = list
+	return nil
+}
+
+// File returns the pcap file to read packets from instead of capturing
+// from the network interfaces. The file is configured for the first
+// interface only.
+func (l InterfaceList) File() string {
+	if len(l) == 0 {
+		return ""
+	}
+	return l[0].File
+}
+
+// InternalNetworks returns the internal networks configured for all
+// interfaces.
+func (l InterfaceList) InternalNetworks() []string {
+	var networks []string
+	for _, iface := range l {
+		networks = append(networks, iface.InternalNetworks...)
+	}
+	return networks
+}
+
 type InterfacesConfig struct {
-	Device                string   `config:"device"`
-	Type                  string   `config:"type"`
-	File                  string   `config:"file"`
-	WithVlans             bool     `config:"with_vlans"`
-	BpfFilter             string   `config:"bpf_filter"`
-	Snaplen               int      `config:"snaplen"`
-	BufferSizeMb          int      `config:"buffer_size_mb"`
-	EnableAutoPromiscMode bool     `config:"auto_promisc_mode"`
-	InternalNetworks      []string `config:"internal_networks"`
+	Device                string             `config:"device"`
+	Type                  string             `config:"type"`
+	File                  string             `config:"file"`
+	WithVlans             bool               `config:"with_vlans"`
+	BpfFilter             string             `config:"bpf_filter"`
+	Snaplen               int                `config:"snaplen"`
+	BufferSizeMb          int                `config:"buffer_size_mb"`
+	EnableAutoPromiscMode bool               `config:"auto_promisc_mode"`
+	InternalNetworks      []string           `config:"internal_networks"`
+	Fanout                FanoutConfig       `config:"fanout"`
+	CaptureStats          CaptureStatsConfig `config:"capture_stats"`
+	Dump                  DumpConfig         `config:"dump"`
 	TopSpeed              bool
 	Dumpfile              string
 	OneAtATime            bool
 	Loop                  int
 }
 
+// FanoutConfig distributes the packets captured by an af_packet sniffer over
+// multiple workers, each with its own socket and protocol analyzers. The
+// kernel hashes the packets by flow, so both directions of a connection are
+// handled by the same worker. Fanout is disabled if Workers is 0 or 1.
+type FanoutConfig struct {
+	Workers int     `config:"workers" validate:"min=0,max=256"`
+	GroupID *uint16 `config:"group_id"`
+}
+
+// CaptureStatsConfig configures the collection of the number of packets
+// received and dropped by the kernel for a capture handle. A warning is
+// logged if the share of dropped packets during a period is greater than
+// DropWarningThreshold.
+type CaptureStatsConfig struct {
+	Period               time.Duration `config:"period"`
+	DropWarningThreshold *float64      `config:"drop_warning_threshold"`
+}
+
+// DropThreshold returns the configured drop warning threshold, or the
+// default of 1% if unset.
+func (c *CaptureStatsConfig) DropThreshold() float64 {
+	if c.DropWarningThreshold == nil {
+		return 0.01
+	}
+	return *c.DropWarningThreshold
+}
+
+// DumpConfig configures writing the captured packets to a ring of rotating
+// pcap or pcapng files. A new file is started when the current file reaches
+// MaxSizeMB. The oldest files are removed when there are more than MaxFiles,
+// or when they have not been written to for MaxAge.
+type DumpConfig struct {
+	Enabled   bool          `config:"enabled"`
+	Path      string        `config:"path"`
+	Name      string        `config:"name"`
+	Format    string        `config:"format"`
+	MaxSizeMB int           `config:"max_size_mb" validate:"min=0"`
+	MaxFiles  int           `config:"max_files" validate:"min=0"`
+	MaxAge    time.Duration `config:"max_age"`
+}
+
+// DumpTriggerConfig configures saving the recent packets of a transaction's
+// flow to a pcap file, when the transaction event matches the When
+// condition. The packets of the last Window are kept in memory, limited to
+// MaxBufferMB per interface.
+type DumpTriggerConfig struct {
+	Enabled     bool               `config:"enabled"`
+	Path        string             `config:"path"`
+	Window      time.Duration      `config:"window"`
+	MaxBufferMB int                `config:"max_buffer_mb" validate:"min=0"`
+	MaxFiles    int                `config:"max_files" validate:"min=0"`
+	When        *conditions.Config `config:"when"`
+}
+
+// TCPConfig holds the settings of the TCP stream reassembly layer.
+type TCPConfig struct {
+	ReorderBuffer ReorderBufferConfig `config:"reorder_buffer"`
+}
+
+// ReorderBufferConfig limits the amount of out-of-order data buffered per
+// TCP stream while waiting for missing segments. A MaxBytes value of 0
+// disables buffering, reporting every hole in the stream as a gap.
+type ReorderBufferConfig struct {
+	MaxBytes int           `config:"max_bytes" validate:"min=0"`
+	Timeout  time.Duration `config:"timeout"`
+}
+
+// DetectionConfig enables classifying the connections not matching the
+// ports of any protocol by the first bytes of their payload. Connections are
+// given up on as unknown once MaxBytes of their payload have been inspected.
+type DetectionConfig struct {
+	Enabled  bool `config:"enabled"`
+	MaxBytes int  `config:"max_bytes" validate:"min=0"`
+}
+
+// IPFragmentsConfig configures the reassembly of fragmented IPv4 and IPv6
+// datagrams. MaxBytes limits the memory used by all incomplete datagrams.
+type IPFragmentsConfig struct {
+	Enabled  *bool         `config:"enabled"`
+	MaxBytes int           `config:"max_bytes" validate:"min=0"`
+	Timeout  time.Duration `config:"timeout"`
+}
+
+func (f *IPFragmentsConfig) IsEnabled() bool {
+	return f.Enabled == nil || *f.Enabled
+}
+
+// TunnelsConfig selects the tunnel encapsulations to be removed by the
+// decoder, so that the inner packets are passed on to the protocol analyzers.
+// VXLAN and GENEVE are detected by the destination UDP port.
+type TunnelsConfig struct {
+	GRE         bool  `config:"gre"`
+	MPLS        bool  `config:"mpls"`
+	VXLANPorts  []int `config:"vxlan_ports"`
+	GenevePorts []int `config:"geneve_ports"`
+}
+
+// Enabled returns true if any tunnel encapsulation is to be decoded.
+func (t *TunnelsConfig) Enabled() bool {
+	return t.GRE || t.MPLS || len(t.VXLANPorts) > 0 || len(t.GenevePorts) > 0
+}
+
 type Flows struct {
 	Enabled       *bool                   `config:"enabled"`
 	Timeout       string                  `config:"timeout"`
@@ -99,6 +271,19 @@ type Flows struct {
 	KeepNull      bool                    `config:"keep_null"`
 	// Index is used to overwrite the index where flows are published
 	Index string `config:"index"`
+	// Export sends the flow records to NetFlow v9 or IPFIX collectors, in
+	// addition to publishing flow events.
+	Export FlowsExportConfig `config:"export"`
+}
+
+// FlowsExportConfig configures the export of flow records to NetFlow v9 or
+// IPFIX collectors over UDP.
+type FlowsExportConfig struct {
+	Enabled             bool          `config:"enabled"`
+	Protocol            string        `config:"protocol"`
+	Collectors          []string      `config:"collectors"`
+	ObservationDomainID uint32        `config:"observation_domain_id"`
+	TemplateRefresh     time.Duration `config:"template_refresh"`
 }
 
 type ProtocolCommon struct {
@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestInterfacesSingle(t *testing.T) {
+	cfg, err := common.NewConfigFrom(`
+interfaces:
+  device: eth0
+  snaplen: 1514
+`)
+	require.NoError(t, err)
+
+	initial := Config{Interfaces: InterfaceList{{Loop: 3}}}
+	config, err := initial.FromStatic(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, config.Interfaces, 1)
+	assert.Equal(t, "eth0", config.Interfaces[0].Device)
+	assert.Equal(t, 1514, config.Interfaces[0].Snaplen)
+	assert.Equal(t, 3, config.Interfaces[0].Loop)
+}
+
+func TestInterfacesList(t *testing.T) {
+	cfg, err := common.NewConfigFrom(`
+interfaces:
+  - device: bond0
+    type: af_packet
+    bpf_filter: "tcp port 80"
+    internal_networks: [private]
+  - device: docker0
+    buffer_size_mb: 100
+    internal_networks: [loopback]
+`)
+	require.NoError(t, err)
+
+	config, err := Config{}.FromStatic(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, config.Interfaces, 2)
+	assert.Equal(t, "bond0", config.Interfaces[0].Device)
+	assert.Equal(t, "af_packet", config.Interfaces[0].Type)
+	assert.Equal(t, "tcp port 80", config.Interfaces[0].BpfFilter)
+	assert.Equal(t, "docker0", config.Interfaces[1].Device)
+	assert.Equal(t, 100, config.Interfaces[1].BufferSizeMb)
+	assert.Equal(t, "", config.Interfaces.File())
+	assert.Equal(t, []string{"private", "loopback"}, config.Interfaces.InternalNetworks())
+}
+
+func TestInterfacesFanout(t *testing.T) {
+	cfg, err := common.NewConfigFrom(`
+interfaces:
+  device: eth0
+  type: af_packet
+  fanout:
+    workers: 4
+    group_id: 1234
+`)
+	require.NoError(t, err)
+
+	config, err := Config{}.FromStatic(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, config.Interfaces, 1)
+	assert.Equal(t, 4, config.Interfaces[0].Fanout.Workers)
+	if assert.NotNil(t, config.Interfaces[0].Fanout.GroupID) {
+		assert.Equal(t, uint16(1234), *config.Interfaces[0].Fanout.GroupID)
+	}
+
+	cfg, err = common.NewConfigFrom(`
+interfaces:
+  device: eth0
+  fanout.workers: 1000
+`)
+	require.NoError(t, err)
+
+	_, err = Config{}.FromStatic(cfg)
+	assert.Error(t, err)
+}
@@ -19,9 +19,13 @@ package decoder
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/flows"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 	"github.com/elastic/beats/v7/packetbeat/protos/icmp"
 	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
@@ -50,6 +54,18 @@ type Decoder struct {
 	udp       layers.UDP
 	truncated bool
 
+	// name of the interface the packets are captured on
+	ingress string
+	// comments of the packet being decoded, read from pcapng files
+	comments []string
+
+	// tunnel decapsulation
+	gre        greLayer
+	mpls       mplsLayer
+	vxlan      vxlanLayer
+	geneve     geneveLayer
+	udpTunnels map[uint16]gopacket.LayerType
+
 	stD1Q, stIP4, stIP6 multiLayer
 
 	icmp4Proc icmp.ICMPv4Processor
@@ -57,11 +73,15 @@ type Decoder struct {
 	tcpProc   tcp.Processor
 	udpProc   udp.Processor
 
+	// reassembly of fragmented IP datagrams, nil if disabled
+	fragments *fragmentTable
+
 	flows          *flows.Flows
 	statPackets    *flows.Uint
 	statBytes      *flows.Uint
 	icmpV4TypeCode *flows.Uint
 	icmpV6TypeCode *flows.Uint
+	tcpFlags       *flows.Uint
 
 	// hold current flow ID
 	flowID              *flows.FlowID // buffer flowID among many calls
@@ -73,6 +93,7 @@ const (
 	netBytesTotalCounter   = "bytes"
 	icmpV4TypeCodeValue    = "icmpV4TypeCode"
 	icmpV6TypeCodeValue    = "icmpV6TypeCode"
+	tcpFlagsValue          = "tcpFlags"
 )
 
 // New creates and initializes a new packet decoder.
@@ -83,6 +104,8 @@ func New(
 	icmp6 icmp.ICMPv6Processor,
 	tcp tcp.Processor,
 	udp udp.Processor,
+	fragments config.IPFragmentsConfig,
+	tunnels config.TunnelsConfig,
 ) (*Decoder, error) {
 	d := Decoder{
 		flows:     f,
@@ -92,6 +115,10 @@ func New(
 	d.stIP4.init(&d.ip4[0], &d.ip4[1])
 	d.stIP6.init(&d.ip6[0], &d.ip6[1])
 
+	if fragments.IsEnabled() {
+		d.fragments = newFragmentTable(fragments)
+	}
+
 	if f != nil {
 		var err error
 		d.statPackets, err = f.NewUint(netPacketsTotalCounter)
@@ -110,6 +137,10 @@ func New(
 		if err != nil {
 			return nil, err
 		}
+		d.tcpFlags, err = f.NewUint(tcpFlagsValue)
+		if err != nil {
+			return nil, err
+		}
 
 		d.flowID = &flows.FlowID{}
 	}
@@ -125,6 +156,21 @@ func New(
 	}
 	d.AddLayers(defaultLayerTypes)
 
+	if tunnels.GRE {
+		d.AddLayer(&d.gre)
+	}
+	if tunnels.MPLS {
+		d.AddLayer(&d.mpls)
+	}
+	if len(tunnels.VXLANPorts) > 0 || len(tunnels.GenevePorts) > 0 {
+		var err error
+		d.udpTunnels, err = udpTunnelPorts(tunnels)
+		if err != nil {
+			return nil, err
+		}
+		d.AddLayers([]gopacket.DecodingLayer{&d.vxlan, &d.geneve})
+	}
+
 	debugf("Layer type: %s", datalink.String())
 
 	switch datalink {
@@ -144,6 +190,16 @@ func New(
 	return &d, nil
 }
 
+// SetIngressInterface sets the name of the interface the packets are
+// captured on. The name is reported with the flows created by the decoder,
+// and passed with the packets to the protocol analyzers.
+func (d *Decoder) SetIngressInterface(name string) {
+	d.ingress = name
+	if d.flowID != nil {
+		d.flowID.SetIngressInterface(name)
+	}
+}
+
 func (d *Decoder) SetTruncated() {
 	d.truncated = true
 }
@@ -160,6 +216,14 @@ func (d *Decoder) AddLayers(layers []gopacket.DecodingLayer) {
 	}
 }
 
+// OnAnnotatedPacket decodes a packet read from a pcapng file. The comments
+// are passed with the packet to the protocol analyzers.
+func (d *Decoder) OnAnnotatedPacket(data []byte, ci *gopacket.CaptureInfo, comments []string) {
+	d.comments = comments
+	d.OnPacket(data, ci)
+	d.comments = nil
+}
+
 func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 	defer logp.Recover("packet decoding failed")
 
@@ -169,6 +233,8 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 	currentType := d.linkLayerType
 
 	packet := protos.Packet{Ts: ci.Timestamp}
+	packet.Capture.Interface = d.ingress
+	packet.Capture.Comments = d.comments
 
 	debugf("decode packet data")
 	processed := false
@@ -191,13 +257,23 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 		nextType := current.NextLayerType()
 		data = current.LayerPayload()
 
-		processed, err = d.process(&packet, currentType)
-		if err != nil {
-			logp.Info("Error processing packet: %v", err)
-			break
+		if d.fragments != nil {
+			data, nextType = d.defragment(currentType, data, nextType, ci.Timestamp)
 		}
-		if processed {
-			break
+
+		if tunnelType, ok := d.udpTunnel(currentType); ok {
+			// Outer UDP header of a VXLAN or GENEVE tunnel. The datagram is
+			// decapsulated instead of being passed to the UDP analyzers.
+			nextType = tunnelType
+		} else {
+			processed, err = d.process(&packet, currentType)
+			if err != nil {
+				logp.Info("Error processing packet: %v", err)
+				break
+			}
+			if processed {
+				break
+			}
 		}
 
 		// choose next decoding layer
@@ -220,9 +296,115 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 		flow := d.flows.Get(d.flowID)
 		d.statPackets.Add(flow, 1)
 		d.statBytes.Add(flow, uint64(ci.Length))
+		if d.flowID.Flags()&flows.TCPFlow != 0 {
+			d.tcpFlags.Or(flow, uint64(tcpControlBits(&d.tcp)))
+		}
 	}
 }
 
+// Endpoints decodes the addresses and ports of a packet, without passing it to
+// the protocol analyzers. For tunneled packets the endpoints of the innermost
+// packet are returned, with the tunnels it has been encapsulated in, outermost
+// first. It returns false if the packet has no IP layer. It must only be used
+// with decoders created without flows.
+func (d *Decoder) Endpoints(data []byte) (common.IPPortTuple, []pb.Tunnel, bool) {
+	var packet protos.Packet
+
+	current := d.linkLayerDecoder
+	currentType := d.linkLayerType
+	for len(data) > 0 {
+		if err := current.DecodeFromBytes(data, d); err != nil {
+			break
+		}
+
+		nextType := current.NextLayerType()
+		data = current.LayerPayload()
+
+		if tunnelType, ok := d.udpTunnel(currentType); ok {
+			nextType = tunnelType
+		} else {
+			switch currentType {
+			case layers.LayerTypeTCP:
+				packet.Tuple.SrcPort = uint16(d.tcp.SrcPort)
+				packet.Tuple.DstPort = uint16(d.tcp.DstPort)
+				return packet.Tuple, packet.Capture.Tunnels, true
+			case layers.LayerTypeUDP:
+				packet.Tuple.SrcPort = uint16(d.udp.SrcPort)
+				packet.Tuple.DstPort = uint16(d.udp.DstPort)
+				return packet.Tuple, packet.Capture.Tunnels, true
+			case layers.LayerTypeICMPv4, layers.LayerTypeICMPv6:
+				return packet.Tuple, packet.Capture.Tunnels, true
+			}
+			// the other layers only add the addresses and tunnels
+			d.process(&packet, currentType)
+		}
+
+		next, ok := d.decoders[nextType]
+		if !ok {
+			break
+		}
+		current = next
+		currentType = nextType
+	}
+	return packet.Tuple, packet.Capture.Tunnels, packet.Tuple.IPLength != 0
+}
+
+// tcpControlBits returns the flags of a TCP header as found in the header,
+// without the NS bit.
+func tcpControlBits(tcp *layers.TCP) uint8 {
+	var bits uint8
+	set := func(flag bool, bit uint8) {
+		if flag {
+			bits |= bit
+		}
+	}
+	set(tcp.FIN, 0x01)
+	set(tcp.SYN, 0x02)
+	set(tcp.RST, 0x04)
+	set(tcp.PSH, 0x08)
+	set(tcp.ACK, 0x10)
+	set(tcp.URG, 0x20)
+	set(tcp.ECE, 0x40)
+	set(tcp.CWR, 0x80)
+	return bits
+}
+
+// defragment passes the payload of fragmented IP packets to the reassembly
+// table. The reassembled datagram is returned once all fragments have been
+// seen. While fragments are still missing the returned payload is nil, which
+// stops decoding of the current packet.
+func (d *Decoder) defragment(
+	layerType gopacket.LayerType,
+	data []byte,
+	nextType gopacket.LayerType,
+	ts time.Time,
+) ([]byte, gopacket.LayerType) {
+	switch layerType {
+	case layers.LayerTypeIPv4:
+		ip4 := &d.ip4[d.stIP4.i]
+		if ip4.Flags&layers.IPv4MoreFragments != 0 || ip4.FragOffset != 0 {
+			return d.fragments.addIPv4(ip4, data, ts)
+		}
+
+	case layers.LayerTypeIPv6:
+		ip6 := &d.ip6[d.stIP6.i]
+		if ip6.NextHeader == layers.IPProtocolIPv6Fragment {
+			return d.fragments.addIPv6(ip6, data, ts)
+		}
+	}
+	return data, nextType
+}
+
+// udpTunnel returns the tunnel layer type if the current layer is a UDP
+// datagram sent to one of the configured tunnel ports.
+func (d *Decoder) udpTunnel(layerType gopacket.LayerType) (gopacket.LayerType, bool) {
+	if layerType != layers.LayerTypeUDP || d.udpTunnels == nil {
+		return gopacket.LayerTypeZero, false
+	}
+	typ, ok := d.udpTunnels[uint16(d.udp.DstPort)]
+	return typ, ok
+}
+
 func (d *Decoder) process(
 	packet *protos.Packet,
 	layerType gopacket.LayerType,
@@ -268,6 +450,22 @@ func (d *Decoder) process(
 		packet.Tuple.DstIP = ip6.DstIP
 		packet.Tuple.IPLength = 16
 
+	case layers.LayerTypeGRE:
+		debugf("GRE packet")
+		d.onTunnel(packet, &d.gre.tunnel)
+
+	case layers.LayerTypeMPLS:
+		debugf("MPLS packet")
+		d.onTunnel(packet, &d.mpls.tunnel)
+
+	case layerTypeVXLAN:
+		debugf("VXLAN packet")
+		d.onTunnel(packet, &d.vxlan.tunnel)
+
+	case layerTypeGeneve:
+		debugf("GENEVE packet")
+		d.onTunnel(packet, &d.geneve.tunnel)
+
 	case layers.LayerTypeICMPv4:
 		debugf("ICMPv4 packet")
 		d.onICMPv4(packet)
@@ -292,6 +490,16 @@ func (d *Decoder) process(
 	return false, nil
 }
 
+func (d *Decoder) onTunnel(packet *protos.Packet, t *tunnel) {
+	if d.flowID != nil {
+		d.flowID.AddTunnel(t.typ, t.id)
+	}
+	packet.Capture.Tunnels = append(packet.Capture.Tunnels, pb.Tunnel{
+		Type: t.typ.String(),
+		ID:   t.id,
+	})
+}
+
 func (d *Decoder) onICMPv4(packet *protos.Packet) {
 	if d.flowID != nil {
 		flow := d.flows.Get(d.flowID)
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 
@@ -197,13 +198,30 @@ func TestDecodePacketData_ipv6Udp(t *testing.T) {
 	assert.NotEqual(t, -1, strings.Index(string(p.Data()), string(udp.pkt.Payload)))
 }
 
+// Test that the TCP flags are reported as found in the TCP header.
+func TestTCPControlBits(t *testing.T) {
+	p := gopacket.NewPacket(ipv4TcpDNS, layers.LinkTypeEthernet, gopacket.Default)
+	tcp, ok := p.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	if !ok {
+		t.Fatal("Failed to decode TCP layer")
+	}
+
+	assert.Equal(t, ipv4TcpDNS[47], tcpControlBits(tcp)) // PSH, ACK
+}
+
 // Creates a new TestDecoder that handles ethernet packets.
 func newTestDecoder(t *testing.T) (*Decoder, *TestTCPProcessor, *TestUDPProcessor) {
+	return newTestTunnelDecoder(t, config.TunnelsConfig{})
+}
+
+// Creates a new TestDecoder that handles ethernet packets and decapsulates
+// the configured tunnels.
+func newTestTunnelDecoder(t *testing.T, tunnels config.TunnelsConfig) (*Decoder, *TestTCPProcessor, *TestUDPProcessor) {
 	icmp4Layer := &TestIcmp4Processor{}
 	icmp6Layer := &TestIcmp6Processor{}
 	tcpLayer := &TestTCPProcessor{}
 	udpLayer := &TestUDPProcessor{}
-	d, err := New(nil, layers.LinkTypeEthernet, icmp4Layer, icmp6Layer, tcpLayer, udpLayer)
+	d, err := New(nil, layers.LinkTypeEthernet, icmp4Layer, icmp6Layer, tcpLayer, udpLayer, config.IPFragmentsConfig{}, tunnels)
 	if err != nil {
 		t.Fatalf("Error creating decoder %v", err)
 	}
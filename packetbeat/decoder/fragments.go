@@ -0,0 +1,309 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/config"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+const (
+	defaultFragmentsMaxBytes = 4 * 1024 * 1024
+	defaultFragmentsTimeout  = 30 * time.Second
+
+	// maximum size of a reassembled IP datagram
+	maxDatagramSize = 65535
+
+	ipv6FragmentHeaderLen = 8
+)
+
+var (
+	fragmentsReassembled = monitoring.NewInt(nil, "ip_fragments.reassembled")
+	fragmentsIncomplete  = monitoring.NewInt(nil, "ip_fragments.incomplete")
+	fragmentsOverlapping = monitoring.NewInt(nil, "ip_fragments.overlapping")
+)
+
+// fragmentTable collects the fragments of IPv4 and IPv6 datagrams until all
+// parts have been seen. Incomplete datagrams are discarded once they time
+// out, or if the table grows over its memory budget, oldest first.
+type fragmentTable struct {
+	sets  map[fragmentKey]*list.Element
+	order *list.List // *fragmentSet ordered by creation time
+	bytes int
+
+	maxBytes int
+	timeout  time.Duration
+}
+
+type fragmentKey struct {
+	src, dst [16]byte
+	id       uint32
+	proto    layers.IPProtocol
+	ipv6     bool
+}
+
+type fragmentSet struct {
+	key       fragmentKey
+	created   time.Time
+	fragments []fragment // ordered by offset
+	bytes     int
+	size      int // total datagram size, -1 until the last fragment has been seen
+	next      layers.IPProtocol
+}
+
+type fragment struct {
+	offset int
+	data   []byte
+}
+
+func newFragmentTable(cfg config.IPFragmentsConfig) *fragmentTable {
+	t := &fragmentTable{
+		sets:     map[fragmentKey]*list.Element{},
+		order:    list.New(),
+		maxBytes: cfg.MaxBytes,
+		timeout:  cfg.Timeout,
+	}
+	if t.maxBytes <= 0 {
+		t.maxBytes = defaultFragmentsMaxBytes
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultFragmentsTimeout
+	}
+	return t
+}
+
+// addIPv4 adds the payload of a fragmented IPv4 packet to the table. Once
+// the datagram is complete the reassembled payload and the layer type of
+// the transport protocol are returned. Otherwise the returned payload is nil.
+func (t *fragmentTable) addIPv4(ip4 *layers.IPv4, payload []byte, ts time.Time) ([]byte, gopacket.LayerType) {
+	var key fragmentKey
+	copy(key.src[:], ip4.SrcIP.To4())
+	copy(key.dst[:], ip4.DstIP.To4())
+	key.id = uint32(ip4.Id)
+	key.proto = ip4.Protocol
+
+	offset := int(ip4.FragOffset) * 8
+	more := ip4.Flags&layers.IPv4MoreFragments != 0
+	return layerOf(t.add(key, offset, more, ip4.Protocol, payload, ts))
+}
+
+// addIPv6 parses the IPv6 fragment extension header at the start of payload
+// and adds the fragment to the table. The reassembled payload and the layer
+// type of the header following the fragment header are returned once the
+// datagram is complete.
+func (t *fragmentTable) addIPv6(ip6 *layers.IPv6, payload []byte, ts time.Time) ([]byte, gopacket.LayerType) {
+	if len(payload) < ipv6FragmentHeaderLen {
+		debugf("IPv6 fragment header truncated")
+		return nil, gopacket.LayerTypeFragment
+	}
+
+	next := layers.IPProtocol(payload[0])
+	offsetFlags := binary.BigEndian.Uint16(payload[2:4])
+	offset := int(offsetFlags>>3) * 8
+	more := offsetFlags&1 != 0
+	id := binary.BigEndian.Uint32(payload[4:8])
+	payload = payload[ipv6FragmentHeaderLen:]
+
+	// atomic fragment (RFC 6946), nothing to reassemble
+	if offset == 0 && !more {
+		return payload, next.LayerType()
+	}
+
+	var key fragmentKey
+	copy(key.src[:], ip6.SrcIP.To16())
+	copy(key.dst[:], ip6.DstIP.To16())
+	key.id = id
+	key.ipv6 = true
+
+	return layerOf(t.add(key, offset, more, next, payload, ts))
+}
+
+func layerOf(data []byte, next layers.IPProtocol) ([]byte, gopacket.LayerType) {
+	if data == nil {
+		return nil, gopacket.LayerTypeFragment
+	}
+	return data, next.LayerType()
+}
+
+func (t *fragmentTable) add(
+	key fragmentKey,
+	offset int,
+	more bool,
+	next layers.IPProtocol,
+	payload []byte,
+	ts time.Time,
+) ([]byte, layers.IPProtocol) {
+	t.expire(ts)
+
+	var set *fragmentSet
+	if elem, exists := t.sets[key]; exists {
+		set = elem.Value.(*fragmentSet)
+	} else {
+		set = &fragmentSet{key: key, created: ts, size: -1}
+		t.sets[key] = t.order.PushBack(set)
+	}
+
+	end := offset + len(payload)
+	if end > maxDatagramSize {
+		debugf("IP fragment exceeds maximum datagram size. offset=%d len=%d", offset, len(payload))
+		t.remove(set)
+		fragmentsIncomplete.Inc()
+		return nil, next
+	}
+
+	if !more {
+		if set.size >= 0 && set.size != end {
+			t.remove(set)
+			fragmentsOverlapping.Inc()
+			return nil, next
+		}
+		if n := len(set.fragments); n > 0 {
+			last := set.fragments[n-1]
+			if last.offset+len(last.data) > end {
+				t.remove(set)
+				fragmentsOverlapping.Inc()
+				return nil, next
+			}
+		}
+		set.size = end
+	}
+	if set.size >= 0 && end > set.size {
+		t.remove(set)
+		fragmentsOverlapping.Inc()
+		return nil, next
+	}
+
+	i, ok := set.insertPos(offset, payload)
+	if !ok {
+		debugf("Overlapping IP fragments. offset=%d len=%d", offset, len(payload))
+		t.remove(set)
+		fragmentsOverlapping.Inc()
+		return nil, next
+	}
+	if i < 0 {
+		// duplicate fragment
+		return set.reassemble(t)
+	}
+
+	t.reserve(set, len(payload))
+	if _, exists := t.sets[key]; !exists {
+		// the set itself had to be evicted to stay within the memory budget
+		return nil, next
+	}
+
+	f := fragment{offset: offset, data: append([]byte(nil), payload...)}
+	set.fragments = append(set.fragments, fragment{})
+	copy(set.fragments[i+1:], set.fragments[i:])
+	set.fragments[i] = f
+	set.bytes += len(payload)
+	t.bytes += len(payload)
+	if offset == 0 {
+		set.next = next
+	}
+
+	return set.reassemble(t)
+}
+
+// insertPos returns the index the fragment must be inserted at. If the
+// fragment is an exact duplicate of a fragment already in the set, -1 is
+// returned. ok is false if the fragment overlaps with other data.
+func (s *fragmentSet) insertPos(offset int, payload []byte) (i int, ok bool) {
+	end := offset + len(payload)
+	i = len(s.fragments)
+	for i > 0 && s.fragments[i-1].offset > offset {
+		i--
+	}
+
+	if i > 0 {
+		prev := &s.fragments[i-1]
+		if prev.offset == offset && bytes.Equal(prev.data, payload) {
+			return -1, true
+		}
+		if prev.offset+len(prev.data) > offset {
+			return 0, false
+		}
+	}
+	if i < len(s.fragments) && s.fragments[i].offset < end {
+		return 0, false
+	}
+	return i, true
+}
+
+// reassemble returns the datagram payload if all fragments have been
+// received and removes the set from the table.
+func (s *fragmentSet) reassemble(t *fragmentTable) ([]byte, layers.IPProtocol) {
+	if s.size < 0 || s.bytes != s.size {
+		return nil, s.next
+	}
+
+	// fragments are ordered and do not overlap, so no holes are left if
+	// the number of bytes collected matches the datagram size.
+	buf := make([]byte, 0, s.size)
+	for _, f := range s.fragments {
+		buf = append(buf, f.data...)
+	}
+	t.remove(s)
+	fragmentsReassembled.Inc()
+	return buf, s.next
+}
+
+// reserve evicts the oldest incomplete datagrams until n more bytes fit
+// into the memory budget.
+func (t *fragmentTable) reserve(set *fragmentSet, n int) {
+	for t.bytes+n > t.maxBytes && t.order.Len() > 0 {
+		oldest := t.order.Front().Value.(*fragmentSet)
+		debugf("IP fragment buffer full, dropping incomplete datagram")
+		t.remove(oldest)
+		fragmentsIncomplete.Inc()
+		if oldest == set {
+			return
+		}
+	}
+}
+
+// expire drops all datagrams that have not been completed within the
+// configured timeout.
+func (t *fragmentTable) expire(now time.Time) {
+	for e := t.order.Front(); e != nil; e = t.order.Front() {
+		set := e.Value.(*fragmentSet)
+		if now.Sub(set.created) <= t.timeout {
+			return
+		}
+		debugf("IP fragments timed out")
+		t.remove(set)
+		fragmentsIncomplete.Inc()
+	}
+}
+
+func (t *fragmentTable) remove(set *fragmentSet) {
+	elem, exists := t.sets[set.key]
+	if !exists {
+		return
+	}
+	t.order.Remove(elem)
+	delete(t.sets, set.key)
+	t.bytes -= set.bytes
+}
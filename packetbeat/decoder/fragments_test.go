@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package decoder
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+var (
+	fragSrcIP = net.IP{192, 168, 0, 1}
+	fragDstIP = net.IP{192, 168, 0, 2}
+)
+
+// udpDatagram builds a UDP header followed by n bytes of payload.
+func udpDatagram(src, dst uint16, n int) []byte {
+	b := make([]byte, 8+n)
+	binary.BigEndian.PutUint16(b[0:], src)
+	binary.BigEndian.PutUint16(b[2:], dst)
+	binary.BigEndian.PutUint16(b[4:], uint16(len(b)))
+	for i := 8; i < len(b); i++ {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+// ipv4Fragment builds an ethernet frame holding one IPv4 fragment.
+func ipv4Fragment(id uint16, offset int, more bool, payload []byte) []byte {
+	b := []byte{
+		0x00, 0x0c, 0x29, 0xce, 0xd1, 0x9e, 0x00, 0x0c, 0x29, 0x7e, 0xec, 0xa4, 0x08, 0x00,
+		0x45, 0x00, 0, 0, 0, 0, 0, 0, 0x40, 0x11, 0, 0,
+	}
+	binary.BigEndian.PutUint16(b[16:], uint16(20+len(payload)))
+	binary.BigEndian.PutUint16(b[18:], id)
+	flags := uint16(offset / 8)
+	if more {
+		flags |= 0x2000
+	}
+	binary.BigEndian.PutUint16(b[20:], flags)
+	b = append(b, fragSrcIP...)
+	b = append(b, fragDstIP...)
+	return append(b, payload...)
+}
+
+// ipv6Fragment builds an ethernet frame holding one IPv6 fragment.
+func ipv6Fragment(id uint32, offset int, more bool, payload []byte) []byte {
+	b := []byte{
+		0x00, 0x60, 0x97, 0x07, 0x69, 0xea, 0x00, 0x00, 0x86, 0x05, 0x80, 0xda, 0x86, 0xdd,
+		0x60, 0x00, 0x00, 0x00, 0, 0, 44, 0x40,
+	}
+	binary.BigEndian.PutUint16(b[18:], uint16(8+len(payload)))
+	b = append(b, net.ParseIP("2001:db8::1")...)
+	b = append(b, net.ParseIP("2001:db8::2")...)
+
+	hdr := make([]byte, 8)
+	hdr[0] = 17 // UDP
+	offsetFlags := uint16(offset/8) << 3
+	if more {
+		offsetFlags |= 1
+	}
+	binary.BigEndian.PutUint16(hdr[2:], offsetFlags)
+	binary.BigEndian.PutUint32(hdr[4:], id)
+	b = append(b, hdr...)
+	return append(b, payload...)
+}
+
+func onPacket(d *Decoder, data []byte, ts time.Time) {
+	d.OnPacket(data, &gopacket.CaptureInfo{Timestamp: ts, CaptureLength: len(data), Length: len(data)})
+}
+
+func TestDecodePacketData_ipv4Fragments(t *testing.T) {
+	d, _, udp := newTestDecoder(t)
+	datagram := udpDatagram(5353, 53, 2000)
+	now := time.Now()
+
+	// fragments arrive out of order
+	onPacket(d, ipv4Fragment(7, 1480, false, datagram[1480:]), now)
+	assert.Nil(t, udp.pkt)
+	onPacket(d, ipv4Fragment(7, 0, true, datagram[:1480]), now)
+
+	if assert.NotNil(t, udp.pkt, "UDP packet not received") {
+		assert.Equal(t, "192.168.0.1", udp.pkt.Tuple.SrcIP.String())
+		assert.Equal(t, uint16(5353), udp.pkt.Tuple.SrcPort)
+		assert.Equal(t, uint16(53), udp.pkt.Tuple.DstPort)
+		assert.Equal(t, datagram[8:], udp.pkt.Payload)
+	}
+}
+
+func TestDecodePacketData_ipv6Fragments(t *testing.T) {
+	d, _, udp := newTestDecoder(t)
+	datagram := udpDatagram(5353, 53, 3000)
+	now := time.Now()
+
+	onPacket(d, ipv6Fragment(42, 0, true, datagram[:1232]), now)
+	onPacket(d, ipv6Fragment(42, 1232, true, datagram[1232:2464]), now)
+	assert.Nil(t, udp.pkt)
+	onPacket(d, ipv6Fragment(42, 2464, false, datagram[2464:]), now)
+
+	if assert.NotNil(t, udp.pkt, "UDP packet not received") {
+		assert.Equal(t, "2001:db8::1", udp.pkt.Tuple.SrcIP.String())
+		assert.Equal(t, uint16(53), udp.pkt.Tuple.DstPort)
+		assert.Equal(t, datagram[8:], udp.pkt.Payload)
+	}
+}
+
+func TestFragmentTable(t *testing.T) {
+	ip4 := &layers.IPv4{SrcIP: fragSrcIP, DstIP: fragDstIP, Protocol: layers.IPProtocolUDP}
+	datagram := udpDatagram(1000, 2000, 40)
+	now := time.Now()
+
+	add := func(table *fragmentTable, id uint16, offset int, more bool, ts time.Time) []byte {
+		ip4.Id = id
+		ip4.FragOffset = uint16(offset / 8)
+		ip4.Flags = 0
+		if more {
+			ip4.Flags = layers.IPv4MoreFragments
+		}
+		end := len(datagram)
+		if more {
+			end = offset + 16
+		}
+		data, _ := table.addIPv4(ip4, datagram[offset:end], ts)
+		return data
+	}
+
+	t.Run("duplicate fragments", func(t *testing.T) {
+		table := newFragmentTable(config.IPFragmentsConfig{})
+		assert.Nil(t, add(table, 1, 0, true, now))
+		assert.Nil(t, add(table, 1, 0, true, now))
+		assert.Nil(t, add(table, 1, 16, true, now))
+		assert.Equal(t, datagram, add(table, 1, 32, false, now))
+		assert.Equal(t, 0, table.bytes)
+		assert.Equal(t, 0, table.order.Len())
+	})
+
+	t.Run("overlapping fragments", func(t *testing.T) {
+		table := newFragmentTable(config.IPFragmentsConfig{})
+		overlapping := fragmentsOverlapping.Get()
+		assert.Nil(t, add(table, 1, 0, true, now))
+		assert.Nil(t, add(table, 1, 8, true, now))
+		assert.Equal(t, overlapping+1, fragmentsOverlapping.Get())
+		assert.Equal(t, 0, table.order.Len())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		table := newFragmentTable(config.IPFragmentsConfig{Timeout: time.Second})
+		incomplete := fragmentsIncomplete.Get()
+		assert.Nil(t, add(table, 1, 0, true, now))
+		assert.Nil(t, add(table, 1, 16, true, now))
+		assert.Nil(t, add(table, 1, 32, false, now.Add(2*time.Second)))
+		assert.Equal(t, incomplete+1, fragmentsIncomplete.Get())
+		assert.Equal(t, 1, table.order.Len())
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		table := newFragmentTable(config.IPFragmentsConfig{MaxBytes: 40})
+		assert.Nil(t, add(table, 1, 0, true, now))
+		assert.Nil(t, add(table, 2, 0, true, now))
+		assert.Nil(t, add(table, 3, 0, true, now))
+		assert.Equal(t, 2, table.order.Len())
+		assert.Equal(t, 32, table.bytes)
+
+		// datagram 1 has been evicted
+		assert.Nil(t, add(table, 1, 16, true, now))
+		assert.Equal(t, 2, table.order.Len())
+	})
+}
@@ -0,0 +1,315 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/flows"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// gopacket has no VXLAN and GENEVE layers. The layer types are only used to
+// select the decoding layer for the payload of tunnel UDP datagrams.
+var (
+	layerTypeVXLAN = gopacket.RegisterLayerType(2001, gopacket.LayerTypeMetadata{
+		Name:    "VXLAN",
+		Decoder: gopacket.DecodeFunc(decodeTunnelUnsupported),
+	})
+	layerTypeGeneve = gopacket.RegisterLayerType(2002, gopacket.LayerTypeMetadata{
+		Name:    "Geneve",
+		Decoder: gopacket.DecodeFunc(decodeTunnelUnsupported),
+	})
+)
+
+const (
+	greFlagChecksum = 0x80
+	greFlagRouting  = 0x40
+	greFlagKey      = 0x20
+	greFlagSequence = 0x10
+
+	etherTypeTransparentBridging = 0x6558
+	etherTypeERSPANTypeII        = 0x88be
+	etherTypeERSPANTypeIII       = 0x22eb
+
+	erspanTypeIILen  = 8
+	erspanTypeIIILen = 12
+	erspanSubHdrLen  = 8
+
+	vxlanHeaderLen  = 8
+	vxlanFlagVNI    = 0x08
+	geneveHeaderLen = 8
+	mplsLabelLen    = 4
+	mplsBottomFlag  = 0x100
+	pwControlLen    = 4
+)
+
+var errTunnelTruncated = errors.New("tunnel header truncated")
+
+func decodeTunnelUnsupported(data []byte, p gopacket.PacketBuilder) error {
+	return errors.New("tunnel layer is only supported by the packetbeat decoder")
+}
+
+// tunnel holds the state shared by all tunnel decoding layers: the tunnel
+// ID to be added to the flow ID, and the encapsulated packet.
+type tunnel struct {
+	typ     flows.TunnelType
+	id      uint32
+	next    gopacket.LayerType
+	payload []byte
+}
+
+func (t *tunnel) NextLayerType() gopacket.LayerType {
+	return t.next
+}
+
+func (t *tunnel) LayerPayload() []byte {
+	return t.payload
+}
+
+// greLayer decodes GRE headers (RFC 2784, RFC 2890), including the ERSPAN
+// type I, II and III headers used for remote port mirroring.
+type greLayer struct {
+	tunnel
+}
+
+func (g *greLayer) CanDecode() gopacket.LayerClass {
+	return layers.LayerTypeGRE
+}
+
+func (g *greLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		return errTunnelTruncated
+	}
+
+	flags := data[0]
+	if version := data[1] & 0x07; version != 0 {
+		return fmt.Errorf("unsupported GRE version %d", version)
+	}
+	if flags&greFlagRouting != 0 {
+		return errors.New("GRE source routing is not supported")
+	}
+	proto := binary.BigEndian.Uint16(data[2:4])
+
+	hdrLen := 4
+	if flags&greFlagChecksum != 0 {
+		hdrLen += 4
+	}
+	g.typ, g.id = flows.TunnelGRE, 0
+	if flags&greFlagKey != 0 {
+		if len(data) < hdrLen+4 {
+			return errTunnelTruncated
+		}
+		g.id = binary.BigEndian.Uint32(data[hdrLen:])
+		hdrLen += 4
+	}
+	if flags&greFlagSequence != 0 {
+		hdrLen += 4
+	}
+	if len(data) < hdrLen {
+		return errTunnelTruncated
+	}
+	payload := data[hdrLen:]
+
+	switch proto {
+	case etherTypeERSPANTypeII:
+		g.typ, g.id = flows.TunnelERSPAN, 0
+		// ERSPAN type I has no header of its own and no GRE sequence number.
+		if flags&greFlagSequence != 0 {
+			if len(payload) < erspanTypeIILen {
+				return errTunnelTruncated
+			}
+			g.id = erspanSessionID(payload)
+			payload = payload[erspanTypeIILen:]
+		}
+		g.next = layers.LayerTypeEthernet
+
+	case etherTypeERSPANTypeIII:
+		if len(payload) < erspanTypeIIILen {
+			return errTunnelTruncated
+		}
+		g.typ, g.id = flows.TunnelERSPAN, erspanSessionID(payload)
+		hdrLen := erspanTypeIIILen
+		if payload[11]&0x01 != 0 {
+			// optional platform specific sub-header
+			hdrLen += erspanSubHdrLen
+		}
+		if len(payload) < hdrLen {
+			return errTunnelTruncated
+		}
+		payload = payload[hdrLen:]
+		g.next = layers.LayerTypeEthernet
+
+	default:
+		g.next = etherTypeLayer(proto)
+	}
+
+	g.payload = payload
+	return nil
+}
+
+func erspanSessionID(hdr []byte) uint32 {
+	return uint32(binary.BigEndian.Uint16(hdr[2:4]) & 0x3ff)
+}
+
+// vxlanLayer decodes VXLAN headers (RFC 7348).
+type vxlanLayer struct {
+	tunnel
+}
+
+func (v *vxlanLayer) CanDecode() gopacket.LayerClass {
+	return layerTypeVXLAN
+}
+
+func (v *vxlanLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < vxlanHeaderLen {
+		return errTunnelTruncated
+	}
+	if data[0]&vxlanFlagVNI == 0 {
+		return errors.New("VXLAN header without valid VNI")
+	}
+
+	v.typ = flows.TunnelVXLAN
+	v.id = uint32(data[4])<<16 | uint32(data[5])<<8 | uint32(data[6])
+	v.next = layers.LayerTypeEthernet
+	v.payload = data[vxlanHeaderLen:]
+	return nil
+}
+
+// geneveLayer decodes GENEVE headers (RFC 8926). Options are skipped.
+type geneveLayer struct {
+	tunnel
+}
+
+func (g *geneveLayer) CanDecode() gopacket.LayerClass {
+	return layerTypeGeneve
+}
+
+func (g *geneveLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < geneveHeaderLen {
+		return errTunnelTruncated
+	}
+	if version := data[0] >> 6; version != 0 {
+		return fmt.Errorf("unsupported GENEVE version %d", version)
+	}
+
+	hdrLen := geneveHeaderLen + int(data[0]&0x3f)*4
+	if len(data) < hdrLen {
+		return errTunnelTruncated
+	}
+
+	g.typ = flows.TunnelGeneve
+	g.id = uint32(data[4])<<16 | uint32(data[5])<<8 | uint32(data[6])
+	g.next = etherTypeLayer(binary.BigEndian.Uint16(data[2:4]))
+	g.payload = data[hdrLen:]
+	return nil
+}
+
+// mplsLayer decodes a single MPLS label stack entry (RFC 3032). The layer
+// is applied once per label. After the bottom of the stack the payload type
+// is guessed from the first nibble, as MPLS does not indicate it.
+type mplsLayer struct {
+	tunnel
+}
+
+func (m *mplsLayer) CanDecode() gopacket.LayerClass {
+	return layers.LayerTypeMPLS
+}
+
+func (m *mplsLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < mplsLabelLen {
+		return errTunnelTruncated
+	}
+
+	entry := binary.BigEndian.Uint32(data)
+	m.typ = flows.TunnelMPLS
+	m.id = entry >> 12
+	m.payload = data[mplsLabelLen:]
+
+	switch {
+	case entry&mplsBottomFlag == 0:
+		m.next = layers.LayerTypeMPLS
+	case len(m.payload) == 0:
+		m.next = gopacket.LayerTypePayload
+	default:
+		switch m.payload[0] >> 4 {
+		case 4:
+			m.next = layers.LayerTypeIPv4
+		case 6:
+			m.next = layers.LayerTypeIPv6
+		case 0:
+			// pseudowire control word (RFC 4385) followed by an Ethernet frame
+			if len(m.payload) < pwControlLen {
+				return errTunnelTruncated
+			}
+			m.payload = m.payload[pwControlLen:]
+			m.next = layers.LayerTypeEthernet
+		default:
+			m.next = gopacket.LayerTypePayload
+		}
+	}
+	return nil
+}
+
+// etherTypeLayer returns the layer type of the protocols that can be
+// encapsulated in a GRE or GENEVE tunnel.
+func etherTypeLayer(proto uint16) gopacket.LayerType {
+	switch layers.EthernetType(proto) {
+	case layers.EthernetTypeIPv4:
+		return layers.LayerTypeIPv4
+	case layers.EthernetTypeIPv6:
+		return layers.LayerTypeIPv6
+	case layers.EthernetTypeMPLSUnicast:
+		return layers.LayerTypeMPLS
+	}
+	if proto == etherTypeTransparentBridging {
+		return layers.LayerTypeEthernet
+	}
+	return gopacket.LayerTypePayload
+}
+
+// udpTunnelPorts maps the configured VXLAN and GENEVE ports to the layer
+// type used to decode the UDP payload.
+func udpTunnelPorts(cfg config.TunnelsConfig) (map[uint16]gopacket.LayerType, error) {
+	ports := map[uint16]gopacket.LayerType{}
+	add := func(name string, list []int, typ gopacket.LayerType) error {
+		for _, port := range list {
+			if port <= 0 || port > 0xffff {
+				return fmt.Errorf("invalid %s port %d", name, port)
+			}
+			if _, exists := ports[uint16(port)]; exists {
+				return fmt.Errorf("duplicate tunnel port %d", port)
+			}
+			ports[uint16(port)] = typ
+		}
+		return nil
+	}
+
+	if err := add("VXLAN", cfg.VXLANPorts, layerTypeVXLAN); err != nil {
+		return nil, err
+	}
+	if err := add("GENEVE", cfg.GenevePorts, layerTypeGeneve); err != nil {
+		return nil, err
+	}
+	return ports, nil
+}
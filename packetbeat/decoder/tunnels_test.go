@@ -0,0 +1,307 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package decoder
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/flows"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+var (
+	outerSrcIP = net.IP{10, 0, 0, 1}
+	outerDstIP = net.IP{10, 0, 0, 2}
+	innerSrcIP = net.IP{172, 16, 0, 1}
+	innerDstIP = net.IP{172, 16, 0, 2}
+)
+
+func ethernetFrame(etherType uint16, payload []byte) []byte {
+	b := []byte{
+		0x00, 0x0c, 0x29, 0xce, 0xd1, 0x9e, 0x00, 0x0c, 0x29, 0x7e, 0xec, 0xa4, 0, 0,
+	}
+	binary.BigEndian.PutUint16(b[12:], etherType)
+	return append(b, payload...)
+}
+
+func ipv4Packet(src, dst net.IP, proto byte, payload []byte) []byte {
+	b := []byte{0x45, 0x00, 0, 0, 0, 0, 0, 0, 0x40, proto, 0, 0}
+	binary.BigEndian.PutUint16(b[2:], uint16(20+len(payload)))
+	b = append(b, src...)
+	b = append(b, dst...)
+	return append(b, payload...)
+}
+
+// innerFrame returns an ethernet frame with a DNS query sent from innerSrcIP
+// to innerDstIP.
+func innerFrame() []byte {
+	return ethernetFrame(0x0800, innerIPv4())
+}
+
+func innerIPv4() []byte {
+	return ipv4Packet(innerSrcIP, innerDstIP, 17, udpDatagram(5353, 53, 32))
+}
+
+func outerUDP(dstPort uint16, payload []byte) []byte {
+	udp := udpDatagram(49152, dstPort, 0)
+	binary.BigEndian.PutUint16(udp[4:], uint16(8+len(payload)))
+	udp = append(udp, payload...)
+	return ethernetFrame(0x0800, ipv4Packet(outerSrcIP, outerDstIP, 17, udp))
+}
+
+func outerGRE(gre []byte) []byte {
+	return ethernetFrame(0x0800, ipv4Packet(outerSrcIP, outerDstIP, 47, gre))
+}
+
+func vxlanPacket(vni uint32) []byte {
+	hdr := make([]byte, 8)
+	hdr[0] = 0x08
+	binary.BigEndian.PutUint32(hdr[4:], vni<<8)
+	return outerUDP(4789, append(hdr, innerFrame()...))
+}
+
+func genevePacket(vni uint32, optionWords int) []byte {
+	hdr := make([]byte, 8+4*optionWords)
+	hdr[0] = byte(optionWords)
+	binary.BigEndian.PutUint16(hdr[2:], 0x6558)
+	binary.BigEndian.PutUint32(hdr[4:], vni<<8)
+	return outerUDP(6081, append(hdr, innerFrame()...))
+}
+
+func mplsPacket(labels ...uint32) []byte {
+	var stack []byte
+	for i, label := range labels {
+		entry := label<<12 | 64
+		if i == len(labels)-1 {
+			entry |= mplsBottomFlag
+		}
+		var tmp [4]byte
+		binary.BigEndian.PutUint32(tmp[:], entry)
+		stack = append(stack, tmp[:]...)
+	}
+	return ethernetFrame(0x8847, append(stack, innerIPv4()...))
+}
+
+func assertInnerDNS(t *testing.T, udp *TestUDPProcessor) {
+	if assert.NotNil(t, udp.pkt, "UDP packet not received") {
+		assert.Equal(t, innerSrcIP.String(), udp.pkt.Tuple.SrcIP.String())
+		assert.Equal(t, innerDstIP.String(), udp.pkt.Tuple.DstIP.String())
+		assert.Equal(t, uint16(5353), udp.pkt.Tuple.SrcPort)
+		assert.Equal(t, uint16(53), udp.pkt.Tuple.DstPort)
+	}
+}
+
+func TestDecodePacketData_vxlan(t *testing.T) {
+	d, _, udp := newTestTunnelDecoder(t, config.TunnelsConfig{VXLANPorts: []int{4789}})
+	onPacket(d, vxlanPacket(100), time.Now())
+
+	assertInnerDNS(t, udp)
+	assert.Equal(t, flows.TunnelVXLAN, d.vxlan.typ)
+	assert.Equal(t, uint32(100), d.vxlan.id)
+	assert.Equal(t, []pb.Tunnel{{Type: "vxlan", ID: 100}}, udp.pkt.Capture.Tunnels)
+}
+
+func TestDecodePacketData_vxlanDisabled(t *testing.T) {
+	d, _, udp := newTestDecoder(t)
+	onPacket(d, vxlanPacket(100), time.Now())
+
+	if assert.NotNil(t, udp.pkt, "UDP packet not received") {
+		assert.Equal(t, outerSrcIP.String(), udp.pkt.Tuple.SrcIP.String())
+		assert.Equal(t, uint16(4789), udp.pkt.Tuple.DstPort)
+		assert.Empty(t, udp.pkt.Capture.Tunnels)
+	}
+}
+
+func TestDecodePacketData_geneve(t *testing.T) {
+	d, _, udp := newTestTunnelDecoder(t, config.TunnelsConfig{GenevePorts: []int{6081}})
+	onPacket(d, genevePacket(0xabcdef, 2), time.Now())
+
+	assertInnerDNS(t, udp)
+	assert.Equal(t, flows.TunnelGeneve, d.geneve.typ)
+	assert.Equal(t, uint32(0xabcdef), d.geneve.id)
+}
+
+func TestDecodePacketData_gre(t *testing.T) {
+	tests := []struct {
+		name string
+		gre  []byte
+		typ  flows.TunnelType
+		id   uint32
+	}{
+		{
+			name: "plain",
+			gre:  append([]byte{0x00, 0x00, 0x08, 0x00}, innerIPv4()...),
+			typ:  flows.TunnelGRE,
+		},
+		{
+			name: "checksum and key",
+			gre: append([]byte{
+				0xa0, 0x00, 0x08, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x04, 0xd2,
+			}, innerIPv4()...),
+			typ: flows.TunnelGRE,
+			id:  1234,
+		},
+		{
+			name: "transparent ethernet bridging",
+			gre:  append([]byte{0x20, 0x00, 0x65, 0x58, 0x00, 0x00, 0x00, 0x07}, innerFrame()...),
+			typ:  flows.TunnelGRE,
+			id:   7,
+		},
+		{
+			name: "erspan type I",
+			gre:  append([]byte{0x00, 0x00, 0x88, 0xbe}, innerFrame()...),
+			typ:  flows.TunnelERSPAN,
+		},
+		{
+			name: "erspan type II",
+			gre: append([]byte{
+				0x10, 0x00, 0x88, 0xbe,
+				0x00, 0x00, 0x00, 0x01,
+				0x10, 0x00, 0x00, 0x2a,
+				0x00, 0x00, 0x00, 0x00,
+			}, innerFrame()...),
+			typ: flows.TunnelERSPAN,
+			id:  42,
+		},
+		{
+			name: "erspan type III with sub-header",
+			gre: append([]byte{
+				0x10, 0x00, 0x22, 0xeb,
+				0x00, 0x00, 0x00, 0x01,
+				0x20, 0x00, 0x03, 0xff,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			}, innerFrame()...),
+			typ: flows.TunnelERSPAN,
+			id:  1023,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, _, udp := newTestTunnelDecoder(t, config.TunnelsConfig{GRE: true})
+			onPacket(d, outerGRE(test.gre), time.Now())
+
+			assertInnerDNS(t, udp)
+			assert.Equal(t, test.typ, d.gre.typ)
+			assert.Equal(t, test.id, d.gre.id)
+		})
+	}
+}
+
+func TestDecodePacketData_greDisabled(t *testing.T) {
+	d, _, udp := newTestDecoder(t)
+	onPacket(d, outerGRE(append([]byte{0x00, 0x00, 0x08, 0x00}, innerIPv4()...)), time.Now())
+	assert.Nil(t, udp.pkt)
+}
+
+func TestDecodePacketData_mpls(t *testing.T) {
+	d, _, udp := newTestTunnelDecoder(t, config.TunnelsConfig{MPLS: true})
+	onPacket(d, mplsPacket(100, 200), time.Now())
+
+	assertInnerDNS(t, udp)
+	assert.Equal(t, flows.TunnelMPLS, d.mpls.typ)
+	assert.Equal(t, uint32(200), d.mpls.id)
+	assert.Equal(t, []pb.Tunnel{{Type: "mpls", ID: 100}, {Type: "mpls", ID: 200}}, udp.pkt.Capture.Tunnels)
+}
+
+func TestTunnelTruncated(t *testing.T) {
+	var vxlan vxlanLayer
+	assert.Equal(t, errTunnelTruncated, vxlan.DecodeFromBytes([]byte{0x08, 0, 0}, nil))
+
+	var geneve geneveLayer
+	assert.Equal(t, errTunnelTruncated, geneve.DecodeFromBytes([]byte{0x02, 0, 0x65, 0x58, 0, 0, 1, 0}, nil))
+
+	var gre greLayer
+	assert.Equal(t, errTunnelTruncated, gre.DecodeFromBytes([]byte{0x20, 0, 0x08, 0x00, 0, 0}, nil))
+
+	var mpls mplsLayer
+	assert.Equal(t, errTunnelTruncated, mpls.DecodeFromBytes([]byte{0, 0, 1}, nil))
+}
+
+func TestUDPTunnelPorts(t *testing.T) {
+	ports, err := udpTunnelPorts(config.TunnelsConfig{VXLANPorts: []int{4789, 8472}, GenevePorts: []int{6081}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, layerTypeVXLAN, ports[4789])
+		assert.Equal(t, layerTypeVXLAN, ports[8472])
+		assert.Equal(t, layerTypeGeneve, ports[6081])
+	}
+
+	_, err = udpTunnelPorts(config.TunnelsConfig{VXLANPorts: []int{4789}, GenevePorts: []int{4789}})
+	assert.Error(t, err)
+
+	_, err = udpTunnelPorts(config.TunnelsConfig{VXLANPorts: []int{70000}})
+	assert.Error(t, err)
+}
+
+// tcpSegment returns a TCP header without payload.
+func tcpSegment(src, dst uint16) []byte {
+	b := make([]byte, 20)
+	binary.BigEndian.PutUint16(b[0:], src)
+	binary.BigEndian.PutUint16(b[2:], dst)
+	b[12] = 5 << 4
+	return b
+}
+
+func TestEndpoints(t *testing.T) {
+	d, err := New(nil, layers.LinkTypeEthernet, nil, nil, nil, nil, config.IPFragmentsConfig{}, config.TunnelsConfig{VXLANPorts: []int{4789}, MPLS: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tuple, tunnels, ok := d.Endpoints(vxlanPacket(100))
+	if assert.True(t, ok) {
+		assert.Equal(t, innerSrcIP.String(), tuple.SrcIP.String())
+		assert.Equal(t, innerDstIP.String(), tuple.DstIP.String())
+		assert.Equal(t, uint16(5353), tuple.SrcPort)
+		assert.Equal(t, uint16(53), tuple.DstPort)
+		assert.Equal(t, []pb.Tunnel{{Type: "vxlan", ID: 100}}, tunnels)
+	}
+
+	tuple, tunnels, ok = d.Endpoints(mplsPacket(100, 200))
+	if assert.True(t, ok) {
+		assert.Equal(t, innerSrcIP.String(), tuple.SrcIP.String())
+		assert.Equal(t, uint16(53), tuple.DstPort)
+		assert.Equal(t, []pb.Tunnel{{Type: "mpls", ID: 100}, {Type: "mpls", ID: 200}}, tunnels)
+	}
+
+	// TCP segments without payload are not skipped
+	tuple, tunnels, ok = d.Endpoints(ethernetFrame(0x0800, ipv4Packet(outerSrcIP, outerDstIP, 6, tcpSegment(40000, 80))))
+	if assert.True(t, ok) {
+		assert.Equal(t, outerSrcIP.String(), tuple.SrcIP.String())
+		assert.Equal(t, uint16(40000), tuple.SrcPort)
+		assert.Equal(t, uint16(80), tuple.DstPort)
+		assert.Empty(t, tunnels)
+	}
+
+	_, _, ok = d.Endpoints(ethernetFrame(0x0806, make([]byte, 28)))
+	assert.False(t, ok)
+}
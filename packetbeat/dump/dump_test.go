@@ -0,0 +1,349 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package dump
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/ecs/code/go/ecs"
+)
+
+// ipv4Frame returns an ethernet frame holding an IPv4 packet.
+func ipv4Frame(src, dst net.IP, proto byte, payload []byte) []byte {
+	b := []byte{
+		0x00, 0x0c, 0x29, 0xce, 0xd1, 0x9e, 0x00, 0x0c, 0x29, 0x7e, 0xec, 0xa4, 0x08, 0x00,
+		0x45, 0x00, 0, 0, 0, 0, 0, 0, 0x40, proto, 0, 0,
+	}
+	binary.BigEndian.PutUint16(b[16:], uint16(20+len(payload)))
+	b = append(b, src.To4()...)
+	b = append(b, dst.To4()...)
+	return append(b, payload...)
+}
+
+// tcpPacket returns an ethernet frame holding a TCP segment without payload.
+func tcpPacket(src, dst net.IP, srcPort, dstPort uint16) []byte {
+	tcp := make([]byte, 20)
+	binary.BigEndian.PutUint16(tcp[0:], srcPort)
+	binary.BigEndian.PutUint16(tcp[2:], dstPort)
+	tcp[12] = 5 << 4
+	return ipv4Frame(src, dst, 6, tcp)
+}
+
+// vxlanPacket returns an ethernet frame holding the inner frame in a VXLAN
+// tunnel.
+func vxlanPacket(vni uint32, inner []byte) []byte {
+	hdr := make([]byte, 16)
+	binary.BigEndian.PutUint16(hdr[0:], 49152)
+	binary.BigEndian.PutUint16(hdr[2:], 4789)
+	binary.BigEndian.PutUint16(hdr[4:], uint16(len(hdr)+len(inner)))
+	hdr[8] = 0x08
+	binary.BigEndian.PutUint32(hdr[12:], vni<<8)
+	return ipv4Frame(net.IP{192, 168, 0, 1}, net.IP{192, 168, 0, 2}, 17, append(hdr, inner...))
+}
+
+func captureInfo(ts time.Time, data []byte) *gopacket.CaptureInfo {
+	return &gopacket.CaptureInfo{Timestamp: ts, CaptureLength: len(data), Length: len(data)}
+}
+
+func TestPcapWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, n, err := newPacketWriter(&buf, FormatPcap, layers.LinkTypeEthernet, 1514)
+	require.NoError(t, err)
+	assert.Equal(t, pcapHeaderLen, n)
+
+	ts := time.Unix(1500000000, 123456000)
+	n, err = w.WritePacket(gopacket.CaptureInfo{Timestamp: ts, Length: 100}, []byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, pcapRecordHdrLen+3, n)
+	require.NoError(t, w.Flush())
+
+	b := buf.Bytes()
+	require.Len(t, b, pcapHeaderLen+pcapRecordHdrLen+3)
+	assert.Equal(t, uint32(pcapMagic), binary.LittleEndian.Uint32(b[0:]))
+	assert.Equal(t, uint32(1514), binary.LittleEndian.Uint32(b[16:]))
+	assert.Equal(t, uint32(layers.LinkTypeEthernet), binary.LittleEndian.Uint32(b[20:]))
+
+	rec := b[pcapHeaderLen:]
+	assert.Equal(t, uint32(1500000000), binary.LittleEndian.Uint32(rec[0:]))
+	assert.Equal(t, uint32(123456), binary.LittleEndian.Uint32(rec[4:]))
+	assert.Equal(t, uint32(3), binary.LittleEndian.Uint32(rec[8:]))
+	assert.Equal(t, uint32(100), binary.LittleEndian.Uint32(rec[12:]))
+	assert.Equal(t, []byte{1, 2, 3}, rec[pcapRecordHdrLen:])
+}
+
+func TestPcapngWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, n, err := newPacketWriter(&buf, FormatPcapng, layers.LinkTypeEthernet, 1514)
+	require.NoError(t, err)
+
+	_, err = w.WritePacket(gopacket.CaptureInfo{Timestamp: time.Unix(1, 0)}, []byte{1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	// walk the blocks, checking that the leading and trailing lengths match
+	var types []uint32
+	for b := buf.Bytes(); len(b) > 0; {
+		require.True(t, len(b) >= 12)
+		blockLen := binary.LittleEndian.Uint32(b[4:])
+		require.True(t, int(blockLen) <= len(b))
+		assert.Equal(t, uint32(0), blockLen%4)
+		assert.Equal(t, blockLen, binary.LittleEndian.Uint32(b[blockLen-4:]))
+		types = append(types, binary.LittleEndian.Uint32(b))
+		b = b[blockLen:]
+	}
+	assert.Equal(t, []uint32{pcapngSectionHeader, pcapngInterfaceDesc, pcapngEnhancedPacket}, types)
+	assert.Equal(t, pcapngSectionHeaderLen+pcapngInterfaceDescLen, n)
+
+	_, _, err = newPacketWriter(&buf, "pcapx", layers.LinkTypeEthernet, 1514)
+	assert.Error(t, err)
+}
+
+func TestRotator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a file of an earlier run is part of the ring
+	old := filepath.Join(dir, "test-eth0-20200101-000000.000.pcap")
+	require.NoError(t, ioutil.WriteFile(old, nil, 0640))
+	// files of other devices are not
+	other := filepath.Join(dir, "test-eth1-20200101-000000.000.pcap")
+	require.NoError(t, ioutil.WriteFile(other, nil, 0640))
+
+	r, err := NewRotator(config.DumpConfig{Path: dir, Name: "test", MaxFiles: 2}, "eth0", layers.LinkTypeEthernet, 65535)
+	require.NoError(t, err)
+	assert.Equal(t, []string{old}, r.ring.files)
+	r.maxSize = 200
+
+	data := make([]byte, 100)
+	for i := 0; i < 3; i++ {
+		r.WritePacket(data, *captureInfo(time.Now(), data))
+		time.Sleep(2 * time.Millisecond)
+	}
+	r.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "test-eth0-*.pcap"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.NotContains(t, files, old)
+	assert.FileExists(t, other)
+}
+
+func TestWindow(t *testing.T) {
+	w := newWindow(layers.LinkTypeEthernet, 10*time.Second, 250)
+	start := time.Now()
+	data := make([]byte, 100)
+
+	w.Add(data, captureInfo(start, data))
+	w.Add(data, captureInfo(start.Add(time.Second), data))
+	assert.Len(t, w.snapshot(), 2)
+
+	// memory limit
+	w.Add(data, captureInfo(start.Add(2*time.Second), data))
+	assert.Len(t, w.snapshot(), 2)
+
+	// packets older than the window
+	w.Add(data, captureInfo(start.Add(20*time.Second), data))
+	packets := w.snapshot()
+	if assert.Len(t, packets, 1) {
+		assert.Equal(t, start.Add(20*time.Second), packets[0].ci.Timestamp)
+	}
+
+	// the data is copied
+	data[0] = 1
+	assert.Equal(t, byte(0), w.snapshot()[0].data[0])
+}
+
+func TestTrigger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.DumpTriggerConfig
+	err = common.MustNewConfigFrom(map[string]interface{}{
+		"enabled": true,
+		"path":    dir,
+		"when.range.http.response.status_code.gte": 500,
+	}).Unpack(&cfg)
+	require.NoError(t, err)
+
+	trigger, err := NewTrigger(cfg, config.TunnelsConfig{})
+	require.NoError(t, err)
+	trigger.Start()
+
+	// two workers capturing on the same interface, like a fanout group
+	client, server := net.IP{10, 0, 0, 1}, net.IP{10, 0, 0, 2}
+	windows := []*Window{
+		trigger.Window("eth0", layers.LinkTypeEthernet),
+		trigger.Window("eth0", layers.LinkTypeEthernet),
+	}
+	for _, w := range windows {
+		assert.Equal(t, trigger.maxBytes/2, w.maxBytes)
+	}
+	start := time.Now()
+	for i, p := range []struct {
+		window int
+		data   []byte
+	}{
+		{0, tcpPacket(client, server, 40000, 80)},
+		{0, tcpPacket(client, server, 40001, 80)},
+		{1, tcpPacket(server, client, 80, 40000)},
+	} {
+		windows[p.window].Add(p.data, captureInfo(start.Add(time.Duration(i)*time.Millisecond), p.data))
+	}
+
+	newEvent := func(status int) (*beat.Event, *pb.Fields) {
+		event := &beat.Event{Fields: common.MapStr{}}
+		event.Fields.Put("http.response.status_code", status)
+		fields := &pb.Fields{
+			Source:      &ecs.Source{IP: client.String(), Port: 40000},
+			Destination: &ecs.Destination{IP: server.String(), Port: 80},
+			Capture:     pb.Capture{Interface: "eth0"},
+		}
+		return event, fields
+	}
+
+	event, fields := newEvent(200)
+	trigger.OnEvent(event, fields)
+	_, err = event.Fields.GetValue("pcap.file")
+	assert.Error(t, err)
+
+	event, fields = newEvent(503)
+	trigger.OnEvent(event, fields)
+	path, err := event.Fields.GetValue("pcap.file")
+	require.NoError(t, err)
+
+	// pending files are written on stop
+	trigger.Stop()
+	content, err := ioutil.ReadFile(path.(string))
+	require.NoError(t, err)
+	packetLen := len(tcpPacket(client, server, 40000, 80))
+	if assert.Len(t, content, pcapHeaderLen+2*(pcapRecordHdrLen+packetLen)) {
+		// the packets of both windows are merged in capture order
+		first := content[pcapHeaderLen+pcapRecordHdrLen:]
+		second := first[packetLen+pcapRecordHdrLen:]
+		assert.Equal(t, tcpPacket(client, server, 40000, 80), first[:packetLen])
+		assert.Equal(t, tcpPacket(server, client, 80, 40000), second)
+	}
+}
+
+func TestTriggerTunnel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.DumpTriggerConfig
+	err = common.MustNewConfigFrom(map[string]interface{}{
+		"enabled":         true,
+		"path":            dir,
+		"when.has_fields": []string{"status"},
+	}).Unpack(&cfg)
+	require.NoError(t, err)
+
+	trigger, err := NewTrigger(cfg, config.TunnelsConfig{VXLANPorts: []int{4789}})
+	require.NoError(t, err)
+	trigger.Start()
+
+	// the same inner flow in two tunnels
+	client, server := net.IP{10, 0, 0, 1}, net.IP{10, 0, 0, 2}
+	inner := tcpPacket(client, server, 40000, 80)
+	w := trigger.Window("eth0", layers.LinkTypeEthernet)
+	start := time.Now()
+	for i, data := range [][]byte{vxlanPacket(100, inner), vxlanPacket(200, inner)} {
+		w.Add(data, captureInfo(start.Add(time.Duration(i)*time.Millisecond), data))
+	}
+
+	event := &beat.Event{Fields: common.MapStr{"status": "Error"}}
+	trigger.OnEvent(event, &pb.Fields{
+		Source:      &ecs.Source{IP: client.String(), Port: 40000},
+		Destination: &ecs.Destination{IP: server.String(), Port: 80},
+		Capture: pb.Capture{
+			Interface: "eth0",
+			Tunnels:   []pb.Tunnel{{Type: "vxlan", ID: 100}},
+		},
+	})
+	path, err := event.Fields.GetValue("pcap.file")
+	require.NoError(t, err)
+
+	trigger.Stop()
+	content, err := ioutil.ReadFile(path.(string))
+	require.NoError(t, err)
+	outer := vxlanPacket(100, inner)
+	if assert.Len(t, content, pcapHeaderLen+pcapRecordHdrLen+len(outer)) {
+		assert.Equal(t, outer, content[pcapHeaderLen+pcapRecordHdrLen:])
+	}
+}
+
+func TestTriggerPendingLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var cfg config.DumpTriggerConfig
+	err = common.MustNewConfigFrom(map[string]interface{}{
+		"enabled":         true,
+		"path":            dir,
+		"when.has_fields": []string{"status"},
+	}).Unpack(&cfg)
+	require.NoError(t, err)
+
+	// not started, no file is written
+	trigger, err := NewTrigger(cfg, config.TunnelsConfig{})
+	require.NoError(t, err)
+	trigger.Window("", layers.LinkTypeEthernet)
+
+	tagged := 0
+	for i := 0; i < maxPendingDumps+1; i++ {
+		event := &beat.Event{Fields: common.MapStr{"status": "Error"}}
+		trigger.OnEvent(event, &pb.Fields{
+			Source:      &ecs.Source{IP: "10.0.0.1", Port: 40000},
+			Destination: &ecs.Destination{IP: "10.0.0.2", Port: 80},
+		})
+		if _, err := event.Fields.GetValue("pcap.file"); err == nil {
+			tagged++
+		}
+	}
+	assert.Equal(t, maxPendingDumps, tagged)
+}
+
+func TestNewTriggerDisabled(t *testing.T) {
+	trigger, err := NewTrigger(config.DumpTriggerConfig{}, config.TunnelsConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, trigger)
+
+	_, err = NewTrigger(config.DumpTriggerConfig{Enabled: true}, config.TunnelsConfig{})
+	assert.Error(t, err)
+}
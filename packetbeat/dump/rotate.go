@@ -0,0 +1,257 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dump
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/paths"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+const (
+	defaultName      = "packetbeat"
+	defaultMaxSizeMB = 100
+	defaultMaxFiles  = 10
+
+	// timestampFormat is used in file names, so that the names of a ring
+	// sort by creation time.
+	timestampFormat = "20060102-150405.000"
+
+	cleanupInterval = time.Minute
+	retryInterval   = 10 * time.Second
+)
+
+var debugf = logp.MakeDebug("dump")
+
+// fileRing keeps track of the files written to a directory, and removes the
+// oldest files.
+type fileRing struct {
+	files    []string // oldest first
+	maxFiles int
+	maxAge   time.Duration
+}
+
+// add adds a file to the ring and removes the files beyond the limits.
+func (r *fileRing) add(path string) {
+	r.files = append(r.files, path)
+	r.cleanup(path)
+}
+
+// cleanup removes the files beyond maxFiles, and the files which have not
+// been modified for maxAge. The current file is never removed.
+func (r *fileRing) cleanup(current string) {
+	for r.maxFiles > 0 && len(r.files) > r.maxFiles {
+		r.remove(r.files[0])
+		r.files = r.files[1:]
+	}
+
+	if r.maxAge <= 0 {
+		return
+	}
+	deadline := time.Now().Add(-r.maxAge)
+	files := r.files[:0]
+	for _, path := range r.files {
+		if path != current {
+			if info, err := os.Stat(path); err != nil || info.ModTime().Before(deadline) {
+				r.remove(path)
+				continue
+			}
+		}
+		files = append(files, path)
+	}
+	r.files = files
+}
+
+func (r *fileRing) remove(path string) {
+	debugf("Removing capture file %s", path)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logp.Warn("Failed to remove capture file %s: %v", path, err)
+	}
+}
+
+// Rotator writes packets to a ring of rotating capture files. It is safe for
+// concurrent use.
+type Rotator struct {
+	mu sync.Mutex
+
+	dir      string
+	prefix   string
+	format   string
+	maxSize  int64
+	linkType layers.LinkType
+	snaplen  int
+	ring     fileRing
+
+	file        *os.File
+	writer      packetWriter
+	size        int64
+	lastCleanup time.Time
+	retryAt     time.Time
+}
+
+// NewRotator creates a ring of capture files for the packets captured from a
+// device. Files left in the directory by an earlier run for the same device
+// are part of the ring.
+func NewRotator(cfg config.DumpConfig, device string, linkType layers.LinkType, snaplen int) (*Rotator, error) {
+	if err := checkFormat(cfg.Format); err != nil {
+		return nil, err
+	}
+
+	dir := cfg.Path
+	if dir == "" {
+		dir = paths.Resolve(paths.Data, "pcap")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = defaultName
+	}
+	if device != "" {
+		name += "-" + device
+	}
+	maxSize := cfg.MaxSizeMB
+	if maxSize == 0 {
+		maxSize = defaultMaxSizeMB
+	}
+	maxFiles := cfg.MaxFiles
+	if maxFiles == 0 {
+		maxFiles = defaultMaxFiles
+	}
+
+	r := &Rotator{
+		dir:      dir,
+		prefix:   name + "-",
+		format:   cfg.Format,
+		maxSize:  int64(maxSize) * 1024 * 1024,
+		linkType: linkType,
+		snaplen:  snaplen,
+		ring: fileRing{
+			maxFiles: maxFiles,
+			maxAge:   cfg.MaxAge,
+		},
+	}
+	r.ring.files = r.existingFiles()
+	return r, nil
+}
+
+// existingFiles returns the files of the ring in the directory, oldest first.
+func (r *Rotator) existingFiles() []string {
+	ext := fileExtension(r.format)
+	matches, err := filepath.Glob(filepath.Join(r.dir, r.prefix+"*"+ext))
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, path := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), r.prefix), ext)
+		if _, err := time.Parse(timestampFormat, ts); err == nil {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// WritePacket writes a packet to the current file, starting a new file if the
+// current file is full.
+func (r *Rotator) WritePacket(data []byte, ci gopacket.CaptureInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.writer == nil || r.size >= r.maxSize {
+		if now.Before(r.retryAt) {
+			return
+		}
+		if err := r.rotate(now); err != nil {
+			logp.Err("Failed to open capture file: %v", err)
+			r.retryAt = now.Add(retryInterval)
+			return
+		}
+	}
+
+	n, err := r.writer.WritePacket(ci, data)
+	if err != nil {
+		logp.Err("Failed to write to capture file %s: %v", r.file.Name(), err)
+		r.close()
+		r.retryAt = now.Add(retryInterval)
+		return
+	}
+	r.size += int64(n)
+
+	if now.Sub(r.lastCleanup) > cleanupInterval {
+		r.lastCleanup = now
+		r.writer.Flush()
+		r.ring.cleanup(r.file.Name())
+	}
+}
+
+func (r *Rotator) rotate(now time.Time) error {
+	r.close()
+
+	path := filepath.Join(r.dir, r.prefix+now.Format(timestampFormat)+fileExtension(r.format))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return err
+	}
+
+	w, n, err := newPacketWriter(f, r.format, r.linkType, r.snaplen)
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+
+	debugf("Writing packets to capture file %s", path)
+	r.file, r.writer, r.size = f, w, int64(n)
+	r.lastCleanup = now
+	r.ring.add(path)
+	return nil
+}
+
+func (r *Rotator) close() {
+	if r.file == nil {
+		return
+	}
+	if err := r.writer.Flush(); err != nil {
+		logp.Err("Failed to write to capture file %s: %v", r.file.Name(), err)
+	}
+	r.file.Close()
+	r.file, r.writer = nil, nil
+}
+
+// Close flushes and closes the current file.
+func (r *Rotator) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.close()
+}
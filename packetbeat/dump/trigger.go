@@ -0,0 +1,328 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dump
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/libbeat/paths"
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/decoder"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+const (
+	defaultWindow      = 30 * time.Second
+	defaultMaxBufferMB = 50
+	defaultMaxTriggers = 100
+
+	// number of matching events waiting for their packets to be saved
+	maxPendingDumps = 16
+)
+
+var droppedDumps = monitoring.NewInt(nil, "dump_trigger.dropped")
+
+// Trigger saves the recent packets of a transaction's flow to a pcap file,
+// when the transaction event matches a condition. The path of the file is
+// added to the event. The files are written in the background, by the
+// goroutine started with Start, so the path is best-effort: it is added
+// before the file is written, and the file is missing if writing fails.
+// It is safe for concurrent use.
+//
+// Every worker records the packets it processes in its own window, so that
+// the workers of a fanout group don't contend on a single lock. The windows
+// of an interface share its memory limit, and are merged when a file is
+// written.
+type Trigger struct {
+	dir       string
+	condition conditions.Condition
+	window    time.Duration
+	maxBytes  int
+	tunnels   config.TunnelsConfig
+
+	mu      sync.Mutex
+	windows map[string][]*Window // by interface name
+	ring    fileRing
+	seq     uint64
+
+	pending chan dump
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// dump is a file to write the packets of a flow to.
+type dump struct {
+	path    string
+	windows []*Window
+	matcher *flowMatcher
+}
+
+// NewTrigger creates a trigger from the configuration. The tunnels are
+// decapsulated to find the packets of tunneled flows. It returns nil if the
+// trigger is disabled.
+func NewTrigger(cfg config.DumpTriggerConfig, tunnels config.TunnelsConfig) (*Trigger, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.When == nil {
+		return nil, errors.New("dump_trigger requires a 'when' condition")
+	}
+
+	condition, err := conditions.NewCondition(cfg.When)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := cfg.Path
+	if dir == "" {
+		dir = paths.Resolve(paths.Data, filepath.Join("pcap", "triggered"))
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	window := cfg.Window
+	if window <= 0 {
+		window = defaultWindow
+	}
+	maxBuffer := cfg.MaxBufferMB
+	if maxBuffer == 0 {
+		maxBuffer = defaultMaxBufferMB
+	}
+	maxFiles := cfg.MaxFiles
+	if maxFiles == 0 {
+		maxFiles = defaultMaxTriggers
+	}
+
+	return &Trigger{
+		dir:       dir,
+		condition: condition,
+		window:    window,
+		maxBytes:  maxBuffer * 1024 * 1024,
+		tunnels:   tunnels,
+		windows:   map[string][]*Window{},
+		ring:      fileRing{maxFiles: maxFiles},
+		pending:   make(chan dump, maxPendingDumps),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// Start starts the goroutine writing the files.
+func (t *Trigger) Start() {
+	t.wg.Add(1)
+	go t.run()
+}
+
+// Stop writes the files pending and stops the writer.
+func (t *Trigger) Stop() {
+	close(t.done)
+	t.wg.Wait()
+}
+
+func (t *Trigger) run() {
+	defer t.wg.Done()
+	for {
+		select {
+		case d := <-t.pending:
+			t.write(d)
+		case <-t.done:
+			for {
+				select {
+				case d := <-t.pending:
+					t.write(d)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Window creates the window keeping the recent packets processed by a worker
+// of an interface. The name is empty if the packets are read from a file.
+// The memory limit is split between the windows of the interface.
+func (t *Trigger) Window(name string, linkType layers.LinkType) *Window {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	windows := append(t.windows[name], newWindow(linkType, t.window, t.maxBytes))
+	t.windows[name] = windows
+	for _, w := range windows {
+		w.setMaxBytes(t.maxBytes / len(windows))
+	}
+	return windows[len(windows)-1]
+}
+
+// OnEvent checks a transaction event against the condition. On match, the
+// event is tagged with the name of the file the packets of the transaction's
+// flow are saved to. The file is written in the background. If too many
+// files are pending, the event is not tagged and no file is written.
+func (t *Trigger) OnEvent(event *beat.Event, fields *pb.Fields) {
+	if fields.Source == nil || fields.Destination == nil || !t.condition.Check(event.Fields) {
+		return
+	}
+
+	t.mu.Lock()
+	windows := t.windows[fields.Capture.Interface]
+	t.seq++
+	seq := t.seq
+	t.mu.Unlock()
+	if len(windows) == 0 {
+		return
+	}
+
+	name := fmt.Sprintf("%s-%s-%d.pcap", defaultName, time.Now().Format(timestampFormat), seq)
+	d := dump{
+		path:    filepath.Join(t.dir, name),
+		windows: windows,
+		matcher: newFlowMatcher(fields),
+	}
+	select {
+	case t.pending <- d:
+		event.Fields.Put("pcap.file", d.path)
+	default:
+		debugf("Too many pending dumps, ignoring triggering event")
+		droppedDumps.Add(1)
+	}
+}
+
+// write saves the packets of the flow found in the windows. The file is
+// written even if no packets are found, as the event references it.
+func (t *Trigger) write(d dump) {
+	// the windows of an interface share its link type
+	linkType := d.windows[0].linkType
+	dec, err := decoder.New(nil, linkType, nil, nil, nil, nil, config.IPFragmentsConfig{}, t.tunnels)
+	if err != nil {
+		logp.Err("Failed to save packets of triggering event: %v", err)
+		return
+	}
+
+	var packets []packet
+	for _, w := range d.windows {
+		for _, p := range w.snapshot() {
+			if d.matcher.match(dec, p.data) {
+				packets = append(packets, p)
+			}
+		}
+	}
+	if len(packets) == 0 {
+		debugf("No packets found for the flow of the triggering event")
+	}
+	// the packets of each window are in order, merge the windows
+	sort.SliceStable(packets, func(i, j int) bool {
+		return packets[i].ci.Timestamp.Before(packets[j].ci.Timestamp)
+	})
+
+	if err := save(d.path, linkType, packets); err != nil {
+		logp.Err("Failed to save packets of triggering event: %v", err)
+		return
+	}
+
+	t.mu.Lock()
+	t.ring.add(d.path)
+	t.mu.Unlock()
+}
+
+func save(path string, linkType layers.LinkType, packets []packet) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return err
+	}
+
+	err = writePackets(f, linkType, packets)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+func writePackets(f *os.File, linkType layers.LinkType, packets []packet) error {
+	w, _, err := newPcapWriter(f, linkType, 65535)
+	if err != nil {
+		return err
+	}
+	for _, p := range packets {
+		if _, err := w.WritePacket(p.ci, p.data); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// flowMatcher matches the packets of a flow in both directions, by the IP
+// addresses and ports of the endpoints. The packets of tunneled flows are
+// matched by the endpoints of the inner packet and the tunnels.
+type flowMatcher struct {
+	ipA, ipB     net.IP
+	portA, portB uint16
+	tunnels      []pb.Tunnel
+}
+
+func newFlowMatcher(fields *pb.Fields) *flowMatcher {
+	return &flowMatcher{
+		ipA:     net.ParseIP(fields.Source.IP),
+		ipB:     net.ParseIP(fields.Destination.IP),
+		portA:   uint16(fields.Source.Port),
+		portB:   uint16(fields.Destination.Port),
+		tunnels: fields.Capture.Tunnels,
+	}
+}
+
+func (m *flowMatcher) match(dec *decoder.Decoder, data []byte) bool {
+	tuple, tunnels, ok := dec.Endpoints(data)
+	if !ok || !sameTunnels(m.tunnels, tunnels) {
+		return false
+	}
+	return m.matchEndpoints(tuple.SrcIP, tuple.SrcPort, tuple.DstIP, tuple.DstPort) ||
+		m.matchEndpoints(tuple.DstIP, tuple.DstPort, tuple.SrcIP, tuple.SrcPort)
+}
+
+func (m *flowMatcher) matchEndpoints(ipA net.IP, portA uint16, ipB net.IP, portB uint16) bool {
+	return m.ipA.Equal(ipA) && m.ipB.Equal(ipB) &&
+		(m.portA == 0 || m.portA == portA) &&
+		(m.portB == 0 || m.portB == portB)
+}
+
+func sameTunnels(a, b []pb.Tunnel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dump
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// Window keeps the packets captured by a worker during the last duration in
+// memory, limited to maxBytes. It is safe for concurrent use, but is only
+// meant to be written by a single worker.
+type Window struct {
+	mu sync.Mutex
+
+	linkType layers.LinkType
+	duration time.Duration
+	maxBytes int
+
+	packets []packet // oldest first
+	bytes   int
+}
+
+type packet struct {
+	ci   gopacket.CaptureInfo
+	data []byte
+}
+
+func newWindow(linkType layers.LinkType, duration time.Duration, maxBytes int) *Window {
+	return &Window{
+		linkType: linkType,
+		duration: duration,
+		maxBytes: maxBytes,
+	}
+}
+
+// Add copies a packet into the window, and removes the packets that are
+// older than the window duration, or beyond the memory limit. The age of
+// the packets is relative to the newest packet, so that the window also
+// works when reading packets from a file.
+func (w *Window) Add(data []byte, ci *gopacket.CaptureInfo) {
+	buf := make([]byte, len(data))
+	copy(buf, data)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.packets = append(w.packets, packet{ci: *ci, data: buf})
+	w.bytes += len(buf)
+
+	deadline := ci.Timestamp.Add(-w.duration)
+	i := 0
+	for ; i < len(w.packets)-1; i++ {
+		p := w.packets[i]
+		if w.bytes <= w.maxBytes && !p.ci.Timestamp.Before(deadline) {
+			break
+		}
+		w.bytes -= len(p.data)
+		w.packets[i] = packet{}
+	}
+	w.packets = w.packets[i:]
+}
+
+// setMaxBytes changes the memory limit of the window. The packets beyond the
+// limit are removed when the next packet is added.
+func (w *Window) setMaxBytes(maxBytes int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.maxBytes = maxBytes
+}
+
+// snapshot returns the packets in the window. The packets must not be
+// modified.
+func (w *Window) snapshot() []packet {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	packets := make([]packet, len(w.packets))
+	copy(packets, w.packets)
+	return packets
+}
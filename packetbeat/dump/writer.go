@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dump
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// Supported file formats.
+const (
+	FormatPcap   = "pcap"
+	FormatPcapng = "pcapng"
+)
+
+// packetWriter writes packets to a capture file.
+type packetWriter interface {
+	WritePacket(ci gopacket.CaptureInfo, data []byte) (int, error)
+	Flush() error
+}
+
+func checkFormat(format string) error {
+	switch format {
+	case "", FormatPcap, FormatPcapng:
+		return nil
+	default:
+		return fmt.Errorf("unknown capture file format '%s'", format)
+	}
+}
+
+// newPacketWriter writes the file header and returns a writer for the
+// packets, and the size of the header.
+func newPacketWriter(w io.Writer, format string, linkType layers.LinkType, snaplen int) (packetWriter, int, error) {
+	if err := checkFormat(format); err != nil {
+		return nil, 0, err
+	}
+	if format == FormatPcapng {
+		return newPcapngWriter(w, linkType, snaplen)
+	}
+	return newPcapWriter(w, linkType, snaplen)
+}
+
+func fileExtension(format string) string {
+	if format == FormatPcapng {
+		return ".pcapng"
+	}
+	return ".pcap"
+}
+
+// pcapWriter writes classic libpcap files with microsecond timestamps.
+type pcapWriter struct {
+	w *bufio.Writer
+}
+
+const (
+	pcapMagic        = 0xa1b2c3d4
+	pcapHeaderLen    = 24
+	pcapRecordHdrLen = 16
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+)
+
+func newPcapWriter(w io.Writer, linkType layers.LinkType, snaplen int) (*pcapWriter, int, error) {
+	var hdr [pcapHeaderLen]byte
+	binary.LittleEndian.PutUint32(hdr[0:], pcapMagic)
+	binary.LittleEndian.PutUint16(hdr[4:], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(hdr[6:], pcapVersionMinor)
+	binary.LittleEndian.PutUint32(hdr[16:], uint32(snaplen))
+	binary.LittleEndian.PutUint32(hdr[20:], uint32(linkType))
+
+	p := &pcapWriter{w: bufio.NewWriter(w)}
+	if _, err := p.w.Write(hdr[:]); err != nil {
+		return nil, 0, err
+	}
+	return p, len(hdr), nil
+}
+
+func (p *pcapWriter) WritePacket(ci gopacket.CaptureInfo, data []byte) (int, error) {
+	var hdr [pcapRecordHdrLen]byte
+	binary.LittleEndian.PutUint32(hdr[0:], uint32(ci.Timestamp.Unix()))
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(ci.Timestamp.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(hdr[8:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(hdr[12:], uint32(originalLength(ci, data)))
+
+	if _, err := p.w.Write(hdr[:]); err != nil {
+		return 0, err
+	}
+	if _, err := p.w.Write(data); err != nil {
+		return 0, err
+	}
+	return len(hdr) + len(data), nil
+}
+
+func (p *pcapWriter) Flush() error {
+	return p.w.Flush()
+}
+
+// pcapngWriter writes pcapng files with a single section and interface, and
+// microsecond timestamps.
+type pcapngWriter struct {
+	w *bufio.Writer
+}
+
+const (
+	pcapngSectionHeader  = 0x0a0d0d0a
+	pcapngInterfaceDesc  = 0x00000001
+	pcapngEnhancedPacket = 0x00000006
+	pcapngByteOrderMagic = 0x1a2b3c4d
+
+	pcapngSectionHeaderLen  = 28
+	pcapngInterfaceDescLen  = 20
+	pcapngEnhancedPacketLen = 32
+)
+
+func newPcapngWriter(w io.Writer, linkType layers.LinkType, snaplen int) (*pcapngWriter, int, error) {
+	var hdr [pcapngSectionHeaderLen + pcapngInterfaceDescLen]byte
+
+	shb := hdr[:pcapngSectionHeaderLen]
+	binary.LittleEndian.PutUint32(shb[0:], pcapngSectionHeader)
+	binary.LittleEndian.PutUint32(shb[4:], pcapngSectionHeaderLen)
+	binary.LittleEndian.PutUint32(shb[8:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[12:], 1) // major version
+	binary.LittleEndian.PutUint64(shb[16:], 0xffffffffffffffff)
+	binary.LittleEndian.PutUint32(shb[24:], pcapngSectionHeaderLen)
+
+	idb := hdr[pcapngSectionHeaderLen:]
+	binary.LittleEndian.PutUint32(idb[0:], pcapngInterfaceDesc)
+	binary.LittleEndian.PutUint32(idb[4:], pcapngInterfaceDescLen)
+	binary.LittleEndian.PutUint16(idb[8:], uint16(linkType))
+	binary.LittleEndian.PutUint32(idb[12:], uint32(snaplen))
+	binary.LittleEndian.PutUint32(idb[16:], pcapngInterfaceDescLen)
+
+	p := &pcapngWriter{w: bufio.NewWriter(w)}
+	if _, err := p.w.Write(hdr[:]); err != nil {
+		return nil, 0, err
+	}
+	return p, len(hdr), nil
+}
+
+func (p *pcapngWriter) WritePacket(ci gopacket.CaptureInfo, data []byte) (int, error) {
+	padding := (4 - len(data)%4) % 4
+	blockLen := pcapngEnhancedPacketLen + len(data) + padding
+	ts := uint64(ci.Timestamp.UnixNano() / 1000)
+
+	var hdr [pcapngEnhancedPacketLen - 4]byte
+	binary.LittleEndian.PutUint32(hdr[0:], pcapngEnhancedPacket)
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(blockLen))
+	binary.LittleEndian.PutUint32(hdr[12:], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(hdr[16:], uint32(ts))
+	binary.LittleEndian.PutUint32(hdr[20:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(hdr[24:], uint32(originalLength(ci, data)))
+
+	var trailer [7]byte
+	binary.LittleEndian.PutUint32(trailer[padding:], uint32(blockLen))
+
+	if _, err := p.w.Write(hdr[:]); err != nil {
+		return 0, err
+	}
+	if _, err := p.w.Write(data); err != nil {
+		return 0, err
+	}
+	if _, err := p.w.Write(trailer[:padding+4]); err != nil {
+		return 0, err
+	}
+	return blockLen, nil
+}
+
+func (p *pcapngWriter) Flush() error {
+	return p.w.Flush()
+}
+
+func originalLength(ci gopacket.CaptureInfo, data []byte) int {
+	if ci.Length > len(data) {
+		return ci.Length
+	}
+	return len(data)
+}
@@ -95,6 +95,16 @@ func (c *Uint) Set(f *Flow, value uint64) {
 	}
 }
 
+// Or sets the bits of value in the counter, collecting the flags seen in
+// the packets of a flow.
+func (c *Uint) Or(f *Flow, value uint64) {
+	uints := f.stats.uints
+	if c.i < len(uints) {
+		uints[c.i] |= value
+		c.f.apply(f.stats.uintFlags)
+	}
+}
+
 func (c *Float) Add(f *Flow, delta float64) {
 	floats := f.stats.floats
 	if c.i < len(floats) {
@@ -146,8 +156,16 @@ func (c *counterReg) newFloat(name string) (*Float, error) {
 
 // XXX:
 //  - error on index > int max
-//  - error if already in use
+//
+// Counters registered multiple times, like by the decoders of multiple
+// workers, share the same index.
 func (reg *counterTypeReg) reg(name string) (int, error) {
+	for i, n := range reg.names {
+		if n == name {
+			return i, nil
+		}
+	}
+
 	debugf("register flow counter: %v", name)
 
 	i := len(reg.names)
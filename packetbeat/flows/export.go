@@ -0,0 +1,503 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flows
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+// export protocol versions, as found in the message headers
+const (
+	netflowV9Version = 9
+	ipfixVersion     = 10
+)
+
+const (
+	exportTemplateIPv4 = 256
+	exportTemplateIPv6 = 257
+
+	// set IDs of template sets
+	netflowV9TemplateSet = 0
+	ipfixTemplateSet     = 2
+
+	netflowV9HeaderLen = 20
+	ipfixHeaderLen     = 16
+	exportSetHeaderLen = 4
+
+	// exportMaxMessageLen keeps a message within a single ethernet frame.
+	exportMaxMessageLen = 1400
+
+	defaultTemplateRefresh = time.Minute
+)
+
+// Information elements of the exported records. NetFlow v9 uses the same
+// field type numbers, except for the timestamps.
+const (
+	ieOctetDeltaCount          = 1
+	iePacketDeltaCount         = 2
+	ieProtocolIdentifier       = 4
+	ieTCPControlBits           = 6
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieLastSwitched             = 21 // NetFlow v9, milliseconds of system uptime
+	ieFirstSwitched            = 22 // NetFlow v9, milliseconds of system uptime
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieVlanID                   = 58
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+)
+
+// names of the flow counters registered by the decoder
+const (
+	exportBytesCounter    = "bytes"
+	exportPacketsCounter  = "packets"
+	exportTCPFlagsCounter = "tcpFlags"
+	exportICMPv4Counter   = "icmpV4TypeCode"
+	exportICMPv6Counter   = "icmpV6TypeCode"
+)
+
+type templateField struct {
+	id, length uint16
+}
+
+type exportTemplate struct {
+	id     uint16
+	fields []templateField
+	length int // length of a data record
+}
+
+// exportRecord is the record of one direction of a flow.
+type exportRecord struct {
+	srcIP, dstIP     net.IP
+	srcPort, dstPort uint16
+	proto            uint8
+	tcpFlags         uint8
+	vlan             uint16
+	bytes, packets   uint64
+	start, end       time.Time
+}
+
+// exportedCounts are the counters of a flow direction sent to the collectors
+// so far. Records hold the difference to the counts of the last export, and
+// start at the end of the last exported record.
+type exportedCounts struct {
+	bytes, packets uint64
+	ts             time.Time
+}
+
+// exporter sends the records of the reported flows to NetFlow v9 or IPFIX
+// collectors. Records are buffered until flush is called, after every
+// report of the flows worker. The exporter is only used by the flows worker.
+type exporter struct {
+	version  uint16
+	domainID uint32
+	conns    []net.Conn
+
+	// templates of the IPv4 and IPv6 records
+	templates [2]exportTemplate
+	// pending records per template
+	records [2][]exportRecord
+
+	refresh       time.Duration
+	lastTemplates time.Time
+
+	// reference of the NetFlow v9 system uptime
+	startTime time.Time
+	sequence  uint32
+}
+
+func newExporter(cfg config.FlowsExportConfig) (*exporter, error) {
+	e := &exporter{
+		domainID:  cfg.ObservationDomainID,
+		refresh:   cfg.TemplateRefresh,
+		startTime: time.Now(),
+	}
+	if e.refresh <= 0 {
+		e.refresh = defaultTemplateRefresh
+	}
+
+	switch cfg.Protocol {
+	case "", "ipfix":
+		e.version = ipfixVersion
+	case "netflow_v9":
+		e.version = netflowV9Version
+	default:
+		return nil, fmt.Errorf("unknown flow export protocol: %s", cfg.Protocol)
+	}
+	e.templates = makeExportTemplates(e.version)
+
+	if len(cfg.Collectors) == 0 {
+		return nil, errors.New("no flow collectors configured")
+	}
+	for _, addr := range cfg.Collectors {
+		conn, err := net.Dial("udp", addr)
+		if err != nil {
+			e.Close()
+			return nil, fmt.Errorf("failed to connect to flow collector %s: %v", addr, err)
+		}
+		e.conns = append(e.conns, conn)
+	}
+
+	return e, nil
+}
+
+func makeExportTemplates(version uint16) [2]exportTemplate {
+	timestamps := []templateField{
+		{ieFlowStartMilliseconds, 8},
+		{ieFlowEndMilliseconds, 8},
+	}
+	if version == netflowV9Version {
+		timestamps = []templateField{
+			{ieFirstSwitched, 4},
+			{ieLastSwitched, 4},
+		}
+	}
+
+	common := []templateField{
+		{ieSourceTransportPort, 2},
+		{ieDestinationTransportPort, 2},
+		{ieProtocolIdentifier, 1},
+		{ieTCPControlBits, 1},
+		{ieVlanID, 2},
+		{ieOctetDeltaCount, 8},
+		{iePacketDeltaCount, 8},
+	}
+	common = append(common, timestamps...)
+
+	templates := [2]exportTemplate{
+		{
+			id:     exportTemplateIPv4,
+			fields: append([]templateField{{ieSourceIPv4Address, 4}, {ieDestinationIPv4Address, 4}}, common...),
+		},
+		{
+			id:     exportTemplateIPv6,
+			fields: append([]templateField{{ieSourceIPv6Address, 16}, {ieDestinationIPv6Address, 16}}, common...),
+		},
+	}
+	for i := range templates {
+		for _, f := range templates[i].fields {
+			templates[i].length += int(f.length)
+		}
+	}
+	return templates
+}
+
+// Close closes the connections to the collectors.
+func (e *exporter) Close() {
+	for _, conn := range e.conns {
+		conn.Close()
+	}
+	e.conns = nil
+}
+
+// add buffers the records of both directions of a flow. Only the packets
+// seen since the last export of the flow are counted. Flows without IP
+// layer are not exported.
+func (e *exporter) add(f *biFlow, uintNames []string) {
+	var rec exportRecord
+	tmpl := 0
+	if src, dst, ok := f.id.IPv4Addr(); ok {
+		rec.srcIP, rec.dstIP = net.IP(src), net.IP(dst)
+	} else if src, dst, ok := f.id.IPv6Addr(); ok {
+		rec.srcIP, rec.dstIP = net.IP(src), net.IP(dst)
+		tmpl = 1
+	} else {
+		return
+	}
+
+	if src, dst, ok := f.id.TCPAddr(); ok {
+		rec.proto = 6
+		rec.srcPort, rec.dstPort = binary.LittleEndian.Uint16(src), binary.LittleEndian.Uint16(dst)
+	} else if src, dst, ok := f.id.UDPAddr(); ok {
+		rec.proto = 17
+		rec.srcPort, rec.dstPort = binary.LittleEndian.Uint16(src), binary.LittleEndian.Uint16(dst)
+	}
+	if vlan := f.id.VLan(); vlan != nil {
+		rec.vlan = binary.LittleEndian.Uint16(vlan)
+	}
+	rec.end = f.ts
+
+	for dir, stats := range f.stats {
+		if stats == nil {
+			continue
+		}
+
+		bytes, _ := uintCounter(stats, uintNames, exportBytesCounter)
+		packets, _ := uintCounter(stats, uintNames, exportPacketsCounter)
+		last := &f.exported[dir]
+		if packets <= last.packets {
+			continue
+		}
+
+		r := rec
+		if dir == 1 {
+			r.srcIP, r.dstIP = rec.dstIP, rec.srcIP
+			r.srcPort, r.dstPort = rec.dstPort, rec.srcPort
+		}
+		r.bytes, r.packets = bytes-last.bytes, packets-last.packets
+		r.start = f.createTS
+		if !last.ts.IsZero() {
+			r.start = last.ts
+		}
+		last.bytes, last.packets, last.ts = bytes, packets, f.ts
+
+		if flags, ok := uintCounter(stats, uintNames, exportTCPFlagsCounter); ok {
+			r.tcpFlags = uint8(flags)
+		}
+
+		// ICMP type and code are exported in the destination port, as
+		// done by most exporters
+		if typeCode, ok := uintCounter(stats, uintNames, exportICMPv4Counter); ok && typeCode > 0 {
+			r.proto, r.srcPort, r.dstPort = 1, 0, uint16(typeCode)
+		} else if typeCode, ok := uintCounter(stats, uintNames, exportICMPv6Counter); ok && typeCode > 0 {
+			r.proto, r.srcPort, r.dstPort = 58, 0, uint16(typeCode)
+		}
+
+		e.records[tmpl] = append(e.records[tmpl], r)
+	}
+}
+
+// uintCounter returns the value of a counter, if it has been set for the flow.
+func uintCounter(stats *flowStats, names []string, name string) (uint64, bool) {
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		if i >= len(stats.uints) || stats.uintFlags[i/8]&(1<<uint(i%8)) == 0 {
+			return 0, false
+		}
+		return stats.uints[i], true
+	}
+	return 0, false
+}
+
+// flush sends the buffered records to all collectors.
+func (e *exporter) flush() {
+	msgs := e.encode(time.Now())
+	for _, conn := range e.conns {
+		for _, msg := range msgs {
+			if _, err := conn.Write(msg); err != nil {
+				logp.Warn("Failed to send flow records to %v: %v", conn.RemoteAddr(), err)
+				break
+			}
+		}
+	}
+}
+
+// exportMessage is a message being encoded.
+type exportMessage struct {
+	buf []byte
+
+	set      int // offset of the open set, or -1
+	setID    uint16
+	count    int // number of template and data records
+	dataRecs int
+}
+
+// encode turns the buffered records into messages. The templates are sent
+// with the first message, if they have not been sent for the refresh
+// interval. Collectors can only decode the records once they have received
+// the templates.
+func (e *exporter) encode(now time.Time) [][]byte {
+	var msgs [][]byte
+	var m *exportMessage
+
+	if e.lastTemplates.IsZero() || now.Sub(e.lastTemplates) >= e.refresh {
+		m = e.beginMessage()
+		e.appendTemplates(m)
+		e.lastTemplates = now
+	}
+
+	for i := range e.templates {
+		tmpl := &e.templates[i]
+		for j := range e.records[i] {
+			need := tmpl.length
+			if m == nil || m.set < 0 || m.setID != tmpl.id {
+				need += exportSetHeaderLen + 3
+			}
+			if m != nil && len(m.buf)+need > exportMaxMessageLen {
+				msgs = append(msgs, e.finishMessage(m, now))
+				m = nil
+			}
+			if m == nil {
+				m = e.beginMessage()
+			}
+
+			if m.set < 0 || m.setID != tmpl.id {
+				e.closeSet(m)
+				e.openSet(m, tmpl.id)
+			}
+			m.buf = e.appendRecord(m.buf, tmpl, &e.records[i][j])
+			m.count++
+			m.dataRecs++
+		}
+		e.records[i] = e.records[i][:0]
+	}
+
+	if m != nil {
+		msgs = append(msgs, e.finishMessage(m, now))
+	}
+	return msgs
+}
+
+func (e *exporter) beginMessage() *exportMessage {
+	hdrLen := ipfixHeaderLen
+	if e.version == netflowV9Version {
+		hdrLen = netflowV9HeaderLen
+	}
+	return &exportMessage{
+		buf: make([]byte, hdrLen, exportMaxMessageLen),
+		set: -1,
+	}
+}
+
+func (e *exporter) openSet(m *exportMessage, id uint16) {
+	m.set = len(m.buf)
+	m.setID = id
+	m.buf = append(m.buf, 0, 0, 0, 0)
+	binary.BigEndian.PutUint16(m.buf[m.set:], id)
+}
+
+// closeSet writes the length of the open set. NetFlow v9 sets are padded
+// to a multiple of 4 bytes.
+func (e *exporter) closeSet(m *exportMessage) {
+	if m.set < 0 {
+		return
+	}
+	if e.version == netflowV9Version {
+		for (len(m.buf)-m.set)%4 != 0 {
+			m.buf = append(m.buf, 0)
+		}
+	}
+	binary.BigEndian.PutUint16(m.buf[m.set+2:], uint16(len(m.buf)-m.set))
+	m.set = -1
+}
+
+func (e *exporter) appendTemplates(m *exportMessage) {
+	setID := uint16(ipfixTemplateSet)
+	if e.version == netflowV9Version {
+		setID = netflowV9TemplateSet
+	}
+
+	e.openSet(m, setID)
+	for _, tmpl := range e.templates {
+		m.buf = appendUint16(m.buf, tmpl.id)
+		m.buf = appendUint16(m.buf, uint16(len(tmpl.fields)))
+		for _, f := range tmpl.fields {
+			m.buf = appendUint16(m.buf, f.id)
+			m.buf = appendUint16(m.buf, f.length)
+		}
+		m.count++
+	}
+	e.closeSet(m)
+}
+
+func (e *exporter) appendRecord(b []byte, tmpl *exportTemplate, r *exportRecord) []byte {
+	for _, f := range tmpl.fields {
+		switch f.id {
+		case ieSourceIPv4Address:
+			b = append(b, r.srcIP.To4()...)
+		case ieDestinationIPv4Address:
+			b = append(b, r.dstIP.To4()...)
+		case ieSourceIPv6Address:
+			b = append(b, r.srcIP.To16()...)
+		case ieDestinationIPv6Address:
+			b = append(b, r.dstIP.To16()...)
+		case ieSourceTransportPort:
+			b = appendUint16(b, r.srcPort)
+		case ieDestinationTransportPort:
+			b = appendUint16(b, r.dstPort)
+		case ieProtocolIdentifier:
+			b = append(b, r.proto)
+		case ieTCPControlBits:
+			b = append(b, r.tcpFlags)
+		case ieVlanID:
+			b = appendUint16(b, r.vlan)
+		case ieOctetDeltaCount:
+			b = appendUint64(b, r.bytes)
+		case iePacketDeltaCount:
+			b = appendUint64(b, r.packets)
+		case ieFlowStartMilliseconds:
+			b = appendUint64(b, uint64(r.start.UnixNano()/int64(time.Millisecond)))
+		case ieFlowEndMilliseconds:
+			b = appendUint64(b, uint64(r.end.UnixNano()/int64(time.Millisecond)))
+		case ieFirstSwitched:
+			b = appendUint32(b, e.uptime(r.start))
+		case ieLastSwitched:
+			b = appendUint32(b, e.uptime(r.end))
+		}
+	}
+	return b
+}
+
+// finishMessage writes the message header. The IPFIX sequence number counts
+// the data records sent before the message, the NetFlow v9 sequence number
+// counts the messages.
+func (e *exporter) finishMessage(m *exportMessage, now time.Time) []byte {
+	e.closeSet(m)
+
+	b := m.buf
+	binary.BigEndian.PutUint16(b[0:], e.version)
+	if e.version == netflowV9Version {
+		binary.BigEndian.PutUint16(b[2:], uint16(m.count))
+		binary.BigEndian.PutUint32(b[4:], e.uptime(now))
+		binary.BigEndian.PutUint32(b[8:], uint32(now.Unix()))
+		binary.BigEndian.PutUint32(b[12:], e.sequence)
+		binary.BigEndian.PutUint32(b[16:], e.domainID)
+		e.sequence++
+	} else {
+		binary.BigEndian.PutUint16(b[2:], uint16(len(b)))
+		binary.BigEndian.PutUint32(b[4:], uint32(now.Unix()))
+		binary.BigEndian.PutUint32(b[8:], e.sequence)
+		binary.BigEndian.PutUint32(b[12:], e.domainID)
+		e.sequence += uint32(m.dataRecs)
+	}
+	return b
+}
+
+// uptime returns the milliseconds since the exporter has been started, the
+// NetFlow v9 system uptime.
+func (e *exporter) uptime(ts time.Time) uint32 {
+	d := ts.Sub(e.startTime)
+	if d < 0 {
+		return 0
+	}
+	return uint32(d / time.Millisecond)
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v>>32)), uint32(v))
+}
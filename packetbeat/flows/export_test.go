@@ -0,0 +1,222 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package flows
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+var exportCounters = []string{"bytes", "packets", "tcpFlags"}
+
+func testExportFlow(ip1, ip2 net.IP, start time.Time) *biFlow {
+	id := newFlowID()
+	id.AddVLan(171)
+	if ip4 := ip1.To4(); ip4 != nil {
+		id.AddIPv4(ip4, ip2.To4())
+	} else {
+		id.AddIPv6(ip1, ip2)
+	}
+	id.AddTCP(38901, 80)
+
+	f := &biFlow{
+		id:       id.rawFlowID,
+		createTS: start,
+		ts:       start.Add(3 * time.Second),
+		dir:      flowDirForward,
+	}
+	f.stats[0] = &flowStats{uintFlags: []uint8{7}, uints: []uint64{100, 2, 0x02 | 0x10}}
+	f.stats[1] = &flowStats{uintFlags: []uint8{7}, uints: []uint64{1460, 1, 0x12}}
+	return f
+}
+
+type exportSet struct {
+	id   uint16
+	body []byte
+}
+
+func parseSets(t *testing.T, msg []byte, hdrLen int) []exportSet {
+	var sets []exportSet
+	for b := msg[hdrLen:]; len(b) > 0; {
+		require.True(t, len(b) >= exportSetHeaderLen)
+		length := int(binary.BigEndian.Uint16(b[2:]))
+		require.True(t, length >= exportSetHeaderLen && length <= len(b))
+		sets = append(sets, exportSet{binary.BigEndian.Uint16(b), b[exportSetHeaderLen:length]})
+		b = b[length:]
+	}
+	return sets
+}
+
+func TestExportIPFIX(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	e, err := newExporter(config.FlowsExportConfig{
+		Collectors:          []string{collector.LocalAddr().String()},
+		ObservationDomainID: 42,
+	})
+	require.NoError(t, err)
+	defer e.Close()
+
+	start := time.Unix(1542292881, 0)
+	e.add(testExportFlow(net.IP{203, 0, 113, 3}, net.IP{198, 51, 100, 2}, start), exportCounters)
+	e.flush()
+
+	buf := make([]byte, 65536)
+	collector.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := collector.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := buf[:n]
+
+	assert.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:]))
+	assert.Equal(t, uint16(n), binary.BigEndian.Uint16(msg[2:]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]))
+	assert.Equal(t, uint32(42), binary.BigEndian.Uint32(msg[12:]))
+
+	sets := parseSets(t, msg, ipfixHeaderLen)
+	require.Len(t, sets, 2)
+	assert.Equal(t, uint16(ipfixTemplateSet), sets[0].id)
+	assert.Equal(t, uint16(exportTemplateIPv4), binary.BigEndian.Uint16(sets[0].body[0:]))
+	assert.Equal(t, uint16(len(e.templates[0].fields)), binary.BigEndian.Uint16(sets[0].body[2:]))
+
+	assert.Equal(t, uint16(exportTemplateIPv4), sets[1].id)
+	recLen := e.templates[0].length
+	require.Len(t, sets[1].body, 2*recLen)
+
+	// the records of both directions
+	for i, expected := range []struct {
+		src, dst         net.IP
+		srcPort, dstPort uint16
+		flags            uint8
+		bytes, packets   uint64
+	}{
+		{net.IP{203, 0, 113, 3}, net.IP{198, 51, 100, 2}, 38901, 80, 0x12, 100, 2},
+		{net.IP{198, 51, 100, 2}, net.IP{203, 0, 113, 3}, 80, 38901, 0x12, 1460, 1},
+	} {
+		rec := sets[1].body[i*recLen:]
+		assert.Equal(t, expected.src, net.IP(rec[0:4]))
+		assert.Equal(t, expected.dst, net.IP(rec[4:8]))
+		assert.Equal(t, expected.srcPort, binary.BigEndian.Uint16(rec[8:]))
+		assert.Equal(t, expected.dstPort, binary.BigEndian.Uint16(rec[10:]))
+		assert.Equal(t, uint8(6), rec[12])
+		assert.Equal(t, expected.flags, rec[13])
+		assert.Equal(t, uint16(171), binary.BigEndian.Uint16(rec[14:]))
+		assert.Equal(t, expected.bytes, binary.BigEndian.Uint64(rec[16:]))
+		assert.Equal(t, expected.packets, binary.BigEndian.Uint64(rec[24:]))
+		assert.Equal(t, uint64(1542292881000), binary.BigEndian.Uint64(rec[32:]))
+		assert.Equal(t, uint64(1542292884000), binary.BigEndian.Uint64(rec[40:]))
+	}
+}
+
+func TestExportDeltas(t *testing.T) {
+	e := &exporter{version: ipfixVersion, refresh: time.Hour}
+	e.templates = makeExportTemplates(e.version)
+
+	now := time.Now()
+	f := testExportFlow(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2"), now)
+	e.add(f, exportCounters)
+	msgs := e.encode(now)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msgs[0][8:]))
+
+	// no new packets, no records and the templates are not due yet
+	e.add(f, exportCounters)
+	assert.Empty(t, e.encode(now))
+
+	f.stats[0].uints[0] += 50
+	f.stats[0].uints[1]++
+	lastExport := f.ts
+	f.ts = f.ts.Add(10 * time.Second)
+	e.add(f, exportCounters)
+	msgs = e.encode(now)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(msgs[0][8:]))
+
+	sets := parseSets(t, msgs[0], ipfixHeaderLen)
+	require.Len(t, sets, 1)
+	assert.Equal(t, uint16(exportTemplateIPv6), sets[0].id)
+	rec := sets[0].body
+	require.Len(t, rec, e.templates[1].length)
+	assert.Equal(t, net.ParseIP("2001:db8::1"), net.IP(rec[0:16]))
+	assert.Equal(t, uint64(50), binary.BigEndian.Uint64(rec[40:]))
+	assert.Equal(t, uint64(1), binary.BigEndian.Uint64(rec[48:]))
+	// the record starts at the end of the previous one
+	assert.Equal(t, uint64(lastExport.UnixNano()/int64(time.Millisecond)), binary.BigEndian.Uint64(rec[56:]))
+	assert.Equal(t, uint64(f.ts.UnixNano()/int64(time.Millisecond)), binary.BigEndian.Uint64(rec[64:]))
+
+	// templates are sent again after the refresh interval
+	msgs = e.encode(now.Add(time.Hour))
+	require.Len(t, msgs, 1)
+	assert.Equal(t, uint16(ipfixTemplateSet), parseSets(t, msgs[0], ipfixHeaderLen)[0].id)
+}
+
+func TestExportNetflowV9(t *testing.T) {
+	e := &exporter{version: netflowV9Version, refresh: time.Hour, startTime: time.Now(), domainID: 7}
+	e.templates = makeExportTemplates(e.version)
+
+	for i := 0; i < 100; i++ {
+		e.add(testExportFlow(net.IP{10, 0, 0, 1}, net.IP{10, 0, byte(i), 2}, e.startTime), exportCounters)
+	}
+	msgs := e.encode(e.startTime.Add(time.Second))
+	require.True(t, len(msgs) > 1)
+
+	records := 0
+	for i, msg := range msgs {
+		assert.True(t, len(msg) <= exportMaxMessageLen)
+		assert.Equal(t, uint16(netflowV9Version), binary.BigEndian.Uint16(msg[0:]))
+		assert.Equal(t, uint32(1000), binary.BigEndian.Uint32(msg[4:]))
+		assert.Equal(t, uint32(i), binary.BigEndian.Uint32(msg[12:]))
+		assert.Equal(t, uint32(7), binary.BigEndian.Uint32(msg[16:]))
+
+		count := 0
+		for _, set := range parseSets(t, msg, netflowV9HeaderLen) {
+			assert.Equal(t, 0, (len(set.body)+exportSetHeaderLen)%4)
+			if set.id == netflowV9TemplateSet {
+				count += 2
+				continue
+			}
+			n := len(set.body) / e.templates[0].length
+			count += n
+			records += n
+		}
+		assert.Equal(t, count, int(binary.BigEndian.Uint16(msg[2:])))
+	}
+	assert.Equal(t, 200, records)
+}
+
+func TestNewExporterInvalid(t *testing.T) {
+	_, err := newExporter(config.FlowsExportConfig{Enabled: true})
+	assert.Error(t, err)
+
+	_, err = newExporter(config.FlowsExportConfig{
+		Enabled:    true,
+		Protocol:   "sflow",
+		Collectors: []string{"127.0.0.1:4739"},
+	})
+	assert.Error(t, err)
+}
@@ -31,6 +31,11 @@ type biFlow struct {
 	dir        flowDirection
 	stats      [2]*flowStats
 	prev, next *biFlow
+
+	ingress string // interface the first packet has been captured on
+
+	// counters sent to the flow collectors, per direction
+	exported [2]exportedCounts
 }
 
 type Flow struct {
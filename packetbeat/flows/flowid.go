@@ -26,7 +26,8 @@ import (
 
 type FlowID struct {
 	rawFlowID
-	flow Flow // remember associated flow for faster lookup
+	flow    Flow   // remember associated flow for faster lookup
+	ingress string // interface the packets are captured on
 }
 
 type rawFlowID struct {
@@ -39,18 +40,20 @@ type flowIDMeta struct {
 	flags FlowIDFlag
 
 	// offsets into flowID
-	offEth        uint8
-	offOutterVlan uint8
-	offVlan       uint8
-	offOutterIPv4 uint8
-	offIPv4       uint8
-	offOutterIPv6 uint8
-	offIPv6       uint8
-	offICMPv4     uint8
-	offICMPv6     uint8
-	offUDP        uint8
-	offTCP        uint8
-	offID         uint8
+	offEth          uint8
+	offOutterVlan   uint8
+	offVlan         uint8
+	offOutterIPv4   uint8
+	offIPv4         uint8
+	offOutterIPv6   uint8
+	offIPv6         uint8
+	offICMPv4       uint8
+	offICMPv6       uint8
+	offUDP          uint8
+	offTCP          uint8
+	offID           uint8
+	offOutterTunnel uint8
+	offTunnel       uint8
 
 	cntEth  uint8
 	cntVlan uint8
@@ -72,8 +75,37 @@ const (
 	UDPFlow
 	TCPFlow
 	ConnectionID
+	OutterTunnelFlow
+	TunnelFlow
 )
 
+// TunnelType identifies the encapsulation protocol a tunnel ID has been
+// taken from.
+type TunnelType uint8
+
+const (
+	TunnelGRE TunnelType = iota + 1
+	TunnelERSPAN
+	TunnelVXLAN
+	TunnelGeneve
+	TunnelMPLS
+)
+
+var tunnelTypeNames = map[TunnelType]string{
+	TunnelGRE:    "gre",
+	TunnelERSPAN: "erspan",
+	TunnelVXLAN:  "vxlan",
+	TunnelGeneve: "geneve",
+	TunnelMPLS:   "mpls",
+}
+
+func (t TunnelType) String() string {
+	if name, ok := tunnelTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 const (
 	SizeEthAddr    = 6
 	SizeVlan       = 2
@@ -90,9 +122,10 @@ const (
 	SizeTCPFlowID    = 2 * SizePortNumber // source + dest port
 	SizeUDPFlowID    = 2 * SizePortNumber // source + dest port
 	SizeConnectionID = 8                  // 64bit internal connection id
+	SizeTunnelFlowID = 1 + 4              // tunnel type + 32bit tunnel id
 
 	SizeFlowIDMax int = SizeEthFlowID +
-		2*(SizeVlanFlowID+SizeIPv4FlowID+SizeIPv6FlowID) +
+		2*(SizeVlanFlowID+SizeIPv4FlowID+SizeIPv6FlowID+SizeTunnelFlowID) +
 		SizeICMPFlowID +
 		SizeTCPFlowID +
 		SizeUDPFlowID +
@@ -104,18 +137,20 @@ const offUnset uint8 = 0xff
 var flowIDEmptyMeta = flowIDMeta{
 	flags: 0,
 
-	offEth:        offUnset,
-	offOutterVlan: offUnset,
-	offVlan:       offUnset,
-	offOutterIPv4: offUnset,
-	offIPv4:       offUnset,
-	offOutterIPv6: offUnset,
-	offIPv6:       offUnset,
-	offICMPv4:     offUnset,
-	offICMPv6:     offUnset,
-	offUDP:        offUnset,
-	offTCP:        offUnset,
-	offID:         offUnset,
+	offEth:          offUnset,
+	offOutterVlan:   offUnset,
+	offVlan:         offUnset,
+	offOutterIPv4:   offUnset,
+	offIPv4:         offUnset,
+	offOutterIPv6:   offUnset,
+	offIPv6:         offUnset,
+	offICMPv4:       offUnset,
+	offICMPv6:       offUnset,
+	offUDP:          offUnset,
+	offTCP:          offUnset,
+	offID:           offUnset,
+	offOutterTunnel: offUnset,
+	offTunnel:       offUnset,
 
 	cntEth:  0,
 	cntVlan: 0,
@@ -153,6 +188,13 @@ func (f *FlowID) Reset(buf []byte) {
 	f.flow.stats = nil
 }
 
+// SetIngressInterface sets the name of the interface new flows are
+// reported to be captured on. The name is not part of the flow ID and is
+// not modified by Reset.
+func (f *FlowID) SetIngressInterface(name string) {
+	f.ingress = name
+}
+
 func (f *FlowID) AddEth(src, dst net.HardwareAddr) {
 	debugf("flowid: add eth")
 	f.addID(&f.offEth, EthFlow, src, dst, flowDirUnset)
@@ -232,6 +274,20 @@ func (f *FlowID) AddTCP(src, dst uint16) {
 	f.addWithPorts(&f.offTCP, TCPFlow, src, dst)
 }
 
+// AddTunnel adds the identifier of a tunnel the packet has been
+// encapsulated in, like the VXLAN VNI or a GRE key. For nested tunnels the
+// two innermost tunnel IDs are kept.
+func (f *FlowID) AddTunnel(typ TunnelType, id uint32) {
+	debugf("flowid: add tunnel")
+	var tmp [SizeTunnelFlowID]byte
+	tmp[0] = byte(typ)
+	binary.LittleEndian.PutUint32(tmp[1:], id)
+	f.addMultLayerID(
+		&f.offTunnel, &f.offOutterTunnel,
+		TunnelFlow, OutterTunnelFlow,
+		tmp[:], nil, flowDirUnset)
+}
+
 func (f *FlowID) AddConnectionID(id uint64) {
 	debugf("flowid: add tcp connection id")
 
@@ -364,6 +420,10 @@ func (f *rawFlowID) Get(i FlowIDFlag) []byte {
 		return f.UDP()
 	case TCPFlow:
 		return f.TCP()
+	case OutterTunnelFlow:
+		return f.OutterTunnel()
+	case TunnelFlow:
+		return f.Tunnel()
 	default:
 		return nil
 	}
@@ -391,6 +451,8 @@ func (f *rawFlowID) Serialize() []byte {
 		f.cntEth,
 		f.cntVlan,
 		f.cntIP,
+		f.offOutterTunnel,
+		f.offTunnel,
 	})
 	enc.Write(f.flowID)
 	enc.Close()
@@ -474,6 +536,29 @@ func (f *rawFlowID) ConnectionID() []byte {
 	return f.extractID(f.offID, SizeConnectionID)
 }
 
+func (f *rawFlowID) OutterTunnel() []byte {
+	return f.extractID(f.offOutterTunnel, SizeTunnelFlowID)
+}
+
+func (f *rawFlowID) OutterTunnelID() (TunnelType, uint32, bool) {
+	return tunnelID(f.OutterTunnel())
+}
+
+func (f *rawFlowID) Tunnel() []byte {
+	return f.extractID(f.offTunnel, SizeTunnelFlowID)
+}
+
+func (f *rawFlowID) TunnelID() (TunnelType, uint32, bool) {
+	return tunnelID(f.Tunnel())
+}
+
+func tunnelID(b []byte) (TunnelType, uint32, bool) {
+	if b == nil {
+		return 0, 0, false
+	}
+	return TunnelType(b[0]), binary.LittleEndian.Uint32(b[1:]), true
+}
+
 func (f *rawFlowID) extractID(off, sz uint8) []byte {
 	if off == offUnset {
 		return nil
@@ -297,3 +297,27 @@ func TestSimilarWithOffsets(t *testing.T) {
 	assert.Equal(t, id1.flags, id2.flags)
 	assert.NotEqual(t, id1.flowIDMeta, id2.flowIDMeta)
 }
+
+func TestFlowIDTunnels(t *testing.T) {
+	id := newFlowID()
+	id.AddIPv4(net.IP{10, 0, 0, 1}, net.IP{10, 0, 0, 2})
+	_, _, ok := id.TunnelID()
+	assert.False(t, ok)
+
+	id.AddTunnel(TunnelMPLS, 100)
+	id.AddTunnel(TunnelMPLS, 200)
+	id.AddTunnel(TunnelVXLAN, 42)
+	assert.Equal(t, OutterTunnelFlow|TunnelFlow, id.Flags()&(OutterTunnelFlow|TunnelFlow))
+
+	// only the two innermost tunnels are kept
+	typ, vni, ok := id.TunnelID()
+	assert.True(t, ok)
+	assert.Equal(t, TunnelVXLAN, typ)
+	assert.Equal(t, uint32(42), vni)
+
+	typ, label, ok := id.OutterTunnelID()
+	assert.True(t, ok)
+	assert.Equal(t, TunnelMPLS, typ)
+	assert.Equal(t, uint32(200), label)
+	assert.Equal(t, "mpls", typ.String())
+}
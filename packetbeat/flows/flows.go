@@ -30,6 +30,7 @@ type Flows struct {
 	worker     *worker
 	table      *flowMetaTable
 	counterReg *counterReg
+	exporter   *exporter
 }
 
 // Reporter callback type, to report flow events to.
@@ -68,9 +69,21 @@ func NewFlows(pub Reporter, watcher procs.ProcessesWatcher, config *config.Flows
 
 	counter := &counterReg{}
 
-	worker, err := newFlowsWorker(pub, watcher, table, counter, timeout, period)
+	var export *exporter
+	if config.Export.Enabled {
+		export, err = newExporter(config.Export)
+		if err != nil {
+			logp.Err("failed to configure flows export: %v", err)
+			return nil, err
+		}
+	}
+
+	worker, err := newFlowsWorker(pub, watcher, table, counter, export, timeout, period)
 	if err != nil {
 		logp.Err("failed to configure flows processing intervals: %v", err)
+		if export != nil {
+			export.Close()
+		}
 		return nil, err
 	}
 
@@ -78,17 +91,21 @@ func NewFlows(pub Reporter, watcher procs.ProcessesWatcher, config *config.Flows
 		table:      table,
 		worker:     worker,
 		counterReg: counter,
+		exporter:   export,
 	}, nil
 }
 
+// Lock must be held by a producer while updating flows. Multiple producers
+// can hold the lock at the same time, but the flows of a connection must
+// always be updated by the same producer.
 func (f *Flows) Lock() {
 	debugf("lock flows")
-	f.table.Lock()
+	f.table.RLock()
 }
 
 func (f *Flows) Unlock() {
 	debugf("unlock flows")
-	f.table.Unlock()
+	f.table.RUnlock()
 }
 
 func (f *Flows) Get(id *FlowID) *Flow {
@@ -106,6 +123,9 @@ func (f *Flows) Start() {
 
 func (f *Flows) Stop() {
 	f.worker.Stop()
+	if f.exporter != nil {
+		f.exporter.Close()
+	}
 }
 
 func (f *Flows) NewInt(name string) (*Int, error) {
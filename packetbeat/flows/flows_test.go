@@ -21,6 +21,7 @@ package flows
 
 import (
 	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -160,3 +161,47 @@ func TestFlowsCounting(t *testing.T) {
 	assert.Equal(t, nil, stat["float1"])
 	assert.Equal(t, 1.4142, stat["float2"])
 }
+
+func TestFlowsConcurrentProducers(t *testing.T) {
+	logp.TestingSetup()
+
+	module, err := NewFlows(nil, procs.ProcessesWatcher{}, &config.Flows{})
+	assert.NoError(t, err)
+
+	// every producer registers the counters it uses
+	packets, err := module.NewUint("packets")
+	assert.NoError(t, err)
+	shared, err := module.NewUint("packets")
+	assert.NoError(t, err)
+	assert.Equal(t, packets.i, shared.i)
+
+	const producers, updates = 4, 100
+	var wg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func(port byte) {
+			defer wg.Done()
+
+			id := newFlowID()
+			addAll(
+				addIP([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}),
+				addTCP([]byte{port, 0}, []byte{80, 0}),
+			)(id)
+			for n := 0; n < updates; n++ {
+				module.Lock()
+				packets.Add(module.Get(id), 1)
+				module.Unlock()
+			}
+		}(byte(i + 1))
+	}
+	wg.Wait()
+
+	count := 0
+	for table := module.table.tables.head; table != nil; table = table.next {
+		for flow := table.flows.head; flow != nil; flow = flow.next {
+			count++
+			assert.Equal(t, uint64(updates), flow.stats[flowDirForward].uints[packets.i])
+		}
+	}
+	assert.Equal(t, producers, count)
+}
@@ -22,18 +22,20 @@ import (
 	"time"
 )
 
-// Table with single produce and single consumer workers.
-// Producers will access internal table only and append new tables
-// to tail list of known flow tables for consumer to iterate concurrently
-// without holding locks for long time. Consumer will never touch the table itself,
-// but only iterate the known flow tables.
+// Table with multiple producers and a single consumer worker.
+// Producers hold the read lock while processing a packet. They will access
+// internal table only and append new tables to tail list of known flow tables
+// for consumer to iterate while holding the write lock. Consumer will never
+// touch the table itself, but only iterate the known flow tables.
 //
 // Note: FlowTables will not be released, as it's assumed different kind of
 //       flow tables is limited by network patterns
 type flowMetaTable struct {
-	sync.Mutex
+	sync.RWMutex
 
-	table map[flowIDMeta]*flowTable // used by producer worker only
+	// protects table and tables against concurrent producers
+	mutex sync.Mutex
+	table map[flowIDMeta]*flowTable // used by producer workers only
 
 	tables flowTableList
 
@@ -65,12 +67,15 @@ type flowList struct {
 }
 
 func (t *flowMetaTable) get(id *FlowID, counter *counterReg) Flow {
+	t.mutex.Lock()
 	sub := t.table[id.flowIDMeta]
 	if sub == nil {
 		sub = &flowTable{table: make(map[string]*biFlow)}
 		t.table[id.flowIDMeta] = sub
 		t.tables.append(sub)
 	}
+	t.mutex.Unlock()
+
 	return sub.get(id, counter)
 }
 
@@ -86,6 +91,7 @@ func (t *flowTable) get(id *FlowID, counter *counterReg) Flow {
 		debugf("create new flow")
 
 		bf = newBiFlow(id.rawFlowID.clone(), ts, id.dir)
+		bf.ingress = id.ingress
 		t.table[string(bf.id.flowID)] = bf
 		t.flows.append(bf)
 	} else if bf.dir != id.dir {
@@ -36,6 +36,9 @@ type flowsProcessor struct {
 	table    *flowMetaTable
 	counters *counterReg
 	timeout  time.Duration
+
+	// exporter sends the flow records to collectors, nil if disabled
+	exporter *exporter
 }
 
 var (
@@ -48,6 +51,7 @@ func newFlowsWorker(
 	watcher procs.ProcessesWatcher,
 	table *flowMetaTable,
 	counters *counterReg,
+	exporter *exporter,
 	timeout, period time.Duration,
 ) (*worker, error) {
 	oneSecond := 1 * time.Second
@@ -89,6 +93,7 @@ func newFlowsWorker(
 		watcher:  watcher,
 		counters: counters,
 		timeout:  timeout,
+		exporter: exporter,
 	}
 	processor.spool.init(pub, defaultBatchSize)
 
@@ -188,6 +193,9 @@ func (fw *flowsProcessor) execute(w *worker, checkTimeout, handleReports, lastRe
 	}
 
 	fw.spool.flush()
+	if fw.exporter != nil {
+		fw.exporter.flush()
+	}
 }
 
 func (fw *flowsProcessor) report(
@@ -201,6 +209,10 @@ func (fw *flowsProcessor) report(
 
 	debugf("add event: %v", event)
 	fw.spool.publish(event)
+
+	if fw.exporter != nil {
+		fw.exporter.add(flow, uintNames)
+	}
 }
 
 func createEvent(
@@ -235,6 +247,9 @@ func createEvent(
 		"flow":  flow,
 		"type":  "flow",
 	}
+	if f.ingress != "" {
+		fields.Put("observer.ingress.interface.name", f.ingress)
+	}
 	network := common.MapStr{}
 	source := common.MapStr{}
 	dest := common.MapStr{}
@@ -258,6 +273,20 @@ func createEvent(
 		putOrAppendUint64(flow, "vlan", vlanID)
 	}
 
+	// add tunnel IDs
+	tunnel := common.MapStr{}
+	if typ, id, ok := f.id.OutterTunnelID(); ok {
+		putOrAppendString(tunnel, "type", typ.String())
+		putOrAppendUint64(tunnel, "id", uint64(id))
+	}
+	if typ, id, ok := f.id.TunnelID(); ok {
+		putOrAppendString(tunnel, "type", typ.String())
+		putOrAppendUint64(tunnel, "id", uint64(id))
+	}
+	if len(tunnel) > 0 {
+		flow["tunnel"] = tunnel
+	}
+
 	// ipv4 layer meta data
 	if src, dst, ok := f.id.OutterIPv4Addr(); ok {
 		srcIP, dstIP := net.IP(src), net.IP(dst)
@@ -342,6 +371,10 @@ func createEvent(
 		stats := encodeStats(f.stats[0], intNames, uintNames, floatNames)
 		for k, v := range stats {
 			switch k {
+			case "tcpFlags":
+				// only sent to flow collectors
+			case "tcp.handshake_rtt":
+				flow.Put("tcp.rtt.us", v)
 			case "icmpV4TypeCode":
 				if typeCode, ok := v.(uint64); ok && typeCode > 0 {
 					network["transport"] = "icmp"
@@ -357,7 +390,8 @@ func createEvent(
 					communityID.ICMP.Code = uint8(typeCode)
 				}
 			default:
-				source[k] = v
+				// counters named like tcp.retransmissions are nested
+				source.Put(k, v)
 			}
 		}
 
@@ -373,9 +407,11 @@ func createEvent(
 		stats := encodeStats(f.stats[1], intNames, uintNames, floatNames)
 		for k, v := range stats {
 			switch k {
-			case "icmpV4TypeCode", "icmpV6TypeCode":
+			case "icmpV4TypeCode", "icmpV6TypeCode", "tcpFlags":
+			case "tcp.handshake_rtt":
+				flow.Put("tcp.rtt.us", v)
 			default:
-				dest[k] = v
+				dest.Put(k, v)
 			}
 		}
 
@@ -429,6 +465,12 @@ func createEvent(
 				dest["process"] = p
 				fields["process"] = p
 			}
+			// The destination process takes priority, as for process.
+			if proc.Dst.ContainerID != "" {
+				fields["container"] = common.MapStr{"id": proc.Dst.ContainerID}
+			} else if proc.Src.ContainerID != "" {
+				fields["container"] = common.MapStr{"id": proc.Src.ContainerID}
+			}
 		}
 	}
 
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/elastic/go-lookslike/isdef"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/elastic/go-lookslike"
 
@@ -129,3 +130,45 @@ func TestCreateEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateEventIngressInterface(t *testing.T) {
+	id := newFlowID()
+	id.AddIPv4([]byte{203, 0, 113, 3}, []byte{198, 51, 100, 2})
+	id.AddUDP(5353, 53)
+
+	bif := newBiFlow(id.rawFlowID, time.Now(), flowDirForward)
+	event := createEvent(procs.ProcessesWatcher{}, time.Now(), bif, false, nil, nil, nil)
+	_, err := event.Fields.GetValue("observer")
+	assert.Error(t, err)
+
+	bif.ingress = "eth1"
+	event = createEvent(procs.ProcessesWatcher{}, time.Now(), bif, false, nil, nil, nil)
+	name, err := event.Fields.GetValue("observer.ingress.interface.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "eth1", name)
+}
+
+func TestCreateEventTCPMetrics(t *testing.T) {
+	id := newFlowID()
+	id.AddIPv4([]byte{203, 0, 113, 3}, []byte{198, 51, 100, 2})
+	id.AddTCP(38901, 80)
+
+	bif := newBiFlow(id.rawFlowID, time.Now(), flowDirForward)
+	names := []string{"bytes", "tcp.retransmissions", "tcp.handshake_rtt"}
+	bif.stats[0] = &flowStats{uintFlags: []uint8{7}, uints: []uint64{100, 2, 1500}}
+	bif.stats[1] = &flowStats{uintFlags: []uint8{3}, uints: []uint64{60, 1, 0}}
+
+	event := createEvent(procs.ProcessesWatcher{}, time.Now(), bif, false, nil, names, nil)
+	for field, expected := range map[string]interface{}{
+		"source.tcp.retransmissions":      uint64(2),
+		"destination.tcp.retransmissions": uint64(1),
+		"flow.tcp.rtt.us":                 uint64(1500),
+	} {
+		v, err := event.Fields.GetValue(field)
+		if assert.NoError(t, err, field) {
+			assert.Equal(t, expected, v, field)
+		}
+	}
+	_, err := event.Fields.GetValue("source.tcp.handshake_rtt")
+	assert.Error(t, err)
+}
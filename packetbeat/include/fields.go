@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded zlib format compressed contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsvftXIze2P/r7/BW6zFqXJscuzLNp7nfu/RIgCev0g2noyZykZ2G5SrY1lKWKpMI4557//bu0t6RSPUwD3TidhD5nCNhVemxJW/v52X8lPx69f3v29vv/i5xIIqQhLOOGmCnXZMxzRjKuWGryRY9wQ+ZUkwkTTFHDMjJaEDNl5PT4ghRK/pulpveXv5IR1SwjUsDnN0xpLgXZSrYGySD5y1/Jec6oZuSGa27I1JhCH25uTriZlqMklbNNllNteLrJUk2MJLqcTJg2JJ1SMWHwkW13zFme6eQvf+mTa7Y4JCzVfyHEcJOzQ/vAXwjJmE4VLwyXAj4i37l3iHv78C+EENIngs7YIVn/34bPmDZ0VqzDF4Tk7IblhySVirlPFPul5Iplh8So0n9oFgU7JBk1/oNaz+sn1LBN2zaZT5kAirEbJgyRik+4sJRM/uLevLRk5xoeysJ77NYomlqKj5WcVS30bNc8pXm+IIoVimkmDBcT6MgPLnTXuXZaliplof+zcfQCfkemVBMh/WhzEsjUw11yQ/OSwaDDYApZlLntxjXrOhtzpQ283xiWYinjN9WoCl6wnItqXO8d3XHlyFgqQvMcW9CJXy92S2eF3QDr24Ot/f5gr7+9czk4OBzsHe7sJgd7Oz+t15Y8pyOW66WLjesqR3Zju4/wjyv85pot5lJlnYt+XGojZ/aRTaRPQbnSYT7HVJARI6U9KUYSmmVkxgwlXIylmlHbiP3czY9cTGWZZ3A6UykM5YIIpu0y4oBgU9t/R3mO66EJVYxoIy3RqPZjDQM49aQaZjK9ZmpIqMjI8PpADx1ZOqj632u0KHKewvjWDsnaWMr+iKq1Hllj4sZ+UiiZlSl8/z91Ys+Y1nTCPkFtw25NJ0G/k4rkcuJIArvEtej2hCMMfmWfdF/3iCwMn/Ffw260u+eGs7k9KVwQCk/bD5gK9LHdaaPK1JSWgrmcaDLnZipLQ6ioDkNtDD0izZQpx15IioucSpFSw0R0Hoy0g5gRSqbljIq+YjSjo5wRXc5mVC2IjM5hfDhnZW54kYe5a8JuubaMYMoWVYezERcsI1wYSaQIT7eX9AeW55L8KFWe1RbL0MmnzkV99/OJkIpd0ZG8YYdka7C927WKr7k2dm7uXR0OgKETwmg69TNu7ryf442Fu2177V/1DUYnTPi94y6Co+ijiZJlcUi2O3fX5ZTh+2Ht3DFzjJgSOrJLjyxzbOb2dFlma+y9OHZtUrGwK0HtKc1zey57JGMGf5GKyJFm6sYuGm5iaTffVNr1k4oYes00mTGqS8Vm9gHPdPxjzdOrCRdpXmaMfMuo5RMwX01mdEForiVRpbBvu36VTuAehIkm37ipuib11DLUEat4N+x3O37Kc+13JBJJlULY0yORQHZs0fyUa3I+ZSrm9FNaFMzuSztZOL9hqnALWAKIsEfHUhohjV19P91DcoYdplaCkGOcNpxne0B71QgTuyWIE2FGjIYtZc/10fkbEGbcPVufkltzWhSbdjI8ZQmpdkfMnzPJPPGAMYOAQvgY9wvXxN7GxEyVLCdT8kvJStu+XmjDZprk/JqR/6Tja9oj71nGcYcUSqZMay4mflnc47pMp5aPv5YTbaieEpwHuQCCB6Lh0YSt7skYiTrxaRmVPM8Sz8fC113nfdmZv/Pct8/Y6a1hIrOXvO26Rsqx2xG4dn8JTVyiFGrZu5WLhGvAyHA+qVh0tAdnkOJCoBQTmrRno1DyhmesZ8UaXbCUj3lK8G0Qn7gOgl6gbI0fzZhRPLX7Kgi4L5P9ZEBe0Fm2v7vRIzkfwdf48c/7dHuHHYwPxjuD8d5gsDWiO7u7bJft7WYH2at0dLCdjrYGL9OoMwLzMmR7sD3oD7b7gz2yvXO4NTjcGpD/GAwGA/Lh8vhfNWqPaZmbK6DXIRnTXLPWsrNiymZM0fyKZ+1FZ26JvvDC+z4JzyzPHHOmkJ9w7c7VCz6GiwpuM73R3ALcyj5qBrKlVwRoqqS2C6UNVZbBjkpDhriDeDaE42kPZvcKHtBduxDjFoG6yPLlz8IHwX+xQvPD6REEN8vJkP/Be3OQEEeMALfjSzaum3bWmrb9uYqJO7kY2HN8pbRWXBOKT+F9ipLNhN8wEIypcK/h0+7rKcuLcZlbHmw5ipt1aNjMJfnO3QeEC22oSJ2g3LjQtO0YbjW7qZyURiopjRVUAacJbXNNBGMZKr7zKU+n7a7CxZDKme3MKnPRvM/Glh/5iwumijea/0iODRMkZ2ND2Kwwi+4lHkvZWl27cKtY3ctFccey+kvUdkRoPqcLTbSxPwPNrRKip34r43I7nRDftQJjUpFMBFEgULt6Fo+E62jEqkdANuLj2oaoVrK5MWqbYkbTqVVMu0nfbMvT310SK1iCf7jrqL4IHWPdTwbJoK/S7boMrRsCdGmkkDNZanIBksg9hOkjQWj1Ggow5MXRxQYeaicau0GmUggGpo0zYZgSzJBzJY1MZSWTvDg73yBKlnA3F4qN+S3TpBQZQ6nBygJK5rY5yzOlIjOpGBHMzKW6JrJgihqprLwd2hyxKc3H9hVKrLiVM0KzGRdcG3uyb7x0b1vL5AxVAWqIM7LgRGYzKXokzRlV+eIv1XqMQfsKI5Y5Txeg5UwZCDF2msmDJTZRzkZBtib3uLRzGcTI1iK5CwjbJDTPZQoyvxtlawGdEBw+rgkqbo1dYy+OLt5ukBI6yBfVHadRwwvLgqfqrEGP2ibd2tvaf9UihFQTKvivwICT7ovrSwkyoG9fNVckHkxksKj31PGFlWF0UzILm2a58NZYu3fR/GEUnbT7Xkq7q1+/Pq6d8DTnLTX5OP7sTj35yL1vj7Lf51S7jc0Nt+cMD5VfZnfAnZTvFXvUfxWbUJWBVmSVHil0L3oeNaIRRwM0l4LmZJzLOVEslSrTNVvN5fG5axXvzGqYrbHZD+zj0cjgaGsmgjZsn7n4r7ekoOk1My/0RgK9oHmncAyq1RVaV62QWuvUq/EKtAqm7TicmumpZBQVmsJgEnIhZywofqVGFdowNSNr3mQs1VplSlJs7DmhG4poTFDj8XVfOxMHruyIBRUfTBwRAdyxtsMSE7/MVRfx+NFs4zaS78Den6UuLUFcq5VtgQs7vH+XAhcATA1oPPCm/Y7GKvoKaVpNWpEP16sPXMBbT4PNFdvb9P0EizkcJBQiaZYRzWZUGJ7CvcJujZM32S1qHj0U77zdSwep00hyw+10+a+sshvZiTIFuqrmpqRuOc7GZCFLFfoY0zxYov1dYznyRKpFzz7qxSJteJ4TJnSpnGzszPRWdMqYNnZ7WJJago15ngcmSItCyUJxali+eITFgGaZYlqvSmuEU4CGI7fn3ACcZBbYz2zEJ6Usdb7AXQ7vhGbnllxazhi4LUjONRhwz857hPqbXSpC7YV1S7S0+ych5L8qigdJNZbV7PoqOvdj8udhmLgPhkjGuvwrCDeReJuVaEzHa3eY8GJohzJMcFjDHslYwUTmFBPUKqSoBgE2rEgUraS45FlYqGjxLC88Ql6IxzxaGNZx7juVl8Y+Qqtau4naAL+1X6K5NDg43Zl3WwxZdveyH+y2BowHaAXqlrtTsL+kNY4Jk0nKzeJqReaVY6uxLF3hN1ZPYpGxtzZMKQwXTJirVGarGOvlXPZzZgyzl2DG6g7sMJp1vXw+b49qbd/D+lmf6IoW5W1k6wqdd09IKjMlRzOmeEqXDL4URi2uuJarWqdj7JKcXbyDheoc+fHRncNd1fZ3Q126Y46poFk3ZeFOu7+NZsLkVSG5MMvG8lqKCTdlhoJZTg380Tmq9f8mazk41Psvd5L9rd2DnUGPrOXUrB2S3b1kb7D3auuA/E/7orcDf9oLrWG21kz1veAVfYUqnydhjzgzHIrhckwmiooyp4qbRSxBLUhqJTnQOyJJ6dgLSMH4iaeHKxSpU2bFAKd9jXMplZMmemDUm/JKt6muHBxeTorpQnP7i/fepp5n6mgIb6WJwlnAS83RrDUDyWfCpJ9ttylwJLWRop+lnWtWSG1ovqoTvH4O3SGbpVrLlFf+XYyWcFOpCPAPF0RS6QzOSRcccsHJPGLkWsi5sBoiJXZq0JFU5Kezc1Kboz0KIJDfULUgc55ZmQ+ufMcp0LUHv3bT9dXuYHfwGLav2IRLsUrG+R56/BTf7P+9LW9F410R53RjXco4/16yEevey1av+jXWSp5QZJgyjB77Fey849rm7QUf+dnR26PouaWTchft5pGagNhBN78tmZD66oirhrB6j03Gi3tSoPZgbX5n50Gf9PICypcvzs5vdu2JOju/2d9oy5kzmq6Cj7w5Ou4eYMPNI6QJfv0ZdUL9+++OycvB7jZERmBQJ8sOyalV82RqmCEvwJzBdY8c9Ee8ulmt7rCBTnknIrpIwbkkP5dFwVRKNfsXmbJbmrGUz2hOMj7hBrxoVpy0I4WwuNCmGz52bBmXIKXQfOLCptiEqYRclClEY9y4B11QHXr/cAyVMDFdFFO25DYYDPqDQX/vFH7u9Ld3WisoqEm6dtDSe3z5Llq/VFRotImdnduZOgsRBua+PboM5lbygiWTxPkk7A0RG4LBtujdFTWXfLgYIwsjMYqCm0tMSC5pRkY0pyKFe3rMFZvTPEeLrpKlvb477BaWCIVU5vFmC69uaqP4cntGTCHb3++JRmjhfKQ2XqPCObb0aL17uz22zrV7iJng7nU7d2sVM57mGOy9qQ1TLLtaZgV4GtnYMr4pn0yZNtEgPD1xLD2YYFGwzE9DlyNvPAgtf1fFLqCMGzXnLJZWFlsbS5m455JUztYsi1yLP2gGVWAksguWyJhhagaSfKFYyrWVxUAkpGglhQg1iMYuRzlPiS7HY34bWoRnXkyNKQ43N/ERfCKRarKRkEu1ANYrUYi85VZyRgFytCCaz4p8QQy9jtcb7nOqDbB2DENGeVFIQ8AQOGd5DrO/fH1SRcWtpTIpr9e6mW9EkdZuCeRf5S4JncKBCerTuLTs4peS5nzMq6XG6A+M84zUmTz3Wwh0F8JuU1aYKhwTXqt85K1jkUD0BCUFVYZHLhrSGgEwJI592f+571HqqnQ8UMZKu1a255SKykdD6vutF1EgRG23JjRiuZx3b//us1I/TzFt1+bzecKoNsls4VrADYMnhmqzFsWU4CBcK1Oqq6BrmCuIPqGbSupc0+VoO9HlaKt2KHu1zV0ND5Uo5wzw0YxVG2s9PItC2kuD5xBIwBSXSwK77CQeKrEaWVzBlH4DTsnGY3sp3jA7CreJHGVesMvXJxs9VC6DZlmtSWgX2U3PO3mBcdjt7PdRdICSNlNt9huajULH7ArCHvl9c1PgpMsYabUS92ep8F1rP5WaqWS1Wym2rWKcgFTofbeDwYCjGQNPkxwvu2KpIK9Pjs4hpBopcRKaivdQWxiDGbMZ5R0B1E8y4Q92VtChV8TaEhAMynLiJcaDP7SvyxJoXVcXEZgE6Q3lOR3lS8wA+YgpQ0650IZFW7dGT3CQfzWbG0azmt2NE19ZsHg7MNrnBuCcfSwmOJE3i5waqzosOQQ49hUayOMVw867BzalerqqXeQoCvzQ9osmVqWY1X9bWRjUMU5BqJBiEafNoZYWbbMPmrlo6yHMimcYvwB/2NkOg2CTSjHGNaV5rU8qsg5ZEaJ8l2zIlQToL4nPRzIuWdSnM9V+lZz2Ymq1cXTPQP4VF91EilgtBVbbTT4l864ggyc5FEdKUUiyhM0OPXufFdiN6+mXHRNZ/3ntmo+ooFcQNbzWI2uKgcYhJle2UZ+c+Am6VlGZsgzJoT4os/rorphMzGoneJ5DTA40CIqbGCsaMlmraaEtEzMRqgMOGQnu9878uzF5U+U+cR0nTlBBTo+3Ufu0x3rMTDplGnx2f6naJ9xol/RYDdSyhHoGby3pkusQeF8fgmtXlcJlUyo2kyaE6RNZGs0zFvXUHBmOiRKX7Ocn5Bp2YV3wqvM31pONsdGqIchrdJ17A5ptlutqqI5gjwnBS8E5trrreP2yIhz2DXmecUARz0IWr2OXC5Lx8Zip2EwK3lYOGatWaLEMrW+YoMIQJm64kmJW9yNUe+7ox4vQOc96PlAJzgd59/57cpZhdi0E55ZNzt2twezv7798+fLg4ODVq46YMUfmVbrW24T2rJXmnOo7aBxoG9r+PBqj2NyicsZ1kdNFU1iMbRCI3NHP2M1DTRFOQuc5N4urbm/i01wEUb/oLeQ+0BK4DjCzis113gWl7jOqTX+r7R/1uUSrO7BnPtfs7MTfYjAHzz67JsD7W9s7u3v7Lw9eDegozdh4sHwmKzwTYS5xtmD3bCJHKHzRnfD2JKN847l9lPv2SZKb7WTGMl62LdIOSOc3YfGu75h5djGLGms4D+/0yNGvVsSoPlmSPL3ou44eyiU8bX4bvuypg/EE96ULctM6ZZaz0NmCPJ42Nzx7UMj3Fwtq9OSBASSeIjEQDZ3rHqGWCD0ySYteTe+QCt31NJcpo6Jbc5jr1pQxRGZFE3YRMp9xPbTG7/Elfpvt7CVTn10coytkXFtNpuR66p/TDckYYEkqGcRbYhAlBwQQvyF6hE1AwLDawY0mr+lslNEe+f74nHx/fEpuqpU9KgpyKiZchCPzjzf2Ffu5Q7roOni0KAhzr9nf3ZB7bqaqFD0ypmpCDeuRHLrvPn743YMUN5mxK80nglr1qqHByYyRi8Z3d6lyl1OmWROBpmYtAX1oxAVVCwznC13rhyeUIrjAPU0WIylzRsWyjfUtfg3GJVqAqskxt9aNz24xF73TrVEbVbJ76cy1I8Qndk+uEMzCHqDILOTFcjsQdO8ggksHnkFLgnc4Mx5Qi8yoKMfUIU6NFpZ6HmroholMRjaVy6CJQlhUzm4oBm0cFZbzfvPugkiRL4mhTOUssf2y5LZIk0LJ28WD6W6oKVeWCnaUZdwlgLZPA9xATBl0STM3tG76j8vcowVNIM1ALQojJ4oWU54SppRUugqLjVu9oTnP4vBlqYhRpTa+P/Ka0RtGShHlOI594Bm8Wr3i782q/dDs3Kp6Ip2y9HoZ+Mzp+/fv3l99eHv5/sPF5enJ1ft37y4fvH4lAtmtKLz0ArurCfKBfdVklipZhadK2v1PjqUqpGoG59/Pw83obMW8wXb5JRkEtCeV4wAOsMCzBQeKllT8oLIBPJwvnP79h3/+dPDm4OgfD6az3dbsIXT+xHWyfmGkYmgJjI9Zx9Eh6bQeEvIPe96o8XGfy44cvgdh7IiZ55Mxe2AjtPJdaLIWrGEJWAdKGy2IkTLXDncKvGyAVMTSa7QCIo/opPrjLj5gKl+Q3t33N3oeQTSv3+Q3TGGIEJ1QLqLQE/tGkE+sRBnbmzpZJK0tyj343/0JVglpIEoFRhPks/rHdyIfhIfr2e0u77wF3hnBBTqAMddqGAtx0c6kgmG1uzRqJEKFjeTCKcuLyIEHpmeMWg1Na2fUFgsr+xoeaZv3lw5X6WeriMKztlGHz+hkpVpSrNxC5yF3DwdpNymCs8mOpE8crqGTFY222plurHTSERVSw7q9z5BquLfkbuzb1pjOYCQOSLY1lhUuZUWcehpIsCHg+ViVEQF7s5I+neAFxXW1sToVI8TnrfEze+avtFGMzuoc7cQyg4v4i0+inkZteQ5j6DXDQDouEGbB36WCzR2oYtyHoABnptMpiyxjZ6LrlfrDVWJGiHOJHg2KMMDZ+pnKZlqgvXBLmtdedX67scxzCTi/MyoEU4dk+N/RhMFM/D/92kf2d81M41MISS1oyv5nmASmzAGt1fkrI8xfEBBC5MOUAna38tKBchoBodpHK1Z0ZCDhRDPRCXkjVQOjxW0bjDwcy1JkOGGuA6A6RLyiPyZJ5eYol5NNKvpcmICj2zeyb6asH9w21NA+9trHVerjKv1s33ZjLKQ2/wprfCTIKb6tGVXptLYGqRSaa6ObuFwjml4j0mrGU6ZR3PJGl8ZWgazDma6FdTbed7mb5KRkuDnwRN1YGVqKXrtdTWZWIoTwUtwgtil267emYtoo7nFzarFUnXufaRfBEtB4hx+HPTLctD++sT/+P/tjzf74X/bH/2t//P/2BxmSF7Ctqm2y4Uc87A3BrTn86zDxoPma4ZGpEx3wf5hIMUWTVsryks0wKXnGNpnwUPvYzGZoZjMtlWLCbDoK91PFqGF9oFIyNbP8r41vaMH7BTXTfkEVnemfYxL+6xGyhzuED+DKdrMZKszVJy6mtcpyYs9PBCNrpsjlqCEzQGLWTGjmVTenjn0M7X6MZDbPuJKPooXMPBQTLm4TCplkds0LJWfMTFkJfzGRQRr5MG6ZmRQ3Xm3XwtDAyT3nIA4axDyFzzOs3TC1ArajHtHMxK3OWQCTQpb7cQ0AlXj6cS1E2fl34YmEDDEExn06dCpQ3Cr0GOARsWGqybCDpw6Tj+JbtpCgXTU2cdxkx3WRKm6Y4tRO0mod9qrFeIxhGBv2PaU6OgJxs/GmPPwoCPmGvLGMIEbVH/aH+M1bCSlKKKYLsjUYRJx8reuejtf5odpcuF6eYscfYZSTT9sPfSUg58KvQZtxOFYUuCKcVC4mMRHd7ZR8FG+semFb1oTmitFs4eNCmEvW9wwa8WnpwivWuMtqR6lLNhBy7n0Iro0R04YUdhF4yjDd3JE3IXY4cZM4Moh59+pmnL0ORr6he3uYOPDVUMrCKnaAEQ64XHG79vYBuKbw7vJNXb9X6ns4btNt52FYmngzg4RT8fQ7dnHc5BfY0BXi/oPNQQ/zvt93J68fCSJvmLKkBV69KFiNcbl9FENJ4E2WL3BLsyx2Sq7lcqLXYFOuIaK3XkvIj4yw24KliPtmhQSaZWTNKHtOKkLBW3ohzJTZ9V6rEPGoIuPSlGqJi9d2+hDjRYTp1hD2W1/cKexHj1fCKlp6msIl8wVZ6liOf/EzQSC8TUxFTermCTBLVph8GFxXgwnsuRxWK924JjF0y4G94c61CxzVHqCdUIl+oTvmFoEPfgp2EO/xcagORJyDZQmwXtQBiLgZZKahZKqhbE0MY4zoca7VkYfkA8OXqM9YL+vQk6FOTL8ZYuTIWvke2LuhbW+Sdr6mOr4AjDcu3QAhsXDBiu7nPBXCLBEIJFD6d4UvWINNvBfIYGjV1TD6QiCDoVkAG3wGGXwGGfzdpAP8LkEG44PvEQ9cQbSOwf+mSIPxFfcMN/jwsT7DDbYn9Aw3+Aw3+Aw3+Aw3+Aw3+Aw3+Aw32Brlnw9uMJaHv17MwWiUz8CDXynwIC/AVRDtp08g67EapF6h+I29BE7e/LTRBaoHVyRcKF891iCA1kUxXm72EPlV0ctIu6iWOicMkkKeZtZPjR74QIX6t4UQrPGR5kC+NhzBrKX/P4MJPoMJPoMJPoMJPoMJPoMJPoMJPoMJPnjCz2CCz2CCz2CCz2CCz2CCNco9gwk+gwn+rsEEs9xLED5S8PVr98Gn0oHuAz8B7pacjxRVnGmSLQSdoYHKtZpLmqE1U/psZ/B2ua8hcBiLskO0ryt07KoYS7KmpxQqJ9T6WUMBuEIcAcXOK0QjnxTjNCFmsD3topmDTukTgg79aL4hJziBfs7FtetvQV4MkyzPhxuuyrs3vklBfuQik3NdvX+Bw32HKe4vhomWXe99EPy2D4J7a+6tsdSGscj5qKvBGU3fXTw8zK6OWJL8AUBAGjN6xgT5bTBBmsvwDBHyu4YIaS7nnwMxpDHrZwCR1QCINMn+jCeyYjyRxgI8w4s8kH5WGU5m2d6KOMWbkz3s8lHj1FO6taKBXvxwtPV5I93e21/dWLf39j9vtHuRT/vJR7u3tf05o9UZYw+J/Pis0V6cnJ6eP260KxI5aiZSp4A1L1lImIZYjBktdBcYxZjnDKEx9XU3g7lmSrB8Zzup9PR7k6KgZlW2vu/KPMfZ2E5bdOmY2PHhR6ccf7wAxXZn++NnTZYlkOppWBoBaD71vI/PP5C4W2KomjATTMeWJJ3Tv93ffcQMraBAxWJFkzsLVUyx29bW7fmU64xQA0/xnPUBQurJZO6CJdEgV02JRlz5IwlxTpvB+febuO3qCmrCrn7mrtvPmPV+spO82h8Mkq2Xu1t7j5g+nxWrdF8codMiQHsVUhlXkuL8FE82ORLEjYr0+xCtAo+R2jj7fe+r9zrXmIsJU4XiwgH0QnrhDROEjg1TRDGkpku/9eUqrNzdhzlXMq6iQgcThkaEDZkCKEvWcxmacwz1gERshNUxilbAL3b0mOlel4+VwIepqYHDjLlibAHMCKGCzFQxavqKOWyY7cHW7uZga9MoBN/pz2huFcg+EqdvO+RiAuAwSyJR0/2DwU66y15tb2/ZX7KU7r3a36E029nPsvEjNo9UfMIFza/gEK3QMxdO0Odyz4vzo7O3l8npP08fMX2nu696zq7bz537Wrg6Pt4enXqrOvz+LtjHUYxYuw9xgvNDeDXbOz/eXrgP7nR+uDJKLpPHdn7y9oL8UjI4yABRJfScqepA2e9dKSWnyTMOZzpEi4PpW0xyFtpakEJxCW6OCTMwR9esa/TFMBMacMkO4fnhBkG5Y+E7iVuHyASPx4DuT+e2MSFHHbsNEA8ag3VoLUjOjQHtDXOGhiFcy5CGA+20R4mvDjcek/Bfm/l9NnALk7CFD0LBGRdhSFDh3sL4JJpOXb9EY1V2opgplYh6G6HhTDcB5y+hthMX9hw5WlV59n5RkPaauV7rMAKjBTk9vqjOw3uWSpW5toDPA3eOLdizajr4pe9ckLl96/T4wjXfTCiz62v3HSKjQBQ25CUw+KaO+WGf8/ubHBky44LPylnPfRja9ZMCBLVoryEM09AODlAoWtPgugqc6VnlKTQJMZEpXMocLI12RlSTQmrNRxh0kgEGi5VXIyQcj1Yooy3cGijVJC21kR5NsAvIwM07zenK0CewDAPFnJawSB4IsoLzU8xlDSjYKZ2c9Ozt0ilFpdeeekYBTDJmoRhw6zMA6geJUayD5lMo8dWCiUz7wBwA+AGu5kkVN+hp0ilubA0S//9LqbPKVNbLegip3bVRHZHGlEjBFAQyRzQ7A1MmmJjlmBy/PXpzSgASy0EWyvzGSocRg1tf1wgpNYzYlImwSyTUqIPLWimmC2lJH1xbUSNwthNyFvidkMaHiTbbdDIZGf5SMh3AMIb22mI1YJjackFM9R2h9X7JjHlIcORd+SshuQ/Sj27Ab2ivBCACUKVzZbx5n6bTWoeEjNgYmF4NfIXrlKqMZQn5iSnpwadmYAqfuiAR5M8VYUf1QBrsagkgxfLNvcIKWJfTqvrVZ/Ax2NOt+UwZzZi6Gud0sjpnso/o2SYOMsKyaBwJgZHUytAULDU1ZLFDcnTUI5fHPfL+pEfeH/XI0UmPHJ/0yMm7JU6Hn9fen6z1yNr7ozjY5y4s7idbSjtXzBOL3ZBUuyRFJx0VSk4UneE2RrOoaZwIzO9gCiGT4sYAorXgFboPsh+9xMqwvbXVrp4kiyWZ1U9KGBe7IwV6o1EIRIR351685gISslDuroni+G/GtKYTlsSB/1xDzJKjrWOixrtrsSkU74FqEFrVbPdOGv79w+n7/2rRMPDo30z2UU4CxnsM1a17izm1K2aVNzlc4Y0hxzd1cAo0qsUKKfpgIrJicAwN/QITlHa2ASbMjoJsbe9vxPk+UtfeqC6ZODmZasJ0Sgt7TqlmZGvgk4o1efHx5OQkSuT+lqbXROdUT52C+0spASIptOyaSsglHekeSalSnE6Y05gccnPOo2T8MWNZ3AIAMSuX4PrR9MhHhW99FLBfmfNtP04qCOv/1SVrPidofo0JmmG//MaZmrxmfHEzvyu9ssVcfmcJhfP5fPliPGcOPmcOfsnMwWpj/TYqkNMUPy3RHB0dtXHMvCp/9aVAOY5aFtE8J2fnVuBkUM90GFuLhg0zjf9y6C2rbp/x8ZinZQ4Gu1KzHhmxlJY6eARuqOLMLLxKGO/qGTXaqskR/n5CTm8N4HqH8UVArH6gZsoUQ+xtoZOIUMPQPCL3cxOshxAOCSjcZspmANcTNY2yCL4E3zOqOWQhhBZvuC5pzn9lTkSykvhYLqkqv/7zWmR4svpc9edWl2LnZfbfQpXxfS+Hi3r7DgKJW6Ne4cFaj09W8Lj4IMKs51bESs2wUetX5UKWKqrTEHlsIOhywm+Ytg/Fvp4efBDHZmKRi9BuJnRoZYxjazpt7juKagDeA+P8M7VBNPqXwuMWF0y5+b+QBVrF84VtQksZbiunh+Ix2kjIkcgIdZau0GYLetsewuWeI+9nsdqpYx6d5yEyyoeis2nDP3d6fB//3BtmaD92KPiyas5j8PDqt0uDLhqBZYr9UnLFMqhy+IUjzk6PL0IUBlyiYR2w7I2RCRmyVCfuoSGm6fohVVwVxDXgZaU2WNgXwhry3G21aEf+OGUC1xYWOlVSR1KkL9rQ7zsDtXNA2QFBGHrOJ1OTL6pMrMoSVs0G3o8SsnJmsML0RLmoBpr92w7VgwSlUzajrTWJU+eWbLGtZJAM6jtMKdmo9HcaffSAFDgqIm+rS/2ALb8AK0+g6QcsqzJDQQafcy69omAAqp0zMmYG6xZ45gGZsSm1V9wcr7Zg0cF9wI1m+TiCNRHY+iN8rSsCIQRCo2ms4QbCgd/LsrmCBFwfzLdkVM5Ed8+hNdJiOwjiTX6tjrSh6fUV1JZ4ugv1q8z4vcQ0hhQKOvgySpYDAsG4IEUOvmV2W0sVBFnuC9Ud+VICf9hIvVhpw/wQsHPGoTIIrVSDHIu42b/pDU1yKibJ2zLPzyU4p079K3U2d1PxXs/moo8+WfgPWUxXMVPIrLg1SxLpqlpwWPdU8bTGrqricPZRAui4roiCbtW8aFT5gBIKVR0uZJ+V9vRaBuYJ95yvsRVSrakJXlXQSsWkaoOE0lxyHE3Cteeboj69ym5OyMAmdvYAg9WLqpA4BwkqeQEDz7Xpwy1AT46R23pYBcI3kkohXBDKiJm5VXNoXGqD1otyYGdccIOAunapcqnt3I78Snya3FiKzzUJcRqiRGTSnMwY1aViMyCJL9bUpmz0GGTUGHrNwn6OyRxvj4rGMzaTEAnFtG3GN5dVlHYlUG54YIuGzcDzUiqWkAtXBs9VJ7N38dAVqsRgEydN+Qifelm8cCLiABg3UihwYqhpyRwPiGpqlkx6snyFS6x9Cc4opw95D5IDG6hbigJKNUa/xtE5InqLnEFZYtgalZQ9pcLTO6WGTSSoOb79sOiWkQyBTH2aZa7enT1PfThPDD4a85y50oHZEJ2D3g0WWrTXDKgzUeyUC9rNYeexJTp4qZnqF1RrS9g+hsa1xSE3hdUsE2ZnOhDhsdUGrah8jGPwNTMwABEtEiCEU4MrVRkaQWdz1kK3ZLYhPxky5VgcK05baa5ZJdHiNlgb8QkZlQDmuWbHF7XIWaMAZqRw5IYpxx0bXRy6FR+ShbtcghZCACjGWRDdY6FNyHPkZuEcoyGVHXhcvsB3XRkb7NEuztBHRPv8VxqDsOty5IfVPA2hfa/Nun7BPokF8AolrYqd1hfK3VNuSpGZk2K5OLs0QZ2KKNmtIZdmaqXSRjWAu8X2p7G0nNUrRIEQH6I/XeUujqVcETQiomhUTMlHGpaahcsHKvhFeclOHtakFFFlpR5RbEJVlse7Am4ReJpYuai0v0hF7DRBfQU1Ei8secMU3FYQxO/FMC9Y8nipmA84RpmJnJ10L8/u/u5Be1GQc92Td2Sx7aZJc3dysMFK0XNhQWwT7t65r8Llik1Ru3lVlBWrGEVwCEHoBNZJKvs3GJ4KXkBRsqX7P+NWPkkdiu//hhJWhs4KZDHUxB9VRQrcWGs0hdEytOT64m9RPEPeupbOBJnZ615zU6JdoOeiZ81cktCtO5Qj1mF9wOvD/xmpUY38jJTmqa8CaZXTHAK5UOiKDXQulMWFDuO2rxhKLBLBssCrQHRckwB4kxFuHEdpjGQmBTeyClWtmlhfB6uAXzH7Jx3xnBsoVHDNWEHKAt058FJ84OpUTamr9Nqgo72e8RSmNO/FK1v57Bt4WbF5e3uwtd8f7PW3dy4HB4eDvcOd3eRg7+VPbcP20gq+T5u26bptxE2KGqXQ9QVRD5g4M4Wa3ZU+Z9UWqfyVhSDljbquuZz0nKqay8lGL+48RmJA+WlRlVWLznEqZxFGlT0s8bBhM0DS0Ax4PUAcCWmC8Q+atzJVrW9QNUMM50xmZV6RGzEMEQPJw8lk0kR1/uJmllxYBU2nrFnnNix72UoBfASEd0crXBSlufIPCCqki9WMnpGliR+i+g3Pc770OXSQwh7aWrq5TtxQaqZIAu7cMIT6bkMehytj+QX+zaw6p3zpElM5bmuhuV18zDMp6F1kXkWx6847kRaZeEi04F3XUzX01s3UvJRwj9qL2X/uxbmajcjeXeALliNQbbO2SWyF+YA/UD0lLwqmprTQUJjV2E+iVL4NcOjSubstDZSfoehXrE0sYzMptFGWNGD2AFu3lWa7DtHW9s7u3v7Lg1eDrt+Ovj0++U0NpWcndqZeXfwEet0B3R3vDQZZe8QCS218CTnpMtxTsL8CR6dK8RsfZ8ygVJOiuS8GLFVL6gF5x0PIgYAyrC7BWJdo7G8vwuSLkIqZOC5dSQe5lq3WaxJe3MGMOWQdD4iCiXRWhrADimQOJ19oOu/U7c+EU5btKUUjh1UjtS6h7LSQxM4NtLVekF6cPOA9jFMlhczlpIbjZ685ee1DRrg+rNGK/K/m5KpP/NIP7y1H7CVbg62fHgy7cs27mN1XrMP7YMFHKfFo6EKnsW2o71tp2mkhM8yLM/HXplW61nN2jOiyLzubZuRfNa6UYPB5V7aiTuuAi4T3mhfyTlQzJiXXU0JzpowXsOCc1CyFjRgUvCjrrTVkapwjmSLIv8YobBhBLUMzulTJlIosh+DWKVuAl3NOlbJnsjrCitk5g+G2+hBFHyCIknk1a26qImVTlhcY0acNVCGbMkgNDfkeqZyhMZdQAx7dSZlTFZJRKvVXWaFviSiWd+GfNmS+FQne2GuUygUh/jDHpmTrAiKcGgSKFvK3ssB8fLfdoASFVf+hadSM8nICUkjbelRFZFA4IcJL+yi/H4HYCnf8Rs+fJ2x52IhzrKm0lesGTKL2+bvk4tZq+HtjFevx3l4Q7NYEg4nd/sJwFU7rB3dc7pBWlhgJrOYBvjeGEmgm06uolH7GtZWKMjAeIwQzqOuAHsCy6pBYLcbFfkHoulGc3XhbwfAK12wIOaSlZgC1iCjT8oYpxTO3xWjE9n14lx9uL5RlJ6X27uc5z7OUqgw3pyV29zJesIJsvSKDg8Pt/cOtAXojjk+/Oxz833/d2t79fy5YWlpC4V8EcRJmVNAJU/jZVuIe3Rq4X+oSsOVTGut7YZEWbWRRsMy/hP/VKv3b1iCx/7dFMm3+tp1sJdvJti7M37a2d7ZrqtEdLlFZGqtn/q7uSKudPvaKdPMd+ljUjAlIaogZMF58kR2c+gUB91ylklOeW8Es2LEKpnzKQrgGoawn2skQGYFlnVLaW2lcuhBKsB4ZIKrfTyIfTVazIiNzw+zOxv1trxwPeBddUtUV3CBMz95fzniKVzuvTFHRBKOhH9kbTYTxe1mbYrQn3GWFLL2qS16EueHfLsUUxYzQaBW2jlKnmyOYUioc5yq9PmD6BeMGigW29eha1yE2EO8RyzZonscLfK9lvYkDm9zCxsGF35UK9lNFFuFS7t1FBBZLSOm3AnxVBdWtwxLJydSYboU2ZhuvSDBueN7tzvC9mmnsHTm0gtGwF4sTVCyC5AX6OoeMYT8wkkmG7H5Gr6vV0UzoJSzTkbbFehyEhFqV/+IiRFh2nT+088NpQynDR5hfLLQz+LXdAq/lJDJ5z1AWrMkHVVin10n9FdhRUbjCXV2SNVlxJ5RaLhZ6ZoXPqTFFtgFmfcRvLUcIHR7KSTYgxUOLLxCArVchdfXdFPv+KusflVZrFJONu/DqWsurGNUrw6xafw+9kfl0EQdK+uCONlNrW8U7XOC2NaAn1C1LQQJ3rNhS2h2I4FuveclDuz9C6J47c/j2sM6DXJOB3zhfmnsF6TjsqCGNk3UIsHbyIdSiUZSFzNmIAGa3y/UQjfFETdrTnjHB3TWlGKRLVJpLuEsawwtst7HudpC4WYejXKbXLCOaGzZcspkuIT0FuBhUPmU+e7opz9/TAAAo/zV76ZNvRNch+fD+Ncm5uPYJMXcjkPs929yRvhWsVADBJzyNg1lCYBwyl6NIde4FAaoGXhNZCw5BR7SXvmJ4U8+kANcsXN/1GtvdK+ZxwpCxxNljm9DP5l8HAzBePnjpuL6+0g059C7pdJxLujTQ8j3X1wRaA0VRcak45p80map2fI9omZdgLdtIaiL6B82cSxCmDE4558BEmcOe+I6KoWE+V0Kq2QM25J0TW38Lxj3+K8ugi09MsodRVDql4PsOrQ7s/toaDDoMojPKXV0JV4FnIUvYI3X3mLtxkCMBGoCOBqTrXlLbxNwZODWze09U00AqushzkM6wDkaHM0Nb/vSw4/24Mr0XriNX23+ZGAFhq41HISYf5+Rdi+Bc062giB74f+l1HTOF3dLUEKkyF1ETjFZRdEQcG+HHVjlvgyurk4I3TNX9MF+8qi4mxWMYYeiwfvZqF/Rd3usfAy5KUGZCizF+SpSJhk95R5kPQIltBJ7j6cR5VsvCCwpRQFlYHQhLdL1yZ+IAo4I2sV7gdnBsxwTRw0p8naBHTtYM8xkxS25AoBzmcpJo+D7x3yepzNgw8Qzdf1xd57EbocpUQPAy10VLMKq5wpEj+urR1RE+O7nYSHyyce2NoB64rU640UTORegRU5esPFHlJIV2U1lgGN/y6UbxXWHC3TfTy/ZeN/RBFb0/7fxET+sn3Z8uuDF2gNauFO8MrYJ37vCA2nP9qxQrTLG7W+GuTdUenorx2N0Q2kUboQvJdnOoy0K5YjTz8qITEvyhqLxS0XWMh9VvJMRMnXNds06kKSsQUCN06rNMAYuHWlYhBaixZyeu87XTUsmCbR7NtGEqo7O1CGyDjkaK3aC+7h+/uFzbQPWZ/PDD4WxWMR5Oc/9Uf7B3OBisbXSw4e7chK/YCmemXD0y2BTiMusGtkYM5ZouR32MOl0DiaKH2wwjOGt3UFA8WpGsuGXxTugRJuwe0FFoquPLGURcyMiwh5OCDPZC2WUGQdgZrXzCr6sLv8Qe/qRBo85utihYl0hUqlUViF1vqjgC+gEYWC8NSmCOXJT2qN8wbfjEz7hu1bqH9iMQE9k1jTlxXPQzVphpq3W85pyXsjJwoXNexBlFLmNYgPJMipymbKketUR/qljDZ+tRs0WHJgXdbO5tv9zKWDbqj/dGg/7u9tZB/+DleNDfpenuwcsB3TkYs/toWX6njHlwBLoMoe+qT+5MEDpC5P9GNglgVLU8ypCoo8nIyl710FiX8GK/hYhhn+Jh23ZE8PvhOyib4EA7nWgXWU2BCYAvxq+Yz6Hxf1ORbUoVTzmO3+s5AKVgoh8tsMsz7/kibyp/5M/fnb35lwc61lW2jL2wecr0RoIvu+QpZ+hsZJSA5QdALViG1GzMx69MFGPirLqPyjrBqNMvJPysv6YuDsWFpeRYO8d30+nY8Bbwank1Bq0CajhY2tAIvyRwjRqj+Kg0K6vcWQH94fqE/mPxInyIFSeR1d9QtbB7KNQMJj8whQG7APLFbqe01OBRAEgVOXb3VJ3zW24SLGA+a8kdZ6hBccN64F4BuIqsV1VutvcdlKaLnavslqWlYT0y5VnGRA8Cw/GnFPmi5zhrj8wVN0us+es/r/nn13pkDd+4ZwFS8lxm8rnM5HOZyecyk5+xnM9lJp9+Fz+XmXwuM/lcZvL3WGZyaerh46R80GKgTVDloFbLPQV7iK7HzVl7vy3Wp7Vw9i+vl1Riu9OgKEbYQuZ3t6aC34XaEdCMW2iU78sCrLzDme1q6Iw83J41pskQZhQ51V1yIuaiYv2b4Aewj/aI5iINzXn7kR93XEWng341HIT7EPBz7oYT6MwFeulGiIu5q/ydzmh76F5dWdXofX8VoFIwZOQS2GRcxiDGD8kUv6nb7BH63RkmI2Nh5+w3p3LGNmker1Sggm36Cpv7koRYekhOFCieCF1/ByXqJk24IPxdXEmXwsdEdUblR2mjRcFUSrUr2FJzBoBUldfckzHw/0O5IJBshRXzkE+GXnuE3dqJ+as6ZxR+z+QdAZ9hAUDUrAH3hoY1eeGNgoaqZPLrRg9WpXYvYfqciEkfIpRerE1+XesB7dewhbUlcThFZGH3ZJ2sTAI9V3xmL1ewbIFT5vuzk41Pspj1rcFgq80gY/vYakferIHWOepupvCbF4z+iipCf2Uln7+yms6/z6LNXKwOuuTM9lX5Gz1fxvuuYr3e5VC1EmUk7u3vHOy0ecuMz9jVCnHl3py9OcXMPS+jxEo2WpzqpaYV0UaB8j0mo0XdeE5cBkxc65NTQROpJpsYwwXQKZszlnHaB49j/HtyOzWz/Oezo7dHtVbleMxTTnP0Uf6r54QMD7qcIMZoB7qClXzR7DhyAOi1dhEMJGQeRmTwuBQP3Yaz1e3CN3YTxkvDBZGpVTrDzqRLgf/WB/u7g47t9wV1pw7VKeg8FJLbQBluM54VVld526ykjiJkABGtRDCfrYrKvRP7O0nrRaoukUfOxcoSFNDFaTtcBxuaApCN+0sMT1v7/asECYUa9FB+PtLUe41NEaTqDtWu1ndQ8x6t2m3etZeey9U/l6t/Llf/XK7+i838uVz9c7n653L1nznnr7RcfUwczX/9jPyWDkulbdCyCVAHoxP1LrbwovADWLwuYJdrsmb/XFJtaWt/52C3NXgUN67+hMLpJQpaIJ5C/PBiBqGsHQH9q7MfwPqD4vwCd3nGFQRhutFtdO7uKGoyijNeadVZqxCBJfgDWIJVlaYXBSm8uGiYiVGfeoix+HZv8CqhOXAHw2+QEa8qfui1iwV0US4kGoeLLnxxcfR2I0E9GIwrIdSwK0bH/qOlmWIaIBSIjbzQsA1GpXHhyhVoa6MG1snbC9KkAiEvALfIwaboDfTqsBnlefVuN+G/SVhOteFpksoHe7hhfbjWJVMJjnuVV6VfIBfgDZfGi+O3sOfsoCBUKyJxIH4nJRwqOliZyQ98MiVHWpeKipSRC0D2J8dHn0egUpiVeVcr4kCv5MXxBmI7d839w8XnTCwCC2PZKjfASdyxW/+Tx67/8d8+XPTIu7/5fXAm0h559+Fvjbq2PXL89m937JXasfwi+yaXKc07c0KfdOP4bj2ve73RKWba7WW51D84m3/OLKWaUOEScFY807hrTV68+0wGcibSL0UIml+Vgq9KDO+iB82JHYEly4dH0mVZYehH0AaqfVxJdQXqwOqAJIIoANVGIJsf+w+CwGWPXIDodt55TI5pzsdSCU4fNX0hzRWo+g+Y713ehctWlZh4OQFBDjQXMCIgPBsiAfDu4qjbg+1Bf/Cyv7VPBjuHW3uHO6/+YzA4HAwePdsRG3diTTzNdDHJ955T3XrVHxzAVLcOdweH23ufMVWs2nt1zRZXNJ/YMzR9CBbF5+zpI99fMDN5iCOMKMaSw9es+1C/v3jcnRZNOC3VzSorgEF/OFlfeCfP7QOp+6qaMgmLgXFXtUsdCoJ72nn/ZyeRBNem2Nve+lxKsdtCCiYecg/cZXc4dc2Fhc8YOFoayx5CyO852/29vZ2X8ep0QR0+kgJf0OoCoCD812BfilZdFzRFWww33erS9iAqMfKQuWimOM2vEIRkRZveAYRj1xX+iS6rE9B9iwNiVYDQSBe9+v4fx5D4sD+KKXWAIj3CTRQGgKZmn0QpQb3NoaKoyKogxFrz6ZRCSRLVvQJ7e999++2r45cnp99+N3h1MHh1srV9fHz0OG4UAsxXzn3P6uUta5lIIeo94kI/sqq+BMaJ1IiGYsgYgB25IN9L8pqKCTmGRCWS85GiaoG12LxtfsLNtByBWX4icyommxO5OcrlaHMit5Kt3U2t0k3MdNq0xIIfyUT+9fXOzsv+6529nc71wSCt/mPvB2ds+TqsCTqYE/ywumasp1SxLJnkckTzIOkK9mCXXYMAX4O14AsaC/zEvkZrQSsZ0Jn8EDD2DnPBxeXfKtG+R17/7YIK8p2iIuU6lZE5oWfVwgSMB0+zX75qK0GNKp81za/NTHAXY6gt/Reb9VdoE+ggwsPn+GfV7V2kxGpFw39U4Rl2EE5O69zFO/eZVYjuZ/54OwyT78MHd0KYfM9kXMg9pUotEP0ds7VpFWgKCTF27FGJyZBlWMetAQVmwmR4JU4D95B0DIvSICAcS6cgNFeIw3ZkZ+deApbKxWWovi6LIudRxuAD6rtzs1hV0vSxZ87dUQJSGMVoGzAYcYKYMFcrKkZ/OZd9lwSVtgLEw2jW9fK5vH2wxFlNckWL8baWue46756MVGZKjrDWc8fAQUy74lquan2OnWR4dvEOFqhbKDpaOtRVbXc3zKW75JgK2pH86FnEPYc4YfKqkM2wvPhWkWLCDRQpFxnJqYE/ul2w/03WcinWDkn/5U6yv7V7sDPokbWcmrVDsruX7A32Xm0dkP9pu8dXKDyvf7A8z+NLNeIaaSBfz6fTImqbHJOJoqLMaQ3U1EzZwvJ4htw9Cno5juvrRVFAXLlKPAD9iYVHyTiXUjm7Ri+YJdrQ3Di8nBTThcbqBSDW94AP4y1fTwWMYOTBRMYFoaWRM7huovukO/RmJLWRop+lrfUqpDY0X9WJXT+H7pCdNuG5YI38NGq4BlAlpoG2F1U6CNjjI18fEcDG7NSgI6nIT2fndeXRBSc4JKY5z1i+wAvX65uATAe/dtP01e5g98EWdsUmVrBaIZN8Dz1+ikf2/368bKwr4pJunEuZ5N9LNmLt/bsc6/TLiwO+cO+vDhsz3rC9IJ2dHb09ip5bOiF3kW4eqQmIFHTz25IJqa+OuGL3wrHvyl/1Mm700V1Srp0TpqI6UddywA5IYHhGV6BjrTTvGBw4ebD0mclZXDjrie+NWsHfkPFvAikAj3vGHIx3XLSlVjVBkNcnR+eWuxxh7ZcKPQDng2vfviNXFhfnrO28btitJooF/Ryq22bAovstL/V4bWCQSW2rR1Hpbqf/UH1ypzpnd/oUq7PCRq/2doQAzs2ca/dcsHTHSOB47zdCzQELN9i7lTfX2laYr//85mSvB6nLGwQBjZgTXBJylGV+UOMAFYnh8q6J0QJqWqmUhnpk9SGiXEK9Hd5VDgScf80KqqiRyrMUWr9LX2hBrxGGtEewvsGU7lztbW1vhAlWUATVrRsXqm5PGh6OMIZKwO68CcYEShSE8ltpjAmAfcdgY3IKglA/6NauQc9d/013XJICUiDweIBlzap0XRwiQLQEH/vCKejkhcnR31OwHlHMV2/KFxuPUJ2/hiT8ryP//utJvf96su6/6oT7wFZlAK/ybLX65BNAv4Cq2wT6dZXH3NnHSqXaUBFVNDg9voB3k28891tara0NjAudQj0nd7Rdo82yTlKhUF+F2cwY1aWCgg1xQSQ7XxcwXU/9nFKVzaliPXLDlSlpTmY0nXIBgc8yvcZ4JEO5ACXQMpX/LEdMCQaQrzKLMLgfAMd7ZyLoFxdt3zXKf9X6787+PNi/2m9nc6RFmZSaTh4iLkCpmezq7iI250xZRRvSVeHOCXWwo7owLoKgqsBin4biMjW9jxu8fVzJDaxsE4l5FynNofAstdKcpVyt7E0kBR7CH5SYuYR+YF/2lpfnpzdMucKqNah897ruhSIkAxjpVk311Diuq3hcD1VAM66vE8VoljSxHj43gMVIU4V0eCwJ8mJCywnbAAzhetnSF3QyUWzSgKMjuCY0z2GoesNBnwXkIle3MJV5ztImHML9SYAIxqulge3TMPG1kOG307lQO5LjiC37W8QrXsu0rgpODE9ZGh8v28j6ul6mj4UWpSJvmfn27N1FTVODnl5zUd52tF0NOuoptAiaoC9YuQSl7N3by3cX7x66TBMmk9+BcwaG+Wdw0NQn+jtz0uDgfzeOmni4X7mzxg71d+mwsQN/dtr8vpw2ds2eHTcrddxYkv+enDfReL9uB44d6J/ZiUMaZpgVrdb6D66vWKKNDu+ZcYp1lfWtydyX1hz6kQ7B7mvPpGKmVEJ7nwLI885Sck8XyJefo/N/oG4RI3kf6UBrNCEYQvM5XWhSwis9KM3lqoUGp9eMUcHFBIrcCleDVNxwJQEEMa4VH6pVY46PwswWp/kPR4wauHOHXZTpsBV2Uqb2YG3eYMviRVeafbBZ03RVm4y8OTqOhxIehpqvEjENPTIlMPL33x2Tl4Pdbbs0upxMmDYsOySnNJ0SmRpmyAuHL94jB/1RFHhq9e0NLAnhNAFnFZpL8nPI7fgXmbJbmrGUz2iOyP6aTPiN963AulfKoquQDx1TjWXHobyHvdANmzCVkAtU6fmNexAdqc734krihBani2LKllz66z+vDQb9waC/dwo/d/rbO2s90vpwNy6pdLef7ssv69s7+QZESzqgCOAYEbeIuMQHwW994WYnh4FB5JeS5gAkGdqMdHSw6FKU6JyLqLL7ldouBVZYFhlTxC5xxrQzM9SX1Uj7fMcBdIXhEjaxO/aLm4iWGYfAFSlLsGrTPA/16ewWU2OaNtA13JSBtX1x01CDBAVNr1lX8a0vQATX9ldNBi5WsxUUSxkE/npifIU0WOVeCPT4CuggdTKmM56vKpnl3QXB/sgLL5MqlkGh24yNOBU9MlaMjXTWI3M0iHZDM+HTnfMp8ycsBPtVQm61/Fx4p9TxYANOpLMqLjeG0tSu0xv5b3rTuWOumRJsVcV2W3PD3sN0QLVWdO5K8XfOaDfZTQb9ra3tvovK6JrV01qLfk/7JsaQdmS+a6P8s4uaPurqt9olvn/HZ1ImjNQ9Uo5KYcpP8Raq5ryTt6wQsW79g0ZOP3T9+sLeUMTLFdHGJ2Rz8lyYKvcvMgiMlKQZqLZMAU498GLeAEP0j0MB5zyXc9uyUxrrsPPkhY/fYhuHJOeivO1ZHQwoLfhtlc0+b9XKOcMhvbuwGuf6umIkY+ifBuObU19d3FPO0f/M6rXb7BOjakFCeEVCznNGNQBak1KDEc7eo7JgVtO1ug4k52NXp8cXPUvVQslCakZ4JAdQV9iyW6uBqT7iyl0tUnDrbDyEdW4Nkq3dZKs1i+6T8AR1+xcF7MeGPvadVOQ4l2UWvJPecYq5ZhASg2YaxPvL+TUjQ7OdzFjGy9kwsRvwZlbt0LZrNMS/9MDAW/lqPepvnONWGVFCi13GlI6y9cUDaxncJXhesFSKTFcCY6hA7iNOm8u7s73XHpJVOL+W2GUAwHzq0GWYMYA4rmjCH+ysaqiRbWMWDMpKs0+cuv9ViiaWQOsabCdemh0TekN5TkdLkPuP8hFThpxyoQ3ruL+Bnhh8/bVs7tUE5kcT/93F6Edj/+3D9VsDWyXqvaMo8EMIEgeHoHKh3/FdNAZjIjJOQaiQYjHjv0bjQVKHPz9g3W0+JkOYFc+GdpfhH94dgobPVIoxrmmz1LfI7N0rZGS1drWJlmzIlfhK2tvQrSoMoXtgT2du/io57cVUKo8DD4XXq3CwOpFqlXgsq+0mn5L5yoCjj5SiCzta2OzQs4+wAC+nm0ctfYs0/BLXfEQFvaLZjIu1HllTrJDKistXttHYH3GfiHNjGilrP1xenrtP7oo4/86n7wSkBfsiVp8GoLCgDpYq96qgZpDPBjWHI0GWkVLlfu6K/VIy/YjkNf/iSGaLz7URI584bBViq2/EGJ6/MXwCo+hawYODl3cP3RXz+pPJT5fO8YTb6JNU/IHluSRzqaIS9C1qrmAPXEqsRn7HTnhhJwH3zZRRq/h1G3G2dneWb4yViUHrR8713pSEGE2n9bWpXee5nGgfNB/aTnPOhMEMLw1411D1BirNULD/N/3QPKuy1VCZxWgtIqToa0NFRlWGw0BiVqEiw3/23+PI+mcnVVFvKxf8s3/sBsqlsN8uKYyxvcN29/Zf9tnBq1F/azvb6dPdvf3+7vb+/tbu1svdR8T5+wWcMTOVK1vE2jph1xGhzxW3oq2E1J+tZD8ZuKKU3nY2KXkG4PZzqkN5lcOqgbXLYNjC7IpZac8qi3NAjAy5khhv9kvJ1IKLSbJWi/+Q42oYaDELvUPwX6FYiq57ltLS3Sm+BA+kSzazOHC+fh+VTlYEuXJG8wXJmHHOJ0Le1RryBeBndp/FiQBcwCC3k0Ey6Nw6359e9sj5uwv784P9IS8ul++FFdciXX/DXRWKYFe1HKl530YHMaTNwMJCyYlaNtiIanQHerMTeIvbF2BlrRLS+OeHx/hC/xKMxHiOE3IsZwVV3vkzi4dMQ6NzHpWniXpbX9ckbta16q1rU5YXbhe41YduFKNGE1kha824BoVhAlW4HfvqZhZ8Ridsc8IfXEHK01uxMVNqZbBe7113VexnzCQ6byQPBzrK5SSGad/smJMupNDsN5e9cBgPFb7iwT9LX+f3oOPd4pen528tf7lZfJ4A5ibztTFtN6wvx7WjJf+CbNu12sG38ZvHMO4alw6tOgHzi3NrR2htqCn1koDzh25p9F8tW/r6GcSOl8ed7w7a6b+r9dfBeO/yu285f1yIrfWBQ3XLw1nj408B5oRmYtAcD1yvWCqVVR4gXgqLXuGvzd5JzS4I1fTQpOLiRQWRI9xcDkNkzBWb0zzvESVLqD+cS2qPV27FVrURi43hqN2GoxZam1KRgQOZhpCqVAoRhNMz9zrKuKFVSjQXkzxqqCIEDtC3ppnQUkHYFtEFFcTOagM5QzwSH1XWSZAONICH22ZozumqbGxhG2GvGLJVrWdlxe915AX5ta3dpdxu45mv+47gMkBoDlVBekSWxv2iSDb7FYySKRic/VAEnS3zWLuXH8qZVmYSqOh5dtIkZu1wVNS8ePvmvOOc2X9nJ0tu3wer1it0r5zF68iW76juuvVmeq+5VXiTkzpffB0+uBMC5KSFzgFmZHvD5nIygZuTpVMquJ45yzl8COYYO5sIHBcMOhUiiGWs1ep+EhWk1Z1r1/Pm1CpekE25aZUR339k+q57EvVC53ISOhqx6FoFiCUytMPFx5JvhrWJ+LcCkpGRLswBMEq0LJVlFbUZWrHHToJlcfvfDL1gNCoNUdTFVJAhjvkbcEJx4YItTo8vHPkegTsC1VyfthZro95ss0K/JThU5gRdEWmV2s0/VnLWCNUMrd5Vwp/cp2x/aOkscoSQOdVifd0geAUCMoTx9UgmYb38rowSG7tlwc0bqjZzOdkclwIKBeukOnJ3ntDaKa0Vz37CgKJgorIzDmm4fonqqNaBbm5Xx+4kb8jTjniuKQVaZWmVKHbDFOTRmriuEcfoHCkc9NhEAjYPHgloBGOT4Ey5fjPJcMXw0C3s25VSsZAlWOeK0sQnMfABy7H8YMiUKS/WXCAb8F9txFAxcsb8CiO7Gs6pEsMeGTKl7H84/KjkGpovsQIzpSKLUMSUJ122midJjIzzAbFjJ2nY+5YWRc5dkm+ow1TqEhhXfBiJK/4SAAtyqn0ODhfccG+pDb2A/OI0L0rSUhs5Wx52LdXEF4jFcu3JSEqjjaJF8q3/rUVINN0mwOBy3pVrupTBuSyvuyhnW4wyGUJ5YedN8Gqq26YQVo4EcRblJvpL45h1UGF3+84prjKlv7l1Hj3zZcylC3/QB74GsNKUFlChKL5EMBsMvEWpwfeqzrpfse0CiwlX4ZLzGrZa8m960waE8ItRirQbE+LJisrVlsJ1bw+Y8080qX+Pvcbj4h71CdKV3EWe3dR8MHbMM6YhrRaianyass8ljJ8ITWMVR6KLnBsEpjOkLOzFAgbSImekoMrUIrAxn1FRdD6CUco16wMMkKhx5iMVdoWhbFgGLcaqtCe5a6UX7+vaNPxke60JJS7VMrQ5pTdW87SyyoJoey/NZIbVg0B5pFgbAgN8mUglSFFSEcHmwL+scjGTN6x+RHJGhSVQY8hNA2DtTEKZPZbBqmQyvXIx8fZ6zLimo5xlREtL+ZTCdT1i4KaLkz5HPq8CLIvuclDMKM5CbYzhFbKVJSf0ghVk6xUZHBxu7x9uDRC9A6J93yxIXfTqLAwYIBzh/n/ACZZQPuauM+pEihkzFPBIYoHJQZBEQiiKKDNumtfEDaeuqRDJrxkj77871mRvd3vXLu/O1v5uBywlvjWmKc+5WSSrsC+uRzN3tfmIH0BLtmzGsYZGj9JUKtQAZDRTu9fsVJeg71Hhr/EKYq+ybImMbO90b6LtnU/SboV3bURBKz730bR+byJ2zA8ORdv/4OZYKC7Vw0p4PW5rNLaF77d9MB65JVjVJNfkgHxTEe0/gmSf1HlaKE9p31d4b7DbgqUu8CywerfbGtiNr7aWhHvstDMJHLnDQD7vOH7y1AUt596nrqYXO2EOypTqKWQ9BUYUq3sVsnaz49AuUq5p3z47udjoxZqdVc1ag3eneyLtYjhjiP9ymNw5dKsowlXlFUU7WG24SE2kj1qF0d48skDNLcr+TWWBBriGctg5lM5tsJSvhE2wahn+t94kocM6UsO9Ngc4OpbsjMhg8BtuimgUnfvhNOj/jdz7ulH2be3DT0Leh5z5GkAzeodms1I48RBNcPKGKSfK0hgTGoREbCcGWNY1e6dHCngMnLNv3UfxumabQGNWpr6Js8Me4AiqrBerOlJHqIlN+A0TWBEuHoWzgxVKGpnK3JlUvBFEjbhRVMV4A1Q70BkXSCMmGmX5GU+V1Ezd8BQQhEuDoGq2swUqLNXD+npRRCYxnv7SszchG0l53SNmbmVM5QYzr6WwckE0N6XTJuZgH8PEZ5FFhiupiBtLVc7O3mZZCJjEsnbBJrCZMW3I2TmRc4TQLqQyuheHR825YqEeYHRHf0YwINQdRlzXtAwuttC2RmcoWTvzLjjLwE6PL9a6Dy/ls9aWWxKG0qkdPyYEZR1jUDD2BDQEiLSClRpJe84g7akR7Xo2JkMkPMbJDEFYGdpF4AKte/5z5VD5emToD7f7CkUiXq2QLmdLbrn9gxZhHOcxi6tVhhdDKpAcB8eLAPhJP2lydu4qQuDuo5rMWZ47Zhma9ce1wnOs89GoQj0xUuZ9OhFSG3ub+iBiI32Ic3W2x3k95/s1o0qQmRU4qekqj203Uc4nU7MZiNnnGVTNWCJ0Hk7f/Yd+u/vDf7z5fu/Nf20eTM/UP89/SXd/+vuvg7+1lihsnRVYd9ZOfGde0vDXgVF0POZp8lG894XGWUYqa8LhR0E+hmY/km982MVHQcg3Lu4Cf+diJEuR4R+yNNFf4C8WNHcv3fq/4pbJN6QUcBg+io/ixykTZEaLwjIFuJG0dw/ZW9NpZTMpuJHKYxGzW9OLm+zwG1WsEfClNQF4WEuVG87mPVfcJVhFNPm45ie8FjctFfm45ma/ltw5Xk9qqUjBFJ8xw1Rr/HHbfip3j7828Oayho5q9OicHC7TWo98XAuLBn+FRVtzs/XLFhEi+Sgqy3HtFWensvcm9BpGRKALqjhztRy4RgtzPFIjAzhsQ4ryGqCZS1hCDXKLC8cJnSRo0LaXdK1ZHGY1k9B5rUd3KDr68kCFcaO+NW+4jAZxWSXyR2n7Uey6/fTs4lwTqeIm/3H+NlzxAVQgWes2HAM9W+xlLNWcqoxlV18K7vDs3OeHo3c38jlEXzkTcqHkbXd86dar7WQr2UrazhVOBV1tQWTAID33F85bNEa88JfBfD5P7JgSqSabKBtaMUVv+iuqj4Ntf5DcTs0sr0XDEHLhricQm3JXf9q/qd1moTmfCHcxgkD+lpnvcjnHRBv4zeXl1dqGHB1UK3wiRdfcOhdkv70QQjxoCe42xr4N+GaCqTjshGaZu+Edsok9QV4MusmpcA/XZlo7pxApKJia2f35j9dHb3Fn/tLnov8LfmAoBqxwTRxeZUKOciuy1inoxuUjGmz3CUf7OvzuQh9gDtHYGtElVmapNQvj0UxkLhwH+AosbPCFHAy2k61fCBMpLXSZO+nfajONaL9aw6iy/8TYdY/8yBXTU6quk43aItwn1MxOKHGzXdGpg3VpB5zVwhY7OceD48ii2a3Q0vPOmS1wosvCyu6c6iMDB1cNS4CKN6IZYQU8yAF2KlEFCO8PdddUv4fsnx/5mLemsxQJ8T4KXpci5+EPH6PKuXc7lLnqmw51zn9Z2QOcYrdcodtuR337a2IFusL665eeZVe6GHI/dpuAptQjOVwn/6bpdS8K8guWlq/TghAlxEcZOX70qyDtheMFAfKzknjQqgR4MTSLJPX/xP7qR9tJ+xXlc7qwkkuZFT1i0qJHeHGz3+fprOgRZtJk4+tcEZN2LMiK0LxcCP67izPyRmYsRyVrHqNu+WPw2lI3sTTdRcpG1r1Cs7RHCj4DQn+dZLYDb9H5j3rn/7Fv+xAa5Npz7zjPxbv6p3eXlYzi+5u1JcE7QwMccM/uuBJ9M1J1GPozBqqqDwLHfK6ebx9jDzEw/JMt9uvqjTOl2DsXEYZjj0iMkheCGn01SWwUss0Bg8hNFTT4AEXXSjYzkqhS3J8ARMuxsd0lviRBs7ql96rpHpmzESjGYPrgwqgSsA9D1vpmoWC+0K6Hbfc6QGUrcg2jUuCajYcU9QiRL7nUoPy0mrZUPTp/E/LrAmGj/Rr5miim0C9xNbk7yeff8DGhIqQbAtVxnjrsC+3TBHBv6ErhuYPeMAvXKkbcKZ4m5I2LUvulZCU2TE4vX0NxVClgC3lzcqEk1BGo7HShmVC/WTE0XkkIzbVSoqeHdoABj/CPsXoi1ueq1f6sOwh5MpWov1bpXeCNivKU0LxhSQModjV10nJN3BBQjSVuBkLUqNZ8vPB5dt5qTMgF5qtRNauZM2tte69UU59tZK95bybksJW6ncNGIiRZ+y+GnnUDeuhl4eD3A5GS52y2L57N1qLxnz69rUWRP1q+W8dk/6jiZjy9P6zU2THh7mJiT2NqcreGryfm3VTBnnrHzO+6MyCDsHJPU8UgSrh+x/WQgZ45z1aPnDp3T/3+PHnzU4/88L5HXrOJfcoq3V1EPy9HOU+vsDlmHkr851K1z6Vqn0vVPpeqfS5V+1yq9rlU7XOp2tZ0nkvVPpeqfTgdmpVq23pD5VF/IiuWt/GsxIwVDEp/FDuWrx34bMh6SlimFpH/9JasNkn+yKYsP9s/qi2rNr8/hTHLz/g3tGZxkcpZHB34eGtWBXhFseXGLec4aMuSBdarWsP3sGSdvPnpwdT+/EjkKtK4QoNdLq2sqAZ7rfx6e1Thvedy7B376AnKsT+NreK4Ak26c419chI8CMvmsvTiNMXwZi0p0WPiRskCoWE+rkKGg4c/eN0pgpGwvEJKRYQUqSZU8F+b6vjZmAgZ40BBwgZjGcvi4pZuXDkbG8JmhVmiRG9dQbz/xfetBXou6vxHwBB/Lur8XNS5It5zUefnos7PRZ2fizo/aVHnQsmsTJ+wWEfLEOx6XCLgdQxdb0dFowLCDFOc5qtNY/RGVNe5M5G21aGVFcqe1stbVOrrlKHHDSJ3Qeu1+k9dRVIoi9rzwrxr0KdLVi0tCqaTLnBYn/iqhhV3GHqBGZBiMw3/KeA/ILjCLzLPGeDJolXX/lYFxy5BlGkZL6syDTXIjqcj9j+go/tv2ovFjArT4XpZyjO++JDDdq3d/RXoZTpl2kA6mWvBR7e3v7lHGUsXehyljCqGhhKfMRuKq9f6rvjujAo6wSjy1JCyqKSVh4Dj8KvVcuWj87PAiEfMHrWA0U6VWhAvgNEmCEXYKjdbI2bo1kPvnzQvtVlau/bJayT77h877FKtrPD6+9d+0Hax/PK47fYl5rLaHXcZK4GftxgQPlHU/RZPvYGgPysQIleozgakbNJrQLHIadqt1F6XI9ZH+efBAltkolmVxBB1Seh4jICLztqMAGQvxlKhVtk3TFBhujgz/tPMlMUSXcfBiD+UJp5H/1ZcxPfvWCdiDMesvzZJw7TpFzLrp1k6f7DAHua6Qu3v0olnD5unwyR56ARXOK9YRvDcB2VRBEywx1QJZpjuESFnNANzVS7LbCxLkalF9y6uXrvP3O842EGUaX3zSZzBuDWflMWEsrwKZGpUa+M6+AH8L5Wzggpvg5XKxarV5PFGHFcMPKhDGa4pywuAZ6RKUYFC0ZjnlsjQDpT/9qZnQHwGtVjAg958HYZRzecxhVRW5oBtF+7HyDXWMK43B/hnNPjFe7QyAFemicYxCJaDi/jm/FR1uHe+/E7AB+7e9k271cPF9j+U7+LZcfGkjos/oNfiz8jBnshl8Qf0Vzw7K56dFZ/rrPideipihKqq9I2Tas5rH35CmKlk6+WyDMjS2tAcq7og4ITvOwJcN1VlG+DoEA/basy/WMW3I4uLrkHNf623C3kZoXE3GGzV4T9UrUGgPBShTGuC8QOMpSqdcsNSU6pVMRi3ZrWuO3fB7cH+1X4bTWdU8jxbsYV3/cidx851BhZnR9W0yYWtVLEKv5PCJ1GNzgAoaTklN+TihyPMzhII1sIAydY3sQSherw7fskOXmXZ/tZo8OrgYLS1zdhgMBi9Oni1v3+w//Ll1iDNHso80ilLr3W5qjv12HXXIqSfPeiFN0yFklDdeJsHo53tVxl9dfBqh+3sDl69Sl9mBzTbS0ev0le7bZdiNIgVzfSknokHgK5NbhPN6F3BRChGoeRE0Rn49nIqJiXYsqXbihoC+zcVyzkd5WyTjcc85UzUhbmAqdPW+ZHkVzqVK5M9zkQGyykmZCrnMTGgcFTYBS5TvNRM9SE1sEcmuRzRvJNm+NWyCbKH2DYyapaKs5eWGQMIaeeY2xTOecqEXplc9xq7c7V4K8t7PFrPZCL+ZMVBagUfZdztBbRH6QhbjM01Ss7IxfnJP4nv7jXXBgssRPKS1nyUswr/VxfZLWD/uib15kY3fzsqaDplofHtpH2GV2jG9ldZo8tq58m2crK6csTn1Eyj0hZ+nXnnpoxL+5ZabcKx2jxmeU7V5kRubiVb28mrzdZ8FIN6NytzH/0gZ3YqaA8NnccOviC1gQzPdSWE8XGN991dSizUKZCWt9rN+dA704p2D6DKo0qN+R1o+wJ81YVhuvsu3N7eacf+rFKx9I6QtgwEgdlOt/KyebxlsYT2omA9XzDcTGn9EXTlVs4ysCwFoLhDoopZj2TF9aRHRorNe0TYDyZs1iOihI//TVU3z1HFg/2Mq5VO/SZo91ppVHB86wpVU5c6JT8wmt0L0rBTm/oRdW1yLpWxx4qc3rK0xF9fnJ9uhAKRvws15fj8Q61bYqiaMBMcElBRt1Nt2d99sHRdcxo99cw8wI7vthbRhNXgXSWEjFADT/GcQZH3bkMclGySY0OOpSqkanrX70WC1UvegQxZWwR/BBXOaRNR5B6ztv2sWJUN027oqo+Y8n6yk7zaHwySrZe7W3sPnTufFVOqVyUMRTWTQKGcQWkkLHp0furqax8JPyrS71sBAx8jtXH2+z5Xx4cRjbmYMFUoLgwZcQEFVyC0g9CxYVZMQVKizUAqIuEOS2XG+jDn0DaipXvzgsYyyDJNS6Ws1oNCOwInp1PwCkNZJaNoME/A6NFq+skaTPP5PBlzxdiCQSGmUS4nm2aqGDV9xbAe/eb2YGt3c7C1aRRNr7mY9Gc0t/JVH4nTtx1yMUmmZpZ3X6CDdP9gsJPuslfb21v2lyyle6/2dyjNdvazbPzQnePrSF/B8Vl1GrQl8udyzIvzo7O3l8npP08fOvfVBmuHCXdFbD9i4mvhrvh4e3TqJQb4vemgXrsPZSKqpBVWhhdoah9+KujjXtZh31F3yIZlCyFsA+r0Q0kph3hbaw8rQPrmCM82o23sqlnV6qODN37ouy94NiRybJgg2tCF9v4K7Ipwo1k+JjQUK4Ig8IIju7IPok3EF7oCdywON/Y5PEQ+m6wKAWP9SCm6cAV9gHhUTQC5XfcsMZQJvhyIKxxp+X/Ye/vmNnKjcfD/51OglKqzlB81IvUuX+09JUvyri6yrTXl3TwbpyhwBiQRDwEugBHNvbqq+xr39e6TXKEbwGDIoSRSMi3b2iQbkZwBuhuNRnejX/LCMOwJGl1PQfhdUFAjUfmGTqxwx1gbpNhISasFQhESbvhNpdzJ3FxbsKO7XGxpPVhrkLXN3P670EzZ/281E/uf1n5dsq2lZweKKS1vQE55f5jom3D8eT6y80CA0aS+BX550PlcWV843/VYs1Swn7pF+okZQgXNJ5prIgUZyHEYcmjVzLBOZMwUKwWHkbhu0fYib+CkCi8McU2iSAvuXIWowOhCj3jKZaFD19X6ZVlCNc9YR/O+oHBPwT5zfe82KV0pc0bFvDV5hT/jxeoIbAzeI5SE2eJOSrU8ZlTBXjwQI/sXF/0Vdq+0kixyGXotygJSYc7ZTG//qG85iTs8Klk+pKLoUbDXMC++9AZhmkjcFN1f6nL0e9643nTHo1HOyN/ftaGsUD0bpXKY2HlZ8nmUJpDY8tBlMNQUq5Ohd11npUwZvFthBEGrXw4vFnKJvb9SNRkZ2Vd0NOApYUpJpUthHI96Q3OexUUFrV2tCm38fFZnvWGkEGUVfN7zNRTg1fIVHwNfjh+GHVNNCgH3Vyyb06z6/ft37zsf3l69/9C+OjvtvH/37uqhy1lALa5V1YBr43QV9QwCrKCWhPpiVvsU1obR4YoFiZ3yMaUJjAd3i5DsBdevZRwAasFJKTzK82hxIXL26y///OPwzeHxbw8lu90DC91j3XEyvWgbqZh2nY/KPVmzz6wdyqeqT/IMFfny9Xnv+YPdGg4gxK2VS0UGjvswZCX2Bmq9VXpjdCfQndi3DLXnP8sneGWNNwIoUOr1tMc4Q0EgPSL56zUDCCHnfW5oXtUR8B7cmmB9yoU2FR0XHBIT7HQrTNUlMCteaWWN7iE7l6XfcEhF1sn5lywGtZKox+rKvS7y3GNHLHbIlKDfWmlTCqTp0FhvqIShncEyZajg9qB5XmrH0ZpCku6M2vxAUya2Y8gmdAtXJJgviy495kOs8JSYn1NQdzSwqYKIKLrwwIBWRbDRulFCB0ZjYFM5HSdJl5pNj4yhOFElhhEuZqGGsgcEg4NhK374cH7aIO2JHkrhHRnk5w/np7oRK0w0agQ/tJvZoppPwpGG9dhDYx44zWaxPpFCG1WkILSp8w3kEzdcTDmotmTZWwoyUlbUphByMuSG92Ot6/L8lChWaFbpPR+dtdRTMw12vIRscz60PA7NrKfTWIgvF2qpJ7WZI9LT7XR3by876h0d7RzsLRzqVO61b1wy3YnxdBrzlKsk3iMVV0kNzWMZUUNRPq81wXLOjCsQidygjlv1aZSFxIFZDVND33YCsi1rO2aW4cW0a81td9yG+gjlZF52uMxNiIWpeh8JSvs5ASytnYNFGdJu8WSY7a1IYL453cMp6wHRg0qe+peEpP3LcesOULb39lcHzPbe/h3g7LW2VwfOXmv7VnB0xlhNGcgvA0779OzscgqcBTLQfzBR+8If6ZgAVdHf7AmoSerCXTHG0xn2img+5Pm8QJU62Tuiygq9Zwf9NLGWc9Df95aupPqzG/8puPHdgnx/3vx6xJ6d+l/HqT9nNZ59+9+0b3/Oqv4YLv565J89/avx9M+h/rPDf8UO//p1ePb7L0vGZ/f/D+r+dxzwfAvwfAvwpG8BPJ9+L5cBX9bTvyhRn+8DHnAf4Kj41a8FlgD3614eLAfwV75iWA7or3wRsQzQ38p1hQP6O7i1WPGVxGL0HbHkO8/6LBF9zv+MiPFjZoKW+P/IOaElFZ6zQ5+zQ5fLDi156IfOEw1UeM4YvYtG/YXcUEvVaTkvvSeOFpBWGUVJOO+7t8K7zM6jiZHLKqijOqxqr6WWqzwTUlfrK95u724vC3gt5I9dN8dO5an9gozmo7FwVxaHBlj9C+Bxa22xAcNijzGLOK9yfTDBdrO1v9nc29zeuWoevmzuvdzZTQ73dv5Y1mUPcj9brBX3UitzBROR89PHZiuHwQqPAYfK3CLXCNHmws3DPULcfF9uyWCCAmZTjjnL9fB9A73caJOGjrxUh32BpZ9OqMBaql1GMt6DQmGmxCPq+0so6So51tDVzcCBwo0DwjtHx6yLVcdA2RImx6K+U7dji6xeMbIYLL+bqv4MlkqRVU+QAbWnCBOkGNUX2d3ZXlY/H0tldb1OxhVLjVRf0HJdPRdaZnMIkoDgdMRdHUG3BnLItmi+RNuWH8DZ8ezlQCr8oO6NH9yv8ezQeHZoLO3Q+ME9Gc8ujFuI8634LgLAT8crEUB6qv6GUEj4iXsSgib+BH0EU7A9Zes/gPod2PVk2YS4b9P095R7Wka9h+p7M9cXZa5Hs+jLovd9rk2go6s3+b767T0apmOBSFCqffFsP4hvriWFXrxcNhTZhlLwqzI33jkFFQvQk7HixjBX5rJLNdvfJUykMoOOT2GU11IFxNUs4mWjozYzv1kd/+wzxD+/Z/1fC6Ym7rtGNXsASlnqEe4dWYbojiQXLn3gOh917HfXScgRkSNnUXQL4/W7cswuM960uWGKdnnOzQRgKUMFy4B6K2Hen/3ceXX+9vj9/yDmLPNmSq3R8Mevr4rjk+bxb7++ujo+Pj6Gz/jPT4sqhbD8eILeN7nxQYt/gjkG2PzELj20/YL5YfjYMrsMRKKaWDkNycJ1b8KaufXzzJEAy2gu+tEJ6Z4PDARTknW7AO0/GrAQZ/+8PH572mn/sYG8Egd4Bhh41JlDCuYblOGU7M+CiZRB9LSbEJjbjv7mw8XVOcwFY/vh8jxuEndDFTTiITmk8OKwohgyxVPAteR2O+bp7+/enyKzn/3c+dV+qoAecWbEeCEbL2MpH9KcKOZSTtEYX2dJn1yvtdau54SjvvjX2snLj8rQj4plHWNGH7tcfBxO6GiUsM9siVRoYMb6NhOPnyNnqMioyqq8gAe+kz4+IUbXYY8ssyiGA36zCuSOu13FbrBtNFik3k1r5689vn75x8WbRZH5xGq0g0fH5Rd+wzax3vCNi0KXPTti/Tncfvf66vfj92cfS0vaHxtvrz6eoF72G/r4Pp4PrbL2mocGFJbx38HE+uOYCwu05eWFTe3azj2PThpIIbJzxRlCdokbdliQCHCOzFvwjw8mVkXTqiHcx1PWLfr9Ss7p/bqiTMH9pUj4NvLXwJxeH6llrkWwKRVAkLfT+l/85R31xkNtBs2MVT+GzOWT9mhqlQtqGBnxG4nZOEoWIiOUjDhLLWp+UGim4j5A8hc8AIdUnPvtHLjaGg6QgykmZJRT+yT2jz87abtsCHIVg+CGRteohcTJnGED+8+Xp6fsQVYaTOF6tuLZzVWkkJU2vcvSF+Ta0TK5DpgcWyGdKmZCFpWl0PkloVmmwN3kfcPeMw0tzQZSmwaRXc3UDVMNn5JVLoBxiR8NkuacCdMg/lG7qwQz1khIelKNqcpY1uGjhJz3sAn/aMRc4t35ZagzL0vo+ei6gXXfsTGRcEQDilHS5zdMWBSM4jec5vmkQYQkQwpqZdwejRuYjIIHvDspK3VEU71sHW0nzWQ7ae1dL1GtfYX3EMd5jmcU1QOmkT2ksIRSnuGcRoiJkH5bQNPdqiQqNJrnkPNd0taNHGrvc0E0N4W7UcD2ZxNZvFCWTXShGGThVUb2ABKa96XiZjC0/LaOaclMsZ6EtyzDWREMh28AYmPh+CxIWlsh/e18dmZd3sXYr6KMvvqFcc2zKqSK38HjiZHXv56+1Q2SySHlAnC0+1OqT9ppne4ruxFyTnW1Qdn9esUskhhSeXiGGu5cOL+sRXrWo6MrJ8Mq9gok2OKC3QbhfY6pIiyfP6PKb249oOxzvq0FVeXdn09LB+ebS93EjFIxCaKXSE8y2rd2oQXDJev73FZCc6ZMxJFCYqMwQLI0GH0DNztFlGfrRsOrQW/+oKUYAe4Y9KUX7R6obMg1XAdbW0DJPHRs1w3/qAUMNsn5aXvr/LJd/tDjio1pnlseZ10/ZFSuJnqgULnLvtYNwkQGHgiSMcNSrMkirKliT0bNyPrZ6fsN1y075Pcyky7TjqMwA7kqtn0LTUGl6lPB/3Lnq1RkpFmRSTEJPXwRKJAC8JcVzpKkitFqKQtSrqPnusA1cEDM7IPYem0bqjYvpMqWMFVde/tVRagcl/30kXNQV3VDIvIuxRzT5d0R6MlToZk9CkvG8unq80l1bAwbjqwteR4piheMflrYwF95QMsVODhmYlmAZRyreDrNJ8CrXKafiGJ/FkwbUFZHRTfnKTl928Yk9F+uri7bZItcXbShboJMZb5w1/2VVTg4RvzPT1E0cu0T98fcDFx7IOjljNIaRXOkLle4KYjlWsZbmNlazYWDqFfbHDm2GPM5fZLnS6QK6TAR3Fp0NGN39Jh13YzjLsb3JM9K7zFZJQIC6ABeWF+K5v577uLdyT86p2/bHbvBOlcX7UXxXnW74RfvKy2GjbQ26O3l1WI+CcPWnmDhV0tiO7w1YlBHcH5vrE314oUmmUyLshxJdTawUu2uf/Gi5EUhTcl9DWs3pdG1KCU5F58AHwyTQgBzvOlEEnS9SfZfMT3R0mfK1DuQfawVE8mYf+IjlnEKnb3tp60HLb3VItmqgoDeTnG8ZqZBRjLn6aSBOhhqORjX4TUHa32CxFhYn8HqFEM27DJV7wN1vu3OpTuKOq9Rs1yUjkXxRM8kcIVJFaKLKjSE40qX5xXak9EhxZm+1zE1K6zrj6xWs4n/W5S+qw1xvRqEvs9kiyh2w/W0StRllirAe+CMcsVd69FO7oVviEyCtaiamO34u1uNzGP3rGWMjPW4wFtBvPADf6H9TRAajK9UCuGWsxcMHDQZiWJ9qsBbrhmYd7oRPY/80uV4/4/yu5fLMVzpqqy0OF9LRa5OLt2oDeTRACbCljJ+U0bDccENpzlp/89baEfOzLrecD+6Qe2AJSx494e8GxTL6ZmcQM4nM/Tw5LbnsqMLBNJSNzg4gp0dSWhqCizjpJkre6iGZC2Mt2blEZyq0bAeCjEFuMZ6iO5nZ2W7w8KeGobyXJeHkxsRQYE6faLvF7icIsbDeZ7alQnQ/wBYuBGj0nVgxP+nEMgUcP+Jzl33dt1gJWmFNDND9kCs22XEaOVpl8QJDr/lUahesaInkmYZ0WxIheEp3jh+hjOdCsI+Yyhzo3JQcA3ey16R28duuEWX/8XK4AWLKFPQl7R0b3r3tApz9GgebBSgLS0PJ/RPu5tvbXieE4YeUSxSB54V8ElEvnIgWI/neZBbdDRScqQ4NSyqd7mAcwKd+KtS4GA34BHrFizcIgBuQfAMu7xfyELnE+RyeCcMC9f5OtRCybk2djXPLxuEetcnePoLwT8TLS3/JIT8T0lxmo/pROO9SalR2b1Nxx4mvx+uE/fFNZKxqisKq82VEQxZ4Utpwo1EwkfXFpTrBMG6bpCMjRhcvhDp9BMio8qL3B7NNdFsVCeisArJAmt1W0Cbq8GHYxKa5zJA7RxEUsihLLQTGbgO5deVo9tJFTfY+nH77cZM5TtITKDpoPTqIXkxwpvNOfn3WvtHdbSIXVzfQ/kfsmAY3bsI//lhrT9L2c8Zubg4maHhnIi0RaK/p4eolmCH2DOo1wYtlKNzxrEYHg31y364O+vagg10T4gfFLiCUOJ8s5cQfSaTlJvJqmpNn3Azmb/Cb6QwitG8HkwpDBdMzCvw9+iwXo3lZo7ReVCQLxzHPtgHoHmh5+Pz9nhRFb+K6IoW5W2l8LebvB4hqcyAHENoGZ0DfCGMmnS4lqtapxOckpy338FC1UJ+cnwruKtifwfqXI45oYJm9ZSFM63W/KsFs89kBzw+82C5kKLPTZGhYpZTAx/qbz/+L7KWS7H2kmwe7CT7rd3DnWaDrOXUrL0ku3vJXnPvqHVI/u/Zg94CvkKP4osPmqlNr3hNefIp8SRsEIo+LlTDZY/0FRVFTlXcWsAM2ISkUEHX2h2VgrZOQTJVDyZXqFKnTODNHOR15RLjMbtMlSVDvW1THjkIXk5Gg4nm9g/0kDdI6mVmHO36VhpLL/sgmmBgsViNZgiaT59Jj229O60rtZFiM0tr12wktaH5qnbwi0uYDsUs1VqmvBp0GlCpFIcvrKJd2gwuhCjEPw3pJHglPwk5FhAYTCxqWM1VkT/OL0kFR7sVQCG/oWpCxjyzOh8c+U5SwFU1/llP16Pd5u7CTndLcsX6XIpVCs73MONdcnPz11l9K4J3RZLTwTpXcP5asC6r52VrV/0l6+rBP77K4HPg7Hzh2idIHR+GfX789jh6bi5S7qDdOlZ9UDvo1quCCak7x1yxxS8Ma0JdaikwP8yljJTzyDkteP388mbX7qjzy5v9jVk9c0jTVciRN8cn9QBOXYkI6a47UPvHHf7+9Qk5aO5uQyn2ot9n2rDsJTmzZp5MDTNk3TmbG+Rws8vLk9XaDhvYvsOpiO6CfSzJv4rRiKmUavZvMmCfqY+fh74LmvT5jfcsV4NYHfg4MWY0FAI6slhJb1ifqYS0izRlWvMb9yA6HjQbUeX7WZTKxGAyGrA5p0Gzudlsbu6dwb93Nrd3ZlZQUJM8UrDUiytFhXZuNsj2jd1CXWoPs7fHV8Hb6opfc2dblwe0JCPFb6zoP33zx0a0xNWDEY6RXNKMdGlORQpHcxRMIxVRsrAndo2rwuI9kgtlyS6VeRoTBWolPG2yoB9zSZt7KoEZRlrauq5Ju65drkdMBXfLE4uXaRgwT4QplnXm2fpfrqHRgPcHTJsICE9PhKUBCI5GLAtoFF3vIggjvy6zWRtRrhQM5/ySVuNa60mZuOeSVA7XrCBci7+Y7puE0UguwjpjWDgf6j+zlGurcQFrUvSF5vyTyynHKBpd9Hr8cxgRnlkfGDN6ubWFj+ATiVT9jYRcYYyzkagqfubDcD3anRDNh6N8Qgz9FK83nNpUGxDgOe2yXKNWKKSB+FNsOWGxv7o41eFcX0tlUnxaqxexEUVmuCWQf5VcEiaFDROMpF5hJcSfBc2xF0kUfevjHiOjpYx7xbhS9jllIzSuIMoRXsOAlyoLuW2REHIurFZOleHRRQyZgQAEkmtaZP/nfnexkcGSA5OryF1dkZSK8iaGVPmtEVEglcJQLvQsQl2Wy3E9+9fvlep+imm7Nh6PE0a1SYYTNwIyDO4Yqs1aKUjOXXsmHGVAy24liCvmC/ppSt1yTRfd7UQX3VZlUzYqzF2CV+lK4agQjbHWwL0oJDGK8txupRFTXM5p3mSRWFQvNXLUAZS+gqRkvR6DLl8WCsdEjjLr7OridKOBJmSwH8s1CeOiuGn4S1wQHJadPR9FGyiZFarT84Zho2ICdgWBR75taQqSdJ4gLVfi/iIVfpvhp0IzlayWlWIPalkHIITcRxE4RPbmHbFUkIvT40sr5o6REqdhqJiHaopDWIzZkPJ8RQh/sFjBhN7cqs9vSKwk/sJVpZ7kjZYl0AtdHkTg+LsjZDPvMmXIGRfasIh1K/SEa/Anw9wYT7US7kbEVxZfNr+pm4shcyFmcFW85bM85mwChH2FbvB4xXDyesBWWTbQURTkIaTEgSNVYephJeAV8xpRcApChRSTIf8rggdJHT5+wIbCvEeuASueYZQCfLDYXgfFJpWih2s6HWQrshpd0ZrJ8xjy3tXGHpcN3aoCCPWAfTmH7JOUtO2BtcaFaxmUyz4X9USKRC0FUVtPPiXzlRW3OfYJzsDsMLO/mQLvsMPj1rSkT7xLBe3QbMjFWoOsKQYWh+h37KD3zFWKoy7LIsNR2GX55R3Z58yPMRPEZ+LfMF0LvD1lHkRGjfdWjqkmqcxzlkL1OPft1YDpMDDk5E5kQXpcZLiBgzjJZV87ORLaQPq5oc4BxrUuEWPGRgM2ZIrmK+wweubnnBEEXAe01nkPasuwz1wbvTHTqzqDDQfePwwT1L4LpmJQpU9ji9FrNyCI0UwybXX1evX7kO729prN3gyRViIbaxqvhthkITBaFzHxtrQnFddQWlNxHa2U7GH1AiEz5m5uK6Qoo+JCeThgMDBmMjbbHDxY1TNdU2NgXJmoIf3ENOGGjKTWvIs14gI/l+aZ5WvLwENmFE+Rx6Gi0BSXV2sA2A0GDpa0yKkCeMOQbMiN7yA8nT3yVhoXwsmxWIFgrjM6Y+ULGvdxBQzw/cgK2UsPRBQsijmsqE5RQ67te+7Mtkc4fLTUB6WYznE6ZDsHbI91e6xJ2X66e3SwnXXZUa/ZOtilrf2dg273cHv3oLc/w6crurusaNCeCTH2NpJyQMXpe6qaF6FPqNvJcG5ABQjHRzTP5RjZIuPaKN4t4hxYN4ZLclYFpIAHvxKUD6jqZej38hHR2lCoVAV3EeXOEcFdFoF/jt+mVAMGZznVhqeuvEJld3kVLfZAodO/0CYKES0dKK8YNbpuEHRDuGMOWgmPQjm+8Khd0OtSmcRyIT27YdDHGTVinuPkinHZdFtxlrlkxlYa++K5jAZWARCm5FLEIWYsUXYFFrMj+Je9FPWmjv0NtnWUfBaXwYSQDQisxboWjWhxPCmCGC1DV7q+rXQY1B1LATJf48SPdj8emxLhEQiznDYFgH0WeSHKJqoysOPNxIJgp/f1QCo7XDItXrwo9UwoQu6C7MBLDsiF2RpTXnOpPJCuakNcLr30rknY6Vz0C64HYdXKzQpb3Z4vpBhVVAZ3LkptQSWx6eOKFDq6CKb9XWMQFeXwU9KpyjWl4PHcs0E2UVoEGjukhlRgOolmc9QNP+dm0/3TmpXo2lQrFH+xQD4sdoXzTdFg1i5bUZE6UL99JuXCZw28OFWQH50dNbp1RQcJp3/FUECMoknO/EKe93AQqcIYVLEp6KZ38xzxPfZa2XVFKl/fQ3LPLNPc/LrHX6nfqm0j/EKFpJyK/TO7WqUcN5LkUn6yZiN1dUyYIVLkk2n7J+pUEU6IeirtJNvJbtUuhAyeKbMw/u5WqxCf9HabL/Qyk9RFNIN7QpSKPm/LJTVtYcBBUmcJWuaJsqws89Bq4lfDxSvEqZ2QuOMEbHld4aGqABEnwZVF0WKkokyyO3LI4pgOl0hW4jQvVSqaJZVC8wxuIS3NrCqWc8HiirGYD+RG7fokK/BAiyreet6EngxVYnoGiHMCzyMfBd6VhbG9ZeauaH2EOWa5AbxxQie4P7A6V/1zngoBSwztDOT+tjLGHN2fM8aeM8aeM8bc+nzvGWO4533l8FLcPsG0MQT1OW1scVif08ZmEXpOG3tOG3tOG3tOG3tOG3tOG3tOG5uB8gdMG0P98gmnjQGAz2ljTzptzHHRHalR9oQAX1J5RMuQNVWbHhXVpSJGUfCciv43mUI2l0TJA2n0DaSQLWZuf+U8MidvpmF4cnlksWPgOY/sOY/sOY/sOY/sOY/sOY/sOY/sOY9sYYSf88ie88ie88ie88ie88gqlHvOI3vOI/um88jMQLHQCtHFC17F390WL7jmetjbjZ1TrXlv4hNNKHTKhP5uNE0lFuWHViM4IzH0sxRyOPnoBv4YFEBLgDfnV+/PyPHV1f928o+Pn4/PSE/RIYPenB/FTEihlR8W9wok5cAODoyQC9YeV6GRHPrXzk/bDfL259e/N6Dl2oaPKacklcOhlf8O5KQcGu4DAKHE0NTwNPk7QBRaucbN8ga8P3Caf2gWIr15a8cox0WIPq7x4Yim5uPaRlKZiqUDkBPJ32MyzEwKQVvloJ+4APcPKPI0HUAzjtBhDO4sDYax4jwNWLA0lcNRzjVe1PUlzRG6ctyPa1FfO2EFrTVUMW7Vgr72X/H2uU+0X1jtr3B8Ov4MIIQQ616hIB41dGzDi3XPbxWJBgMBQ8AzYcFC4hvs2UDphLwO07nx+EwwAPEmnwv6zzCDBnqbiH7oGkiYtQ+xvb4hXPSZNiBc0MnLjJJ6hIZXPgU07fcRVd/uoEYAxTtz1rDHPbAypW3NbgqOPk2kdoWfPXH/x/XkLTQjdEa2fAyjf3SjNCqmOFlnn5PQ1IgaQ9NPyZAbxaCpEb6it66Om83m9hbZWKsjG/46j2Ar1BTXKrzuUxDuS7yYVjMy+uHEq6fdbFfyKfKtuiMYsF2YFFp8PiEixsPXE/S+I83SOxw8X2WLByn60F3uB1qMzP4tvXXVau4dzeFW+O0Wyv2AvpG1SobswqsYL128U77kKp7I4ZC6agVtxET0MQR8pFic7Dq7wk9EHN2bzjF9ZzfOl6Xz/d+/heC66H4tyQT+PhRPMRQPPQXisR5G9mazdZugSpqL92WdQ/jvQLDNl1oLLu6tgmsVi3spx0y1ByzPH2l1n4ZQu/cyxKSvVwhWsgyLjbGQ4yb0ePNem9BO9TaXjfPY+EoR4JG5umhXOhNW/Cs9mRbae7/jlq2+ayHhRrO8ByclF4YJA50V8wmhN5JDC/7NjI3MIHSdLI1gBOJzstcsNaaUKZeKCPmQLJih93cfpHw0WCj/7EEJOBA5RLjIwGR3sX4IArJoVqjwtctGj8hdK5ov2p2zk9Nfzjrv28ed38+vfukcn7U7re3Dzsmrk077l+Ptvf1FN7YrzR3Rd0UUujx7s8lEKjOWEW2oyDZpLgWrrLSE2hSh2b2DFe67K6IbXFaYvTsssK3lJvuc5gXGSPbI9SyanXRAubgmmovUXV1XQzEJxLVo6Uoyha6JOdf1Xtg35+dJkjx8ARCyVfue4/WIgJkpQFFZoaojagCZq/PXbKl1uq7M4VeLGhfwUK3r1ONKmwoL+WI0g0oiXNV3jgu31iD+r/t5y2tWcUD1IBlmeytavJOKVBR9a4ZwYaJ2x29O90jGwdsne+T07H1Y49lqCRB9fI/t9xorm2iuDROpiyvBrHy40YOF0Y0p12cIUSlXDO/dDDb3tUqSj5mGcWpXq/n6YP/k4PX2yd7eq9enB6eHZ4evDl/vvnr96nXz5Ojs5CHrpge09WQWrv3Lceu7Wrmjs52jndOjndbO4eHh4en24eH2/v7J9ulRa2+7tXvaOm2dnJy92l44d3JqBatH4Fdfw+29/fpVrNA4qu3x8FWsjowr+nj7b//w4PX+/v5xc2/37HXr4Lh5eLb9eru1v312/Gr35NVJ83R7f++sdXpweLD36uxg99XrnZOD1vbJ8dH26fHrhVOkHM5c62JluttpWY+JZbGt9x+Whmg+hMh/AmV17sFYzQ65vR/KydufXKEd8l5KQ06OG+Tdh5/ORU9RbVSRwh3eFaPDBjk9+SnE1Zye/BRHFC9G3v/QnVWpGy7oAzJqysoKCIerTGftigEG207IiCnLppY92+2LrdjWIFCTS2R6QD/Vx1Jlu2yv2zrM9rt7e+lBa/tg+/BoZ3u7lR7td+n24gl7jlxCmg7tmYUYMqsyRZXhqGFbVxwCq4O9MB4w4YuoVBQaTYSEhAWmomoy07ueZ/XaznZzu7XZtP+9ajZfwn+TZrP5x7Iaj6VFFwrGfSViOJVvYUK0jg6aj0kILHLzhYMcq3sJqvVQKA5kt8fbcyfnDcvzSjN9rNMykNqANDOy0mLewVPW7OGa2GNhiO5tCNACw5QYmZDfscBPOE7swz6gsVFWk6mM3Wd2hUbclZWJc3lcYZnaNYI4eSz5laRy2XVBuf2Uzo6Z06I8ISpku9dpMZzg73BMnMq0GDLh0hwf+ZTQxQjjEzrox1h5eJSbtl4vqjhU8JsBy3M5z+ib403Z3tvv/HzyptP+5XjncNfahOWDZyentz0aJlp7kA35ea95lNAc0voMv2EgUlZF6wuOmqrn2AgOlwiz3j5+u5FgcIydT2PSfHcyV/2hhRlIxc0EI2MitodIg25hXCwVpm5ChGaZcmy119O3bTJNBULWXdp+llKV6Y0GDF+JRmf10Sov/h6JlQctE2qBCYK/Stnv18kFoYGgWT95u2G/s0BBsZqI0mENagniNU5rsJBfeH9AjrUuFBUp8x3oT5Y1zKp0ghIKK6cR1nBYP9mAige6jgQf2o+AXxZL/1WyQ82xs366LDec/PSh3SDvgh1yLlI4XOA4LjORGrGtUsM5lb36mFwEBVPiki2rYSM/rZeDFxt1xHtjmc1KsN84Gz8CsnGluBUjHE+tyfq7BwqXc5E+Mj1o3ikEX5VqV0cWmhMLgaXOhyXJM7WrHoFEUNS4I1UHQkJXdzkbdAcsqqyInz9oDlcN0oaA08vavXNCc96TSnD6ECo8tnUOtig1UaOTe5jjt1ie283t5mbzYLO1T5o7L1t7L3eO/heYnw9F+lHN8Duxnra7b8W4dbTZPASMWy93my+39x6OMWaldj6xSYfmfbu/BsOVGd5uvrLQNhNM+YYLLqX2E6vf8O/bDzoEI7zTQt2sanNf4T31TRxgwQjLc/tA6n4qMSdhTeqva8PPocB1La0E12a0t71wMNIcgrHPIymYeEAllwpNztxwgQ0ypvjNDBOEu897Ir2/t7dzEC+SyNjnurCk5Qmh+V+LMM5tRIASEvyvED0e8YAe0RRuYLt8Tt7AdnP38CEoaaY4zTsLlxZ+hGQ8nNoXDYajt/RI1J7+0xc31Xs2XyKw9KrlowEVBRQDa1TLNJcXN2NuBhIM6NwqadYCDrc4leHTAVU0hVI+dQuxt/f61aujk4PTs1evm0eHzaPT1vbJyfGDJJXmfUFNYam6YgF9Xs3XjZcjABVLqN8ZUcya0szST1dr/hCnvvRkAcFM5GdJLqjokxM1GRlJct5VVE0S0mYshHb1uRkUXavQbfVlTkV/qy+3urnsbvVlK2ntbmmVbqUwwJYlFvwr6cu/XezsHGxe7Ozt1C4T3ihuPvAIcc6dp+G20MFv4cGqQ1wPqGJZ0s9ll+ZBXxZs4Soj9XR4Cm6Jx/dKePyeoltiWkx6hyOWP73FL9G++qm0Exrk4qc2FeS1oiLlOpWR36JhLc4EvBRflHuetDuiQpzHwPap+SNuExoVRnhs5J+g86GGFkuj+oM7EVxEzmo1y6irkAXCqXm1rL2zMHIrtBXnBD+X1mMoogKN4fBar4GX/HQEHUjmVcfRLB1t7+2rhS1Cpg3t5nAQLUCFrpQ5o2Ieoq/wZ9LLaQVdV57u6qJNBOtLw/E+dkyhoFXKtO4VuVXWK2om9OXh9kkXgy8IE6Aj2s+FECxfeGsL9tl0fFD+V13+kBnQZfAV4MOyhFy6moAYqkZ4NbAReqwcvz12ZfesvuR17PF4nHAqKKROUG01+yETRm+ZXG8CZnY3WXw2cey5PySfB2aY/43mI7Hp4dzkmd6oCYXEmqCRMZbLMeT/63qOtdButZKFGVYxXQxXxqxcTyV8ALM6GKBIRoUSljU/o3I3zeULsyjGqHwbGQgO1mUyEGbRfCoZCPMgW9EyfOkMhHjNllqnbyMDwcH9XWcg+KX8njIQ4nX7fjMQnsLKfakMhKkV/I4zEO65itWRv/EMBIfzSjMQ2gvlGszkF1TPK4S/1lReaa6BA+Q/dGdlgaP1yQYIyKMnG+wc7e7utmh3f+9gb5dtbzcPui3W6u7uHXR39ndb2ZL0+hLhDNrQ4Wgmvt4Fiz/FZIOIFo8e5bAIMb56soEjxGqD2tv3Dl+fOijmCJ2ZQMIvLnSeY6qfdkx1vEzPMdUL0ukpXF4+Ukx1DX5P8fLyG4+prqHyk77EfFBMdQ2yT+0Oc6Ux1XfQ4wlea648prqGRD/gdWhMhR8mpnoa6e8/pjrG+EeKqZ6D93NM9YIE+/FiqucQ4luOqY5Reo6pfmIx1ZXFeY6pfjox1ZWF+YFjquvp8BTcEo/vlXiOqX7smOo66j5pd8SDYqrrsH1q/ogvFlN9F/JP0PmwbEx1Hao/uBPhu4upng2D+aLtqFBtrfQU9mEbI6q0i9OE76XifW6ZGaNV51xKJtsLX9T4NVxxSPFbu2o5/4tlGG4LISIhohgOwZgE90G/LJV+K/KBkUdUlF0k6nGtw3MujrVtMWc7PNp5fc85+JxK7M5khZRRNGWhBeYxPqyYu46FiBo5YspFn/vGdRBnTiHKt+wXTolifxbQFUwSKiCYx43rmraBfKAko4Z2Lfn/LJiahLaY5S7q9Y7o4dFhq3uQptke/a97Ehhx+QoUniYifMaK8lGzddfBEPtmlwR0waldZs15YmSfWcJV+3u7kV0vU0/mARVZjuZtmIQLw9SmC8Jmmae8nqXybrd3tN3b2Ts46O7sZnSf7qTsaPsoa7Im2z3Y2Z8mrYf3KxHYT39vTo7fcQ3VB7w/sIQLLf6hMd6QUV0oZ7UDewd2dawdyB8zuD+YZgjbbPaa+weUNrv0qLndPagQslBe4Ln2Ch/eX7gvbmuv8OH9hW+c4Lr0EVdLD01paad2JzNV0JT2w/sLjZfv7slSWFladBWD5uokk2Nh2UYSnQ7YkDVcE+UGGVEzcCNIUobl379fwmq7S59i72ffslXlpWhaq9aBjDvfnwui5ZBB9oWVYZbSQzrBFiEuh+b80lJhyxLXUhxbV+eTRvDz0GlUBbToP3dlLu3Y2KO/DKAgY3Ab9aWdw/507epYIjVjCBEhC5iLw9DQg98wRXNyfnmzH8ZkIs2lcwRf/+sa1u7639dk/fzs6jV5/7oMLd4+2NneQJjiB0t/lPdrQfZAl/nen5kPRvfghhER7Nru+nOqcIbEKp9PsypOgQZLFtySoBh4b6W0B6ZGFXLbP4wLPAax/5kPl80ZzXBXmWgJr2ZH55pAaI1mhnArwVyKRsPyq5DGHhdqAv1pBnC4Vt+fGtxPO2KKy4wMC21gkK49ESx8LKueKGW+FD7cZWRtJPpRCUr7+lpiv4vmeiuNy1wYYxFXhxfoTxbO8rTzkGqy7k17Q1XS/2ujAZhXpJFlDiJFHNgbGG59rf/XWgPhwRHWNur5bBR5CKOWr/3hYpcLD+KtS6mMsxacGCJw7Ymb5m/XkVAycrQ2tY7Xf7vGu0tTUdI9EjUtxHtF/gVV6CfZ9u+8h+3R7AkHDYr50Mpl16R4Igvoj1NK3UnEVdrI6aBLLsh1ofLEjnkNOaQQhg6SG6UE1+CIFhhcyDI0gEFD9uIQlLvKsNgEem5Cn08/LOXiy93dnS3NqEoH//3nT+57/Pw3I0czK+/F0g+2+i8+iKHMrHqYlZIZtpkmmjFRWZGwCjUSjAsimEEVUApupDX0UDDKLih3WdAGusxKYMd0wCOK0VKhslojJOWSXPZ1I5zX0JPKMEH+Y2VsMKtcsgIoWBUBEHNc6LodXgvDUm3PizHVAdBGRQUU0tQLyKUYz4445+cZnhxRrac47IvmX7rpys5fcEAnNXCZwcpgMoMpeCKZ7wi5VgOiVA+4AUen1EvnrpgLmyzPlBnYdnfrb7d2d3dmgAXLfZXqGkzoNgf+2mWoteEvLqe6DrcwrqX/FAPPnL//Decv6nSxOyyeJbEnEK0q5ELad2HnR8E+GIYUwZ44bV7hXTLM1y1MeKoRTYbIolYYRoQ8KSoIG45MCQ+Ajk9eu7ddT/cQA8EhD0sYTg0jXWbGjImKCmbGEo2gGiUDM+eZYllntTbdVWS1l0CAuPdWqqXBaMSCDNBFF3+KlnZG843GwofBjF7rSRlH/a3ZRVqLv5iW1qhBO1pnzDA15IJlVjtIuWa5S2yjkHDtXD9lNIYuej3+OYwIz0BtgpdbW/gIPpFI1d9IyJWauG4KdDRS8jMfYmwT19be03w4yifEgFU/q1zb5c1pl+WajHmeg4oJZ9+Y5Tlgf3VxqktBlcqk+LRWf4zUBUYGLyc4EVbFH22Ybb64hQNv2oDB6Jjrl7WqNsJ/y/E5i7FnuFVuijApnBvO4EAVZUL+LGiOCpJ7BswzZwiWCNA891jjLQ77nLIRqhkDaS1I+1ohMme9zEiBBNwn1LucIptuGgLw/LqaJijh4PcU/crBH2d852OYOaVCyPJMrOywRkSB0isyjVCX5ZiYN7vh66VDVYLEtEUXEtUmGU7cCLhFUEZQbaIz3bls3CgVuxhw1e5eMMgwz6+66G4nuui2KmKoUdnOJXh4QjjjxufolGOsoYPKHi5GUZ6XDoI525rqha/ejRx1AKWvcDCwXo+lkANltVRkIkeZdXZ1cbrRQO/VJyHHwpK3XJMwLgrYhvcQg6iMxUG0gWocKNPzls6wqOtvKofAI9/2+QFnx7yjo1yJ+x8i8NsMPxWaqRWGwnxw09UaEDFMbgDvtC+/uc1rD1wKVzbOd+81V8IFKuxWuNCuLFDowqNom0IrZXZDg1PBeYnBqg1c5LouW/4Z0BsGHjQG4UtSRa44YRRn2qmtMAmIJKnAEhbwGs+8lPEXDFQQCoVVnLWMp0ckZIdhYRdooTygos90slpp8TZaYvTeSzUpSQ6q+JBByKjszdMbqSAXp8eXlrTHyOynYahYTCzeDsbRBJIkV8j41azMxWv+ObDtgf2Fw9dW36Tf0ueFLpWRhtVeQpeyWjv5OO8yZcgZF9owLpYlJ+yiJ7M/AJqnskGQNHxV3qXZ6+hQdRCo4prX64k2bLg1yqmxQn3pfYTYrfDwi1cdJ18W9KmSLV+UV92q+GNsgN0FFTb9rxysPYg2QQkvCBVSTIb8rwgeXK7w8YNmvSK3G/3avpTw7NryMn6wCF8HJTuVood8QfPqYS6yGrul0Cxbnu3rGD6t5pR9CWb3N1i6WoZhIdDrufkxgF/9kdAeSOVsYqlILvtRsIGeU6qCwpmwLPWUzFdWYiLU0MMQIzszoaiWGl7KCad/1qD64l9rn3iXCtqh2ZCLtQZZUwwMaNHv2EGXqEj3VE7BL6olhjAIb0Q9mcP/KSjHJVW+MfW4BPxZQX4UBbkk6LOKfCdxvjcleRq/b0pNLoF/VpS/hKJc0vcbVJVL4J+V5WWU5ZJ+P7y6/FQ0pDg28XtQfO5cgC+nG3ksfiSVp4rzN6PJVMF+GgqKh+lZ71hI7/Bke0rqROjH8m1rCWRBWfoIikSIYvvR9QNDVZ+ZJ3OKPAX3kiPJU9GcFoP6e9GvvrJjyVHzR1KxlqHMN6OILYPc01DXFoP8Wan7Es4kR9ynpPotBvk3riB+JTeSI94PqSPGgY4d2i8zGaNwRxJ/f6+gRxzJhz4KqL8CvQSGDPNqKOkqOY4qWgSpcDVgE5c5pwdyTOxJKMiYdX1pBsizs0Nx0S+TdFwBlyKA6xNkFo9TzJid5msdC272ujXnlwMpZtn3KwFakrq+MCLtUcVngP1OMlenRHnEfZ0K99VR5o38i+c53dpLmmQd1/R/JyeXH9z6kndt0trutDDk+w1N7Rf/3CDHo1HOfmfdf3Cztd/cS1pJa68C7Po/frl6c9HA935m6Se54QtYbbW2kyZ5I7s8Z1utvbPW7qFboK395m7UXjQsk056dMjzVSX6vWsTnI+s+whyxbIBNQ2SsS6nokF6irGuzhpkzEUmx3qjlrj4dC0+P162/Dss3yT6TmH2RpWIy1aE5m0Kil6iuVDLt8iKb+R/6M2sFJI6+cSUYKsypWdww9kDOliVio5v24m7yW7S3Gy1tjehXDhP67D6Doxs8jh840veRFxzG6P8s46a3mT7Wlzi53dyJmXCSN0gRbcQprhLtlA1rrmfkTqxgK7MHNOYCHTt5nVVg8Aqo4b1peJ/4RNyGnkuTFmCxx417ijvKkkzKAHMVGoNLJDFnOnIVnsXHteM9GSey7Ed2fXdLqtsQNbxeqizt/GS5FwUnxtkSFOgtOCfy6Q2R+/Zkkfv2mQiixcvlNWIKOSvQTqUS9t0hR5yrk3DlaKJ8uGw/E0YciRHhbVVs4Rc5oxqRnJmSKEhO4x0J5ZQws5ABZYNx6nOTtoNS9WRkiOpGeFRLjbNMui1Xp/fBKguaoVInay2JOTM3lhEdLaaSatOcVgtClF9znuopFYJqhg9N3lQFZy589vF8dv7Gjr2WW/iUFXm2zuTfkIOm9tJ609iaH9db2CS7oimn5gJRf005tFRTbjoQxkv6JiGf8L4VGuZcldh1w4hfOEP8LGAE8ZSIGxsGloZuMnwYPed2cNOe4v1TxJLgzosFEulyuxwXPRzh62hfUjnBelSQGkjaAfviTjA0jsW0D83udj8kzCR0pEuEErdcC6iOshIpaaJmYx4GuUVu8w0KG9GQ/EWzYSWiqyzpJ+QPxj71CC/c8X0gKpPG1CRhN+wfEKCaQwOREV70OlhihJcCKbmrioOQfAhh1y5wJqs+5w7N6r7rYr/xhwkb0cP8XPjLorlLeihtHTjQkUyL7+5CBLO4i5qeMUyOna9ZJ4chvb7IC/ckO+6vv1txNyee5OYy90pUsN//nE3ZODt2AUI9cjCrnC1Nr3jMOM6VQwcldM7zI0JEETjzVuXHldsTPNcN4gC5tcN9EzRjHRpTkXKlF7C37Ay5zogen6KxpVllbI/RliVelm/6Fm2QpfEu5GrvA3YgbtwUfxkYTTP7tXJJZwcRS6Yol0e1Zn3R0jNT7edJfYoqQx3j4xhWgsAmUkgdkUsK27EBRJ2UZ1cabEfaEAqe14lsaeFSgfcMOzsCgiaGYpRCASMLlOvQE115bu8zr8ZpMd6L75DOwWfgZ2r/aF9tmH/wPZROTz4X1MM0yCnvoqxVOS1kwoblRoBmIuvX25t/VnQfKL7BVVZgn9DD5I/x6w7YPloqyc7UMEu37LaaM6yPrNDb1UQ7HjNnulkYIb/+hUGCoBViVE++++N2gpjvuqjz/KuV2Jf/GvN47ZEvEGa22PJl/lYEQdBS6zKxKHUeYVCOoWCb47JKgtX9ajFRdOgvVoiVX8rvdF6q77U/W/thfuDRBh8426GmbWIvqhfCNjO7ozVQeWgOZze0/PWjTBn06U3LBlyoxisF8jMrR79EzZP/rf0hnWgVEInAlB3UsWskfivE2iFU5k6lumcoQ5x9nkktZVJJ7+dxZj+u5YzzoW1Ct+1CfYEJNtJazvZb8QFwaqkcRbu+8uTJFk8sBy6VK1663nZHd1pgjaHV/hc37J09duubhnn7LuzRUm0Mq3LUsZTxAml9fPTDV92xrUrq5Ryqj/cAWPLtgk5j4tykKJ6lewmcQP7+IlZ2lcHXmwLjQfUdLju2K3Esw23Z6b3SWWGmT1zfvrvOWu5if0pm83mwn0GoXY3W113lGOiGBYanS/MKraFk2xYXH3IDe+jaVihlV+0sJOyqfWrI1z9yqV9vtnlwn4Lnv20z//b/vFToPN+q7UEmS3Ddla6iZwFLhXRKRX17D6322mr2TpMlmEoO5dgKrlhIpOr6jtz5cqYzVNfACSCINWifMUE7eaLN6dMpWJJt9qq8C5Ee7mkZq5S37ZDYp0iRUXf3f83k6a1TVrNpOkqldk/SZf5W6mh1IZodsNUXMH3lVW6tRtRWmvf6rBaM62HEHAAJ8ool9x4Yg2ZUTzVZJ0aQ9NP5AaC40oPNBbP/czNpEFGit/wnPWZ657gIpkMU9hMYqNB+HBEU1OOGscl2THCuPa1voJh7VAuihFg2nCdFVI5YnMUmBqF0xsvwO6bmUwLi/JGre6+l+wtt/RM3HAlhR15oTv6FfLAWQziXcxAxYSEktHAPW7lGmSZlYNoFa6YHV8/saUzbDiS6qmu2pWD7q4FgyvoITUFLoAldcajUpHlMkHgn1vD9HH30QJUX+19B7hI3vpecxVPU+mUWH/72+lGqYhAvU1DDY8u7WF5gJ+p+MRFH64W1i7keK1B1t6wjBfDNeT+tV94f7AGS2ONW3KzbRc7iOEwInCInnYcQ6xuOZeBqcqxdpKmq804Ad9vxnpcRCRzNlD5cGXtIu6CJ7gmcixYhpoVFbSP/r/X5+/bV8k71ce2g2QdvrBCmHxob1pcMiKk2Bwp2YvaB8aN/RpkPJBWeHDtO3gYSQYsH8H5ATchmqXAtFYzB7liNcORFBHrGEaHmtBUSY3K/1iqPJvDuuImSwTXJunLG/AGbTrRBWxcLzz8BdcibOyWZ4WaTeCIWu0GKhpayoJw8QcthU6/hqlq7BixZ7ZU3LiFIor1qYLYlkh0LEfhWuPETpVWQLjTV/x5r3lUdRFDL8GTstfqPbzEL7BluYUCDh+8a0Ory25E7zS2mwuGj1q56krH99ibzLEnWT4huez3XR8scnXRJlYo441dxvscTlrfp7lsvPxfnjosLYzVN0mXC6q41Z/aW2/O35xVZxMu96UrM3gGDmiaTzQ0S4B2Mh5KCTc3n8Ie/933nIlb1GIIusaeXPbtBnT/CPEAEFN7bX+AHpLXCQzjRhxQPWDa8+Dp2ftNJuzpk1WbhStWZqC4pkn2zWtoZQctfCrXaF1WhhuE+128n0RA7MuJHtDtvf3rjYDe2Y1bVGrKkPcIjNkrAH9HWF6g6kYVFE8K7FyJ9IgrOrsrArvazjFIrk2uk6jr5rVrfuVGhJ/TnDNhHEEXv+2iOWxiexxB/tKqIq5DG1PX1jiCw1WMXm8fv91IMMbVzqfJDVUTe4KkM1sV/wmd5FEZidYLXGFdaANvtyjEPuOqlm3A7A44fdsm01QgZN0ON+Z5llKVaWcuVNLGWH3T+Bd/j3p7LKzRQOyLehpNyEMPcguUFef1HfzriOCaDENrdmLVF3KsdaGoSBlp4x3xycLd2gNtnkJjcl2H9uKdyR1OT7EZ+eKrjr3IQ69xq+o1yLsPP0Ht9rIcN3Qfv4VDKvvwodzypBuRv7FMZSXSb5yNl0TwqfUeX15YnIv0EWjwBFuQL06SqR2zJFl+wHblQpoONPxbANWsqlNUbSU+ZAT6LfJ0MKP8YoOhXIo+gzA6zTPocXFDc57N8YNvNzebB5utfdLcednae7lz9L+azZfNxcN7pOngPeaqMAUfzn2xbB1tNg8By9bL3ebL7b3lsMQ2Cp1PbNKhed/ul8GqwtaP/XwhHA4DUkylB8QnVr+B37cXPrAiXNNC3axqs0LAhp0P8XTBWizP7QOp+6nEloR1qFi0blZd/hwcRbX0sTb/aG974augiEjs80iKxVqLTnVtq9DhzA1X9uxiCpqbVBcbg3juj+j+3t7OQbwwImOf6+I2FkNe878WYZDbEAd3EP8rXHRFa61HNLWGL+lyU2/tbDd3Dxd3dylO8w7eFq6Iy12qN07t7+XhmAwsX386g9sKhJ42TKTRHQUA0HNRFdBIBrhiNKAQ+cHTBuEmyspA74FxHh4JhmluFSe45hqNMFmjMnzZD7qW+Ht7r1+9Ojo5OD179bp5dNg8Om1tn5wcLyx5gltp5UL2vFpkIl6CAFQscX5nEJQ8HDK4Gqz2WiROvfCuMfKzJBdU9MmJmoyMJDnvKqomCWkzFm7q+9wMii7EA/ZlTkV/qy+3urnsbvVlK2ntbmmVbqUwwNbnveYR/Cvpy79d7OwcbF7s7NV3bbRmzd7+5hLHgHOOPA0XgA4+AA9WHbJ6QBXLkn4uuzQPeqtgC1cBKHF/Cib+41j4HqenaOJPiz3vmLOb8lYbv331U6mjN8jFT20qyGtrvXOdysgHgPc9YPE/Opc8adO+QpBlMXxqtv1tQqCy4I+B8BM05GvwXwi9H9Agd5fzq9X2opxEvHkB1auWbXfuQmjTQvKSdBmEaVCRDqTCj5upj1J2d4qv8JkKKP8HjH/ie2S6s9W+Hu7H/NUW3NznuWudDtcfFuT4Fifc4ECC5UBqEx0kSDea89AafUTNwD8cPVgDoP3nlI0US+HWbBNursoX4ZoQPvFqfiUVPtmzAp/FLzF8yP4qq6XMAw8zY6YeHvI+xk6/JEYVrDo6UqQyrIQN5r7CD506PpqDelgfCDeDkJZ+oWBRcLI6/O5BertC8XO3ogWDLrumt45siWtNH6YTLrSJnOx30gjcQvgu8e8SnvltkeayyModcGI/+rgXRYbM0IwaWr8p3rhfMagprbwKwbulbUazrAMPdPyQ9smUaY3Bl/EeqWAOLyV8SPtRdfqyVtWQb9JumrW2d3ZvZ5BzOwI5Pw1hwQiup4hjj7+RY7tS8JDMs5hRPUAW/gSh8rjesdS1D9+63NEcHsAyTPj2aQJC4fmFZ7oH907NdV82jmYb0nTABetEVR9un8y9EJeJuO9ccfRh5x4C7fa37jvrSEmQYvdcOPf44uumWL/ULm+fo/Jo7fheLGQy/QS86uTCqf9cs73wN9BN7PmY5yw1vvc//mZ3uB5IZToomUtdwx/HON9mkAlzjs0AFlksCRNPB6iOOKOnxMSKCFb/Si3R5kxlJc7is4Gkm7IuF5h16s37Tbr8dK4lPvkbuXp3+u4l+UWOrXoxpCOscvLfM7BUDnpy+2FP5stzEmQ6gpB4zrXnb8m3v+CnmkHORU/G3OqOBWjc7mVNxKD2+1r2dOfG2Uk7rnjgu4vrhKU6mQzzxD2HSbVUoV9aSLFZvlltm+wQuZ3T5y9NpWaoH6IrZc6ouCd5eyVFILmuXPbZeaVOugXP72MGhNN7rXV42moerd0PnHdtAjPEcVz1gKQyY7X74DZYtFHMpIP7A+NnwQLLYhI48FPRZUowAyEmjg//EX9XM275e9C5qgpUOSiJufB2qVq+dKdkrQB9O89NU3wks3qxs9Bmjigwkujmml1cO1VRI8OXnelSZuTD+Wn9RHw0M0/lq/tPcX45OwMY7iOaPh7ZyhFnJ5PZzKHywMl8mbs5k02ZQQ+f0A9YV5nCzvj//T//r3b162ZBcmfE3x98GkU/d4Z0NOKi755d+/s9RUeEkzs9h3Q0CzKUY0bv3ZODO4KtHnjNckgRe3qgB8jqAVdslPOU6tk60A/j3nLcOZsmY6NcToZTToGHT1yOO2dicCn2ivzRUY4GnjP1HVrrshOHYd1dScZ7kM9siBlQDICHH0LdY1UIw4dsY0Xa/KJY4NROuXDneKlZXIYvasZ1P5Y6RXBo1OkA5dhkIQWAfb4vZdwMSZntcIvZMT2NHIuKA3oWwBnqevzh1ariWL5xW0WPiq5BbmFNcpd/ewa2exevn1sS6sHwzK9ocmtNk1uqmtxaPvXOAqozNFqgfj2pqWHvN81/ZC4/cbpJCyMzriFvstxB/yf+Sk7dLxMSP0cix92dPtCaoWIl3sERhpx3O+CeS9BJXE2PXMAp7m9RXLSM7AUAovqO9XNOOa3uNd0ZTQeubvyAVmpquJjElArSZYRxMyjpmpGswEJChipTjPz64kAcGmIMsaRHcOtDCs+IKjpkxiKmXOosrBszYNEn1n7FL+zHhqsFAaBB8hzN7RBGYwDV+SU+4SQU4VkDspsgl7YCEmTRGQ2UqSehS/4ZKZkVqVmckBDRF8S/G8ZamQG326Zdml0q077QoZTpejTzxh1TR/UdFpwZ3w1BDQH9iBe0PamhyCwX9XAUKl9u9g/vL8hAjjEYDKdz3AqQ3Eb0tFCsuluqXpY5s/4+YLANSvzGVAcWdx4pWpiBPR58IS5FhDTB0TB9fRmKLtVOiZ4n56MKlR3B0dD1Famj0gXQMcKHEnv0FBUayzhYWHq5HCPUdGQszPPkWeSzv2UNfOkjaOARzVQtNPfL1dVlg7yZtH+9aJD3LOOYA/n+w5sNEtUWWbPArVkkfDFV+0UIw3M1CLI633q5fUFXufvedbrSiJchkImIdUqnkUpunZKqvr7PXh0Oqcg2cy4eb+oZzWwOAMddLfPCMFDsyjR15U5MAKIc6/Y5x1J9slZd6OB1N+7ulajplyNAFYTb54UT5x58yYesDj143TJ9ZQ6X2vlI3MMFNxxu9G9fxalZH4mBlpz9QTyEY93NQ1NzPiYPVUG4fd5FeWgKvSoPuetnVr1xVozmneB5nHfD5+sL96QaU5WxrHyl3rCad1Fe2UeOA3xdklgsw5XASMnPkwYemyD5wzipj8iA0rGuY41Hv0zgLVX411I54V6R9waUM/84YZ8NRFRnvgdSHOsRxrLjkAGjGVMNq3w7u4Nc/3PztaeP/es6bqQmcmT8UOOTa5JxbQfOIBSc5mM60U65hYjxhtMGyZCadBDllEKOOyLb4aNrQElYpdXSy1FhmrOAuCOpzD1Xevr5hZb5yq8m1AMbKWlkKvO41mB1w1u+ENL4XAmnUJc3SSbFbotaF0PgZ6ejwKnbgRChUlFZe201B8jXX6tXV+6jrID6wY1meW+e4mEfSXpRr6QFFLRzV5lbW80bQ7q4JjnVxn8SOL4lDcwBSVbWpG2UjcfCcOiz1tAfQRimsLCGw8LF9Yl8MsUTMAG/Q4E9dzU9cKzzU4L1WKCqRagMP2SGOgqKrNx9s7PFBf2nc0/mGZpQ/TtKBfCeNVfAvWcttYScl8oYRd/DpqFQMgMeiDZP2IJjnudxmV0srlEpnf9Cz8yfc23ijolQvOg2EWsRvztQo7oYd0S4TfPcnOHiB28dcWZZ5gwYPXfreFhwESJadSKY6XQnhumOkeZOwN2r8MJSU2HrgoUmc6/cZ7qMabMUXvZFLtA/eG/kpmZbBLV4vjvwc9IUJPKMNL2KTuWHCtXKCX+rbLU4F/fQMge8P8BgYfdKjY2XoEJGJ9gXFapBgRgII2GCVsZGTGTat7D1x1YDyoRgRRZtz3k8ooeMijjDiAt83wrvUt2FEebYhW61pNa8m7MOtliIHeDv/hF9OFMqskA3femD6a9PUAfCryskHTIzkPfy0oDivnXDVHcLX6olaqlSGZf0WNryxM3mLkl+PrtqkMt3bfvvD1euuKIkUK/R6gPtXy/iQYidOoy03j67ODu5apAPl6fHV2cNcnp2cWb/vxxl6qTxBV/vxjWXfZ7SfKpELIAS8yrUrNXEyBqsK1rZh/cXaG8UI29ywJmuc6oHZH2rWoO+gRmC+Fo00vVWoZnSW63rhuc7hI5r/9s1DpS5knJ65sESrNAqA1YQopYFnJhXoSuT69nU43nufUN5HlMgHo1NH+wW4ds4/Bb6o202JRluo7YnV1Wzt/xTIUX5bIywffQTm2zidtdGKv90uYvxLUjcriD5Z8GC2beg+w9ehfB9MiiG1CJIM4wex8yDCE1uUCspVy1qk6Wl3VXWXIIOktc/n10RxyodzahKB1g42DBtHIM4VxY3MUtMj4MbjHBn9sCIZAx1z6Pxphdd0WH1Ni+6sbmFGq6dbOlh19VljiPYrMggUhGLaPR8Ze2vBor3zOb7y5Ppt8s3otvYSjPbqFzCVGhSTSKClajJkGld3tXOQfMNPuSmvYTDF3I63JkXt3oodOE67zqLllUE+jAMJV2h25FiwWJWdAx87/POoxpkzsE8YPmoV5TV8MD6UrLo5kwPpDTYDcgpAIqOy4P/PXyYzgWfPeI9HPEOBpjmnOxuBRbkHLvS9qlwpk5tc89VcP/ChD/DxzyqgLFORxAnASDmdMIUGEVOJkNhvEk5fhheFiq2sxTTTJhK74p6ppoqLvl4mOKwXxvVitI4ZFQXrupupDu+ib4m65EmqTcW0SLj0V1JwcwfrxW/YZXj6q0x1Nj5XeZOjRlym4s1XILCC9PUIm+liXULKKgwpTrMrpjb+yRnom8G1e6e+J2f5/wyvp24OvHuqZnEJMBdFnc5gebZKstQALn1a5Lg/w8AAP//p9r4zg=="
+	return "eNrsvXl320a2L/q/PwWeeq0nK4eESE2W9W7eu4ykJFrHlhVTTrrT7iWCQJFEGwQYDJKZc/u7vz3UBBCURVmC5ZgZbIkEathVtWuPv/0357fe2/Oz85/+L+ckceIkd0QQ5k4+CTNnFEbCCcJU+Hk0bznw8Y2XOWMRi9TLReAM5/CccE6P+84sTf4Nj7We/c0Zehl8l8T0+bVIsxB+7rrdjttx4euLSMADznWYQXuTPJ9lR9vb4zCfFEPXT6bbIvKyPPS3hZ85eeJkxXgsstzxJ14MP+BH2O4oFFGQuc+etZ0PYn7kwNPPHCcP80gc4QPwSyAyPw1nOXRPHzk/yncc+fYR/OQ4bSf2pvDS5v/Owyn05E1nm/SF40TiWkRHjp+kQn6Sij8KoEdw5ORpoT7M5zN4PwCSyA9KPW+ewBfb2LZzMxExUQzajXMnScNxGCMlYR785iWSHf7DhwL9nviYp56PFB+lydS00MKuQ9+LojmMbJaKDD4M4zF1pAanu6tduywpUl/o/s9G1gv8nTOB9+JEjTZyNJlavEuuvagQNGg9mFkyKyLsRjYrOxuFKawkTak8LNhhIrw2o5qFMxGFsRnXW0l3XjlnlKQOdMQtZK5aL/ERRoUbYHOn0z1od/bbO7uXncOjzv7R7p57uL/7+2ZpySNvKKJs6WLzuiZD3NjyI/7lir+BjXeTpEHtoh8XWQ5LBY9sM31mHkxez+fYi52hcAo8KbCjvSBwpiL3nDCGqU09bAQ/l/Nz+pOkgGnj6fSTOPfC2IlhDfCY0YBoU+M/PSAK9Zc5XgqrmydINKCwHKsewKki1SBI/A8iHTheHDiDD4fZQJKlhqr/s+HNZhGsMY5v48jZGCVJe+ilGy1nQ8TX+AnwgaDw6fv/lIkNmybzxuIT1M5hr9cS9EdY8igZS5LQLpEtyj0hCcNf4ZPy65aTQBvT8E+9G3H3XIfiBk8KUNKjp/EDkWr6YHcZnHE/L5CC8ETm3ACTSoocKGUOQ2kM0BV0nkr24vi8yDAwoJiIrfMAS4vrDF1PiqkXt1PhBd4QmG1WTKdeOncS6xzah3NaRHkIi6H6zWB1wgwZwUTMTYfTIRyeACYHHSWxfnpxSX8WUZQ4vyVpFJQWK/fGnzoX5d0fjmN44MobJtfwXbezs1e3iq9grDg3+W6mDwD05wjPn6gZV3feP+2NxbttZ+Nf5Q0GE4zV3pEXQc/6aJwmxezI2andXZdAbHpfr508ZpIRew7MrMglyxzlN3i6kNnmeC+OZJtePMeV8PCURhGeyxb0k/MPsKGSYSbSa1w03sQJbr5JgusH3+beB/hqCrcjbLkpPqCYjnqsenrhqoj9qAiE84PwkE/QfKENbw7sMUuctIjxbdkv8B+6B2mi7ndyqrLJbIIMFXaP5t2033H8XhhlakcykaDdGE9PwgTCsVnzS2WTcAulNqefAPcQuC9xsnR+9VTpFkACxHqPAm/JgeHh6qvpHjln3KGPEgSMiKZN5xkPaMuM0MUt4UgRZghPuda57l28JmFG3rPlKck1h6Fu42RCuBwdszts/hwkQhGPGDMJKLAZeL9A43gbQ2Ow78YT549CFNh+Nge+Pc2cKPwgnP/2Rh+8FtxuQcg7BHa5D2cVHlTLIh/PCjgcQKNXMM/cyyYOz8PpE8E10fho0lZXZLREHfu0DIswClzFx/TXded92Zm/9dwvnrHTj8AEA7zksesSKUdyR/DaPdNNXLIUiuwd5aJYNgAMQ51POHI17dEZ9HghWIrRTeLZACpfhwHcDCDWZDPhh6PQd/htEp/CTAt6mrIlfgR3dRr6uK+0gPvCPXA7znNvGhzsbbVgeYf0NX/8zwNvZ1ccjg5Hu53RfqfTHXq7e3tiT+zvBYfBS394uOMPu50XvtWZQ/PKnZ3OTqfd2QFpxtnZPep24D/nvzrwj/Pu8vhfJWqPPLgeroheR84IOIBYWHYxm8CBS73oKgwWF13IJXrghVd9OkB04JkwwJT5CRCaz9VzODZ4UdFtlm1Vt0CIsg8sD8qWShHw/DTJcKHgPKTIYIfAnwe8g8JgQMcTD2b9Ch56e7gQowUC1ZHl4c/Cuzj8A4Xm1emhBTfkZMz/6L0bkhCBfxO3C4Nbpx0sTBv/bGLiUi4m9mxfKQsrDpTgp/g+ZclmDEoCCcbwK7/GT8uvJyKajYoIeTByFDlr3XB+k4AOyPcBsAvYN7EvBeXKhZZhx3Sr4aaSUppjpDQx81LiNLptGEQsRMCK780kBG690JW+GEBAw85QmbPmDeId8CN1cdFU+UZTH4HcAbOPxAh09Oksn9cvMdybC6uLC9fE6l5CE8uXVV2i2BHIJzfeHO61HP/UNEclJJuorczLLXVCfhcFRteQLNaigKa2eZaPhOwImtOPkGwEm8TeEGYlqxujtCmmIKSiYlpP+mpbiv7ykmhgCX6V11F5EWrGCteS22mn/k5Zhs4qAnSRJ3EyTYrM6ZMkcgdhugdn07zGAozzvNff4kMtRWM5SLjHY0GmjTO43dNY5M5FmuQJPKVH/fzsYsuBDulunqViFH6E1SngomKpAWWBNImwOeSZcPKnQC04j7C+6QdQ/9DkkaQob+s2hwLk3RG+AhICtAun2gvgVAK7xZN9raR7bC1IpqwKwNaRRhaeyHSawBH1I+Gl0fyZdQ+T9qVHnIDKPCctBwYbymm6K0tscTEdatn6Lpd2lGgxcmGR5AXEbaItJfFJ5pejXFhAKQTrj0uCilxj2Rgs9fkWLA92APe1vuMy1vD0svCpOqvQo7RJu/vdg5cLhEjSsReHfxIDdusvrocSZEjfvqquSIm1GoNFuaeaL1CGyaqS2R2Et8ravbHmT6Oopd1PSYK7+tWr49IJ96NwQU0+tj+7VU/uyffxKKt97mVyY4d5iOeMD5VaZnnApZSvFHvWf1Mx9tKAtCJUepIYpDjzPGtEw5AN0PABCJCjKLlBayEaD0q2msvjC9kq35lmmAtjww/wcWtkdLThXGttGJ/p/+PcmXn+B5E/BwmMemHzzkwyqIWu2LqKQmqpU6XGp6RVCDTKKTVTUQmYTpx5NBjX6Sdw0SjFDy48ehJOydTZUCbjJN0wpiTgiIoTyqHElQlmfHzl19LEwSsL96JS8cnEYRFAHmscFiyRXGbThT1+NtvIjaQ6wPuzyAokiGzV2BbgfRjev4uYF4BMDWw8UKb9msYMfUGuX2gSRT5erzZxAWU91TZXbm9b9aMt5nSQWIhEQ2wmQNjLQSPEewUOsZQ3xUfWPFos3j3Tcp+SOuGx6xCnG/4pjN0IJypS0lWzMC88uRwg7M1BMdV9wIHXlmh11yBHHifpvIWPKrEoy0M0fMdoOZH7ls30KDrBkua4PZCkSDAQSCLNBL0ZbNxZClsS2PI9LAZAG6BX1pTWSKeADUdyz8kBSMlMs5/pMBwXcCXBpGiX0zu62RskVwZtkdsC9PKMDLhnFy00EPDNjt4EvLA+woO4f1zH+YehuJZUbVkN1zf1btSY1HkYuPKDAZOxLP/GaHUy4m1QsDGdr92BG84GOJSBy8MaoPFwBpSViglrFSDhGFEVuY4lihopzl0LC4YWa3nhHvJCyVY4z0V2R+Wlso/YqrbYRGmAP+CXbC7VDk555uUWY5Zdv+yHewsD5gPUgLol7xTuz10Yx1gkrg9axFVD5pVj1FiWrvBr1JOEZewtDTNB1zFM5spPgibGenmTwD2dw4F2sMeyA1uPZjNbPp/z3qrWz/JEG1qUc8vWpTuvn1CSghjUm4oUroUlgy9gDedXYZY0tU7H3KVz1n9DC1U78uPercNtavvLoS7dMcde7AX1lKU77e42GnjlapaEcb5sLK+AD4K8F7BgBhIq/VI7qs3/cTYicqi3X+y6B929w91OCz7ycvhob9/d7+y/7B46/9msHfjjXmgVszXw4bYSvKyvWOVTJATJis1wLIbDd2NQb0BKT4E92RIUeqxBkiO9w5KUjpWApI2ffHrClEVqX6AYILUv0AhBZmJpokVGvUlodBtz5fDwImc2mWcYpaK9t77imZk1hPMkt8JZyEsdsllrSpIPEF7Ntt4UOExAXozbgV+7ZjP41ouaOsGbF9Qds1kvAxk3NP5djpaQUzEE+FUGkRidQTrptENOO5lhoT7EyU2MGqLn4NSoI3j697MLpzRHPAokkF9jkMMNSHtAXbryJadg1x79WE/Xl3udvc592H4qxjDDJhnnW+rxU3yz/cvxbeNtiHPKsS5lnL8UYijq9zLqVX/aWskjigxoB8HosT/Jzjsqbd6W9pGf9c571nNLJyUv2u1eOiaxw9v+oRBxkl31wrQirN5hk4WzO1Kg9GBpfnBclD6p5AWWL5+fXVzv4YmCvw+2FuXMqec3wUde947rB1hx88RJrv36wC/5hL/98dh50dnbocgIDurEOMpTVPMSPxcwSzJnYCTEYXsYmpsVdYctdspLEVFGCt4kzj+L2Uyk6AT6lzOBlQ2EH06B1wUh3L7kRUNxEkdKYXG6TTl87hgZVwxKaAa04LApMQZtw+kXPkVjXMsHZVAde/94DEaYmMxnE7HkNuh02vDf/in9udve2a3xv+Zu3Q5aeo8v30Wbl2hMZJsY7CiYqbQQcWDuee9Sm1ud58Idu9IngTeEbQgm26JyV5Rc8vpitCyMaMIkNxeQO0o8II4XoRsN7+kRnKcbNHCRRRf9IhiLtFlLhBnIwPc3Wyh1M8vTcLk9w6YQ9vc10YgtnPfUxktUuOCW7q137yyOrXbtVjET3L5uF3KtbMZTHQPem8BZUhFcLbMCPI5sjIxvEo4nGKxuBqHoyWNp0QSBYwVqGlkxVMYD3fKPJnaBZVyrOWmxRFkMo29d+RxGzm8gi9ywP6gGVXAksgyWwMDEdEqSPHBVP8xQFiOR0GMrKUWoUTR2MYxCH4Y6GoUfdYv0zHMM4D/a3uZH+Am0t225zmU6J9absBD5MUTJmQVI4JtZCKOcY8ifvd50n3sYIw6sncOQWV7EADsyBN4I+A1nf/nqxETFbfiJW3zYqGe+FkUWdosmf5O7RHdKB0arT6MC2cUf6EAAQUMvNUd/cJynpc5EkdpCpLuge0LMchOOSa8ZH/nCsXApegLkdQ+GYLlonIUREEMKuS/8X37PUpfR8UgZK3CtsGfYZMZH45T3W8uigI7aXpgQLH5yU7/9689K+TzZtN24ublxBewrdzqXLfCG4RMDX2y4dhgOOW+4FUx+0EHXNFcSfXQ3RurcgM92XPijWzqUrdLmNsNjJUo6A1Q0o2ljo8VnMU7w0ggjCiQAcTVZEtiFk1hVYs2T2RVN6QtwSjEa4aUIkhWMQm4iSZnnAs72VouVS61ZmjXR7TK7aSknLzEO3M5qH1kHyF1kqtV+n1l3kc0zaI983dyUOOkyRmpW4u4slb5b2E8FSAtus1vJtq1ynECSsvcdB8MBR1NBnqZktOyKhRV8ddK7oJBqpsSJbsreQ5v1MxbwRNTQhNEA51CHShFz6weFnPjqG/R1IYE2M3MRkUnQuwaCYeRmvRkggu2RO6cY+CesrVuiJznIn8zmptE0s7t54o0Fiy8GRqvcAJ6zisUkJ/L2DHQ4VB3c28beoIHcXjHuvH5gIFVMGrPZM0WJH2K/bGJNU4H670IWhicZJ2ZWJPHcTptjLc3aZnDWZLT1gGaFUfcYv0C/4GwHWrCBv0e8ppgFYPWJFuFFWZGifJdsyEYC9JfE5zMZlyzq45lqnySn7U9QG2f3DOVfhXE9kSxW6xGrrSdfmkSiqeCiXpp6lGRJm516Vj4rshuX0y/rXHb/3PgQDr3Yu6KoYUxJTAVpHPH4ChtVyYmfoKuJykyKoBqUaT66LSaTs9odPs86JocaJMUtHqWezmQ102JbJmcimANOGQm35d+NnNcm9wlNsiZxwsOM/x3WPvFYj0TuT4Cw6LN7Ztp30C7LSY9moMgSyhm8paTLMNOB9+UhqLT8IpbZlKmYwpjV0w68nsEhtnqqjozH5Dky2U9NyA7Fk69Kf2M52ZgbNQ1RXqPsXBnQsNkwM0OVBLtPCJ5PzrHmruPNS0M47pvyPO2AojDQWbySXYJcEYJSkdpmUvK2hpSxikILMrQ25kRDgyK+DtMknpb9CGbP9X7r685DWAUZqETnw3nz9ifnLODsWgrOLaqcu16DOTg4ePHixeHh4cuXL5eSuUnX+iKhFWv1otDLbqGxpq2VwvI5NGaxeYHKQZiBuDWvCou2DYKRO9qBuF7VFCEl9DDCYKl6b+LjXARWv+wtDFWgJXEdYmaGzdXeBUXWRutLu7voH1W5RM0d2DOVa3Z2om4xmoNin3UTCNvdnd29/YMXhy873tCHBessn0mDZ0LPxc4WrJ+N5QilL+oT3h5llK8Vt7dy3z5J8nzHnYogLBYt0hJI54uweNm3zTzrmEWJNVzod1pO708UMcwnS5Kn523Z0apcQtHmy/BlRR2OJ7grXZiblimznIVO5879aYM57ekX0HQ1eWgArqKIDUTj3WQtx0MitJyxP2uV9A5MsUN3vRclvvDies3hJqt19SVNWWNkhMxnXA+LzieJL/FltrOSTFV2sY2uAHc9ajJFmE3Uc1lFMiZYEiODKEsMo+SQAKI2RMsRYxIwUDu4zpxX3nQYeCBhHF/A/6fwkW6yN5s5pzFokvrI/PoaX8HPJdJF3cHz4GshX8Of5ZBbcqYw8BacGhBac/gsou7rjx9/t5LilgTiCuNGPFSvKhochsH1K9/dpsrB4mSiikBTspaQPjQMYwyfoyg73XW2ekIpgwvc0WQxTEBL9uKlIfz8NRmXvBmpmiHn1srx4RaT0Tv1GjUCqm2uyvWweVS5m70tLbOQEstxIOzeYQSXGjyDBQle4swoQC0QGOJi5EnEKYwtMlBDoLEHiWVTudSaKIVFwYw9DtqAkwKc97s3fTie0XyZ92bqYr/C/TjzXfQmzVemew6L2lgqWC8IQpkAunga6AYSac4uaSGHVk9/NFdKtKAxpRmk81mejFNvBjzdEWmKWeI6LNZuFe6yMLDDl9EqnRbA/mV/zivhXaPH3cpxHKnAM3rVvKLuTdO+SVdDVS/2J8L/sAx85vTt2zdvr96dX7591788Pbl6++bN5crrVzCQXUPhpX3uriTIa/ZVkllMskqI+C6w/4GNprMkrQbn383DLbxpw7wBu3xIBkHtIWAdcQAJWKDYggRFcw0/sAFJVuULp7/8/PffD18f9n5dmc64rcUqdP7EdbLZx2xLtgTax6zm6CAgUFgJXQ8DL1dxn8uOHL9HYeyMmaeSMVtkI0T5zthF7WANJGAZKA1jUWE+mcSdIi8bIRXBKWYrIPOIzYe7+IipPCC96+9v9jySaF6+yUES4xAhb4xm0LwUc6DlE5QobXtTLYv0SotyB/53d4IZIY1EKc1otHxW/vhW5AP9cDm7XeadL4B3WnCBEmBMtqrH4shoZwuGFXep1YiFCmvJhZhubjnwyPTMUau66UwateM5yr7o0biHrblJP5shShgsGnXCKWJXfiE3LnWuc/d4kLhJGZwtiZcNN/fGDY3W7Ew5Vm9cExVSwrq9y5BKuLefwL5dNN3RSCSQ7B3Bxh6ZOOU0EG1D4PPRlBGBe0NJ3xvzBRVmZmPVKkaMz1viZ3jmrzLM5Z2WOdoJMoO+/cUnUU+tthSHQRxODqSD+5Gy/dRdGosbCapo9wGDIgwXH2H+DFptXPdK+WGTmKHjXKxHtSJMcLYG76SSFogXboEpH9ar0m83SqIoIZxfoDgQ+MgZ/I81YTIT/6dd+gh/zkRe+ZRCUmeeL/4zcG2o7incmZnxCzKICAkIOvJh4hF2d6qkg1RqBBhkJ6MVDR0FSTjWTECxeJ2kFYwWuW048nCUFHHAE0aLlARUp4hX9se4frI9BI1n24vbISJlSRzddp60YeRt7baBXtvca5tXqc2r9E98W8OtZPm/9Br3YueU386El/qT0hoABTI0MFRxuYae/4GRVgO0K7G4pYwula1CWYfTrBTWWXlf5m46J4XgzcEnCn3cmBW22C4G9GYSBI43CDYlPqqtiYiSaahwc0qxVLV7X2QygkWj8Q7eD1rOYBv/+A7/+P/wjw3843/hH/8v/vF/8A/49zltK7NNttSIB60BuTUHfxu4CjQ/E3xkykQn/B+BjhKK4DHK8pLNMC5Aw9gWsYLa52a2dTPbfpGiUW9bUrjtw4Rz0SYquZN8Gv2t8o03C9szL5+0MTNqmv3TJuG/7iF7yEO4AlfGzZaDrnP1iYtpw1hO8PxYMLJotRzzGZ0SEnMm4kwo1U2qY+91u+8tmU0xLvd9vIDMPEC75EfXo0wyXHPQ2UBanIiCfoPZUBr5wG5Z5D5vvNKupaGRk/smJHEwZ8xT+jzg2g0TFLAl9WACud3qjdBgUsxy328QoFLov9/QUXbqXXrChfFRCIz8dCBVILtV6lHDI3LDwNkGNTx1APT5QcwT0q4qm9husua6gAWEsxF6OEnUOvCq5XiMgR4b940B++YI2M3am/Lofew43zmvkRHYqPqD9oC/OU8oRYnF9Bgu9Y7FyTfq7ml7nVfV5vT18hg7vsdRTiptX/flkpxLP2ptRuJYecQV6aQC9W0iytsJVvI1qhfYMuZuIDTqXMWFCJmsrxg049N6c6VY8y4rHaU62SCGIUgfgmxjiLlVM1wEdFxQurkkL9ySMBy7SR4ZxbwrddPOXicj30C+PXAl+KouZYGKHWGEEy6X3S7ePgTXpN9dvqnL90p5D9ttyu080Etjb2aScAxPv2UX200+wIY2iPsrm4NW877fdSdvgrgBhEqRtMSr0eNuMy65j2woCb7Jojlvadj+llNyA0HjN2hTbjCid7bhOr8h6vIMrZV0EDKuzrGBFViERSh6K5vH0Deu94ZBxPNSZ1SgoWSJjykZZ6sYLyxMt4qwv/DFrcK+9bgRVtnSUxUuhSrIUsZy1FUZGAhvm1NR3bJ5gsySBpOPg+tKMIEtmcOK0o2CRqTQLQn2xjsXF9iqPeDVQiWqha6ZmwU++CnYQb7HR7o6kHKwLAHWszogETegzDSWTDMqW2PDGDN63DMd2syQfGT4isszzpZ1qMhQJqbaDDZyZKl8D+1d3bYySUtfUxlfgMZrl26gkFi6YOP65xQV9CwZCERT+qvCFyzBJt4JZNDENXINowcCGTSpbWj+XoMMrkEG1yCDjwkyaB98hXggC6I9NaRB+4pbww2u4QbXcINruME13OAabnANN7iGG1zDDa7hBh8EbtCWh58u5qA1yjXw4BMFHgxn5Cqw9tMnkPVECVIP7oBrvAROXv++VQeqR1ckXShPHmuQQOusGC85e4r8MvSCOcOiInVOBCWFPM6sHxs9cEWF+stCCJb4yFPHEQwW9P81mOAaTHANJrgGE1yDCa7BBNdggmswwTWY4BpMcA0muAYTXIMJrsEE12CC3zCYYBBFlUjBV6/umA50F/gJcrdE4TD1UgyhCeYY3+pbWh8ap9iamahsZ/J2ya8pcJiLslO0ryx0LKsYJ6C3TDyqnFDqZ4MFYIM4QoqdUoiGKilGakIi5/YyGc2sdUqVEHSkRvOdc8ITaINa9EH2N3eeD1wg42BLVnlXxjcgwm9hHCQ3mXm/z8N9wynu8GKW1L0HXOBjmwT3hbkvjKU0jDn8Utfg1PPf9FcPsysjlrh/ARCQyozWmCBfBhOkugxriJCvGiKkupzfBmJIZdZrAJFmAESqZF/jiTSMJ1JZgDW8yIr0Q2XYnQb7TYGOnuxzl/caJwjY3YYG2v+51/28ke7sHzQ3Vujs80a7b/m0H3200NnnjDYLhJg1Ndr+yenpxf1G25DIUTKRSgWseslSwjTFYky9WVYHRjEK4ZokaMzsQz2D+YDRLtHujmv09LujvHp5U7a+H9HqTbPBThfoUhddePReKsfv+6TY7u68/6zJCpdSPXPhWwCaj44Yc/HOsbt1ci8di1ybjpEktdP/eLB3jxmioODF88YgtVUVU+52Yeu2VMp1gDYsfAo+bBOE1KPJ3EAFa5BNU6ISV35PQlx41eD8u00cu7qimrDNz1x2+xmzPnB33ZcHnY7bfbHX3b/H9MPprEn3RY+dFhraC42ssiTFxSmfbMS0kaNy2m2KVqHHnNI44Rvpq1c61yjE5PpZCtuAJVRKLwSly/FGmHSUCqamTL9V5SpQ7m7TnI2Mi1GB2oSRMcJG4hMoCygTnKF5w6EelIjNsDrwmgF+wdFzpntZPk5jfhgW1waHwfBBMSdmxFBB+QSBXdpoqiNsmJ1Od2+7093GLHgMC2hPMWE1FW0mThs7RABsBIdZEonqHxx2dv098XJnp4s/BL63//Jg1/OC3YMgGN1j8yQp6A4w3Ss6RI0WJpAn6HO5Z/+id3Z+6Z7+/fQe05e6e9Nzlt1+7tw39NXx/mPvVFnV6ec32j7OYsTGSs6POKs4P877d3F+yDJKMpMHO4cXnT8KQQeZIKri7AajajXoAHwvSylJTV6EdKZ1tDiZvmNMeFVtIbx/mJCbA2QKmqNsVjb6fAATIFyyI3p+sOWw3DFXnditU2SCwmNg96d02+Q6R5271RAPGQfreKUgOTkGtjfAT8KspU7DoXYWR8mvDrbuk/Bfmvm9MAkX8EE8csZZGBKIx8amRIpPwqB/7tfJuCo7EBTEvNjqbTgv1T4vGdgpxAHOkaSVybNXi8K0z4TstQwjAC2fHvfNeXgLl0IayLaIzxN3ti3YUzMd/lJ1jrn68Ba0J5uvJpTh+uK+Y2QUisKmvARB35QxP/A5tb+dXu5MgXjTYtqSHz4rY0BKBDVrrzEM0wAHRygUC9PA8BIVONNC5ckEn2JrPl3KIVkacUaIjZdkWTjkoJOAMFhQXrWQcBRaYWJt4YWBQkM+jDVRaIJ1QAZy3n7kNYY+wWUYPM5p0YukgCANnB9sKs4aSGmn1HLSs/OlU7JKrzViu6ZZWCyUA25VBkD5IAmP66CpFEp+FaEoMhWYQwA/xNUUqewGFU1qxY0uyKTyv6XUabruhh2DDbvWqiNSmRIG51Igs0WzMzJlkokZ6HB83nt96hAkloQsTKJrlA4tBre5mTGk1MBiU7mFXZJQjTq6rEG0zGYJkl67tqxG6GzDudb8DgM/ZZhotU0pkzkDuC8yDYYxwGtLlIBhSstFMdW3hNarJcvz6IHgRnRyH6UfXZPfEK8EIgJRpXZllHkfyFzqECONRsT0SuArYeZ7KQzNdX4XaaLAp6ZkCp/IIBHmz4aww3IgDXe1BJBi+eZusALW5cRUv/oMPkZ7etFUKbxApFejyBs350xWET07joSMQBbNI3FoJKUyNAgWVkIWO3J6vZZzedxy3p7A//BzD/4+hv9P3ixxOvxz4+0JBv687dnBPrdhcT/aUuJcOU/MdkMCA+YkRSkdgYA4Tr0pb2M2i+aVE8H5HSA1snvRaowgWmehQfdh9pMtsTLsdLuL1ZOS2ZLM6kcljIzdSWL2RrMQyAjv0r0I6jElZLHcXRLFZQCeyDJECLcD/zE1FegtaSuZaK7ctdwUi/dENQqtqrZ7Kw1/eXf69h8LNNQ8+ovJPqmUgPkeY3XrzmJO6Ypp8ianK7wy5GrOD6MXl6vFxkncJhMRisE2NPRzTlDa3SGYMByF09052LLzfZKs9Ia5ZOzkZBB3YbAe5gRivi3MRCUVQx/vT05OrETuHxDJMoOFmEgF948iIYgk3bJsCnaqN8xaiJ6RhojrzhqTRG6OQisZfyREYLdAQMypTHB9n7ec9ym/9T6m/Sqkb/t+UoFe/yeXrLlO0HyKCZp6v3zhTM2wZHyRM78tvfLZV55QCAd5+WKsMwfXmYMPmTloNtaXUYGkpvhpiaYH/9TgDrAqf/VQoBy9BYsoUPzsAgVOQfVMB7a1aFAx06gvB8qyKvdZCJvCB86DW6HIYC8Phe8hvHyiIlph2vlcqYTPyigNGarJFv4+1ozNCddbj88CYlUDReO6YOztOHMtQg1084zcD40r6yGFQxIKN7w9Jbgeq2mWRfgl+l54WUhZCLrF6xBBhBHUnEUklMRhEkvVOMvwhPqc+bVbp9gpmf1LqDKq7+VwUedvKJD4c/G9Pz8kVp4s7XFRQYRBS64ISs20UctX5TwpUqtOg+WxoaBLNIZn+JDt62nRB3ZsJhe50O3C5tOtjHhsVafNXUdhBqA8MNI/UxpEpX802zEV4MqV83+ezNgqjkjnCCSd6NtK6qF8jLbQvx2gX4osXRUY4wqTWO45Un4W1E4l86g9D5ZRXhed9Sv+udPju/jnXsMo2rZDQZVVkx6D1avfLg26qASWpeKPIkwRMQoDQx92c6OrQ0Vh0CWq14HL3uQJluDwM1c+NOA0XTWkSjwx8TJ0zFBOD4U1oHuQtpq1I39DjGhaW1po9MJaUqQq2tBuSwO1dEDhgCgMPQJ1BwH1dSaWsYSZ2dD7VkJWJHKuMD1OZVSDF/wbh6pAgrDkg7ewJnbq3JIt1nU7bqe8wzBHo7LHrI9WSIHzYsvbKlM/aMvPycqjafqOy6pMWZDh56RLD7Q/AtUGyo5EznULFPOgzFhEEIOTxVebtujwPghzkN9GFqxJzK2798v6aiCGkgjNprGKG4gH/kWrDNbkgS8ZlTTR3XFolbTYGoIok19dHWX/wxXVlvjGsBUuOY3Bp4IOqowSckAiGELuRORbhgHYsgnJcg9Ud+ShBH69kVq20ibrJqCd0w6VYWilEuSYxc3+7V17buTFY/e8iKKLhJxTp+qVMpu7NrxXsTnro08W/mMWU1fMlDIrPuZLEulMLTiue5piWpzFrkxxOHzUIXRcWUQhW6h5UanyQSUUTB0uZp9Ge3qVaOZJ95yqsaVTrTF6RXpVSSuFhnQbji7NBR2ZScj2VFOeSq/CzUkZ2OiM9ggGq2VVIZEOElbyNAbeMwX+K6U91JNt5LYWV4FQjQARYhmEMoSPUM3x7FIbXrkoB3cGRMsZUBeXKkoQ3QBoLVfi0+TmUnyySYrTiAtGJo3QMZmBWD0lkqhiTYuUtR6jjBosGKn3s01me3sYGk/FNKFIKCAzBljL5gJDaVkCBYEQ1Q4XU/K8YHKS05dl8GR1MryLB7JQJQebSGlKRfiUy+LpE2EHwMiRUoGTHG0aFZljhagmfwVc789Wjbg3rQ8pD5IEGyhbijRKNUe/2tE5sfUW+vmxtCVuDSNlY8CRpDdmUo0TUnOME1QuOjKSAZGpDRSX9e7wPLXpPAn6CMMFZenAYMDOQeUGe2ZfM6TOWLFTMmg3op0nlujgCJjQngHbRcK2OTRuURySU2hmmTg7U4IIj1AbRFH5mMegamZwACJbJEgI93JeKWNoJJ1NWgvlkmFDajLOJOTiWHbaSnXNjETL22BjGI6dYUFgnhs4PqvFUFQKYFoKR4S1zJg7Vro4kis+cObyctFaiENAMdKCKB8zgWBo/gvzuXSM6lR24nGwERhkhsvYcI+4OAMVEa3yXz0bhD0rhmpY1dOg21farOyX7JNcAA/ByDFGu7xQ8p6SU7LMnB6Xi8Ol0eqURcl6DbnAGkt5WKkGcLvY/jiWlrNyhSgS4nX0p6zcFXIpVwaNsChqFVNSkYZoDFSXD1Xws/KSpTyMTg+rslILPR9eGkT2rqBbhJ52UC4q8AcYFE6T1FdSI/nCwtpwdFtREL8Sw5RgGWYli7QMOGaZyTk7qV+evYO9w8VFYc51R94R2LabKs3lyeEGjaInw4LENt29N6oKlyw25eHmTa2sWKy+SOAQsJPHtE5AIfidDE+zcEZFyZbu/yBE+cSXKL7/m0pY5TB/ZjGwqa2PTJECOdYSTWm0gi25qvibFc8QLVxLZxjTisnYYV6wXaAlo2fRJai7lYdyKGqsD3x9qF8tNaqSnwGj9lUVSFROIwrkYqHLNtDJUBYZOszbvlRQQE+UloVeJaLzmmjAGyBtLjlKZSTTBMSUxISqmiYw2C4xK4a/esMwQmAIeO+DEDOnmLE7h16yD1yZqmhV4JGW6YjXM59CoEfLXlnjs6/gZdnm7Z1O96Dd2W/v7F52Do86+0e7e+7h/ovfN+9ewfdx0zZlt5W4ybhEKXZ9UdQDJ85MqGa30edQbUlSdWUxSHmlris80pKqKvy41SrVzrWQGFh+mpuyatY59oFnWpAAcFjsYdNmoKShKfF6gjhCt5ky/lHzKFOV+iZVU8dwTpOgiAy5GcOQMZAUnEyQ5FadP7uZJRfWDOMRq3Vu9bIX6SplbZZAeNe0EsazIr9SD8RenMhYTesZkOPth7zsNXCNcOlz7CClPdRdurlO5FBKpkiH3Ll6COXdxjyOVwb5Bf8uUJ1LVemS3DhuS6G5dXxMMSnqPQ6UioLrHtYiLYp4lWjB264nM/SFm6l6KfEexYtZfa7EuZKNCO8u8gUnQ1JtA7c2N70hkednTJN7DiLeBJO5sTBrjp9YqXxb5ND1buRtmVP5GY/9is/Kpq8pVrHFIEg2e5CtG6XZeuD73b39gxeHLzt1P/V+OD75oobSsxOcqVIXP4Fed+jtjfY7nWBxxDGX2ngIOelS31O0vzRHx4i0axVnLKhUU4o5vVwMGLqpQckxEHIkoAzMJWjrEpX9rUQYED1UKqYrubQFo54lC62XJDy7AwwCz21AFE6kQxkCB2TJHFK+yLybWt0erntWlvGUspED1cgsK6jsNMboeGg8gV9aWnqR8oDyME7SJE4QdtEvobdHSfJBhYyE2VGJVs7/qk7OfKKWfnBnOWLf7Xa6v68Mu4Kmn69Kh1fBgvdS4tnQxU5jbKitWqnaaSkzTIkz9tf5Qulaxdk5ogtfljZNy7+qSglqn7exFdVaB2QkvNK8mHeymjEuQPKCk4IgyVLAonNSshRWYlD4oiy3VpGpeY7OhEH+M47CphGUMjRtWFiQ+kDTDEh/mJOX8wbNAFgOWB/hVOCcyXBrPmTRhwiSJlHLLpSri5RNRDTjiD6sbB4gN6HUUJ3vAXIdG3PRsooe3TEWrNPJKDaGHAh9S0SxqA7/tCLzNSR4c69WKheF+NMcq5KtDIiQahApWszfihnn48vtRiUoUP2nplkziooxSSGL1iMTkeHRCYmVtM/ye4/EVrrjQVqX54lbHlTiHEsqrXHdkEkUn79NLl7MUpD3RhPr8RYvCHTcKIMJbn/Yvqk+re/kcblFWlliJEDNg3xvgiXQIPGvTMILHm6UigIyHjMEM6nrhB4gAnNIUIuRsV8Uug6nXlwrW8HgitdsQDmkaMhFzZ9RptG4k4aB3GKexfZVeJcabkuXZYcmlPv5JowCTMXizYnErl/GPujY3ZcO3Eo7B0fdDnsjjk9/POr833/r7uz9P30Blz8Qin9zGCdh6sVA7pQ/67ry0W5H/lCWgJFPZVzfi4u0gJyCMeLqJf47S/3vux2MbXC7TpDl3++4XXfH3clm+fcgKe7c1SUKDB31zK/qjkTt9L5XpJzvQMWiBiKmpAabAfPFZ9nBPbUg5J4zKrkXRiiYaTsWCFEqZUFfg1TWk+1kjIwgglop7TzJZboQS7AKGYDQPqQXx/LRBCUrMjM3zu6s3N945SjAO+uSMldwhTAtvL+k8ZSv9tCYoqwJWkPv4Y0W6/ErWdvjaE+6y2ZJoVRd57meG/8uU0xZzHhWCkqgsHWWOuUcyZRicJxNer3G9NPGDRYLsHXrWs90bCDfI8g20CZvLfCdlvXaDmySC2sHF/5YpLSfDFlimXIvLyKyWFJKPwrwpgqqXIclklNeYroGbQwbNyQYVTzvuDNUr0AcyztyhILRoGWLExi9pCQv0tdDyhhWAwPOLpjdY1isXh04KNkSlilJuwhRwhASaVP+i76OsKw7f2znp9PGUoaKMO/PM2nwW3QLYMCBMXlPWRYsp0zoTpVOqq7AmorCBnd1Sdak4U4stcDYpih8YkB+sEVmfcZvLYYMHa7LSVYgxXWLzxmArWWQutpyim11lbV7BWqN8XjrNry6mtBlL2sMs2rzLfUGu3VuB0qq4I5FpubUgA4suMCxNaIn1S3zSQKXrBgpLQ+E9q2XvOR2OGWqQxH47UGZB8kmNb+RvjT5CtNxUFNDmicrEWBx8jrUolKUxbkRQ4cwu2WuR1wZj9Uknna4FEN5TaE1GZmm1lz0XVIZnma7lXXHQfJmHQyjBGFV0ZsjBks20yWlpxAXo8qnQmVPV+X5OxoACOW/ZC999I0oO3TevX2FyY8fVELM7Qjkas9Wd6RqhSsVUPAJsHUrmEUHxjFz6Vmqc0sLUCXwGstacEQ6Il76CPolawiQa5au73KN7foVUzhhzFjs7LFt6mf7b50OGS9XXrow+3CVVeTQ26TTUZR4SwMt30JrDrVGiiKiLIWcf1Jlqpnke8AiooKsZVtuSUTHcF52CdKUySknHZgsc+CJd2+ZzxXa41bYkLdObPOcjHtYO4e6+MQkWxxFlcGVhdeObrWD+wtUkhqDKKadcV0JWYEHcw9wj5TdY/LGYY5EaACZNaCs7CXFJm6kgTMTuPdiMw2moow8J+mM62DUODMy5E+rHe/7lenty44wGm5oSvQsnlmhx6QepZh8npNyLZJzLVsIimiR/9f7UMZMgSPlo4U5kBE12mhlRUfYsRFqbMZ5q11ZtRRENO18/phVdTkpnsMIdYfls1e6oG/zXv+mcVG0MqNbtPFTrEw0fko5ylQAim0jUBwvc6VntZgpQcEKKNOrQ2GJstdQmjjIqJDltl4gd7BtxyTRAyW+WtAjKWvq+WDWfzwmBMoBfOFm9L2rvncxcmbgKoauPh7YGP3ajWAyFRi8THaxIBiVXOHMEVX1aHOEz076W65KNi69odUDudUx6tdBn6bqkVOXUJ4wOUkmKCOZcRjf8ula8V16wvU304u6cPqVKnp/2vnJntZPuj9lcKPtAC1dKcoZaoJ3bvGA4rn+M4kbTLG7XeEuTRUPj2E8uBuskE0rJFvOoSwLRRippORFKSSoQ2G8UtZ1zIdVbSTGTL0Js5J1wkdDMftkVacqy5SweDxkFUlMauzZiex847TA4L7t3hQz7QNvumGBbXjDYSquWV9Xj/cvN7ZYfXZ+/vloOjWMB6vQyafanf2jTmdjq4YN1+cmPGErHMiF6T2DTSkus2xgq8RQIpRAm6NON0iiaPE24wjO0h2kFY+FSFbesnwntBwR4x7IrNBUyZcDirhILMMeT4oy2EGGwuOMgrA0WqmEX1kX/ksEjUq7GVCxTiQq0qixco8VFSemfggGVkmDCTHHMMbo7fgaUx3GasZlq9YdtJ+YMZFl05wTF8btAM73ZKF1vuakl9IYuNg5H9sZRTJjOCblGfOKfLFUj1qiPxnW8Nl61HReo0lRN9v7Oy+6sIbD9mh/2Gnv7XQP24cvRvCT5+8dvuh4u4cjsQoWLsbZlzOEfjSf3Jog1GPk/0o2CWFULXiUKVEHEZzg/JZDY2XCC35LEcMqxQPblkRQ++FHKpsgQTulaGdZTYkJkC9GrZjKoVG/AyPbRuOumaAdv9eSAEraRA/LTF2eKc+X89r4I//549nrfymg48xky+CFjcmxIBrRyzJ5Sho6KxklZPkhUAu02OLjlfk8q9r8tFX3XlknHHX6QMLP5itPxqGYohYouqhuah0bygJuljfjoFVCDSdLGxvhlwSueTlMfljkonmgP14f3b8tXugPueIks/prrEAEe0jXDHZ+BmZFAbsE8iU+TrwiI48CQapAF3xPlTk/chNtAVNZS/I4Uw2Ka9Ei9wrBVQQtU7kZ7zsqTWc7V8VH4cNIW3A3B4GIWxQYzn8ikEFLcla4a2HHi6UQFup5xK/gN+5YgLSaubAuM/ng+V7rMpPrMpPrMpMPtqDrMpPrMpPrMpPrMpNfS5nJpamH95PySYuhNkmVo1otdxTsKbqeN2fp/UWx3i+Fsz+8XmLEdqlBeRxhS5nf9ZoKf6drR1AzcqFZvi9mZOUdTLGrgTTyoKcAvQEDmpHlVJfJiZyLyvVvtB8AH22hbcnXzSn7kRq3XUWnhn4lHITHvhtOqDMZ6JVVQlxuLX+XBd7i0JW60tToVX8GUEkbMqKE2KRdxsDGDwkwkaRks2fod2mYtIyFtbPfniRTsQ3szF88tNT0FTf3kIRYnrSWkuLJ0PW3UKJs0qQLQt3FFtqciomqjcq30kZnM5GiNYwvopIzgKSqqOSetIH/V+WCRLIGK+Yxn9S9thDEByamrmq4cujnILkl4FMvAImaJeBe3TAaq6RRMPdSd/wnhnzFlvtMQ6mSP96gbKsIpecb4z9Ba0fab3ALG0vicGaWhV2RddyYBHqRhlO8XMmyRU6Zn85Otj7JYja7nU53kUHa9rFmR16tgebWp2nUMYUvXjD6CVWEfmIln59YTeevs2hzGDcHXXKGfRl/o+LLfN8Z1qtcDrUZifsHu4e7i7xlCgLbVYO4cq/PXp9y5p6SUWwlmy1O5VLTKcbmkPI9Ao2ubDx3ZAaMXesz9GIP0by3OYaLoFO2pyIIvTZ5HO2f3Y9Y0POfZ73zXqnVBNGf0e9NT/2rJYUMBbrsMsZoDboCSr5sdhxKAPRyaRQCA9GZhxYZFC7Fqttw2twufI2b0F4aLNXlo9Kpd6a3FPhvs3Ow16nZfg+oO9WoTlrn8Si5jZRh94711x+FhufVSuosQmoQUSOCqWxVVu6l2O/eVhGiTuRJbuLGEhTYxYkdbpINLSWQjbtLDI9b+/1JgoRSDXoqP29p6q3KptBSdY1qt1CPKrBUo9VVu+3b9tK6XP26XP26XP26XP0DehvX5erX5erX5eo/a85PtFx9KWo9/FM8UFVbtlRig8gmSB20TtQb28LLwg9h8cqAXawwhb8uqbbUBQ15bzGumcSNq29QOL1kQYvEU4ofnk8plNX9krj0tP6kOD/nXQ66EQVhytFt1e5uK2rSijNutOosKkRkCX5HluDUpOlZQQrP+xUzMetTqxiLP+53XrpeRNwBHd7EiJuKH3olYwFllItjjUNGFz7v9863XNaDybiiQw3rYnQoIrkARZhTfRCoz/JC0zZAwBUOVzagrZUaWFiQqEoFx3lOuEUSNgVxGQjMcOqFkXm3nvDfuQKdFKEPF/TKHm5anzDLClhWHneTV6VaIBngTZfG8+Nz2nM4KArVskisiV9LCYmKTlZm5+dwPHF6iHjvoS+4T8j+znHv8whUxHlj3lVDHOoVCLPF2M51c3/X/5yJWWBhImhyA5zYHcv1P7nv+h9//67fct58r/bBWezDr+++r9S1bTnH59/fsldKx/JB9g16gKPanNBH3TiqW8XrXm3Vipm4vZBL/RqKm8+ZZZKOvVgm4DQ8U7trmOibz2QgsHEeihCgfxRxmH9BeoBQjiNAsry7J12WFYa+B22o2sdVkl6ROtAckIQWBajaCGXzc/9aELhsOX0S3S5qj8kxHCUQO+PQu9f04yS/IlX/gbwLlwtVYuzlJAQ50lzIiMDwbIwEENYXR93p7HTanRft7oHT2T3q7h/tvvyvTueo07n3bIdilKSiqelyku8dp9p92e4c0lS7R3udo539z5gqV+29gr155UWYgZhPpg3t6Z7qT5uZFMSRXXIYeqglw9t+73MnDBrDdZMVwKg/nqwqvBNF+IAvvzJTdvRicNxV6VKnguCKdsr/WUukGNjGbH+n+7mUEh9nSSzi/IHsDqeyOb3wiGVxvbDsOoT8jrM92N/fffEpqMN7UuABrS4ECoI2F6mZWquezbC4HdpiwjxbcvqtEiOrzAUU5hBucwYhaWjTS4Bw7trgn8BNqk9A/S1OiFUaQsOft8r7f2RD4tP+mE08CSjSQsBQEwbApmaVRJmQehtRRVHQT3UQYql5f+JRSZK0fgX293/84YeXxy9OTn/4sfPysPPypLtzfNy7HzfSAeaNc9+zcnnLUiaSjnq3uNBvwtSX4DiREtFYDBkRsCPs358S55UHQtoxJSo5UThMvXTOtdiUbX4MDRdDMsuPE6zWA3+hgX4If3fd7t52lvrbnOm0jcSiP9xx8rdXu7sv2q9293dr14eDtNr3vR+kseVpWBMybU5Qw6qNpYYNKwJ3DKTzIi3pxiL/TAI8BWvBAxoL1MSeorVgIRlQmvwYMPYWc0H/8nsj2recV9/3vdj5EQ0BYeYnljmhhWqhS8aDx9kvT9pKUKLKZ03zqZkJbmMMpaV/sFk/QZtADRFWn+O3qtvLSIlmRcNfTXgGDkLKabW7eHcVEJOxSMoYJj/pD26FMIHH7ELuvpfCJUSZYZyt7ZlAU0qIwbFbJSZ1lmEZt4YUGBiSfsVOA1eQdIKL0jAgnPAnJDQbxGEc2dlFy1RR5riMtI0VEKLQyhhcob47MMymkqaPFXOujxLAuhjCi2pyPzE0G4h51VAx+subpC2ToPyFAHE9ms1s+VzOe6snuKpJNrQY56XMddl5/WSSNJ84Pa71XDNwEtOuwixpan2OpWR41n9DC1QvFPWWDrWp7S6HuXSXHHuxV5P8qFjEHYcITOVqllTD8uxbJYlBwaIi5VgFGNrGX+pdsP/jbIBOvHHktF/sugfdvcPdTgs+8nL4aG/f3e/sv+weOv/Z/JKx3pvvkOcpfKlKXKOnyddS6bSM2gbfjUHsQ6xuWzTNsYiPT+hcyN2toJdju76eFQUUprISD0F/cuFRROLFijt0b7a0WWIRmpuHFzmzyTzj6gUk1reID/MtX04FtGDkyUSGQXxFnkzpurHuk/rQm2GS5UncDvzFwCr4BsS3hk7s5gV1x+y0Cs9Fa6SmUcI1oCoxFbQ9q9KBxh4fqvqIBDaGU6OO4Onfzy7KyqMMTpBITDdhgMgFdOEqfZOQ6ejHepq+3OvsdVaHGx+jYNUgk3xLPX6KR7Z/OV421oa4pBznUib5SyGGwl8B6/ThxQFVuPdPiY1pb9iWls4w2cl6bumE5EW63UvHJFJ42z8UIk6yq16YimwlCdeS75SMa310m5SLc+JUVCnqIgesgQSmZzIDOraQ5m2DA7srS59BMrULZz3yvVEq+Ksz/nNNCsLjngoJ420XbSlVTYidVye9C+QuPa79YtADeD7VKri3leJ8TGt7WDbsmolyQT+J6ratseieSgIXDdJ9Vs1hLe/0n80nt6pzuNMnXJ2VNrrZ2xYCeJgjODA/py3dNhI43/uVUHPCwtX27lSZa7EVoeo/vz7Zb1Hq8pbDgEZCCi6u0wsCNaiRhorkcHnZxHBONa3QMaGSlspDZLnEU3Z4WTmQcP4zMfNSD3alYile+S59nsWIasrAC1zfYOLtXu13d7b0BA0Ugbl17ULVi5Omhy2MoYKwO6+1McFzUgrlR2kMXbygRHOwsXNKglBb69ayQcVd/+3tyiQFpoDm8QTLGph0XR4iQbRoH/tcKujO8zxif89MILyhqt4UzbfuoTo/hST8p5F//3RS759O1v2TTrjXbDXR4FWKrZpPPgH0S6i6VaBfWXlMnn2uVIqVTK2KBggGju+63ynut7Ra2yIwLnVK9Zzk0baNbVZZJ0T0RaHehNmAcIPVGaYqbVkWRML5yoDpcurnxEsDTP1pOddhmhcwp6nnT2CmGPiM9YpSFRVNSiAylf8uhljyiiBfMbz1PnC8tyaCPrho+6ZS/qvUf3325+HB1cFiNoc/K9wCa4eusOGp1ExwdXsRmwu4/XDJMF2V7hxdB9uqCyMjCEwFFnyaisuU9L4w59tHltzgyjaWmNdH/xEWnvVQmkPKlcreWFLgEf3iOflNQv3QvmzZsQhmTxNkKlYTHCsBxwjP8nXYP6oISYdG2i2pnhmP68oe16oKaBBmH7AmV+BWsR4+N4AlT3IT0qGwJJznY68Yiy3CEC6XLX3ujcdYk6YMR+fwmiBaHA4125LQZxq5SNYt9JMoEn4VDuHuJGAE42ZpgH3mWGDmaZDhy+lcrB3Bb4Ytq1tEKV7LtC4DJ8anzLePFzaCGNpL9DEb8fZc5D+cvemXNDXq6VUYFx9r2jaDtnoyuaaoCaqClUtQyt6cX77pv1l1mcYicb8C5wwN81tw0JQn+pU5aXjwX42jxh7uE3fW4FC/SocNDnzttPm6nDa4ZmvHTaOOGyT51+S8scb7tB04ONBv2YlTNcM0tFqbP8u+bInWOrxnuVSsTdZ3BuK65MoDNdIB2X3xTKYiL9I4Uz4FkuelpeSOLpCHn6P0f7BuYSN59zJNazYhYCr9jTdH1HV8pUWluWS1UO30Qr8YSJZU5DaWNUjj6zBNCATRrhWvq1Vzjk/KmS1S8x8MhZfTnTuoo8zsjpQpPViaN9mywlldmr22WXt+U5vMed07todSAoEGynPom0SmJEb+9sdj50VnbweXJivGY4Gg6EfOKewmBEQUufNc4ou3nMP20Ao8RX17i0tCSE1AWoVuEuefOrfjX84EDnAg/HCKgFiI7J854/Ba+VZo3Y2yyOeDO/YyLjtO5T3wQs/FGBQ+p88qfXgtH2RHqvS9yJI4usXJfDYR8dLySZ1OG/7bP6U/d9s7u1hHqfrhnl1S6XY/3cMv6/mtfIOiJSVQBHEMi1tYXAIO50dVuFnKYWQQ+QOrJyKQpG7T0tHJouuxRCddRMbuhzGQrqywjA4wB5c4wJJbZGYoLytICfB8zQGUheFcMcYd++AmomXGIXJFonmb6pxGuj4dbrF05PkVdA05ZWJtD24aqpBg5mGdzcchgmz7SZMB+EIjWyEVvqDAX0WMJ0iDJveCpscToEOSuSNvGkZNJbO86Tvcn/NcyaSpCKjQbSCGoQey6igVYpghRB4bROuhmfjp2vkAu/3GILcW/Fx8p5TxYDVOpLQqLjeGej6u0+vk39517Y75gG7AportLsyNe9fTIdU69W4Q7huxC+tmtOfuuZ12t7vTllEZdbN6XGvR17RvbAxpSebbNsrf66ipoq6+1C5R/Us+g4axBOSxYljEefEp3uKlN2Etb2kQsQ4tiqyUyn5VYW8q4iWLaPMTSXXycJMkVqUaLdQO08QLSLUFjQEz1YkXhxUwRPU4FXDG2t3YslQay7DzznMVvyW2jrBgdfGxhToYURrk4JZVC6paK+eMhwT7BzTOzc0U84PYP03GN6m+yrgnLBbbMhFQahj4xNAsiA6vcJ2LSGAB6kiggkpGOLxHgVCo6aKuQ8n53NXpcb+FVEV7HhZDDi05wJOFLeu1GprqPa7cZpGCF87GKqyz23G7e273jtiND6+TXSJYH+zHij6GLs3jKCkC7Z1UjlPONaOQGDbTMN5fFH6Ao5TvuFjGoZgOXNyA19NWuRh7yTWq419aZOA1vlqF+mvnuBkjiinhWGNMqSlbP1uxlsFtgmdfwKCDzAiMugK5ijitLu/uzv7ikFDhfCqxywSA+dihyzRjAnFsKoQZZ1VCjXTrB4XS7NU3KJoggTYzsp0oaXbkeNdAMCyRUm+0jmB75M4phuSJmvub6MnB199WYL418a8uRt8a+5cP118YWJOo95KixA8pSJwcgqkM/bbvohEZE5lxwi0fJ/F8ioGEpgAukVr/+o7rbsMBG9CswmCAu4x/Ue4QNnzC3yNe02qpbwTZ9WKQyIzVWtYmWrIhG/GVLG5Duao0hPqBPZ65+Uly2v4E6yKwz4wKr5twsDKRSpV4kNXWky9NosaAo3tp6s1xtLTZqWcVYUFeTjmPUvpW1S/xIRx6sXflBdMwRpdEKrAWAgakYqO2P+IuEed5XklZ+/ny8uIOEec/qvQdjbSAL3L1aQIK0+pgkUZKFcRkFaxNntt7kxYujdTcU4EgC/dIXlMvDpNg/rk2YuYTRwuF2Mob0YbnrwzfoVHUreDh4Yvbhy6LeX1rKPzS8cTb6JNU/FmAou/ArK0S9AvUbGAPXCZcjfyWnfAcJ0H3zUR4qPjVG3G6e7vLN0ZjYtBmT7req5KQQK9vaW1K1zmw4UwFzeu2/SjEqoQ094zwrqnqDVWa8cj+X/VDh4HJVmNllqO1YJfFbUxcCbw04GEwMU2oyODv7bc8svbZycCOMYZvjuVAoUv8dklhjJ1dsbd/8KItDl8O292dYLftwe/tvZ2Dg+5e98XePeL81QJORT5JGlvE0jpx1xahL9IQRduEUn+67oHbkUUple1sXIQBgdtjYo4qr3JkGti41IYtzq6YFnhWhZ0DgtiCytZGGwgGk87RarNRiv+Aw6KHwRYz3TsF/81AZWDXPfxdyDtFleChdMlqFgfPV+2jQsqKJFfC4OaYxSSdT47zptSQKgCPgTSlRAB4Gwe543bcTu3W+en0suVcvEE8qIt3+EfSv1y+FxquRbr5OpRVKLRdFTlS9b61DqJOm6GFpZITpWywoZexO1CZnchbvHgBGmsVWj7l84NjfqF9SUZiPseuc4yl4VLl/JnaQ/Z0o2j/LAkO8gvMNrCbla0q69pERDO5C+TqUzdYbAp2oEHWmiIIHSgMY6rCLdlXPbMIp95YbI/D0X05QipGAphSU7Beb2V3JvbTZhK1N5KCA8XSXDZM+3bNnLIZMHPxxWUvHsaqwpc9+LX0dXEHOt4ufil6fmn5S87i8wQwOZmnxrTlsB6Oa1tL/oBsW7Zaw7f5m/sw7hKX1q1KAfPBubUkNGJAFtmSgPNVtzT7r5bGjZbOIHe8PO58r7OY/tusv47Ge5vfvSv9caamvAwcKlsezioffwowRzdjg+Yo4HqExE5ReaB4KS56xT9We3dKdkGqpscmFRkvGjvJkDeXxBDBspU3XhS1HBgg1R+O0E099CIUW9MtW2zUR+2jPmq6tQkMihzIng6pgkdjLZyeyddZxrXSXxBbJLIaMoTgAarWMhFn6PyLCSQTJoKz2mLOYI9ERZXVEqQGDWB12wwoBV7WZMlIogb1yiFbZj2NFb9Vkxek1rYMa4/beKrqvjO4DBE6pKogLQyUlD+kTjD9k4ySPhmc1VCADMs81vLlVTlTYyYBQ8+zkyoxS4fDULN//vqi5pxRGMXJktt3ZdW66cqrah3F8h1VX7c+n3RWMcnC5inzxVf6g1shQE4W0DnIjIw3LDQ5pptTYOneMJtKyzl9SOYYnI0FjksGHYMIgozVrO4nUUEWupPtKt7so+JF2ZTbqIyo/i3Td9mTCMSFJnVHQ2FdqwSx5AxwuPyY+92gNBH1lkYyggFwmANhlGTwI7KK0gxR7MFJwHCt9r8bKMEIizGmnoypcAY85u/ICYXOCPoCDQZMvnvgjlA118etxVqpN4vCP5VAlQhySHCqzEm6ItPKx82PqSGVUE3jp8MXOIf/TNf6kDgu7E2majKcK97iu9bKS1yMtuJ+b7ws3tzMGbyCARn0+FpOkNB6qV1pJTbWy4Lb1166jdWmR0VMhYIz1xy5O3OfcvHsRwwo0iYqnLFOw1VLVEa11nSTu9p2JylDXiaJJ5tKSatEPJ0MPkgpjza36xqFHJ2DUXmU7TNOCJuHjwQ1wrFJdKZkvwE8QyvGh26ObxulAp4k69wMTpF1EjUfQI6lBgMaQKrEmj6zAfXVlg0Vk8DOlCvM7Gpw46XxoAWHM03xr5D+MHKNFy2xAsPzlkXIYsrjOlvNoyRG2vmA3LGUNPC+9RBbXCb56jpMRVYQ47IPI+lvNmBB5GUqByeMQ/SIs6VW90Lyi9S8PMcHLTKZLg+7TtKxKhDL5drdYZLkoNp4M/cH9dNiNCFtZ5cYHAi/q9zgMsvrNsphi1Ymgy4vLL0JSk2V25TCypkg0qJcRX+pHLM69Wvn1ik2mdJf3Tr3nvky5lKHP6gCXzVYqe/NqEKRfYlwNhh5i/yc37MTwOpewXaJxeircMl51VvN/bd37S1djCL2GywMsrAUsns8YNI/UaX+HfZaaBf3KE/Qa+QuUuym5IPBMcMlSmm1FFWj0pRVLqH9hBVsMyJYTGBmOQPT5Q6GlsVsIMVqqTMvzUsR2JzPmHrsfCSjlGxWBRgwUe3MR/gRVpjKhgXUoq1KK5LLVlr2vi5NQ022tTAhV6ZamqxS7xo1T5RV5nDcYA9Mk4CrB5Hy6HFtCA7wFTGcRJw9Al+IG+JfqFxMYS3KRyQSmPA6qw65agAsnUkqs4dQgLAqQeJfyZh4vB6DMMO4xABGiJSHCeB1DXwf3XR20udQ5VWQZVFeDinmcQldG2NwxWxlyQnti5nTfel0Do92Do66HUbvoGjf13OnLHrVFgbUEI50/69wghMqH3PbGZUiBRxJj/BIbIFJQpBYQiiLKFNEDytfE9ehJ5vSkfyZEJhAnTn7ezt7uLy73YM9d8mcXNAtQ6yV5DZhX9y0Zi5r8zlqAAuyZTWO1fhufTSw0b2SWDPFvYZTXYK+h5kMfI0biL0SpsjObv0m2tn9JO0avGstCqL43GbT+p2JWDM/OhQvls1xhi771Up43W9rVLaF6nfxYNxzSwjTJOyOQ+c7Q7T/0pK9W+Zpujwlvp/yvSE+IlawNCcoVi93WwW78WV3SbjH7v4ycuuBfN5x/OSp01rOnU9dSS+WwhyVKc0mlPWkGZGt7hlk7WrHul2mXNW+fXbS32rZmh2qZguDl6d7nOBiSGOI+nLg3jp0VBTpqlKKIg4Wa/GBmGj0UVQY8eZJZqy5Wdm/fjJjA1xFOawdyubtRayWbYKmZfgvvUl0h2WkhjttDnJ0LNkZlsHgC24KaxS1++FU6/+V3PuyUfa89OEnIe91znwJoJm9Q9NpEUvxkE1wCNAuRVnPxoQmIZHbsQGWs5K9UyEF3AfOWbWuong1VH0ZaAxl6ms7O2wFR5CxXjQX0Uia2DiEIXNFOHsU0g4GByFP/CSSJhVlBEmHIch8qY03AMRg0BkZSBOPM5blp6GfJugLCX1CEMbadAiqhp3NWWExD2cfYGLGJBb6f7TwJhTDJPkA9+QNypipHMxNKYUVLroszAupTdyQfYwTn+PAMlzB4ORYTDk7vM0CHTDJZe20TWA7wACrswsnuWEIbTTFZS07POoGzr6uB1iK27x3MCDVHWZcV7/QLjbLqc9q88aZcsEhAzs97m/UH14vnC5suSVhKLXa8X1CUDY5BoVjT0hDoEgrWqlhgueM0p4q0a7A+AZMeI6TGZCwMsBFQHsAWvfU56lE5Ws5A3W45VcsEoVmhbJiuuSWOzisqb9GnCefXzUZXkypQFhUUzpeYoKfVJOGDSgrQvDug316I0DjZmZpMqflcTV4jmU+alWoh82XRG0PhoiWSkcHEcOelCHO5myPonLO9yvhpTHo74hsmdeVx8ZNFIXjSb6tidkOA6qasUToPJq8+a/sfO/n/3r90/7rf2wfTs7Sv1/84e/9/sufne9rMLLl1mnAurNxojpTkoa6DmBTj0ah776P36pC4yJwjDXh6H3svNfNvgehXgYUwOfwi7B+DuMhViLnX+DasX4jfzFc5PKlj+o3u2X4oojpMMDn8W/oP5oCf0WmQDdSptxDeGtKrWyawLIkqcIihoZbdpM1fiPDGglfOnMIHhapch2Km5Ys7qKtIpnzfkNNeMNuGhjd+w05+w331vEqUmORWZBVpgJmvzB+u201ldvHXxp4dVl1RyV61E6Ol2mjBb/oRaPf9KJtyNmqZbMIATM3luPSK9JOhfcm9apH5FAXcPEKWcsBniQLsz1SOMEKHLYiRSkNEPHmcAkzkltkOI7uxGWDNl7SpWZ5mGYmuvNSj/JQ1PSlgArtRlVrynBpDeLSJPJbaftW7Dp+eta/wEhlu8lfL871Fa9BBdyNesMx0XPRzZykN8ALRXD1UHCHID/I/HD27lo+B+sraUKGUX+sjy/tvtxxu/DvonMlxNy3RgsiEwbphbpwztkY8VxdBjc3Ny6OCQtxb7NsiGJKtq2uqDYPdvED9+Mkn0alaBjH6cvricSmSNafVm9mcrOAVDWO5cVIAjkoIz/C1cWJNvSTzMsrtU05OqxWqESKurnVLsjB4kLE8UpLcLsx9lzjm8UYo27CTmDLyBteIpvgCVJi0HXkxfLhstvOPqcUKQitTnF//vqqd8478492GLf/4A9yjwNWQkx3IbxK1+lhNky1GASPS0U0YPduyPZ1+lmGPtAcrLFVoktQZik1S+NBrEQZjkN8hRZW+0IOO3Ag/kB/gTfLikhK/6jNVKL9Sg2zyv67EKBT/IZIuBMPqLa1cqgZTsiVs22qDDmuy2LAWSls8WHiyKzZNWjpeSPNFjzRZWFlt071noGDTcMSsOLNaEZcAY9ygKVKZADh1aGuza+i7J/fwlG4WDl3GRLiXRS8OkVOwR/eR5WT79Yoc+abGnVOfVkJugGiLFXodhajvtU10YQn+NULxbKNLsbcT3x0SVNqORFdJ/+GubWsID9taXmaFgQrId7KyFGjb4K0fckLNOSnkXjYqkR4MV5gSer/zf2Vj7aU9g3lI2+OkksRwNrkPvwRzq4P2qE/hR9F7sO98CRXBIb6xdC8ZAj+m/6Z8zoJRMRK1o2NuqWOwSukros03WPKWta9GcwZNLVwSoR+mmTGgS8mkvxF7/y/9m2vQ4Nke2XPxZvyp7eXlbTi+6u1Jck742k44BbuuIJ9M4jns2DoDwSpqioInPO5Wqp9jj3kwPBPttguqzfSlIJ3LiMM2x4RGyVPBzWqapLcKGWbEwaRnCpp8BqKbiHZDNOLivjuBAANeJRjd64qSVCtbqm8alkLtLohKcZk+gjjPC0I+1BnrYMeSfOldhVsu9IBjK3omeICqBTIZu0hWT1S5EuUZKT8LDSNVO1dvNb5dZarSe9Xy9fkcQr9EleTvJNU/g36L2OdbkhU53lmel9kKk2A90ZmFJ5b6E2zME4z3hSu81pGqQELLbhh5/TyFRVHTWLaQsqcDAtAdQSMnU43o+s3p4KNVwmF5qKUqOiRScCAe/jHRDkR63PVanXWJYS8M0lYfzXpXeSNsvKU2LyBpCEUu3KYU4R41/gdVWOxm6EQNTR/jOYqz05ZjR2nz/lqXjotmTNLbSuvlHd79pryZlIOG1oxqjlsjoUkS75gC3pWDmjVy0LC72siuetstgfPZlug8Tef3rZAkb9avlvNZP+q4qY9vW/CxiQnXF9M7HFMTfLWUPXElJtK21NvmfltdwZlEBr3NNz6FCVcvuNazEDPpGerBWKe/KnU7snr31vOz29bzisxxqdQ6a4j+gXGavlX3JzI16VqHzBwd12qdmFC61K161K161K161K161K161K161K1tbk+61K1HN9j57Qu6g3Go/5IVixl42nEjKUNSn8VO5aqHbg2ZD0mLFO8tmR9kiR/ZVOWmu1fN1zqWzNmqRl/QWtWGPvJ1I4OvL81ywBeedxy5ZaTHHTBkkXWq1LDd7BkwTsrU/vzI5FNpLFBg10urTRUg71Ufn1xVOty7A2XY38cW8WxAU26dY1VchI9SMsms/TsNEX9ZikpUWHiWskCJtJoZEKGtYdfe909BiMRkUFKZYSUJB17cfhnVR0/G8FOtHGgKGFDCKCaXdxSjisSo9wR01m+RInuXlG8f/+ndVHndVHndVHndVHndVHndVHndVHndVHn+xd1BmIFhZ83CPoke3RuN4RaQ892rKJRGmEG2L8XNZvGqIyosnNpInW/XKHsSbm8hVFfMeQCPW4UuUtaL+o/ZRUpZVkUz4tQrkGVLmlaguYztw4cViW+pgPDHQZKYCak2CCjv2b0Fwmu9AOwIkF4smzVxZ9McOwSRJkF46Up01CC7Hg8Yv9KHd190/bnUw90OP/uhSUefMh6u5bufgN66cPVkFM6WSW6ffGbO5SxlKHHVsoo6MlkKFEZs7q4eqlvw3eBYBhajIHIPiJZGmllFXCc8KpZrty7ONOMeCjwqGmMdi9N544SwLwqCIXeKtfdoci97qr3D+gEWb60du2j10hW3d932EXaWOH1t6/UoHGx1PLI7fYQc2l2x13aSuDnLQaFT8zKfovH3kDUHwqEzBXM2aCUTe8DoVhE6OOoOywfiqFos/yzssBmmWiakhisLh1vNGLARWltZgCy52iEIq2yDQNAiIwazqwyUfJitkTXkTDi9yjOxGm3X4iLqP4l62SMYZv1l/MYsTTpLAnafuDfZPeea4Pa36UUz1abp8QkWXWCDc7LlhEU92FZlAET8Jhi4C1mYGHR0IDMVVFSBCMMKU7nW0tPN7+2UpLe4sHWoszCN5/EGbRbU0lZIk6RV5FMzWqtFfFgwP8wDcqL5yZtimPVSvJ4JY7LBh7MdBkuNFATPCOIEF7MQtEojJDI1A6V/1amZ0J8JrU4pgeV+VoPw8znPoVUGnPAvqutmpxXlsRt2DnxNA1+9h41BmBjmqgcA2056Ns356eqw71R5Xc0PnD9tq/arVYX2/9Svou14+JRHRd/Qa/F2mXxYC6Lv6C/Yu2sWDsrPtdZ8ZV6KmyEKlP6Rko1F6UPPyHMGNl6uSxDsjRmXHBVFwacUH1bgOu5qWxDHJ3iYRcaUy+a+HZmcdY1iEW3S+1SXoZuXA6GW5X4D6Y1CpSnIpR+STBewVgKSlyIWBFF2hSDkWtW6rp2F3w8PLg6WETTGRZhFDRs4d3syfNYu87E4nBUVZuc3kqGVaidpD+xanRqQEnklGHu9H/ucXZWzGAtgpBsVRNLEKpHe6MX4vBlEBx0h52Xh4fD7o4QnU5n+PLw5cHB4cGLF92OH6xs5pwI/0NWNHWnHsvuFgipZk96IdYjUCWh6vE2D4e7Oy8DD6a+K3b3Oi9f+i+CQy/Y94cv/Zd7iy5FaxANzfSknIlHgK5VbmPNCNhqrItRpMk49abk24u8eFyQLTuRWzGjwP5tRNbGwhbbAgNTQxGXhTmNqVMTr0skv8r8ZNZcmHlAywkTmsDNaRGDCkfpXSAzxQtMaaTUwJYzjpKhF9XSjL9aNkGxim0jgFt+qZ0PmTGBkNaOeZHCUQiiYdaYXPeKu5O1eI3l3R6tYjIWf0Jx0EPBJ83l7UW0Z+mIW7TNNRhj2784+bujunuF/mQqsGDJS1kWwp40+L/ZLPhI2L+yyWx7q56/9WC0E6Eb33E7XzJQVV1llS7NzktqgDYbK0d8gVVQTGkLtc5h7aa0S/sWGZb2hWO1fSxA9ki3x8l21+3uuC+3ayzqVO+mMffRzxiGMJNOYt257eDTUhvJ8AhGrISwcFTifbeXEtN1ChLkrbg5V70zUbR77FJjagdiX4SviiUt6u/CnZ3d7hdVLJUjZFEGosBsqVsp2dzeslxCG2u9qILh+cQrP8KuXOMsYxhVBRQH+3Q2bTnB7MO4Bdoolh+I8YMxJsrFBX38by+t5znw6tPWtdQmWOzVaFR0fMsKVVWXOnV+Fl5wJ0jDWm3qN9a1nQu4KvBYAfWFX/CPzy9Ot3SByK9CTTm+eFfq1sm9dAxqvXJIUEXdWrXlYG9l6brkNGokmS8mUAHqdqHccUtVQggQDQKfgg+pyHu9IY5KNgHXdY6TdJakVe/6nUjQvOStyRAsiuD3oMKFV0UUucOssZ+GVVk97Yqueo8pH7i77suDTsftvtjr7q+cojadYRWj5msmkUI5pdJIXPQIOB/X1+7FalROu40CBj/mlMYJ38hcHRVGNIILWqSzFKvEDMOYCq5QaIfjjdBfmwompaeKVyd0h6EHpE1zNsAqhJauzAsZl0FOfL9A/PSWFNoZONmfkFeYyirBa9o8QaNnq+knazBh2Q2MqxRzQYWYhlEy3s4niBHaxhQ35H/bO53u3nanu43lnzEypz31IpSv2kycNnaIhjysw1F/gXb8g8POrr8nXu7sdPGHwPf2Xx7sel6wexAEo9WjeLiO9BUdn6bToJHIn8sx+xe9s/NL9/Tvp6vOvdlgbT3huojte0x8Q98V7z/2TpXEQD9XHdQbq4R/SKTZikBT+vBTQR93sg6rjupDNpAt6LANP0kZX1kh3pba4wqQqjnQoLetbSyrWZXqo5M3fqC6n4XBAJYhR2NQ7s0z5a/grtCTICJEBVarTkHgs5DZFT7INhFV6IrcsTxc2+ewinw2zhpj5GnqzWVBHyIedE7I7WgYByEt174ciiscZklU5IJrglruKQq/0wKqxSpfQ+tDIWNtmGJYbUFQ3dQ4Az57XYI7WZprS3o03AfbWTbBNNt2hH+i0Qr/7nZc/Ld7UJdsi/S8IjClh6qb/ErE41xff2ofYT8UYDSvamOlpFSrhJYCzpc11pAK+NuwwNobsOO8aJ7B67DFJ8mNHdc9N+vk3KBNQjMOrGOC62YdL+c13VT6hSmviRVpEUpTIQswWZHNQj9MikxXXa1flnuI5oG4woxsj/wU4mOY3blMyjBJIuHFy9bkB/6aHasz0jEQytvRvdmVlGr3WJ4WYvMzZ4Q/wQo2WL0SOZllMlRSFA6ktDkXM73Vo6rkJJ9wC7Ic9lkx8khf47x4Yw3iNBG3pmpEyHbPa1mbrjdDV8x3b/oEK1S/jeCic7Ff4X6c+S4ltnzuMuTwd/Zk3Fm+SHP2rQiHh1a/HIotwF1Ftb/8dD7L0S0xA1HVEVgFOjPM2G4VRNQwsEEFUa9OsXSH7A9l1ms0VxsU/HCkMBToVfOKioE37ZsIAA8GGpP/SgRLilW/ffvm7dW788u37/qXpydXb9+8ufzc5SwIi6spDLg+d1cSzyjAirAk0kfT2iuzzoU3bZiRYJcPyU2oPfItUrIXuV9NHABLwa5hHnatqVWZyOkvP//998PXh71fP5fseAZW8mN94mba7GNwcSYrH5kzWXPOUA8NK+iTYcCCvHl92XvqYkfFgZg4armIKYeGe1NQ2I69Iay3Um0MzKWA+aiSoXj/IzYlHXn2CDBD2Xy0O5QY0gOSv14yoBByBJQBrl2SEdgPjirYGING8pKMSwaJOVe6jfOySWCRvXqlNboD77wv/aZwVQdXURh/7VGP5ZX7sYgiNTsMTJOwNCTfIrcxDKkaGqsUFStUhBSWiqLCxwPrw2jp2FpTStJdEJs/U5Wx9RinTdXCU0erLyuD2lM+RIO3xPKcgrqrQVQAEZl18YVBpYrooA2thA6OxuCicpmdJG2jPN0QOFEphpEcs4ShrAbCwcF0FN+9OztpoSVkCkOQhgznJ/gwa9kCk2cVgp/iYcapAu9TVxrjsevCPHSbLc76OAGmkcLFRjGK0jaAuJELlCO0JdzeCVb3QVbrU8jJFLbS2Ja6Ls5O4D7EeCa79rx113qKmr7W4xPKNg8xxMCjYtbVNBZHwYUi9bBI9BLhfMff298PXo5evtx9sb9yqJM5a99YPHavYiqxz0jJVPIJHlFD0XBZaYL7GTMuiSWGOcu4ZZuGARKnzQqMb6rKTlC2ZW3FTBNeDGwYUzL5utX4CKYzxTtk5ibFwpStjw5z+yUBLN3dFyujE8MRd6fBfkMM8/XJPndZP5BsUspTf8yR9H/udT8xlJ39g+YGA519Yjj73Z3mhgOd3TqcLBBi1tRw+ienpxeV4TyJUspPktVuqiudE6BK8hvegOhL4HBXjvGUij2Gi0/DaFmgSh3vnYGEAUxvbaB/GAP9Xb10huprM/5TMOPLBfnrWfPrJ7Y26n8Zo/6S1Vjb9r9q2/6SVf02TPz1k19b+pux9C+h/trg37DBv34d1nb/+5Jxbf7/Rs3/cgesvQBrL8CT9gKoffpXcQY8rqV/VaKu/QGf4Q+QVPziboF7DPfLOg/uN+Av7GK436C/sCPiPoP+WtwVctB/Aa9Fwy6J1eg7E+5fPOvTTHSd/2kR49vMBDXz/5ZzQg0V1tmh6+zQ+2WHmj30TeeJaiqsM0Y/RaPxSmaoe+G0nBnriaQFpVVaURLS+q608KHAftBacl8BdVY3q1q31P2QZ3Tqaj3i7c7ezn0HPnv89bigrhS1N53Z8ml07zkN0vofClsMHSvhVJS2iLQq1wcTAF8+aHf22zu7l53Do87+0e6ee7i/+/t9TfbE9wP38Vfmkjpyzk4eelvJGTR4DcipLAW55hG1O/edEKa5/yVVUJpZxTCHu54+b7GVm3VSXZHXy/S5YOinY5DCCEt1iN6zEQGF5WYeVt1fkPGGKVwqVNUtpwsFizZTZ8o4eiOGjDpGwlacRwzqW/GOrbJ6xQxn8EAW0D7IkHFQvkFA8oK5g5hZzOpBdnd37iufY1V1DC8KQDr08ySd/5V2IW42OUFHT7AacVdrGZ/Avtz2onuUbfkGjB1rKwdT4Rs1b3zjdo21QWNt0Li3QeMbt2SsTRi3EOdrsV3oAT8dq4Qe0lO1N2gg4SduSdCS+BO0EVTG9pS1fz3Uv4Bef++EuK9T9VeUe1pKvRrVX01dX3VzPZhGb0DvxyFwqHkZb/Jt+dM7FExngEgSqhV4tmpEFdfCgp0rwzESyDZBwTelbryRAioD0Ds3aZgjOCVlBw29TBzsOSIGDYAqPpVq36iJp4sTN4WO+iL/FWX8048U/wxE+gUTGOVnrXL2AEFZZjM+O4kJ0Z0loLFw2O4gml3hZwNX54gkM6lRYASvlO9Mm0PgDlK1AV3SG4YR5ijhWEyooAmoRw7z9vSnqx/Ozntv/8Ezh4+lmlKrNPz+yw9F77jT+/WXHy578A/9zv98vzKWNi4/36BZA5lJm8ecY8DFT3DpqewX9U/N25rZhSYSFgKLOWu19k1aM7l+anO4tGUy+Nq6IeXzegNRl85zXID+7y1aiNO/X/TOT+DXLd4rdoCnHkNoVebA4nWyQBl3Kf4osNAERU/LDmlzY+uv3726PKO+qG3VXBTZReKuQU8mKPyIUni52biYYjVImqvZ7djmyW9v3p7wZofffsHfSkO3dqa18XQ2XiD8EBRXIIpMOWVlHONTncFGd2OwtTQr+vjofZp770EDvwK9+T1o+O+nc282w1joe6RC02asLzPx8DlyORDMS4PyXuALX3IflRCT1c2et8zK+BzhdROT6w2HqbjmstGkkSozLfZfe339/N+vXq86GRhhA3P5GYbcZrzhaxmFDucKWqy/h/tvfrz8rff29L3RpNW1cX75/pjlsl/Zxvf+bIrC2o+hLkCBG/8NdZy9vwljHDTu5dV9H3WVex6cNJRChH3ZGUK4xC1sljgC3SPLFvz9ZxOrJGnVEO79iRgW43Ep5/RuVVEq434sEp5b9hrqU8kjtZtrldkYAZD4bVX+sz/8BN64xmYAvQPFj6mQ+aQjz0fhAtPwZuF1wtk40BamfcEnwsepqUapmMozK/mLHqBLys79lgbcDBUHysGM51ilFp/k+vGnx32ZDeFc2kOQTbNpFEciec60xfXnze2JSUWwcakLWbOV7+4wtQQyo9PLLP3YGUhaugM9kx4yaT8Vuc6iQgqdXWBV1JTMTco2rCzTVNIMk4FaTjIEbQ5ktJZKyTILkMvEj5bjR1gJDx6Rj+KpikWOSoILU7yBOwSuv3DmYrlYRHOH+0/IxLuzC40zn5jRh7NBi3HfuTBRLIlGFPOccYj2cZgCiA7Aw6No3sI8ElifnIqxmfJoYU6deWQBB1FVI3VYXR11X+64HXfH7e4P7oHW3qAfogcEozsKusQyvrg9YEMCoVK14aREyImQ6lhQ0d0yJyoyVs8p59vQVrassfdhS2VhXkiPApc/w0q/KW6TDL2cmIVXalkNEJYXqxvnkynut+eclgzvjxJ6CzccsmC6fPUgtlaOz6KktQbpj/1hz5nxxeBHVkZf/cLI4lklUtnv8PUEt8cvJ+dwIoNkigne1FOL9O1MSp3yIzwIUQjKYLYyycJVEkNKDy9QQ94LcIrrJr1o0clKN0MTZ4USbHnBbhvhXa6pIqoU+XprPrn1gsLnVFkLzE5Vvj+Vlk7GN5m6yRmlcKUo1gsrLRsF4QJuNRyGTNZXua2wEwQ6JPWOjBMuFEaTNAqjKuCGXVh5trI1dg0q9Yc1RWvgcoMeKdauBhVMw4zcwagLpEmkK7bDJpWP4sDokJyd9LfPLvrmC3Qg3gADxz0uhqpJC67GeqBII5l9Db/AhiELBJZxFj5jssSoquDNmAnn+enJ2y1ZLVvn94rcv085jiKfJE1t23MqCpqkYy8O/5T3K6JKZaIIkniua/jyoIgL0E/InGFDofe1NCLHrKPadXrX0AWxcA5s7RVUwLT9CuZyD1VVlrdvKkKlpzqUZJOyqmySJy9TzDldXl6BijwlmuFVaDaWSldfTqoeCB7TGeqSZ5ag+Ep4H55+5bZLMnAsxLLQlpFbRdFpOQF+iBL/A5w+kK+ynITVWTGEQ+ycnPc5Cf3ny8uLvrPtXL7qE25C4idRtvLNFTS2m2j+ZyfMGhH1hhP30SYkywNRLWfm1syaLXG5tJs0W67deCtvtm5n5SDqZosj2xpjtKRO8nKOVCIdJ4KjRueBbHR7jVlZzdiuYvwkMDMXTpsdAUF0ICusgqK5+5l79eb4v6/ggF3hAbuCg7XqvJsuN7z5tlRiGFbe+xS8mr1PdLO1N1jJyYnNoxLDMoK0ezM21eZmBtK1Xxg4knJvpKXiqYcHTQHcJDe7r4V6k2+5RT1Ep/lA8+EwKR5gxJ5OJsFQqWTPbHqypg/CW70BWcVaidi9CT+EMxGEHlX2xt+2P2vpUYoU+RfgCLLnFqwNMAfQ3EkGYymH4zqU5IDaJ3GMleUZRqeYiulQpPU2UGnbvrqQV9HVjyxZrkrHoniidxKZwoCmKrqoXKx7zACy6r5ifdK6pDAI8S7X1CKzrr+yup0O//+0az9fTnTdZxBV0EGQVUWioUCq0N4jY5QEd3WX1IleRcHktSirmH37s1uVzJ58FjcGdBXG7BVkhx/ZC/E7DFlVyhcobLFczpFWcFhlRJ8PHA9yIApS72CLmOd5vwxD9v8z/x5FyQ25dNPAaJzolrs8vpCttniP6mHy2HwRXptouDCG3QfN9f9xTuXIRf4825JfqjKR0KAZC/v+eO9qwbLak2TI0XyBHs8MV1F0oUBaTzZOhmCpRyI6XsEwTpmQsIfp1NnQ7W0gP6Jb1WpWjSKuDDxjPET5tdSy5WWBt0YO101mLidlPaahEE4f2rKzShf2PKTlqV/qgO0PNAvZogVdR0r8v4vYN2U12bgr365rzJAWLsmFJkfE1nEZOVq5apI45ua31RTKLla2RIKQAF9PPWBMPnscP9KdDl+JjxzK3CpdFLDgaL1EyFV4DA4xTDf8U5jgBZwoXLpeybypzNOp7mOEhgfVZsxsV11ObJ+Wnu8sR/+0YIsog9SRZYVsEpatnAg2ChH5w9g60mSWoiPSwrtcwTjBRvymBDg6DXzFygXTXgSam2Y802E4LpIig0nRLqd3DNQskivTWChRmCFMtnN20QL2JE2fZOmHG+4jPIj7x3WcfxiKYxj5PGO/SQmpMfVu1JjUeRi48oMBk7EsK8YozZkIhqBQUJrkkUBXBA5l4PKwBi2YI3ovCO5byicY4GAQTfFqrolm8zI3LlAgeaCANonBx22iLyXRo5YGoiROpoj+zSyD18F8XLq6JVeRjT3v9c+3FpDvKDEBVCxj1WPycoS3WHLz73cPXtbRwjZxud9g0YI31vyXh7X+lCRjEDpevTpeoOGSiLRVor+rTZQh2Cn2jPDaqISydc/ILcZXQ/2yH+4tmrboADURuMKj5P4WnRBjkbg+4mU2hDV9jDafpSv8Gq3mwovqhwlfhogzuQTg78HHenmTwD1NblQC5NPXsQr2odFsZsvnc95bVcQvT7ShRTkvAX/LzusnlKQgBvUotMxbMvgC1nB+FWZJU+t0zF06Z/03tFC1Iz/u3Trcpra/HOrSHXMMOlRQT1m602rVv9phwitXZPFZNpZXwAdB3gtYMEM/Of5S7/34H2cD2ObGkdN+sesedPcOdzst+MjL4aO9fXe/s/+ye+j8Z7N24A1aFDffAVduK8GrYsnHuve+dCN5bONiMRy+G4OaA1J6apcWgAfmIOJiLArqHSVAWykg/f/tfWt320ay4Pf8ChzlnLU0l4RI6u092TmyHok2sqOx5GTuTOZQINAkEYEAA4CSlU/7N/bv7S/ZenQ3ukGQIimalm3OuTcWSaC7qrq6uqq6HrntwQxTVql9EfPNHOV1RQnHY3YwHkSVDFW2TXHkMHiRM+yDDY1/sIccTE0lM81o13dJjvTCB9kEI4sFNZoBaT5AeIVttTutk4C+GNcDv3LNhvCrF61qB7+6oulYzHoZ6LihHXSqUbGKw2MokGEzyBAiHf+ERaqVV/IuTh5iCgx2EDWu5po6/7q4ciwccSuQQn6PJc4fQNsD6tKRLyUFXVXzn9V0Pdpt7DYWEfsYWZrEqxSc72nGp+Rm/R8n0+BdkeSUsE4UnP8YiY6o5mW0q/5K4pWoDCoHDufT1z5a6qgw7Ivjd8fGcxORkgft9nHaI7XD234zEnGStY/DVGTLCHWppMDkMJciUk4hJ7XgzYur+13cUfDv/ta4njnw/FXIkbfHJ9UAlq5EMCZE+fpAXvIOf39+4hw0dltUih0DN7ETxmvnDM28xM9F7mxKZ3PNOax3wuJkRdthi9t3SBVRXrA/JM6/R8OhSH0vE/9x+rCyKn6e+i5kGL6nPMt2EKsEnyfmjIZRTB1ZUNLnogdIOdcjH1OMwnv5IDseMjH0UtXPolAm+o9DsPAnZJY36vB/e2f03516a6fiXjB3lxQs9eoGnYrSzUbZvqZbCJN6AlCdb7S3VRa/DqVtXRzQ2HUgvEfRf/r2X1vGEtsHIx0jUeIBPbzIi306mo1gGmDbFA54+LrCVYF4Y3r+p848NYlCtRJeNlnYj7mgzV1KYKaRFrauK9KuK5driangcnlM8TJ+nYx5Itgcoz3J1v90DY36Ya8PrGMAoejJsNQIQZBLgUZj1FEuAiuHTpK5ZuRK0XDSL4ka10Y3SVz5HBa/2EBBuGF+Ue6bxNFIMsIar9GwcD7VfwbZmKHGRazpsS80Cu9kTjlH0WSjbjf8qEekZzbxcvj19jY/wk+gV23LdW44xhld5KgqfgwH+noUpGOG3ThAUHt35nrTqe3BuCjAI68jooy1Qrz6JncftZxA7G8uTzN9rm/4iTu626gWsQZFxvPwFflXySV6Utow2kjqjlBC/InXBNSLxIi+VXGPhtFSxL1yXKn46IshG1cU5UivccCLzUJyW7iOc4H3cEPs3mlcxDhjEJBAkk2L8P/l7zI2UltyZHJhpgvNDExW3MQ4Nr/VDAqg2wM7HY0jhCUnHqrZv3qv2PvJpO0GlokRwFfu4FGOwAzDOwZ+2CgEyYVsz8SjYHa26lbCuHK+oJqm0C034LsWtpBrWpuyZjF3AZ7VlUJSwRhjo8Z7MU7wJjCMcCuBOhMmE5o3IRLz6qV5MmwTSp9BUopuV1CXL4RCMpGkzKaAvb1VYxNS24/FmhRZoiRuauoSlwQHsrPiI2MDueNCtTxvVTEBXEHikS9bmpIknSRIi5WYXaTSb5WB9e5qWcn0oBZ1AHTIvRGBA09NOmJhBS9Pj69QzB0zJU71UCYPvarGWMAT0YoQRjebQxMqc6s6v8FFSdz+Bm+0kECvsuIgIsffEyGbEbBH7pxhvz1hsK5FT7oGfzHMzfFUK+FuRnxl8WWTm7rJGDIZYkZXxdsqy8OdBvsK3eDmivHk1YCtsmygpCjJQ0qJI0dqyqmHVsAr5zWy4MRMFkz1CP8y4GFS648fuKEwbLBbwioMOEqBPiC2t1qxgX+7vKblINs4qNAV0UyexJAzVxtbLhvKVSUQqgH7dA7ZFylpr/tojauWQVHSC+NqIhmi1iNRW02+NIlWVtzmWCU4E7PTzOpmirzDEo+paUl3YceLvTamnsWgrW+kgiyOuNfGQWfMVTKjLosiw0bYZfHlE9nnQo0xFsSXm79xuhZ5e4o8CLw7k8Nin1g/iSI4IEQRowTDZ3pgysnFuLNuiPnTuIG1OAE2yKQc0W0g1dxU54DjWheIMRPDvhhgjN8KO4yeqTnHBAF6qiVamyD/8DJBfAQVPtsa61Ud0IYj7x+HCWaqCyaob1ilL+MWo7dyQBKjQSKwK+GEiPxDb7e712h0Z0pNWr6zf6JwTEdxzNG6jImypRWpwoxKa6ZgHQWmv52qF8RJIOTNrUWKIipOl4cjBiNjJhBZBcHlK2NdU01gZJmogXeHJRNyvJ3Mwg7XiNP8XJhnyNfIwAORp5gfSxspFmNcbtcAwA1GDhYf77gJXj2kGGABi8AUNOYlswzhDLlYQSxkZ3Qhihcy3scWGOT7SSyyFx4II1iUc1hZnYJpbvE9eWbjEU4fkfqkFHsTnA7BzoHYE52uaHhi3989OmgFHXHUbTQPdr3m/s5Bp3PY2j3o7s+Y67R8TjX1McWEHHtrSDmiYvmequJF6hMqdzKdG1QBQvIRhj4+MFsEWKcohC1tMLkcQyY5wz5Bymu/EpUPsPUy9nupiGiQE1Spiu4ijIpW2l1mgH/B3+LFF2Jwhh4A4Fgur2DtLqWimR4odvpjL/oiRLRwoLwRXp5VDcJuCHnMUSvhoS7Hpx/FBb0tdYQH/oYNwz5OoxHzBCeXiUtdbsVx5sIr9VXGvigu8zSrEAgluWRwCHq3SXZpFsMR1MtKiipTB3+jbW0kn5llMClkgwJrua5FzVgcRQotRovQlY5qK134lPlY0pCpGidqtNl4rCTCDRDGOa0EAD7LvGBkE9kMLHnTRRBwelUPxNrhcHjGr14VeiYVIZdBduQlJ+T0bLWS1zxJFZCyaoNZLt287cOdDis1CsGqUqtWbFba6ni+OGC3miqDPBeTDEF1TNNHFimUdInxNp3vGrWoKIYvSSebawrBo7hnC/YHSQtNY4kUHL6cTpKJCeqGmrPekP9r7lcVNM5XEsjHxa54vhINxu2yFRWpI/VbZVLOfdbQi6WC/OzsqNCtLR1En/6WocAYGZOcqYUEtqNBgMHVGBi2YENX3s0TxPeD0spuLal8O4Pknj2/bvkr9avdNkItlE7Kseyf8dUq5DgsUpQkd2g2erKOCeY6xhi2Zts/RqcKfUJUU2nHbbm7tl1IGTwls9D8bqpVyE8qu00VehlL6sIIgIC1+CIvTyU1bXPAgVtlCSLzGFlWyDyenfhVk/EKZmonJe5IAVtcVyioLCDMJLiiKJqJlJFJ9kQOmRnTIRPJCpwmpUoZs8CAGcjmVKrsqIpFcEKYFWM5H+g7fURzkhV5oGMb72zShIoMNjEVA5g5gReGj4LvyvTYyjKTV7Qqwpyz3AheM6GT3B9cnav6OUUFjSWHdmpyf1kZY5Lu64yxdcbYOmPsG8kY4z2vKocX4vYFpo0xqOu0sXXa2DptbJ02tk4bW6eNrdPG1mlj67SxddrYctLGWL98wWljBOA6bexFp41JLnoiNQpPCPIlmXcmKmuqMj3KqEuFzi7ynAK1v8QUsokkcp9Joy8ghWw+c/sz55FJefPi88hMx8A6j2ydR7bOI1vnka3zyNZ5ZOs8snUe2TqPbJ1Hts4jW+eRrfPI1nlk6zyybziPLO9j8y07XvDG/G5avOCG7GGPGxv0vQwzZmSiiUedMqm/m+djdX2lRMoZQWX8iAE6j7/LgX/XCiAS4O3Fzfsz5/jm5n+c/Pz7x+Mzp5sCuNSb8/d4LKQQ5QfibkFSDCzh4Ag5be2FqW4kx/61i9PrmvPux/PfatRybUvFlGNywmCA8l+C7BZD030AIeTmGGfnu38jiHQrV7NZHjpppOavm4UkyrzFMYpxGaLfN0C9hmF/39hyramE3yc5AbMZZBiblIK2ikHvMP0OLX5U5NHpjc04dIcxurPMOYyV56nRgvmYgRVhxDodLgnIUYKuGPf3DaOvXYyCFg1VjltF0DfmjvbTq/0Zjk/JnxoEHWLdHaUUj6o7tvHFuuK3sXYvzBD0jF4wnfhGe1ZTGqxEPZ0cLxwLBnCUySeD/gPOoKHeJphDJ7sGwg4E+5Bcy3iXgfmDOQkXdvKKPE0w0hgVsqgEtNfrMaqq3UFVCI+xM8cNe94DK1PaNnBThOzTZGpb/KyI+9+yJy/2v/DGZMvvevTf5Sg1yxQHdv/o6qZGXg7P3LmDME8FNTXiV7Ltm+NGo9HadrY2qsjGv04i2Ao1xQ2L11UKwqzEM2k1JqOfT7xq2o13JS+Rb9UdwYjt9KTU4vMFEdEcvpqgs440Tm998HyWLa6l6HN3uRpoPjKrt4A+zcbe0QRupd+mUO4b9I1sWBmyc6+iuXTmTvmUq3gCKp8nqxVcMyZwxlII+BC70qdTVviFiKOZ6WzS16uQSJ+SzrO/P4Xg2ajzuSQT+ftYPJlQPPcUMMd6HtkbjeY0QeU25u/LOoHwX4Fgmyy15lzcqYJrFYt7lTyI9LovomhJq/syhNrMy2CSvlohWMkyzDfGXI4b3eNNeW10O9VpLhvpsVGVIsgjg82tzc6Eln+lm/ijTHm/zZatqmuhE+aZiLp0UmIcUsydFaNHx7tPwgCz0+qBGOKFpOw6WRjBDMRHd69RaEzY4o7NX8qHFNoMnd194IfD/lz5Z89KwKHIIcAyIJNdxvoxCMyiwSjVX8tsdIPclaL58rp9dnL601n7/fVx+7eLm5/ax2fX7WbrsH3y5qR9/dNxa29/7tb2XJrboO+KKHR19rYOmzhBlwUWBgjqXoRhseZKJ1SbQje7l7DSfbclusllxdm7gxG3tayLj1jkgWIku87tOJptv48ZjU4Wxr68urZDMTmuJUtkSSbdNRHvxic4QS5c133+AjBkq/Y9m+thADNWgMJaIdsR1afM1clrttA63dqpmnK1ACQOeLDrOnXDNMstFlLFaPpWIpztO+eFQ6+5/Gs2b3nFKuKNljsI9la0eCeWVIx7aIagw7Vod/z2dA9jjgWXczo9e6/XeLxaAkUfz7D9zrmySYZRULEv40o4K59u9GhhslrJ9alDVIoV43u3nJv7opKkYqZpnMrVapwf7J8cnLdO9vbenJ8enB6eHb45PN99c/7mvHFydHbynHXL+l7zxSwcSPPmV7VyR2c7RzunRzvNnUP432nr8LC1v3/SOj1q7rWau6fN0+bJydmb1vEzV9A+Aj/7GgI41ato0dio7fH8VbRH5hVd3v7bPzw439/fP27s7Z6dNw+OG4dnrfNWc791dvxmF9SQxmlrf++seXpweLD35uwAdubOyUGzdXJ81Do9Pm8suLphlo1WprudFvWYgDSGrfcHKGe6tzdBpD6RsjrxYLSzQ6b3Qzl594MstOO8T5LcOTmuOb98+OEi7qZelqcjn+7wboQ3qDmnJz/ouBr424wono+8f3g7q1I3ZNAHZdQUlRUYDlmZDu2KPgfbPmKUKbIpsuf19eW2aWtwTa44gF1/Vx1LFeyKvU7zMNjv7O35wIUHrcOjnVar6R/td7zW7qLcGCd52+vmczFkYDOFzXDw2/ZNSIHV2l54wOIcsoiKpdBgaTVKWBCpUU2mvOvDoFrbaTVazXoD/++m0XhN/+c2Go1/vXoGLTpUMO4zEUOqfHMTonl00FgmIbjITXuFl3bHVK3Ho+JAuD3eXUg5n4soysw2TFynBQPxSZqBfW+2mC8upyWFMZIPjoUBu7cpQIsMU/jTdX7jAj/6OMGHVUBjragmY43dE7hCw1CWlTFzeWRhmco1ojh5Lvnl+smi68Jy+yWdHWOnRXFCWGSb6bQYPPLvdEycJv5oAB84mm3Jp0QG+gHFJ7TZj7Hy8Cg5bbVeZDlU+Bt0dCaTjL4J3hRQ2No/nrxFb8rO4S7ahMWD8N9pj+qJNp5lQ34E5nC9iNL6MB6DRMqqaH0ZsqaqONaAQybCbF4fv9tyOTgG58s4aR7WYpL6443yfoI1FjgyxmB7ijTAqtUcS8WpmxShWaQco/Z6+u7aKVPBcTZl2n7ge2mQYYoGfLai0UV1tMqrvxli5VnLxFqgy+CvUvardZJBaCRoNk/ebeF3CBQVqzEordfArY6lYI0TDRbnJwxIO4YxUswIVR3oT46XQicqobByGnENh82TLap4kFWR4MP1EvALTOm/SnaoOHY2TxflhpMfPlzDaaLtkIvYp8OFjuMiE6lm2ioVnGPt1WVyERVMMUu2rIaN1LRKDl5uVRezGrEE+zUUD0tA1qwUt2KEzakB31+eKVyAjZZMDy9qj+Iw/4xk8SIsFJgjdT4sSJ7SrloCiaiocTtJ2xQSurrLWa07cFHl1FHza83hpuZcU8Dp1VZ1GawoBEsyDr3nUGHZ1jnZol5uNDqZwRyfYnmCBQ6G50G9ue80dl43917vHP0XmZ/PRXqpZviTWJft7qkYN4/qjUPCuPl6t/G6tfd8jDkrtQ0M2/YijLzO+4OVGd5yvqLQtohFqhouyJRamKGSGmBuLAlvfwSH6opwvuF76nszwEI4YGDhA778qcDc0WtSfV2rf9YFritpFYNIGe61mksimPg4TGIR50sqQnsmh9NsABshvB9jAn33OSPS+3t7OwfmIgFgH6vCkhYnRBb+JZZEBCohgWkP0rlh8EA29Hy6ge2EE/IGWo3dw+eglAHBQQuYu7TwEpLxeGpVNJiO3sIjUXn6ly9u7Hs2VSKw8KpFw74Hw2MxsJpdprm4uMG7n4QM6AiVNLSA9S1O+XI+9Xwq5VO1EHt752/eHJ0cnJ69OW8cHTaOTputk5PjZ0kqLFvl4XXFygX0hZ2vay6HBsqUUL9h2BGa0gLpl9k1fxypvnRBs6dyiz8mzqUHOt5J+jjE+v1hJ/VSWN5rIXRoVw8GHnVQodvuJRE8Df9sd6KkA/823ebudpb62z4NsI3Eov+4veT7y52dg/rlzt5O5TLxjWL9mUeIdO68DLdFpv0WCqwqxDNgXxG4PaCgF2l9ORb5cujwEtwSy/dKKPxeoluiLCaVw5HLn07xS1zf/FDYCTXn8odrL8ZEudgPMz8x/BY1tDhd8lJ8Uu550e4IizjLwPal+SOmCQ2LEZaN/At0PlTQYmFUv3EngozIWa1maXQVQiCkmlfJ2jtzI7dCW3FC8HNhPeoiKtQYjq/1anzJ7w2pA8mk6jiZ8IegAaVzW4TYJLUT0UE0BxU6SRIJL57YJYJ/drqRZ6Ery9NhCH0segn21qMGQh4VtMLarVipBJR1+wYey3+G+KSMwY/hNCQdET+P4lhEc2/tGFBrq6D8z7r8OjOgI+grwgc7Dl7JmoAcquaE8VhYAhVO5rJ7qC8pHRs9p6EXe5Q6genxvRjvvrPtPMrqhBnuJsSnzmNP/MH92M8H0fdgccV1BWc9xDvF8VBIrglqGGMRJtVQF0F3UrLHNqj8865cKjKw/VbFrICYnfBBzCphoCIZ9pVuzFHx+H6Jy+dmUY5R+TIyECSsi2QgjKP5UjIQJkH2lWQgmGu20Dp9GRkIEu6vOgNBLeXXlIFgrtvXm4HwElbuU2UglFbwK85AmHEVv6oMBInzSjMQrufKNRjLL7DPK4b/8+caSED+8Hayz5tswIAsPdlg52h3d7fpdfb3DvZ2RavVOOg0RbOzu3fQ2dnfbQYL0utThDOAtjsYjsXXy2Dxl5hsYNBi6VEO8xDjsycbSEKsNqj9eubw9dJBMUHojAUSfnKhs46pftkx1eYyrWOq56TTVxRTXYHfOqZ66THVFVT+emOqK5D9pmOqn6DHOqa6kkTf4HWoSYVvJqa6jPTXH1NtYvwtxVRPwHsdUz0nwb69mOoJhPiSY6pNlNYx1S8sptpanHVM9cuJqbYW5huOqa6mw9cTU12F3zqm+lNyz9cbU12F7TcTU/0U8l9RTHUVqt+4E+Gri6keD4P5pO2oWG21egqrsA34LpNxmvQ9KGG9EJmZo1UnXEq6rVcLorzqkOJ3uGoRdn/kcFsKEdERxXQImiSYBf2iVPpU5DUjD7246CJRjWsVnhNxrGyLOd7hEedVPefos59wdyYUUjmYH0K3wDzmh1Mhr2MpogaeTmX0uWpcR3HmHkX5Fv3CPXgPC8/nHHlOwTzfFREkoc+dwjx0cHkYOeHA06ChKw4qdlG3e+QdHh02Owe+H+x5381IYMblM1C4TET6zBXljWbrsoMh980uCCiDU7E7PJXK6wkknN3fW44se5kqMmMwScTmrZ4EK+WndRmEjVHJTPlsnMq7ne5Rq7uzd3DQ2dkNvH1vxxdHraOgIRpi92Bnv0xaBe9nIrCafmZONt+RDdWxUSISjnslwnvUGG8gvGyUSqud2Fuzq2RtTX6TwdXBNEbYRqPb2D/wvEbHO2q0OgcWIUdpZLdX+PD+cob2CvCUapwgu/Q5spYem9LYSlHIkxmEO7pA4JWML9/lk5nVR72TCmqu7gTYjx7YJgFy9gW28+OCijUYKe/LERKnCMufvV/CartLn3LvZ9WyNY0K0bRh14E0O99fxIDdQFD2BcowpPTAe+QWITKHBsMe4mAbiYsU59bV0WNN+3m8MqrUgBrHJucRjl3jPBQdQAG8h26jXoJz4E+3so4lU9OEkBFCwGQcBsIJRgaI5QhGvN/XY4rYjxLpCL799y2t3e1/bp3Ni7Obc+f9eRFa3DrYaW0xTOaDhT9K+bUoe6AjVO/PQAWjK3CLXsoE9qs5qnDqxCqVT7MqTqEGSwhuQVAOvEcprYCpUIXk9tfjEo9R7H+gwmUj4QW8q3JjCW/GR8d2JRhak4kcnYxhLlM0asivcHjicZE+Un+aPh2u9vulwdW0cFSHSQDHAQgpHKSDJwLCh2lSVhd1nS/FD8ODG8O4Z5SgxNc3XPzOmOtdksvMhQcu4irxIv0J4SxOOwUptsGVpn3upW7vL2A7xNySRsgcDmbOFN5UzXCbG72/NmoMD4+wsVXNZ0PDQ2i0fO0N5rtceBZvXWHP5MQUQw5de/Km+f7WEEp5MtworSM8wHeXuaWkKyQqWohjo7BvrO0fCERqj4YnHDUoDgcol2WT4sdkRP1xCqn7aHBVlifloEsQaLewTC6OeUs5pBSGTpKbpUSYkSM65uBCjPhNVcCiEoek3NlR09QE2p3WvcmWi693d3e2M+Glfv/vf/4gv+fP3wOrjK28Ekvf2OqDljhIAlQPg0Iy0zbDuGwRWyuiV6FCgsFzschZBUziEBgDJCgLxqRDyl2gtYEOdvpSTEc8AupToVCh1khJudg2Pqvp85p6UsFaOH+gjNVmlUxWIAXLEgAmx+mu2/o1PSy8DOcFplcqQGuWCgjio1pALsR4OOKEn8d4cuhlWYnDPmn+pZyu6PxFB7RbAVfeXxlMmMliwWPIfEnIjQoQQYQtfvnLTqnX0l0xEbakOFPGYAMuqJRW8P0YsGS5r1Jdownl5uBfO4K1Nv5F5lRX4abHRfqXGHjs/P07nb+s05nuMHMWF08gz1bI4wTfpZ1vBPtwGJIBuyu1+ZTvkmk+jLRWT9WMyRhZ1gr1iJQnhbnTg2FewEOg85O38m3Z013HQISUhxVjijUYfyD3QFRaKhhIQjaCKpQMzpzHeKL2am26G8NqL4Agca+sVKTBcCi0DMhGHf7JWNoxzdcYix8mM3qjmyRm1N8GLtKG+UVZWrMGLWkdYP/eQYi1F0Bi+2EmIpnY5lHCtXT9FNEY2ajbDT/qEekZqk0AIpof4SfwhmMLVjl9lN0UvOEwTT6GA45tAjjA3stAC4L5crLqx5VrXN7I64gow+D8iFRMOvseBHxC7G8uT7NCUPmJO7rbqD5GqgIjtZeTnAir4o9rmm2yuKUDr2zAcHTM7etKVZvhn3J8jmOsGG6Vm0JPSueGNDhYRXkECYC3LWHB3GSeSUOwQMCLIoU13+KA2BNDVjOw0we/NgIE0tKmkVLAJfeJp1xOhk1XhoA8v7KmCUs4+t1nv7L2x+Wq8zHNDNsKVkePae2wmkGBwitSRgidqg/VG75aOtgSxKQtu5BgJ7mDRzkCbxGWEfCDcaZLl40cxbKLCddM3gtqGab4Fb5r4WVf0xJDNWs7F+DxCSGNG5WjU4yxwQ4qPFzy1AujwkEwYVt72dxX76Aftgmlz3AwCDjSfMqBQi2VmUhSZlOANMPcI/Re3cXo4vQyY030uCxga8pDTKLSFAfGBqpwoJTn/c5Qx0wpSTzyZZ8fdHZMOjqKlZj9EKHfxvgJLzhWGArzQU5XaUCYMJWc9sU307z2xKV0ZSN990pzBVnMCjsKFwBzxEKXHmXblFopi3tPOxWkl5isWs1Fsusy8k/fw+ojscBQHVD/YFEKVxx8E4Jex2orTUIiCWiEs8X0WhgoKaMuGOAHjwqrSGuZTw9DyA70ws7RQhnm6wEsq5UW74wlZu99kj4WJCdVfCAoZDTpTtIbgR6Xp8dXSNpjZvZTPZQpJuZvByNpQkmSK2R8OyvTXRRsPLA/cfjaShxRY/R5lRXKSA21F92lrNJOPo6Af3LnDDSRXITxouSkXfRi9gdB81I2CJMmDFYbUh7aYcsFVWTz+uwR1nuwPYy8HIW6+zzsVnj4mavOky8KeqlkyyflVbkq6hjrc3fBFI5LLpVVHKxdijZhCR9jREr8OMAAIFNLGxjMDHseiyrCRr/Fl4DRbpGX+QMifKuVbPi3y3zhRfZhjrn0Y3YLemEWZ/sqhvftnLJPwezqBiuzyzDMBXo1Ny8D+NUfCdd99J6ytpiio98INsgmlKrw6ExYlHppEomVt0jkECOcGRMNycoJCzkh9c/qGnR3YceLvbYXgBGDlehSQQZ03GvjoAtUpPsmtEQdBqGMqLVyXEmVL0w9LgBfK8hLUZALgq5V5CeJ87UpyWX8vig1uQB+rSh/CkW5oO8XqCoXwK+V5UWU5YJ+37y6/FI0JDM28WtQfOYNUVuibqSw+JZUHhvnL0aTscF+GQqKgmmtd8yldyiyvSR1Qvdj+bK1hHll6RIUCR3F9q3rB7mX9kS+di+Nk+QL8y1JqNeOpaU4liQ1116l6ZT52lxKFnJflD9JQr5W6j6FM0kS9wv0JEnI126kRdxIknjfpI5oBjq2vV6RyWiEOzrm9zMFPfJIKvQxpvor1EtgIDivxnM6afJgVLTQUuEGkzY5cy7D1ip4EsbOg+io0gyUZ4dDYUCrTtKRBVxGGlyVIDN/nGIgcJrPdSzI2avWPLzqJ7GY0Sr85IAWpK4ujOh1vTT8WjNXS6Lc4L62xX3VBen/CqPI295zG84mr+n/dE6uPsj1dX65dpqtdpNDvt96Pn7xzy3neAhv/yY6P4f59n5jz226zT27+8bPP928vazxez8K/y7ZUgWstpstmOxt0gkjsd3cO2vuHsoFgqF2jfaiepkyt+sNwmhViX6AIs/nbKoI8lQEfS+vwXOd0ItrsNWF6GQBJjbEAciOrUri8tOV+Hx72fK/cPkmbDhLCrMyqmKzbIVu3pZS0Us2Fyr5llnxbfKHdy+qKHwn0lisypQew41n1+hwVSrvYdpO3HV33Ua92WzVqVx46Fdh9Q1eYkzgG1XyxuCaaYzyzypqKpPtc3GJml/KGR9kdZLVnFFnFOejp2SLlz6ElbIFAV2ZOZZxItCtnFdWDSKrDAwyLKH8Fz+RlJHHUk96XDxq5FEOupgXUAlgkfpoYJEsxryRwlb7RT+eYbnrKEoecGTZd7uoskFZx5u6zt7WaziP4tHHmjPwfKJ0HH6sGTnNRO/xkkfAP4/J6NWrFDUij/LXKB1Kpm3KQg+Y9lSTpWiMfDguf6OHHCbDEdqq2C48Elh9LhJYIp6ywzD/CQgV4wxezGXDeaqzk+saUnWYJsMEXgqNXGwvCKjXenV+E6E6rxUCy7nakpBje2Me0dlsuM0qxWG1KBj1OWdQSVEJsoye+0irCtLc+fXy+N2shg4+q0wcLEWj8u2lSf/oHDZabvNPMC97m9kWJ+kOPf9O5LqoX8Z5dFjUI+5RGS/qmMZ/0vheliV+KCvs4hCxKvxBPhZywiAF9Mb2dCsDORkf7Kozu95p77j+iYs0qMICq96kAQ4HoEUSW0CF0nlJuoyotBG1g/+uyBik4poA6J/1MK7/iWXJvGE2Yihh+7OLqAoyx6ppArwR+kZescxMo/Jmni7ekok4g0E2hdtznX8JcVdzfguBeH0vvduiiiThPWZSatOYHIip16VODyVKhABJOnFVeQiHH5LIFQucOZsq506OKn+z8d+agOR09Bg/Oe68WE5Bj6Xld+o4iB61/EbvnZRwiHtcwSvI6Nz1UihyAB16JC/kkL90VPtbg7kV97oml8tTpIL/1OOqmqjibdMFSPXI9K6QtTaV4zAIYd8KclSWd5gckyAwxpu0Ll2g+QN8C1ycEvNnNfZMwdnZ8SLssJZmC/gbVuZcJ0QvTtm4QlYp+mPoVamW9fOeZSt0SfwylJW3CTtyF86LHywmtkyax112P4qwvxDY00WdeXWEVPw07SzBo8QaboaMYa8SAGcsgVgWsbTciHMk7LI6udJiP9SAFCsksEqCp0Xq98NccGdXQjAfo5hHgYDGZeoNqamyfJfS+etaemx2zTu0U/IZ4FzXH67PtvAPbh8V0YPflRgGXlBVjOHJcykVtqwaAZyLj/W2sNzFY9YbeWng8t/Ug+TPB9Hpi2i43U3aVMEu2kZtNBJBT+DQ2xaCbaXZY8JdPvj3P2ggDZhNjOLZ/2xVVhhTVR9Vlne1Evvq3xsKtwXiDfwIjyVV5mOVLbGsiXWpc4tCmU8F3ySTWQtne9TMomnUXg3LKWz791m2XV3q/tfrufuDGBh8TU7RG/Jl6y+qF4K2szxjM61ywL7D07s8b9UIEzadfy/cQZingtaLZOZ21/uTNk/0PfzaplIJbQPArA1qAhqJ/z6hVjjW1KZMxybwqENgrzEsHg2LfmZi+p9KzriI0SoEq5Z7AjqgHLbc/ZpZEMwmjbRw31+duO78geXUpWrVW0/JbuNOk7Q5vsLHkicTl65621Ut44R9dzYviVamdSFlFEWkUNq8ON1SZWdkuzKrlFP14U4YI9u6zoVZlANdKuZVspxEDqziJ8Zpbw883xZ6gI3bDmHPwFYKgy25Z8r7xG46Xt4zF6f/mbCWde5PCf+bu88g1e4Wq+uOcgwzcqHRycLMsi2kZOPi6kDgsMemoUUrtWh6JwWl9ZtW4dNeOb8X1jthjN+SZx8+/x3/+EHTeb/ZXIDMyLDtlW4iaYEDaTKselPJ7hO7nTYbzUN3EYbCuYDwLpAvSNIVomvWJiqrLwSSwyBVonwDi92J5m9OiaEibsduVfgUol2wgPMpZfpgSK5TlGJqO1/ZN9wG2iZN+FdWKsM/4XBUt1IDLEKXYSF0s4LvG1S6MzligtY+6rBZBsb8gAIO6EQZRkmYK2INRJ6GfuZsennu+XdANAyOKzzQXDz3Izxag80b3oeR6AnZPUFGMmG1fXJRbdWw3jT8XYxqxiXhGEZdYNDCUxoWh5JRjATTluysgC0sJigwFQqnMl6I3etB4o8Q5a1K3X3P3Vts6UV8H6ZJjCPPdUe/Qh44M0F8ihm8GDhAlYwm7pErV3MWWTmKVgGjCMfPXtjSgSUD8vGlrtqNhO6pBaMr6AH2A6UFQFIHoVEqslgmCvyTa+gvdx/NQfXV3neQi+Sd6jVneZoKp8Tmu19BvdSKCNXbzD2MDS5KH+LyED97Mdb8o6uFjcvkAePI3gLFR4MN5v6Nn8Jef4OWBo1b5x4DSTpaDOsRiUOysuOYYnWLuXKaqhhrB8bi2oyP5PsFumMJRLO2Oo5QPGytncFd9ARWXH/ACoqkWXmx12P/3/nF++sb95e0x20HnU36AoWw8+G6jrjgjWFcB4p1jfaBZmM/LPuOV4CDMFMdPAA79N/Q+UE3IZnwiWlRMye5gprhEDS7gki58AagGfppkrHyD4wQBRNYN74PXOy27faSe/IG1aXoIjauFh7qgmseNpbLs0LNRnNEpXZDFQ2RsiRc1EHrUaffHEs2RpaqDGc2Ng2WC4XFXr2UYlsM0bEYhSuNE5zKt0B40leMnQNtFzH1Ejwpeq3OduOILcsRCjp8+K6NrS7ciMppjJuLhjdauWZWx3fTmxxyT7LoEYMqe7IPlnNzee2gUOYbuyDshXTSqj7NReNl3f4KWD9HfdMBS8NDz2PNud5+e/H2zJ4tlrkvHTiRBd+ewDaNHjNqlkDtZDJ9HYU3N3d6j/+mes6YLWo5BD3jnlz4do26f+h4AIqpvcUfqIfkrUvDyBExlF1kigdPz97X4RxJ8K7HahaOfalVBopsmoRv3lIrO2rhY12jobtZhRvo+12+n2RA8GU363utvf3bLY3e2b1cVC8vQt4NMMavANQdYXGBCgS1QFGk4M6VTA+zorO8IsDVlo5B5zaPMtfounkrm1/JEelnPwrxNoEJOv9tlxfRJsbjiPKXslW3MZVtjQ04dCPT43dbLse44nxYojd9xBPEH9uqUgVRneRZGTHWi1xhHWoDj1uUYp95VYs2YLgDTt9dO2UqOGCrxLgVo8AHWZZJc8FKGxPVTeNf/c3o7TG3RkOxL+nLaEKue5AjUCjOrU2p6D6tyTC1ZndQfXGOYQxsuQzf8R3xyfHCtHkJjcmz5XQmlzi9xGbk86869yLXvcZR1YOPH36g2u1FOW7qPj6FQ6x9+FxuedGNyN8iU6FE+jUUDwsi+NJ6jy8uLIBdlkCDF9iCfH6SlHbMgmT5BtuVx0nepoZ/c6Aa2DqFbSthJhj1Wwz9/pjyyw2GsBeUoDA6DGHBHhf3AH8wwQ/eatQbB/XmvtPYed3ce71z9F+NxutGYxFE+R5zVZiSD2dWLPHu6JCwbL7ebbxu7S2GJbdRaAMztr0IAyry/qrC1o/VfDocjgNScqsHBMxQSYH318fPwNUfpfdilQEbOB/jKYO1wGTCB3z5U4Gto9fBsmjlrFnxs3YUVdIHbf7hXqv5DCKJj8Mknq+1aKlrm0WHMzlc0bNLpNTcxF5sDuKZHdH9vb2dA3NhALCPVXEb8yGfhX+JJSFO7iDMV5BmuLHW2RA7qYN+0gnzbMJO3z2c392VhnBK823haosa8NTqXp6OSc3y1aczua1I6GUAlm/cURAAXRlVQY1kiCuGfY8iP0LQfcPcyMpg70EuPTwJGaYRKk50zTUccrKGNXzRD7qS+Ht752/eHJ0cnJ69OW8cHTaOTputk5PjuSWPdiutXMhe2EUmzCXQQJkS5zdBQcmDgaCrQbvXoiPVC+Uac35MnEsP9K6T9HEIGzoKO6mHoRvXQuib+h4MPOpQPGAvieBp+Ge7EyUd+LfpNne3s9Tf9mmAbfS30H/cXvL95c7OQf1yZ6+6ayOaNXv79QWOAekceRkugEz7ABRYVchiFLwI3B5QzYu03hqLfHHcX4KJvxwLX+H0Ek38sthTjjnclFNt/OubHwodveZc/nDtxc45Wu9h5ieGD4Dve8jiXzqXvGjT3iLIohi+NNt+mhCwFnwZCL9AQ74C/7nQ+wYNcnk5v1ptz8hJ5JsXUr0q2XbnKYTqCMlrUOAoTAM2NCiG/LHuqyhleaf4hp+xQPlfNP6J6pEpz1Z8Xd+PqasturmPItk6na4/EGTzFkff4FCCJXYJNQ4SphuslG6Njn231cPGgxUA4v9OsdO5T7dmdbq5Kl6ka0L6FNr5lRg90it64in4ED8Xq+j8VVRLmQQeZ8aUHh6EPY6dfu3k6UjYozNFrGET2mDq4pI+tKv4aALqen0o3IxCWnqjlBaFJ6vCbwbS4wqZz01FiwZddE2njozERdMHuxnFIAQKyfMkjcgtxO866l0M3Jbbwo+SUVDsgBP8qOJeUowc8PCOtnpTvJW/clCTb71KwbuFbQYf2vRAWw2JT/oiyzj40twj39k5MPCSGw6AXkVNsaJW1SCsex0/aLZ2dqczyAWOgIl7KiyYwVUUkezxvXOMK0UPJWD1GYyqAEL4XYZK4frEUlc+PHW5jTkUgEWY8PRpNEL6+blnmoF7S3PNysbGbAPP74exaBtVH6ZPJl8wy0TMOpcZfdieQaBNf2vWWWHBSYrNuHDy8fnXDVsza+1y+hzWo5XjK7EQJP4d8aqUC6fqc8X24t9IN8HzMYoADcxYQaHAv+EOz7AUXZslc6FrqOOY56trmTDh2NRgzZmEyacDVUcc01NMYhkEq36lkmgTpkKJM/9sJOlK1uUcs5benG3SxaejtsYZCs6bX05/ee38hP3CE2fgDbnKyd/HYLEO+icO+ynyvJDpDIKrOBfP34Jvf+JPFYNcxN3E5FZ5LFDjdiVrDAbF7yvZU54bZyfXZsUD1V08c4WfuY+DyJXPcVKtl7JfGkMmizfttskSkemcPnlprJqhaohOkkTCi2ckb7egCCXXFcv+XVXllc4ojGYxA/TpvdE8PG02jjZmAwfz/nAGM46rGhD0/VTug2mwZHkqcr8/OzBqFi6wHD9qDrwbdbCMVk4hJpIPfza/qxi3+F3rXLYCVQzqmFw4XaoWLz0pWS2g55OuwyRwZyT3FIoaFIABufpB5VSjMFjaTFcw04eL0+qJwuHYPNZXs09xcfVdZXkHup5ZGjLFiBWXzYFY7hrFqszdhMlKZtDzJ1QDVlWmwBn/3//5v5msXzcOkjwj/vbs08j4uQ2H3BArxfKzG3/bmBsneXrCQOMgUzlm9t69OLgN2KqBz0REKWIvD3QNWTXgwFlRiNd4+XK3SzHuhE0TwAPJ46DkFHj+xMW4EyYml2J3FC0dZWPgCVM/obUuOrEeVt6VBGGX8pmx6InHAfD0g657nI5idLBsrUibnxcLnloqF/IcLzSLK/1Fxbjyx0Kn0A6NKh2gGHs+BUB8nJUycga3yHaYYnaMaXYPseWAHgdwjLoKf3rVVhyNRPEpFT0sXWMaaz7p354U0fB08fqJJaGeDc/kiiZTa5pMqWoytXzqkwVUx2g0R/162y/INezVpvkjiZK70Kt7ozzBWmbJvenb+N/8K96z0i+PjvmcYzjunvSBVgxlKvESDj3kpNsB+ZzLTmI7PXIOp7i6RZHRMkA4BYBR37F6zpLTaqbpzjwsOk2Vu/ueVVNDxiRiVlpHOCLEgi1FWbhgxIWEci/NsT0Jry8PFFJDjAGX9NBufUrhGXopAI5Zg8AanDpL6yZysuhdtF/5C/xYk7UgCDRKnvMiHCLPOIDq4oqfkBIKnqxRdhPl0logURYd5hkBZapJKJN/YKhg5OfzE5Ii+rT4l8OglalxmzbtwuxiTfsq06VMN42Zt56Y2qjvMOfM/K4OatDoG7yQ4UlNRWbDuBqOURotNvuH95cOdkqgYDCeTnIrQTKN6P4oFfZusb0sE2b9rS9oGxT4PXiZZnHpkcIwNjweVCGuFMOGtaOhfH2piy5VTsmeJ+mj0pUdydHQURWpjdIF1DFChRIr9FIvzriMA8LSjYBiBLU3xECvYJI8M3z2U9ZAlT6iBh7GTHahuZ9ubmCrvn28/sdlzXkvQEzTtff7D2+x6JwecAOB20AkVDFV/EKH4ckaBEGVb73YvqSrPH3vWq40omQIZSJyndIyUu7UKb20l82yVwcDEEb1KIyXN/WYZjYBgONOlkSjXJBiV6Spp/LEJCCKsabPiTmYaNXpDl5P4y5fMZp+SQLYIEyfl06cGfgSb1Ar0KPXkelLF5aU2rkk7gnjMA/pRn/6KpZmXRIDLTj7s3iIx3qah0pzLpOHbBCmzzsvD5XQs3lIXj8L+8Y5FV7U1p7HSTd8qr4wCMsHLwW9qnil2rCadFFu7SPJAaouiSmW6UoA8Pj4WONjkyS/URNIRmRQ6VjZsUahXyTwulZLPhbulrzPSTlTj2O4EUVUB6oHkhnrocfCcZy+8EDHrqHyLe0O5/af9XNFH/zr1mykFkfM+LrGJ/wBxwsOHFAouBc9eI+ZVG4pYrwmtUGstuL3jZxSynFnZGEdbgmlGJVWpJekQpmziLjo2JxxpcvPz7XMN2o1qR4YgJUnfhKZtQbtDY98gS0BZK6EVKiLm6Tc526LWTYajMlEDTGobyJyx+78ZzMvzCrgY9yIi9IRIta6COqIquHCbS8Vt0Xg/y0otkMvvq05t/cfI/4Dc4PuBbfOA8bLbl0sN6l0h1hkJAUJfuaR7yz3AWaaR2Y9WK4CYdR4p1ep2QJSmMrpTCVSyQIzEkAmrSjPUPgTZBcjhOHXf2JhZ8Dtx7N3Z7+eOb++u+Cd9eP7M6R+zVDygNLvr6/gcVm+AoNmJEO8vbq8Zqf1J6HOq8z0hpRIJXVe0uLaFHJWKL4b56iJUv2HjWr1dxbll9RZsOxE1J2kyOIjbtfovTWHwn8hK71naMlxiCCWDvGyXH2KeXzcajQHJe2hi6RWNLIrigXRHUhG/TawpgsXapFYyDjROHosMRlNEAZPQco1YngsWH6u70NVUnSnAXQpSgpigS8lzcdnMxtEzMjKXE3eSC1RnlrZEKCLlr/Fgh77supYPp/OBtNTZoj0JxnR673KxuZnVjR4enz3EqpLlm9E/hkEWyHQChG3uGAzKGV2A11csJmkWUu1p+jkD900z91RNieh3mPSVD2HX6zulzcnoAjBDs363h2FpQxCLJ0lYCcHYDkPhJcRUzHLFcXNwsLtJXU2vQmv//udUtaPT35WT8G32/CxbHFRWVfGStB5Lct/zYveO+3DRIwy0eMKTnrQ3LgOlpNOhARWp51027AjS7FkC4OhjE8uYgvmhAjR8saThVISAh0zUwFOMKK7wVy0Pf9ucbroYXBV0McJazYjQf4SadKWbaSWQxDTeaTI4gX3mKqER4nn4JSyc9VkuLLHuM1tbpYElt6gyMPdyOvNRSY4lD8ROOcXC4ADEuQTgfP++mZGcGACMA75Sm21m9yceTpMn2y7GxPNtOfLgH36jT87kT6xCDBJNSYHSp3snoB0xUJhdhKuWDzMDtiKBcU4YNV+LdR3no6Oty2WJ9KKyobZhOHMB6eOOGa7TBjQeG7qeFKGUqg8Zs62O49gELbzJH8ScPkqvbDQVJID5pnMZpqp0+GiL4SXyS0zI1eabR7UzPmewE+6HOjwGXM53BjOp+d6Hiy36lQHBOI8msG138eCbJShKV+puFhz2QsOdgu2PeUSvLaZw1UxAjEUYCxIE0H7CmtUm5HLYGboXGW/KFgUsVnWAeul4PsoIIo7BhphwmWcXK0EVIhOJNrc186MOvrlZ+PDWZoa1351VW+u/PUJGzH8dTmVpp8EM9+WbMPonW1+qZKohR87l5VmTMNKvsiRaT+e3dScq18weffqw42saJ84VCQfnbDX/7g0B8Eb+Y4eafP67PLsBN76cHV6fHNWc07hM/5bjFI6F1SXjadxxUgb34tKfTkIFJNXqVEIlo6twNpyheP1Ol3yjIbKdCTHVwb/7Tub23bjrxqXZeHXjJFutzHoJ9tu3tYU3zF0MKH87ZYHCmQd72zswe+MECbZn5BWkFJFqbkHEUBfAKDPuwt2vrqQB4vfoIA5mih7vxDhaRw+hf58IVaSDNOorchlX6cg/1ikKJ41EcZHQdLVebtnORaXr9mSQL5F1bIsJP8cCX3XNmfMBb1KOdNOfzTACvnCCzhll9O9DTTDnL0txaoZvYmzBHcV3lGRa+gWtpUjWaWdCex9x91a4HDJJYPI+IEwN1miPA5vMAwaIt8HjYiNMlMmnByvvOipN7DVLCNMbgo1kEVgvCKsKbOX2UwbQpHBfq8b83lr7W/6adjN6++vTspvF28YIbA8e/lKIinng1Rkf6NEdQciy4oA2QlovuWH5LRXdPhSIr0888z+eqNsRMusrxGFJdAHeqhEdhcZgpWorimx7zLyvSr2ZRR+llE9WBy9OyocnHTllSYj4L6snyR50YJVL6zvoYofLeDZvcLdrM4+GIbKbDv9JAo0wMKnW2HWRyqOlVeG75HuCFhIRST7qDbyQ4rmcSwx0S9mfDUpJypEaTAaDNt5GvZ6Ir1FKste3IX4CXUKN3dby4sZC4NNTiqbDHQAfozHjYMankD6DUl08gpg9FaXXqQ/iwuNrgePBlVU53pRJeNlxrIA/KZDjWv6XBeO94CmtEeTACwEKsogbd8U5MLfbp1kWNBIAaUXVo5IN/rFuvKmxiPZHE91NagKd8JtqhJcinsvYOlC+XxPH8pF4MbVTLUXzOFpX0zQLiW4c0ovlDb4lNbrSkeNJgL6JrDLMAP2EBqlLze9ITsxqEmJ94hGJ3C11AuoIv5jMb4eHk5180IMyIodbcxeidWCrdRVYnmYymX9zKhahot08asdpOyXt8bXzqZhzWRb81gy5uiyl4DeZFbAkM1x1R4BthrDeH5TeFpslfZh0AtlajnvktzUb6mSYkl9HV8xef6AiRX38n5NVwQkzZa+U/NcXJlhiXQdQ3EpYxVJCHcYY0F7eREKMLd+ThL8f4PnCoE="
 }
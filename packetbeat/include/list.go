@@ -24,16 +24,24 @@ import (
 	_ "github.com/elastic/beats/v7/packetbeat/protos/amqp"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/cassandra"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/dhcpv4"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/dhcpv6"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/dns"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/http"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/http2"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/icmp"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/kafka"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/kerberos"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/ldap"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/memcache"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/mongodb"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/mqtt"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/mysql"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/nfs"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/pgsql"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/quic"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/redis"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/sip"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/thrift"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/tls"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/websocket"
 )
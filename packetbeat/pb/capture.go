@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pb
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Capture holds the metadata of a captured packet. Protocol analyzers keep
+// the capture metadata of the packets with the transaction the packets
+// belong to, and add it to the transaction's event with AddCapture.
+type Capture struct {
+	// Interface is the name of the interface the packet has been captured
+	// on.
+	Interface string
+
+	// Tunnels the packet has been encapsulated in, outermost first.
+	Tunnels []Tunnel
+
+	// Comments attached to the packet in a pcapng file.
+	Comments []string
+}
+
+// Tunnel identifies a tunnel a packet has been captured in.
+type Tunnel struct {
+	Type string
+	ID   uint32
+}
+
+// Add merges the metadata of another packet of the same transaction. The
+// interface and the tunnels are the same for all packets of a transaction,
+// and are kept from the first packet added. The comments of all packets are
+// collected.
+func (c *Capture) Add(other *Capture) {
+	if other == nil {
+		return
+	}
+	if c.Interface == "" {
+		c.Interface = other.Interface
+	}
+	if len(c.Tunnels) == 0 {
+		c.Tunnels = other.Tunnels
+	}
+	if len(other.Comments) > 0 {
+		// copy on append, the comments can be shared with other captures
+		n := len(c.Comments)
+		c.Comments = append(c.Comments[:n:n], other.Comments...)
+	}
+}
+
+// AddCapture adds the metadata of a packet of the transaction to the
+// fields.
+func (f *Fields) AddCapture(c *Capture) {
+	f.Capture.Add(c)
+}
+
+func (c *Capture) marshal(m common.MapStr) error {
+	if c.Interface != "" {
+		if _, err := m.Put("observer.ingress.interface.name", c.Interface); err != nil {
+			return err
+		}
+	}
+	if len(c.Comments) > 0 {
+		if _, err := m.Put("pcap.comments", c.Comments); err != nil {
+			return err
+		}
+	}
+
+	switch len(c.Tunnels) {
+	case 0:
+	case 1:
+		if _, err := m.Put("network.tunnel.type", c.Tunnels[0].Type); err != nil {
+			return err
+		}
+		if _, err := m.Put("network.tunnel.id", uint64(c.Tunnels[0].ID)); err != nil {
+			return err
+		}
+	default:
+		types := make([]string, len(c.Tunnels))
+		ids := make([]uint64, len(c.Tunnels))
+		for i, t := range c.Tunnels {
+			types[i], ids[i] = t.Type, uint64(t.ID)
+		}
+		if _, err := m.Put("network.tunnel.type", types); err != nil {
+			return err
+		}
+		if _, err := m.Put("network.tunnel.id", ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestMarshalCapture(t *testing.T) {
+	eth0 := &Capture{Interface: "eth0"}
+	vxlan := &Capture{Tunnels: []Tunnel{{Type: "vxlan", ID: 100}}}
+	mpls := &Capture{Tunnels: []Tunnel{{Type: "mpls", ID: 100}, {Type: "mpls", ID: 200}}}
+
+	for _, test := range []struct {
+		name     string
+		captures []*Capture
+		expected common.MapStr
+	}{
+		{
+			name:     "no tunnel",
+			captures: []*Capture{{}, nil},
+			expected: common.MapStr{},
+		},
+		{
+			name:     "interface",
+			captures: []*Capture{eth0, {Interface: "eth1"}},
+			expected: common.MapStr{
+				"observer": common.MapStr{
+					"ingress": common.MapStr{
+						"interface": common.MapStr{"name": "eth0"},
+					},
+				},
+			},
+		},
+		{
+			name: "comments",
+			captures: []*Capture{
+				{Comments: []string{"request"}},
+				{},
+				{Comments: []string{"response", "retransmitted"}},
+			},
+			expected: common.MapStr{
+				"pcap": common.MapStr{
+					"comments": []string{"request", "response", "retransmitted"},
+				},
+			},
+		},
+		{
+			name:     "single tunnel",
+			captures: []*Capture{vxlan, mpls},
+			expected: common.MapStr{
+				"network": common.MapStr{
+					"tunnel": common.MapStr{"type": "vxlan", "id": uint64(100)},
+				},
+			},
+		},
+		{
+			name:     "nested tunnels",
+			captures: []*Capture{{}, mpls},
+			expected: common.MapStr{
+				"network": common.MapStr{
+					"tunnel": common.MapStr{
+						"type": []string{"mpls", "mpls"},
+						"id":   []uint64{100, 200},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var f Fields
+			for _, c := range test.captures {
+				f.AddCapture(c)
+			}
+
+			m := common.MapStr{}
+			if err := f.Capture.marshal(m); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expected, m)
+		})
+	}
+}
+
+func TestAddCaptureCopiesComments(t *testing.T) {
+	requ := Capture{Comments: make([]string, 1, 2)}
+	requ.Comments[0] = "request"
+
+	trans := requ
+	trans.Add(&Capture{Comments: []string{"response"}})
+	other := requ
+	other.Add(&Capture{Comments: []string{"other"}})
+
+	assert.Equal(t, []string{"request", "response"}, trans.Comments)
+	assert.Equal(t, []string{"request", "other"}, other.Comments)
+}
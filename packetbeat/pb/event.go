@@ -69,10 +69,16 @@ type Fields struct {
 	DestinationProcess *ecs.Process `ecs:"destination.process"`
 	Process            *ecs.Process `ecs:"process"`
 
+	// Container identifies the container the process runs in, if any.
+	Container *ecs.Container `ecs:"container"`
+
 	Error struct {
 		Message []string
 	}
 
+	// Capture holds the metadata of the packets of the transaction.
+	Capture Capture
+
 	ICMPType uint8 // ICMP message type for use in computing network.community_id.
 	ICMPCode uint8 // ICMP message code for use in computing network.community_id.
 }
@@ -130,6 +136,10 @@ func (f *Fields) SetSource(endpoint *common.Endpoint) {
 
 	if endpoint.PID > 0 {
 		f.SourceProcess = makeProcess(&endpoint.Process)
+		// The destination process takes priority, see ComputeValues.
+		if endpoint.ContainerID != "" && f.Container == nil {
+			f.Container = &ecs.Container{ID: endpoint.ContainerID}
+		}
 	}
 }
 
@@ -145,6 +155,9 @@ func (f *Fields) SetDestination(endpoint *common.Endpoint) {
 
 	if endpoint.PID > 0 {
 		f.DestinationProcess = makeProcess(&endpoint.Process)
+		if endpoint.ContainerID != "" {
+			f.Container = &ecs.Container{ID: endpoint.ContainerID}
+		}
 	}
 }
 
@@ -392,7 +405,7 @@ func (f *Fields) MarshalMapStr(m common.MapStr) error {
 		m.Put("error.message", f.Error.Message)
 	}
 
-	return nil
+	return f.Capture.marshal(m)
 }
 
 // MarshalStruct marshals any struct containing ecs or packetbeat tags into the
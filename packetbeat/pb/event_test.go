@@ -69,6 +69,23 @@ func TestComputeValues(t *testing.T) {
 	assert.Equal(t, f.Network.Direction, "ingress")
 }
 
+func TestSetEndpointsContainer(t *testing.T) {
+	f := NewFields()
+	f.SetDestination(&common.Endpoint{
+		IP:      "127.0.0.1",
+		Port:    80,
+		Process: common.Process{PID: 100, Name: "nginx", ContainerID: "server"},
+	})
+	f.SetSource(&common.Endpoint{
+		IP:      "127.0.0.1",
+		Port:    4000,
+		Process: common.Process{PID: 200, Name: "curl", ContainerID: "client"},
+	})
+
+	// The destination process takes priority.
+	assert.Equal(t, &ecs.Container{ID: "server"}, f.Container)
+}
+
 func TestIsEmptyValue(t *testing.T) {
 	assert.False(t, isEmptyValue(reflect.ValueOf(time.Duration(1))))
 	assert.False(t, isEmptyValue(reflect.ValueOf(time.Duration(0))))
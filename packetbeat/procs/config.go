@@ -17,15 +17,41 @@
 
 package procs
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// Backends used to find the process that owns a local socket.
+const (
+	// BackendAuto uses netlink where available and falls back to a scan.
+	BackendAuto = "auto"
+	// BackendNetlink queries the kernel with NETLINK_SOCK_DIAG for the
+	// socket being looked up. Linux only.
+	BackendNetlink = "netlink"
+	// BackendScan lists all the sockets of the system on each refresh, from
+	// /proc on Linux and the IP Helper API on Windows.
+	BackendScan = "scan"
+)
 
 type ProcsConfig struct {
 	Enabled         bool          `config:"enabled"`
+	Backend         string        `config:"backend"`
 	MaxProcReadFreq time.Duration `config:"max_proc_read_freq"`
 	Monitored       []ProcConfig  `config:"monitored"`
 	RefreshPidsFreq time.Duration `config:"refresh_pids_freq"`
 }
 
+// Validate checks that the configured backend is known.
+func (c *ProcsConfig) Validate() error {
+	switch c.Backend {
+	case "", BackendAuto, BackendNetlink, BackendScan:
+		return nil
+	}
+	return fmt.Errorf("invalid procs backend %q, must be one of %s, %s or %s",
+		c.Backend, BackendAuto, BackendNetlink, BackendScan)
+}
+
 type ProcConfig struct {
 	Process     string `config:"process"`
 	CmdlineGrep string `config:"cmdline_grep"`
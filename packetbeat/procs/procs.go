@@ -21,6 +21,7 @@ import (
 	"net"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -55,6 +56,7 @@ type process struct {
 	name, exe, cwd string
 	args           []string
 	startTime      time.Time
+	containerID    string
 
 	// To control cache expiration
 	expiration time.Time
@@ -71,7 +73,20 @@ type processWatcherImpl interface {
 	GetLocalIPs() ([]net.IP, error)
 }
 
+// portLookupImpl is implemented by the backends able to find the owner of
+// the sockets bound to a single local port, without listing every socket
+// in the system.
+type portLookupImpl interface {
+	// GetPortToPIDMapping returns the local endpoints bound to the given port
+	// and the PID that owns them.
+	GetPortToPIDMapping(transport applayer.Transport, port uint16) (ports map[endpoint]int, err error)
+}
+
 type ProcessesWatcher struct {
+	// protects portProcMap and processCache, as the watcher is shared by
+	// concurrent workers
+	mutex *sync.Mutex
+
 	portProcMap  map[applayer.Transport]map[endpoint]portProcMapping
 	localAddrs   []net.IP
 	processCache map[int]*process
@@ -81,14 +96,28 @@ type ProcessesWatcher struct {
 	procConfig []ProcConfig
 
 	impl processWatcherImpl
+
+	// lookup is used before falling back to a full listing of the sockets
+	// with impl. It is nil when the selected backend doesn't support it.
+	lookup portLookupImpl
 }
 
 func (proc *ProcessesWatcher) Init(config ProcsConfig) error {
-	return proc.initWithImpl(config, proc)
+	if err := proc.initWithImpl(config, proc); err != nil {
+		return err
+	}
+	if !proc.enabled {
+		return nil
+	}
+
+	var err error
+	proc.lookup, err = newPortLookup(config.Backend)
+	return err
 }
 
 func (proc *ProcessesWatcher) initWithImpl(config ProcsConfig, impl processWatcherImpl) error {
 	proc.impl = impl
+	proc.mutex = &sync.Mutex{}
 	proc.portProcMap = map[applayer.Transport]map[endpoint]portProcMapping{
 		applayer.TransportUDP: make(map[endpoint]portProcMapping),
 		applayer.TransportTCP: make(map[endpoint]portProcMapping),
@@ -145,6 +174,7 @@ func (proc *ProcessesWatcher) FindProcessesTuple(tuple *common.IPPortTuple, tran
 			procTuple.Src.Args = p.args
 			procTuple.Src.Exe = p.exe
 			procTuple.Src.StartTime = p.startTime
+			procTuple.Src.ContainerID = p.containerID
 			if logp.IsDebug("procs") {
 				logp.Debug("procs", "Found process '%s' (pid=%d) for %s:%d/%s", p.name, p.pid, tuple.SrcIP, tuple.SrcPort, transport)
 			}
@@ -159,6 +189,7 @@ func (proc *ProcessesWatcher) FindProcessesTuple(tuple *common.IPPortTuple, tran
 			procTuple.Dst.Args = p.args
 			procTuple.Dst.Exe = p.exe
 			procTuple.Dst.StartTime = p.startTime
+			procTuple.Dst.ContainerID = p.containerID
 			if logp.IsDebug("procs") {
 				logp.Debug("procs", "Found process '%s' (pid=%d) for %s:%d/%s", p.name, p.pid, tuple.DstIP, tuple.DstPort, transport)
 			}
@@ -171,6 +202,9 @@ func (proc *ProcessesWatcher) FindProcessesTuple(tuple *common.IPPortTuple, tran
 func (proc *ProcessesWatcher) findProc(address net.IP, port uint16, transport applayer.Transport) *process {
 	defer logp.Recover("FindProc exception")
 
+	proc.mutex.Lock()
+	defer proc.mutex.Unlock()
+
 	procMap, ok := proc.portProcMap[transport]
 	if !ok {
 		return nil
@@ -181,6 +215,18 @@ func (proc *ProcessesWatcher) findProc(address net.IP, port uint16, transport ap
 		return p.proc
 	}
 
+	if proc.lookup != nil {
+		endpoints, err := proc.lookup.GetPortToPIDMapping(transport, port)
+		if err == nil {
+			proc.updateEntries(transport, endpoints)
+			if p, exists = lookupMapping(address, port, procMap); exists {
+				return p.proc
+			}
+			return nil
+		}
+		logp.Err("unable to look up local port %d/%s, listing all sockets: %v", port, transport, err)
+	}
+
 	proc.updateMap(transport)
 
 	p, exists = lookupMapping(address, port, procMap)
@@ -222,6 +268,10 @@ func (proc *ProcessesWatcher) updateMap(transport applayer.Transport) {
 		logp.Err("unable to list local ports: %v", err)
 	}
 
+	proc.updateEntries(transport, endpoints)
+}
+
+func (proc *ProcessesWatcher) updateEntries(transport applayer.Transport, endpoints map[endpoint]int) {
 	proc.expireProcessCache()
 
 	for e, pid := range endpoints {
@@ -322,14 +372,15 @@ func (proc *ProcessesWatcher) GetProcess(pid int) *process {
 		name = filepath.Base(info.Args[0])
 	}
 	return &process{
-		pid:        info.PID,
-		ppid:       info.PPID,
-		name:       name,
-		exe:        info.Exe,
-		cwd:        info.CWD,
-		args:       info.Args,
-		startTime:  info.StartTime,
-		expiration: time.Now().Add(processCacheExpiration),
+		pid:         info.PID,
+		ppid:        info.PPID,
+		name:        name,
+		exe:         info.Exe,
+		cwd:         info.CWD,
+		args:        info.Args,
+		startTime:   info.StartTime,
+		containerID: getContainerID(info.PID),
+		expiration:  time.Now().Add(processCacheExpiration),
 	}
 }
 
@@ -28,6 +28,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -143,6 +144,43 @@ func findSocketsOfPid(prefix string, pid int) (inodes []uint64, err error) {
 	return inodes, nil
 }
 
+// containerIDRegexp matches the container IDs used by Docker, containerd and
+// CRI-O in cgroup paths, as in /docker/<id> or /cri-containerd-<id>.scope.
+var containerIDRegexp = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// getContainerID returns the ID of the container the given process runs in,
+// or an empty string if it doesn't run in a container.
+func getContainerID(pid int) string {
+	return findContainerIDOfPid("", pid)
+}
+
+func findContainerIDOfPid(prefix string, pid int) string {
+	file, err := os.Open(filepath.Join(prefix, "/proc", strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		logp.Debug("procs", "Open cgroup of pid %d: %s", pid, err)
+		return ""
+	}
+	defer file.Close()
+	return parseProcCgroup(file)
+}
+
+// Parses the /proc/<pid>/cgroup file, made of hierarchy-ID:controllers:path
+// lines, and returns the first container ID found in a path.
+func parseProcCgroup(input io.Reader) string {
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		if ids := containerIDRegexp.FindAllString(parts[2], -1); len(ids) > 0 {
+			// Nested containers list the outer one first.
+			return ids[len(ids)-1]
+		}
+	}
+	return ""
+}
+
 func socketsFromProc(filename string, ipv6 bool) ([]*socketInfo, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -165,3 +166,34 @@ func TestParse_Proc_Net_Tcp6(t *testing.T) {
 		t.Error("Failed to parse source IP address 2001:db8::123:ffff:89ab:cdef, got instead", socketInfo[4].srcIP.String())
 	}
 }
+
+func TestParseProcCgroup(t *testing.T) {
+	for _, testCase := range []struct {
+		name, cgroup, expected string
+	}{
+		{
+			name: "docker cgroup v1",
+			cgroup: "12:pids:/docker/8ff2b5a4c2e61d6d3cbf6d4a1b8e9f0c7d5a3b1e2f4c6d8a0b2c4e6f8a1b3c5d\n" +
+				"11:memory:/docker/8ff2b5a4c2e61d6d3cbf6d4a1b8e9f0c7d5a3b1e2f4c6d8a0b2c4e6f8a1b3c5d\n" +
+				"0::/system.slice/containerd.service\n",
+			expected: "8ff2b5a4c2e61d6d3cbf6d4a1b8e9f0c7d5a3b1e2f4c6d8a0b2c4e6f8a1b3c5d",
+		},
+		{
+			name:     "kubernetes cgroup v2",
+			cgroup:   "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod7c2d1e0a_5b3f_4c8e_9a1d_2f6b8e0c4a7d.slice/cri-containerd-3e9b1c7a5d2f4e6a8c0b2d4f6a8c1e3b5d7f9a0c2e4b6d8f1a3c5e7b9d0f2a4c.scope\n",
+			expected: "3e9b1c7a5d2f4e6a8c0b2d4f6a8c1e3b5d7f9a0c2e4b6d8f1a3c5e7b9d0f2a4c",
+		},
+		{
+			name:     "no container",
+			cgroup:   "0::/user.slice/user-1000.slice/session-2.scope\n",
+			expected: "",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := parseProcCgroup(strings.NewReader(testCase.cgroup))
+			if result != testCase.expected {
+				t.Errorf("Expected container ID %q but got %q", testCase.expected, result)
+			}
+		})
+	}
+}
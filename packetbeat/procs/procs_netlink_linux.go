@@ -0,0 +1,331 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+// +build linux
+
+package procs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/gosigar/sys"
+	"github.com/elastic/gosigar/sys/linux"
+)
+
+// Constants from linux/inet_diag.h used to filter the dumped sockets.
+const (
+	inetDiagReqBytecode = 1 // INET_DIAG_REQ_BYTECODE attribute.
+
+	inetDiagBcSGE = 2 // INET_DIAG_BC_S_GE, local port >= value.
+	inetDiagBcSLE = 3 // INET_DIAG_BC_S_LE, local port <= value.
+)
+
+var nativeEndian = sys.GetEndian()
+
+var diagProtocols = map[applayer.Transport]uint8{
+	applayer.TransportTCP: syscall.IPPROTO_TCP,
+	applayer.TransportUDP: syscall.IPPROTO_UDP,
+}
+
+// newPortLookup returns the netlink backend unless the scan backend is
+// selected. With the auto backend the /proc scan is used when the kernel
+// doesn't support socket diagnostics.
+func newPortLookup(backend string) (portLookupImpl, error) {
+	if backend == BackendScan {
+		logp.Info("Process watcher listing all sockets from /proc")
+		return nil, nil
+	}
+
+	lookup := newSockDiagLookup("")
+	if err := lookup.probe(); err != nil {
+		if backend == BackendNetlink {
+			return nil, fmt.Errorf("netlink procs backend not available: %v", err)
+		}
+		logp.Warn("Netlink socket diagnostics not available, listing all sockets from /proc instead: %v", err)
+		return nil, nil
+	}
+	logp.Info("Process watcher using netlink socket diagnostics")
+	return lookup, nil
+}
+
+// sockDiagLookup finds the owners of the sockets bound to a local port by
+// asking the kernel for those sockets only with NETLINK_SOCK_DIAG, then
+// resolving their inodes to PIDs.
+type sockDiagLookup struct {
+	readBuf []byte
+	seq     uint32
+	inodes  *inodeCache
+}
+
+func newSockDiagLookup(prefix string) *sockDiagLookup {
+	return &sockDiagLookup{
+		readBuf: make([]byte, os.Getpagesize()),
+		inodes:  newInodeCache(prefix),
+	}
+}
+
+// probe checks that the kernel answers socket diagnostics requests.
+func (l *sockDiagLookup) probe() error {
+	_, err := l.dump(linux.AF_INET, syscall.IPPROTO_TCP, 0)
+	return err
+}
+
+// GetPortToPIDMapping returns the local endpoints bound to the given port
+// and the PID that owns them.
+func (l *sockDiagLookup) GetPortToPIDMapping(transport applayer.Transport, port uint16) (ports map[endpoint]int, err error) {
+	protocol, ok := diagProtocols[transport]
+	if !ok {
+		return nil, fmt.Errorf("unsupported transport protocol id: %d", transport)
+	}
+
+	sockets, err := l.dump(linux.AF_INET, protocol, port)
+	if err != nil {
+		return nil, err
+	}
+	ipv6socks, err := l.dump(linux.AF_INET6, protocol, port)
+	if err != nil {
+		// Ignore the error when IPv6 is disabled.
+		logp.Debug("procs", "Dumping IPv6 sockets on port %d/%s: %v", port, transport, err)
+	}
+	sockets = append(sockets, ipv6socks...)
+
+	wanted := make(map[uint64]uint32, len(sockets))
+	for _, s := range sockets {
+		// Sockets in TIME_WAIT state have no inode, they no longer
+		// belong to a process.
+		if s.Inode != 0 {
+			wanted[uint64(s.Inode)] = s.UID
+		}
+	}
+	owners := l.inodes.resolve(wanted)
+
+	ports = make(map[endpoint]int, len(owners))
+	for _, s := range sockets {
+		if pid, found := owners[uint64(s.Inode)]; found {
+			ports[endpoint{address: s.SrcIP().String(), port: uint16(s.SrcPort())}] = pid
+		}
+	}
+	return ports, nil
+}
+
+// dump returns the sockets of the given family and protocol bound to a local
+// port. The filtering is done by the kernel.
+func (l *sockDiagLookup) dump(family linux.AddressFamily, protocol uint8, port uint16) ([]*linux.InetDiagMsg, error) {
+	req := linux.InetDiagReqV2{
+		Family:   uint8(family),
+		Protocol: protocol,
+		States:   linux.AllTCPStates,
+	}
+	var data bytes.Buffer
+	if err := binary.Write(&data, nativeEndian, req); err != nil {
+		return nil, err
+	}
+	data.Write(localPortFilter(port))
+
+	l.seq++
+	msg := syscall.NetlinkMessage{
+		Header: syscall.NlMsghdr{
+			Type:  uint16(linux.SOCK_DIAG_BY_FAMILY),
+			Flags: uint16(syscall.NLM_F_DUMP | syscall.NLM_F_REQUEST),
+			Seq:   l.seq,
+		},
+		Data: data.Bytes(),
+	}
+	return linux.NetlinkInetDiagWithBuf(msg, l.readBuf, nil)
+}
+
+// localPortFilter returns an INET_DIAG_REQ_BYTECODE attribute matching the
+// sockets bound to the given local port. It uses the S_GE and S_LE
+// operations as S_EQ is not available in older kernels.
+func localPortFilter(port uint16) []byte {
+	// Every comparison is followed by a pseudo-operation holding the port.
+	// A match jumps to the next comparison and a mismatch jumps past the end
+	// of the program, which rejects the socket.
+	ops := []struct {
+		code, yes uint8
+		no        uint16
+	}{
+		{inetDiagBcSGE, 8, 20}, {0, 0, port},
+		{inetDiagBcSLE, 8, 12}, {0, 0, port},
+	}
+
+	b := make([]byte, 4+4*len(ops))
+	nativeEndian.PutUint16(b[0:], uint16(len(b)))
+	nativeEndian.PutUint16(b[2:], inetDiagReqBytecode)
+	for i, op := range ops {
+		off := 4 + 4*i
+		b[off] = op.code
+		b[off+1] = op.yes
+		nativeEndian.PutUint16(b[off+2:], op.no)
+	}
+	return b
+}
+
+// inodeCache maps socket inodes to the PID of the process that holds them.
+// It is updated incrementally: on a miss, the processes started since the
+// last update are scanned first, then the ones running as the user that
+// created the socket and only then all the others, stopping as soon as all
+// the inodes are found.
+type inodeCache struct {
+	prefix  string
+	inodes  map[uint64]int
+	sockets map[int][]uint64 // Socket inodes of each scanned process.
+}
+
+func newInodeCache(prefix string) *inodeCache {
+	return &inodeCache{
+		prefix:  prefix,
+		inodes:  make(map[uint64]int),
+		sockets: make(map[int][]uint64),
+	}
+}
+
+// resolve returns the PID owning each of the wanted inodes, which are mapped
+// to the UID that created the socket.
+func (c *inodeCache) resolve(wanted map[uint64]uint32) map[uint64]int {
+	owners := make(map[uint64]int, len(wanted))
+	uids := make(map[uint32]bool)
+	for inode, uid := range wanted {
+		if pid, found := c.inodes[inode]; found {
+			owners[inode] = pid
+			continue
+		}
+		uids[uid] = true
+	}
+	if len(owners) == len(wanted) {
+		return owners
+	}
+
+	pids, err := c.listPids()
+	if err != nil {
+		logp.Err("Listing processes: %s", err)
+		return owners
+	}
+	c.forgetExited(pids)
+	for inode, pid := range owners {
+		if _, running := c.sockets[pid]; !running {
+			delete(owners, inode)
+		}
+	}
+
+	var passes [3][]int
+	for _, pid := range pids {
+		switch _, seen := c.sockets[pid]; {
+		case !seen:
+			passes[0] = append(passes[0], pid)
+		case uids[c.uidOf(pid)]:
+			passes[1] = append(passes[1], pid)
+		default:
+			passes[2] = append(passes[2], pid)
+		}
+	}
+
+	for _, pass := range passes {
+		for _, pid := range pass {
+			for _, inode := range c.scan(pid) {
+				if _, found := wanted[inode]; found {
+					owners[inode] = pid
+				}
+			}
+			if len(owners) == len(wanted) {
+				return owners
+			}
+		}
+	}
+	return owners
+}
+
+// scan reads the socket inodes of a process and updates the cache.
+func (c *inodeCache) scan(pid int) []uint64 {
+	c.forget(pid)
+
+	inodes, err := findSocketsOfPid(c.prefix, pid)
+	if err != nil {
+		// The process is likely gone.
+		logp.Debug("procs", "FindSocketsOfPid: %s", err)
+		return nil
+	}
+
+	c.sockets[pid] = inodes
+	for _, inode := range inodes {
+		c.inodes[inode] = pid
+	}
+	return inodes
+}
+
+func (c *inodeCache) forget(pid int) {
+	for _, inode := range c.sockets[pid] {
+		if c.inodes[inode] == pid {
+			delete(c.inodes, inode)
+		}
+	}
+	delete(c.sockets, pid)
+}
+
+func (c *inodeCache) forgetExited(pids []int) {
+	running := make(map[int]bool, len(pids))
+	for _, pid := range pids {
+		running[pid] = true
+	}
+	for pid := range c.sockets {
+		if !running[pid] {
+			c.forget(pid)
+		}
+	}
+}
+
+func (c *inodeCache) listPids() ([]int, error) {
+	procfs, err := os.Open(filepath.Join(c.prefix, "/proc"))
+	if err != nil {
+		return nil, err
+	}
+	defer procfs.Close()
+	names, err := procfs.Readdirnames(0)
+	if err != nil {
+		return nil, err
+	}
+
+	pids := make([]int, 0, len(names))
+	for _, name := range names {
+		if pid, err := strconv.Atoi(name); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
+
+// uidOf returns the UID a process runs as, which is the owner of its /proc
+// directory.
+func (c *inodeCache) uidOf(pid int) uint32 {
+	info, err := os.Stat(filepath.Join(c.prefix, "/proc", strconv.Itoa(pid)))
+	if err != nil {
+		return ^uint32(0)
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return st.Uid
+	}
+	return ^uint32(0)
+}
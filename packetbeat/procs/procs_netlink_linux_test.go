@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+
+// +build linux
+
+package procs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func TestLocalPortFilter(t *testing.T) {
+	b := localPortFilter(8080)
+	assert.Len(t, b, 20)
+
+	// nlattr header.
+	assert.EqualValues(t, 20, nativeEndian.Uint16(b[0:]))
+	assert.EqualValues(t, inetDiagReqBytecode, nativeEndian.Uint16(b[2:]))
+
+	// local port >= 8080, jumping past the end on mismatch.
+	assert.Equal(t, []byte{inetDiagBcSGE, 8}, b[4:6])
+	assert.EqualValues(t, 20, nativeEndian.Uint16(b[6:]))
+	assert.EqualValues(t, 8080, nativeEndian.Uint16(b[10:]))
+
+	// local port <= 8080.
+	assert.Equal(t, []byte{inetDiagBcSLE, 8}, b[12:14])
+	assert.EqualValues(t, 12, nativeEndian.Uint16(b[14:]))
+	assert.EqualValues(t, 8080, nativeEndian.Uint16(b[18:]))
+}
+
+func TestInodeCacheResolve(t *testing.T) {
+	logp.TestingSetup()
+
+	pathPrefix, err := ioutil.TempDir("", "inode-cache")
+	if err != nil {
+		t.Fatal("TempDir failed:", err)
+	}
+	defer os.RemoveAll(pathPrefix)
+
+	err = createFakeDirectoryStructure(pathPrefix, []testProcFile{
+		{path: "/proc/100/fd/0", isLink: true, contents: "/dev/null"},
+		{path: "/proc/100/fd/3", isLink: true, contents: "socket:[1001]"},
+		{path: "/proc/200/fd/3", isLink: true, contents: "socket:[2001]"},
+		{path: "/proc/200/fd/4", isLink: true, contents: "socket:[2002]"},
+		{path: "/proc/self", isLink: true, contents: "100"},
+	})
+	if err != nil {
+		t.Fatal("CreateFakeDirectoryStructure failed:", err)
+	}
+
+	uid := uint32(os.Getuid())
+	cache := newInodeCache(pathPrefix)
+
+	owners := cache.resolve(map[uint64]uint32{1001: uid, 2002: uid, 9999: uid})
+	assert.Equal(t, map[uint64]int{1001: 100, 2002: 200}, owners)
+	assert.Len(t, cache.sockets, 2)
+
+	// A new process and a new socket in a known process are both found.
+	err = createFakeDirectoryStructure(pathPrefix, []testProcFile{
+		{path: "/proc/300/fd/5", isLink: true, contents: "socket:[3001]"},
+		{path: "/proc/100/fd/4", isLink: true, contents: "socket:[1002]"},
+	})
+	if err != nil {
+		t.Fatal("CreateFakeDirectoryStructure failed:", err)
+	}
+	owners = cache.resolve(map[uint64]uint32{3001: uid, 1002: uid})
+	assert.Equal(t, map[uint64]int{3001: 300, 1002: 100}, owners)
+
+	// Cached inodes are resolved without scanning, and the sockets of
+	// exited processes are forgotten on the next scan.
+	if err = os.RemoveAll(pathPrefix + "/proc/200"); err != nil {
+		t.Fatal(err)
+	}
+	owners = cache.resolve(map[uint64]uint32{2001: uid})
+	assert.Equal(t, map[uint64]int{2001: 200}, owners)
+
+	owners = cache.resolve(map[uint64]uint32{2001: uid, 4001: uid})
+	assert.Empty(t, owners)
+	assert.NotContains(t, cache.sockets, 200)
+	assert.NotContains(t, cache.inodes, uint64(2002))
+}
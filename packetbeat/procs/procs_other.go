@@ -19,10 +19,28 @@
 
 package procs
 
-import "github.com/elastic/beats/v7/packetbeat/protos/applayer"
+import (
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+)
 
 // GetLocalPortToPIDMapping returns the list of local port numbers and the PID
 // that owns them.
 func (proc *ProcessesWatcher) GetLocalPortToPIDMapping(transport applayer.Transport) (ports map[endpoint]int, err error) {
 	return nil, nil
 }
+
+// getContainerID returns the ID of the container the given process runs in.
+// Containers are only detected on Linux.
+func getContainerID(pid int) string {
+	return ""
+}
+
+// newPortLookup returns the backend used to look up single ports. Only the
+// scan backend is available on this platform.
+func newPortLookup(backend string) (portLookupImpl, error) {
+	if backend == BackendNetlink {
+		logp.Warn("The netlink procs backend is only available on Linux, listing all sockets instead")
+	}
+	return nil, nil
+}
@@ -20,6 +20,7 @@
 package procs
 
 import (
+	"errors"
 	"net"
 	"strings"
 	"testing"
@@ -304,3 +305,86 @@ func TestFindProcessTuple(t *testing.T) {
 		})
 	}
 }
+
+type testingLookup struct {
+	portToPID map[applayer.Transport]map[endpoint]int
+	err       error
+}
+
+func (l *testingLookup) GetPortToPIDMapping(transport applayer.Transport, port uint16) (ports map[endpoint]int, err error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	ports = make(map[endpoint]int)
+	for e, pid := range l.portToPID[transport] {
+		if e.port == port {
+			ports[e] = pid
+		}
+	}
+	return ports, nil
+}
+
+func TestFindProcessTupleWithPortLookup(t *testing.T) {
+	logp.TestingSetup()
+	config := ProcsConfig{Enabled: true}
+	containerID := "2d1c3f5e0b7a9c8d6e4f2a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b7c9d"
+	server := runningProcess{
+		process: process{
+			name:        "nginx",
+			args:        strings.Fields("nginx: worker process"),
+			pid:         4242,
+			containerID: containerID,
+		},
+		ports: []endpoint{
+			{address: anyIPv4, port: 8080},
+		},
+		proto: applayer.TransportTCP,
+	}
+	tuple := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP:   net.ParseIP("10.0.0.7"),
+			SrcPort: 51234,
+			DstIP:   net.ParseIP("192.168.1.1"),
+			DstPort: 8080,
+		},
+	}
+
+	t.Run("lookup", func(t *testing.T) {
+		// The full listing doesn't know about the server, only the port
+		// lookup does.
+		impl := newTestingImpl([]net.IP{net.ParseIP("192.168.1.1")}, nil)
+		impl.pidToProcess[server.pid] = &server.process
+		lookup := &testingLookup{portToPID: newTestingImpl(nil, []runningProcess{server}).portToPID}
+
+		procs := ProcessesWatcher{}
+		assert.NoError(t, procs.initWithImpl(config, impl))
+		procs.lookup = lookup
+
+		result := procs.FindProcessesTuple(&tuple, applayer.TransportTCP)
+		assert.Equal(t, "nginx", result.Dst.Name)
+		assert.Equal(t, 4242, result.Dst.PID)
+		assert.Equal(t, containerID, result.Dst.ContainerID)
+		assert.Equal(t, 0, result.Src.PID)
+	})
+
+	t.Run("fallback on error", func(t *testing.T) {
+		impl := newTestingImpl([]net.IP{net.ParseIP("192.168.1.1")}, []runningProcess{server})
+
+		procs := ProcessesWatcher{}
+		assert.NoError(t, procs.initWithImpl(config, impl))
+		procs.lookup = &testingLookup{err: errors.New("netlink unavailable")}
+
+		result := procs.FindProcessesTuple(&tuple, applayer.TransportTCP)
+		assert.Equal(t, "nginx", result.Dst.Name)
+		assert.Equal(t, containerID, result.Dst.ContainerID)
+	})
+}
+
+func TestProcsConfigValidate(t *testing.T) {
+	for _, backend := range []string{"", BackendAuto, BackendNetlink, BackendScan} {
+		config := ProcsConfig{Backend: backend}
+		assert.NoError(t, config.Validate(), backend)
+	}
+	config := ProcsConfig{Backend: "ebpf"}
+	assert.Error(t, config.Validate())
+}
@@ -29,6 +29,7 @@ import (
 
 	"golang.org/x/sys/windows"
 
+	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
 )
 
@@ -209,3 +210,18 @@ func getMachineEndiannes() binary.ByteOrder {
 	}
 	return binary.BigEndian
 }
+
+// getContainerID returns the ID of the container the given process runs in.
+// Containers are only detected on Linux.
+func getContainerID(pid int) string {
+	return ""
+}
+
+// newPortLookup returns the backend used to look up single ports. Only the
+// scan backend is available on this platform.
+func newPortLookup(backend string) (portLookupImpl, error) {
+	if backend == BackendNetlink {
+		logp.Warn("The netlink procs backend is only available on Linux, listing all sockets instead")
+	}
+	return nil, nil
+}
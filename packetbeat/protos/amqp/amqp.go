@@ -18,6 +18,7 @@
 package amqp
 
 import (
+	"bytes"
 	"strconv"
 	"strings"
 	"time"
@@ -193,6 +194,29 @@ func (amqp *amqpPlugin) ConnectionTimeout() time.Duration {
 	return amqp.transactionTimeout
 }
 
+// DetectTCP recognizes the protocol header a client starts a connection
+// with.
+func (amqp *amqpPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectAmqp(data)
+}
+
+// amqpProtocolHeader is the protocol header of AMQP 0-9, followed by the
+// minor version.
+var amqpProtocolHeader = []byte("AMQP\x00\x00\x09")
+
+func detectAmqp(data []byte) protos.Detection {
+	if len(data) < len(amqpProtocolHeader) {
+		if bytes.HasPrefix(amqpProtocolHeader, data) {
+			return protos.DetectionNeedMore
+		}
+		return protos.DetectionMismatch
+	}
+	if bytes.HasPrefix(data, amqpProtocolHeader) {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
 func (amqp *amqpPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 
@@ -211,7 +235,7 @@ func (amqp *amqpPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	if priv.data[dir] == nil {
 		priv.data[dir] = &amqpStream{
 			data:    pkt.Payload,
-			message: &amqpMessage{ts: pkt.Ts},
+			message: &amqpMessage{ts: pkt.Ts, capture: pkt.Capture},
 		}
 	} else {
 		// concatenate databytes
@@ -227,7 +251,7 @@ func (amqp *amqpPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 
 	for len(stream.data) > 0 {
 		if stream.message == nil {
-			stream.message = &amqpMessage{ts: pkt.Ts}
+			stream.message = &amqpMessage{ts: pkt.Ts, capture: pkt.Capture}
 		}
 
 		ok, complete := amqp.amqpMessageParser(stream)
@@ -272,6 +296,7 @@ func (amqp *amqpPlugin) handleAmqpRequest(msg *amqpMessage) {
 	}
 
 	trans.ts = msg.ts
+	trans.capture = msg.capture
 	trans.src, trans.dst = common.MakeEndpointPair(msg.tcpTuple.BaseTuple, msg.cmdlineTuple)
 	if msg.direction == tcp.TCPDirectionReverse {
 		trans.src, trans.dst = trans.dst, trans.src
@@ -329,6 +354,7 @@ func (amqp *amqpPlugin) handleAmqpResponse(msg *amqpMessage) {
 
 	trans.endTime = msg.ts
 	trans.notes = msg.notes
+	trans.capture.Add(&msg.capture)
 
 	amqp.publishTransaction(trans)
 
@@ -366,6 +392,7 @@ func (amqp *amqpPlugin) handlePublishing(client *amqpMessage) {
 	}
 
 	trans.ts = client.ts
+	trans.capture = client.capture
 	trans.src, trans.dst = common.MakeEndpointPair(client.tcpTuple.BaseTuple, client.cmdlineTuple)
 
 	trans.method = client.method
@@ -401,6 +428,7 @@ func (amqp *amqpPlugin) handleDelivering(server *amqpMessage) {
 	}
 
 	trans.ts = server.ts
+	trans.capture = server.capture
 	trans.src, trans.dst = common.MakeEndpointPair(server.tcpTuple.BaseTuple, server.cmdlineTuple)
 
 	//for publishing and delivering, bytes in and out represent the length of the
@@ -443,6 +471,7 @@ func (amqp *amqpPlugin) publishTransaction(t *amqpTransaction) {
 	pbf.Network.Protocol = pbf.Event.Dataset
 	pbf.Network.Transport = "tcp"
 	pbf.Error.Message = t.notes
+	pbf.AddCapture(&t.capture)
 
 	fields := evt.Fields
 	fields["type"] = pbf.Event.Dataset
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 )
 
 type amqpMethod func(*amqpMessage, []byte) (bool, bool)
@@ -195,6 +196,7 @@ type amqpFrame struct {
 
 type amqpMessage struct {
 	ts             time.Time
+	capture        pb.Capture
 	tcpTuple       common.TCPTuple
 	cmdlineTuple   *common.ProcessTuple
 	method         string
@@ -221,10 +223,11 @@ type amqpStream struct {
 
 // contains the result of parsing
 type amqpTransaction struct {
-	tuple common.TCPTuple
-	src   common.Endpoint
-	dst   common.Endpoint
-	ts    time.Time
+	tuple   common.TCPTuple
+	src     common.Endpoint
+	dst     common.Endpoint
+	ts      time.Time
+	capture pb.Capture
 
 	method   string
 	request  string
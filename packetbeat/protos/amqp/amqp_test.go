@@ -1176,3 +1176,16 @@ func TestAmqp_MultipleBodyFrames(t *testing.T) {
 	assert.Equal(t, "basic.publish", trans["method"])
 	assert.Equal(t, "***hello I like to publish big messages***", trans["request"])
 }
+
+func TestDetectAmqp(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"AMQP\x00\x00\x09\x01", protos.DetectionMatch},
+		{"AMQ", protos.DetectionNeedMore},
+		{"AMQP\x01\x01\x00\x0a", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectAmqp([]byte(test.data)), "%q", test.data)
+	}
+}
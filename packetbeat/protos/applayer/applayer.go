@@ -108,6 +108,9 @@ type Transaction struct {
 
 	// BytesOut is the number of bytes send by source endpoint to destination endpoint
 	BytesOut uint64
+
+	// Capture holds the metadata of the packets of the transaction
+	Capture pb.Capture
 }
 
 // TransactionTimestamp defines a transaction its initial timestamps as unix
@@ -128,6 +131,9 @@ type Message struct {
 	IsRequest    bool
 	Size         uint64
 	Notes        []string
+
+	// Capture holds the metadata of the packet the message has been read from
+	Capture pb.Capture
 }
 
 // Error code if stream exceeds max allowed size on Append.
@@ -217,6 +223,7 @@ func (t *Transaction) InitWithMsg(
 		msg.CmdlineTuple,
 		nil,
 	)
+	t.Capture = msg.Capture
 }
 
 // Event fills common event fields.
@@ -234,6 +241,7 @@ func (t *Transaction) Event(event *beat.Event) error {
 	pbf.Network.Transport = t.Transport.String()
 	pbf.Network.Protocol = pbf.Event.Dataset
 	pbf.Error.Message = t.Notes
+	pbf.AddCapture(&t.Capture)
 
 	fields := event.Fields
 	fields[pb.FieldsKey] = pbf
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package applayer
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+// A FramedStream splits the data of a stream into messages whose size is
+// read from their header. Messages larger than the maximum message size are
+// returned truncated to their first bytes, and the rest of them is skipped.
+// Unlike a Stream, which fails once its limit is reached, a FramedStream
+// keeps parsing the messages following a truncated one.
+// The zero value of a FramedStream is a valid empty stream.
+type FramedStream struct {
+	data []byte
+
+	// bytes of a truncated message still to be skipped
+	skip int
+
+	// timestamp and capture metadata of the packet the buffered data starts
+	// in, and of the last packet appended
+	ts, lastTs           time.Time
+	capture, lastCapture pb.Capture
+}
+
+// A Frame is a message read from a FramedStream.
+type Frame struct {
+	// Data holds the message, including its header. If Truncated is set, it
+	// only holds the bytes buffered once the maximum message size was
+	// reached.
+	Data      []byte
+	HeaderLen int
+	Size      int
+	Truncated bool
+
+	// Ts and Capture are the timestamp and the capture metadata of the
+	// packet the message starts in.
+	Ts      time.Time
+	Capture pb.Capture
+}
+
+// FrameHeaderFunc reads the header of the message at the start of data. It
+// returns the length of the header and of the message body. The header
+// length is 0 if data doesn't hold the complete header yet.
+type FrameHeaderFunc func(data []byte) (headerLen, bodyLen int, err error)
+
+// Append adds the payload of a packet to the stream, after skipping the rest
+// of a truncated message. It returns false if no data has been added.
+func (s *FramedStream) Append(data []byte, ts time.Time, capture *pb.Capture) bool {
+	if s.skip > 0 {
+		n := s.skip
+		if n > len(data) {
+			n = len(data)
+		}
+		s.skip -= n
+		data = data[n:]
+	}
+	if len(data) == 0 {
+		return false
+	}
+	if len(s.data) == 0 {
+		s.ts, s.capture = ts, *capture
+	}
+	s.lastTs, s.lastCapture = ts, *capture
+	s.data = append(s.data, data...)
+	return true
+}
+
+// Next returns the next message of the stream, or false if more data is
+// needed. If the header of the message is invalid, the stream is reset and
+// the error of the header function is returned. Next is to be called until
+// it returns false after every Append, so that the messages get the
+// timestamp of the packet they start in.
+func (s *FramedStream) Next(header FrameHeaderFunc, maxMessageSize int) (Frame, bool, error) {
+	if len(s.data) == 0 {
+		s.data = nil
+		return Frame{}, false, nil
+	}
+	headerLen, bodyLen, err := header(s.data)
+	if err != nil {
+		s.Reset()
+		return Frame{}, false, err
+	}
+	if headerLen == 0 {
+		return Frame{}, false, nil
+	}
+
+	total := headerLen + bodyLen
+	if len(s.data) < total && len(s.data) < maxMessageSize {
+		// wait for more data
+		return Frame{}, false, nil
+	}
+
+	f := Frame{
+		HeaderLen: headerLen,
+		Size:      total,
+		Truncated: len(s.data) < total,
+		Ts:        s.ts,
+		Capture:   s.capture,
+	}
+	if f.Truncated {
+		f.Data = s.data
+		s.skip = total - len(s.data)
+		s.data = nil
+	} else {
+		f.Data = s.data[:total]
+		s.data = s.data[total:]
+	}
+	s.ts, s.capture = s.lastTs, s.lastCapture
+	return f, true, nil
+}
+
+// Bytes returns the data buffered in the stream.
+func (s *FramedStream) Bytes() []byte {
+	return s.data
+}
+
+// Reset drops the data buffered in the stream.
+func (s *FramedStream) Reset() {
+	*s = FramedStream{}
+}
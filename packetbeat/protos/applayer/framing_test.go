@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package applayer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+var errTestHeader = errors.New("invalid message type")
+
+// testHeader reads a header made of a message type and the length of the
+// message body.
+func testHeader(data []byte) (int, int, error) {
+	if len(data) < 2 {
+		return 0, 0, nil
+	}
+	if data[0] == 0xff {
+		return 0, 0, errTestHeader
+	}
+	return 2, int(data[1]), nil
+}
+
+func message(typ byte, body string) []byte {
+	return append([]byte{typ, byte(len(body))}, body...)
+}
+
+func join(data ...[]byte) []byte {
+	var b []byte
+	for _, d := range data {
+		b = append(b, d...)
+	}
+	return b
+}
+
+var framingStart = time.Unix(1500000000, 0)
+
+// packetTs and packetCapture identify the i-th packet appended to a stream.
+func packetTs(i int) time.Time {
+	return framingStart.Add(time.Duration(i) * time.Millisecond)
+}
+
+func packetCapture(i int) pb.Capture {
+	return pb.Capture{Interface: fmt.Sprintf("packet%d", i)}
+}
+
+// frame returns the frame expected for a message starting in the i-th
+// packet.
+func frame(i int, data []byte, size int) Frame {
+	return Frame{
+		Data:      data,
+		HeaderLen: 2,
+		Size:      size,
+		Truncated: len(data) < size,
+		Ts:        packetTs(i),
+		Capture:   packetCapture(i),
+	}
+}
+
+func TestFramedStream(t *testing.T) {
+	long := message(3, "abcdefghij")
+
+	tests := []struct {
+		name    string
+		max     int
+		packets [][]byte
+		frames  []Frame
+	}{
+		{
+			name:    "single message",
+			packets: [][]byte{message(1, "hello")},
+			frames:  []Frame{frame(0, message(1, "hello"), 7)},
+		},
+		{
+			name:    "messages in one packet",
+			packets: [][]byte{join(message(1, "ab"), message(2, ""), message(1, "c"))},
+			frames: []Frame{
+				frame(0, message(1, "ab"), 4),
+				frame(0, message(2, ""), 2),
+				frame(0, message(1, "c"), 3),
+			},
+		},
+		{
+			name:    "partial header and body",
+			packets: [][]byte{{1}, {5, 'h', 'e'}, []byte("llo")},
+			frames:  []Frame{frame(0, message(1, "hello"), 7)},
+		},
+		{
+			name:    "message starting in a later packet",
+			packets: [][]byte{message(1, "ab"), message(2, "cd")},
+			frames: []Frame{
+				frame(0, message(1, "ab"), 4),
+				frame(1, message(2, "cd"), 4),
+			},
+		},
+		{
+			name:    "message continued in a later packet",
+			packets: [][]byte{join(message(1, "ab"), message(2, "cd")[:3]), message(2, "cd")[3:]},
+			frames: []Frame{
+				frame(0, message(1, "ab"), 4),
+				frame(0, message(2, "cd"), 4),
+			},
+		},
+		{
+			name:    "truncated message",
+			max:     6,
+			packets: [][]byte{long[:7], long[7:9], join(long[9:], message(1, "x"))},
+			frames: []Frame{
+				frame(0, long[:7], len(long)),
+				frame(2, message(1, "x"), 3),
+			},
+		},
+		{
+			name:    "complete message larger than the maximum",
+			max:     6,
+			packets: [][]byte{long},
+			frames:  []Frame{frame(0, long, len(long))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			max := test.max
+			if max == 0 {
+				max = 1000
+			}
+
+			var s FramedStream
+			var frames []Frame
+			for i, data := range test.packets {
+				capture := packetCapture(i)
+				s.Append(data, packetTs(i), &capture)
+				for {
+					f, ok, err := s.Next(testHeader, max)
+					require.NoError(t, err)
+					if !ok {
+						break
+					}
+					frames = append(frames, f)
+				}
+			}
+			assert.Equal(t, test.frames, frames)
+			assert.Empty(t, s.Bytes())
+		})
+	}
+}
+
+func TestFramedStreamSkip(t *testing.T) {
+	var s FramedStream
+	long := message(3, "abcdefghij")
+	capture := packetCapture(0)
+
+	assert.False(t, s.Append(nil, packetTs(0), &capture))
+
+	assert.True(t, s.Append(long[:4], packetTs(0), &capture))
+	f, ok, err := s.Next(testHeader, 4)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, f.Truncated)
+
+	// the rest of the truncated message is skipped, over several packets
+	assert.False(t, s.Append(long[4:8], packetTs(1), &capture))
+	assert.False(t, s.Append(long[8:], packetTs(2), &capture))
+	assert.Empty(t, s.Bytes())
+
+	assert.True(t, s.Append(message(1, "x"), packetTs(3), &capture))
+	f, ok, err = s.Next(testHeader, 4)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, message(1, "x"), f.Data)
+	assert.Equal(t, packetTs(3), f.Ts)
+}
+
+func TestFramedStreamHeaderError(t *testing.T) {
+	var s FramedStream
+	capture := packetCapture(0)
+
+	s.Append(join(message(1, "ab"), []byte{0xff, 0}, message(1, "cd")), packetTs(0), &capture)
+	_, ok, err := s.Next(testHeader, 1000)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the stream is reset, the messages following the error are dropped
+	_, ok, err = s.Next(testHeader, 1000)
+	assert.Equal(t, errTestHeader, err)
+	assert.False(t, ok)
+	assert.Empty(t, s.Bytes())
+
+	_, ok, err = s.Next(testHeader, 1000)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFramedStreamReset(t *testing.T) {
+	var s FramedStream
+	capture := packetCapture(0)
+
+	s.Append(message(1, "hello")[:4], packetTs(0), &capture)
+	assert.Len(t, s.Bytes(), 4)
+
+	s.Reset()
+	assert.Empty(t, s.Bytes())
+
+	capture = packetCapture(1)
+	s.Append(message(2, "ab"), packetTs(1), &capture)
+	f, ok, err := s.Next(testHeader, 1000)
+	require.NoError(t, err)
+	if assert.True(t, ok) {
+		assert.Equal(t, frame(1, message(2, "ab"), 4), f)
+	}
+}
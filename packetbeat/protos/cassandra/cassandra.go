@@ -18,6 +18,7 @@
 package cassandra
 
 import (
+	"encoding/binary"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -42,6 +43,7 @@ type cassandra struct {
 // Application Layer tcp stream data to be stored on tcp connection context.
 type connection struct {
 	streams [2]*stream
+	framing framing
 	trans   transactions
 }
 
@@ -98,12 +100,9 @@ func (cassandra *cassandra) setFromConfig(config *cassandraConfig) error {
 	parser := &cassandra.parserConfig
 	parser.maxBytes = tcp.TCPMaxDataInStream
 
-	// set parser's compressor, only `snappy` supported right now
-	if config.Compressor == gocql.Snappy {
-		parser.compressor = gocql.SnappyCompressor{}
-	} else {
-		parser.compressor = nil
-	}
+	// set parser's compressor, used unless a connection's STARTUP request
+	// negotiating compression was seen
+	parser.compressor = gocql.NewCompressor(config.Compressor)
 
 	// parsed ignored ops
 	if len(config.OPsIgnored) > 0 {
@@ -135,6 +134,32 @@ func (cassandra *cassandra) ConnectionTimeout() time.Duration {
 	return cassandra.transConfig.transactionTimeout
 }
 
+// DetectTCP recognizes the STARTUP and OPTIONS requests a client starts a
+// connection with.
+func (cassandra *cassandra) DetectTCP(data []byte) protos.Detection {
+	return detectCassandra(data)
+}
+
+// detectCassandra checks the frame header of requests of protocol version 3
+// to 5. The header holds the version, flags, stream ID, opcode and length.
+func detectCassandra(data []byte) protos.Detection {
+	const (
+		hdrLen    = 9
+		opStartup = 0x01
+		opOptions = 0x05
+	)
+	if len(data) < hdrLen {
+		return protos.DetectionNeedMore
+	}
+
+	version, opcode := data[0], data[4]
+	length := binary.BigEndian.Uint32(data[5:])
+	if version < 3 || version > 5 || (opcode != opStartup && opcode != opOptions) || length > 256<<20 {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
 // GetPorts returns the ports numbers packets shall be processed for.
 func (cassandra *cassandra) GetPorts() []int {
 	return cassandra.ports.Ports
@@ -153,13 +178,13 @@ func (cassandra *cassandra) Parse(
 	st := conn.streams[dir]
 	if st == nil {
 		st = &stream{}
-		st.parser.init(&cassandra.parserConfig, func(msg *message) error {
+		st.parser.init(&cassandra.parserConfig, &conn.framing, func(msg *message) error {
 			return conn.trans.onMessage(tcptuple.IPPort(), dir, msg)
 		})
 		conn.streams[dir] = st
 	}
 
-	if err := st.parser.feed(pkt.Ts, pkt.Payload); err != nil {
+	if err := st.parser.feed(pkt); err != nil {
 		debugf("%v, dropping TCP stream for error in direction %v.", err, dir)
 		cassandra.onDropConnection(conn)
 		return nil
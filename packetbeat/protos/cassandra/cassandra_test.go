@@ -0,0 +1,276 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package cassandra
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+
+	gocql "github.com/elastic/beats/v7/packetbeat/protos/cassandra/internal/gocql"
+)
+
+const serverPort = 9042
+
+const (
+	opStartup = 0x01
+	opReady   = 0x02
+	opQuery   = 0x07
+	opResult  = 0x08
+	opEvent   = 0x0c
+
+	flagCompress = 0x01
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	e.events = append(e.events, event)
+}
+
+func cassandraModForTests(store *eventStore) *cassandra {
+	var c cassandra
+	config := defaultConfig
+	config.Ports = []int{serverPort}
+	c.init(store.publish, procs.ProcessesWatcher{}, &config)
+	return &c
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: serverPort,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// frame encodes a frame of protocol version 3 or later.
+func frame(version, flags byte, op byte, body []byte) []byte {
+	b := []byte{version, flags, 0, 1, op, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(b[5:], uint32(len(body)))
+	return append(b, body...)
+}
+
+func startupBody(compression string) []byte {
+	options := [][2]string{{"CQL_VERSION", "3.0.0"}}
+	if compression != "" {
+		options = append(options, [2]string{"COMPRESSION", compression})
+	}
+	b := []byte{0, byte(len(options))}
+	for _, kv := range options {
+		for _, s := range kv {
+			b = append(b, 0, byte(len(s)))
+			b = append(b, s...)
+		}
+	}
+	return b
+}
+
+func queryBody(query string) []byte {
+	b := make([]byte, 4, 4+len(query)+3)
+	binary.BigEndian.PutUint32(b, uint32(len(query)))
+	b = append(b, query...)
+	return append(b, 0, 1, 0) // consistency ONE, no flags
+}
+
+// voidResult is the body of a RESULT response of kind Void.
+var voidResult = []byte{0, 0, 0, 1}
+
+// statusChangeBody is the body of an EVENT announcing a node being up.
+var statusChangeBody = []byte{
+	0, 13, 'S', 'T', 'A', 'T', 'U', 'S', '_', 'C', 'H', 'A', 'N', 'G', 'E',
+	0, 2, 'U', 'P',
+	4, 192, 168, 0, 3, 0, 0, 0x23, 0x52,
+}
+
+// lz4Body compresses body as a v4 frame body made of LZ4 literals only.
+func lz4Body(body []byte) []byte {
+	b := make([]byte, 4, len(body)+16)
+	binary.BigEndian.PutUint32(b, uint32(len(body)))
+	n := len(body)
+	if n < 15 {
+		b = append(b, byte(n<<4))
+	} else {
+		b = append(b, 0xf0)
+		for n -= 15; n >= 255; n -= 255 {
+			b = append(b, 0xff)
+		}
+		b = append(b, byte(n))
+	}
+	return append(b, body...)
+}
+
+func segment(t *testing.T, payload []byte, selfContained bool, compressor gocql.Compressor) []byte {
+	b, err := gocql.EncodeSegment(payload, selfContained, compressor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func parse(c *cassandra, tuple *common.TCPTuple, dir uint8, private protos.ProtocolData, payload []byte) protos.ProtocolData {
+	pkt := protos.Packet{Ts: time.Now(), Payload: payload}
+	return c.Parse(&pkt, tuple, dir, private)
+}
+
+func requestQuery(t *testing.T, event beat.Event) interface{} {
+	v, err := event.Fields.GetValue("cassandra.request.query")
+	assert.NoError(t, err)
+	return v
+}
+
+func TestDetectCassandra(t *testing.T) {
+	for version := byte(3); version <= 5; version++ {
+		assert.Equal(t, protos.DetectionMatch, detectCassandra(frame(version, 0, opStartup, startupBody(""))))
+	}
+	assert.Equal(t, protos.DetectionMismatch, detectCassandra(frame(6, 0, opStartup, startupBody(""))))
+	assert.Equal(t, protos.DetectionNeedMore, detectCassandra([]byte{4, 0, 0}))
+}
+
+func TestConfigCompressor(t *testing.T) {
+	for _, name := range []string{"", "snappy", "lz4"} {
+		config := defaultConfig
+		config.Compressor = name
+		assert.NoError(t, config.Validate(), name)
+	}
+
+	config := defaultConfig
+	config.Compressor = "deflate"
+	assert.Error(t, config.Validate())
+}
+
+func TestNegotiatedCompression(t *testing.T) {
+	store := &eventStore{}
+	c := cassandraModForTests(store)
+	tuple := testTCPTuple()
+	query := "SELECT * FROM system.local"
+
+	var private protos.ProtocolData
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(4, 0, opStartup, startupBody("lz4")))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x84, 0, opReady, nil))
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(4, flagCompress, opQuery, lz4Body(queryBody(query))))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x84, flagCompress, opResult, lz4Body(voidResult)))
+	assert.NotNil(t, private)
+
+	if assert.Len(t, store.events, 2) {
+		assert.Equal(t, query, requestQuery(t, store.events[1]))
+		result, _ := store.events[1].Fields.GetValue("cassandra.response.result.type")
+		assert.Equal(t, "void", result)
+	}
+}
+
+func TestProtocolV5Segments(t *testing.T) {
+	store := &eventStore{}
+	c := cassandraModForTests(store)
+	tuple := testTCPTuple()
+	lz4 := gocql.LZ4Compressor{}
+	query := "SELECT * FROM ks.tbl WHERE k IN (" + strings.Repeat("'key', ", 100) + "'key')"
+
+	var private protos.ProtocolData
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(5, 0, opStartup, startupBody("lz4")))
+
+	// The first segment directly follows READY in the same packet.
+	event := frame(0x85, 0, opEvent, statusChangeBody)
+	ready := append(frame(0x85, 0, opReady, nil), segment(t, event, true, lz4)...)
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, ready)
+
+	// The query frame is split over two segments.
+	request := frame(5, 0, opQuery, queryBody(query))
+	split := len(request) / 2
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, segment(t, request[:split], false, lz4))
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, segment(t, request[split:], false, lz4))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, segment(t, frame(0x85, 0, opResult, voidResult), true, lz4))
+	assert.NotNil(t, private)
+
+	if assert.Len(t, store.events, 3) {
+		change, _ := store.events[1].Fields.GetValue("cassandra.response.event.change")
+		assert.Equal(t, "UP", change)
+		assert.Equal(t, query, requestQuery(t, store.events[2]))
+	}
+}
+
+func TestProtocolV5SegmentsUncompressed(t *testing.T) {
+	store := &eventStore{}
+	c := cassandraModForTests(store)
+	tuple := testTCPTuple()
+	query := "SELECT * FROM system.peers"
+
+	var private protos.ProtocolData
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(5, 0, opStartup, startupBody("")))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x85, 0, opReady, nil))
+
+	// Segments might be split over multiple packets.
+	data := segment(t, frame(5, 0, opQuery, queryBody(query)), true, nil)
+	for i := range data {
+		private = parse(c, tuple, tcp.TCPDirectionOriginal, private, data[i:i+1])
+	}
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, segment(t, frame(0x85, 0, opResult, voidResult), true, nil))
+	assert.NotNil(t, private)
+
+	if assert.Len(t, store.events, 2) {
+		assert.Equal(t, query, requestQuery(t, store.events[1]))
+	}
+}
+
+func TestProtocolV5SegmentChecksum(t *testing.T) {
+	store := &eventStore{}
+	c := cassandraModForTests(store)
+	tuple := testTCPTuple()
+
+	var private protos.ProtocolData
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(5, 0, opStartup, startupBody("")))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x85, 0, opReady, nil))
+
+	data := segment(t, frame(5, 0, opQuery, queryBody("SELECT now() FROM system.local")), true, nil)
+	data[len(data)-1] ^= 0xff
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, data)
+	assert.Nil(t, private)
+	assert.Len(t, store.events, 1)
+}
+
+func TestProtocolV4NotSegmented(t *testing.T) {
+	store := &eventStore{}
+	c := cassandraModForTests(store)
+	tuple := testTCPTuple()
+
+	var private protos.ProtocolData
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(4, 0, opStartup, startupBody("")))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x84, 0, opReady, nil))
+	private = parse(c, tuple, tcp.TCPDirectionOriginal, private, frame(4, 0, opQuery, queryBody("SELECT 1")))
+	private = parse(c, tuple, tcp.TCPDirectionReverse, private, frame(0x84, 0, opResult, voidResult))
+	assert.NotNil(t, private)
+	assert.Len(t, store.events, 2)
+}
@@ -47,8 +47,8 @@ var (
 )
 
 func (c *cassandraConfig) Validate() error {
-	if !(c.Compressor == "" || c.Compressor == "snappy") {
-		return fmt.Errorf("invalid compressor config: %s, only snappy supported", c.Compressor)
+	if c.Compressor != "" && gocql.NewCompressor(c.Compressor) == nil {
+		return fmt.Errorf("invalid compressor config: %s, only snappy and lz4 supported", c.Compressor)
 	}
 	return nil
 }
@@ -18,7 +18,12 @@
 package cassandra
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
 	"github.com/golang/snappy"
+	"github.com/pierrec/lz4"
 )
 
 type Compressor interface {
@@ -48,8 +53,69 @@ func (s SnappyCompressor) Decode(data []byte) ([]byte, error) {
 
 const LZ4 string = "lz4"
 
-type LZ4Compressor struct {
-	//TODO
+var errIncompressible = errors.New("lz4: data is incompressible")
+
+// LZ4Compressor implements the Compressor interface for the LZ4 compression
+// algorithm. Compressed frame bodies are prefixed with the uncompressed
+// length as a 4 byte big endian integer, followed by a raw LZ4 block.
+type LZ4Compressor struct{}
+
+func (s LZ4Compressor) Name() string {
+	return LZ4
+}
+
+func (s LZ4Compressor) Encode(data []byte) ([]byte, error) {
+	block, err := s.EncodeBlock(data)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4+len(block))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[4:], block)
+	return buf, nil
+}
+
+func (s LZ4Compressor) Decode(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("lz4: compressed body too short: %d bytes", len(data))
+	}
+	size := binary.BigEndian.Uint32(data)
+	return s.DecodeBlock(data[4:], int(size))
+}
+
+// EncodeBlock compresses data into a raw LZ4 block, as found in the payload
+// of compressed protocol v5 segments.
+func (s LZ4Compressor) EncodeBlock(data []byte) ([]byte, error) {
+	hashTable := make([]int, 1<<16)
+	buf := make([]byte, lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, buf, hashTable)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errIncompressible
+	}
+	return buf[:n], nil
+}
+
+// DecodeBlock decompresses a raw LZ4 block of known uncompressed size, as
+// found in the payload of compressed protocol v5 segments.
+func (s LZ4Compressor) DecodeBlock(data []byte, size int) ([]byte, error) {
+	if size < 0 || size > maxFrameSize {
+		return nil, fmt.Errorf("lz4: invalid uncompressed length: %d", size)
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+	buf := make([]byte, size)
+	n, err := lz4.UncompressBlock(data, buf)
+	if err != nil {
+		return nil, err
+	}
+	if n != size {
+		return nil, fmt.Errorf("lz4: uncompressed %d bytes, expected %d", n, size)
+	}
+	return buf, nil
 }
 
 const Deflate string = "deflate"
@@ -57,3 +123,16 @@ const Deflate string = "deflate"
 type DeflateCompressor struct {
 	//TODO
 }
+
+// NewCompressor returns the compressor negotiated under the given name in
+// a STARTUP message, or nil if the algorithm is not supported.
+func NewCompressor(name string) Compressor {
+	switch name {
+	case Snappy:
+		return SnappyCompressor{}
+	case LZ4:
+		return LZ4Compressor{}
+	default:
+		return nil
+	}
+}
@@ -56,6 +56,23 @@ func (f frameHeader) String() string {
 	return fmt.Sprintf("version:%s, flags: %s, steam: %v, OP: %v, length: %v", f.Version.String(), getHeadFlagString(f.Flags), f.Stream, f.Op.String(), f.BodyLength)
 }
 
+// ProtoVersion returns the protocol version of the frame, without the
+// direction bit.
+func (f frameHeader) ProtoVersion() int {
+	return int(f.Version.version())
+}
+
+// IsStartup returns true if the frame is a STARTUP request.
+func (f frameHeader) IsStartup() bool {
+	return f.Op == opStartup && f.Version.IsRequest()
+}
+
+// EndsStartup returns true if the frame is a READY or AUTHENTICATE response,
+// which conclude the STARTUP exchange.
+func (f frameHeader) EndsStartup() bool {
+	return (f.Op == opReady || f.Op == opAuthenticate) && f.Version.IsResponse()
+}
+
 var framerPool = sync.Pool{
 	New: func() interface{} {
 		return &Framer{compres: nil, isCompressed: false, Header: nil, r: nil, decoder: nil}
@@ -73,6 +90,9 @@ type Framer struct {
 	// if this frame was read then the header will be here
 	Header *frameHeader
 
+	// options sent in a STARTUP request, like the negotiated compression
+	Startup map[string]string
+
 	r *streambuf.Buffer
 
 	decoder Decoder
@@ -94,7 +114,7 @@ func (f *Framer) ReadHeader() (head *frameHeader, err error) {
 	}
 	version := v & protoVersionMask
 
-	if version < protoVersion1 || version > protoVersion4 {
+	if version < protoVersion1 || version > protoVersion5 {
 		return nil, fmt.Errorf("unsupported version: %x ", v)
 	}
 
@@ -235,7 +255,9 @@ func (f *Framer) ReadFrame() (data map[string]interface{}, err error) {
 	switch f.Header.Op {
 
 	//below ops are requests
-	case opStartup, opAuthResponse, opOptions, opPrepare, opExecute, opBatch, opRegister:
+	case opStartup:
+		f.Startup = (f.decoder).ReadStringMap()
+	case opAuthResponse, opOptions, opPrepare, opExecute, opBatch, opRegister:
 	//ignored
 	case opQuery:
 		data = f.parseQueryFrame()
@@ -399,6 +399,7 @@ const (
 	protoVersion2      = 0x02
 	protoVersion3      = 0x03
 	protoVersion4      = 0x04
+	protoVersion5      = 0x05
 
 	maxFrameSize = 256 * 1024 * 1024
 )
@@ -408,10 +409,14 @@ type protoVersion byte
 func (p protoVersion) IsRequest() bool {
 	v := p.version()
 
-	if v < protoVersion1 || v > protoVersion4 {
+	if v < protoVersion1 || v > protoVersion5 {
 		logp.Err("unsupported request version: %x", v)
 	}
 
+	if v == protoVersion5 {
+		return p == 0x05
+	}
+
 	if v == protoVersion4 {
 		return p == 0x04
 	}
@@ -426,10 +431,14 @@ func (p protoVersion) IsRequest() bool {
 func (p protoVersion) IsResponse() bool {
 	v := p.version()
 
-	if v < protoVersion1 || v > protoVersion4 {
+	if v < protoVersion1 || v > protoVersion5 {
 		logp.Err("unsupported response version: %x", v)
 	}
 
+	if v == protoVersion5 {
+		return p == 0x85
+	}
+
 	if v == protoVersion4 {
 		return p == 0x84
 	}
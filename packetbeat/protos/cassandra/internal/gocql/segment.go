@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cassandra
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Starting with protocol v5, frames following the STARTUP exchange are
+// wrapped in segments. A segment header holds the payload length and a flag
+// telling whether the payload contains complete frames only, and is
+// protected by a CRC24 checksum. The payload is followed by its CRC32
+// checksum. If compression was negotiated, the header additionally holds the
+// uncompressed payload length, which is 0 for payloads sent uncompressed.
+const (
+	segmentHeaderLen           = 3
+	compressedSegmentHeaderLen = 5
+	segmentHeaderCRCLen        = 3
+	segmentPayloadCRCLen       = 4
+
+	segmentLengthBits = 17
+	segmentLengthMask = 1<<segmentLengthBits - 1
+
+	crc24Init = 0x875060
+	crc24Poly = 0x1974F0B
+)
+
+var (
+	ErrSegmentHeaderCRC  = errors.New("segment header checksum mismatch")
+	ErrSegmentPayloadCRC = errors.New("segment payload checksum mismatch")
+
+	// The payload CRC32 is computed as if the payload was prefixed by
+	// these bytes.
+	crc32Init = crc32.ChecksumIEEE([]byte{0xfa, 0x2d, 0x55, 0xca})
+)
+
+// blockCompressor is implemented by compressors supported in segments, which
+// carry the uncompressed length in the segment header.
+type blockCompressor interface {
+	EncodeBlock(data []byte) ([]byte, error)
+	DecodeBlock(data []byte, size int) ([]byte, error)
+}
+
+// Segment is a protocol v5 segment.
+type Segment struct {
+	// Payload holds the uncompressed frame bytes. It may reference the
+	// data the segment was decoded from.
+	Payload []byte
+
+	// SelfContained is set if the payload consists of complete frames.
+	// Otherwise it is one part of a frame spread over multiple segments.
+	SelfContained bool
+}
+
+// DecodeSegment decodes the segment at the start of data, validating both
+// checksums. The compressor must be the one negotiated in the STARTUP
+// request, or nil. It returns the number of bytes consumed, which is 0 if
+// data does not hold a complete segment yet.
+func DecodeSegment(data []byte, compressor Compressor) (seg Segment, n int, err error) {
+	headerLen := segmentHeaderLen
+	if compressor != nil {
+		headerLen = compressedSegmentHeaderLen
+	}
+	if len(data) < headerLen+segmentHeaderCRCLen {
+		return seg, 0, nil
+	}
+
+	header := readUint64LE(data[:headerLen])
+	crc := readUint64LE(data[headerLen : headerLen+segmentHeaderCRCLen])
+	if crc24(header, headerLen) != uint32(crc) {
+		return seg, 0, ErrSegmentHeaderCRC
+	}
+
+	payloadLen := int(header & segmentLengthMask)
+	var uncompressedLen int
+	flags := header >> segmentLengthBits
+	if compressor != nil {
+		uncompressedLen = int(flags & segmentLengthMask)
+		flags >>= segmentLengthBits
+	}
+	seg.SelfContained = flags&1 == 1
+
+	start := headerLen + segmentHeaderCRCLen
+	end := start + payloadLen
+	if len(data) < end+segmentPayloadCRCLen {
+		return seg, 0, nil
+	}
+
+	payload := data[start:end]
+	if crc32.Update(crc32Init, crc32.IEEETable, payload) != binary.LittleEndian.Uint32(data[end:]) {
+		return seg, 0, ErrSegmentPayloadCRC
+	}
+
+	if uncompressedLen > 0 {
+		block, ok := compressor.(blockCompressor)
+		if !ok {
+			return seg, 0, fmt.Errorf("compressor %s is not supported in segments", compressor.Name())
+		}
+		payload, err = block.DecodeBlock(payload, uncompressedLen)
+		if err != nil {
+			return seg, 0, err
+		}
+	}
+
+	seg.Payload = payload
+	return seg, end + segmentPayloadCRCLen, nil
+}
+
+// EncodeSegment wraps payload in a segment. If a compressor is given, the
+// payload is compressed unless compression does not reduce its size.
+func EncodeSegment(payload []byte, selfContained bool, compressor Compressor) ([]byte, error) {
+	if len(payload) > segmentLengthMask {
+		return nil, fmt.Errorf("segment payload too large: %d bytes", len(payload))
+	}
+
+	headerLen := segmentHeaderLen
+	header := uint64(len(payload))
+	flagsShift := uint(segmentLengthBits)
+	if compressor != nil {
+		block, ok := compressor.(blockCompressor)
+		if !ok {
+			return nil, fmt.Errorf("compressor %s is not supported in segments", compressor.Name())
+		}
+		headerLen = compressedSegmentHeaderLen
+		flagsShift += segmentLengthBits
+		if compressed, err := block.EncodeBlock(payload); err == nil && len(compressed) < len(payload) {
+			header = uint64(len(compressed)) | uint64(len(payload))<<segmentLengthBits
+			payload = compressed
+		}
+	}
+	if selfContained {
+		header |= 1 << flagsShift
+	}
+
+	start := headerLen + segmentHeaderCRCLen
+	buf := make([]byte, start+len(payload)+segmentPayloadCRCLen)
+	putUintLE(buf[:headerLen], header)
+	putUintLE(buf[headerLen:start], uint64(crc24(header, headerLen)))
+	copy(buf[start:], payload)
+	binary.LittleEndian.PutUint32(buf[start+len(payload):], crc32.Update(crc32Init, crc32.IEEETable, payload))
+	return buf, nil
+}
+
+// crc24 computes the checksum of the segment header, given as the little
+// endian integer value of its first n bytes.
+func crc24(value uint64, n int) uint32 {
+	crc := uint32(crc24Init)
+	for i := 0; i < n; i++ {
+		crc ^= uint32(value&0xff) << 16
+		value >>= 8
+		for j := 0; j < 8; j++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= crc24Poly
+			}
+		}
+	}
+	return crc & 0xffffff
+}
+
+func putUintLE(b []byte, v uint64) {
+	for i := range b {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+func readUint64LE(b []byte) uint64 {
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
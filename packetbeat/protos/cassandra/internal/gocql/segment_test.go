@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package cassandra
+
+import (
+	"bytes"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lz4Literals returns a valid LZ4 block holding data as literals only.
+func lz4Literals(data []byte) []byte {
+	var block []byte
+	n := len(data)
+	if n < 15 {
+		block = append(block, byte(n<<4))
+	} else {
+		block = append(block, 0xf0)
+		for n -= 15; n >= 255; n -= 255 {
+			block = append(block, 0xff)
+		}
+		block = append(block, byte(n))
+	}
+	return append(block, data...)
+}
+
+func TestSegmentRoundTrip(t *testing.T) {
+	payload := []byte("frame bytes of a self-contained segment")
+	for _, compressor := range []Compressor{nil, LZ4Compressor{}} {
+		for _, selfContained := range []bool{true, false} {
+			data, err := EncodeSegment(payload, selfContained, compressor)
+			if !assert.NoError(t, err) {
+				continue
+			}
+
+			seg, n, err := DecodeSegment(data, compressor)
+			assert.NoError(t, err)
+			assert.Equal(t, len(data), n)
+			assert.Equal(t, payload, seg.Payload)
+			assert.Equal(t, selfContained, seg.SelfContained)
+		}
+	}
+}
+
+func TestDecodeCompressedSegment(t *testing.T) {
+	payload := bytes.Repeat([]byte("cql"), 100)
+	block := lz4Literals(payload)
+
+	header := uint64(len(block)) | uint64(len(payload))<<17 | 1<<34
+	data := make([]byte, 8, 8+len(block)+4)
+	putUintLE(data[:5], header)
+	putUintLE(data[5:8], uint64(crc24(header, 5)))
+	data = append(data, block...)
+	data = append(data, 0, 0, 0, 0)
+	putUintLE(data[len(data)-4:], uint64(crc32Update(block)))
+
+	seg, n, err := DecodeSegment(data, LZ4Compressor{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, payload, seg.Payload)
+	assert.True(t, seg.SelfContained)
+}
+
+func TestDecodeSegmentIncomplete(t *testing.T) {
+	data, err := EncodeSegment([]byte("payload"), true, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(data); i++ {
+		_, n, err := DecodeSegment(data[:i], nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	}
+}
+
+func TestDecodeSegmentChecksums(t *testing.T) {
+	data, err := EncodeSegment([]byte("payload"), true, nil)
+	assert.NoError(t, err)
+
+	corrupt := append([]byte{}, data...)
+	corrupt[0] ^= 0x01
+	_, _, err = DecodeSegment(corrupt, nil)
+	assert.Equal(t, ErrSegmentHeaderCRC, err)
+
+	corrupt = append([]byte{}, data...)
+	corrupt[6] ^= 0x01
+	_, _, err = DecodeSegment(corrupt, nil)
+	assert.Equal(t, ErrSegmentPayloadCRC, err)
+}
+
+func TestLZ4Compressor(t *testing.T) {
+	payload := bytes.Repeat([]byte("abcd"), 64)
+	body := append([]byte{0, 0, 1, 0}, lz4Literals(payload)...)
+
+	c := LZ4Compressor{}
+	data, err := c.Decode(body)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, data)
+
+	_, err = c.Decode(body[:3])
+	assert.Error(t, err)
+
+	body[3] = 1
+	_, err = c.Decode(body)
+	assert.Error(t, err)
+}
+
+func crc32Update(b []byte) uint32 {
+	return crc32.Update(crc32Init, crc32.IEEETable, b)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common/streambuf"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/protos"
 	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
 
 	gocql "github.com/elastic/beats/v7/packetbeat/protos/cassandra/internal/gocql"
@@ -31,9 +32,15 @@ import (
 type parser struct {
 	buf       streambuf.Buffer
 	config    *parserConfig
+	framing   *framing
 	framer    *gocql.Framer
 	message   *message
 	onMessage func(m *message) error
+
+	// segments buffers protocol v5 segments, whose payloads are decoded
+	// into buf, once the stream has switched to segment framing.
+	segments  streambuf.Buffer
+	segmented bool
 }
 
 type parserConfig struct {
@@ -42,6 +49,23 @@ type parserConfig struct {
 	ignoredOps map[gocql.FrameOp]bool
 }
 
+// framing holds the framing negotiated by the STARTUP exchange of a
+// connection. It is shared by the parsers of both directions.
+type framing struct {
+	// compressor selected in the STARTUP request. It replaces the configured
+	// compressor once a STARTUP request has been seen.
+	compressor gocql.Compressor
+	negotiated bool
+
+	// protocol version of the STARTUP request, set until the server
+	// concludes the exchange.
+	startupVersion int
+
+	// set once the STARTUP exchange completed on protocol v5 or later.
+	// Subsequent frames are wrapped in segments.
+	segmented bool
+}
+
 // check whether this ops is enabled or not
 func (p *parser) CheckFrameOpsIgnored() bool {
 	if p.config.ignoredOps != nil && len(p.config.ignoredOps) > 0 {
@@ -82,11 +106,13 @@ var (
 
 func (p *parser) init(
 	cfg *parserConfig,
+	framing *framing,
 	onMessage func(*message) error,
 ) {
 	*p = parser{
 		buf:       streambuf.Buffer{},
 		config:    cfg,
+		framing:   framing,
 		onMessage: onMessage,
 	}
 
@@ -106,15 +132,101 @@ func (p *parser) append(data []byte) error {
 	return nil
 }
 
-func (p *parser) feed(ts time.Time, data []byte) error {
-	if err := p.append(data); err != nil {
+// appendSegments buffers data holding protocol v5 segments and appends the
+// payloads of all complete segments to the frame buffer.
+func (p *parser) appendSegments(data []byte) error {
+	_, err := p.segments.Write(data)
+	if err != nil {
+		return err
+	}
+
+	if p.config.maxBytes > 0 && p.segments.Total() > p.config.maxBytes {
+		return errStreamTooLarge
+	}
+
+	for {
+		segment, n, err := gocql.DecodeSegment(p.segments.Bytes(), p.framing.compressor)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break // wait for more data
+		}
+		if err := p.append(segment.Payload); err != nil {
+			return err
+		}
+		p.segments.Advance(n)
+	}
+	p.segments.Reset()
+	return nil
+}
+
+// compressor returns the compressor used for frame bodies.
+func (p *parser) compressor() gocql.Compressor {
+	if p.framing.negotiated {
+		return p.framing.compressor
+	}
+	return p.config.compressor
+}
+
+// negotiate updates the connection framing from a STARTUP request or from
+// the READY or AUTHENTICATE response concluding the STARTUP exchange.
+func (p *parser) negotiate() {
+	header := p.framer.Header
+	switch {
+	case header.IsStartup() && p.framer.Startup != nil:
+		name := p.framer.Startup["COMPRESSION"]
+		compressor := gocql.NewCompressor(name)
+		if compressor == nil && name != "" {
+			logp.Warn("unsupported compression negotiated: %s", name)
+		}
+		p.framing.compressor = compressor
+		p.framing.negotiated = true
+		p.framing.startupVersion = header.ProtoVersion()
+	case header.EndsStartup() && p.framing.startupVersion > 0:
+		p.framing.segmented = p.framing.startupVersion >= 5
+		p.framing.startupVersion = 0
+	}
+}
+
+// syncFraming switches the stream to segment framing once negotiated,
+// moving all bytes not parsed yet to the segment buffer.
+func (p *parser) syncFraming() error {
+	if p.segmented || !p.framing.segmented {
+		return nil
+	}
+
+	if isDebug {
+		debugf("switching to protocol v5 segment framing")
+	}
+	p.segmented = true
+	rest := p.buf.Bytes()
+	p.buf = streambuf.Buffer{}
+	if len(rest) == 0 {
+		return nil
+	}
+	return p.appendSegments(rest)
+}
+
+func (p *parser) feed(pkt *protos.Packet) error {
+	if err := p.syncFraming(); err != nil {
+		return err
+	}
+
+	var err error
+	if p.segmented {
+		err = p.appendSegments(pkt.Payload)
+	} else {
+		err = p.append(pkt.Payload)
+	}
+	if err != nil {
 		return err
 	}
 
 	for p.buf.Total() > 0 {
 		if p.message == nil {
 			// allocate new message object to be used by parser with current timestamp
-			p.message = p.newMessage(ts)
+			p.message = p.newMessage(pkt)
 		}
 
 		msg, err := p.parse()
@@ -133,15 +245,21 @@ func (p *parser) feed(ts time.Time, data []byte) error {
 		if err := p.onMessage(msg); err != nil {
 			return err
 		}
+
+		// frames following the STARTUP exchange might be wrapped in segments
+		if err := p.syncFraming(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (p *parser) newMessage(ts time.Time) *message {
+func (p *parser) newMessage(pkt *protos.Packet) *message {
 	return &message{
 		Message: applayer.Message{
-			Ts: ts,
+			Ts:      pkt.Ts,
+			Capture: pkt.Capture,
 		},
 	}
 }
@@ -162,8 +280,9 @@ func (p *parser) parserBody() (bool, error) {
 		return false, nil
 	}
 
-	//check if the ops already ignored
-	if p.message.ignored {
+	//check if the ops already ignored. STARTUP requests are always parsed
+	//for the negotiated compression.
+	if p.message.ignored && !p.framer.Header.IsStartup() {
 		if isDebug {
 			debugf("message marked to be ignored, let's do this")
 		}
@@ -211,7 +330,7 @@ func (p *parser) parse() (*message, error) {
 		if isDebug {
 			debugf("start new framer")
 		}
-		p.framer = gocql.NewFramer(&p.buf, p.config.compressor)
+		p.framer = gocql.NewFramer(&p.buf, p.compressor())
 	}
 
 	// check if the frame header were parsed or not
@@ -266,6 +385,7 @@ func (p *parser) parse() (*message, error) {
 	msg.Direction = dir
 
 	msg.header = p.framer.Header.ToMap()
+	p.negotiate()
 
 	if msg.IsRequest {
 		p.message.results.requests.append(msg)
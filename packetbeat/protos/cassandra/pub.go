@@ -75,6 +75,12 @@ func (pub *transPub) createEvent(requ, resp *message) beat.Event {
 	pbf.Event.Dataset = "cassandra"
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = pbf.Event.Dataset
+	if requ != nil {
+		pbf.AddCapture(&requ.Capture)
+	}
+	if resp != nil {
+		pbf.AddCapture(&resp.Capture)
+	}
 
 	fields := evt.Fields
 	fields["type"] = pbf.Event.Dataset
@@ -18,6 +18,7 @@
 package dhcpv4
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -80,6 +81,27 @@ func (p *dhcpv4Plugin) GetPorts() []int {
 	return p.dhcpv4Config.Ports
 }
 
+// DetectUDP recognizes BOOTP messages holding the DHCP magic cookie.
+func (p *dhcpv4Plugin) DetectUDP(data []byte) protos.Detection {
+	return detectDHCPv4(data)
+}
+
+// magicCookie follows the BOOTP header in DHCP messages.
+var magicCookie = []byte{99, 130, 83, 99}
+
+func detectDHCPv4(data []byte) protos.Detection {
+	const cookieOffset = 236
+	if len(data) < cookieOffset+4 {
+		return protos.DetectionMismatch
+	}
+
+	op := data[0]
+	if (op != 1 && op != 2) || !bytes.Equal(data[cookieOffset:cookieOffset+4], magicCookie) {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
 func (p *dhcpv4Plugin) ParseUDP(pkt *protos.Packet) {
 	if event := p.parseDHCPv4(pkt); event != nil {
 		p.report(*event)
@@ -119,6 +141,7 @@ func (p *dhcpv4Plugin) parseDHCPv4(pkt *protos.Packet) *beat.Event {
 	pbf.Event.Dataset = "dhcpv4"
 	pbf.Network.Transport = "udp"
 	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&pkt.Capture)
 
 	fields := evt.Fields
 	fields["type"] = pbf.Event.Dataset
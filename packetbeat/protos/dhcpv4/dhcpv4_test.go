@@ -265,3 +265,13 @@ func normalizeEvent(t testing.TB, event beat.Event) interface{} {
 	}
 	return out
 }
+
+func TestDetectDHCPv4(t *testing.T) {
+	assert.Equal(t, protos.DetectionMatch, detectDHCPv4(dhcpRequest))
+	assert.Equal(t, protos.DetectionMatch, detectDHCPv4(dhcpACK))
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv4(dhcpRequest[:200]))
+
+	bootp := append([]byte(nil), dhcpRequest...)
+	bootp[236] = 0
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv4(bootp))
+}
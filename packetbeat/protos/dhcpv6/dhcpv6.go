@@ -0,0 +1,422 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dhcpv6
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/ecs/code/go/ecs"
+)
+
+var (
+	metricTotalPackets       = monitoring.NewUint(nil, "dhcpv6.total_packets")
+	metricParseFailures      = monitoring.NewUint(nil, "dhcpv6.parse_failures")
+	metricUnmatchedRequests  = monitoring.NewUint(nil, "dhcpv6.unmatched_requests")
+	metricUnmatchedResponses = monitoring.NewUint(nil, "dhcpv6.unmatched_responses")
+)
+
+func init() {
+	protos.Register("dhcpv6", New)
+}
+
+// New constructs a new dhcpv6 protocol plugin.
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	return newPlugin(testMode, results, watcher, cfg)
+}
+
+func newPlugin(testMode bool, results protos.Reporter, watcher procs.ProcessesWatcher, cfg *common.Config) (*dhcpv6Plugin, error) {
+	config := defaultConfig
+
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &dhcpv6Plugin{
+		dhcpv6Config: config,
+		report:       results,
+		watcher:      watcher,
+		log:          logp.NewLogger("dhcpv6"),
+	}
+	p.requests = common.NewCacheWithRemovalListener(
+		config.TransactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			requ, ok := v.(*message)
+			if !ok {
+				p.log.Error("Expired value is not a *dhcpv6.message.")
+				return
+			}
+			metricUnmatchedRequests.Inc()
+			p.publish(requ, nil)
+		})
+	p.requests.StartJanitor(config.TransactionTimeout)
+	return p, nil
+}
+
+type dhcpv6Plugin struct {
+	dhcpv6Config
+	report  protos.Reporter
+	watcher procs.ProcessesWatcher
+	log     *logp.Logger
+
+	// requests waiting for the reply of the server
+	requests *common.Cache
+}
+
+// transactionKey identifies the exchanges of a client. The client sends
+// all the messages of an exchange with the same transaction ID, from the
+// same address, or through the same relay agent.
+type transactionKey struct {
+	endpoint string
+	id       uint32
+	duid     string
+}
+
+func (p *dhcpv6Plugin) GetPorts() []int {
+	return p.dhcpv6Config.Ports
+}
+
+// DetectUDP recognizes the messages of clients, which always hold the
+// elapsed time option, and the messages forwarded by relay agents.
+func (p *dhcpv6Plugin) DetectUDP(data []byte) protos.Detection {
+	return detectDHCPv6(data)
+}
+
+func detectDHCPv6(data []byte) protos.Detection {
+	if len(data) < headerLen {
+		return protos.DetectionMismatch
+	}
+	var m message
+	if err := parseMessage(data, &m); err != nil || !isClientMessage(m.msgType) {
+		return protos.DetectionMismatch
+	}
+	for _, opt := range m.options {
+		if opt.code == optElapsedTime && len(opt.data) == 2 {
+			return protos.DetectionMatch
+		}
+	}
+	return protos.DetectionMismatch
+}
+
+func (p *dhcpv6Plugin) ParseUDP(pkt *protos.Packet) {
+	metricTotalPackets.Inc()
+
+	m := &message{
+		ts:      pkt.Ts,
+		tuple:   pkt.Tuple,
+		capture: pkt.Capture,
+		size:    len(pkt.Payload),
+	}
+	if err := parseMessage(pkt.Payload, m); err != nil {
+		metricParseFailures.Inc()
+		if m.msgType == 0 {
+			p.log.Warnw("Dropping packet: failed parsing DHCPv6 data", "error", err)
+			return
+		}
+		p.log.Debugw("Failed parsing DHCPv6 options", "error", err)
+	}
+
+	switch {
+	case isClientMessage(m.msgType):
+		p.handleRequest(m)
+	case m.msgType == msgAdvertise || m.msgType == msgReply:
+		p.handleReply(m)
+	default:
+		// reconfigure messages of the server, or unknown messages
+		p.publish(nil, m)
+	}
+}
+
+func (p *dhcpv6Plugin) handleRequest(m *message) {
+	key := transactionKey{
+		endpoint: endpoint(m.tuple.SrcIP, m.tuple.SrcPort),
+		id:       m.transactionID,
+		duid:     string(m.clientDUID),
+	}
+	if v := p.requests.Get(key); v != nil {
+		old := v.(*message)
+		if old.msgType == m.msgType {
+			// The client sends the same message again until the server
+			// replies.
+			old.retransmissions++
+			return
+		}
+		metricUnmatchedRequests.Inc()
+		p.publish(old, nil)
+	}
+	p.requests.Put(key, m)
+}
+
+func (p *dhcpv6Plugin) handleReply(m *message) {
+	key := transactionKey{
+		endpoint: endpoint(m.tuple.DstIP, m.tuple.DstPort),
+		id:       m.transactionID,
+		duid:     string(m.clientDUID),
+	}
+	v := p.requests.Delete(key)
+	if v == nil {
+		// Servers answering a solicit message after the first one are
+		// reported on their own.
+		metricUnmatchedResponses.Inc()
+		p.publish(nil, m)
+		return
+	}
+	p.publish(v.(*message), m)
+}
+
+func endpoint(ip []byte, port uint16) string {
+	return fmt.Sprintf("%x:%d", ip, port)
+}
+
+func (p *dhcpv6Plugin) publish(requ, resp *message) {
+	if p.report == nil {
+		return
+	}
+	p.report(p.newTransaction(requ, resp))
+}
+
+// newTransaction reports a request with the reply of the server. Either
+// of them can be missing.
+func (p *dhcpv6Plugin) newTransaction(requ, resp *message) beat.Event {
+	first := requ
+	if first == nil {
+		first = resp
+	}
+
+	evt, pbf := pb.NewBeatEvent(first.ts)
+
+	src, dst := common.MakeEndpointPair(first.tuple.BaseTuple, nil)
+	pbf.SetSource(&src)
+	pbf.SetDestination(&dst)
+	pbf.Source.Bytes = int64(first.size)
+	if requ == nil {
+		// Reverse, the message was sent by the server.
+		client, server := ecs.Client(*pbf.Destination), ecs.Server(*pbf.Source)
+		pbf.Client = &client
+		pbf.Server = &server
+	} else if resp != nil {
+		pbf.Destination.Bytes = int64(resp.size)
+		pbf.Event.End = resp.ts
+	}
+
+	pbf.Event.Start = first.ts
+	pbf.Event.Dataset = "dhcpv6"
+	pbf.Network.Transport = "udp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&first.capture)
+	if requ != nil && resp != nil {
+		pbf.AddCapture(&resp.capture)
+	}
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+
+	dhcpData := common.MapStr{
+		"transaction_id": fmt.Sprintf("0x%06x", first.transactionID),
+		"message_type":   messageTypeName(first.msgType),
+	}
+	fields["dhcpv6"] = dhcpData
+
+	status := common.OK_STATUS
+	var notes []string
+	if requ != nil && resp != nil {
+		dhcpData.Put("response_type", messageTypeName(resp.msgType))
+	} else if requ != nil {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched request")
+	}
+	if requ != nil && requ.retransmissions > 0 {
+		dhcpData.Put("retransmissions", requ.retransmissions)
+	}
+
+	// Relay agents forward the address of the client.
+	relay := first.relay
+	if relay != nil {
+		dhcpData.Put("relay.link_address", relay.linkAddress.String())
+		dhcpData.Put("relay.peer_address", relay.peerAddress.String())
+		dhcpData.Put("relay.hops", first.relayHops)
+		dhcpData.Put("client_ip", relay.peerAddress.String())
+		pbf.AddIP(relay.peerAddress.String())
+	} else if requ != nil {
+		dhcpData.Put("client_ip", requ.tuple.SrcIP.String())
+	} else {
+		dhcpData.Put("client_ip", resp.tuple.DstIP.String())
+	}
+
+	clientDUID := first.clientDUID
+	serverDUID := first.serverDUID
+	if resp != nil {
+		if clientDUID == nil {
+			clientDUID = resp.clientDUID
+		}
+		if resp.serverDUID != nil {
+			serverDUID = resp.serverDUID
+		}
+	}
+	if len(clientDUID) > 0 {
+		dhcpData.Put("client_duid", formatDUID(clientDUID))
+		if name := duidTypeName(clientDUID); name != "" {
+			dhcpData.Put("client_duid_type", name)
+		}
+	}
+	if mac := duidMAC(clientDUID); mac != nil {
+		dhcpData.Put("client_mac", mac.String())
+	} else if relay != nil {
+		if mac := linkLayerMAC(relay.clientLinkLayerAddr); mac != nil {
+			dhcpData.Put("client_mac", mac.String())
+		}
+	}
+	if len(serverDUID) > 0 {
+		dhcpData.Put("server_duid", formatDUID(serverDUID))
+	}
+
+	// The identity associations assigned by the server, or requested by the
+	// client.
+	ias := first.ias
+	if resp != nil && len(resp.ias) > 0 {
+		ias = resp.ias
+	}
+	putIdentityAssociations(dhcpData, pbf, ias, resp != nil)
+
+	if resp != nil {
+		if resp.status.present {
+			dhcpData.Put("status_code", statusCodeName(resp.status.code))
+			if resp.status.message != "" {
+				dhcpData.Put("status_message", resp.status.message)
+			}
+		}
+		if resp.failed() {
+			status = common.ERROR_STATUS
+			pbf.Event.Outcome = "failure"
+		}
+	}
+
+	// options of the request, then of the reply
+	opts := common.MapStr{}
+	if requ != nil {
+		opts.Update(optionsToMap(requ.options))
+	}
+	if resp != nil {
+		opts.Update(optionsToMap(resp.options))
+	}
+	if len(opts) > 0 {
+		dhcpData.Put("option", opts)
+	}
+
+	fields["status"] = status
+	pbf.Error.Message = notes
+	return evt
+}
+
+// putIdentityAssociations reports the identity associations of a message,
+// and the addresses and prefixes assigned to the client by the server.
+func putIdentityAssociations(dhcpData common.MapStr, pbf *pb.Fields, ias []identityAssociation, assigned bool) {
+	var (
+		iaNA, iaTA, iaPD []common.MapStr
+		addresses        []string
+		prefixes         []string
+	)
+	for _, ia := range ias {
+		info := common.MapStr{"iaid": ia.iaid}
+		if ia.kind != optIATA {
+			info["t1_sec"] = ia.t1
+			info["t2_sec"] = ia.t2
+		}
+		if ia.status.present {
+			info["status_code"] = statusCodeName(ia.status.code)
+			if ia.status.message != "" {
+				info["status_message"] = ia.status.message
+			}
+		}
+
+		var leases []common.MapStr
+		for _, l := range ia.leases {
+			lease := common.MapStr{
+				"preferred_lifetime_sec": l.preferredLifetime,
+				"valid_lifetime_sec":     l.validLifetime,
+			}
+			if l.status.present {
+				lease["status_code"] = statusCodeName(l.status.code)
+			}
+			// Addresses with a valid lifetime of 0 are no longer valid.
+			valid := assigned && l.validLifetime > 0 && !l.status.failed()
+			if ia.kind == optIAPD {
+				prefix := fmt.Sprintf("%s/%d", l.ip, l.prefixLen)
+				lease["prefix"] = prefix
+				if valid {
+					prefixes = append(prefixes, prefix)
+				}
+			} else {
+				lease["address"] = l.ip.String()
+				if valid {
+					addresses = append(addresses, l.ip.String())
+					pbf.AddIP(l.ip.String())
+				}
+			}
+			leases = append(leases, lease)
+		}
+
+		switch ia.kind {
+		case optIANA:
+			if len(leases) > 0 {
+				info["addresses"] = leases
+			}
+			iaNA = append(iaNA, info)
+		case optIATA:
+			if len(leases) > 0 {
+				info["addresses"] = leases
+			}
+			iaTA = append(iaTA, info)
+		case optIAPD:
+			if len(leases) > 0 {
+				info["prefixes"] = leases
+			}
+			iaPD = append(iaPD, info)
+		}
+	}
+
+	if len(iaNA) > 0 {
+		dhcpData.Put("ia_na", iaNA)
+	}
+	if len(iaTA) > 0 {
+		dhcpData.Put("ia_ta", iaTA)
+	}
+	if len(iaPD) > 0 {
+		dhcpData.Put("ia_pd", iaPD)
+	}
+	if len(addresses) > 0 {
+		dhcpData.Put("assigned_ip", addresses)
+	}
+	if len(prefixes) > 0 {
+		dhcpData.Put("assigned_prefix", prefixes)
+	}
+}
@@ -0,0 +1,331 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package dhcpv6
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+var _ protos.UDPPlugin = &dhcpv6Plugin{}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func newTestPlugin(t *testing.T, store *eventStore) *dhcpv6Plugin {
+	p, err := newPlugin(true, store.publish, procs.ProcessesWatcher{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func getValue(t *testing.T, evt beat.Event, key string) interface{} {
+	v, err := evt.GetValue(key)
+	if err != nil {
+		t.Errorf("missing %s: %v", key, err)
+	}
+	return v
+}
+
+func u16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return b
+}
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func opt(code uint16, data ...[]byte) []byte {
+	body := bytes.Join(data, nil)
+	return append(append(u16(code), u16(uint16(len(body)))...), body...)
+}
+
+func dhcpMessage(msgType uint8, xid uint32, opts ...[]byte) []byte {
+	hdr := []byte{msgType, byte(xid >> 16), byte(xid >> 8), byte(xid)}
+	return append(hdr, bytes.Join(opts, nil)...)
+}
+
+func relayMessage(msgType uint8, link, peer net.IP, opts ...[]byte) []byte {
+	hdr := append([]byte{msgType, 0}, link.To16()...)
+	hdr = append(hdr, peer.To16()...)
+	return append(hdr, bytes.Join(opts, nil)...)
+}
+
+var (
+	// DUID-LLT of an Ethernet interface
+	clientDUID = []byte{0x00, 0x01, 0x00, 0x01, 0x27, 0x6f, 0xac, 0x71, 0x52, 0x54, 0x00, 0x12, 0x34, 0x56}
+	// DUID-EN
+	serverDUID = []byte{0x00, 0x02, 0x00, 0x00, 0xab, 0x11, 0x01, 0x02, 0x03, 0x04}
+
+	clientAddr = net.ParseIP("fe80::5054:ff:fe12:3456")
+	serverAddr = net.ParseIP("fe80::1")
+	multicast  = net.ParseIP("ff02::1:2")
+)
+
+func elapsed(hundredths uint16) []byte {
+	return opt(optElapsedTime, u16(hundredths))
+}
+
+func iaNA(iaid, t1, t2 uint32, addrs ...[]byte) []byte {
+	return opt(optIANA, u32(iaid), u32(t1), u32(t2), bytes.Join(addrs, nil))
+}
+
+func iaAddr(ip string, preferred, valid uint32, opts ...[]byte) []byte {
+	return opt(optIAAddr, net.ParseIP(ip).To16(), u32(preferred), u32(valid), bytes.Join(opts, nil))
+}
+
+func iaPD(iaid, t1, t2 uint32, prefixes ...[]byte) []byte {
+	return opt(optIAPD, u32(iaid), u32(t1), u32(t2), bytes.Join(prefixes, nil))
+}
+
+func iaPrefix(prefix string, length uint8, preferred, valid uint32) []byte {
+	return opt(optIAPrefix, u32(preferred), u32(valid), []byte{length}, net.ParseIP(prefix).To16())
+}
+
+func status(code uint16, msg string) []byte {
+	return opt(optStatusCode, u16(code), []byte(msg))
+}
+
+func packet(ts time.Time, src net.IP, srcPort uint16, dst net.IP, dstPort uint16, payload []byte) *protos.Packet {
+	return &protos.Packet{
+		Ts:      ts,
+		Tuple:   common.NewIPPortTuple(16, src, srcPort, dst, dstPort),
+		Payload: payload,
+	}
+}
+
+func TestDetectDHCPv6(t *testing.T) {
+	solicit := dhcpMessage(msgSolicit, 0x1234, opt(optClientID, clientDUID), elapsed(0))
+	assert.Equal(t, protos.DetectionMatch, detectDHCPv6(solicit))
+	relayed := relayMessage(msgRelayForw, net.ParseIP("2001:db8:1::1"), clientAddr, opt(optRelayMsg, solicit))
+	assert.Equal(t, protos.DetectionMatch, detectDHCPv6(relayed))
+
+	// messages of servers, truncated messages and messages without the
+	// elapsed time
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv6(dhcpMessage(msgAdvertise, 0x1234, elapsed(0))))
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv6(solicit[:len(solicit)-1]))
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv6(dhcpMessage(msgSolicit, 0x1234, opt(optClientID, clientDUID))))
+	assert.Equal(t, protos.DetectionMismatch, detectDHCPv6([]byte("GET / HTTP/1.1\r\n")))
+}
+
+func TestDomainList(t *testing.T) {
+	data := []byte("\x07example\x03com\x00\x03lab\x07example\x03org\x00")
+	assert.Equal(t, []string{"example.com", "lab.example.org"}, domainList(data))
+	assert.Equal(t, []string{"host"}, domainList([]byte("\x04host")))
+	assert.Equal(t, []string{"example"}, domainList([]byte("\x07example\xc0\x0c")))
+}
+
+func TestDUID(t *testing.T) {
+	assert.Equal(t, "52:54:00:12:34:56", duidMAC(clientDUID).String())
+	assert.Equal(t, "52:54:00:12:34:56", duidMAC([]byte{0, 3, 0, 1, 0x52, 0x54, 0, 0x12, 0x34, 0x56}).String())
+	assert.Nil(t, duidMAC(serverDUID))
+	assert.Equal(t, "00:02:00:00:ab:11:01:02:03:04", formatDUID(serverDUID))
+}
+
+func TestDHCPv6_exchanges(t *testing.T) {
+	var store eventStore
+	p := newTestPlugin(t, &store)
+	ts := time.Now()
+
+	oro := opt(optORO, u16(optDNSServers), u16(optDomainList), u16(optNTPServer))
+	solicit := dhcpMessage(msgSolicit, 0xa1b2c3,
+		opt(optClientID, clientDUID), elapsed(0), oro,
+		iaNA(1, 0, 0), iaPD(2, 0, 0, iaPrefix("::", 56, 0, 0)))
+	p.ParseUDP(packet(ts, clientAddr, 546, multicast, 547, solicit))
+	// the client sends the message again
+	solicit2 := dhcpMessage(msgSolicit, 0xa1b2c3,
+		opt(optClientID, clientDUID), elapsed(100), oro,
+		iaNA(1, 0, 0), iaPD(2, 0, 0, iaPrefix("::", 56, 0, 0)))
+	p.ParseUDP(packet(ts.Add(time.Second), clientAddr, 546, multicast, 547, solicit2))
+	assert.Empty(t, store.events)
+
+	advertise := dhcpMessage(msgAdvertise, 0xa1b2c3,
+		opt(optClientID, clientDUID), opt(optServerID, serverDUID), opt(optPreference, []byte{255}),
+		iaNA(1, 3600, 5400, iaAddr("2001:db8::100", 7200, 10800)),
+		iaPD(2, 3600, 5400, iaPrefix("2001:db8:100::", 56, 7200, 10800)))
+	p.ParseUDP(packet(ts.Add(1100*time.Millisecond), serverAddr, 547, clientAddr, 546, advertise))
+
+	request := dhcpMessage(msgRequest, 0x0d0e0f,
+		opt(optClientID, clientDUID), opt(optServerID, serverDUID), elapsed(0), oro,
+		iaNA(1, 0, 0, iaAddr("2001:db8::100", 0, 0)),
+		iaPD(2, 0, 0, iaPrefix("2001:db8:100::", 56, 0, 0)))
+	p.ParseUDP(packet(ts.Add(2*time.Second), clientAddr, 546, multicast, 547, request))
+	reply := dhcpMessage(msgReply, 0x0d0e0f,
+		opt(optClientID, clientDUID), opt(optServerID, serverDUID),
+		iaNA(1, 3600, 5400, iaAddr("2001:db8::100", 7200, 10800)),
+		iaPD(2, 3600, 5400, iaPrefix("2001:db8:100::", 56, 7200, 10800)),
+		opt(optDNSServers, net.ParseIP("2001:db8::53").To16(), net.ParseIP("2001:db8::54").To16()),
+		opt(optDomainList, []byte("\x07example\x03com\x00")),
+		opt(optNTPServer, opt(ntpSubOptServerFQDN, []byte("\x03ntp\x07example\x03com\x00"))))
+	p.ParseUDP(packet(ts.Add(2100*time.Millisecond), serverAddr, 547, clientAddr, 546, reply))
+
+	if !assert.Len(t, store.events, 2) {
+		return
+	}
+
+	evt := store.events[0]
+	assert.Equal(t, "dhcpv6", getValue(t, evt, "type"))
+	assert.Equal(t, "OK", getValue(t, evt, "status"))
+	assert.Equal(t, "udp", getValue(t, evt, "network.transport"))
+	assert.Equal(t, "fe80::5054:ff:fe12:3456", getValue(t, evt, "client.ip"))
+	assert.Equal(t, "0xa1b2c3", getValue(t, evt, "dhcpv6.transaction_id"))
+	assert.Equal(t, "solicit", getValue(t, evt, "dhcpv6.message_type"))
+	assert.Equal(t, "advertise", getValue(t, evt, "dhcpv6.response_type"))
+	assert.Equal(t, 1, getValue(t, evt, "dhcpv6.retransmissions"))
+	assert.Equal(t, "00:01:00:01:27:6f:ac:71:52:54:00:12:34:56", getValue(t, evt, "dhcpv6.client_duid"))
+	assert.Equal(t, "link-layer-time", getValue(t, evt, "dhcpv6.client_duid_type"))
+	assert.Equal(t, "52:54:00:12:34:56", getValue(t, evt, "dhcpv6.client_mac"))
+	assert.Equal(t, "00:02:00:00:ab:11:01:02:03:04", getValue(t, evt, "dhcpv6.server_duid"))
+	assert.Equal(t, []string{"DNS Recursive Name Server", "Domain Search List", "NTP Server"},
+		getValue(t, evt, "dhcpv6.option.option_request"))
+	assert.EqualValues(t, 255, getValue(t, evt, "dhcpv6.option.preference"))
+	assert.Equal(t, []string{"2001:db8::100"}, getValue(t, evt, "dhcpv6.assigned_ip"))
+	assert.Equal(t, []string{"2001:db8:100::/56"}, getValue(t, evt, "dhcpv6.assigned_prefix"))
+	assert.EqualValues(t, len(solicit), getValue(t, evt, "source.bytes"))
+	assert.EqualValues(t, len(advertise), getValue(t, evt, "destination.bytes"))
+
+	evt = store.events[1]
+	assert.Equal(t, "request", getValue(t, evt, "dhcpv6.message_type"))
+	assert.Equal(t, "reply", getValue(t, evt, "dhcpv6.response_type"))
+	assert.Equal(t, []common.MapStr{{
+		"iaid":   uint32(1),
+		"t1_sec": uint32(3600),
+		"t2_sec": uint32(5400),
+		"addresses": []common.MapStr{{
+			"address":                "2001:db8::100",
+			"preferred_lifetime_sec": uint32(7200),
+			"valid_lifetime_sec":     uint32(10800),
+		}},
+	}}, getValue(t, evt, "dhcpv6.ia_na"))
+	assert.Equal(t, []common.MapStr{{
+		"iaid":   uint32(2),
+		"t1_sec": uint32(3600),
+		"t2_sec": uint32(5400),
+		"prefixes": []common.MapStr{{
+			"prefix":                 "2001:db8:100::/56",
+			"preferred_lifetime_sec": uint32(7200),
+			"valid_lifetime_sec":     uint32(10800),
+		}},
+	}}, getValue(t, evt, "dhcpv6.ia_pd"))
+	assert.Equal(t, []string{"2001:db8::53", "2001:db8::54"}, getValue(t, evt, "dhcpv6.option.dns_servers"))
+	assert.Equal(t, []string{"example.com"}, getValue(t, evt, "dhcpv6.option.domain_search"))
+	assert.Equal(t, []string{"ntp.example.com"}, getValue(t, evt, "dhcpv6.option.ntp_servers"))
+	assert.Contains(t, getValue(t, evt, "related.ip"), "2001:db8::100")
+}
+
+func TestDHCPv6_relayed(t *testing.T) {
+	var store eventStore
+	p := newTestPlugin(t, &store)
+	ts := time.Now()
+
+	relayAddr := net.ParseIP("2001:db8:1::1")
+	serverAddr := net.ParseIP("2001:db8::547")
+	enterpriseDUID := serverDUID
+
+	solicit := dhcpMessage(msgSolicit, 0x112233,
+		opt(optClientID, enterpriseDUID), elapsed(0), opt(optRapidCommit), iaNA(7, 0, 0))
+	forward := relayMessage(msgRelayForw, relayAddr, clientAddr,
+		opt(optInterfaceID, []byte("eth1")),
+		opt(optClientLinkLayerAddr, u16(hardwareTypeEthernet), []byte{0x52, 0x54, 0, 0xaa, 0xbb, 0xcc}),
+		opt(optRelayMsg, solicit))
+	p.ParseUDP(packet(ts, relayAddr, 547, serverAddr, 547, forward))
+
+	reply := dhcpMessage(msgReply, 0x112233,
+		opt(optClientID, enterpriseDUID), opt(optServerID, clientDUID), opt(optRapidCommit),
+		opt(optIANA, u32(7), u32(0), u32(0), status(statusNoAddrsAvail, "no addresses left")))
+	back := relayMessage(msgRelayRepl, relayAddr, clientAddr,
+		opt(optInterfaceID, []byte("eth1")), opt(optRelayMsg, reply))
+	p.ParseUDP(packet(ts.Add(time.Millisecond), serverAddr, 547, relayAddr, 547, back))
+
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+	evt := store.events[0]
+	assert.Equal(t, "2001:db8:1::1", getValue(t, evt, "source.ip"))
+	assert.Equal(t, "2001:db8:1::1", getValue(t, evt, "dhcpv6.relay.link_address"))
+	assert.Equal(t, "fe80::5054:ff:fe12:3456", getValue(t, evt, "dhcpv6.relay.peer_address"))
+	assert.Equal(t, 1, getValue(t, evt, "dhcpv6.relay.hops"))
+	assert.Equal(t, "fe80::5054:ff:fe12:3456", getValue(t, evt, "dhcpv6.client_ip"))
+	assert.Equal(t, "enterprise", getValue(t, evt, "dhcpv6.client_duid_type"))
+	assert.Equal(t, "52:54:00:aa:bb:cc", getValue(t, evt, "dhcpv6.client_mac"))
+	assert.Equal(t, true, getValue(t, evt, "dhcpv6.option.rapid_commit"))
+	assert.Equal(t, "reply", getValue(t, evt, "dhcpv6.response_type"))
+	assert.Equal(t, []common.MapStr{{
+		"iaid":           uint32(7),
+		"t1_sec":         uint32(0),
+		"t2_sec":         uint32(0),
+		"status_code":    "NoAddrsAvail",
+		"status_message": "no addresses left",
+	}}, getValue(t, evt, "dhcpv6.ia_na"))
+	assert.Equal(t, "Error", getValue(t, evt, "status"))
+	assert.Equal(t, "failure", getValue(t, evt, "event.outcome"))
+}
+
+func TestDHCPv6_serverMessages(t *testing.T) {
+	var store eventStore
+	p := newTestPlugin(t, &store)
+	ts := time.Now()
+
+	// a reply without request, and a reconfigure message
+	reply := dhcpMessage(msgReply, 0x445566,
+		opt(optClientID, clientDUID), opt(optServerID, serverDUID), status(statusSuccess, "released"))
+	p.ParseUDP(packet(ts, serverAddr, 547, clientAddr, 546, reply))
+	reconfigure := dhcpMessage(msgReconfigure, 0,
+		opt(optClientID, clientDUID), opt(optServerID, serverDUID))
+	p.ParseUDP(packet(ts, serverAddr, 547, clientAddr, 546, reconfigure))
+
+	if !assert.Len(t, store.events, 2) {
+		return
+	}
+	evt := store.events[0]
+	assert.Equal(t, "reply", getValue(t, evt, "dhcpv6.message_type"))
+	assert.Equal(t, "fe80::5054:ff:fe12:3456", getValue(t, evt, "client.ip"))
+	assert.Equal(t, "fe80::1", getValue(t, evt, "server.ip"))
+	assert.Equal(t, "fe80::5054:ff:fe12:3456", getValue(t, evt, "dhcpv6.client_ip"))
+	assert.Equal(t, "Success", getValue(t, evt, "dhcpv6.status_code"))
+	assert.Equal(t, "released", getValue(t, evt, "dhcpv6.status_message"))
+	assert.Equal(t, "OK", getValue(t, evt, "status"))
+
+	evt = store.events[1]
+	assert.Equal(t, "reconfigure", getValue(t, evt, "dhcpv6.message_type"))
+	assert.Equal(t, "OK", getValue(t, evt, "status"))
+}
@@ -0,0 +1,457 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dhcpv6
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+// Message types of RFC 8415.
+const (
+	msgSolicit            = 1
+	msgAdvertise          = 2
+	msgRequest            = 3
+	msgConfirm            = 4
+	msgRenew              = 5
+	msgRebind             = 6
+	msgReply              = 7
+	msgRelease            = 8
+	msgDecline            = 9
+	msgReconfigure        = 10
+	msgInformationRequest = 11
+	msgRelayForw          = 12
+	msgRelayRepl          = 13
+)
+
+var messageTypes = map[uint8]string{
+	msgSolicit:            "solicit",
+	msgAdvertise:          "advertise",
+	msgRequest:            "request",
+	msgConfirm:            "confirm",
+	msgRenew:              "renew",
+	msgRebind:             "rebind",
+	msgReply:              "reply",
+	msgRelease:            "release",
+	msgDecline:            "decline",
+	msgReconfigure:        "reconfigure",
+	msgInformationRequest: "information-request",
+	msgRelayForw:          "relay-forw",
+	msgRelayRepl:          "relay-repl",
+}
+
+func messageTypeName(t uint8) string {
+	if name, ok := messageTypes[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", t)
+}
+
+// isClientMessage returns true for the messages sent by clients, all
+// answered by the server.
+func isClientMessage(t uint8) bool {
+	switch t {
+	case msgSolicit, msgRequest, msgConfirm, msgRenew, msgRebind,
+		msgRelease, msgDecline, msgInformationRequest:
+		return true
+	}
+	return false
+}
+
+// Option codes.
+const (
+	optClientID            = 1
+	optServerID            = 2
+	optIANA                = 3
+	optIATA                = 4
+	optIAAddr              = 5
+	optORO                 = 6
+	optPreference          = 7
+	optElapsedTime         = 8
+	optRelayMsg            = 9
+	optUnicast             = 12
+	optStatusCode          = 13
+	optRapidCommit         = 14
+	optUserClass           = 15
+	optVendorClass         = 16
+	optVendorOpts          = 17
+	optInterfaceID         = 18
+	optReconfAccept        = 20
+	optDNSServers          = 23
+	optDomainList          = 24
+	optIAPD                = 25
+	optIAPrefix            = 26
+	optSNTPServers         = 31
+	optInfoRefreshTime     = 32
+	optClientFQDN          = 39
+	optNTPServer           = 56
+	optBootfileURL         = 59
+	optClientArchType      = 61
+	optClientLinkLayerAddr = 79
+	optSolMaxRT            = 82
+	optInfMaxRT            = 83
+)
+
+const (
+	headerLen      = 4
+	relayHeaderLen = 34
+
+	// maxRelayDepth is the limit of relay agents of RFC 8415, HOP_COUNT_LIMIT.
+	maxRelayDepth = 8
+)
+
+var (
+	errShortMessage        = errors.New("message too short")
+	errTruncatedOption     = errors.New("truncated option")
+	errMissingRelayMessage = errors.New("relayed message without relay message option")
+	errTooManyRelays       = errors.New("too many relay agents")
+)
+
+type option struct {
+	code uint16
+	data []byte
+}
+
+func parseOptions(data []byte) ([]option, error) {
+	var opts []option
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return opts, errTruncatedOption
+		}
+		code := binary.BigEndian.Uint16(data)
+		length := int(binary.BigEndian.Uint16(data[2:]))
+		if len(data) < 4+length {
+			return opts, errTruncatedOption
+		}
+		opts = append(opts, option{code: code, data: data[4 : 4+length]})
+		data = data[4+length:]
+	}
+	return opts, nil
+}
+
+// statusCode is the status of a reply, or of one of its identity
+// associations or addresses.
+type statusCode struct {
+	present bool
+	code    uint16
+	message string
+}
+
+func parseStatusCode(data []byte) statusCode {
+	if len(data) < 2 {
+		return statusCode{}
+	}
+	return statusCode{
+		present: true,
+		code:    binary.BigEndian.Uint16(data),
+		message: string(data[2:]),
+	}
+}
+
+func (s statusCode) failed() bool {
+	return s.present && s.code != statusSuccess
+}
+
+// Status codes.
+const (
+	statusSuccess       = 0
+	statusUnspecFail    = 1
+	statusNoAddrsAvail  = 2
+	statusNoBinding     = 3
+	statusNotOnLink     = 4
+	statusUseMulticast  = 5
+	statusNoPrefixAvail = 6
+)
+
+var statusCodes = map[uint16]string{
+	statusSuccess:       "Success",
+	statusUnspecFail:    "UnspecFail",
+	statusNoAddrsAvail:  "NoAddrsAvail",
+	statusNoBinding:     "NoBinding",
+	statusNotOnLink:     "NotOnLink",
+	statusUseMulticast:  "UseMulticast",
+	statusNoPrefixAvail: "NoPrefixAvail",
+}
+
+func statusCodeName(code uint16) string {
+	if name, ok := statusCodes[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", code)
+}
+
+// lease is an address or a prefix of an identity association.
+type lease struct {
+	ip                net.IP
+	prefixLen         int // of the delegated prefixes only
+	preferredLifetime uint32
+	validLifetime     uint32
+	status            statusCode
+}
+
+// identityAssociation holds the addresses (IA_NA, IA_TA) or the prefixes
+// (IA_PD) assigned to a client.
+type identityAssociation struct {
+	kind   uint16
+	iaid   uint32
+	t1, t2 uint32
+	leases []lease
+	status statusCode
+}
+
+func parseIdentityAssociation(opt option) (identityAssociation, error) {
+	ia := identityAssociation{kind: opt.code}
+	data := opt.data
+	switch opt.code {
+	case optIATA:
+		if len(data) < 4 {
+			return ia, errTruncatedOption
+		}
+		ia.iaid = binary.BigEndian.Uint32(data)
+		data = data[4:]
+	default:
+		if len(data) < 12 {
+			return ia, errTruncatedOption
+		}
+		ia.iaid = binary.BigEndian.Uint32(data)
+		ia.t1 = binary.BigEndian.Uint32(data[4:])
+		ia.t2 = binary.BigEndian.Uint32(data[8:])
+		data = data[12:]
+	}
+
+	opts, err := parseOptions(data)
+	for _, o := range opts {
+		switch o.code {
+		case optIAAddr:
+			if len(o.data) < 24 {
+				return ia, errTruncatedOption
+			}
+			l := lease{
+				ip:                net.IP(o.data[:16]),
+				preferredLifetime: binary.BigEndian.Uint32(o.data[16:]),
+				validLifetime:     binary.BigEndian.Uint32(o.data[20:]),
+			}
+			l.status = leaseStatus(o.data[24:])
+			ia.leases = append(ia.leases, l)
+		case optIAPrefix:
+			if len(o.data) < 25 {
+				return ia, errTruncatedOption
+			}
+			l := lease{
+				preferredLifetime: binary.BigEndian.Uint32(o.data),
+				validLifetime:     binary.BigEndian.Uint32(o.data[4:]),
+				prefixLen:         int(o.data[8]),
+				ip:                net.IP(o.data[9:25]),
+			}
+			l.status = leaseStatus(o.data[25:])
+			ia.leases = append(ia.leases, l)
+		case optStatusCode:
+			ia.status = parseStatusCode(o.data)
+		}
+	}
+	return ia, err
+}
+
+func leaseStatus(data []byte) statusCode {
+	opts, _ := parseOptions(data)
+	for _, o := range opts {
+		if o.code == optStatusCode {
+			return parseStatusCode(o.data)
+		}
+	}
+	return statusCode{}
+}
+
+// relayInfo describes the relay agent closest to the client.
+type relayInfo struct {
+	linkAddress net.IP
+	peerAddress net.IP
+
+	// link-layer address of the client, added by the relay agent
+	clientLinkLayerAddr []byte
+}
+
+type message struct {
+	ts      time.Time
+	tuple   common.IPPortTuple
+	capture pb.Capture
+	size    int
+
+	msgType       uint8
+	transactionID uint32
+
+	// relay agents the message went through
+	relay     *relayInfo
+	relayHops int
+
+	clientDUID []byte
+	serverDUID []byte
+	ias        []identityAssociation
+	status     statusCode
+
+	// the options not decoded above
+	options []option
+
+	// number of times a request was sent again by the client
+	retransmissions int
+}
+
+// parseMessage decodes a message, unwrapping the messages relayed.
+func parseMessage(data []byte, m *message) error {
+	for len(data) > 0 && (data[0] == msgRelayForw || data[0] == msgRelayRepl) {
+		if m.relayHops >= maxRelayDepth {
+			return errTooManyRelays
+		}
+		if len(data) < relayHeaderLen {
+			return errShortMessage
+		}
+		relay := &relayInfo{
+			linkAddress: net.IP(data[2:18]),
+			peerAddress: net.IP(data[18:34]),
+		}
+		opts, err := parseOptions(data[relayHeaderLen:])
+		if err != nil {
+			return err
+		}
+		data = nil
+		for _, o := range opts {
+			switch o.code {
+			case optRelayMsg:
+				data = o.data
+			case optClientLinkLayerAddr:
+				relay.clientLinkLayerAddr = o.data
+			}
+		}
+		if data == nil {
+			return errMissingRelayMessage
+		}
+
+		// The innermost relay agent is the closest to the client.
+		m.relay = relay
+		m.relayHops++
+	}
+
+	if len(data) < headerLen {
+		return errShortMessage
+	}
+	m.msgType = data[0]
+	m.transactionID = uint32(data[1])<<16 | uint32(data[2])<<8 | uint32(data[3])
+
+	opts, err := parseOptions(data[headerLen:])
+	for _, o := range opts {
+		switch o.code {
+		case optClientID:
+			m.clientDUID = o.data
+		case optServerID:
+			m.serverDUID = o.data
+		case optIANA, optIATA, optIAPD:
+			ia, iaErr := parseIdentityAssociation(o)
+			if iaErr != nil && err == nil {
+				err = iaErr
+			}
+			m.ias = append(m.ias, ia)
+		case optStatusCode:
+			m.status = parseStatusCode(o.data)
+		default:
+			m.options = append(m.options, o)
+		}
+	}
+	return err
+}
+
+// failed returns true if the server reports a failure, for the message or
+// for all of its identity associations.
+func (m *message) failed() bool {
+	if m.status.failed() {
+		return true
+	}
+	if len(m.ias) == 0 {
+		return false
+	}
+	for _, ia := range m.ias {
+		if !ia.status.failed() {
+			return false
+		}
+	}
+	return true
+}
+
+// DUID types.
+const (
+	duidLLT  = 1
+	duidEN   = 2
+	duidLL   = 3
+	duidUUID = 4
+)
+
+var duidTypes = map[uint16]string{
+	duidLLT:  "link-layer-time",
+	duidEN:   "enterprise",
+	duidLL:   "link-layer",
+	duidUUID: "uuid",
+}
+
+func duidTypeName(duid []byte) string {
+	if len(duid) < 2 {
+		return ""
+	}
+	return duidTypes[binary.BigEndian.Uint16(duid)]
+}
+
+const hardwareTypeEthernet = 1
+
+// duidMAC returns the MAC address of the link-layer DUIDs of Ethernet
+// interfaces.
+func duidMAC(duid []byte) net.HardwareAddr {
+	if len(duid) < 4 || binary.BigEndian.Uint16(duid[2:]) != hardwareTypeEthernet {
+		return nil
+	}
+	var addr []byte
+	switch binary.BigEndian.Uint16(duid) {
+	case duidLLT:
+		if len(duid) >= 8 {
+			addr = duid[8:]
+		}
+	case duidLL:
+		addr = duid[4:]
+	}
+	if len(addr) != 6 {
+		return nil
+	}
+	return net.HardwareAddr(addr)
+}
+
+// linkLayerMAC returns the MAC address of a client link-layer address
+// option of an Ethernet interface.
+func linkLayerMAC(data []byte) net.HardwareAddr {
+	if len(data) != 8 || binary.BigEndian.Uint16(data) != hardwareTypeEthernet {
+		return nil
+	}
+	return net.HardwareAddr(data[2:])
+}
+
+// formatDUID formats DUIDs as the hexadecimal bytes separated by colons.
+func formatDUID(duid []byte) string {
+	return net.HardwareAddr(duid).String()
+}
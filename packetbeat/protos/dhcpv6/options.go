@@ -0,0 +1,244 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dhcpv6
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+var optionNames = map[uint16]string{
+	optClientID:            "Client Identifier",
+	optServerID:            "Server Identifier",
+	optIANA:                "IA_NA",
+	optIATA:                "IA_TA",
+	optORO:                 "Option Request",
+	optPreference:          "Preference",
+	optElapsedTime:         "Elapsed Time",
+	optUnicast:             "Server Unicast",
+	optRapidCommit:         "Rapid Commit",
+	optUserClass:           "User Class",
+	optVendorClass:         "Vendor Class",
+	optVendorOpts:          "Vendor-specific Information",
+	optReconfAccept:        "Reconfigure Accept",
+	21:                     "SIP Server Domain Names",
+	22:                     "SIP Server Addresses",
+	optDNSServers:          "DNS Recursive Name Server",
+	optDomainList:          "Domain Search List",
+	optIAPD:                "IA_PD",
+	optSNTPServers:         "SNTP Servers",
+	optInfoRefreshTime:     "Information Refresh Time",
+	optClientFQDN:          "Client FQDN",
+	41:                     "POSIX Timezone",
+	42:                     "TZDB Timezone",
+	optNTPServer:           "NTP Server",
+	optBootfileURL:         "Boot File URL",
+	60:                     "Boot File Parameters",
+	optClientArchType:      "Client System Architecture Type",
+	optClientLinkLayerAddr: "Client Link-Layer Address",
+	optSolMaxRT:            "SOL_MAX_RT",
+	optInfMaxRT:            "INF_MAX_RT",
+}
+
+// NTP server suboptions.
+const (
+	ntpSubOptServerAddr = 1
+	ntpSubOptMcastAddr  = 2
+	ntpSubOptServerFQDN = 3
+)
+
+// optionsToMap decodes the options reported in the events. Malformed
+// options are ignored.
+func optionsToMap(options []option) common.MapStr {
+	opts := common.MapStr{}
+
+	for _, opt := range options {
+		data := opt.data
+		switch opt.code {
+		case optORO:
+			var names []string
+			for ; len(data) >= 2; data = data[2:] {
+				code := binary.BigEndian.Uint16(data)
+				if name, ok := optionNames[code]; ok {
+					names = append(names, name)
+				} else {
+					names = append(names, fmt.Sprintf("Unknown (%v)", code))
+				}
+			}
+			opts.Put("option_request", names)
+
+		case optPreference:
+			if len(data) == 1 {
+				opts.Put("preference", data[0])
+			}
+
+		case optElapsedTime:
+			// in hundredths of a second
+			if len(data) == 2 {
+				opts.Put("elapsed_time_ms", int(binary.BigEndian.Uint16(data))*10)
+			}
+
+		case optUnicast:
+			if len(data) == net.IPv6len {
+				opts.Put("server_unicast", net.IP(data).String())
+			}
+
+		case optRapidCommit:
+			opts.Put("rapid_commit", true)
+
+		case optReconfAccept:
+			opts.Put("reconfigure_accept", true)
+
+		case optUserClass:
+			opts.Put("user_class", stringList(data))
+
+		case optVendorClass:
+			if len(data) >= 4 {
+				opts.Put("vendor_class", common.MapStr{
+					"enterprise_id": binary.BigEndian.Uint32(data),
+					"data":          stringList(data[4:]),
+				})
+			}
+
+		case optVendorOpts:
+			if len(data) >= 4 {
+				opts.Put("vendor_options", common.MapStr{
+					"enterprise_id": binary.BigEndian.Uint32(data),
+					"data":          hex.EncodeToString(data[4:]),
+				})
+			}
+
+		case optDNSServers:
+			opts.Put("dns_servers", addressList(data))
+
+		case optSNTPServers:
+			opts.Put("sntp_servers", addressList(data))
+
+		case optDomainList:
+			opts.Put("domain_search", domainList(data))
+
+		case optInfoRefreshTime:
+			if len(data) == 4 {
+				opts.Put("information_refresh_time_sec", binary.BigEndian.Uint32(data))
+			}
+
+		case optSolMaxRT:
+			if len(data) == 4 {
+				opts.Put("sol_max_rt_sec", binary.BigEndian.Uint32(data))
+			}
+
+		case optInfMaxRT:
+			if len(data) == 4 {
+				opts.Put("inf_max_rt_sec", binary.BigEndian.Uint32(data))
+			}
+
+		case optClientFQDN:
+			// flags, followed by the name
+			if len(data) >= 1 {
+				if names := domainList(data[1:]); len(names) > 0 {
+					opts.Put("client_fqdn", names[0])
+				}
+			}
+
+		case optNTPServer:
+			var servers []string
+			subOpts, _ := parseOptions(data)
+			for _, sub := range subOpts {
+				switch sub.code {
+				case ntpSubOptServerAddr, ntpSubOptMcastAddr:
+					if len(sub.data) == net.IPv6len {
+						servers = append(servers, net.IP(sub.data).String())
+					}
+				case ntpSubOptServerFQDN:
+					servers = append(servers, domainList(sub.data)...)
+				}
+			}
+			opts.Put("ntp_servers", servers)
+
+		case optBootfileURL:
+			opts.Put("boot_file_url", string(data))
+
+		case optClientArchType:
+			var types []uint16
+			for ; len(data) >= 2; data = data[2:] {
+				types = append(types, binary.BigEndian.Uint16(data))
+			}
+			opts.Put("client_arch_types", types)
+		}
+	}
+
+	if len(opts) > 0 {
+		return opts
+	}
+	return nil
+}
+
+// stringList decodes a list of strings prefixed by their 16-bit length.
+func stringList(data []byte) []string {
+	var list []string
+	for len(data) >= 2 {
+		n := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+n {
+			break
+		}
+		list = append(list, string(data[2:2+n]))
+		data = data[2+n:]
+	}
+	return list
+}
+
+func addressList(data []byte) []string {
+	var list []string
+	for ; len(data) >= net.IPv6len; data = data[net.IPv6len:] {
+		list = append(list, net.IP(data[:net.IPv6len]).String())
+	}
+	return list
+}
+
+// domainList decodes a list of domain names in the uncompressed wire format
+// of DNS.
+func domainList(data []byte) []string {
+	var (
+		list   []string
+		labels []string
+	)
+	for len(data) > 0 {
+		n := int(data[0])
+		if n == 0 {
+			list = append(list, strings.Join(labels, "."))
+			labels = nil
+			data = data[1:]
+			continue
+		}
+		if n > 63 || len(data) < 1+n {
+			break
+		}
+		labels = append(labels, string(data[1:1+n]))
+		data = data[1+n:]
+	}
+	if len(labels) > 0 {
+		// partial name, like the host name of a client FQDN
+		list = append(list, strings.Join(labels, "."))
+	}
+	return list
+}
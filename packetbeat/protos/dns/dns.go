@@ -17,15 +17,17 @@
 
 // Package dns provides support for parsing DNS messages and reporting the
 // results. This package supports the DNS protocol as defined by RFC 1034
-// and RFC 1035. It does not have any special support for RFC 2671 (EDNS) or
-// RFC 4035 (DNS Security Extensions), but since those specifications only
-// add backwards compatible features there will be no issues handling the
-// messages.
+// and RFC 1035. The EDNS OPT pseudo-record (RFC 6891) is decoded into
+// structured fields, including the client subnet (RFC 7871), cookie
+// (RFC 7873) and extended error (RFC 8914) options. Responses also carry a
+// summary of their DNSSEC (RFC 4035) flags and signatures.
 package dns
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -102,6 +104,7 @@ type hashableDNSTuple [maxDNSTupleRawSize]byte
 type dnsMessage struct {
 	ts           time.Time          // Time when the message was received.
 	tuple        common.IPPortTuple // Source and destination addresses of packet.
+	capture      pb.Capture         // Metadata of the packet.
 	cmdlineTuple *common.ProcessTuple
 	data         *mkdns.Msg // Parsed DNS packet data.
 	length       int        // Length of the DNS message in bytes (without DecodeOffset).
@@ -298,6 +301,47 @@ func (dns *dnsPlugin) ConnectionTimeout() time.Duration {
 	return dns.transactionTimeout
 }
 
+// dnsHeaderLen is the length of the fixed size header of DNS messages.
+const dnsHeaderLen = 12
+
+// detectDNS checks the header and the question of a query or response. A
+// message must hold a single question and use a standard opcode.
+func detectDNS(data []byte) protos.Detection {
+	if len(data) < dnsHeaderLen {
+		return protos.DetectionNeedMore
+	}
+	opcode := (data[2] >> 3) & 0xf
+	if opcode > 5 || opcode == 3 || binary.BigEndian.Uint16(data[4:]) != 1 {
+		return protos.DetectionMismatch
+	}
+
+	// the question name is a sequence of labels, followed by type and class
+	i := dnsHeaderLen
+	for {
+		if i >= len(data) {
+			return protos.DetectionNeedMore
+		}
+		n := int(data[i])
+		if n == 0 {
+			break
+		}
+		if n > 63 {
+			return protos.DetectionMismatch
+		}
+		i += 1 + n
+	}
+	if len(data) < i+5 {
+		return protos.DetectionNeedMore
+	}
+
+	// the top bit of the class is the mDNS unicast response flag
+	switch binary.BigEndian.Uint16(data[i+3:]) & 0x7fff {
+	case mkdns.ClassINET, mkdns.ClassCHAOS, mkdns.ClassHESIOD, mkdns.ClassANY:
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
 func (dns *dnsPlugin) receivedDNSRequest(tuple *dnsTuple, msg *dnsMessage) {
 	debugf("Processing query. %s", tuple.String())
 
@@ -375,6 +419,12 @@ func (dns *dnsPlugin) publishTransaction(t *dnsTransaction) {
 	pbf.Network.Transport = t.transport.String()
 	pbf.Network.Protocol = "dns"
 	pbf.Error.Message = t.notes
+	if t.request != nil {
+		pbf.AddCapture(&t.request.capture)
+	}
+	if t.response != nil {
+		pbf.AddCapture(&t.response.capture)
+	}
 
 	fields := evt.Fields
 	fields["type"] = "dns"
@@ -397,6 +447,9 @@ func (dns *dnsPlugin) publishTransaction(t *dnsTransaction) {
 		}
 		addDNSToMapStr(dnsEvent, pbf, t.response.data, dns.includeAuthorities,
 			dns.includeAdditionals)
+		if dnssec := dnssecToMapStr(t.request.data, t.response.data); dnssec != nil {
+			dnsEvent["dnssec"] = dnssec
+		}
 
 		if t.response.data.Rcode == 0 {
 			fields["status"] = common.OK_STATUS
@@ -436,6 +489,9 @@ func (dns *dnsPlugin) publishTransaction(t *dnsTransaction) {
 		}
 		addDNSToMapStr(dnsEvent, pbf, t.response.data, dns.includeAuthorities,
 			dns.includeAdditionals)
+		if dnssec := dnssecToMapStr(nil, t.response.data); dnssec != nil {
+			dnsEvent["dnssec"] = dnssec
+		}
 		if dns.sendResponse {
 			fields["response"] = dnsToString(t.response.data)
 		}
@@ -569,25 +625,42 @@ func optToMapStr(rrOPT *mkdns.OPT) common.MapStr {
 		"ext_rcode": dnsResponseCodeToString(rrOPT.ExtendedRcode()),
 	}
 	for _, o := range rrOPT.Option {
-		switch o.(type) {
+		switch x := o.(type) {
 		case *mkdns.EDNS0_DAU:
 			optMapStr["dau"] = o.String()
 		case *mkdns.EDNS0_DHU:
 			optMapStr["dhu"] = o.String()
 		case *mkdns.EDNS0_EXPIRE:
-			optMapStr["local"] = o.String()
+			optMapStr["expire"] = x.Expire
 		case *mkdns.EDNS0_LLQ:
 			optMapStr["llq"] = o.String()
 		case *mkdns.EDNS0_LOCAL:
+			if x.Code == edns0ExtendedError {
+				// Only the first extended error is reported, RFC 8914 allows
+				// several but in practice resolvers send a single one.
+				if _, found := optMapStr["extended_error"]; !found {
+					if ede := extendedErrorToMapStr(x.Data); ede != nil {
+						optMapStr["extended_error"] = ede
+					}
+				}
+				continue
+			}
 			optMapStr["local"] = o.String()
 		case *mkdns.EDNS0_N3U:
 			optMapStr["n3u"] = o.String()
 		case *mkdns.EDNS0_NSID:
 			optMapStr["nsid"] = o.String()
 		case *mkdns.EDNS0_SUBNET:
-			optMapStr["subnet"] = o.String()
+			optMapStr["client_subnet"] = subnetToMapStr(x)
 		case *mkdns.EDNS0_COOKIE:
-			optMapStr["cookie"] = o.String()
+			optMapStr["cookie"] = cookieToMapStr(x)
+		case *mkdns.EDNS0_TCP_KEEPALIVE:
+			if x.Length != 0 {
+				// The timeout is expressed in units of 100 milliseconds.
+				optMapStr["tcp_keepalive"] = uint32(x.Timeout) * 100
+			}
+		case *mkdns.EDNS0_PADDING:
+			optMapStr["padding"] = len(x.Padding)
 		case *mkdns.EDNS0_UL:
 			optMapStr["ul"] = o.String()
 		}
@@ -595,6 +668,138 @@ func optToMapStr(rrOPT *mkdns.OPT) common.MapStr {
 	return optMapStr
 }
 
+// subnetToMapStr converts an EDNS Client Subnet option [RFC7871] to a MapStr.
+func subnetToMapStr(o *mkdns.EDNS0_SUBNET) common.MapStr {
+	m := common.MapStr{
+		"source_prefix_length": o.SourceNetmask,
+		"scope_prefix_length":  o.SourceScope,
+	}
+
+	var bits int
+	switch o.Family {
+	case 1:
+		m["family"] = "ipv4"
+		bits = 8 * net.IPv4len
+	case 2:
+		m["family"] = "ipv6"
+		bits = 8 * net.IPv6len
+	default:
+		m["family"] = strconv.Itoa(int(o.Family))
+		return m
+	}
+
+	if o.Address != nil {
+		m["address"] = o.Address.String()
+		if int(o.SourceNetmask) <= bits {
+			mask := net.CIDRMask(int(o.SourceNetmask), bits)
+			subnet := net.IPNet{IP: o.Address.Mask(mask), Mask: mask}
+			m["subnet"] = subnet.String()
+		}
+	}
+	return m
+}
+
+// cookieToMapStr converts a DNS Cookie option [RFC7873] to a MapStr. The
+// client cookie is always 8 bytes long, the optional server cookie follows it.
+func cookieToMapStr(o *mkdns.EDNS0_COOKIE) common.MapStr {
+	const clientCookieLen = 2 * 8 // hex encoded
+
+	cookie := strings.ToLower(o.Cookie)
+	if len(cookie) <= clientCookieLen {
+		return common.MapStr{"client": cookie}
+	}
+	return common.MapStr{
+		"client": cookie[:clientCookieLen],
+		"server": cookie[clientCookieLen:],
+	}
+}
+
+// extendedErrorToMapStr decodes the payload of an Extended DNS Error option
+// [RFC8914]. It returns nil if the option is truncated.
+func extendedErrorToMapStr(data []byte) common.MapStr {
+	if len(data) < 2 {
+		return nil
+	}
+	code := binary.BigEndian.Uint16(data)
+	m := common.MapStr{
+		"code": code,
+		"name": dnsExtendedErrorToString(code),
+	}
+	// The extra text is UTF-8 and not NUL terminated, but some
+	// implementations add one anyway.
+	if text := strings.TrimRight(string(data[2:]), "\x00"); text != "" {
+		m["text"] = text
+	}
+	return m
+}
+
+// dnssecToMapStr summarizes the DNSSEC related information of a response.
+// The request is optional and is used to tell whether the client asked
+// for DNSSEC records. It returns nil if neither message uses EDNS nor
+// carries DNSSEC flags or signatures.
+func dnssecToMapStr(request, response *mkdns.Msg) common.MapStr {
+	query := response
+	if request != nil {
+		query = request
+	}
+	opt := query.IsEdns0()
+	signed := hasRRSIG(response.Answer) || hasRRSIG(response.Ns)
+	if opt == nil && response.IsEdns0() == nil && !signed &&
+		!response.AuthenticatedData && !response.CheckingDisabled {
+		return nil
+	}
+
+	m := common.MapStr{
+		"ok":                opt != nil && opt.Do(),
+		"authentic_data":    response.AuthenticatedData,
+		"checking_disabled": response.CheckingDisabled,
+		"signed":            signed,
+	}
+
+	if status := dnssecStatus(response); status != "" {
+		m["status"] = status
+	}
+	return m
+}
+
+// dnssecStatus derives the validation status reported by a resolver from
+// the AD flag and the extended error, if any. It returns an empty string
+// when the response carries no evidence of validation.
+func dnssecStatus(response *mkdns.Msg) string {
+	if response.AuthenticatedData {
+		return "secure"
+	}
+
+	opt := response.IsEdns0()
+	if opt == nil {
+		return ""
+	}
+	for _, o := range opt.Option {
+		local, ok := o.(*mkdns.EDNS0_LOCAL)
+		if !ok || local.Code != edns0ExtendedError || len(local.Data) < 2 {
+			continue
+		}
+		switch binary.BigEndian.Uint16(local.Data) {
+		case 1, 2: // Unsupported DNSKEY Algorithm, Unsupported DS Digest Type
+			return "insecure"
+		case 5: // DNSSEC Indeterminate
+			return "indeterminate"
+		case 6, 7, 8, 9, 10, 11, 12: // DNSSEC Bogus to NSEC Missing
+			return "bogus"
+		}
+	}
+	return ""
+}
+
+func hasRRSIG(records []mkdns.RR) bool {
+	for _, rr := range records {
+		if rr.Header().Rrtype == mkdns.TypeRRSIG {
+			return true
+		}
+	}
+	return false
+}
+
 // rrsToMapStr converts an slice of RR's to an slice of MapStr's and optionally
 // returns a list of the IP addresses found in the resource records.
 func rrsToMapStrs(records []mkdns.RR, ipList bool) ([]common.MapStr, []string) {
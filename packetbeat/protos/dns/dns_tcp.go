@@ -53,6 +53,18 @@ type dnsConnectionData struct {
 	prevRequest *dnsMessage
 }
 
+// DetectTCP recognizes DNS queries and responses following the message
+// length.
+func (dns *dnsPlugin) DetectTCP(data []byte) protos.Detection {
+	if len(data) < decodeOffset {
+		return protos.DetectionNeedMore
+	}
+	if int(binary.BigEndian.Uint16(data)) < dnsHeaderLen {
+		return protos.DetectionMismatch
+	}
+	return detectDNS(data[decodeOffset:])
+}
+
 func (dns *dnsPlugin) Parse(pkt *protos.Packet, tcpTuple *common.TCPTuple, dir uint8, private protos.ProtocolData) protos.ProtocolData {
 	defer logp.Recover("Dns ParseTcp")
 
@@ -96,7 +108,7 @@ func (dns *dnsPlugin) doParse(conn *dnsConnectionData, pkt *protos.Packet, tcpTu
 		conn.data[dir] = stream
 	} else {
 		if stream.message == nil { // nth message of the same stream
-			stream.message = &dnsMessage{ts: pkt.Ts, tuple: pkt.Tuple}
+			stream.message = &dnsMessage{ts: pkt.Ts, tuple: pkt.Tuple, capture: pkt.Capture}
 		}
 
 		stream.rawData = append(stream.rawData, payload...)
@@ -137,7 +149,7 @@ func newStream(pkt *protos.Packet, tcpTuple *common.TCPTuple) *dnsStream {
 	return &dnsStream{
 		tcpTuple: tcpTuple,
 		rawData:  pkt.Payload,
-		message:  &dnsMessage{ts: pkt.Ts, tuple: pkt.Tuple},
+		message:  &dnsMessage{ts: pkt.Ts, tuple: pkt.Tuple, capture: pkt.Capture},
 	}
 }
 
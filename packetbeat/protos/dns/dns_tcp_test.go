@@ -665,3 +665,14 @@ func BenchmarkParallelTcpParse(b *testing.B) {
 		}
 	})
 }
+
+// Verify that queries and responses are detected after the message length.
+func TestDetectTCP(t *testing.T) {
+	dns := &dnsPlugin{}
+	for _, q := range messagesTCP {
+		assert.Equal(t, protos.DetectionMatch, dns.DetectTCP(q.request), q.qName)
+		assert.Equal(t, protos.DetectionNeedMore, dns.DetectTCP(q.request[:16]), q.qName)
+	}
+
+	assert.Equal(t, protos.DetectionMismatch, dns.DetectTCP([]byte("GET / HTTP/1.1\r\n")))
+}
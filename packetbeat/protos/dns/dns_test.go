@@ -326,3 +326,116 @@ func TestRRsToMapStrsWithOPTRecord(t *testing.T) {
 	assert.Equal(t, "miek.nl", mapStr["name"])
 	assert.EqualValues(t, 10, mapStr["preference"])
 }
+
+func TestOptToMapStr(t *testing.T) {
+	o := new(mkdns.OPT)
+	o.Hdr.Name = "."
+	o.Hdr.Rrtype = mkdns.TypeOPT
+	o.SetUDPSize(1232)
+	o.SetDo()
+	o.Option = []mkdns.EDNS0{
+		&mkdns.EDNS0_SUBNET{
+			Code:          mkdns.EDNS0SUBNET,
+			Family:        1,
+			SourceNetmask: 24,
+			SourceScope:   0,
+			Address:       net.ParseIP("192.0.2.17").To4(),
+		},
+		&mkdns.EDNS0_COOKIE{
+			Code:   mkdns.EDNS0COOKIE,
+			Cookie: "24A8A3B0E1C9D2F401000000604E3D2A63B1F5C4A1A5C0D3",
+		},
+		&mkdns.EDNS0_TCP_KEEPALIVE{
+			Code:    mkdns.EDNS0TCPKEEPALIVE,
+			Length:  2,
+			Timeout: 150,
+		},
+		&mkdns.EDNS0_LOCAL{
+			Code: edns0ExtendedError,
+			Data: append([]byte{0x00, 0x07}, "signature expired"...),
+		},
+	}
+
+	m := optToMapStr(o)
+	assert.Equal(t, true, m["do"])
+	assert.Equal(t, "0", m["version"])
+	assert.EqualValues(t, 1232, m["udp_size"])
+	assert.EqualValues(t, 15000, m["tcp_keepalive"])
+	assert.Equal(t, common.MapStr{
+		"family":               "ipv4",
+		"address":              "192.0.2.17",
+		"subnet":               "192.0.2.0/24",
+		"source_prefix_length": uint8(24),
+		"scope_prefix_length":  uint8(0),
+	}, m["client_subnet"])
+	assert.Equal(t, common.MapStr{
+		"client": "24a8a3b0e1c9d2f4",
+		"server": "01000000604e3d2a63b1f5c4a1a5c0d3",
+	}, m["cookie"])
+	assert.Equal(t, common.MapStr{
+		"code": uint16(7),
+		"name": "Signature Expired",
+		"text": "signature expired",
+	}, m["extended_error"])
+	assert.NotContains(t, m, "local")
+}
+
+func TestDNSSECToMapStr(t *testing.T) {
+	request := new(mkdns.Msg)
+	request.SetQuestion("example.com.", mkdns.TypeA)
+	request.SetEdns0(4096, true)
+
+	t.Run("secure", func(t *testing.T) {
+		response := new(mkdns.Msg)
+		response.SetReply(request)
+		response.AuthenticatedData = true
+		response.Answer = []mkdns.RR{
+			&mkdns.RRSIG{Hdr: mkdns.RR_Header{Name: "example.com.", Rrtype: mkdns.TypeRRSIG, Class: mkdns.ClassINET}},
+		}
+
+		m := dnssecToMapStr(request, response)
+		assert.Equal(t, common.MapStr{
+			"ok":                true,
+			"authentic_data":    true,
+			"checking_disabled": false,
+			"signed":            true,
+			"status":            "secure",
+		}, m)
+	})
+
+	t.Run("bogus", func(t *testing.T) {
+		response := new(mkdns.Msg)
+		response.SetRcode(request, mkdns.RcodeServerFailure)
+		opt := &mkdns.OPT{Hdr: mkdns.RR_Header{Name: ".", Rrtype: mkdns.TypeOPT}}
+		opt.Option = []mkdns.EDNS0{
+			&mkdns.EDNS0_LOCAL{Code: edns0ExtendedError, Data: []byte{0x00, 0x06}},
+		}
+		response.Extra = []mkdns.RR{opt}
+
+		m := dnssecToMapStr(request, response)
+		assert.Equal(t, true, m["ok"])
+		assert.Equal(t, false, m["authentic_data"])
+		assert.Equal(t, false, m["signed"])
+		assert.Equal(t, "bogus", m["status"])
+	})
+
+	t.Run("unvalidated", func(t *testing.T) {
+		response := new(mkdns.Msg)
+		response.SetReply(request)
+		response.SetEdns0(4096, false)
+
+		m := dnssecToMapStr(nil, response)
+		assert.Equal(t, false, m["ok"])
+		assert.NotContains(t, m, "status")
+	})
+
+	t.Run("without EDNS", func(t *testing.T) {
+		plain := new(mkdns.Msg)
+		plain.SetQuestion("example.com.", mkdns.TypeA)
+		response := new(mkdns.Msg)
+		response.SetReply(plain)
+
+		assert.Nil(t, dnssecToMapStr(plain, response))
+		assert.Nil(t, dnssecToMapStr(nil, response))
+	})
+}
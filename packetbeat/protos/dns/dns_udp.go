@@ -26,6 +26,14 @@ import (
 // Only EDNS packets should have their size beyond this value
 const maxDNSPacketSize = (1 << 9) // 512 (bytes)
 
+// DetectUDP recognizes DNS queries and responses.
+func (dns *dnsPlugin) DetectUDP(data []byte) protos.Detection {
+	if detectDNS(data) == protos.DetectionMatch {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
 func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet) {
 	defer logp.Recover("Dns ParseUdp")
 	packetSize := len(pkt.Payload)
@@ -46,6 +54,7 @@ func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet) {
 	dnsMsg := &dnsMessage{
 		ts:           pkt.Ts,
 		tuple:        pkt.Tuple,
+		capture:      pkt.Capture,
 		cmdlineTuple: dns.watcher.FindProcessesTupleUDP(&pkt.Tuple),
 		data:         dnsPkt,
 		length:       packetSize,
@@ -420,6 +420,25 @@ func TestPublishTransaction_edns(t *testing.T) {
 	assert.Equal(t, common.OK_STATUS, mapValue(t, m, "status"))
 	assert.Nil(t, mapValue(t, m, "error.message"))
 	assertMapStrData(t, m, q)
+	assert.Equal(t, true, mapValue(t, m, "dns.opt.do"))
+	assert.Equal(t, true, mapValue(t, m, "dns.dnssec.ok"))
+	assert.Equal(t, true, mapValue(t, m, "dns.dnssec.authentic_data"))
+	assert.Equal(t, true, mapValue(t, m, "dns.dnssec.signed"))
+	assert.Equal(t, "secure", mapValue(t, m, "dns.dnssec.status"))
+}
+
+// Verify that DNSSEC information is not published for messages without EDNS.
+func TestPublishTransaction_noDnssec(t *testing.T) {
+	results := &eventStore{}
+	dns := newDNS(results, testing.Verbose())
+	q := elasticA
+	dns.ParseUDP(newPacket(forward, q.request))
+	dns.ParseUDP(newPacket(reverse, q.response))
+
+	m := expectResult(t, results)
+	assertMapStrData(t, m, q)
+	assert.Nil(t, mapValue(t, m, "dns.opt"))
+	assert.Nil(t, mapValue(t, m, "dns.dnssec"))
 }
 
 // Verify that a non-edns answer to a edns query publishes Notes.
@@ -537,3 +556,16 @@ func parseUDPRequestResponse(t testing.TB, dns *dnsPlugin, results *eventStore,
 	assert.Nil(t, mapValue(t, m, "error.message"))
 	assertMapStrData(t, m, q)
 }
+
+// Verify that queries and responses are detected, but no other payloads.
+func TestDetectUDP(t *testing.T) {
+	dns := &dnsPlugin{}
+	for _, q := range messages {
+		assert.Equal(t, protos.DetectionMatch, dns.DetectUDP(q.request), q.qName)
+		assert.Equal(t, protos.DetectionMatch, dns.DetectUDP(q.response), q.qName)
+		assert.Equal(t, protos.DetectionMismatch, dns.DetectUDP(q.request[:14]), q.qName)
+	}
+
+	garbage := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
+	assert.Equal(t, protos.DetectionMismatch, dns.DetectUDP(garbage))
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetDns returns asset data.
 // This is the base64 encoded zlib format compressed contents of protos/dns.
 func AssetDns() string {
-	return "eJzMl0tv3LYTwO/7KQZ7+Sf410pToJc9FEhrFwhQGEGc9tDLhiJHK9YUR50h11Y+fUFK2pW8j7iP2PHFC2o4/M2Twwu4xW4FxssCINjgcAXLy+ub5QLAoGi2bbDkV3B5fXMhLWpbWQ24RR+gsuiMFAsYfq0WAAAX4FWDo8r0F7oWV7Bhiu2wMpWf7qmc2kihYqiJbVDBbnEnM+opiRwqP1mfcf4w+QDwJnFntdDDd9ZvINQqQKgRGKUlb9KaIG+RwQooDyNBBxXxTGHaZahR1mdkiIIGrM/rf0aUBFEsThjGqCOLJb9WW2WdKt0XMe+uxlAjw3DcNpNxBxLbljhkG8fzB/aZ1mxY749HmGJQLKP5onHSzqaEM5ZRB8lLQ7wCQRtZIoKaKewt3nnAdQW8P+0OylDKnTQ3JQT6YPXaqKC+cE6OmIOJmrxYgyyTlJV5xHZ4Jw3QNepb6zdrYyXF/Ynilc+aBmymRezGqxAZYaucNSqdBFSNxcTdSXMCR69VQLM+4o//1h7yrstElWUJ8P3r76DsAsoIyti6Du6Q5yFhDJE9miMm7PoEBmfWrYuyJo+nQD/UCFhVqFM3hEDthcMturELvcAPv1y+hKQFyCM0xAhOleiKGc/PxID3qmkdfpO5077/v04NDpYVUVEqLjbklN8UxJtimepiOV2Y60tYqRayAoMBubG+d1qvGzQ1KFAxNamlYlOiMWhAU9sNvpsp7JUl6TqEdvXqVRtLZ7XEqrL3mWAiPliyAtWoT+QLTUW8PeJq5eUOWdaaog8HKeLIbx6XH8laH5sSOaEzCkXWuVaJjaQSDcr6/VXw0XgphsM/9tddsTiCN9wzFuUAjso/UIdHpq8Hxay6ESTFQYGxOrc1ztcYoNL15GYTzF97lz8MRo9+zKF74mdy6h5g59iHSk8IQqM6IM7/PAUo0+2nXUxZabDFfhCgwwtRk6/sJvKuQb1T+hZDiSqcd1GRVs4V9nSUCAR3tdU1hNrKQ3dAi5y8IUerYPhRaGo+A5RidA4ofU8W5mp8EIFDqqMw1zfnEbRTIucYskCCSD35X4C8vT4CYozt7/onq7jdif+05PbIz1Rye4DPlNyB4FOV3P7gr6TkJkDPVXIThK+l5IoQ3OMTNdgmpUtA3iqXMAQ1eSPjnHkkZCnPSgStdD0bcQFKrNJkZANITdGZJGasaMUGTQG/I1OaQiMKNKj8fpbN3uj3zF9JfTr3RxXHa/KsLx68JD7jjIzRy5TjmDVaX2SJhJ2rqep9M+xIRSYzdX259XmnvNkHf6pzF+UDG6gNxRbz4+9cSl2lVBrkjubK8tvlceWG/u4w/7YCwdDPtYGVF9U32igoKaVvrn46YQjehzVrMicr9Oo+oE/tanxoQJI+bIN7s358c/nb1fubE8ZF067Ffjp8r5xr4u8xvxmI/yfw6+U7aFXnSBlIiuCF9f2L5GWxWPwVAAD//ysvXY8="
+	return "eNrNWd1P40YQf+evGPFSUMF3nK5Vj4dKHOEq1AoQ0FN1L2HjHSdbNl53dx3I/fWd2bUdO4lDuO+TOJzNeOY33zPLIdzj/Bhk7nYAvPIaj2F3cHGzSx8lutSqwiuTHwOdHboCU5WpFHCGuYdMoZYuIcr4dExPAIeQiynWLPmfnxf0eWxNWVQnbfr2O5kWY5eI0k+MVV54NcOGpuYzMkajyFvnHZy/t74AOGHcgS1E8HOVj8FPhKf/ECy6wuSSzxzaGVpQDkQONYI5ZMZ2GPJb0kyFygNkKB1KoA98/l+JjkEkOz2KWUxL64hiKGZCaTHSX0W9hwkSHAuVuFlAZufgyqIw1gcda/kV9g7XoFi0xxaqEDxlUX5VP6VaccBJEpR6F44qf3kDBQEpEUSHYdS4sYCeJ3Ddbw4TQAndqy4HBCFQ6VAKL75yTNYwKxVTkzsl0bpWyLquxxp4vQqkE0zvSc5QKsd+/0b+CrLaDutwcWqcC19ahJnQiixLksBkdTLZea863pZ5KjzK4Rp7fFl9TK7nAVGmrPPwy9ErGM09KVUBtVgQwQParksskmI5yjUqNHUCvZbDQpduaHLsA3pLMjDLKPA5JrwpDjWVX11XoT28/WuwD8yFoCJMDZmTUht10sHzzljARzEtNB4E3Pzez0dc4GA3MyYZCZuMjRb5ODF2nOxyXuy2D7r8GBbnQmAg0aOdqjwaLfKmuJ2SlTJrplxScTpCKalYpqaYV7brMIzMmHrifXH84kVRjrRKXZll6jEgaJFXmhyDmIqPZMnUJOX9GlOL3JFn3DA1Ze5XQkSbfLxdfLC2eUkqWIZOMWdKm4ZcNVY6TlFPzli0gjvqfkkl/C62O0K3Cq/qMwrdCjgz+pd8vmX4UsuyVsxrIOwHQemXhrJmQxsDFOmk1dkchm+jyZedEaEnGxF/J6MuADSGXWbaQwhTshAZgn/lxsOIu1+qS45KiQXGQcCsNkRCkqlxaZsCdSXSe/QjFH6ziRI+2ZTY7VGCWtnDRJGP/IRSb8kcUKBla7i1WVA9UBpMnwDEPtoEiL9nDUM2LnlgFdVaMBc3myGkWji3CUMgYBBckz8DyPnFGiBSqtjrv1nGNRI/NeUWkL9Tyi0APJFyK4TfKuUWgn+QlGsB+l4p14Lwo6Rc4r3ePlC9mnK40GhB4yHDoPyhbc3Vc+Yal3GcUYyllHidERfoNOPJSHlwE1NSZBIZzaepsBSLCXxAa3gKpdEMpjQ2LmbZYI34TndLiuEcRSXrc3KjLZY2iSeMEWBEmlE9ZtXaJ4GCYYdsyqJtqjc4yVyHXUy3GHcilwvnt3k2Xl7RgZalhCZ5Xv42hdQZh1JFtzZWdl/urmcuzXOH+fOMYsPHudZbqqQiFlrazh2H9M3ZaY8i+OiHNjWyN0PPHj0ZkNKgXjSAqVfL4EKttyeD92fXNz3KlbIYOvURn1XErzHsDMb+5ODvwRUUYq6NkMCMYI8yI2wk+706Fso+T+AHXiXieyENbbPPkKhg5uDes3+uzq/PqvU5AKlStA8Kb7J9Mi8W1w5A+y6ts2RkewDCUWed4CM4bym+ezgXnFodhZ7W8i/Mx35SR37QqGLTVmmjbX1aDO8RC9pfZ88z8bnU0bam9FQXSGuv3JJ9b0+voOHexjRVWqsnbB2X8KErRzn6JBP0zrwPzImUFN+0rQWqjkVO4y5/E9hUGNYGvipmr7dBIqKsFWOp4il0joFUk8kGbFtgiL/65FVsIxELPD0fXHPBF73KH715lbxM6OfFq62MEIvssLBI2+1Qh0B8VvhcNFMc36LwrawgyCPlm0ouGqv5Oqgihu0sRHv6l8G3DlN4DgMviKIgwXRkDtpQe+8fA1Rj7hVWiPsQcIBUF1GRfttSUnFfubBa4d5c0D2HO1YNZYjWGpss9Z6nDds0JEYQeNDU9e7y8PRycAZ71+9O4bc3R6/310bpr1tB2jRBhyJdORNXkKwVGvsvvDXj0m0l39PnDaWg3qYyi3hIQx2tUvQCCO/DKMZDfR+6Fem0tVAVTcz9510f3tqSRsusfffJocwfK+0v/4S9weU+J8MB9dJ4BUh9Jn69dHMYl7Gq0PXuhRX2L3k13daDB0HN4V1rclILggFPlnsng0qdevDkkbnDjobt6mKX3CC0ru+3n6PeZ19c1zpVjmjdND9QwtZcYe90wNrs9yPhOvvp4lsVjzJ25Rqu2b3h+vrm/I/aShvQUDMq3XZufb/QOb5GxJYmCtncRMBJ0D6sA8vJ02EVC85iVwgjcQKXeagKd47/eIF3B3BHe3PzPOLUv4OlP6URSX1vTAFyl8CFiWnzQGHW4Q9jAuuoAVPQSGp0vV04itzZ+R+qm7Nh"
 }
@@ -95,6 +95,51 @@ func dnsHashToString(h uint8) string {
 	return s
 }
 
+// edns0ExtendedError is the EDNS option code of Extended DNS Errors [RFC8914].
+// It is decoded by miekg/dns as an EDNS0_LOCAL option.
+const edns0ExtendedError = 15
+
+// extendedErrors maps the INFO-CODE of Extended DNS Errors [RFC8914] to
+// their names.
+var extendedErrors = map[uint16]string{
+	0:  "Other",
+	1:  "Unsupported DNSKEY Algorithm",
+	2:  "Unsupported DS Digest Type",
+	3:  "Stale Answer",
+	4:  "Forged Answer",
+	5:  "DNSSEC Indeterminate",
+	6:  "DNSSEC Bogus",
+	7:  "Signature Expired",
+	8:  "Signature Not Yet Valid",
+	9:  "DNSKEY Missing",
+	10: "RRSIGs Missing",
+	11: "No Zone Key Bit Set",
+	12: "NSEC Missing",
+	13: "Cached Error",
+	14: "Not Ready",
+	15: "Blocked",
+	16: "Censored",
+	17: "Filtered",
+	18: "Prohibited",
+	19: "Stale NXDOMAIN Answer",
+	20: "Not Authoritative",
+	21: "Not Supported",
+	22: "No Reachable Authority",
+	23: "Network Error",
+	24: "Invalid Data",
+}
+
+// dnsExtendedErrorToString converts an extended error code to a string. If
+// the code's string representation is unknown then the numeric value will be
+// returned as a string.
+func dnsExtendedErrorToString(code uint16) string {
+	s, exists := extendedErrors[code]
+	if !exists {
+		return strconv.Itoa(int(code))
+	}
+	return s
+}
+
 // dnsTypeBitsMapToString converts a map of type bits to a string. If the type's
 // string representation is unknown then the numeric value will be returned
 // as a string.
@@ -36,6 +36,7 @@ import (
 	"github.com/elastic/beats/v7/packetbeat/pb"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/websocket"
 	"github.com/elastic/ecs/code/go/ecs"
 )
 
@@ -74,6 +75,13 @@ type httpConnectionData struct {
 	streams   [2]*stream
 	requests  messageList
 	responses messageList
+
+	// the last request asked for a tunnel
+	tunnelRequested bool
+	// the last request asked for a WebSocket upgrade
+	websocketRequest *message
+	// set once the connection stops being HTTP
+	upgrade *protos.ProtocolUpgrade
 }
 
 type messageList struct {
@@ -250,6 +258,57 @@ func (http *httpPlugin) ConnectionTimeout() time.Duration {
 	return http.transactionTimeout
 }
 
+// DetectTCP recognizes the request or status line of HTTP/1.x.
+func (http *httpPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectHTTP(data)
+}
+
+// maxDetectedMethodLen limits the length of the request methods recognized
+// by detectHTTP.
+const maxDetectedMethodLen = 16
+
+var constHTTP1Version = []byte("HTTP/1.")
+
+func detectHTTP(data []byte) protos.Detection {
+	line, complete := data, false
+	if i := bytes.Index(data, constCRLF); i >= 0 {
+		line, complete = data[:i], true
+	}
+
+	// status line
+	if len(line) < len(constHTTPVersion) && !complete {
+		if bytes.HasPrefix(constHTTPVersion, line) {
+			return protos.DetectionNeedMore
+		}
+	} else if bytes.HasPrefix(line, constHTTPVersion) {
+		return protos.DetectionMatch
+	}
+
+	// request line: the method is a token of upper case letters, the line
+	// ends with the version
+	sp := bytes.IndexByte(line, ' ')
+	method := line
+	if sp >= 0 {
+		method = line[:sp]
+	}
+	if sp == 0 || len(method) > maxDetectedMethodLen {
+		return protos.DetectionMismatch
+	}
+	for _, c := range method {
+		if (c < 'A' || c > 'Z') && c != '-' {
+			return protos.DetectionMismatch
+		}
+	}
+	if !complete {
+		return protos.DetectionNeedMore
+	}
+	// the HTTP/2 connection preface looks like a request line
+	if sp < 0 || !bytes.HasPrefix(line[bytes.LastIndexByte(line, ' ')+1:], constHTTP1Version) {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
 // Parse function is used to process TCP payloads.
 func (http *httpPlugin) Parse(
 	pkt *protos.Packet,
@@ -264,6 +323,9 @@ func (http *httpPlugin) Parse(
 	if conn == nil {
 		return nil
 	}
+	if conn.upgrade != nil {
+		return conn.upgrade
+	}
 	return conn
 }
 
@@ -330,8 +392,9 @@ func (http *httpPlugin) doParse(
 
 	for len(st.data) > 0 || extraMsgSize > 0 {
 		if st.message == nil {
-			st.message = &message{ts: pkt.Ts}
+			st.message = &message{ts: pkt.Ts, capture: pkt.Capture}
 		}
+		st.message.tunnelRequested = conn.tunnelRequested
 
 		parser := newParser(&http.parserConfig)
 		ok, complete := parser.parse(st, extraMsgSize)
@@ -349,20 +412,91 @@ func (http *httpPlugin) doParse(
 		}
 
 		// all ok, ship it
+		msg := st.message
 		http.messageComplete(conn, tcptuple, dir, st)
 
 		// and reset stream for next message
 		st.PrepareForNewMessage()
+
+		if upgrade := conn.switchProtocols(msg); upgrade != nil {
+			conn.upgradeTo(upgrade)
+			break
+		}
 	}
 
 	return conn
 }
 
+// switchProtocols returns the protocol of the rest of the connection if the
+// message ends the HTTP exchanges, like the responses establishing a tunnel
+// or switching to the protocol asked for by the Upgrade header. Tunneled
+// protocols are detected from their data.
+func (conn *httpConnectionData) switchProtocols(m *message) *protos.ProtocolUpgrade {
+	if m.isRequest {
+		conn.tunnelRequested = bytes.Equal(m.method, constConnect)
+		conn.websocketRequest = nil
+		if bytes.EqualFold(m.upgrade, constWebsocket) {
+			conn.websocketRequest = m
+		}
+		return nil
+	}
+
+	switch {
+	case conn.tunnelRequested && 200 <= m.statusCode && m.statusCode < 300:
+		return &protos.ProtocolUpgrade{Protocol: protos.UnknownProtocol}
+	case m.statusCode == 101 && bytes.EqualFold(m.upgrade, constWebsocket):
+		return &protos.ProtocolUpgrade{
+			Protocol: protos.Lookup("websocket"),
+			Data:     newWebsocketHandshake(conn.websocketRequest, m),
+		}
+	case m.statusCode == 101:
+		return &protos.ProtocolUpgrade{Protocol: protos.UnknownProtocol}
+	}
+	if m.statusCode >= 200 {
+		conn.tunnelRequested = false
+		conn.websocketRequest = nil
+	}
+	return nil
+}
+
+// newWebsocketHandshake returns the details of the handshake passed to the
+// WebSocket analyzer. The request is nil if it was not seen.
+func newWebsocketHandshake(requ, resp *message) *websocket.Handshake {
+	hs := &websocket.Handshake{
+		Ts:          resp.ts,
+		Capture:     resp.capture,
+		ClientDir:   1 - resp.direction,
+		Subprotocol: string(resp.wsProtocol),
+	}
+	if len(resp.wsExtensions) > 0 {
+		hs.Extensions = parseCommaSeparatedList(resp.wsExtensions)
+	}
+	if requ != nil {
+		hs.Ts = requ.ts
+		hs.Capture = requ.capture
+		hs.URI = string(requ.requestURI)
+		hs.Host = string(requ.host)
+		hs.Origin = string(requ.origin)
+	}
+	return hs
+}
+
+// upgradeTo hands the connection over to another protocol, with the data not
+// parsed yet.
+func (conn *httpConnectionData) upgradeTo(upgrade *protos.ProtocolUpgrade) {
+	for dir, st := range conn.streams {
+		if st != nil {
+			upgrade.Pending[dir] = st.data
+		}
+	}
+	conn.upgrade = upgrade
+}
+
 func newStream(pkt *protos.Packet, tcptuple *common.TCPTuple) *stream {
 	return &stream{
 		tcptuple: tcptuple,
 		data:     pkt.Payload,
-		message:  &message{ts: pkt.Ts},
+		message:  &message{ts: pkt.Ts, capture: pkt.Capture},
 	}
 }
 
@@ -543,6 +677,12 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 	pbf.AddIP(dst.IP)
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = "http"
+	if requ != nil {
+		pbf.AddCapture(&requ.capture)
+	}
+	if resp != nil {
+		pbf.AddCapture(&resp.capture)
+	}
 
 	fields := evt.Fields
 	fields["type"] = pbf.Network.Protocol
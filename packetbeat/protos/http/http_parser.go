@@ -28,18 +28,29 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/streambuf"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
 )
 
 // Http Message
 type message struct {
 	ts               time.Time
+	capture          pb.Capture
 	hasContentLength bool
 	headerOffset     int
 	version          version
 	connection       common.NetString
+	upgrade          common.NetString
 	chunkedLength    int
 
+	// WebSocket handshake headers
+	origin       common.NetString
+	wsProtocol   common.NetString
+	wsExtensions common.NetString
+
+	// the message may be the response to a CONNECT request
+	tunnelRequested bool
+
 	isRequest    bool
 	tcpTuple     common.TCPTuple
 	cmdlineTuple *common.ProcessTuple
@@ -114,15 +125,21 @@ var (
 	constClose       = []byte("close")
 	constKeepAlive   = []byte("keep-alive")
 	constHTTPVersion = []byte("HTTP/")
+	constConnect     = []byte("CONNECT")
+	constWebsocket   = []byte("websocket")
 
 	nameContentLength    = []byte("content-length")
 	nameContentType      = []byte("content-type")
 	nameTransferEncoding = []byte("transfer-encoding")
 	nameContentEncoding  = []byte("content-encoding")
 	nameConnection       = []byte("connection")
+	nameUpgrade          = []byte("upgrade")
 	nameHost             = []byte("host")
 	nameReferer          = []byte("referer")
 	nameUserAgent        = []byte("user-agent")
+	nameOrigin           = []byte("origin")
+	nameWSProtocol       = []byte("sec-websocket-protocol")
+	nameWSExtensions     = []byte("sec-websocket-extensions")
 )
 
 func newParser(config *parserConfig) *parser {
@@ -288,9 +305,11 @@ func (parser *parser) parseHeaders(s *stream, m *message) (cont, ok, complete bo
 		s.data = s.data[m.size:]
 		s.parseOffset = 0
 
-		if !m.isRequest && ((100 <= m.statusCode && m.statusCode < 200) || m.statusCode == 204 || m.statusCode == 304) {
+		if !m.isRequest && ((100 <= m.statusCode && m.statusCode < 200) || m.statusCode == 204 || m.statusCode == 304 ||
+			(m.tunnelRequested && 200 <= m.statusCode && m.statusCode < 300)) {
 			//response with a 1xx, 204 , or 304 status  code is always terminated
-			// by the first empty line after the  header fields
+			// by the first empty line after the  header fields, like the
+			// 2xx responses establishing a tunnel
 			if isDebug {
 				debugf("Terminate response, status code %d", m.statusCode)
 			}
@@ -402,6 +421,8 @@ func (parser *parser) parseHeader(m *message, data []byte) (bool, bool, int) {
 				m.encodings = append(encodings, m.encodings...)
 			} else if bytes.Equal(headerName, nameConnection) {
 				m.connection = headerVal
+			} else if bytes.Equal(headerName, nameUpgrade) {
+				m.upgrade = headerVal
 			} else if len(config.realIPHeader) > 0 && bytes.Equal(headerName, []byte(config.realIPHeader)) {
 				if ips := bytes.SplitN(headerVal, []byte{','}, 2); len(ips) > 0 {
 					m.realIP = trim(ips[0])
@@ -412,6 +433,12 @@ func (parser *parser) parseHeader(m *message, data []byte) (bool, bool, int) {
 				m.referer = headerVal
 			} else if bytes.Equal(headerName, nameUserAgent) {
 				m.userAgent = headerVal
+			} else if bytes.Equal(headerName, nameOrigin) {
+				m.origin = headerVal
+			} else if bytes.Equal(headerName, nameWSProtocol) {
+				m.wsProtocol = headerVal
+			} else if bytes.Equal(headerName, nameWSExtensions) {
+				m.wsExtensions = headerVal
 			}
 
 			if config.sendHeaders {
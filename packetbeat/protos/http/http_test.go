@@ -35,6 +35,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/websocket"
 	"github.com/elastic/beats/v7/packetbeat/publish"
 )
 
@@ -1315,6 +1316,71 @@ func TestHttpParser_sendRequestResponse(t *testing.T) {
 	assert.Equal(t, resp, contents)
 }
 
+func TestHttpParser_upgrade(t *testing.T) {
+	for name, test := range map[string]struct {
+		req, resp string
+		upgraded  bool
+		protocol  protos.Protocol
+		pending   string
+		handshake *websocket.Handshake
+	}{
+		"tunnel": {
+			req:      "CONNECT example.org:443 HTTP/1.1\r\nHost: example.org:443\r\n\r\n",
+			resp:     "HTTP/1.0 200 Connection established\r\n\r\n\x16\x03\x03",
+			upgraded: true,
+			protocol: protos.UnknownProtocol,
+			pending:  "\x16\x03\x03",
+		},
+		"tunnel refused": {
+			req:  "CONNECT example.org:443 HTTP/1.1\r\nHost: example.org:443\r\n\r\n",
+			resp: "HTTP/1.1 407 Proxy Authentication Required\r\nContent-Length: 0\r\n\r\n",
+		},
+		"websocket": {
+			req: "GET /chat HTTP/1.1\r\nHost: example.org\r\n" +
+				"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+				"Origin: https://example.org\r\nSec-WebSocket-Protocol: chat, superchat\r\n\r\n",
+			resp: "HTTP/1.1 101 Switching Protocols\r\n" +
+				"Upgrade: WebSocket\r\nConnection: Upgrade\r\n" +
+				"Sec-WebSocket-Protocol: chat\r\n" +
+				"Sec-WebSocket-Extensions: permessage-deflate; client_max_window_bits\r\n\r\n\x81\x05hello",
+			upgraded: true,
+			protocol: protos.Lookup("websocket"),
+			pending:  "\x81\x05hello",
+			handshake: &websocket.Handshake{
+				ClientDir:   0,
+				URI:         "/chat",
+				Host:        "example.org",
+				Origin:      "https://example.org",
+				Subprotocol: "chat",
+				Extensions:  []string{"permessage-deflate; client_max_window_bits"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var store eventStore
+			http := httpModForTests(&store)
+			tcptuple := testCreateTCPTuple()
+
+			private := http.Parse(&protos.Packet{Payload: []byte(test.req)}, tcptuple, 0, nil)
+			private = http.Parse(&protos.Packet{Payload: []byte(test.resp)}, tcptuple, 1, private)
+			assert.Len(t, store.events, 1)
+
+			upgrade, upgraded := private.(*protos.ProtocolUpgrade)
+			if !assert.Equal(t, test.upgraded, upgraded) || !upgraded {
+				return
+			}
+			assert.Equal(t, test.protocol, upgrade.Protocol)
+			assert.Empty(t, upgrade.Pending[0])
+			assert.Equal(t, test.pending, string(upgrade.Pending[1]))
+			if test.handshake != nil {
+				assert.Equal(t, test.handshake, upgrade.Data)
+			} else {
+				assert.Nil(t, upgrade.Data)
+			}
+		})
+	}
+}
+
 func testCreateTCPTuple() *common.TCPTuple {
 	t := &common.TCPTuple{
 		IPLength: 4,
@@ -2045,3 +2111,21 @@ func BenchmarkHttpLargeResponseBody(b *testing.B) {
 	}
 	b.ReportAllocs()
 }
+
+func TestDetectHTTP(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"GET /index.html HTTP/1.1\r\nHost: example.com\r\n", protos.DetectionMatch},
+		{"HTTP/1.1 200 OK\r\n", protos.DetectionMatch},
+		{"GE", protos.DetectionNeedMore},
+		{"POST /api/v1/ite", protos.DetectionNeedMore},
+		{"get / HTTP/1.1\r\n", protos.DetectionMismatch},
+		{"GET /\r\n", protos.DetectionMismatch},
+		{"SSH-2.0-OpenSSH_8.2\r\n", protos.DetectionMismatch},
+		{"PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectHTTP([]byte(test.data)), "%q", test.data)
+	}
+}
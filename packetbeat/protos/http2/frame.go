@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Length of the header preceding the payload of every frame.
+const frameHeaderLen = 9
+
+// clientPreface is sent by the client before its first frame.
+const clientPreface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
+type frameType uint8
+
+const (
+	frameData         frameType = 0x0
+	frameHeaders      frameType = 0x1
+	framePriority     frameType = 0x2
+	frameRSTStream    frameType = 0x3
+	frameSettings     frameType = 0x4
+	framePushPromise  frameType = 0x5
+	framePing         frameType = 0x6
+	frameGoAway       frameType = 0x7
+	frameWindowUpdate frameType = 0x8
+	frameContinuation frameType = 0x9
+)
+
+// Frame flags. The meaning of a flag depends on the frame type.
+const (
+	flagEndStream  = 0x1
+	flagAck        = 0x1
+	flagEndHeaders = 0x4
+	flagPadded     = 0x8
+	flagPriority   = 0x20
+)
+
+// Identifiers of the SETTINGS parameters.
+const (
+	settingHeaderTableSize = 0x1
+)
+
+// Length of a parameter in a SETTINGS frame.
+const settingLen = 6
+
+// Size of the HPACK dynamic table until the peer announces another one.
+const defaultHeaderTableSize = 4096
+
+var (
+	errFramePadding  = errors.New("invalid frame padding")
+	errFrameTooShort = errors.New("frame payload too short")
+)
+
+type frameHeader struct {
+	length   uint32
+	typ      frameType
+	flags    uint8
+	streamID uint32
+}
+
+func readFrameHeader(data []byte) frameHeader {
+	return frameHeader{
+		length:   uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]),
+		typ:      frameType(data[3]),
+		flags:    data[4],
+		streamID: binary.BigEndian.Uint32(data[5:]) & 0x7fffffff,
+	}
+}
+
+func (h frameHeader) has(flag uint8) bool {
+	return h.flags&flag != 0
+}
+
+func (h frameHeader) String() string {
+	return fmt.Sprintf("type=%d flags=0x%x stream=%d length=%d",
+		h.typ, h.flags, h.streamID, h.length)
+}
+
+// unpad removes the padding of DATA, HEADERS and PUSH_PROMISE frames.
+func unpad(h frameHeader, payload []byte) ([]byte, error) {
+	if !h.has(flagPadded) {
+		return payload, nil
+	}
+	if len(payload) == 0 {
+		return nil, errFramePadding
+	}
+	padding := int(payload[0])
+	payload = payload[1:]
+	if padding > len(payload) {
+		return nil, errFramePadding
+	}
+	return payload[:len(payload)-padding], nil
+}
+
+// headerBlockFragment returns the HPACK encoded header fields of a HEADERS
+// frame.
+func headerBlockFragment(h frameHeader, payload []byte) ([]byte, error) {
+	payload, err := unpad(h, payload)
+	if err != nil {
+		return nil, err
+	}
+	if h.has(flagPriority) {
+		// skip stream dependency and weight
+		if len(payload) < 5 {
+			return nil, errFrameTooShort
+		}
+		payload = payload[5:]
+	}
+	return payload, nil
+}
+
+// pushPromise returns the promised stream and the HPACK encoded request
+// header fields of a PUSH_PROMISE frame.
+func pushPromise(h frameHeader, payload []byte) (uint32, []byte, error) {
+	payload, err := unpad(h, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(payload) < 4 {
+		return 0, nil, errFrameTooShort
+	}
+	return binary.BigEndian.Uint32(payload) & 0x7fffffff, payload[4:], nil
+}
+
+// errorCodeNames maps the error codes of RST_STREAM and GOAWAY frames to
+// their names.
+var errorCodeNames = []string{
+	"NO_ERROR",
+	"PROTOCOL_ERROR",
+	"INTERNAL_ERROR",
+	"FLOW_CONTROL_ERROR",
+	"SETTINGS_TIMEOUT",
+	"STREAM_CLOSED",
+	"FRAME_SIZE_ERROR",
+	"REFUSED_STREAM",
+	"CANCEL",
+	"COMPRESSION_ERROR",
+	"CONNECT_ERROR",
+	"ENHANCE_YOUR_CALM",
+	"INADEQUATE_SECURITY",
+	"HTTP_1_1_REQUIRED",
+}
+
+func errorCodeName(code uint32) string {
+	if int(code) < len(errorCodeNames) {
+		return errorCodeNames[code]
+	}
+	return fmt.Sprintf("0x%x", code)
+}
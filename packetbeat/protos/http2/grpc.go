@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http2
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Length of the prefix of a gRPC message, holding the compression flag and
+// the message length.
+const grpcPrefixLen = 5
+
+func isGRPC(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/grpc")
+}
+
+// grpcCounter counts the length-prefixed messages in the DATA of a gRPC
+// stream direction. Messages can span frames and frames can hold several
+// messages.
+type grpcCounter struct {
+	messages  int
+	prefix    int    // bytes of the current message prefix seen
+	length    uint32 // message length read from the prefix
+	remaining uint32 // bytes of the current message not seen yet
+}
+
+func (c *grpcCounter) add(data []byte) {
+	for len(data) > 0 {
+		if c.remaining > 0 {
+			n := c.remaining
+			if uint32(len(data)) < n {
+				n = uint32(len(data))
+			}
+			c.remaining -= n
+			data = data[n:]
+			continue
+		}
+
+		if c.prefix == 0 {
+			c.messages++
+		} else {
+			c.length = c.length<<8 | uint32(data[0])
+		}
+		c.prefix++
+		data = data[1:]
+		if c.prefix == grpcPrefixLen {
+			c.remaining = c.length
+			c.prefix, c.length = 0, 0
+		}
+	}
+}
+
+// splitGRPCPath returns the service and method of a gRPC request path of the
+// form "/package.Service/Method".
+func splitGRPCPath(path string) (service, method string, ok bool) {
+	if !strings.HasPrefix(path, "/") {
+		return "", "", false
+	}
+	path = path[1:]
+	i := strings.LastIndexByte(path, '/')
+	if i <= 0 || i == len(path)-1 {
+		return "", "", false
+	}
+	return path[:i], path[i+1:], true
+}
+
+// decodeGRPCMessage removes the percent-encoding of a grpc-message trailer.
+func decodeGRPCMessage(msg string) string {
+	if decoded, err := url.PathUnescape(msg); err == nil {
+		return decoded
+	}
+	return msg
+}
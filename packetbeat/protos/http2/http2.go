@@ -0,0 +1,899 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2/hpack"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/beats/v7/packetbeat/protos/http"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/ecs/code/go/ecs"
+)
+
+// stream holds the state of a direction of an HTTP/2 connection.
+type stream struct {
+	applayer.Stream
+	decoder *hpack.Decoder
+	started bool
+
+	// header block waiting for CONTINUATION frames
+	continued *headerBlock
+}
+
+// headerBlock collects the fragments of the header block of a HEADERS or
+// PUSH_PROMISE frame.
+type headerBlock struct {
+	streamID  uint32
+	promised  uint32 // stream promised by a PUSH_PROMISE, 0 for HEADERS
+	endStream bool
+	size      int
+	fragments []byte
+}
+
+type connection struct {
+	streams      [2]*stream
+	transactions map[uint32]*transaction
+}
+
+// transaction holds the messages exchanged on an HTTP/2 stream.
+type transaction struct {
+	id        uint32
+	messages  [2]*message // indexed by TCP direction
+	reset     bool
+	resetCode uint32
+}
+
+type message struct {
+	ts              time.Time
+	capture         pb.Capture
+	tcpTuple        common.TCPTuple
+	cmdlineTuple    *common.ProcessTuple
+	direction       uint8
+	isRequest       bool
+	headersReceived bool
+	ended           bool
+	notes           []string
+
+	// pseudo-header fields
+	method, scheme, authority, path string
+	statusCode                      int
+
+	contentType string
+	fields      []hpack.HeaderField
+	trailers    []hpack.HeaderField
+
+	// size of the frames of the message, and of its DATA without padding
+	size     int
+	bodySize int
+
+	grpc          grpcCounter
+	grpcStatus    int
+	hasGRPCStatus bool
+	grpcMessage   string
+}
+
+// HTTP/2 protocol plugin
+type http2Plugin struct {
+	// config
+	ports              []int
+	sendRequest        bool
+	sendResponse       bool
+	sendAllHeaders     bool
+	headersWhitelist   map[string]bool
+	maxMessageSize     int
+	maxStreams         int
+	transactionTimeout time.Duration
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("http2")
+	isDebug = false
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "http2.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "http2.unmatched_responses")
+	untrackedStreams   = monitoring.NewInt(nil, "http2.untracked_streams")
+)
+
+var (
+	errExpectedContinuation   = errors.New("expected CONTINUATION frame")
+	errUnexpectedContinuation = errors.New("unexpected CONTINUATION frame")
+	errFrameSize              = errors.New("invalid frame size")
+	errStreamZero             = errors.New("stream frame sent on the connection")
+	errHeaderBlockTooLarge    = errors.New("header block exceeds max_message_size")
+)
+
+func init() {
+	protos.Register("http2", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &http2Plugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (h2 *http2Plugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *http2Config) error {
+	h2.setFromConfig(config)
+
+	h2.results = results
+	h2.watcher = watcher
+	isDebug = logp.IsDebug("http2")
+
+	return nil
+}
+
+func (h2 *http2Plugin) setFromConfig(config *http2Config) {
+	h2.ports = config.Ports
+	h2.sendRequest = config.SendRequest
+	h2.sendResponse = config.SendResponse
+	h2.sendAllHeaders = config.SendAllHeaders
+	h2.headersWhitelist = nil
+	if !config.SendAllHeaders && len(config.SendHeaders) > 0 {
+		h2.headersWhitelist = map[string]bool{}
+		for _, hdr := range config.SendHeaders {
+			h2.headersWhitelist[strings.ToLower(hdr)] = true
+		}
+	}
+	h2.maxMessageSize = config.MaxMessageSize
+	h2.maxStreams = config.MaxStreams
+	h2.transactionTimeout = config.TransactionTimeout
+}
+
+func (h2 *http2Plugin) GetPorts() []int {
+	return h2.ports
+}
+
+func (h2 *http2Plugin) ConnectionTimeout() time.Duration {
+	return h2.transactionTimeout
+}
+
+// DetectTCP recognizes the connection preface sent by HTTP/2 clients with
+// prior knowledge of the server's support.
+func (h2 *http2Plugin) DetectTCP(data []byte) protos.Detection {
+	return detectHTTP2(data)
+}
+
+func detectHTTP2(data []byte) protos.Detection {
+	n := len(data)
+	if n > len(clientPreface) {
+		n = len(clientPreface)
+	}
+	if string(data[:n]) != clientPreface[:n] {
+		return protos.DetectionMismatch
+	}
+	if n < len(clientPreface) {
+		return protos.DetectionNeedMore
+	}
+	return protos.DetectionMatch
+}
+
+func (h2 *http2Plugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseHttp2 exception")
+
+	conn := h2.ensureConnection(private)
+	conn = h2.doParse(conn, pkt, tcptuple, dir)
+	if conn == nil {
+		return nil
+	}
+	return conn
+}
+
+func (h2 *http2Plugin) newConnectionData() *connection {
+	conn := &connection{
+		transactions: map[uint32]*transaction{},
+	}
+	for i := range conn.streams {
+		st := &stream{
+			decoder: hpack.NewDecoder(defaultHeaderTableSize, nil),
+		}
+		st.decoder.SetMaxStringLength(h2.maxMessageSize)
+		st.Stream.Init(h2.maxMessageSize)
+		conn.streams[i] = st
+	}
+	return conn
+}
+
+func (h2 *http2Plugin) ensureConnection(private protos.ProtocolData) *connection {
+	if private == nil {
+		return h2.newConnectionData()
+	}
+
+	priv, ok := private.(*connection)
+	if !ok {
+		logp.Warn("http2 connection data type error, create new one")
+		return h2.newConnectionData()
+	}
+	if priv == nil {
+		logp.Warn("Unexpected: http2 connection data not set, create new one")
+		return h2.newConnectionData()
+	}
+
+	return priv
+}
+
+func (h2 *http2Plugin) doParse(
+	conn *connection,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) *connection {
+	st := conn.streams[dir]
+	if err := st.Append(pkt.Payload); err != nil {
+		if isDebug {
+			debugf("%v, dropping TCP stream", err)
+		}
+		return nil
+	}
+
+	if !st.started {
+		switch detectHTTP2(st.Buf.Bytes()) {
+		case protos.DetectionNeedMore:
+			// wait for the rest of the preface
+			return conn
+		case protos.DetectionMatch:
+			st.Buf.Advance(len(clientPreface))
+		}
+		st.started = true
+	}
+
+	for {
+		data := st.Buf.Bytes()
+		if len(data) < frameHeaderLen {
+			break
+		}
+		hdr := readFrameHeader(data)
+		n := frameHeaderLen + int(hdr.length)
+		if len(data) < n {
+			break
+		}
+
+		if err := h2.handleFrame(conn, hdr, data[frameHeaderLen:n], pkt, tcptuple, dir); err != nil {
+			if isDebug {
+				debugf("%v in frame (%v), dropping connection state", err, hdr)
+			}
+			return nil
+		}
+		st.Buf.Advance(n)
+	}
+	st.Reset()
+
+	return conn
+}
+
+func (h2 *http2Plugin) handleFrame(
+	conn *connection,
+	hdr frameHeader,
+	payload []byte,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) error {
+	st := conn.streams[dir]
+	if st.continued != nil && (hdr.typ != frameContinuation || hdr.streamID != st.continued.streamID) {
+		return errExpectedContinuation
+	}
+
+	switch hdr.typ {
+	case frameData:
+		data, err := unpad(hdr, payload)
+		if err != nil {
+			return err
+		}
+		if t := conn.transactions[hdr.streamID]; t != nil && t.messages[dir] != nil {
+			m := t.messages[dir]
+			m.size += frameHeaderLen + len(payload)
+			m.bodySize += len(data)
+			if isGRPC(m.contentType) {
+				m.grpc.add(data)
+			}
+		}
+		if hdr.has(flagEndStream) {
+			h2.endStream(conn, hdr.streamID, dir)
+		}
+
+	case frameHeaders:
+		if hdr.streamID == 0 {
+			return errStreamZero
+		}
+		fragment, err := headerBlockFragment(hdr, payload)
+		if err != nil {
+			return err
+		}
+		block := &headerBlock{
+			streamID:  hdr.streamID,
+			endStream: hdr.has(flagEndStream),
+			size:      frameHeaderLen + len(payload),
+		}
+		return h2.addHeaderFragment(conn, block, fragment, hdr.has(flagEndHeaders), pkt, tcptuple, dir)
+
+	case framePushPromise:
+		if hdr.streamID == 0 {
+			return errStreamZero
+		}
+		promised, fragment, err := pushPromise(hdr, payload)
+		if err != nil {
+			return err
+		}
+		block := &headerBlock{
+			streamID: hdr.streamID,
+			promised: promised,
+			size:     frameHeaderLen + len(payload),
+		}
+		return h2.addHeaderFragment(conn, block, fragment, hdr.has(flagEndHeaders), pkt, tcptuple, dir)
+
+	case frameContinuation:
+		block := st.continued
+		if block == nil {
+			return errUnexpectedContinuation
+		}
+		st.continued = nil
+		block.size += frameHeaderLen + len(payload)
+		return h2.addHeaderFragment(conn, block, payload, hdr.has(flagEndHeaders), pkt, tcptuple, dir)
+
+	case frameRSTStream:
+		if len(payload) != 4 {
+			return errFrameSize
+		}
+		h2.resetStream(conn, hdr.streamID, binary.BigEndian.Uint32(payload))
+
+	case frameSettings:
+		if len(payload)%settingLen != 0 {
+			return errFrameSize
+		}
+		if !hdr.has(flagAck) {
+			conn.applySettings(payload, dir)
+		}
+	}
+
+	// PRIORITY, PING, GOAWAY, WINDOW_UPDATE and unknown frames carry no
+	// transaction data.
+	return nil
+}
+
+// applySettings applies the SETTINGS sent in a direction to the peer's
+// direction.
+func (conn *connection) applySettings(payload []byte, dir uint8) {
+	for ; len(payload) >= settingLen; payload = payload[settingLen:] {
+		id := binary.BigEndian.Uint16(payload)
+		value := binary.BigEndian.Uint32(payload[2:])
+		if id == settingHeaderTableSize {
+			// bounds the size updates of the peer's dynamic table
+			conn.streams[1-dir].decoder.SetMaxDynamicTableSize(value)
+		}
+	}
+}
+
+// addHeaderFragment decodes the header block once its last fragment is
+// seen. All header blocks are decoded, whether their stream is tracked or
+// not, to keep the HPACK dynamic table in sync with the peer's. A header
+// block larger than max_message_size can't be skipped without losing the
+// sync, so the connection state is dropped.
+func (h2 *http2Plugin) addHeaderFragment(
+	conn *connection,
+	block *headerBlock,
+	fragment []byte,
+	endHeaders bool,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) error {
+	st := conn.streams[dir]
+	if len(block.fragments)+len(fragment) > h2.maxMessageSize {
+		return errHeaderBlockTooLarge
+	}
+	block.fragments = append(block.fragments, fragment...)
+	if !endHeaders {
+		st.continued = block
+		return nil
+	}
+
+	fields, err := st.decoder.DecodeFull(block.fragments)
+	if err != nil {
+		return err
+	}
+
+	if block.promised != 0 {
+		// The server sends the request of a pushed response itself. Report
+		// it as sent by the client in the promised stream.
+		t := h2.getTransaction(conn, block.promised)
+		if t == nil {
+			return nil
+		}
+		m := h2.newMessage(pkt, tcptuple, 1-dir)
+		m.size = block.size
+		m.ended = true
+		m.notes = append(m.notes, "Server push")
+		m.addHeaderBlock(fields)
+		t.messages[1-dir] = m
+		return nil
+	}
+
+	t := h2.getTransaction(conn, block.streamID)
+	if t == nil {
+		return nil
+	}
+	m := t.messages[dir]
+	if m == nil {
+		m = h2.newMessage(pkt, tcptuple, dir)
+		t.messages[dir] = m
+	}
+	m.size += block.size
+	m.addHeaderBlock(fields)
+	if block.endStream {
+		h2.endStream(conn, block.streamID, dir)
+	}
+	return nil
+}
+
+// getTransaction returns the transaction of a stream, which is created if
+// the limit of concurrent streams is not reached.
+func (h2 *http2Plugin) getTransaction(conn *connection, id uint32) *transaction {
+	if t := conn.transactions[id]; t != nil {
+		return t
+	}
+	if len(conn.transactions) >= h2.maxStreams {
+		if isDebug {
+			debugf("too many open streams, ignoring stream %d", id)
+		}
+		untrackedStreams.Add(1)
+		return nil
+	}
+	t := &transaction{id: id}
+	conn.transactions[id] = t
+	return t
+}
+
+func (h2 *http2Plugin) newMessage(pkt *protos.Packet, tcptuple *common.TCPTuple, dir uint8) *message {
+	return &message{
+		ts:           pkt.Ts,
+		capture:      pkt.Capture,
+		tcpTuple:     *tcptuple,
+		cmdlineTuple: h2.watcher.FindProcessesTupleTCP(tcptuple.IPPort()),
+		direction:    dir,
+	}
+}
+
+// addHeaderBlock adds the fields of a decoded header block. The first block
+// holds the header fields, unless it is an interim response, later blocks
+// hold the trailers.
+func (m *message) addHeaderBlock(fields []hpack.HeaderField) {
+	if m.headersReceived && !m.isInterim() {
+		m.trailers = append(m.trailers, fields...)
+	} else {
+		m.headersReceived = true
+		m.fields = fields
+	}
+
+	for _, f := range fields {
+		switch f.Name {
+		case ":method":
+			m.method = f.Value
+			m.isRequest = true
+		case ":scheme":
+			m.scheme = f.Value
+		case ":authority":
+			m.authority = f.Value
+		case ":path":
+			m.path = f.Value
+		case ":status":
+			m.statusCode, _ = strconv.Atoi(f.Value)
+		case "content-type":
+			m.contentType = f.Value
+		case "grpc-status":
+			if code, err := strconv.Atoi(f.Value); err == nil {
+				m.grpcStatus, m.hasGRPCStatus = code, true
+			}
+		case "grpc-message":
+			m.grpcMessage = decodeGRPCMessage(f.Value)
+		}
+	}
+}
+
+// isInterim reports whether the message is an informational (1xx) response
+// to be followed by the final response.
+func (m *message) isInterim() bool {
+	return !m.isRequest && m.statusCode >= 100 && m.statusCode < 200
+}
+
+func (m *message) header(name string) string {
+	for _, f := range m.fields {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+func (m *message) getEndpoints() (src *common.Endpoint, dst *common.Endpoint) {
+	source, destination := common.MakeEndpointPair(m.tcpTuple.BaseTuple, m.cmdlineTuple)
+	src, dst = &source, &destination
+	if m.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+	return src, dst
+}
+
+func (t *transaction) request() *message {
+	for _, m := range t.messages {
+		if m != nil && m.isRequest {
+			return m
+		}
+	}
+	return nil
+}
+
+func (t *transaction) response() *message {
+	for _, m := range t.messages {
+		if m != nil && !m.isRequest && m.statusCode != 0 {
+			return m
+		}
+	}
+	return nil
+}
+
+// endStream marks the message sent in a direction of a stream as complete.
+// The transaction is published once the final response is complete, as the
+// server can end a stream before the client is done with the request.
+func (h2 *http2Plugin) endStream(conn *connection, id uint32, dir uint8) {
+	t := conn.transactions[id]
+	if t == nil || t.messages[dir] == nil {
+		return
+	}
+	t.messages[dir].ended = true
+
+	if resp := t.response(); resp != nil && resp.ended {
+		h2.publishTransaction(conn, t)
+	}
+}
+
+func (h2 *http2Plugin) resetStream(conn *connection, id uint32, code uint32) {
+	t := conn.transactions[id]
+	if t == nil {
+		return
+	}
+	t.reset, t.resetCode = true, code
+	h2.publishTransaction(conn, t)
+}
+
+func (h2 *http2Plugin) publishTransaction(conn *connection, t *transaction) {
+	delete(conn.transactions, t.id)
+
+	requ, resp := t.request(), t.response()
+	switch {
+	case requ == nil && resp == nil:
+		return
+	case requ == nil:
+		unmatchedResponses.Add(1)
+	case resp == nil && !t.reset:
+		unmatchedRequests.Add(1)
+	}
+
+	if h2.results != nil {
+		h2.results(h2.newTransaction(t, requ, resp))
+	}
+}
+
+func (h2 *http2Plugin) newTransaction(t *transaction, requ, resp *message) beat.Event {
+	status := common.OK_STATUS
+	var notes []string
+	if requ == nil {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched response")
+	} else {
+		notes = append(notes, requ.notes...)
+		if resp == nil && !t.reset {
+			status = common.ERROR_STATUS
+			notes = append(notes, "Unmatched request")
+		}
+	}
+	if resp != nil && resp.statusCode >= 400 {
+		status = common.ERROR_STATUS
+	}
+	if t.reset {
+		notes = append(notes, "Stream reset with "+errorCodeName(t.resetCode))
+		if t.resetCode != 0 {
+			status = common.ERROR_STATUS
+		}
+	}
+
+	var ts time.Time
+	var src, dst *common.Endpoint
+	if requ != nil {
+		ts = requ.ts
+		src, dst = requ.getEndpoints()
+	} else {
+		ts = resp.ts
+		dst, src = resp.getEndpoints()
+	}
+
+	evt, pbf := pb.NewBeatEvent(ts)
+	pbf.SetSource(src)
+	pbf.SetDestination(dst)
+	pbf.AddIP(src.IP)
+	pbf.AddIP(dst.IP)
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = "http2"
+	if requ != nil {
+		pbf.AddCapture(&requ.capture)
+	}
+	if resp != nil {
+		pbf.AddCapture(&resp.capture)
+	}
+
+	fields := evt.Fields
+	fields["type"] = pbf.Network.Protocol
+	evt.PutValue("http2.stream_id", t.id)
+
+	httpFields := http.ProtocolFields{Version: "2"}
+	grpc := requ != nil && isGRPC(requ.contentType) || resp != nil && isGRPC(resp.contentType)
+	if requ != nil {
+		path, query := requ.path, ""
+		if i := strings.IndexByte(path, '?'); i >= 0 {
+			path, query = path[:i], path[i+1:]
+		}
+
+		pbf.Source.Bytes = int64(requ.size)
+		pbf.Event.Start = requ.ts
+		host, port := splitAuthority(requ.authority)
+		if net.ParseIP(host) == nil {
+			pbf.Destination.Domain = host
+			pbf.AddHost(host)
+		} else {
+			pbf.AddIP(host)
+		}
+		if port == 0 {
+			port = int(pbf.Destination.Port)
+		}
+
+		// http
+		httpFields.RequestMethod = common.NetString(strings.ToLower(requ.method))
+		httpFields.RequestBytes = int64(requ.size)
+		httpFields.RequestBodyBytes = int64(requ.bodySize)
+		httpFields.RequestHeaders = h2.collectHeaders(requ)
+
+		// url
+		u := newURL(requ.scheme, host, int64(port), path, query)
+		pb.MarshalStruct(evt.Fields, "url", u)
+
+		// user-agent
+		userAgent := ecs.UserAgent{Original: requ.header("user-agent")}
+		pb.MarshalStruct(evt.Fields, "user_agent", userAgent)
+
+		// grpc
+		if grpc {
+			if service, method, ok := splitGRPCPath(path); ok {
+				evt.PutValue("grpc.service", service)
+				evt.PutValue("grpc.method", method)
+			}
+			evt.PutValue("grpc.request.messages", requ.grpc.messages)
+		}
+
+		// packetbeat root fields
+		if h2.sendRequest {
+			fields["request"] = rawMessage(requ)
+		}
+		fields["method"] = httpFields.RequestMethod
+		fields["query"] = fmt.Sprintf("%s %s", requ.method, path)
+	}
+
+	if resp != nil {
+		pbf.Destination.Bytes = int64(resp.size)
+		pbf.Event.End = resp.ts
+
+		// http
+		httpFields.ResponseStatusCode = int64(resp.statusCode)
+		httpFields.ResponseBytes = int64(resp.size)
+		httpFields.ResponseBodyBytes = int64(resp.bodySize)
+		httpFields.ResponseHeaders = h2.collectHeaders(resp)
+
+		// grpc
+		if grpc {
+			evt.PutValue("grpc.response.messages", resp.grpc.messages)
+			if resp.hasGRPCStatus {
+				evt.PutValue("grpc.status_code", resp.grpcStatus)
+				if resp.grpcStatus != 0 {
+					status = common.ERROR_STATUS
+				}
+			}
+			if resp.grpcMessage != "" {
+				evt.PutValue("grpc.message", resp.grpcMessage)
+			}
+		}
+
+		// packetbeat root fields
+		if h2.sendResponse {
+			fields["response"] = rawMessage(resp)
+		}
+	}
+
+	fields["status"] = status
+	pbf.Error.Message = notes
+	pb.MarshalStruct(evt.Fields, "http", httpFields)
+	return evt
+}
+
+func (h2 *http2Plugin) collectHeaders(m *message) common.MapStr {
+	hdrs := common.MapStr{}
+	if len(m.contentType) > 0 {
+		hdrs["content-type"] = m.contentType
+	}
+
+	if !h2.sendAllHeaders && h2.headersWhitelist == nil {
+		return hdrs
+	}
+	for _, f := range m.fields {
+		if f.IsPseudo() || f.Name == "content-type" {
+			continue
+		}
+		if !h2.sendAllHeaders && !h2.headersWhitelist[f.Name] {
+			continue
+		}
+		if v, exists := hdrs[f.Name]; exists {
+			hdrs[f.Name] = v.(string) + ", " + f.Value
+		} else {
+			hdrs[f.Name] = f.Value
+		}
+	}
+	return hdrs
+}
+
+// rawMessage formats the header fields and trailers of a message, one per
+// line.
+func rawMessage(m *message) string {
+	var b strings.Builder
+	for _, fields := range [][]hpack.HeaderField{m.fields, m.trailers} {
+		for _, f := range fields {
+			b.WriteString(f.Name)
+			b.WriteString(": ")
+			b.WriteString(f.Value)
+			b.WriteString("\r\n")
+		}
+	}
+	return b.String()
+}
+
+// splitAuthority splits the optional port from the :authority of a request.
+func splitAuthority(authority string) (host string, port int) {
+	host, portStr, err := net.SplitHostPort(authority)
+	if err != nil {
+		return authority, 0
+	}
+	port, err = strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return authority, 0
+	}
+	return host, port
+}
+
+// newURL returns a new ecs.Url object with data from the request.
+func newURL(scheme, host string, port int64, path, query string) *ecs.Url {
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := &ecs.Url{
+		Scheme: scheme,
+		Domain: host,
+		Path:   path,
+		Query:  query,
+	}
+	defaultPort := int64(80)
+	if scheme == "https" {
+		defaultPort = 443
+	}
+	if port != defaultPort {
+		u.Port = port
+	}
+	if host != "" && port > 0 {
+		hostPort := host
+		if u.Port != 0 {
+			hostPort = net.JoinHostPort(host, strconv.Itoa(int(port)))
+		} else if strings.IndexByte(host, ':') != -1 {
+			hostPort = "[" + host + "]"
+		}
+		full := url.URL{
+			Scheme:   scheme,
+			Host:     hostPort,
+			Path:     path,
+			RawQuery: query,
+		}
+		u.Full = full.String()
+	}
+	return u
+}
+
+func (h2 *http2Plugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	// The header compression state can not be recovered once a header
+	// block is missing. Publish the open streams before the connection
+	// state is dropped.
+	if conn, ok := private.(*connection); ok && conn != nil {
+		h2.flushTransactions(conn)
+	}
+	return private, true
+}
+
+func (h2 *http2Plugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	return private
+}
+
+// Expired publishes the streams still open when the connection expires.
+func (h2 *http2Plugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	h2.flushTransactions(conn)
+}
+
+func (h2 *http2Plugin) flushTransactions(conn *connection) {
+	ids := make([]uint32, 0, len(conn.transactions))
+	for id := range conn.transactions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		h2.publishTransaction(conn, conn.transactions[id])
+	}
+}
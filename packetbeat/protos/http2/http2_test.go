@@ -0,0 +1,406 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package http2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2/hpack"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func http2ModForTests(store *eventStore) *http2Plugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	h2, err := New(false, callback, procs.ProcessesWatcher{}, common.NewConfig())
+	if err != nil {
+		panic(err)
+	}
+	return h2.(*http2Plugin)
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 50051,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// packet is the payload of a TCP segment sent in a direction.
+type packet struct {
+	dir  uint8
+	data []byte
+}
+
+// headerEncoder encodes the header blocks sent in a direction of a
+// connection.
+type headerEncoder struct {
+	buf bytes.Buffer
+	enc *hpack.Encoder
+}
+
+func newHeaderEncoder() *headerEncoder {
+	e := &headerEncoder{}
+	e.enc = hpack.NewEncoder(&e.buf)
+	return e
+}
+
+// block encodes pairs of header field names and values.
+func (e *headerEncoder) block(fields ...string) []byte {
+	e.buf.Reset()
+	for i := 0; i+1 < len(fields); i += 2 {
+		e.enc.WriteField(hpack.HeaderField{Name: fields[i], Value: fields[i+1]})
+	}
+	return append([]byte(nil), e.buf.Bytes()...)
+}
+
+func frame(typ frameType, flags uint8, streamID uint32, payload []byte) []byte {
+	b := make([]byte, frameHeaderLen, frameHeaderLen+len(payload))
+	b[0], b[1], b[2] = byte(len(payload)>>16), byte(len(payload)>>8), byte(len(payload))
+	b[3], b[4] = byte(typ), flags
+	binary.BigEndian.PutUint32(b[5:], streamID)
+	return append(b, payload...)
+}
+
+func grpcMessage(data string) []byte {
+	b := make([]byte, grpcPrefixLen, grpcPrefixLen+len(data))
+	binary.BigEndian.PutUint32(b[1:], uint32(len(data)))
+	return append(b, data...)
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func TestDetectHTTP2(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{clientPreface, protos.DetectionMatch},
+		{clientPreface + "\x00\x00\x00\x04", protos.DetectionMatch},
+		{"PRI * HTTP/2.0\r\n", protos.DetectionNeedMore},
+		{"PRI * HTTP/1.1\r\n", protos.DetectionMismatch},
+		{"GET / HTTP/1.1\r\n", protos.DetectionMismatch},
+		{"\x00\x00\x00\x04\x00\x00\x00\x00\x00", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectHTTP2([]byte(test.data)), "%q", test.data)
+	}
+}
+
+func TestGRPCCounter(t *testing.T) {
+	data := concat(grpcMessage("abc"), grpcMessage(""), grpcMessage("hello world"))
+
+	var c grpcCounter
+	c.add(data)
+	assert.Equal(t, 3, c.messages)
+
+	c = grpcCounter{}
+	for i := range data {
+		c.add(data[i : i+1])
+	}
+	assert.Equal(t, 3, c.messages)
+}
+
+func TestSplitGRPCPath(t *testing.T) {
+	for _, test := range []struct {
+		path, service, method string
+		ok                    bool
+	}{
+		{"/helloworld.Greeter/SayHello", "helloworld.Greeter", "SayHello", true},
+		{"/Service/Method", "Service", "Method", true},
+		{"/helloworld.Greeter/", "", "", false},
+		{"//SayHello", "", "", false},
+		{"/index.html", "", "", false},
+		{"helloworld.Greeter/SayHello", "", "", false},
+	} {
+		service, method, ok := splitGRPCPath(test.path)
+		assert.Equal(t, test.ok, ok, test.path)
+		assert.Equal(t, test.service, service, test.path)
+		assert.Equal(t, test.method, method, test.path)
+	}
+}
+
+func TestHTTP2Parser(t *testing.T) {
+	grpcRequest := func(client *headerEncoder, id uint32) [][]byte {
+		block := client.block(
+			":method", "POST",
+			":scheme", "http",
+			":path", "/helloworld.Greeter/SayHello",
+			":authority", "localhost:50051",
+			"content-type", "application/grpc",
+			"te", "trailers",
+		)
+		// padded DATA frame holding two messages
+		data := concat([]byte{3}, grpcMessage("first"), grpcMessage("second"), []byte{0, 0, 0})
+		packet := concat(
+			frame(frameHeaders, 0, id, block[:4]),
+			frame(frameContinuation, flagEndHeaders, id, block[4:]),
+			frame(frameData, flagEndStream|flagPadded, id, data),
+		)
+		// split in the middle of the DATA frame
+		return [][]byte{packet[:len(packet)-10], packet[len(packet)-10:]}
+	}
+	grpcResponse := func(server *headerEncoder, id uint32, status, message string) []byte {
+		return concat(
+			frame(frameHeaders, flagEndHeaders, id, server.block(
+				":status", "200",
+				"content-type", "application/grpc",
+			)),
+			frame(frameData, 0, id, grpcMessage("reply")),
+			frame(frameHeaders, flagEndHeaders|flagEndStream, id, server.block(
+				"grpc-status", status,
+				"grpc-message", message,
+			)),
+		)
+	}
+	grpcCall := func(fields common.MapStr) common.MapStr {
+		fields.Update(common.MapStr{
+			"http.request.method":       common.NetString("post"),
+			"http.response.status_code": 200,
+			"grpc.service":              "helloworld.Greeter",
+			"grpc.method":               "SayHello",
+			"grpc.request.messages":     2,
+			"grpc.response.messages":    1,
+			"http.request.body.bytes":   2*grpcPrefixLen + len("firstsecond"),
+		})
+		return fields
+	}
+
+	for _, test := range []struct {
+		title string
+		// packets returns the payloads sent by the client (0) and the
+		// server (1), in order.
+		packets        func(client, server *headerEncoder) []packet
+		maxMessageSize int
+		expire         bool
+		dropped        bool
+		expected       []common.MapStr
+	}{
+		{
+			title: "transaction",
+			packets: func(client, server *headerEncoder) []packet {
+				return []packet{
+					{0, concat(
+						[]byte(clientPreface),
+						frame(frameSettings, 0, 0, nil),
+						frame(frameHeaders, flagEndHeaders|flagEndStream, 1, client.block(
+							":method", "GET",
+							":scheme", "http",
+							":authority", "example.com:8080",
+							":path", "/index.html?q=1",
+							"user-agent", "test",
+						)),
+					)},
+					{1, concat(
+						frame(frameSettings, 0, 0, nil),
+						frame(frameSettings, flagAck, 0, nil),
+						frame(frameHeaders, flagEndHeaders, 1, server.block(
+							":status", "200",
+							"content-type", "text/html",
+						)),
+					)},
+					{1, frame(frameData, flagEndStream, 1, []byte("hello"))},
+				}
+			},
+			expected: []common.MapStr{{
+				"type":                               "http2",
+				"status":                             common.OK_STATUS,
+				"http2.stream_id":                    1,
+				"http.version":                       "2",
+				"http.request.method":                common.NetString("get"),
+				"http.response.status_code":          200,
+				"http.response.body.bytes":           5,
+				"http.response.headers.content-type": "text/html",
+				"url.domain":                         "example.com",
+				"url.port":                           8080,
+				"url.path":                           "/index.html",
+				"url.query":                          "q=1",
+				"url.full":                           "http://example.com:8080/index.html?q=1",
+				"user_agent.original":                "test",
+				"query":                              "GET /index.html",
+				"grpc":                               nil,
+			}},
+		},
+		{
+			title: "grpc",
+			packets: func(client, server *headerEncoder) []packet {
+				var packets []packet
+				packets = append(packets, packet{0, []byte(clientPreface)})
+				for _, data := range grpcRequest(client, 1) {
+					packets = append(packets, packet{0, data})
+				}
+				packets = append(packets, packet{1, grpcResponse(server, 1, "0", "")})
+				// the headers of the second call are encoded with the
+				// dynamic table
+				for _, data := range grpcRequest(client, 3) {
+					packets = append(packets, packet{0, data})
+				}
+				return append(packets, packet{1, grpcResponse(server, 3, "5", "user%20not%20found")})
+			},
+			expected: []common.MapStr{
+				grpcCall(common.MapStr{
+					"http2.stream_id":  1,
+					"status":           common.OK_STATUS,
+					"grpc.status_code": 0,
+				}),
+				grpcCall(common.MapStr{
+					"http2.stream_id":  3,
+					"status":           common.ERROR_STATUS,
+					"grpc.status_code": 5,
+					"grpc.message":     "user not found",
+				}),
+			},
+		},
+		{
+			title: "reset",
+			packets: func(client, server *headerEncoder) []packet {
+				return []packet{
+					{0, concat(
+						[]byte(clientPreface),
+						frame(frameHeaders, flagEndHeaders|flagEndStream, 1, client.block(
+							":method", "GET",
+							":scheme", "http",
+							":path", "/slow",
+						)),
+					)},
+					{0, frame(frameRSTStream, 0, 1, []byte{0, 0, 0, 8})},
+				}
+			},
+			expected: []common.MapStr{{
+				"status":        common.ERROR_STATUS,
+				"error.message": "Stream reset with CANCEL",
+			}},
+		},
+		{
+			title: "expired",
+			packets: func(client, server *headerEncoder) []packet {
+				return []packet{
+					{0, concat(
+						[]byte(clientPreface),
+						frame(frameHeaders, flagEndHeaders|flagEndStream, 1, client.block(
+							":method", "GET",
+							":scheme", "http",
+							":path", "/",
+						)),
+					)},
+				}
+			},
+			expire: true,
+			expected: []common.MapStr{{
+				"status":        common.ERROR_STATUS,
+				"error.message": "Unmatched request",
+			}},
+		},
+		{
+			title: "header block not continued",
+			packets: func(client, server *headerEncoder) []packet {
+				return []packet{
+					{0, concat(
+						[]byte(clientPreface),
+						frame(frameHeaders, 0, 1, client.block(":method", "GET")),
+						frame(frameData, 0, 1, nil),
+					)},
+				}
+			},
+			dropped: true,
+		},
+		{
+			title:          "header block too large",
+			maxMessageSize: 100,
+			packets: func(client, server *headerEncoder) []packet {
+				block := make([]byte, 60)
+				return []packet{
+					{0, concat(
+						[]byte(clientPreface),
+						frame(frameHeaders, 0, 1, block),
+					)},
+					{0, frame(frameContinuation, 0, 1, block)},
+				}
+			},
+			dropped: true,
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			h2 := http2ModForTests(&store)
+			if test.maxMessageSize != 0 {
+				h2.maxMessageSize = test.maxMessageSize
+			}
+			tcptuple := testTCPTuple()
+			ts := time.Now()
+
+			var private protos.ProtocolData
+			for i, p := range test.packets(newHeaderEncoder(), newHeaderEncoder()) {
+				pkt := protos.Packet{Ts: ts.Add(time.Duration(i) * time.Millisecond), Payload: p.data}
+				private = h2.Parse(&pkt, tcptuple, p.dir, private)
+			}
+			if test.dropped {
+				assert.Nil(t, private)
+				return
+			}
+			if test.expire {
+				h2.Expired(tcptuple, private)
+			}
+
+			if !assert.Len(t, store.events, len(test.expected)) {
+				return
+			}
+			for i, expected := range test.expected {
+				for field, value := range expected {
+					actual, err := store.events[i].GetValue(field)
+					if value == nil {
+						assert.Equal(t, common.ErrKeyNotFound, err, field)
+						continue
+					}
+					assert.NoError(t, err, field)
+					assert.EqualValues(t, value, actual, field)
+				}
+			}
+		})
+	}
+}
@@ -142,10 +142,11 @@ func (icmp *icmpPlugin) ProcessICMPv4(
 		seq:         seq,
 	}
 	msg := &icmpMessage{
-		ts:     pkt.Ts,
-		Type:   typ,
-		code:   code,
-		length: len(icmp4.BaseLayer.Payload),
+		ts:      pkt.Ts,
+		capture: pkt.Capture,
+		Type:    typ,
+		code:    code,
+		length:  len(icmp4.BaseLayer.Payload),
 	}
 
 	if isRequest(tuple, msg) {
@@ -177,10 +178,11 @@ func (icmp *icmpPlugin) ProcessICMPv6(
 		seq:         seq,
 	}
 	msg := &icmpMessage{
-		ts:     pkt.Ts,
-		Type:   typ,
-		code:   code,
-		length: len(icmp6.BaseLayer.Payload),
+		ts:      pkt.Ts,
+		capture: pkt.Capture,
+		Type:    typ,
+		code:    code,
+		length:  len(icmp6.BaseLayer.Payload),
 	}
 
 	if isRequest(tuple, msg) {
@@ -294,6 +296,12 @@ func (icmp *icmpPlugin) publishTransaction(trans *icmpTransaction) {
 	pbf.Event.Dataset = "icmp"
 	pbf.Event.Type = []string{"connection"}
 	pbf.Error.Message = trans.notes
+	if trans.request != nil {
+		pbf.AddCapture(&trans.request.capture)
+	}
+	if trans.response != nil {
+		pbf.AddCapture(&trans.response.capture)
+	}
 
 	// common fields - group "event"
 	fields := evt.Fields
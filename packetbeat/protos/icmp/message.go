@@ -24,6 +24,7 @@ import (
 	"github.com/tsg/gopacket/layers"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 )
 
 // TODO: more types (that are not provided as constants in gopacket)
@@ -77,10 +78,11 @@ var icmp6PairTypes = map[uint8]bool{
 
 // Contains all used information from the ICMP message on the wire.
 type icmpMessage struct {
-	ts     time.Time
-	Type   uint8
-	code   uint8
-	length int
+	ts      time.Time
+	capture pb.Capture
+	Type    uint8
+	code    uint8
+	length  int
 }
 
 func isRequest(tuple *icmpTuple, msg *icmpMessage) bool {
@@ -0,0 +1,461 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"strconv"
+)
+
+type apiKey int16
+
+const (
+	apiProduce      apiKey = 0
+	apiFetch        apiKey = 1
+	apiMetadata     apiKey = 3
+	apiOffsetCommit apiKey = 8
+	apiAPIVersions  apiKey = 18
+)
+
+var apiKeyNames = []string{
+	"Produce",
+	"Fetch",
+	"ListOffsets",
+	"Metadata",
+	"LeaderAndIsr",
+	"StopReplica",
+	"UpdateMetadata",
+	"ControlledShutdown",
+	"OffsetCommit",
+	"OffsetFetch",
+	"FindCoordinator",
+	"JoinGroup",
+	"Heartbeat",
+	"LeaveGroup",
+	"SyncGroup",
+	"DescribeGroups",
+	"ListGroups",
+	"SaslHandshake",
+	"ApiVersions",
+	"CreateTopics",
+	"DeleteTopics",
+	"DeleteRecords",
+	"InitProducerId",
+	"OffsetForLeaderEpoch",
+	"AddPartitionsToTxn",
+	"AddOffsetsToTxn",
+	"EndTxn",
+	"WriteTxnMarkers",
+	"TxnOffsetCommit",
+	"DescribeAcls",
+	"CreateAcls",
+	"DeleteAcls",
+	"DescribeConfigs",
+	"AlterConfigs",
+	"AlterReplicaLogDirs",
+	"DescribeLogDirs",
+	"SaslAuthenticate",
+	"CreatePartitions",
+	"CreateDelegationToken",
+	"RenewDelegationToken",
+	"ExpireDelegationToken",
+	"DescribeDelegationToken",
+	"DeleteGroups",
+	"ElectLeaders",
+	"IncrementalAlterConfigs",
+	"AlterPartitionReassignments",
+	"ListPartitionReassignments",
+	"OffsetDelete",
+	"DescribeClientQuotas",
+	"AlterClientQuotas",
+	"DescribeUserScramCredentials",
+	"AlterUserScramCredentials",
+	"Vote",
+	"BeginQuorumEpoch",
+	"EndQuorumEpoch",
+	"DescribeQuorum",
+	"AlterPartition",
+	"UpdateFeatures",
+	"Envelope",
+	"FetchSnapshot",
+	"DescribeCluster",
+	"DescribeProducers",
+	"BrokerRegistration",
+	"BrokerHeartbeat",
+	"UnregisterBroker",
+	"DescribeTransactions",
+	"ListTransactions",
+	"AllocateProducerIds",
+}
+
+// maxAPIVersion bounds the versions accepted when detecting the protocol.
+const maxAPIVersion = 20
+
+func (k apiKey) valid() bool {
+	return k >= 0 && int(k) < len(apiKeyNames)
+}
+
+func (k apiKey) String() string {
+	if k.valid() {
+		return apiKeyNames[k]
+	}
+	return strconv.Itoa(int(k))
+}
+
+// bodyVersions holds the first flexible version and the last known
+// version of the APIs with decoded bodies.
+var bodyVersions = map[apiKey]struct{ flexible, last int16 }{
+	apiProduce:      {9, 11},
+	apiFetch:        {12, 14},
+	apiMetadata:     {9, 12},
+	apiOffsetCommit: {8, 9},
+	apiAPIVersions:  {3, 4},
+}
+
+// bodyVersion reports whether the body of a message can be decoded, and
+// whether it uses the flexible encoding.
+func bodyVersion(key apiKey, version int16) (flexible, ok bool) {
+	v, ok := bodyVersions[key]
+	if !ok || version > v.last {
+		return false, false
+	}
+	return version >= v.flexible, true
+}
+
+var errorCodeNames = []string{
+	"NONE",
+	"OFFSET_OUT_OF_RANGE",
+	"CORRUPT_MESSAGE",
+	"UNKNOWN_TOPIC_OR_PARTITION",
+	"INVALID_FETCH_SIZE",
+	"LEADER_NOT_AVAILABLE",
+	"NOT_LEADER_OR_FOLLOWER",
+	"REQUEST_TIMED_OUT",
+	"BROKER_NOT_AVAILABLE",
+	"REPLICA_NOT_AVAILABLE",
+	"MESSAGE_TOO_LARGE",
+	"STALE_CONTROLLER_EPOCH",
+	"OFFSET_METADATA_TOO_LARGE",
+	"NETWORK_EXCEPTION",
+	"COORDINATOR_LOAD_IN_PROGRESS",
+	"COORDINATOR_NOT_AVAILABLE",
+	"NOT_COORDINATOR",
+	"INVALID_TOPIC_EXCEPTION",
+	"RECORD_LIST_TOO_LARGE",
+	"NOT_ENOUGH_REPLICAS",
+	"NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+	"INVALID_REQUIRED_ACKS",
+	"ILLEGAL_GENERATION",
+	"INCONSISTENT_GROUP_PROTOCOL",
+	"INVALID_GROUP_ID",
+	"UNKNOWN_MEMBER_ID",
+	"INVALID_SESSION_TIMEOUT",
+	"REBALANCE_IN_PROGRESS",
+	"INVALID_COMMIT_OFFSET_SIZE",
+	"TOPIC_AUTHORIZATION_FAILED",
+	"GROUP_AUTHORIZATION_FAILED",
+	"CLUSTER_AUTHORIZATION_FAILED",
+	"INVALID_TIMESTAMP",
+	"UNSUPPORTED_SASL_MECHANISM",
+	"ILLEGAL_SASL_STATE",
+	"UNSUPPORTED_VERSION",
+	"TOPIC_ALREADY_EXISTS",
+	"INVALID_PARTITIONS",
+	"INVALID_REPLICATION_FACTOR",
+	"INVALID_REPLICA_ASSIGNMENT",
+	"INVALID_CONFIG",
+	"NOT_CONTROLLER",
+	"INVALID_REQUEST",
+	"UNSUPPORTED_FOR_MESSAGE_FORMAT",
+	"POLICY_VIOLATION",
+	"OUT_OF_ORDER_SEQUENCE_NUMBER",
+	"DUPLICATE_SEQUENCE_NUMBER",
+	"INVALID_PRODUCER_EPOCH",
+	"INVALID_TXN_STATE",
+	"INVALID_PRODUCER_ID_MAPPING",
+	"INVALID_TRANSACTION_TIMEOUT",
+	"CONCURRENT_TRANSACTIONS",
+	"TRANSACTION_COORDINATOR_FENCED",
+	"TRANSACTIONAL_ID_AUTHORIZATION_FAILED",
+	"SECURITY_DISABLED",
+	"OPERATION_NOT_ATTEMPTED",
+	"KAFKA_STORAGE_ERROR",
+	"LOG_DIR_NOT_FOUND",
+	"SASL_AUTHENTICATION_FAILED",
+	"UNKNOWN_PRODUCER_ID",
+	"REASSIGNMENT_IN_PROGRESS",
+	"DELEGATION_TOKEN_AUTH_DISABLED",
+	"DELEGATION_TOKEN_NOT_FOUND",
+	"DELEGATION_TOKEN_OWNER_MISMATCH",
+	"DELEGATION_TOKEN_REQUEST_NOT_ALLOWED",
+	"DELEGATION_TOKEN_AUTHORIZATION_FAILED",
+	"DELEGATION_TOKEN_EXPIRED",
+	"INVALID_PRINCIPAL_TYPE",
+	"NON_EMPTY_GROUP",
+	"GROUP_ID_NOT_FOUND",
+	"FETCH_SESSION_ID_NOT_FOUND",
+	"INVALID_FETCH_SESSION_EPOCH",
+	"LISTENER_NOT_FOUND",
+	"TOPIC_DELETION_DISABLED",
+	"FENCED_LEADER_EPOCH",
+	"UNKNOWN_LEADER_EPOCH",
+	"UNSUPPORTED_COMPRESSION_TYPE",
+	"STALE_BROKER_EPOCH",
+	"OFFSET_NOT_AVAILABLE",
+	"MEMBER_ID_REQUIRED",
+	"PREFERRED_LEADER_NOT_AVAILABLE",
+	"GROUP_MAX_SIZE_REACHED",
+	"FENCED_INSTANCE_ID",
+}
+
+func errorCodeName(code int16) string {
+	switch {
+	case code == -1:
+		return "UNKNOWN_SERVER_ERROR"
+	case code >= 0 && int(code) < len(errorCodeNames):
+		return errorCodeNames[code]
+	}
+	return strconv.Itoa(int(code))
+}
+
+// parseRequestBody decodes the topics, partitions and records of the
+// requests of the supported APIs.
+func parseRequestBody(d *decoder, m *message) {
+	v := m.apiVersion
+	switch m.apiKey {
+	case apiProduce:
+		if v >= 3 {
+			d.string() // transactional_id
+		}
+		m.acks = d.int16()
+		d.skip(4) // timeout_ms
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := d.string()
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				m.records += countRecords(d.bytes())
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+
+	case apiFetch:
+		d.skip(12) // replica_id, max_wait_ms, min_bytes
+		if v >= 3 {
+			d.skip(4) // max_bytes
+		}
+		if v >= 4 {
+			d.skip(1) // isolation_level
+		}
+		if v >= 7 {
+			d.skip(8) // session_id, session_epoch
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := readTopic(d, v >= 13)
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				if v >= 9 {
+					d.skip(4) // current_leader_epoch
+				}
+				d.skip(8) // fetch_offset
+				if v >= 12 {
+					d.skip(4) // last_fetched_epoch
+				}
+				if v >= 5 {
+					d.skip(8) // log_start_offset
+				}
+				d.skip(4) // partition_max_bytes
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+
+	case apiMetadata:
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			m.addTopic(readTopic(d, v >= 10))
+			d.taggedFields()
+		}
+
+	case apiOffsetCommit:
+		m.groupID = d.string()
+		if v >= 1 {
+			d.skip(4)  // generation_id
+			d.string() // member_id
+		}
+		if v >= 7 {
+			d.string() // group_instance_id
+		}
+		if v >= 2 && v <= 4 {
+			d.skip(8) // retention_time_ms
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := d.string()
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				d.skip(8) // committed_offset
+				if v >= 6 {
+					d.skip(4) // committed_leader_epoch
+				}
+				if v == 1 {
+					d.skip(8) // commit_timestamp
+				}
+				d.string() // committed_metadata
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+	}
+}
+
+// parseResponseBody decodes the topics, partitions, error codes and
+// records of the responses of the supported APIs.
+func parseResponseBody(d *decoder, m *message) {
+	v := m.apiVersion
+	switch m.apiKey {
+	case apiProduce:
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := d.string()
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				m.addError(d.int16())
+				d.skip(8) // base_offset
+				if v >= 2 {
+					d.skip(8) // log_append_time_ms
+				}
+				if v >= 5 {
+					d.skip(8) // log_start_offset
+				}
+				if v >= 8 {
+					for k, n := 0, d.arrayLen(); k < n && d.err == nil; k++ {
+						d.skip(4)  // batch_index
+						d.string() // batch_index_error_message
+						d.taggedFields()
+					}
+					d.string() // error_message
+				}
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+
+	case apiFetch:
+		if v >= 1 {
+			d.skip(4) // throttle_time_ms
+		}
+		if v >= 7 {
+			m.addError(d.int16())
+			d.skip(4) // session_id
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := readTopic(d, v >= 13)
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				m.addError(d.int16())
+				d.skip(8) // high_watermark
+				if v >= 4 {
+					d.skip(8) // last_stable_offset
+					if v >= 5 {
+						d.skip(8) // log_start_offset
+					}
+					for k, n := 0, d.arrayLen(); k < n && d.err == nil; k++ {
+						d.skip(16) // producer_id, first_offset
+						d.taggedFields()
+					}
+				}
+				if v >= 11 {
+					d.skip(4) // preferred_read_replica
+				}
+				m.records += countRecords(d.bytes())
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+
+	case apiMetadata:
+		if v >= 3 {
+			d.skip(4) // throttle_time_ms
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			d.skip(4)  // node_id
+			d.string() // host
+			d.skip(4)  // port
+			if v >= 1 {
+				d.string() // rack
+			}
+			d.taggedFields()
+		}
+		if v >= 2 {
+			d.string() // cluster_id
+		}
+		if v >= 1 {
+			d.skip(4) // controller_id
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			m.addError(d.int16())
+			m.addTopic(d.string())
+			if v >= 10 {
+				d.skip(16) // topic_id
+			}
+			if v >= 1 {
+				d.skip(1) // is_internal
+			}
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addError(d.int16())
+				d.skip(8) // partition_index, leader_id
+				if v >= 7 {
+					d.skip(4) // leader_epoch
+				}
+				d.int32Array() // replica_nodes
+				d.int32Array() // isr_nodes
+				if v >= 5 {
+					d.int32Array() // offline_replicas
+				}
+				d.taggedFields()
+			}
+			if v >= 8 {
+				d.skip(4) // topic_authorized_operations
+			}
+			d.taggedFields()
+		}
+
+	case apiOffsetCommit:
+		if v >= 3 {
+			d.skip(4) // throttle_time_ms
+		}
+		for i, n := 0, d.arrayLen(); i < n && d.err == nil; i++ {
+			topic := d.string()
+			for j, n := 0, d.arrayLen(); j < n && d.err == nil; j++ {
+				m.addPartition(topic, d.int32())
+				m.addError(d.int16())
+				d.taggedFields()
+			}
+			d.taggedFields()
+		}
+
+	case apiAPIVersions:
+		m.addError(d.int16())
+	}
+}
+
+// readTopic reads the name of a topic, or its ID in the versions
+// identifying topics by ID.
+func readTopic(d *decoder, byID bool) string {
+	if byID {
+		return d.uuid()
+	}
+	return d.string()
+}
@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	errShortMessage = errors.New("message too short")
+	errInvalidSize  = errors.New("invalid message size")
+)
+
+// decoder reads the fields of a message. Flexible versions of the messages
+// use compact strings and arrays with a varint length, and carry tagged
+// fields at the end of each structure. The first error is sticky, fields
+// read after an error are zero.
+type decoder struct {
+	buf      []byte
+	flexible bool
+	err      error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.buf) {
+		d.err = errShortMessage
+		d.buf = nil
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) skip(n int) {
+	d.take(n)
+}
+
+func (d *decoder) int8() int8 {
+	if b := d.take(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *decoder) int16() int16 {
+	if b := d.take(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *decoder) int32() int32 {
+	if b := d.take(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *decoder) int64() int64 {
+	if b := d.take(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errShortMessage
+		d.buf = nil
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// length reads the length of a string, byte array or array. Null values
+// have a length of -1.
+func (d *decoder) length(classic func() int) int {
+	if !d.flexible {
+		return classic()
+	}
+	// compact lengths are stored plus one, zero being null
+	n := d.uvarint()
+	if n > maxMessageLen {
+		d.err = errShortMessage
+		d.buf = nil
+		return -1
+	}
+	return int(n) - 1
+}
+
+// string reads a string, or a nullable string which is empty if null.
+func (d *decoder) string() string {
+	n := d.length(func() int { return int(d.int16()) })
+	if n <= 0 {
+		return ""
+	}
+	return string(d.take(n))
+}
+
+// bytes reads a byte array. The available bytes are returned if the
+// array is truncated.
+func (d *decoder) bytes() []byte {
+	n := d.length(func() int { return int(d.int32()) })
+	if n <= 0 || d.err != nil {
+		return nil
+	}
+	if n > len(d.buf) {
+		b := d.buf
+		d.err = errShortMessage
+		d.buf = nil
+		return b
+	}
+	return d.take(n)
+}
+
+// arrayLen reads the number of elements of an array. Null arrays have no
+// element.
+func (d *decoder) arrayLen() int {
+	n := d.length(func() int { return int(d.int32()) })
+	if n < 0 {
+		return 0
+	}
+	if n > len(d.buf) {
+		// elements take one byte at least, the decoding of a truncated
+		// array stops at the end of the data
+		return len(d.buf)
+	}
+	return n
+}
+
+// uuid reads a topic ID, formatted like by the Kafka tools.
+func (d *decoder) uuid() string {
+	if b := d.take(16); b != nil {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	return ""
+}
+
+// int32Array skips an array of int32 values.
+func (d *decoder) int32Array() {
+	d.skip(4 * d.arrayLen())
+}
+
+// taggedFields skips the tagged fields at the end of a structure of a
+// flexible version.
+func (d *decoder) taggedFields() {
+	if !d.flexible {
+		return
+	}
+	for n := d.uvarint(); n > 0 && d.err == nil; n-- {
+		d.uvarint() // tag
+		d.skip(int(d.uvarint()))
+	}
+}
+
+// countRecords returns the number of records of the complete record
+// batches (magic 2) or legacy message sets (magic 0 and 1) in the records
+// of a partition.
+func countRecords(records []byte) int {
+	const (
+		batchHeaderLen   = 61
+		logOverheadLen   = 12 // offset and length preceding all formats
+		magicOffset      = 16
+		recordsLenOffset = 57
+	)
+
+	count := 0
+	for len(records) > magicOffset {
+		size := int(int32(binary.BigEndian.Uint32(records[8:])))
+		if size < 0 || logOverheadLen+size > len(records) {
+			// the last batch of fetched data can be partial
+			break
+		}
+		switch records[magicOffset] {
+		case 2:
+			if logOverheadLen+size < batchHeaderLen {
+				return count
+			}
+			count += int(int32(binary.BigEndian.Uint32(records[recordsLenOffset:])))
+		case 0, 1:
+			count++
+		default:
+			return count
+		}
+		records = records[logOverheadLen+size:]
+	}
+	return count
+}
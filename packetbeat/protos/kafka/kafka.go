@@ -0,0 +1,539 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+type connection struct {
+	streams   [2]applayer.FramedStream
+	clientDir uint8
+	requests  map[int32]*message
+}
+
+type message struct {
+	ts           time.Time
+	capture      pb.Capture
+	tcpTuple     common.TCPTuple
+	cmdlineTuple *common.ProcessTuple
+	direction    uint8
+	isRequest    bool
+	size         int
+	truncated    bool
+	invalid      bool
+
+	apiKey        apiKey
+	apiVersion    int16
+	correlationID int32
+	clientID      string
+	groupID       string
+	acks          int16
+
+	topics     []string
+	partitions []string
+	records    int
+	errorCode  int16
+}
+
+// Kafka protocol plugin
+type kafkaPlugin struct {
+	// config
+	ports              []int
+	maxMessageSize     int
+	maxPendingRequests int
+	transactionTimeout time.Duration
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("kafka")
+	isDebug = false
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "kafka.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "kafka.unmatched_responses")
+)
+
+// Length of the size preceding each message.
+const sizeLen = 4
+
+// maxMessageLen is the maximum size of a message accepted by default by
+// the brokers.
+const maxMessageLen = 100 * 1024 * 1024
+
+func init() {
+	protos.Register("kafka", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &kafkaPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (kafka *kafkaPlugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *kafkaConfig) error {
+	kafka.setFromConfig(config)
+
+	kafka.results = results
+	kafka.watcher = watcher
+	isDebug = logp.IsDebug("kafka")
+
+	return nil
+}
+
+func (kafka *kafkaPlugin) setFromConfig(config *kafkaConfig) {
+	kafka.ports = config.Ports
+	kafka.maxMessageSize = config.MaxMessageSize
+	kafka.maxPendingRequests = config.MaxPendingRequests
+	kafka.transactionTimeout = config.TransactionTimeout
+}
+
+func (kafka *kafkaPlugin) GetPorts() []int {
+	return kafka.ports
+}
+
+func (kafka *kafkaPlugin) ConnectionTimeout() time.Duration {
+	return kafka.transactionTimeout
+}
+
+// DetectTCP recognizes the header of a request.
+func (kafka *kafkaPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectKafka(data)
+}
+
+func detectKafka(data []byte) protos.Detection {
+	// size, api_key, api_version, correlation_id and client_id length
+	const hdrLen = 14
+	if len(data) < hdrLen {
+		return protos.DetectionNeedMore
+	}
+
+	size := int(int32(binary.BigEndian.Uint32(data)))
+	key := apiKey(binary.BigEndian.Uint16(data[4:]))
+	version := int16(binary.BigEndian.Uint16(data[6:]))
+	clientIDLen := int(int16(binary.BigEndian.Uint16(data[12:])))
+	if size < hdrLen-sizeLen || size > maxMessageLen || !key.valid() ||
+		version < 0 || version > maxAPIVersion ||
+		clientIDLen < -1 || clientIDLen > size-(hdrLen-sizeLen) {
+		return protos.DetectionMismatch
+	}
+
+	clientID := data[hdrLen:]
+	if clientIDLen >= 0 && len(clientID) > clientIDLen {
+		clientID = clientID[:clientIDLen]
+	}
+	for _, c := range clientID {
+		if c < 0x20 || c > 0x7e {
+			return protos.DetectionMismatch
+		}
+	}
+	if len(clientID) < clientIDLen {
+		return protos.DetectionNeedMore
+	}
+	return protos.DetectionMatch
+}
+
+func (kafka *kafkaPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseKafka exception")
+
+	conn := kafka.ensureConnection(private, tcptuple)
+	kafka.doParse(conn, pkt, tcptuple, dir)
+	return conn
+}
+
+func (kafka *kafkaPlugin) newConnectionData(tcptuple *common.TCPTuple) *connection {
+	// The client sends the requests. The first packet seen usually comes
+	// from the client too, unless the connection started before the
+	// capture.
+	clientDir := uint8(tcp.TCPDirectionOriginal)
+	if kafka.isServerPort(tcptuple.SrcPort) && !kafka.isServerPort(tcptuple.DstPort) {
+		clientDir = tcp.TCPDirectionReverse
+	}
+	return &connection{
+		clientDir: clientDir,
+		requests:  map[int32]*message{},
+	}
+}
+
+func (kafka *kafkaPlugin) isServerPort(port uint16) bool {
+	for _, p := range kafka.ports {
+		if p == int(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func (kafka *kafkaPlugin) ensureConnection(private protos.ProtocolData, tcptuple *common.TCPTuple) *connection {
+	if private == nil {
+		return kafka.newConnectionData(tcptuple)
+	}
+
+	priv, ok := private.(*connection)
+	if !ok {
+		logp.Warn("kafka connection data type error, create new one")
+		return kafka.newConnectionData(tcptuple)
+	}
+	if priv == nil {
+		logp.Warn("Unexpected: kafka connection data not set, create new one")
+		return kafka.newConnectionData(tcptuple)
+	}
+
+	return priv
+}
+
+func (kafka *kafkaPlugin) doParse(
+	conn *connection,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	st := &conn.streams[dir]
+	if !st.Append(pkt.Payload, pkt.Ts, &pkt.Capture) {
+		return
+	}
+
+	for {
+		// Messages larger than the limit are decoded from their first
+		// bytes, the rest is skipped.
+		f, ok, err := st.Next(readSize, kafka.maxMessageSize)
+		if err != nil {
+			if isDebug {
+				debugf("%v, dropping stream data", err)
+			}
+			return
+		}
+		if !ok {
+			return
+		}
+		kafka.handleKafka(conn, f.Data[sizeLen:], f.Size, f.Truncated, f.Ts, &f.Capture, tcptuple, dir)
+	}
+}
+
+// readSize reads the size prefixing a message.
+func readSize(data []byte) (hdrLen, size int, err error) {
+	if len(data) < sizeLen {
+		return 0, 0, nil
+	}
+	size = int(int32(binary.BigEndian.Uint32(data)))
+	if size < sizeLen || size > maxMessageLen {
+		return 0, 0, errInvalidSize
+	}
+	return sizeLen, size, nil
+}
+
+func (kafka *kafkaPlugin) handleKafka(
+	conn *connection,
+	data []byte,
+	size int,
+	truncated bool,
+	ts time.Time,
+	capture *pb.Capture,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	m := &message{
+		ts:        ts,
+		capture:   *capture,
+		tcpTuple:  *tcptuple,
+		direction: dir,
+		isRequest: dir == conn.clientDir,
+		size:      size,
+		truncated: truncated,
+	}
+	d := &decoder{buf: data}
+
+	if m.isRequest {
+		m.apiKey = apiKey(d.int16())
+		m.apiVersion = d.int16()
+		m.correlationID = d.int32()
+		m.clientID = d.string()
+		if d.err != nil || !m.apiKey.valid() {
+			if isDebug {
+				debugf("invalid request header, ignoring message")
+			}
+			return
+		}
+
+		if flexible, ok := bodyVersion(m.apiKey, m.apiVersion); ok {
+			d.flexible = flexible
+			d.taggedFields()
+			parseRequestBody(d, m)
+			m.invalid = d.err != nil && !truncated
+		}
+		m.cmdlineTuple = kafka.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		kafka.onRequest(conn, m)
+		return
+	}
+
+	m.correlationID = d.int32()
+	if d.err != nil {
+		return
+	}
+	requ := conn.requests[m.correlationID]
+	if requ == nil {
+		if isDebug {
+			debugf("response with unknown correlation ID %d", m.correlationID)
+		}
+		unmatchedResponses.Add(1)
+		return
+	}
+	delete(conn.requests, m.correlationID)
+
+	m.apiKey, m.apiVersion = requ.apiKey, requ.apiVersion
+	if flexible, ok := bodyVersion(m.apiKey, m.apiVersion); ok {
+		d.flexible = flexible
+		// ApiVersions responses keep the old header for clients to parse
+		// them whatever the version they requested.
+		if m.apiKey != apiAPIVersions {
+			d.taggedFields()
+		}
+		parseResponseBody(d, m)
+		m.invalid = d.err != nil && !truncated
+	}
+	kafka.publishTransaction(requ, m)
+}
+
+func (kafka *kafkaPlugin) onRequest(conn *connection, m *message) {
+	if m.apiKey == apiProduce && m.acks == 0 {
+		// the broker does not respond to produce requests without acks
+		kafka.publishTransaction(m, nil)
+		return
+	}
+
+	if _, exists := conn.requests[m.correlationID]; exists {
+		if isDebug {
+			debugf("duplicate correlation ID %d, dropping old request", m.correlationID)
+		}
+		unmatchedRequests.Add(1)
+	} else if len(conn.requests) >= kafka.maxPendingRequests {
+		if isDebug {
+			debugf("too many pending requests, ignoring request %d", m.correlationID)
+		}
+		unmatchedRequests.Add(1)
+		return
+	}
+	conn.requests[m.correlationID] = m
+}
+
+func (m *message) addTopic(topic string) {
+	if topic == "" {
+		return
+	}
+	for _, t := range m.topics {
+		if t == topic {
+			return
+		}
+	}
+	m.topics = append(m.topics, topic)
+}
+
+func (m *message) addPartition(topic string, partition int32) {
+	m.addTopic(topic)
+	m.partitions = append(m.partitions, topic+"-"+strconv.Itoa(int(partition)))
+}
+
+// addError keeps the first error reported by a response.
+func (m *message) addError(code int16) {
+	if m.errorCode == 0 {
+		m.errorCode = code
+	}
+}
+
+func (kafka *kafkaPlugin) publishTransaction(requ, resp *message) {
+	if kafka.results == nil {
+		return
+	}
+	kafka.results(kafka.newTransaction(requ, resp))
+}
+
+func (kafka *kafkaPlugin) newTransaction(requ, resp *message) beat.Event {
+	source, destination := common.MakeEndpointPair(requ.tcpTuple.BaseTuple, requ.cmdlineTuple)
+	src, dst := &source, &destination
+	if requ.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(requ.ts)
+	pbf.SetSource(src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(dst)
+	pbf.AddIP(dst.IP)
+	pbf.Source.Bytes = int64(requ.size)
+	pbf.Event.Dataset = "kafka"
+	pbf.Event.Start = requ.ts
+	pbf.Event.Action = "kafka." + strings.ToLower(requ.apiKey.String())
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&requ.capture)
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = requ.apiKey.String()
+
+	status := common.OK_STATUS
+	info := common.MapStr{
+		"api_key":        requ.apiKey.String(),
+		"api_version":    requ.apiVersion,
+		"correlation_id": requ.correlationID,
+	}
+	if requ.clientID != "" {
+		info["client_id"] = requ.clientID
+	}
+	if requ.groupID != "" {
+		info["group_id"] = requ.groupID
+	}
+
+	topics, partitions := requ.topics, requ.partitions
+	records := requ.records
+	if resp != nil {
+		if len(topics) == 0 {
+			topics = resp.topics
+		}
+		if len(partitions) == 0 {
+			partitions = resp.partitions
+		}
+		records += resp.records
+	}
+	if len(topics) > 0 {
+		info["topics"] = topics
+		if len(topics) == 1 {
+			fields["resource"] = topics[0]
+		}
+	}
+	if len(partitions) > 0 {
+		info["partitions"] = partitions
+	}
+	if requ.apiKey == apiProduce || requ.apiKey == apiFetch {
+		info["records"] = records
+	}
+
+	var notes []string
+	for _, m := range []*message{requ, resp} {
+		switch {
+		case m == nil:
+		case m.truncated:
+			notes = append(notes, "Message truncated")
+		case m.invalid:
+			notes = append(notes, "Failed to decode message body")
+		}
+	}
+
+	if resp != nil {
+		pbf.AddCapture(&resp.capture)
+		pbf.Destination.Bytes = int64(resp.size)
+		pbf.Event.End = resp.ts
+		if resp.errorCode != 0 {
+			status = common.ERROR_STATUS
+			info["error_code"] = resp.errorCode
+			info["error"] = errorCodeName(resp.errorCode)
+			pbf.Event.Outcome = "failure"
+		}
+	} else if requ.apiKey != apiProduce || requ.acks != 0 {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched request")
+	}
+
+	fields["status"] = status
+	fields["kafka"] = info
+	pbf.Error.Message = notes
+	return evt
+}
+
+func (kafka *kafkaPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	// The message boundaries are lost. Publish the pending requests before
+	// the connection state is dropped.
+	if conn, ok := private.(*connection); ok && conn != nil {
+		kafka.flushRequests(conn)
+	}
+	return private, true
+}
+
+func (kafka *kafkaPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	return private
+}
+
+// Expired publishes the requests still waiting for a response when the
+// connection expires.
+func (kafka *kafkaPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	kafka.flushRequests(conn)
+}
+
+func (kafka *kafkaPlugin) flushRequests(conn *connection) {
+	requests := make([]*message, 0, len(conn.requests))
+	for _, m := range conn.requests {
+		requests = append(requests, m)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].correlationID < requests[j].correlationID
+	})
+
+	conn.requests = map[int32]*message{}
+	for _, m := range requests {
+		unmatchedRequests.Add(1)
+		kafka.publishTransaction(m, nil)
+	}
+}
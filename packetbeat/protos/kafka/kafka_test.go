@@ -0,0 +1,489 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func kafkaModForTests(store *eventStore) *kafkaPlugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	kafka, err := New(false, callback, procs.ProcessesWatcher{}, common.NewConfig())
+	if err != nil {
+		panic(err)
+	}
+	return kafka.(*kafkaPlugin)
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 9092,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// encoder writes the fields of a message, in the flexible encoding if
+// enabled.
+type encoder struct {
+	bytes.Buffer
+	flexible bool
+}
+
+func (e *encoder) int8(v int8) {
+	e.WriteByte(byte(v))
+}
+
+func (e *encoder) int16(v int16) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *encoder) int32(v int32) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *encoder) int64(v int64) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *encoder) length(n int, classic func()) {
+	if !e.flexible {
+		classic()
+		return
+	}
+	var b [binary.MaxVarintLen64]byte
+	e.Write(b[:binary.PutUvarint(b[:], uint64(n+1))])
+}
+
+func (e *encoder) string(s string) {
+	e.length(len(s), func() { e.int16(int16(len(s))) })
+	e.WriteString(s)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.length(len(b), func() { e.int32(int32(len(b))) })
+	e.Write(b)
+}
+
+func (e *encoder) arrayLen(n int) {
+	e.length(n, func() { e.int32(int32(n)) })
+}
+
+func (e *encoder) tags() {
+	if e.flexible {
+		e.WriteByte(0)
+	}
+}
+
+// message returns the encoded fields preceded by their size.
+func (e *encoder) message() []byte {
+	b := make([]byte, sizeLen, sizeLen+e.Len())
+	binary.BigEndian.PutUint32(b, uint32(e.Len()))
+	return append(b, e.Bytes()...)
+}
+
+func newRequest(key apiKey, version int16, correlationID int32, clientID string) *encoder {
+	flexible, _ := bodyVersion(key, version)
+	e := &encoder{}
+	e.int16(int16(key))
+	e.int16(version)
+	e.int32(correlationID)
+	e.string(clientID)
+	e.flexible = flexible
+	e.tags()
+	return e
+}
+
+func newResponse(key apiKey, version int16, correlationID int32) *encoder {
+	flexible, _ := bodyVersion(key, version)
+	e := &encoder{flexible: flexible}
+	e.int32(correlationID)
+	e.tags()
+	return e
+}
+
+// recordBatch returns a record batch holding n records.
+func recordBatch(n int32) []byte {
+	b := make([]byte, 61+10*n)
+	binary.BigEndian.PutUint32(b[8:], uint32(len(b)-12))
+	b[16] = 2
+	binary.BigEndian.PutUint32(b[57:], uint32(n))
+	return b
+}
+
+// legacyMessage returns a message of a magic 1 message set.
+func legacyMessage() []byte {
+	b := make([]byte, 12+22)
+	binary.BigEndian.PutUint32(b[8:], 22)
+	b[16] = 1
+	return b
+}
+
+// packet is the payload of a TCP segment sent in a direction.
+type packet struct {
+	dir  uint8
+	data []byte
+}
+
+// chunks splits data in packets of at most size bytes.
+func chunks(dir uint8, data []byte, size int) []packet {
+	var packets []packet
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		packets = append(packets, packet{dir, data[:n]})
+		data = data[n:]
+	}
+	return packets
+}
+
+func TestDetectKafka(t *testing.T) {
+	header := func(size int32, key, version int16, clientID string) []byte {
+		e := &encoder{}
+		e.int32(size)
+		e.int16(key)
+		e.int16(version)
+		e.int32(1)
+		e.string(clientID)
+		return e.Bytes()
+	}
+
+	for _, test := range []struct {
+		data     []byte
+		expected protos.Detection
+	}{
+		{header(100, 3, 9, "consumer-1"), protos.DetectionMatch},
+		{header(100, 0, 3, ""), protos.DetectionMatch},
+		{header(100, 0, 3, "producer")[:16], protos.DetectionNeedMore},
+		{[]byte{0, 0, 0, 100, 0, 1}, protos.DetectionNeedMore},
+		{header(100, 100, 3, "producer"), protos.DetectionMismatch},
+		{header(100, 1, 30, "consumer"), protos.DetectionMismatch},
+		{header(-1, 1, 3, "consumer"), protos.DetectionMismatch},
+		{header(100, 1, 3, "\x01\x02"), protos.DetectionMismatch},
+		{[]byte("GET / HTTP/1.1\r\nHost: example.com\r\n"), protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectKafka(test.data), "%q", test.data)
+	}
+}
+
+func TestCountRecords(t *testing.T) {
+	records := append(recordBatch(3), recordBatch(2)...)
+	assert.Equal(t, 5, countRecords(records))
+
+	// the last batch of a fetch response can be partial
+	partial := recordBatch(4)
+	assert.Equal(t, 5, countRecords(append(records, partial[:len(partial)-1]...)))
+
+	legacy := append(legacyMessage(), legacyMessage()...)
+	assert.Equal(t, 2, countRecords(legacy))
+
+	assert.Equal(t, 0, countRecords(nil))
+}
+
+func produceRequest() []byte {
+	requ := newRequest(apiProduce, 3, 7, "producer-1")
+	requ.string("")  // transactional_id
+	requ.int16(1)    // acks
+	requ.int32(1000) // timeout_ms
+	requ.arrayLen(1)
+	requ.string("orders")
+	requ.arrayLen(2)
+	requ.int32(0)
+	requ.bytes(recordBatch(3))
+	requ.int32(1)
+	requ.bytes(recordBatch(2))
+	return requ.message()
+}
+
+func produceResponse() []byte {
+	resp := newResponse(apiProduce, 3, 7)
+	resp.arrayLen(1)
+	resp.string("orders")
+	resp.arrayLen(2)
+	for i, errorCode := range []int16{0, 6} {
+		resp.int32(int32(i))
+		resp.int16(errorCode)
+		resp.int64(100) // base_offset
+		resp.int64(-1)  // log_append_time_ms
+	}
+	resp.int32(0) // throttle_time_ms
+	return resp.message()
+}
+
+func flexibleProduceRequest() []byte {
+	requ := newRequest(apiProduce, 9, 1, "producer-1")
+	requ.string("") // transactional_id
+	requ.int16(0)   // acks
+	requ.int32(1000)
+	requ.arrayLen(1)
+	requ.string("logs")
+	requ.arrayLen(1)
+	requ.int32(2)
+	requ.bytes(recordBatch(10))
+	requ.tags()
+	requ.tags()
+	requ.tags()
+	return requ.message()
+}
+
+func metadataRequest() []byte {
+	requ := newRequest(apiMetadata, 1, 3, "admin")
+	requ.arrayLen(2)
+	requ.string("orders")
+	requ.string("payments")
+	return requ.message()
+}
+
+func fetchRequest(correlationID int32, topic string) []byte {
+	requ := newRequest(apiFetch, 12, correlationID, "consumer-1")
+	requ.int32(-1)  // replica_id
+	requ.int32(500) // max_wait_ms
+	requ.int32(1)   // min_bytes
+	requ.int32(1 << 20)
+	requ.int8(0)
+	requ.int32(0) // session_id
+	requ.int32(-1)
+	requ.arrayLen(1)
+	requ.string(topic)
+	requ.arrayLen(1)
+	requ.int32(0)
+	requ.int32(-1) // current_leader_epoch
+	requ.int64(42) // fetch_offset
+	requ.int32(-1) // last_fetched_epoch
+	requ.int64(-1) // log_start_offset
+	requ.int32(1 << 20)
+	requ.tags()
+	requ.tags()
+	requ.arrayLen(0) // forgotten_topics_data
+	requ.string("")  // rack_id
+	requ.tags()
+	return requ.message()
+}
+
+func fetchResponse(correlationID int32, topic string, records []byte) []byte {
+	resp := newResponse(apiFetch, 12, correlationID)
+	resp.int32(0) // throttle_time_ms
+	resp.int16(0) // error_code
+	resp.int32(0) // session_id
+	resp.arrayLen(1)
+	resp.string(topic)
+	resp.arrayLen(1)
+	resp.int32(0)
+	resp.int16(0)
+	resp.int64(100) // high_watermark
+	resp.int64(100) // last_stable_offset
+	resp.int64(0)   // log_start_offset
+	resp.arrayLen(0)
+	resp.int32(-1) // preferred_read_replica
+	resp.bytes(records)
+	resp.tags()
+	resp.tags()
+	resp.tags()
+	return resp.message()
+}
+
+func TestKafkaParser(t *testing.T) {
+	const (
+		client = tcp.TCPDirectionOriginal
+		server = tcp.TCPDirectionReverse
+	)
+	produce := produceResponse()
+
+	for _, test := range []struct {
+		title          string
+		packets        []packet
+		maxMessageSize int
+		expire         bool
+		expected       []common.MapStr
+	}{
+		{
+			title: "produce",
+			packets: []packet{
+				{client, produceRequest()},
+				{server, produce[:10]},
+				{server, produce[10:]},
+			},
+			expected: []common.MapStr{{
+				"type":                 "kafka",
+				"method":               "Produce",
+				"resource":             "orders",
+				"status":               common.ERROR_STATUS,
+				"kafka.api_key":        "Produce",
+				"kafka.api_version":    3,
+				"kafka.correlation_id": 7,
+				"kafka.client_id":      "producer-1",
+				"kafka.topics":         []string{"orders"},
+				"kafka.partitions":     []string{"orders-0", "orders-1"},
+				"kafka.records":        5,
+				"kafka.error_code":     6,
+				"kafka.error":          "NOT_LEADER_OR_FOLLOWER",
+			}},
+		},
+		{
+			title: "produce without acks",
+			packets: []packet{
+				{client, flexibleProduceRequest()},
+			},
+			expected: []common.MapStr{{
+				"status":           common.OK_STATUS,
+				"kafka.partitions": []string{"logs-2"},
+				"kafka.records":    10,
+				"error.message":    nil,
+			}},
+		},
+		{
+			title: "pipelined fetch",
+			packets: append(
+				[]packet{{client, append(fetchRequest(1, "orders"), fetchRequest(2, "payments")...)}},
+				chunks(server, append(
+					fetchResponse(1, "orders", append(recordBatch(2), recordBatch(3)...)),
+					fetchResponse(2, "payments", recordBatch(1))...,
+				), 50)...,
+			),
+			expected: []common.MapStr{
+				{
+					"status":               common.OK_STATUS,
+					"kafka.api_key":        "Fetch",
+					"kafka.correlation_id": 1,
+					"kafka.partitions":     []string{"orders-0"},
+					"kafka.records":        5,
+				},
+				{
+					"status":               common.OK_STATUS,
+					"kafka.api_key":        "Fetch",
+					"kafka.correlation_id": 2,
+					"kafka.partitions":     []string{"payments-0"},
+					"kafka.records":        1,
+				},
+			},
+		},
+		{
+			title:          "truncated",
+			maxMessageSize: 200,
+			packets: append(
+				[]packet{{client, append(fetchRequest(1, "orders"), fetchRequest(2, "orders")...)}},
+				chunks(server, append(
+					fetchResponse(1, "orders", append(recordBatch(1), recordBatch(50)...)),
+					fetchResponse(2, "orders", recordBatch(1))...,
+				), 100)...,
+			),
+			expected: []common.MapStr{
+				{
+					"kafka.records": 1,
+					"error.message": "Message truncated",
+				},
+				{
+					"kafka.correlation_id": 2,
+					"kafka.records":        1,
+				},
+			},
+		},
+		{
+			title: "expired",
+			packets: []packet{
+				{client, metadataRequest()},
+			},
+			expire: true,
+			expected: []common.MapStr{{
+				"status":        common.ERROR_STATUS,
+				"error.message": "Unmatched request",
+				"kafka.topics":  []string{"orders", "payments"},
+			}},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			kafka := kafkaModForTests(&store)
+			if test.maxMessageSize != 0 {
+				kafka.maxMessageSize = test.maxMessageSize
+			}
+			tcptuple := testTCPTuple()
+			ts := time.Now()
+
+			var private protos.ProtocolData
+			for i, p := range test.packets {
+				pkt := protos.Packet{Ts: ts.Add(time.Duration(i) * time.Millisecond), Payload: p.data}
+				private = kafka.Parse(&pkt, tcptuple, p.dir, private)
+			}
+			if test.expire {
+				kafka.Expired(tcptuple, private)
+			}
+
+			if !assert.Len(t, store.events, len(test.expected)) {
+				return
+			}
+			for i, expected := range test.expected {
+				for field, value := range expected {
+					actual, err := store.events[i].GetValue(field)
+					if value == nil {
+						assert.Equal(t, common.ErrKeyNotFound, err, field)
+						continue
+					}
+					assert.NoError(t, err, field)
+					assert.EqualValues(t, value, actual, field)
+				}
+			}
+		})
+	}
+}
+
+func TestKafka_clientDirection(t *testing.T) {
+	kafka := kafkaModForTests(nil)
+	kafka.ports = []int{9092}
+
+	tuple := testTCPTuple()
+	assert.Equal(t, uint8(tcp.TCPDirectionOriginal), kafka.newConnectionData(tuple).clientDir)
+
+	// first packet seen sent by the broker
+	tuple.SrcPort, tuple.DstPort = tuple.DstPort, tuple.SrcPort
+	assert.Equal(t, uint8(tcp.TCPDirectionReverse), kafka.newConnectionData(tuple).clientDir)
+}
@@ -0,0 +1,531 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kerberos
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+const (
+	// Messages sent over TCP are prefixed by their length. The high bit of
+	// the length is reserved for extensions.
+	recordHeaderLen = 4
+	reservedBit     = 0x80000000
+
+	maxMessageSize     = tcp.TCPMaxDataInStream
+	maxPendingRequests = 16
+)
+
+type stream struct {
+	data    []byte
+	ts      time.Time
+	capture pb.Capture
+}
+
+type connection struct {
+	streams [2]stream
+
+	// requests waiting for their reply, in order
+	requests []*message
+}
+
+// Kerberos protocol plugin
+type kerberosPlugin struct {
+	// config
+	ports              []int
+	transactionTimeout time.Duration
+
+	// requests sent over UDP waiting for their reply, by client tuple
+	udpRequests *common.Cache
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("kerberos")
+	isDebug = false
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "kerberos.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "kerberos.unmatched_responses")
+)
+
+func init() {
+	protos.Register("kerberos", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &kerberosPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (krb *kerberosPlugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *kerberosConfig) error {
+	krb.setFromConfig(config)
+	krb.udpRequests = common.NewCacheWithRemovalListener(
+		krb.transactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			requ, ok := v.(*message)
+			if !ok {
+				logp.Err("Expired value is not a *kerberos.message.")
+				return
+			}
+			unmatchedRequests.Add(1)
+			krb.publishTransaction(requ, nil)
+		})
+	krb.udpRequests.StartJanitor(krb.transactionTimeout)
+
+	krb.results = results
+	krb.watcher = watcher
+	isDebug = logp.IsDebug("kerberos")
+
+	return nil
+}
+
+func (krb *kerberosPlugin) setFromConfig(config *kerberosConfig) {
+	krb.ports = config.Ports
+	krb.transactionTimeout = config.TransactionTimeout
+}
+
+func (krb *kerberosPlugin) GetPorts() []int {
+	return krb.ports
+}
+
+func (krb *kerberosPlugin) ConnectionTimeout() time.Duration {
+	return krb.transactionTimeout
+}
+
+// DetectTCP recognizes the request a client starts a connection with.
+func (krb *kerberosPlugin) DetectTCP(data []byte) protos.Detection {
+	if len(data) < recordHeaderLen {
+		return protos.DetectionNeedMore
+	}
+	length := common.BytesNtohl(data)
+	if length&reservedBit != 0 || length == 0 {
+		return protos.DetectionMismatch
+	}
+	return detectRequest(data[recordHeaderLen:])
+}
+
+// DetectUDP recognizes requests sent to a KDC over UDP.
+func (krb *kerberosPlugin) DetectUDP(data []byte) protos.Detection {
+	if detectRequest(data) == protos.DetectionMatch {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
+var pvno5 = []byte{0xa1, 0x03, 0x02, 0x01, 0x05}
+
+// detectRequest recognizes the start of an AS-REQ or TGS-REQ: the
+// application tag, the sequence and the protocol version 5.
+func detectRequest(data []byte) protos.Detection {
+	if len(data) == 0 {
+		return protos.DetectionNeedMore
+	}
+	if data[0] != 0x60|msgASReq && data[0] != 0x60|msgTGSReq {
+		return protos.DetectionMismatch
+	}
+	rest := data
+	for _, id := range []byte{data[0], 0x30} {
+		if len(rest) < 2 {
+			return protos.DetectionNeedMore
+		}
+		if rest[0] != id {
+			return protos.DetectionMismatch
+		}
+		hdrLen := 2
+		if rest[1]&0x80 != 0 {
+			hdrLen += int(rest[1] & 0x7f)
+			if hdrLen == 2 || hdrLen > 6 {
+				return protos.DetectionMismatch
+			}
+		}
+		if len(rest) < hdrLen {
+			return protos.DetectionNeedMore
+		}
+		rest = rest[hdrLen:]
+	}
+	if len(rest) < len(pvno5) {
+		if bytes.HasPrefix(pvno5, rest) {
+			return protos.DetectionNeedMore
+		}
+		return protos.DetectionMismatch
+	}
+	if !bytes.HasPrefix(rest, pvno5) {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
+func (krb *kerberosPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseKerberos exception")
+
+	conn := ensureConnection(private)
+	krb.doParse(conn, pkt, tcptuple, dir)
+	return conn
+}
+
+func ensureConnection(private protos.ProtocolData) *connection {
+	if private == nil {
+		return &connection{}
+	}
+
+	priv, ok := private.(*connection)
+	if !ok {
+		logp.Warn("kerberos connection data type error, create new one")
+		return &connection{}
+	}
+	if priv == nil {
+		logp.Warn("Unexpected: kerberos connection data not set, create new one")
+		return &connection{}
+	}
+
+	return priv
+}
+
+func (krb *kerberosPlugin) doParse(
+	conn *connection,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	st := &conn.streams[dir]
+	if len(st.data) == 0 {
+		st.ts, st.capture = pkt.Ts, pkt.Capture
+	}
+	st.data = append(st.data, pkt.Payload...)
+
+	for len(st.data) >= recordHeaderLen {
+		length := common.BytesNtohl(st.data)
+		if length&reservedBit != 0 || length > maxMessageSize {
+			if isDebug {
+				debugf("invalid record length %d, dropping stream data", length)
+			}
+			*st = stream{}
+			return
+		}
+		total := recordHeaderLen + int(length)
+		if len(st.data) < total {
+			// wait for more data
+			break
+		}
+
+		m := &message{
+			ts:        st.ts,
+			capture:   st.capture,
+			tuple:     tcptuple.BaseTuple,
+			direction: dir,
+			transport: "tcp",
+			size:      total,
+		}
+		decodeMessage(st.data[recordHeaderLen:total], m)
+		st.data = st.data[total:]
+		st.ts, st.capture = pkt.Ts, pkt.Capture
+
+		if isRequest(m.msgType) {
+			m.cmdlineTuple = krb.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		}
+		krb.handleMessage(conn, m)
+	}
+	if len(st.data) == 0 {
+		st.data = nil
+	}
+}
+
+func decodeMessage(data []byte, m *message) {
+	// The message type is known from the application tag even if the rest
+	// of the message can't be decoded.
+	if len(data) > 0 && data[0]&0xe0 == 0x60 {
+		m.msgType = int(data[0] & 0x1f)
+	}
+	err := parseMessage(data, m)
+	m.invalid = err != nil
+	if isDebug {
+		debugf("%s message of %d bytes, err=%v", messageTypes[m.msgType], m.size, err)
+	}
+}
+
+func (krb *kerberosPlugin) handleMessage(conn *connection, m *message) {
+	switch {
+	case isRequest(m.msgType):
+		if len(conn.requests) >= maxPendingRequests {
+			if isDebug {
+				debugf("too many pending requests, ignoring request")
+			}
+			unmatchedRequests.Add(1)
+			return
+		}
+		conn.requests = append(conn.requests, m)
+
+	case messageTypes[m.msgType] != "":
+		if len(conn.requests) == 0 {
+			if isDebug {
+				debugf("%s without request", messageTypes[m.msgType])
+			}
+			unmatchedResponses.Add(1)
+			return
+		}
+		requ := conn.requests[0]
+		conn.requests = conn.requests[1:]
+		krb.publishTransaction(requ, m)
+
+	default:
+		if isDebug {
+			debugf("ignoring message of unknown type %d", m.msgType)
+		}
+	}
+}
+
+// ParseUDP parses the requests and replies exchanged with a KDC over UDP.
+// Clients send one request at a time from a port, the reply is matched
+// by the tuple.
+func (krb *kerberosPlugin) ParseUDP(pkt *protos.Packet) {
+	defer logp.Recover("ParseUDP kerberos exception")
+
+	m := &message{
+		ts:        pkt.Ts,
+		capture:   pkt.Capture,
+		tuple:     pkt.Tuple.BaseTuple,
+		direction: tcp.TCPDirectionOriginal,
+		transport: "udp",
+		size:      len(pkt.Payload),
+	}
+	decodeMessage(pkt.Payload, m)
+
+	switch {
+	case isRequest(m.msgType):
+		m.cmdlineTuple = krb.watcher.FindProcessesTupleUDP(&pkt.Tuple)
+		if old := krb.udpRequests.Put(pkt.Tuple.Hashable(), m); old != nil {
+			unmatchedRequests.Add(1)
+			krb.publishTransaction(old.(*message), nil)
+		}
+
+	case messageTypes[m.msgType] != "":
+		key := pkt.Tuple.RevHashable()
+		v := krb.udpRequests.Delete(key)
+		if v == nil {
+			unmatchedResponses.Add(1)
+			return
+		}
+		krb.publishTransaction(v.(*message), m)
+
+	default:
+		if isDebug {
+			debugf("invalid datagram %s", pkt.Tuple.String())
+		}
+	}
+}
+
+func (krb *kerberosPlugin) publishTransaction(requ, resp *message) {
+	if krb.results == nil {
+		return
+	}
+	krb.results(krb.newTransaction(requ, resp))
+}
+
+func (krb *kerberosPlugin) newTransaction(requ, resp *message) beat.Event {
+	source, destination := common.MakeEndpointPair(requ.tuple, requ.cmdlineTuple)
+	src, dst := &source, &destination
+	if requ.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(requ.ts)
+	pbf.SetSource(src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(dst)
+	pbf.AddIP(dst.IP)
+	pbf.Source.Bytes = int64(requ.size)
+	pbf.Event.Dataset = "kerberos"
+	pbf.Event.Start = requ.ts
+	pbf.Network.Transport = requ.transport
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&requ.capture)
+
+	method := "AS"
+	if requ.msgType == msgTGSReq {
+		method = "TGS"
+	}
+	pbf.Event.Action = "kerberos." + strings.ToLower(method)
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = method
+
+	info := common.MapStr{
+		"request_type": messageTypes[requ.msgType],
+	}
+
+	// The client is only named by AS requests, the replies name it too.
+	client, clientRealm := requ.client, requ.clientRealm
+	service, serviceRealm := requ.service, requ.serviceRealm
+	if resp != nil {
+		if client == "" {
+			client, clientRealm = resp.client, resp.clientRealm
+		}
+		if service == "" {
+			service, serviceRealm = resp.service, resp.serviceRealm
+		}
+	}
+	if client != "" {
+		info["client"] = client
+		evt.Fields.Put("user.name", client)
+		pbf.AddUser(client)
+	}
+	if clientRealm != "" {
+		info["client_realm"] = clientRealm
+		evt.Fields.Put("user.domain", clientRealm)
+	}
+	if service != "" {
+		info["service"] = service
+		fields["resource"] = service
+	}
+	if serviceRealm != "" {
+		info["realm"] = serviceRealm
+	}
+
+	if options := kdcOptions(requ.kdcOptions); len(options) > 0 {
+		info["kdc_options"] = options
+	}
+	if len(requ.etypes) > 0 {
+		names := make([]string, len(requ.etypes))
+		for i, etype := range requ.etypes {
+			names[i] = encryptionTypeName(etype)
+		}
+		info["encryption_types"] = names
+	}
+	if len(requ.padataTypes) > 0 {
+		names := make([]string, len(requ.padataTypes))
+		for i, typ := range requ.padataTypes {
+			names[i] = paDataTypeName(typ)
+		}
+		info["padata_types"] = names
+	}
+
+	status := common.OK_STATUS
+	var notes []string
+	if requ.invalid {
+		notes = append(notes, "Failed to decode request")
+	}
+
+	if resp != nil {
+		pbf.AddCapture(&resp.capture)
+		pbf.Event.End = resp.ts
+		pbf.Destination.Bytes = int64(resp.size)
+		if resp.invalid {
+			notes = append(notes, "Failed to decode response")
+		}
+
+		info["response_type"] = messageTypes[resp.msgType]
+		if resp.msgType == msgKRBError {
+			info["error_code"] = resp.errorCode
+			info["error"] = errorName(resp.errorCode)
+			if resp.errorText != "" {
+				info["error_text"] = resp.errorText
+			}
+			if !isExpectedError(resp.errorCode) {
+				status = common.ERROR_STATUS
+				pbf.Event.Outcome = "failure"
+			}
+		} else if !resp.invalid {
+			info["ticket_encryption_type"] = encryptionTypeName(resp.ticketEType)
+			info["reply_encryption_type"] = encryptionTypeName(resp.replyEType)
+		}
+	} else {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched request")
+	}
+
+	fields["status"] = status
+	fields["kerberos"] = info
+	pbf.Error.Message = notes
+	return evt
+}
+
+func (krb *kerberosPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	// The message boundaries are lost. Publish the pending requests before
+	// the connection state is dropped.
+	if conn, ok := private.(*connection); ok && conn != nil {
+		krb.flushRequests(conn)
+	}
+	return private, true
+}
+
+func (krb *kerberosPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	return private
+}
+
+// Expired publishes the requests still waiting for a reply when the
+// connection expires.
+func (krb *kerberosPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	krb.flushRequests(conn)
+}
+
+func (krb *kerberosPlugin) flushRequests(conn *connection) {
+	requests := conn.requests
+	conn.requests = nil
+	for _, m := range requests {
+		unmatchedRequests.Add(1)
+		krb.publishTransaction(m, nil)
+	}
+}
@@ -0,0 +1,387 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package kerberos
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func kerberosModForTests(store *eventStore) *kerberosPlugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	krb, err := New(false, callback, procs.ProcessesWatcher{}, common.NewConfig())
+	if err != nil {
+		panic(err)
+	}
+	return krb.(*kerberosPlugin)
+}
+
+func mustMarshal(v interface{}, params string) []byte {
+	b, err := asn1.MarshalWithParams(v, params)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// explicit wraps an encoded element in a context-specific tag. The asn1
+// package doesn't add the tags of RawValue fields itself.
+func explicit(tag int, b []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, IsCompound: true, Bytes: b}
+}
+
+func kerberosTime() asn1.RawValue {
+	t := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	return explicit(5, mustMarshal(t, "generalized"))
+}
+
+func principal(names ...string) principalName {
+	return principalName{NameType: 1, NameString: names}
+}
+
+// forwardable, renewable, canonicalize and renewable-ok
+var testOptions = asn1.BitString{Bytes: []byte{0x40, 0x81, 0x00, 0x10}, BitLength: 32}
+
+func request(msgType int, client principalName, service principalName, padata ...paData) []byte {
+	req := kdcReq{
+		PVNO:    5,
+		MsgType: msgType,
+		PAData:  padata,
+		ReqBody: kdcReqBody{
+			Options: testOptions,
+			CName:   client,
+			Realm:   "EXAMPLE.COM",
+			SName:   service,
+			Till:    kerberosTime(),
+			Nonce:   1234567,
+			EType:   []int32{18, 17, 23},
+		},
+	}
+	return mustMarshal(req, fmt.Sprintf("application,explicit,tag:%d", msgType))
+}
+
+func reply(msgType int, client principalName, service principalName) []byte {
+	tkt := ticket{
+		TktVNO:  5,
+		Realm:   "EXAMPLE.COM",
+		SName:   service,
+		EncPart: encryptedData{EType: 18, KVNO: 2, Cipher: []byte("ticket")},
+	}
+	rep := kdcRep{
+		PVNO:    5,
+		MsgType: msgType,
+		CRealm:  "EXAMPLE.COM",
+		CName:   client,
+		Ticket:  explicit(5, mustMarshal(tkt, "application,explicit,tag:1")),
+		EncPart: encryptedData{EType: 23, Cipher: []byte("reply")},
+	}
+	return mustMarshal(rep, fmt.Sprintf("application,explicit,tag:%d", msgType))
+}
+
+func krbErrorMessage(code int32, text string, client principalName, service principalName) []byte {
+	e := krbError{
+		PVNO:      5,
+		MsgType:   msgKRBError,
+		STime:     explicit(4, mustMarshal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), "generalized")),
+		SUsec:     12,
+		ErrorCode: code,
+		CRealm:    "EXAMPLE.COM",
+		CName:     client,
+		Realm:     "EXAMPLE.COM",
+		SName:     service,
+		EText:     text,
+	}
+	return mustMarshal(e, "application,explicit,tag:30")
+}
+
+// record prefixes a message with its length, as sent over TCP.
+func record(msg []byte) []byte {
+	n := len(msg)
+	return append([]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}, msg...)
+}
+
+// generalString changes the PrintableString encoding a string to a
+// GeneralString, used by most implementations.
+func generalString(msg []byte, s string) []byte {
+	printable := append([]byte{0x13, byte(len(s))}, s...)
+	general := append([]byte{0x1b, byte(len(s))}, s...)
+	return bytes.Replace(msg, printable, general, -1)
+}
+
+var (
+	krbtgt = principal("krbtgt", "EXAMPLE.COM")
+	alice  = principal("alice")
+	http   = principal("HTTP", "web.example.com")
+)
+
+func testTuples() (common.IPPortTuple, common.IPPortTuple) {
+	tuple := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 51000, DstPort: 88,
+		},
+	}
+	reversed := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP: tuple.DstIP, DstIP: tuple.SrcIP,
+			SrcPort: tuple.DstPort, DstPort: tuple.SrcPort,
+		},
+	}
+	return tuple, reversed
+}
+
+// segment is the payload of a TCP segment sent in a direction.
+type segment struct {
+	dir  uint8
+	data []byte
+}
+
+func client(data ...[]byte) segment {
+	return segment{tcp.TCPDirectionOriginal, bytes.Join(data, nil)}
+}
+
+func server(data ...[]byte) segment {
+	return segment{tcp.TCPDirectionReverse, bytes.Join(data, nil)}
+}
+
+// assertEvents checks the fields of the published events. A nil value
+// expects the field to be absent.
+func assertEvents(t *testing.T, expected []common.MapStr, events []beat.Event) {
+	if !assert.Len(t, events, len(expected)) {
+		return
+	}
+	for i, fields := range expected {
+		for field, value := range fields {
+			actual, err := events[i].GetValue(field)
+			if value == nil {
+				assert.Equal(t, common.ErrKeyNotFound, err, field)
+				continue
+			}
+			assert.NoError(t, err, field)
+			assert.EqualValues(t, value, actual, field)
+		}
+	}
+}
+
+func TestDetectKerberos(t *testing.T) {
+	krb := kerberosModForTests(nil)
+
+	asReq := request(msgASReq, alice, krbtgt)
+	assert.Equal(t, protos.DetectionMatch, krb.DetectUDP(asReq))
+	assert.Equal(t, protos.DetectionMatch, krb.DetectTCP(record(asReq)))
+	assert.Equal(t, protos.DetectionMatch, krb.DetectTCP(record(request(msgTGSReq, principalName{}, http))[:16]))
+	assert.Equal(t, protos.DetectionNeedMore, krb.DetectTCP(record(asReq)[:8]))
+	assert.Equal(t, protos.DetectionMismatch, krb.DetectUDP(asReq[:8]))
+
+	// replies and other protocol versions
+	assert.Equal(t, protos.DetectionMismatch, krb.DetectUDP(reply(msgASRep, alice, krbtgt)))
+	v4 := bytes.Replace(asReq, pvno5, []byte{0xa1, 0x03, 0x02, 0x01, 0x04}, 1)
+	assert.Equal(t, protos.DetectionMismatch, krb.DetectUDP(v4))
+	assert.Equal(t, protos.DetectionMismatch, krb.DetectTCP([]byte{0x80, 0, 0, 1, 0x6a}))
+	assert.Equal(t, protos.DetectionMismatch, krb.DetectTCP([]byte("GET / HTTP/1.1\r\n")))
+}
+
+func TestKdcOptions(t *testing.T) {
+	assert.Equal(t, []string{"forwardable", "renewable", "canonicalize", "renewable_ok"}, kdcOptions(testOptions))
+	assert.Empty(t, kdcOptions(asn1.BitString{}))
+}
+
+func TestKerberos_asExchange(t *testing.T) {
+	var store eventStore
+	krb := kerberosModForTests(&store)
+	tuple, reversed := testTuples()
+	ts := time.Now()
+
+	// The first request is refused, asking for pre-authentication.
+	asReq := generalString(request(msgASReq, alice, krbtgt), "alice")
+	krb.ParseUDP(&protos.Packet{Ts: ts, Tuple: tuple, Payload: asReq})
+	assert.Empty(t, store.events)
+	krbErr := krbErrorMessage(kdcErrPreauthRequired, "", alice, krbtgt)
+	krb.ParseUDP(&protos.Packet{Ts: ts.Add(time.Millisecond), Tuple: reversed, Payload: krbErr})
+
+	tuple.SrcPort++
+	reversed.DstPort++
+	asReq = request(msgASReq, alice, krbtgt,
+		paData{Type: 2, Value: []byte("timestamp")}, paData{Type: 128, Value: []byte{0x30, 0x00}})
+	krb.ParseUDP(&protos.Packet{Ts: ts.Add(2 * time.Millisecond), Tuple: tuple, Payload: asReq})
+	asRep := reply(msgASRep, alice, krbtgt)
+	krb.ParseUDP(&protos.Packet{Ts: ts.Add(3 * time.Millisecond), Tuple: reversed, Payload: asRep})
+
+	assertEvents(t, []common.MapStr{
+		{
+			"type":                   "kerberos",
+			"method":                 "AS",
+			"event.action":           "kerberos.as",
+			"network.transport":      "udp",
+			"user.name":              "alice",
+			"user.domain":            "EXAMPLE.COM",
+			"resource":               "krbtgt/EXAMPLE.COM",
+			"kerberos.response_type": "KRB-ERROR",
+			"kerberos.error":         "KDC_ERR_PREAUTH_REQUIRED",
+			"status":                 common.OK_STATUS,
+			"event.outcome":          nil,
+		},
+		{
+			"source.ip":                       "192.168.0.1",
+			"source.bytes":                    len(asReq),
+			"destination.bytes":               len(asRep),
+			"kerberos.request_type":           "AS-REQ",
+			"kerberos.response_type":          "AS-REP",
+			"kerberos.client":                 "alice",
+			"kerberos.realm":                  "EXAMPLE.COM",
+			"kerberos.kdc_options":            []string{"forwardable", "renewable", "canonicalize", "renewable_ok"},
+			"kerberos.encryption_types":       []string{"aes256-cts-hmac-sha1-96", "aes128-cts-hmac-sha1-96", "rc4-hmac"},
+			"kerberos.padata_types":           []string{"PA-ENC-TIMESTAMP", "PA-PAC-REQUEST"},
+			"kerberos.ticket_encryption_type": "aes256-cts-hmac-sha1-96",
+			"kerberos.reply_encryption_type":  "rc4-hmac",
+			"status":                          common.OK_STATUS,
+		},
+	}, store.events)
+}
+
+func TestKerberosParser(t *testing.T) {
+	tgsReq := record(request(msgTGSReq, principalName{}, http, paData{Type: 1, Value: []byte("ap-req")}))
+	tgsRep := record(reply(msgTGSRep, alice, http))
+
+	// a request cut short is still matched with its reply
+	asReq := request(msgASReq, alice, krbtgt)
+	invalid := append([]byte{asReq[0], 0x03}, asReq[2:5]...)
+
+	for _, test := range []struct {
+		title    string
+		segments []segment
+		expire   bool
+		expected []common.MapStr
+	}{
+		{
+			title: "tgs exchange",
+			segments: []segment{
+				client(tgsReq[:3]),
+				client(tgsReq[3:20]),
+				client(tgsReq[20:]),
+				server(tgsRep[:30]),
+				server(tgsRep[30:]),
+			},
+			expected: []common.MapStr{{
+				"method":            "TGS",
+				"network.transport": "tcp",
+				"resource":          "HTTP/web.example.com",
+				"kerberos.service":  "HTTP/web.example.com",
+				// the client is named by the reply only
+				"user.name":             "alice",
+				"kerberos.client_realm": "EXAMPLE.COM",
+				"kerberos.padata_types": []string{"PA-TGS-REQ"},
+				"source.bytes":          len(tgsReq),
+				"destination.bytes":     len(tgsRep),
+				"status":                common.OK_STATUS,
+			}},
+		},
+		{
+			title: "errors",
+			segments: []segment{
+				// reply without request
+				server(record(reply(msgTGSRep, alice, http))),
+				// two requests sent on the connection, the second one left pending
+				client(
+					record(request(msgTGSReq, principalName{}, principal("cifs", "unknown.example.com"))),
+					record(request(msgTGSReq, principalName{}, http))),
+				server(record(krbErrorMessage(7, "Server not found in Kerberos database", alice,
+					principal("cifs", "unknown.example.com")))),
+			},
+			expire: true,
+			expected: []common.MapStr{
+				{
+					"resource":            "cifs/unknown.example.com",
+					"user.name":           "alice",
+					"kerberos.error_code": 7,
+					"kerberos.error":      "KDC_ERR_S_PRINCIPAL_UNKNOWN",
+					"kerberos.error_text": "Server not found in Kerberos database",
+					"status":              common.ERROR_STATUS,
+					"event.outcome":       "failure",
+				},
+				{
+					"resource":      "HTTP/web.example.com",
+					"status":        common.ERROR_STATUS,
+					"error.message": "Unmatched request",
+				},
+			},
+		},
+		{
+			title: "invalid message",
+			segments: []segment{
+				client(record(invalid)),
+				server(record(krbErrorMessage(24, "", alice, krbtgt))),
+			},
+			expected: []common.MapStr{{
+				"method":         "AS",
+				"user.name":      "alice",
+				"kerberos.error": "KDC_ERR_PREAUTH_FAILED",
+				"error.message":  "Failed to decode request",
+				"event.outcome":  "failure",
+			}},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			krb := kerberosModForTests(&store)
+			tuple, _ := testTuples()
+			tcptuple := &common.TCPTuple{IPLength: 4, BaseTuple: tuple.BaseTuple}
+			tcptuple.ComputeHashables()
+			ts := time.Now()
+
+			var private protos.ProtocolData
+			for i, s := range test.segments {
+				pkt := protos.Packet{Ts: ts.Add(time.Duration(i) * time.Millisecond), Payload: s.data}
+				private = krb.Parse(&pkt, tcptuple, s.dir, private)
+			}
+			if test.expire {
+				krb.Expired(tcptuple, private)
+			}
+
+			assertEvents(t, test.expected, store.events)
+		})
+	}
+}
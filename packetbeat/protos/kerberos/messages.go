@@ -0,0 +1,256 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kerberos
+
+import (
+	"encoding/asn1"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+// Message types, the application tag of the messages exchanged with the
+// KDC.
+const (
+	msgASReq    = 10
+	msgASRep    = 11
+	msgTGSReq   = 12
+	msgTGSRep   = 13
+	msgKRBError = 30
+)
+
+var messageTypes = map[int]string{
+	msgASReq:    "AS-REQ",
+	msgASRep:    "AS-REP",
+	msgTGSReq:   "TGS-REQ",
+	msgTGSRep:   "TGS-REP",
+	msgKRBError: "KRB-ERROR",
+}
+
+var errUnknownMessage = errors.New("unknown Kerberos message")
+
+func isRequest(msgType int) bool {
+	return msgType == msgASReq || msgType == msgTGSReq
+}
+
+type message struct {
+	ts           time.Time
+	capture      pb.Capture
+	tuple        common.BaseTuple
+	cmdlineTuple *common.ProcessTuple
+	direction    uint8
+	transport    string
+	size         int
+	invalid      bool
+
+	msgType int
+
+	// principals of the client and of the service, without the realm
+	client       string
+	clientRealm  string
+	service      string
+	serviceRealm string
+
+	// request
+	kdcOptions  asn1.BitString
+	etypes      []int32
+	padataTypes []int32
+
+	// reply, the encryption types of the ticket and of the part of the
+	// reply for the client
+	ticketEType int32
+	replyEType  int32
+
+	// error
+	errorCode int32
+	errorText string
+}
+
+// The structures of RFC 4120 decoded. The fields not reported are left
+// raw, or not decoded when at the end of a structure.
+
+type principalName struct {
+	NameType   int32    `asn1:"explicit,tag:0"`
+	NameString []string `asn1:"explicit,tag:1"`
+}
+
+func (p principalName) String() string {
+	return strings.Join(p.NameString, "/")
+}
+
+type paData struct {
+	Type  int32  `asn1:"explicit,tag:1"`
+	Value []byte `asn1:"explicit,tag:2"`
+}
+
+type encryptedData struct {
+	EType  int32  `asn1:"explicit,tag:0"`
+	KVNO   int64  `asn1:"optional,explicit,tag:1"`
+	Cipher []byte `asn1:"explicit,tag:2"`
+}
+
+type kdcReq struct {
+	PVNO    int        `asn1:"explicit,tag:1"`
+	MsgType int        `asn1:"explicit,tag:2"`
+	PAData  []paData   `asn1:"optional,explicit,tag:3"`
+	ReqBody kdcReqBody `asn1:"explicit,tag:4"`
+}
+
+type kdcReqBody struct {
+	Options asn1.BitString `asn1:"explicit,tag:0"`
+	CName   principalName  `asn1:"optional,explicit,tag:1"`
+	Realm   string         `asn1:"explicit,tag:2"`
+	SName   principalName  `asn1:"optional,explicit,tag:3"`
+	From    asn1.RawValue  `asn1:"optional,explicit,tag:4"`
+	Till    asn1.RawValue  `asn1:"explicit,tag:5"`
+	RTime   asn1.RawValue  `asn1:"optional,explicit,tag:6"`
+	Nonce   int64          `asn1:"explicit,tag:7"`
+	EType   []int32        `asn1:"explicit,tag:8"`
+}
+
+type kdcRep struct {
+	PVNO    int           `asn1:"explicit,tag:0"`
+	MsgType int           `asn1:"explicit,tag:1"`
+	PAData  []paData      `asn1:"optional,explicit,tag:2"`
+	CRealm  string        `asn1:"explicit,tag:3"`
+	CName   principalName `asn1:"explicit,tag:4"`
+	Ticket  asn1.RawValue `asn1:"explicit,tag:5"`
+	EncPart encryptedData `asn1:"explicit,tag:6"`
+}
+
+// ticket is encoded as [APPLICATION 1].
+type ticket struct {
+	TktVNO  int           `asn1:"explicit,tag:0"`
+	Realm   string        `asn1:"explicit,tag:1"`
+	SName   principalName `asn1:"explicit,tag:2"`
+	EncPart encryptedData `asn1:"explicit,tag:3"`
+}
+
+type krbError struct {
+	PVNO      int           `asn1:"explicit,tag:0"`
+	MsgType   int           `asn1:"explicit,tag:1"`
+	CTime     asn1.RawValue `asn1:"optional,explicit,tag:2"`
+	CUsec     int           `asn1:"optional,explicit,tag:3"`
+	STime     asn1.RawValue `asn1:"explicit,tag:4"`
+	SUsec     int           `asn1:"explicit,tag:5"`
+	ErrorCode int32         `asn1:"explicit,tag:6"`
+	CRealm    string        `asn1:"optional,explicit,tag:7"`
+	CName     principalName `asn1:"optional,explicit,tag:8"`
+	Realm     string        `asn1:"explicit,tag:9"`
+	SName     principalName `asn1:"explicit,tag:10"`
+	EText     string        `asn1:"optional,explicit,tag:11"`
+}
+
+// parseMessage decodes a message exchanged with the KDC.
+func parseMessage(data []byte, m *message) error {
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Class != asn1.ClassApplication || messageTypes[raw.Tag] == "" {
+		return errUnknownMessage
+	}
+	m.msgType = raw.Tag
+
+	switch m.msgType {
+	case msgASReq, msgTGSReq:
+		var req kdcReq
+		if _, err := asn1.Unmarshal(raw.Bytes, &req); err != nil {
+			return err
+		}
+		body := &req.ReqBody
+		if len(body.CName.NameString) > 0 {
+			m.client = body.CName.String()
+			m.clientRealm = body.Realm
+		}
+		m.service = body.SName.String()
+		m.serviceRealm = body.Realm
+		m.kdcOptions = body.Options
+		m.etypes = body.EType
+		for _, pa := range req.PAData {
+			m.padataTypes = append(m.padataTypes, pa.Type)
+		}
+
+	case msgASRep, msgTGSRep:
+		var rep kdcRep
+		if _, err := asn1.Unmarshal(raw.Bytes, &rep); err != nil {
+			return err
+		}
+		var tkt ticket
+		if _, err := asn1.UnmarshalWithParams(rep.Ticket.Bytes, &tkt, "application,explicit,tag:1"); err != nil {
+			return err
+		}
+		m.client = rep.CName.String()
+		m.clientRealm = rep.CRealm
+		m.service = tkt.SName.String()
+		m.serviceRealm = tkt.Realm
+		m.ticketEType = tkt.EncPart.EType
+		m.replyEType = rep.EncPart.EType
+
+	case msgKRBError:
+		var e krbError
+		if _, err := asn1.Unmarshal(raw.Bytes, &e); err != nil {
+			return err
+		}
+		if len(e.CName.NameString) > 0 {
+			m.client = e.CName.String()
+			m.clientRealm = e.CRealm
+		}
+		m.service = e.SName.String()
+		m.serviceRealm = e.Realm
+		m.errorCode = e.ErrorCode
+		m.errorText = e.EText
+	}
+	return nil
+}
+
+// kdcOptionNames are the names of the KDC options, by bit.
+var kdcOptionNames = map[int]string{
+	1:  "forwardable",
+	2:  "forwarded",
+	3:  "proxiable",
+	4:  "proxy",
+	5:  "allow_postdate",
+	6:  "postdated",
+	8:  "renewable",
+	11: "opt_hardware_auth",
+	14: "cname_in_addl_tkt",
+	15: "canonicalize",
+	16: "request_anonymous",
+	26: "disable_transited_check",
+	27: "renewable_ok",
+	28: "enc_tkt_in_skey",
+	30: "renew",
+	31: "validate",
+}
+
+func kdcOptions(options asn1.BitString) []string {
+	var names []string
+	for bit := 0; bit < options.BitLength; bit++ {
+		if options.At(bit) == 0 {
+			continue
+		}
+		if name, ok := kdcOptionNames[bit]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
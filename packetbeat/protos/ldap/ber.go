@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import "errors"
+
+// Identifier octets of the BER elements used by LDAP. The tag number of
+// the elements is always lower than 31, fitting in the identifier octet.
+const (
+	classMask        = 0xc0
+	classApplication = 0x40
+	classContext     = 0x80
+	constructed      = 0x20
+	tagMask          = 0x1f
+
+	idBoolean     = 0x01
+	idInteger     = 0x02
+	idOctetString = 0x04
+	idEnumerated  = 0x0a
+	idSequence    = 0x30
+	idSet         = 0x31
+)
+
+var (
+	errShortMessage   = errors.New("message too short")
+	errInvalidLength  = errors.New("invalid BER length")
+	errUnexpectedTag  = errors.New("unexpected BER tag")
+	errInvalidInteger = errors.New("invalid BER integer")
+)
+
+// element is a BER encoded value.
+type element struct {
+	id      byte
+	content []byte
+}
+
+// readHeader decodes the identifier and length octets of the element at the
+// start of data. It returns the length of the header and of the content,
+// hdrLen is 0 if more data is needed. LDAP only allows the definite form of
+// lengths, but some servers always use the long form.
+func readHeader(data []byte) (hdrLen, length int, err error) {
+	if len(data) < 2 {
+		return 0, 0, nil
+	}
+	if data[0]&tagMask == tagMask {
+		return 0, 0, errUnexpectedTag
+	}
+
+	b := data[1]
+	if b&0x80 == 0 {
+		return 2, int(b), nil
+	}
+	n := int(b & 0x7f)
+	if n == 0 || n > 4 {
+		// indefinite form, or too large
+		return 0, 0, errInvalidLength
+	}
+	if len(data) < 2+n {
+		return 0, 0, nil
+	}
+	for _, c := range data[2 : 2+n] {
+		length = length<<8 | int(c)
+	}
+	return 2 + n, length, nil
+}
+
+// decoder reads the elements of a constructed element. The first error is
+// sticky, elements read after an error are empty. The available content of
+// a truncated element is returned with errShortMessage.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+	d.buf = nil
+}
+
+// more returns true if elements are left to read.
+func (d *decoder) more() bool {
+	return d.err == nil && len(d.buf) > 0
+}
+
+// peek returns the identifier of the next element, 0 if there is none.
+func (d *decoder) peek() byte {
+	if !d.more() {
+		return 0
+	}
+	return d.buf[0]
+}
+
+func (d *decoder) next() element {
+	if d.err != nil {
+		return element{}
+	}
+	hdrLen, length, err := readHeader(d.buf)
+	if err == nil && hdrLen == 0 {
+		err = errShortMessage
+	}
+	if err != nil {
+		d.fail(err)
+		return element{}
+	}
+
+	e := element{id: d.buf[0]}
+	if end := hdrLen + length; end <= len(d.buf) {
+		e.content = d.buf[hdrLen:end]
+		d.buf = d.buf[end:]
+	} else {
+		e.content = d.buf[hdrLen:]
+		d.fail(errShortMessage)
+	}
+	return e
+}
+
+// expect reads the next element, which must have the given identifier.
+func (d *decoder) expect(id byte) []byte {
+	if d.more() && d.buf[0] != id {
+		d.fail(errUnexpectedTag)
+	}
+	return d.next().content
+}
+
+// optional reads the next element if it has the given identifier.
+func (d *decoder) optional(id byte) ([]byte, bool) {
+	if d.peek() != id {
+		return nil, false
+	}
+	return d.next().content, true
+}
+
+// enter returns a decoder of the elements of the next element, which must
+// have the given identifier. The errors of the returned decoder are merged
+// by calling join.
+func (d *decoder) enter(id byte) *decoder {
+	return &decoder{buf: d.expect(id)}
+}
+
+// join keeps the first error of a decoder returned by enter.
+func (d *decoder) join(sub *decoder) {
+	if sub.err != nil && d.err == nil {
+		d.err = sub.err
+	}
+}
+
+func (d *decoder) string() string {
+	return string(d.expect(idOctetString))
+}
+
+func (d *decoder) integer() int64 {
+	return d.intValue(d.expect(idInteger))
+}
+
+func (d *decoder) enumerated() int64 {
+	return d.intValue(d.expect(idEnumerated))
+}
+
+func (d *decoder) boolean() bool {
+	b := d.expect(idBoolean)
+	return len(b) > 0 && b[0] != 0
+}
+
+func (d *decoder) intValue(b []byte) int64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(b) == 0 || len(b) > 8 {
+		d.fail(errInvalidInteger)
+		return 0
+	}
+	v := int64(int8(b[0]))
+	for _, c := range b[1:] {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
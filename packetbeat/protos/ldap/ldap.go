@@ -0,0 +1,640 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+type connection struct {
+	streams [2]applayer.FramedStream
+
+	// requests waiting for their response, by message ID
+	requests map[int64]*message
+
+	// name of the user authenticated by the last successful bind
+	user string
+}
+
+// udpRequestKey identifies a request sent over UDP by the tuple of the
+// client and the message ID.
+type udpRequestKey struct {
+	tuple common.HashableIPPortTuple
+	id    int64
+}
+
+// LDAP protocol plugin
+type ldapPlugin struct {
+	// config
+	ports              []int
+	maxMessageSize     int
+	maxPendingRequests int
+	transactionTimeout time.Duration
+
+	// requests sent over UDP waiting for their response
+	udpRequests *common.Cache
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("ldap")
+	isDebug = false
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "ldap.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "ldap.unmatched_responses")
+)
+
+func init() {
+	protos.Register("ldap", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &ldapPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (ldap *ldapPlugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *ldapConfig) error {
+	ldap.setFromConfig(config)
+	ldap.udpRequests = common.NewCacheWithRemovalListener(
+		ldap.transactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			requ, ok := v.(*message)
+			if !ok {
+				logp.Err("Expired value is not a *ldap.message.")
+				return
+			}
+			unmatchedRequests.Add(1)
+			ldap.publishTransaction(requ, nil)
+		})
+	ldap.udpRequests.StartJanitor(ldap.transactionTimeout)
+
+	ldap.results = results
+	ldap.watcher = watcher
+	isDebug = logp.IsDebug("ldap")
+
+	return nil
+}
+
+func (ldap *ldapPlugin) setFromConfig(config *ldapConfig) {
+	ldap.ports = config.Ports
+	ldap.maxMessageSize = config.MaxMessageSize
+	ldap.maxPendingRequests = config.MaxPendingRequests
+	ldap.transactionTimeout = config.TransactionTimeout
+}
+
+func (ldap *ldapPlugin) GetPorts() []int {
+	return ldap.ports
+}
+
+func (ldap *ldapPlugin) ConnectionTimeout() time.Duration {
+	return ldap.transactionTimeout
+}
+
+// DetectTCP recognizes the request a client starts a connection with.
+func (ldap *ldapPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectLDAP(data)
+}
+
+// DetectUDP recognizes connectionless LDAP requests.
+func (ldap *ldapPlugin) DetectUDP(data []byte) protos.Detection {
+	if detectLDAP(data) == protos.DetectionMatch {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
+func detectLDAP(data []byte) protos.Detection {
+	if len(data) == 0 {
+		return protos.DetectionNeedMore
+	}
+	if data[0] != idSequence {
+		return protos.DetectionMismatch
+	}
+	hdrLen, _, err := readHeader(data)
+	if err != nil {
+		return protos.DetectionMismatch
+	}
+	if hdrLen == 0 {
+		return protos.DetectionNeedMore
+	}
+
+	// message ID, followed by the identifier of the request
+	rest := data[hdrLen:]
+	if len(rest) < 2 {
+		return protos.DetectionNeedMore
+	}
+	if rest[0] != idInteger || rest[1] == 0 || rest[1] > 4 {
+		return protos.DetectionMismatch
+	}
+	idLen := 2 + int(rest[1])
+	if len(rest) <= idLen {
+		return protos.DetectionNeedMore
+	}
+	id := rest[idLen]
+	op := opCode(id & tagMask)
+	if id&classMask != classApplication || !op.isRequest() {
+		return protos.DetectionMismatch
+	}
+	primitive := op == opUnbindRequest || op == opDelRequest || op == opAbandonRequest
+	if (id&constructed == 0) != primitive {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
+func (ldap *ldapPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseLDAP exception")
+
+	conn := ensureConnection(private)
+	if ldap.doParse(conn, pkt, tcptuple, dir) {
+		return conn.upgradeToTLS()
+	}
+	return conn
+}
+
+func newConnectionData() *connection {
+	return &connection{requests: map[int64]*message{}}
+}
+
+func ensureConnection(private protos.ProtocolData) *connection {
+	if private == nil {
+		return newConnectionData()
+	}
+
+	priv, ok := private.(*connection)
+	if !ok {
+		logp.Warn("ldap connection data type error, create new one")
+		return newConnectionData()
+	}
+	if priv == nil {
+		logp.Warn("Unexpected: ldap connection data not set, create new one")
+		return newConnectionData()
+	}
+
+	return priv
+}
+
+// upgradeToTLS hands the connection over to the TLS analyzer after a
+// successful StartTLS operation, with the data not parsed yet.
+func (conn *connection) upgradeToTLS() *protos.ProtocolUpgrade {
+	upgrade := &protos.ProtocolUpgrade{Protocol: protos.Lookup("tls")}
+	for dir := range conn.streams {
+		upgrade.Pending[dir] = conn.streams[dir].Bytes()
+	}
+	return upgrade
+}
+
+// doParse parses the messages of a stream. It returns true once the
+// connection switches to TLS.
+func (ldap *ldapPlugin) doParse(
+	conn *connection,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) bool {
+	st := &conn.streams[dir]
+	if !st.Append(pkt.Payload, pkt.Ts, &pkt.Capture) {
+		return false
+	}
+
+	for {
+		// Messages larger than the limit are decoded from their first
+		// bytes, the rest is skipped.
+		f, ok, err := st.Next(readMessageHeader, ldap.maxMessageSize)
+		if err != nil {
+			if isDebug {
+				debugf("invalid message header, dropping stream data: %v", err)
+			}
+			return false
+		}
+		if !ok {
+			return false
+		}
+
+		m := &message{
+			ts:        f.Ts,
+			capture:   f.Capture,
+			tuple:     tcptuple.BaseTuple,
+			direction: dir,
+			transport: "tcp",
+			size:      f.Size,
+			truncated: f.Truncated,
+		}
+		decodeMessage(f.Data, m)
+
+		if m.op.isRequest() {
+			m.cmdlineTuple = ldap.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		}
+		if ldap.handleMessage(conn, m) {
+			return true
+		}
+	}
+}
+
+// readMessageHeader reads the header of the SEQUENCE holding a message.
+func readMessageHeader(data []byte) (hdrLen, length int, err error) {
+	hdrLen, length, err = readHeader(data)
+	if err == nil && data[0] != idSequence {
+		// Messages protected by a SASL security layer can't be decoded.
+		err = errUnexpectedTag
+	}
+	return hdrLen, length, err
+}
+
+func decodeMessage(data []byte, m *message) {
+	err := parseMessage(data, m)
+	// Truncated messages are only reported as invalid if the fields
+	// reported are missing.
+	m.invalid = err != nil && (!m.truncated || err != errShortMessage)
+	if isDebug {
+		debugf("%s message %d of %d bytes, err=%v", m.op, m.id, m.size, err)
+	}
+}
+
+// handleMessage correlates a message received on a connection. It returns
+// true if the message completes a successful StartTLS operation.
+func (ldap *ldapPlugin) handleMessage(conn *connection, m *message) bool {
+	switch {
+	case m.op.isRequest():
+		if m.op == opBindRequest {
+			// the connection is anonymous until the bind succeeds
+			conn.user = ""
+		}
+		m.user = conn.user
+		if isOneWay(m.op) {
+			ldap.publishTransaction(m, nil)
+			return false
+		}
+
+		if old := conn.requests[m.id]; old != nil {
+			if isDebug {
+				debugf("duplicate message ID %d, dropping old request", m.id)
+			}
+			unmatchedRequests.Add(1)
+			ldap.publishTransaction(old, nil)
+		} else if len(conn.requests) >= ldap.maxPendingRequests {
+			if isDebug {
+				debugf("too many pending requests, ignoring request %d", m.id)
+			}
+			unmatchedRequests.Add(1)
+			return false
+		}
+		conn.requests[m.id] = m
+
+	case m.id == 0:
+		// unsolicited notification, like a notice of disconnection
+		ldap.publishTransaction(m, nil)
+
+	default:
+		requ := conn.requests[m.id]
+		if requ == nil {
+			if isDebug {
+				debugf("%s with unknown message ID %d", m.op, m.id)
+			}
+			unmatchedResponses.Add(1)
+			return false
+		}
+		if !addResponse(requ, m) {
+			return false
+		}
+		delete(conn.requests, m.id)
+		if requ.op == opBindRequest && m.hasResult && m.resultCode == resultSuccess {
+			conn.user = requ.dn
+		}
+		ldap.publishTransaction(requ, m)
+		return requ.op == opExtendedRequest && requ.requestName == oidStartTLS &&
+			m.hasResult && m.resultCode == resultSuccess
+	}
+	return false
+}
+
+// addResponse adds a response to its request. It returns true if the
+// response completes the request.
+func addResponse(requ, resp *message) bool {
+	switch {
+	case resp.op == finalResponse(requ.op):
+		return true
+	case requ.op == opSearchRequest && resp.op == opSearchResultEntry:
+		requ.entries++
+	case requ.op == opSearchRequest && resp.op == opSearchResultReference:
+		requ.references++
+	case resp.op == opIntermediateResponse:
+	default:
+		if isDebug {
+			debugf("unexpected %s response to %s request %d", resp.op, requ.op, requ.id)
+		}
+		unmatchedResponses.Add(1)
+		return false
+	}
+	requ.responseBytes += resp.size
+	return false
+}
+
+// ParseUDP parses the messages of connectionless LDAP, used by Active
+// Directory clients to locate domain controllers. A datagram can hold
+// several messages, like the entry and the result of a search.
+func (ldap *ldapPlugin) ParseUDP(pkt *protos.Packet) {
+	defer logp.Recover("ParseUDP ldap exception")
+
+	data := pkt.Payload
+	for len(data) > 0 {
+		hdrLen, length, err := readHeader(data)
+		if err != nil || hdrLen == 0 || data[0] != idSequence || hdrLen+length > len(data) {
+			if isDebug {
+				debugf("invalid datagram %s", pkt.Tuple.String())
+			}
+			return
+		}
+		size := hdrLen + length
+
+		m := &message{
+			ts:        pkt.Ts,
+			capture:   pkt.Capture,
+			tuple:     pkt.Tuple.BaseTuple,
+			direction: tcp.TCPDirectionOriginal,
+			transport: "udp",
+			size:      size,
+		}
+		decodeMessage(data[:size], m)
+		ldap.handleDatagramMessage(pkt, m)
+		data = data[size:]
+	}
+}
+
+func (ldap *ldapPlugin) handleDatagramMessage(pkt *protos.Packet, m *message) {
+	switch {
+	case m.op.isRequest():
+		m.cmdlineTuple = ldap.watcher.FindProcessesTupleUDP(&pkt.Tuple)
+		if isOneWay(m.op) {
+			ldap.publishTransaction(m, nil)
+			return
+		}
+		key := udpRequestKey{tuple: pkt.Tuple.Hashable(), id: m.id}
+		if old := ldap.udpRequests.Put(key, m); old != nil {
+			unmatchedRequests.Add(1)
+			ldap.publishTransaction(old.(*message), nil)
+		}
+
+	case m.id == 0:
+		ldap.publishTransaction(m, nil)
+
+	default:
+		key := udpRequestKey{tuple: pkt.Tuple.RevHashable(), id: m.id}
+		v := ldap.udpRequests.Get(key)
+		if v == nil {
+			unmatchedResponses.Add(1)
+			return
+		}
+		requ := v.(*message)
+		if addResponse(requ, m) {
+			ldap.udpRequests.Delete(key)
+			ldap.publishTransaction(requ, m)
+		}
+	}
+}
+
+func (ldap *ldapPlugin) publishTransaction(requ, resp *message) {
+	if ldap.results == nil {
+		return
+	}
+	ldap.results(ldap.newTransaction(requ, resp))
+}
+
+func (ldap *ldapPlugin) newTransaction(requ, resp *message) beat.Event {
+	source, destination := common.MakeEndpointPair(requ.tuple, requ.cmdlineTuple)
+	src, dst := &source, &destination
+	if requ.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(requ.ts)
+	pbf.SetSource(src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(dst)
+	pbf.AddIP(dst.IP)
+	pbf.Source.Bytes = int64(requ.size)
+	pbf.Event.Dataset = "ldap"
+	pbf.Event.Start = requ.ts
+	pbf.Network.Transport = requ.transport
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&requ.capture)
+
+	operation := requ.op.String()
+	if requ.op == opExtendedResponse {
+		operation = "notification"
+	}
+	pbf.Event.Action = "ldap." + operation
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = operation
+
+	info := common.MapStr{
+		"message_id": requ.id,
+		"operation":  operation,
+	}
+	if requ.dn != "" {
+		info["dn"] = requ.dn
+		fields["resource"] = requ.dn
+	}
+
+	switch requ.op {
+	case opBindRequest:
+		info["version"] = requ.version
+		if requ.authType != "" {
+			info["auth_type"] = requ.authType
+		}
+		if requ.mechanism != "" {
+			info["sasl_mechanism"] = requ.mechanism
+		}
+	case opSearchRequest:
+		if requ.scope >= 0 && requ.scope < int64(len(scopeNames)) {
+			info["scope"] = scopeNames[requ.scope]
+		}
+		info["size_limit"] = requ.sizeLimit
+		info["time_limit"] = requ.timeLimit
+		if requ.filter != "" {
+			info["filter"] = requ.filter
+			fields["query"] = requ.filter
+		}
+	case opModifyDNRequest:
+		info["new_rdn"] = requ.newRDN
+		info["delete_old_rdn"] = requ.deleteOldRDN
+		if requ.newSuperior != "" {
+			info["new_superior"] = requ.newSuperior
+		}
+	case opAbandonRequest:
+		info["abandon_id"] = requ.abandonID
+	case opExtendedRequest:
+		info["request_name"] = requ.requestName
+		if name, ok := extendedOperations[requ.requestName]; ok {
+			fields["resource"] = name
+		} else if requ.requestName != "" {
+			fields["resource"] = requ.requestName
+		}
+	}
+	if len(requ.attributes) > 0 {
+		info["attributes"] = requ.attributes
+	}
+
+	// The user named by a bind request, or bound to the connection.
+	user := requ.user
+	if requ.op == opBindRequest {
+		user = requ.dn
+	}
+	if user != "" {
+		evt.Fields.Put("user.name", user)
+		pbf.AddUser(user)
+	}
+
+	status := common.OK_STATUS
+	var notes []string
+	if requ.invalid {
+		notes = append(notes, "Failed to decode request")
+	}
+	if resp != nil && resp.invalid {
+		notes = append(notes, "Failed to decode response")
+	}
+
+	if requ.op == opSearchRequest && (resp != nil || requ.responseBytes > 0) {
+		info["entries"] = requ.entries
+		if requ.references > 0 {
+			info["references"] = requ.references
+		}
+	}
+	pbf.Destination.Bytes = int64(requ.responseBytes)
+
+	// the result of a notification is reported by the notification
+	result := resp
+	if resp == nil && requ.op == opExtendedResponse {
+		result = requ
+	}
+	if result != nil && result.hasResult {
+		info["result_code"] = result.resultCode
+		info["result"] = resultName(result.resultCode)
+		if result.matchedDN != "" {
+			info["matched_dn"] = result.matchedDN
+		}
+		if result.diagnosticMessage != "" {
+			info["diagnostic_message"] = result.diagnosticMessage
+		}
+		if result.responseName != "" {
+			info["response_name"] = result.responseName
+		}
+		if isFailure(result.resultCode) {
+			status = common.ERROR_STATUS
+			pbf.Event.Outcome = "failure"
+		}
+	}
+
+	if resp != nil {
+		pbf.AddCapture(&resp.capture)
+		pbf.Event.End = resp.ts
+		pbf.Destination.Bytes += int64(resp.size)
+	} else if !isOneWay(requ.op) && result == nil {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched request")
+	}
+
+	fields["status"] = status
+	fields["ldap"] = info
+	pbf.Error.Message = notes
+	return evt
+}
+
+func (ldap *ldapPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	// The message boundaries are lost. Publish the pending requests before
+	// the connection state is dropped.
+	if conn, ok := private.(*connection); ok && conn != nil {
+		ldap.flushRequests(conn)
+	}
+	return private, true
+}
+
+func (ldap *ldapPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	return private
+}
+
+// Expired publishes the requests still waiting for a response when the
+// connection expires.
+func (ldap *ldapPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	ldap.flushRequests(conn)
+}
+
+func (ldap *ldapPlugin) flushRequests(conn *connection) {
+	requests := make([]*message, 0, len(conn.requests))
+	for _, m := range conn.requests {
+		requests = append(requests, m)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].id < requests[j].id
+	})
+	conn.requests = map[int64]*message{}
+
+	for _, m := range requests {
+		unmatchedRequests.Add(1)
+		ldap.publishTransaction(m, nil)
+	}
+}
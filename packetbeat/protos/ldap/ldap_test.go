@@ -0,0 +1,611 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package ldap
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func ldapModForTests(store *eventStore) *ldapPlugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	ldap, err := New(false, callback, procs.ProcessesWatcher{}, common.NewConfig())
+	if err != nil {
+		panic(err)
+	}
+	return ldap.(*ldapPlugin)
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 389,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// tlv encodes an element with the shortest form of the length.
+func tlv(id byte, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	n := len(body)
+	switch {
+	case n < 0x80:
+		return append([]byte{id, byte(n)}, body...)
+	case n < 0x100:
+		return append([]byte{id, 0x81, byte(n)}, body...)
+	default:
+		return append([]byte{id, 0x82, byte(n >> 8), byte(n)}, body...)
+	}
+}
+
+// longTLV encodes an element with a 4 bytes length, like Active Directory.
+func longTLV(id byte, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	n := len(body)
+	return append([]byte{id, 0x84, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}, body...)
+}
+
+func integer(id byte, v int64) []byte {
+	b := []byte{byte(v)}
+	for v >>= 8; v != 0 && v != -1; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	return tlv(id, b)
+}
+
+func str(s string) []byte {
+	return tlv(idOctetString, []byte(s))
+}
+
+func boolean(v bool) []byte {
+	if v {
+		return tlv(idBoolean, []byte{0xff})
+	}
+	return tlv(idBoolean, []byte{0})
+}
+
+func app(op opCode, content ...[]byte) []byte {
+	return tlv(classApplication|constructed|byte(op), content...)
+}
+
+func ldapMessage(id int64, op []byte) []byte {
+	return tlv(idSequence, integer(idInteger, id), op)
+}
+
+func result(op opCode, code int64, diagnostic string) []byte {
+	return app(op, integer(idEnumerated, code), str(""), str(diagnostic))
+}
+
+func simpleBind(id int64, dn string) []byte {
+	return ldapMessage(id, app(opBindRequest,
+		integer(idInteger, 3), str(dn), tlv(idAuthSimple, []byte("secret"))))
+}
+
+func equal(attr, value string) []byte {
+	return tlv(filterEqualityMatch, str(attr), str(value))
+}
+
+func present(attr string) []byte {
+	return tlv(filterPresent, []byte(attr))
+}
+
+func searchRequest(id int64, base string, scope int64, filter []byte, attrs ...string) []byte {
+	var list [][]byte
+	for _, attr := range attrs {
+		list = append(list, str(attr))
+	}
+	return ldapMessage(id, app(opSearchRequest,
+		str(base), integer(idEnumerated, scope), integer(idEnumerated, 0),
+		integer(idInteger, 0), integer(idInteger, 30), boolean(false),
+		filter, tlv(idSequence, list...)))
+}
+
+func searchEntry(id int64, dn string) []byte {
+	return longTLV(idSequence, integer(idInteger, id), longTLV(
+		classApplication|constructed|byte(opSearchResultEntry),
+		str(dn), longTLV(idSequence, longTLV(idSequence, str("cn"), longTLV(idSet, str("test"))))))
+}
+
+// segment is the payload of a TCP segment sent in a direction.
+type segment struct {
+	dir  uint8
+	data []byte
+}
+
+func client(data ...[]byte) segment {
+	return segment{tcp.TCPDirectionOriginal, bytes.Join(data, nil)}
+}
+
+func server(data ...[]byte) segment {
+	return segment{tcp.TCPDirectionReverse, bytes.Join(data, nil)}
+}
+
+// chunks splits data in segments of at most size bytes.
+func chunks(dir uint8, data []byte, size int) []segment {
+	var segments []segment
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		segments = append(segments, segment{dir, data[:n]})
+		data = data[n:]
+	}
+	return segments
+}
+
+// parse feeds the segments to the plugin, one millisecond apart.
+func parse(ldap *ldapPlugin, tcptuple *common.TCPTuple, segments []segment) protos.ProtocolData {
+	ts := time.Now()
+	var private protos.ProtocolData
+	for i, s := range segments {
+		pkt := protos.Packet{Ts: ts.Add(time.Duration(i) * time.Millisecond), Payload: s.data}
+		private = ldap.Parse(&pkt, tcptuple, s.dir, private)
+	}
+	return private
+}
+
+func TestReadHeader(t *testing.T) {
+	for _, test := range []struct {
+		data           []byte
+		hdrLen, length int
+		err            error
+	}{
+		{data: []byte{0x30}},
+		{data: []byte{0x30, 0x05}, hdrLen: 2, length: 5},
+		{data: []byte{0x30, 0x81, 0xc8}, hdrLen: 3, length: 200},
+		{data: []byte{0x30, 0x84, 0x00, 0x00}},
+		{data: []byte{0x30, 0x84, 0x00, 0x00, 0x01, 0x00}, hdrLen: 6, length: 256},
+		{data: []byte{0x30, 0x80}, err: errInvalidLength},
+		{data: []byte{0x30, 0x85, 1, 1, 1, 1, 1}, err: errInvalidLength},
+		{data: []byte{0x3f, 0x01}, err: errUnexpectedTag},
+	} {
+		hdrLen, length, err := readHeader(test.data)
+		assert.Equal(t, test.err, err, "% x", test.data)
+		assert.Equal(t, test.hdrLen, hdrLen, "% x", test.data)
+		assert.Equal(t, test.length, length, "% x", test.data)
+	}
+}
+
+func TestWriteFilter(t *testing.T) {
+	for _, test := range []struct {
+		filter   []byte
+		expected string
+	}{
+		{present("objectClass"), "(objectClass=*)"},
+		{equal("cn", "John (admin)*"), `(cn=John \28admin\29\2a)`},
+		{equal("objectGUID", "\x01\xff\x10"), `(objectGUID=\01\ff\10)`},
+		{
+			tlv(filterAnd,
+				equal("objectClass", "user"),
+				tlv(filterOr,
+					tlv(filterSubstrings, str("cn"), tlv(idSequence,
+						tlv(classContext|0, []byte("jo")))),
+					tlv(filterSubstrings, str("mail"), tlv(idSequence,
+						tlv(classContext|2, []byte("@example.com"))))),
+				tlv(filterNot, tlv(filterExtensibleMatch,
+					tlv(classContext|1, []byte("1.2.840.113556.1.4.803")),
+					tlv(classContext|2, []byte("userAccountControl")),
+					tlv(classContext|3, []byte("2"))))),
+			"(&(objectClass=user)(|(cn=jo*)(mail=*@example.com))(!(userAccountControl:1.2.840.113556.1.4.803:=2)))",
+		},
+		{
+			tlv(filterSubstrings, str("cn"), tlv(idSequence,
+				tlv(classContext|0, []byte("a")),
+				tlv(classContext|1, []byte("b")),
+				tlv(classContext|1, []byte("c")),
+				tlv(classContext|2, []byte("d")))),
+			"(cn=a*b*c*d)",
+		},
+		{
+			tlv(filterSubstrings, str("cn"), tlv(idSequence,
+				tlv(classContext|1, []byte("b")))),
+			"(cn=*b*)",
+		},
+		{tlv(filterGreaterOrEqual, str("uidNumber"), str("1000")), "(uidNumber>=1000)"},
+		{
+			tlv(filterExtensibleMatch,
+				tlv(classContext|2, []byte("ou")),
+				tlv(classContext|3, []byte("Sales")),
+				tlv(classContext|4, []byte{0xff})),
+			"(ou:dn:=Sales)",
+		},
+	} {
+		d := &decoder{buf: test.filter}
+		var b strings.Builder
+		err := writeFilter(&b, d.next(), 0)
+		if assert.NoError(t, err) {
+			assert.Equal(t, test.expected, b.String())
+		}
+	}
+
+	// nesting limit
+	filter := present("cn")
+	for i := 0; i <= maxFilterDepth; i++ {
+		filter = tlv(filterNot, filter)
+	}
+	var b strings.Builder
+	d := &decoder{buf: filter}
+	assert.Equal(t, errInvalidFilter, writeFilter(&b, d.next(), 0))
+}
+
+func TestDetectLDAP(t *testing.T) {
+	bind := simpleBind(1, "cn=admin,dc=example,dc=com")
+	assert.Equal(t, protos.DetectionMatch, detectLDAP(bind))
+	assert.Equal(t, protos.DetectionNeedMore, detectLDAP(bind[:4]))
+	assert.Equal(t, protos.DetectionNeedMore, detectLDAP(nil))
+	assert.Equal(t, protos.DetectionMatch, detectLDAP(ldapMessage(7, tlv(classApplication|byte(opDelRequest), []byte("cn=x")))))
+	assert.Equal(t, protos.DetectionMismatch, detectLDAP(searchEntry(1, "cn=x")))
+	assert.Equal(t, protos.DetectionMismatch, detectLDAP(ldapMessage(1, tlv(classApplication|byte(opBindRequest), nil))))
+	assert.Equal(t, protos.DetectionMismatch, detectLDAP([]byte("GET / HTTP/1.1\r\n")))
+
+	ldap := ldapModForTests(nil)
+	assert.Equal(t, protos.DetectionMismatch, ldap.DetectUDP(bind[:4]))
+}
+
+func TestLDAPParser(t *testing.T) {
+	search := searchRequest(2, "dc=example,dc=com", 2,
+		tlv(filterAnd, equal("objectClass", "user"), equal("sAMAccountName", "alice")),
+		"cn", "memberOf")
+	responses := bytes.Join([][]byte{
+		searchEntry(2, "cn=alice,dc=example,dc=com"),
+		searchEntry(2, "cn=alice2,dc=example,dc=com"),
+		ldapMessage(2, app(opSearchResultReference, str("ldap://dc2.example.com/dc=example,dc=com"))),
+		ldapMessage(2, result(opSearchResultDone, 0, "")),
+	}, nil)
+
+	modify := ldapMessage(4, app(opModifyRequest, str("cn=alice,dc=example,dc=com"), tlv(idSequence,
+		tlv(idSequence, integer(idEnumerated, 2), tlv(idSequence, str("mail"), tlv(idSet, str("alice@example.com")))),
+		tlv(idSequence, integer(idEnumerated, 0), tlv(idSequence, str("memberOf"), tlv(idSet, str("cn=admins")))))))
+	add := ldapMessage(5, app(opAddRequest, str("cn=bob,dc=example,dc=com"), tlv(idSequence,
+		tlv(idSequence, str("objectClass"), tlv(idSet, str("person"))),
+		tlv(idSequence, str("sn"), tlv(idSet, str("Bob"))))))
+	del := ldapMessage(6, tlv(classApplication|byte(opDelRequest), []byte("cn=carol,dc=example,dc=com")))
+	modDN := ldapMessage(7, app(opModifyDNRequest, str("cn=bob,dc=example,dc=com"), str("cn=robert"),
+		boolean(true), tlv(idNewSuperior, []byte("ou=people,dc=example,dc=com"))))
+	compare := ldapMessage(8, app(opCompareRequest, str("cn=alice,dc=example,dc=com"),
+		tlv(idSequence, str("department"), str("sales"))))
+
+	largeEntry := searchEntry(2, "cn=alice,dc=example,dc=com,"+strings.Repeat("x", 200))
+
+	for _, test := range []struct {
+		title          string
+		segments       []segment
+		maxMessageSize int
+		expire         bool
+		expected       []common.MapStr
+	}{
+		{
+			title: "bind and search",
+			segments: []segment{
+				client(simpleBind(1, "cn=svc-web,ou=Services,dc=example,dc=com")),
+				server(ldapMessage(1, result(opBindResponse, 0, ""))),
+				client(search[:10]),
+				client(search[10:]),
+				server(responses[:7]),
+				server(responses[7:100]),
+				server(responses[100:]),
+			},
+			expected: []common.MapStr{
+				{
+					"type":           "ldap",
+					"method":         "bind",
+					"event.action":   "ldap.bind",
+					"status":         common.OK_STATUS,
+					"user.name":      "cn=svc-web,ou=Services,dc=example,dc=com",
+					"ldap.auth_type": "simple",
+					"ldap.version":   3,
+					"ldap.result":    "success",
+				},
+				{
+					"method":            "search",
+					"resource":          "dc=example,dc=com",
+					"query":             "(&(objectClass=user)(sAMAccountName=alice))",
+					"ldap.filter":       "(&(objectClass=user)(sAMAccountName=alice))",
+					"ldap.scope":        "sub",
+					"ldap.time_limit":   30,
+					"ldap.attributes":   []string{"cn", "memberOf"},
+					"ldap.entries":      2,
+					"ldap.references":   1,
+					"user.name":         "cn=svc-web,ou=Services,dc=example,dc=com",
+					"source.bytes":      len(search),
+					"destination.bytes": len(responses),
+					"status":            common.OK_STATUS,
+				},
+			},
+		},
+		{
+			title: "bind failure",
+			segments: []segment{
+				client(simpleBind(1, "cn=admin,dc=example,dc=com")),
+				server(ldapMessage(1, result(opBindResponse, 0, ""))),
+				client(simpleBind(2, "cn=alice,dc=example,dc=com")),
+				server(ldapMessage(2, result(opBindResponse, 49,
+					"80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 52e, v2580"))),
+				client(searchRequest(3, "", 0, present("objectClass"))),
+				server(ldapMessage(3, result(opSearchResultDone, 0, ""))),
+			},
+			expected: []common.MapStr{
+				{
+					"user.name": "cn=admin,dc=example,dc=com",
+				},
+				{
+					"user.name":               "cn=alice,dc=example,dc=com",
+					"status":                  common.ERROR_STATUS,
+					"event.outcome":           "failure",
+					"ldap.result_code":        49,
+					"ldap.result":             "invalidCredentials",
+					"ldap.diagnostic_message": "80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 52e, v2580",
+				},
+				// the connection is anonymous after the failed bind
+				{
+					"ldap.scope":   "base",
+					"ldap.entries": 0,
+					"user.name":    nil,
+				},
+			},
+		},
+		{
+			title: "sasl bind",
+			segments: []segment{
+				client(ldapMessage(1, app(opBindRequest, integer(idInteger, 3), str(""),
+					tlv(idAuthSASL, str("GSS-SPNEGO"), str("token"))))),
+				server(ldapMessage(1, app(opBindResponse, integer(idEnumerated, 14), str(""), str(""),
+					tlv(classContext|7, []byte("token"))))),
+			},
+			expected: []common.MapStr{{
+				"ldap.auth_type":      "sasl",
+				"ldap.sasl_mechanism": "GSS-SPNEGO",
+				"ldap.result":         "saslBindInProgress",
+				"status":              common.OK_STATUS,
+			}},
+		},
+		{
+			title: "pipelined updates answered out of order",
+			segments: []segment{
+				client(modify, add, del, modDN, compare),
+				server(
+					ldapMessage(6, result(opDelResponse, 32, "")),
+					ldapMessage(5, result(opAddResponse, 0, "")),
+					ldapMessage(4, result(opModifyResponse, 50, "")),
+					ldapMessage(8, result(opCompareResponse, 6, "")),
+					ldapMessage(7, result(opModifyDNResponse, 0, ""))),
+			},
+			expected: []common.MapStr{
+				{
+					"method":      "delete",
+					"ldap.dn":     "cn=carol,dc=example,dc=com",
+					"ldap.result": "noSuchObject",
+					"status":      common.ERROR_STATUS,
+				},
+				{
+					"method":          "add",
+					"ldap.attributes": []string{"objectClass", "sn"},
+				},
+				{
+					"method":          "modify",
+					"ldap.attributes": []string{"mail", "memberOf"},
+					"ldap.result":     "insufficientAccessRights",
+				},
+				{
+					"method":          "compare",
+					"ldap.attributes": []string{"department"},
+					"status":          common.OK_STATUS,
+				},
+				{
+					"method":              "modify_dn",
+					"ldap.new_rdn":        "cn=robert",
+					"ldap.delete_old_rdn": true,
+					"ldap.new_superior":   "ou=people,dc=example,dc=com",
+				},
+			},
+		},
+		{
+			title: "refused StartTLS",
+			segments: []segment{
+				client(ldapMessage(1, app(opExtendedRequest, tlv(idRequestName, []byte(oidStartTLS))))),
+				server(ldapMessage(1, result(opExtendedResponse, 53, ""))),
+			},
+			expected: []common.MapStr{{
+				"method":            "extended",
+				"resource":          "StartTLS",
+				"ldap.request_name": oidStartTLS,
+				"ldap.result":       "unwillingToPerform",
+			}},
+		},
+		{
+			title: "one way and unmatched",
+			segments: []segment{
+				client(searchRequest(2, "dc=example,dc=com", 1, present("cn"))),
+				client(ldapMessage(3, tlv(classApplication|byte(opAbandonRequest), []byte{2}))),
+				server(searchEntry(2, "cn=alice,dc=example,dc=com")),
+				server(ldapMessage(0, app(opExtendedResponse, integer(idEnumerated, 52), str(""),
+					str("server shutting down"), tlv(idResponseName, []byte(oidNoticeOfDisconnection))))),
+				client(ldapMessage(4, tlv(classApplication|byte(opUnbindRequest), nil))),
+			},
+			expire: true,
+			expected: []common.MapStr{
+				{
+					"method":          "abandon",
+					"ldap.abandon_id": 2,
+					"status":          common.OK_STATUS,
+				},
+				{
+					"method":             "notification",
+					"source.ip":          "192.168.0.2",
+					"ldap.result":        "unavailable",
+					"ldap.response_name": oidNoticeOfDisconnection,
+					"status":             common.ERROR_STATUS,
+				},
+				{
+					"method": "unbind",
+					"status": common.OK_STATUS,
+				},
+				{
+					"method":        "search",
+					"ldap.entries":  1,
+					"status":        common.ERROR_STATUS,
+					"error.message": "Unmatched request",
+				},
+			},
+		},
+		{
+			title:          "truncated entry",
+			maxMessageSize: 64,
+			segments: append(append(
+				[]segment{client(searchRequest(2, "dc=example,dc=com", 2, present("objectClass")))},
+				chunks(tcp.TCPDirectionReverse, largeEntry, 50)...),
+				server(ldapMessage(2, result(opSearchResultDone, 0, ""))),
+			),
+			expected: []common.MapStr{{
+				"ldap.entries":  1,
+				"status":        common.OK_STATUS,
+				"error.message": nil,
+			}},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			ldap := ldapModForTests(&store)
+			if test.maxMessageSize != 0 {
+				ldap.maxMessageSize = test.maxMessageSize
+			}
+			tcptuple := testTCPTuple()
+
+			private := parse(ldap, tcptuple, test.segments)
+			if test.expire {
+				ldap.Expired(tcptuple, private)
+			}
+
+			if !assert.Len(t, store.events, len(test.expected)) {
+				return
+			}
+			for i, expected := range test.expected {
+				for field, value := range expected {
+					actual, err := store.events[i].GetValue(field)
+					if value == nil {
+						assert.Equal(t, common.ErrKeyNotFound, err, field)
+						continue
+					}
+					assert.NoError(t, err, field)
+					assert.EqualValues(t, value, actual, field)
+				}
+			}
+		})
+	}
+}
+
+func TestLDAP_startTLS(t *testing.T) {
+	var store eventStore
+	ldap := ldapModForTests(&store)
+	tcptuple := testTCPTuple()
+	startTLS := client(ldapMessage(1, app(opExtendedRequest, tlv(idRequestName, []byte(oidStartTLS)))))
+
+	// refused by the server
+	private := parse(ldap, tcptuple, []segment{
+		startTLS,
+		server(ldapMessage(1, result(opExtendedResponse, 53, ""))),
+	})
+	assert.IsType(t, &connection{}, private)
+
+	private = parse(ldap, tcptuple, []segment{
+		startTLS,
+		server(ldapMessage(1, result(opExtendedResponse, 0, "")), []byte{0x16, 0x03}),
+	})
+	if assert.IsType(t, &protos.ProtocolUpgrade{}, private) {
+		upgrade := private.(*protos.ProtocolUpgrade)
+		assert.Equal(t, protos.Lookup("tls"), upgrade.Protocol)
+		assert.Equal(t, []byte{0x16, 0x03}, upgrade.Pending[tcp.TCPDirectionReverse])
+	}
+	assert.Len(t, store.events, 2)
+}
+
+func TestLDAP_udp(t *testing.T) {
+	var store eventStore
+	ldap := ldapModForTests(&store)
+
+	tuple := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 51000, DstPort: 389,
+		},
+	}
+	reversed := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP: tuple.DstIP, DstIP: tuple.SrcIP,
+			SrcPort: tuple.DstPort, DstPort: tuple.SrcPort,
+		},
+	}
+	ts := time.Now()
+
+	ping := searchRequest(11, "", 0, tlv(filterAnd,
+		equal("DnsDomain", "example.com"), equal("NtVer", "\x06\x00\x00\x00")), "Netlogon")
+	ldap.ParseUDP(&protos.Packet{Ts: ts, Tuple: tuple, Payload: ping})
+	assert.Empty(t, store.events)
+
+	response := append(searchEntry(11, ""), ldapMessage(11, result(opSearchResultDone, 0, ""))...)
+	ldap.ParseUDP(&protos.Packet{Ts: ts.Add(time.Millisecond), Tuple: reversed, Payload: response})
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+
+	expected := common.MapStr{
+		"network.transport": "udp",
+		"source.ip":         "192.168.0.1",
+		"ldap.filter":       `(&(DnsDomain=example.com)(NtVer=\06\00\00\00))`,
+		"ldap.entries":      1,
+		"destination.bytes": len(response),
+		"status":            common.OK_STATUS,
+	}
+	for field, value := range expected {
+		actual, err := store.events[0].GetValue(field)
+		assert.NoError(t, err, field)
+		assert.EqualValues(t, value, actual, field)
+	}
+}
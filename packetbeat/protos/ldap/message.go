@@ -0,0 +1,461 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+)
+
+// opCode is the application tag of the protocolOp of an LDAPMessage.
+type opCode uint8
+
+const (
+	opBindRequest           opCode = 0
+	opBindResponse          opCode = 1
+	opUnbindRequest         opCode = 2
+	opSearchRequest         opCode = 3
+	opSearchResultEntry     opCode = 4
+	opSearchResultDone      opCode = 5
+	opModifyRequest         opCode = 6
+	opModifyResponse        opCode = 7
+	opAddRequest            opCode = 8
+	opAddResponse           opCode = 9
+	opDelRequest            opCode = 10
+	opDelResponse           opCode = 11
+	opModifyDNRequest       opCode = 12
+	opModifyDNResponse      opCode = 13
+	opCompareRequest        opCode = 14
+	opCompareResponse       opCode = 15
+	opAbandonRequest        opCode = 16
+	opSearchResultReference opCode = 19
+	opExtendedRequest       opCode = 23
+	opExtendedResponse      opCode = 24
+	opIntermediateResponse  opCode = 25
+)
+
+// operationNames are the names of the operations reported, by request.
+var operationNames = map[opCode]string{
+	opBindRequest:     "bind",
+	opUnbindRequest:   "unbind",
+	opSearchRequest:   "search",
+	opModifyRequest:   "modify",
+	opAddRequest:      "add",
+	opDelRequest:      "delete",
+	opModifyDNRequest: "modify_dn",
+	opCompareRequest:  "compare",
+	opAbandonRequest:  "abandon",
+	opExtendedRequest: "extended",
+}
+
+func (op opCode) isRequest() bool {
+	_, ok := operationNames[op]
+	return ok
+}
+
+func (op opCode) String() string {
+	if name, ok := operationNames[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("op_%d", op)
+}
+
+// finalResponse returns the operation of the response completing a request.
+// Searches are completed by a SearchResultDone, following the results.
+func finalResponse(requ opCode) opCode {
+	switch requ {
+	case opSearchRequest:
+		return opSearchResultDone
+	case opExtendedRequest:
+		return opExtendedResponse
+	}
+	return requ + 1
+}
+
+// isOneWay returns true for the requests without response.
+func isOneWay(op opCode) bool {
+	return op == opUnbindRequest || op == opAbandonRequest
+}
+
+var scopeNames = []string{"base", "one", "sub", "children"}
+
+// extendedOperations names the extended operations commonly used.
+var extendedOperations = map[string]string{
+	oidStartTLS:                "StartTLS",
+	"1.3.6.1.4.1.4203.1.11.1":  "PasswordModify",
+	"1.3.6.1.4.1.4203.1.11.3":  "WhoAmI",
+	"1.3.6.1.1.8":              "Cancel",
+	oidNoticeOfDisconnection:   "NoticeOfDisconnection",
+	"1.2.840.113556.1.4.1781":  "FastBind",
+	"1.3.6.1.4.1.1466.101.119": "TTLRefresh",
+}
+
+const (
+	oidStartTLS              = "1.3.6.1.4.1.1466.20037"
+	oidNoticeOfDisconnection = "1.3.6.1.4.1.1466.20036"
+)
+
+type message struct {
+	ts           time.Time
+	capture      pb.Capture
+	tuple        common.BaseTuple
+	cmdlineTuple *common.ProcessTuple
+	direction    uint8
+	transport    string
+	size         int
+	truncated    bool
+	invalid      bool
+
+	id int64
+	op opCode
+
+	// entry the request applies to, the name of the user for binds
+	dn string
+	// user bound to the connection when the request was sent
+	user string
+
+	// bind
+	version   int64
+	authType  string
+	mechanism string
+
+	// search
+	scope      int64
+	sizeLimit  int64
+	timeLimit  int64
+	filter     string
+	attributes []string
+
+	// modify DN
+	newRDN       string
+	deleteOldRDN bool
+	newSuperior  string
+
+	abandonID int64
+
+	// extended operations
+	requestName  string
+	responseName string
+
+	// LDAPResult of the responses
+	hasResult         bool
+	resultCode        int64
+	matchedDN         string
+	diagnosticMessage string
+
+	// responses to the request
+	entries       int
+	references    int
+	responseBytes int
+}
+
+// Identifiers of the authentication choices of BindRequest.
+const (
+	idAuthSimple = classContext | 0
+	idAuthSASL   = classContext | constructed | 3
+)
+
+// Identifiers of the optional elements of ExtendedRequest, ExtendedResponse
+// and ModifyDNRequest.
+const (
+	idRequestName  = classContext | 0
+	idResponseName = classContext | 10
+	idNewSuperior  = classContext | 0
+)
+
+// parseMessage decodes an LDAPMessage. The fields reported are decoded from
+// the available data if the message is truncated.
+func parseMessage(data []byte, m *message) error {
+	d := &decoder{buf: data}
+	body := d.enter(idSequence)
+	m.id = body.integer()
+	// Connectionless LDAP of RFC 1798 has the name of the user after
+	// the message ID.
+	body.optional(idOctetString)
+
+	op := body.next()
+	if body.err == nil && op.id&classMask != classApplication {
+		body.fail(errUnexpectedTag)
+	}
+	m.op = opCode(op.id & tagMask)
+
+	od := &decoder{buf: op.content}
+	switch m.op {
+	case opBindRequest:
+		m.version = od.integer()
+		m.dn = od.string()
+		switch od.peek() {
+		case idAuthSimple:
+			// the password is not decoded
+			od.next()
+			m.authType = "simple"
+		case idAuthSASL:
+			sasl := od.enter(idAuthSASL)
+			m.authType = "sasl"
+			m.mechanism = sasl.string()
+			od.join(sasl)
+		}
+
+	case opSearchRequest:
+		m.dn = od.string()
+		m.scope = od.enumerated()
+		od.enumerated() // derefAliases
+		m.sizeLimit = od.integer()
+		m.timeLimit = od.integer()
+		od.boolean() // typesOnly
+		filter := od.next()
+		if od.err == nil {
+			var b strings.Builder
+			if err := writeFilter(&b, filter, 0); err != nil {
+				od.fail(err)
+			}
+			m.filter = b.String()
+		}
+		attrs := od.enter(idSequence)
+		for attrs.more() {
+			m.attributes = append(m.attributes, attrs.string())
+		}
+		od.join(attrs)
+
+	case opModifyRequest:
+		m.dn = od.string()
+		changes := od.enter(idSequence)
+		for changes.more() {
+			change := changes.enter(idSequence)
+			change.enumerated() // operation
+			mod := change.enter(idSequence)
+			m.attributes = append(m.attributes, mod.string())
+			change.join(mod)
+			changes.join(change)
+		}
+		od.join(changes)
+
+	case opAddRequest:
+		m.dn = od.string()
+		attrs := od.enter(idSequence)
+		for attrs.more() {
+			attr := attrs.enter(idSequence)
+			m.attributes = append(m.attributes, attr.string())
+			attrs.join(attr)
+		}
+		od.join(attrs)
+
+	case opDelRequest:
+		m.dn = string(op.content)
+
+	case opModifyDNRequest:
+		m.dn = od.string()
+		m.newRDN = od.string()
+		m.deleteOldRDN = od.boolean()
+		if superior, ok := od.optional(idNewSuperior); ok {
+			m.newSuperior = string(superior)
+		}
+
+	case opCompareRequest:
+		m.dn = od.string()
+		ava := od.enter(idSequence)
+		m.attributes = []string{ava.string()}
+		od.join(ava)
+
+	case opAbandonRequest:
+		m.abandonID = od.intValue(op.content)
+
+	case opExtendedRequest:
+		m.requestName = string(od.expect(idRequestName))
+
+	case opBindResponse, opSearchResultDone, opModifyResponse, opAddResponse,
+		opDelResponse, opModifyDNResponse, opCompareResponse:
+		parseResult(od, m)
+
+	case opExtendedResponse:
+		parseResult(od, m)
+		for od.more() {
+			e := od.next()
+			if e.id == idResponseName {
+				m.responseName = string(e.content)
+			}
+		}
+
+	case opUnbindRequest, opSearchResultEntry, opSearchResultReference, opIntermediateResponse:
+		// the content is not reported
+
+	default:
+		od.fail(errUnknownOperation)
+	}
+
+	for _, err := range []error{d.err, body.err, od.err} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errUnknownOperation = errors.New("unknown LDAP operation")
+
+// parseResult decodes the LDAPResult components of a response. Referrals
+// are not decoded.
+func parseResult(d *decoder, m *message) {
+	m.resultCode = d.enumerated()
+	m.matchedDN = d.string()
+	m.diagnosticMessage = d.string()
+	m.hasResult = d.err == nil
+}
+
+// Identifiers of the filter choices.
+const (
+	filterAnd             = classContext | constructed | 0
+	filterOr              = classContext | constructed | 1
+	filterNot             = classContext | constructed | 2
+	filterEqualityMatch   = classContext | constructed | 3
+	filterSubstrings      = classContext | constructed | 4
+	filterGreaterOrEqual  = classContext | constructed | 5
+	filterLessOrEqual     = classContext | constructed | 6
+	filterPresent         = classContext | 7
+	filterApproxMatch     = classContext | constructed | 8
+	filterExtensibleMatch = classContext | constructed | 9
+)
+
+var filterOperators = map[byte]string{
+	filterEqualityMatch:  "=",
+	filterGreaterOrEqual: ">=",
+	filterLessOrEqual:    "<=",
+	filterApproxMatch:    "~=",
+}
+
+// maxFilterDepth limits the nesting of the filters formatted.
+const maxFilterDepth = 32
+
+var errInvalidFilter = errors.New("invalid search filter")
+
+// writeFilter formats a search filter in the string representation of
+// RFC 4515.
+func writeFilter(b *strings.Builder, f element, depth int) error {
+	if depth > maxFilterDepth {
+		return errInvalidFilter
+	}
+
+	d := &decoder{buf: f.content}
+	b.WriteByte('(')
+	switch f.id {
+	case filterAnd, filterOr:
+		b.WriteByte("&|"[f.id&tagMask])
+		for d.more() {
+			if err := writeFilter(b, d.next(), depth+1); err != nil {
+				return err
+			}
+		}
+
+	case filterNot:
+		b.WriteByte('!')
+		if err := writeFilter(b, d.next(), depth+1); err != nil {
+			return err
+		}
+
+	case filterEqualityMatch, filterGreaterOrEqual, filterLessOrEqual, filterApproxMatch:
+		b.WriteString(d.string())
+		b.WriteString(filterOperators[f.id])
+		writeValue(b, d.expect(idOctetString))
+
+	case filterSubstrings:
+		b.WriteString(d.string())
+		b.WriteByte('=')
+		// the initial substring is followed by a wildcard, the final one
+		// preceded by a wildcard, and any other substring surrounded by
+		// wildcards
+		star, final := false, false
+		subs := d.enter(idSequence)
+		for subs.more() {
+			s := subs.next()
+			if s.id&tagMask != 0 && !star {
+				b.WriteByte('*')
+			}
+			writeValue(b, s.content)
+			final = s.id&tagMask == 2
+			star = !final
+			if star {
+				b.WriteByte('*')
+			}
+		}
+		if !star && !final {
+			b.WriteByte('*')
+		}
+		d.join(subs)
+
+	case filterPresent:
+		b.Write(f.content)
+		b.WriteString("=*")
+
+	case filterExtensibleMatch:
+		var rule, value []byte
+		var attr string
+		dnAttributes := false
+		for d.more() {
+			e := d.next()
+			switch e.id {
+			case classContext | 1:
+				rule = e.content
+			case classContext | 2:
+				attr = string(e.content)
+			case classContext | 3:
+				value = e.content
+			case classContext | 4:
+				dnAttributes = len(e.content) > 0 && e.content[0] != 0
+			}
+		}
+		b.WriteString(attr)
+		if dnAttributes {
+			b.WriteString(":dn")
+		}
+		if len(rule) > 0 {
+			b.WriteByte(':')
+			b.Write(rule)
+		}
+		b.WriteString(":=")
+		writeValue(b, value)
+
+	default:
+		return errInvalidFilter
+	}
+	b.WriteByte(')')
+	return d.err
+}
+
+// writeValue writes an assertion value of a filter, escaping the special
+// characters. Binary values are escaped entirely.
+func writeValue(b *strings.Builder, v []byte) {
+	const hex = "0123456789abcdef"
+	binary := !utf8.Valid(v)
+	for _, c := range v {
+		switch {
+		case c == '*' || c == '(' || c == ')' || c == '\\' || c < 0x20 || c == 0x7f,
+			binary && c >= 0x80:
+			b.WriteByte('\\')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0f])
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
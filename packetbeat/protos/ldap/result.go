@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import "strconv"
+
+// Result codes of LDAPResult, from RFC 4511 and the extensions defining
+// additional codes.
+var resultCodes = map[int64]string{
+	0:    "success",
+	1:    "operationsError",
+	2:    "protocolError",
+	3:    "timeLimitExceeded",
+	4:    "sizeLimitExceeded",
+	5:    "compareFalse",
+	6:    "compareTrue",
+	7:    "authMethodNotSupported",
+	8:    "strongerAuthRequired",
+	10:   "referral",
+	11:   "adminLimitExceeded",
+	12:   "unavailableCriticalExtension",
+	13:   "confidentialityRequired",
+	14:   "saslBindInProgress",
+	16:   "noSuchAttribute",
+	17:   "undefinedAttributeType",
+	18:   "inappropriateMatching",
+	19:   "constraintViolation",
+	20:   "attributeOrValueExists",
+	21:   "invalidAttributeSyntax",
+	32:   "noSuchObject",
+	33:   "aliasProblem",
+	34:   "invalidDNSyntax",
+	36:   "aliasDereferencingProblem",
+	48:   "inappropriateAuthentication",
+	49:   "invalidCredentials",
+	50:   "insufficientAccessRights",
+	51:   "busy",
+	52:   "unavailable",
+	53:   "unwillingToPerform",
+	54:   "loopDetect",
+	64:   "namingViolation",
+	65:   "objectClassViolation",
+	66:   "notAllowedOnNonLeaf",
+	67:   "notAllowedOnRDN",
+	68:   "entryAlreadyExists",
+	69:   "objectClassModsProhibited",
+	71:   "affectsMultipleDSAs",
+	80:   "other",
+	118:  "canceled",
+	119:  "noSuchOperation",
+	120:  "tooLate",
+	121:  "cannotCancel",
+	122:  "assertionFailed",
+	123:  "authorizationDenied",
+	4096: "syncRefreshRequired",
+}
+
+const (
+	resultSuccess            = 0
+	resultCompareFalse       = 5
+	resultCompareTrue        = 6
+	resultSASLBindInProgress = 14
+)
+
+func resultName(code int64) string {
+	if name, ok := resultCodes[code]; ok {
+		return name
+	}
+	return strconv.FormatInt(code, 10)
+}
+
+// isFailure returns true for the result codes reporting that the operation
+// was not performed.
+func isFailure(code int64) bool {
+	switch code {
+	case resultSuccess, resultCompareFalse, resultCompareTrue, resultSASLBindInProgress:
+		return false
+	}
+	return true
+}
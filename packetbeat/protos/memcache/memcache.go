@@ -357,6 +357,7 @@ func newTransaction(requ, resp *message) *transaction {
 		t.BytesOut = requ.Size
 		t.BytesIn = resp.Size
 		t.EndTime = resp.Ts
+		t.Capture.Add(&resp.Capture)
 		t.Notes = append(t.Notes, requ.Notes...)
 		t.Notes = append(t.Notes, resp.Notes...)
 	case requ != nil && resp == nil:
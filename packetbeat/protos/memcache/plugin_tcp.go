@@ -165,6 +165,7 @@ func (mc *memcache) memcacheParseTCP(
 			// wait for more data
 			break
 		}
+		msg.Capture = pkt.Capture
 		stream.reset()
 
 		tuple := tcptuple.IPPort()
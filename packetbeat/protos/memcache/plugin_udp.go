@@ -133,6 +133,7 @@ func (mc *memcache) ParseUDP(pkt *protos.Packet) {
 			connection.killTransaction(trans)
 			return
 		}
+		msg.Capture = pkt.Capture
 
 		// apply memcached to transaction
 		done, err = mc.onUDPMessage(trans, &pkt.Tuple, dir, msg)
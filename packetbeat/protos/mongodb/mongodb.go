@@ -18,6 +18,7 @@
 package mongodb
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
 	"time"
@@ -118,6 +119,28 @@ func (mongodb *mongodbPlugin) ConnectionTimeout() time.Duration {
 	return mongodb.transactionTimeout
 }
 
+// DetectTCP recognizes the header of a wire protocol message.
+func (mongodb *mongodbPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectMongodb(data)
+}
+
+// maxMessageLen is the maximum size of a wire protocol message.
+const maxMessageLen = 48000000
+
+func detectMongodb(data []byte) protos.Detection {
+	const hdrLen = 16
+	if len(data) < hdrLen {
+		return protos.DetectionNeedMore
+	}
+
+	length := int32(binary.LittleEndian.Uint32(data))
+	code := opCode(binary.LittleEndian.Uint32(data[12:]))
+	if length < hdrLen || length > maxMessageLen || !validOpcode(code) {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
 func (mongodb *mongodbPlugin) Parse(
 	pkt *protos.Packet,
 	tcptuple *common.TCPTuple,
@@ -176,7 +199,7 @@ func (mongodb *mongodbPlugin) doParse(
 
 	for len(st.data) > 0 {
 		if st.message == nil {
-			st.message = &mongodbMessage{ts: pkt.Ts}
+			st.message = &mongodbMessage{ts: pkt.Ts, capture: pkt.Capture}
 		}
 
 		ok, complete := mongodbMessageParser(st)
@@ -207,7 +230,7 @@ func newStream(pkt *protos.Packet, tcptuple *common.TCPTuple) *stream {
 	s := &stream{
 		tcptuple: tcptuple,
 		data:     pkt.Payload,
-		message:  &mongodbMessage{ts: pkt.Ts},
+		message:  &mongodbMessage{ts: pkt.Ts, capture: pkt.Capture},
 	}
 	return s
 }
@@ -290,6 +313,7 @@ func newTransaction(requ, resp *mongodbMessage) *transaction {
 
 		trans.cmdline = requ.cmdlineTuple
 		trans.ts = requ.ts
+		trans.capture = requ.capture
 		trans.src, trans.dst = common.MakeEndpointPair(requ.tcpTuple.BaseTuple, requ.cmdlineTuple)
 		if requ.direction == tcp.TCPDirectionReverse {
 			trans.src, trans.dst = trans.dst, trans.src
@@ -311,6 +335,7 @@ func newTransaction(requ, resp *mongodbMessage) *transaction {
 
 		trans.endTime = resp.ts
 		trans.bytesOut = resp.messageLength
+		trans.capture.Add(&resp.capture)
 
 	}
 
@@ -392,6 +417,7 @@ func (mongodb *mongodbPlugin) publishTransaction(t *transaction) {
 	pbf.Event.End = t.endTime
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&t.capture)
 
 	fields := evt.Fields
 	fields["type"] = pbf.Event.Dataset
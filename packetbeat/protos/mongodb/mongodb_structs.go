@@ -23,10 +23,12 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 )
 
 type mongodbMessage struct {
-	ts time.Time
+	ts      time.Time
+	capture pb.Capture
 
 	tcpTuple     common.TCPTuple
 	cmdlineTuple *common.ProcessTuple
@@ -86,6 +88,7 @@ type transaction struct {
 	endTime  time.Time
 	bytesOut int
 	bytesIn  int
+	capture  pb.Capture
 
 	mongodb common.MapStr
 
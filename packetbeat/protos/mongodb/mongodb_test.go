@@ -391,3 +391,17 @@ func TestDocumentLengthBoundsChecked(t *testing.T) {
 	private = mongodb.Parse(&req, tcptuple, 0, private)
 	assert.NotNil(t, private, "mongodb parser recovered from a panic")
 }
+
+func TestDetectMongodb(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"\x3a\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\xd4\x07\x00\x00", protos.DetectionMatch},
+		{"\x3a\x00\x00\x00\x01\x00", protos.DetectionNeedMore},
+		{"\x3a\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x99\x00\x00\x00", protos.DetectionMismatch},
+		{"GET / HTTP/1.1\r\n\r\n", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectMongodb([]byte(test.data)), "%q", test.data)
+	}
+}
@@ -0,0 +1,196 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	errShortMessage    = errors.New("message too short")
+	errInvalidVarint   = errors.New("invalid variable byte integer")
+	errInvalidProperty = errors.New("invalid property")
+
+	errInvalidProtocolName = errors.New("invalid protocol name")
+)
+
+// maxVarintLen is the maximum size of a variable byte integer.
+const maxVarintLen = 4
+
+// decoder reads the fields of a packet. The first error is sticky, fields
+// read after an error are zero.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.buf) {
+		d.err = errShortMessage
+		d.buf = nil
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) skip(n int) {
+	d.take(n)
+}
+
+func (d *decoder) uint8() uint8 {
+	if b := d.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) uint16() uint16 {
+	if b := d.take(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *decoder) varint() int {
+	if d.err != nil {
+		return 0
+	}
+	v, n, err := readVarint(d.buf)
+	if err != nil || n == 0 {
+		if err == nil {
+			err = errShortMessage
+		}
+		d.err = err
+		d.buf = nil
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// string reads an UTF-8 string or binary data, prefixed by its length.
+func (d *decoder) string() string {
+	n := int(d.uint16())
+	return string(d.take(n))
+}
+
+func (d *decoder) empty() bool {
+	return d.err == nil && len(d.buf) == 0
+}
+
+// readVarint decodes a variable byte integer. It returns a size of 0 if the
+// data is too short.
+func readVarint(data []byte) (v int, n int, err error) {
+	for shift := uint(0); n < len(data); shift += 7 {
+		if n == maxVarintLen {
+			return 0, 0, errInvalidVarint
+		}
+		b := data[n]
+		n++
+		v |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, n, nil
+		}
+	}
+	if n >= maxVarintLen {
+		return 0, 0, errInvalidVarint
+	}
+	return 0, 0, nil
+}
+
+// properties of MQTT 5 packets reported in events
+type properties struct {
+	reasonString     string
+	assignedClientID string
+	topicAlias       uint16
+}
+
+// Property identifiers, defined in section 2.2.2.2 of the MQTT 5 specification.
+const (
+	propPayloadFormat        = 0x01
+	propMessageExpiry        = 0x02
+	propContentType          = 0x03
+	propResponseTopic        = 0x08
+	propCorrelationData      = 0x09
+	propSubscriptionID       = 0x0b
+	propSessionExpiry        = 0x11
+	propAssignedClientID     = 0x12
+	propServerKeepAlive      = 0x13
+	propAuthMethod           = 0x15
+	propAuthData             = 0x16
+	propRequestProblemInfo   = 0x17
+	propWillDelay            = 0x18
+	propRequestResponseInfo  = 0x19
+	propResponseInfo         = 0x1a
+	propServerReference      = 0x1c
+	propReasonString         = 0x1f
+	propReceiveMaximum       = 0x21
+	propTopicAliasMaximum    = 0x22
+	propTopicAlias           = 0x23
+	propMaximumQoS           = 0x24
+	propRetainAvailable      = 0x25
+	propUserProperty         = 0x26
+	propMaximumPacketSize    = 0x27
+	propWildcardSubAvailable = 0x28
+	propSubIDAvailable       = 0x29
+	propSharedSubAvailable   = 0x2a
+)
+
+// properties reads the properties of a MQTT 5 packet.
+func (d *decoder) properties() (props properties) {
+	n := d.varint()
+	pd := decoder{buf: d.take(n), err: d.err}
+	for pd.err == nil && len(pd.buf) > 0 {
+		switch id := pd.varint(); id {
+		case propPayloadFormat, propRequestProblemInfo, propRequestResponseInfo,
+			propMaximumQoS, propRetainAvailable, propWildcardSubAvailable,
+			propSubIDAvailable, propSharedSubAvailable:
+			pd.skip(1)
+		case propServerKeepAlive, propReceiveMaximum, propTopicAliasMaximum:
+			pd.skip(2)
+		case propTopicAlias:
+			props.topicAlias = pd.uint16()
+		case propMessageExpiry, propSessionExpiry, propWillDelay, propMaximumPacketSize:
+			pd.skip(4)
+		case propSubscriptionID:
+			pd.varint()
+		case propContentType, propResponseTopic, propCorrelationData,
+			propAuthMethod, propAuthData, propResponseInfo, propServerReference:
+			pd.string()
+		case propAssignedClientID:
+			props.assignedClientID = pd.string()
+		case propReasonString:
+			props.reasonString = pd.string()
+		case propUserProperty:
+			pd.string()
+			pd.string()
+		default:
+			pd.err = errInvalidProperty
+		}
+	}
+	if d.err == nil {
+		d.err = pd.err
+	}
+	return props
+}
@@ -0,0 +1,671 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/applayer"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+type connection struct {
+	streams   [2]applayer.FramedStream
+	clientDir uint8
+
+	// set by the CONNECT packet, MQTT 3.1.1 is assumed if not seen
+	version  uint8
+	clientID string
+
+	connect *message
+	// requests waiting for their acknowledgement, by direction of the
+	// requests and packet identifier
+	requests [2]map[uint16]*message
+	// topic aliases of MQTT 5, by direction
+	aliases [2]map[uint16]string
+}
+
+type message struct {
+	ts           time.Time
+	capture      pb.Capture
+	tcpTuple     common.TCPTuple
+	cmdlineTuple *common.ProcessTuple
+	direction    uint8
+	size         int
+	truncated    bool
+	invalid      bool
+
+	packetType packetType
+	flags      uint8
+	packetID   uint16
+	// size of the variable header and payload, and the bytes of them
+	// available
+	bodySize int
+	bodyLen  int
+
+	// CONNECT
+	version      uint8
+	clientID     string
+	username     string
+	cleanSession bool
+	keepAlive    uint16
+
+	// CONNACK
+	sessionPresent bool
+
+	// PUBLISH
+	topic       string
+	qos         uint8
+	retain      bool
+	dup         bool
+	payloadSize int
+
+	// SUBSCRIBE and UNSUBSCRIBE
+	topics []string
+
+	hasReasonCode bool
+	reasonCode    uint8
+	reasonCodes   []byte
+	props         properties
+
+	// QoS 2 flow of a PUBLISH request
+	pubrec    *message
+	pubrelLen int
+}
+
+// MQTT protocol plugin
+type mqttPlugin struct {
+	// config
+	ports              []int
+	maxMessageSize     int
+	maxPendingRequests int
+	transactionTimeout time.Duration
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("mqtt")
+	isDebug = false
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "mqtt.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "mqtt.unmatched_responses")
+)
+
+func init() {
+	protos.Register("mqtt", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &mqttPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (mqtt *mqttPlugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *mqttConfig) error {
+	mqtt.setFromConfig(config)
+
+	mqtt.results = results
+	mqtt.watcher = watcher
+	isDebug = logp.IsDebug("mqtt")
+
+	return nil
+}
+
+func (mqtt *mqttPlugin) setFromConfig(config *mqttConfig) {
+	mqtt.ports = config.Ports
+	mqtt.maxMessageSize = config.MaxMessageSize
+	mqtt.maxPendingRequests = config.MaxPendingRequests
+	mqtt.transactionTimeout = config.TransactionTimeout
+}
+
+func (mqtt *mqttPlugin) GetPorts() []int {
+	return mqtt.ports
+}
+
+func (mqtt *mqttPlugin) ConnectionTimeout() time.Duration {
+	return mqtt.transactionTimeout
+}
+
+// DetectTCP recognizes the CONNECT packet starting a connection.
+func (mqtt *mqttPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectMQTT(data)
+}
+
+func detectMQTT(data []byte) protos.Detection {
+	if len(data) == 0 {
+		return protos.DetectionNeedMore
+	}
+	if packetType(data[0]>>4) != packetConnect || data[0]&0x0f != 0 {
+		return protos.DetectionMismatch
+	}
+	_, n, err := readVarint(data[1:])
+	if err != nil {
+		return protos.DetectionMismatch
+	}
+	if n == 0 {
+		return protos.DetectionNeedMore
+	}
+
+	// protocol name and level
+	hdr := data[1+n:]
+	for _, name := range [][]byte{protocolNameMQTT, protocolNameMQIsdp} {
+		expected := append([]byte{0, byte(len(name))}, name...)
+		if len(hdr) < len(expected)+1 {
+			if bytes.HasPrefix(expected, hdr) {
+				return protos.DetectionNeedMore
+			}
+			continue
+		}
+		if bytes.HasPrefix(hdr, expected) {
+			if level := hdr[len(expected)]; level < mqtt31 || level > mqtt5 {
+				return protos.DetectionMismatch
+			}
+			return protos.DetectionMatch
+		}
+	}
+	return protos.DetectionMismatch
+}
+
+func (mqtt *mqttPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseMQTT exception")
+
+	conn := mqtt.ensureConnection(private, tcptuple)
+	mqtt.doParse(conn, pkt, tcptuple, dir)
+	return conn
+}
+
+func (mqtt *mqttPlugin) newConnectionData(tcptuple *common.TCPTuple) *connection {
+	// The client sends the CONNECT packet. The first packet seen usually
+	// comes from the client too, unless the connection started before the
+	// capture.
+	clientDir := uint8(tcp.TCPDirectionOriginal)
+	if mqtt.isServerPort(tcptuple.SrcPort) && !mqtt.isServerPort(tcptuple.DstPort) {
+		clientDir = tcp.TCPDirectionReverse
+	}
+	return &connection{
+		clientDir: clientDir,
+		version:   mqtt311,
+		requests:  [2]map[uint16]*message{{}, {}},
+	}
+}
+
+func (mqtt *mqttPlugin) isServerPort(port uint16) bool {
+	for _, p := range mqtt.ports {
+		if p == int(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mqtt *mqttPlugin) ensureConnection(private protos.ProtocolData, tcptuple *common.TCPTuple) *connection {
+	if private == nil {
+		return mqtt.newConnectionData(tcptuple)
+	}
+
+	priv, ok := private.(*connection)
+	if !ok {
+		logp.Warn("mqtt connection data type error, create new one")
+		return mqtt.newConnectionData(tcptuple)
+	}
+	if priv == nil {
+		logp.Warn("Unexpected: mqtt connection data not set, create new one")
+		return mqtt.newConnectionData(tcptuple)
+	}
+
+	return priv
+}
+
+func (mqtt *mqttPlugin) doParse(
+	conn *connection,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	st := &conn.streams[dir]
+	if !st.Append(pkt.Payload, pkt.Ts, &pkt.Capture) {
+		return
+	}
+
+	for {
+		// Packets larger than the limit are decoded from their first
+		// bytes, the rest is skipped.
+		f, ok, err := st.Next(readFixedHeader, mqtt.maxMessageSize)
+		if err != nil {
+			if isDebug {
+				debugf("invalid packet length, dropping stream data")
+			}
+			return
+		}
+		if !ok {
+			return
+		}
+		mqtt.handleMQTT(conn, f.Data[0], f.Data[f.HeaderLen:], f.Size-f.HeaderLen, f.Size, f.Truncated, f.Ts, &f.Capture, tcptuple, dir)
+	}
+}
+
+// readFixedHeader reads the remaining length of the fixed header of a
+// packet.
+func readFixedHeader(data []byte) (hdrLen, size int, err error) {
+	size, n, err := readVarint(data[1:])
+	if err != nil || n == 0 {
+		return 0, 0, err
+	}
+	return 1 + n, size, nil
+}
+
+func (mqtt *mqttPlugin) handleMQTT(
+	conn *connection,
+	first byte,
+	body []byte,
+	bodySize int,
+	size int,
+	truncated bool,
+	ts time.Time,
+	capture *pb.Capture,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	m := &message{
+		ts:         ts,
+		capture:    *capture,
+		tcpTuple:   *tcptuple,
+		direction:  dir,
+		size:       size,
+		truncated:  truncated,
+		packetType: packetType(first >> 4),
+		flags:      first & 0x0f,
+		bodySize:   bodySize,
+		bodyLen:    len(body),
+	}
+	d := &decoder{buf: body}
+	parseBody(d, m, conn.version)
+	// Truncated packets are only reported as invalid if the fields reported
+	// are missing. The payload of PUBLISH packets is not decoded.
+	m.invalid = d.err != nil && (!truncated || d.err != errShortMessage)
+	if isDebug {
+		debugf("%s packet of %d bytes", m.packetType, size)
+	}
+
+	switch m.packetType {
+	case packetConnect:
+		if m.invalid {
+			return
+		}
+		conn.clientDir = dir
+		conn.version = m.version
+		conn.clientID = m.clientID
+		if conn.connect != nil {
+			unmatchedRequests.Add(1)
+			mqtt.publishTransaction(conn, conn.connect, nil)
+		}
+		m.cmdlineTuple = mqtt.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		conn.connect = m
+
+	case packetConnack:
+		requ := conn.connect
+		if requ == nil {
+			unmatchedResponses.Add(1)
+			return
+		}
+		conn.connect = nil
+		if m.props.assignedClientID != "" {
+			conn.clientID = m.props.assignedClientID
+		}
+		mqtt.publishTransaction(conn, requ, m)
+
+	case packetPublish:
+		mqtt.resolveTopicAlias(conn, m)
+		m.cmdlineTuple = mqtt.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		if m.qos == 0 || m.invalid {
+			mqtt.publishTransaction(conn, m, nil)
+			return
+		}
+		mqtt.onRequest(conn, m)
+
+	case packetSubscribe, packetUnsubscribe:
+		m.cmdlineTuple = mqtt.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		mqtt.onRequest(conn, m)
+
+	case packetPuback, packetPubcomp, packetSuback, packetUnsuback:
+		mqtt.onResponse(conn, m)
+
+	case packetPubrec:
+		requ := conn.requests[1-dir][m.packetID]
+		if requ == nil || requ.packetType != packetPublish || requ.qos != 2 {
+			unmatchedResponses.Add(1)
+			return
+		}
+		requ.pubrec = m
+		if m.hasReasonCode && isFailure(conn.version, m.packetType, m.reasonCode) {
+			// the flow ends with the refusal
+			delete(conn.requests[1-dir], m.packetID)
+			mqtt.publishTransaction(conn, requ, m)
+		}
+
+	case packetPubrel:
+		if requ := conn.requests[dir][m.packetID]; requ != nil {
+			requ.pubrelLen = m.size
+		}
+
+	case packetDisconnect:
+		m.cmdlineTuple = mqtt.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		mqtt.publishTransaction(conn, m, nil)
+	}
+}
+
+// resolveTopicAlias sets the topic of MQTT 5 PUBLISH packets sent with a
+// topic alias only.
+func (mqtt *mqttPlugin) resolveTopicAlias(conn *connection, m *message) {
+	alias := m.props.topicAlias
+	if alias == 0 {
+		return
+	}
+	aliases := conn.aliases[m.direction]
+	if m.topic != "" {
+		if aliases == nil {
+			aliases = map[uint16]string{}
+			conn.aliases[m.direction] = aliases
+		}
+		aliases[alias] = m.topic
+		return
+	}
+	m.topic = aliases[alias]
+}
+
+func (mqtt *mqttPlugin) onRequest(conn *connection, m *message) {
+	requests := conn.requests[m.direction]
+	if _, exists := requests[m.packetID]; exists {
+		if isDebug {
+			debugf("duplicate packet ID %d, dropping old request", m.packetID)
+		}
+		unmatchedRequests.Add(1)
+	} else if len(requests) >= mqtt.maxPendingRequests {
+		if isDebug {
+			debugf("too many pending requests, ignoring request %d", m.packetID)
+		}
+		unmatchedRequests.Add(1)
+		return
+	}
+	requests[m.packetID] = m
+}
+
+// expectedResponse returns the type of the packet completing a request.
+func expectedResponse(requ *message) packetType {
+	switch requ.packetType {
+	case packetPublish:
+		if requ.qos == 2 {
+			return packetPubcomp
+		}
+		return packetPuback
+	case packetSubscribe:
+		return packetSuback
+	case packetUnsubscribe:
+		return packetUnsuback
+	}
+	return 0
+}
+
+func (mqtt *mqttPlugin) onResponse(conn *connection, m *message) {
+	requests := conn.requests[1-m.direction]
+	requ := requests[m.packetID]
+	if requ == nil || expectedResponse(requ) != m.packetType {
+		if isDebug {
+			debugf("%s with unknown packet ID %d", m.packetType, m.packetID)
+		}
+		unmatchedResponses.Add(1)
+		return
+	}
+	delete(requests, m.packetID)
+	mqtt.publishTransaction(conn, requ, m)
+}
+
+func (mqtt *mqttPlugin) publishTransaction(conn *connection, requ, resp *message) {
+	if mqtt.results == nil {
+		return
+	}
+	mqtt.results(mqtt.newTransaction(conn, requ, resp))
+}
+
+// isOneWay returns true for the packets not acknowledged.
+func isOneWay(m *message) bool {
+	return m.packetType == packetDisconnect ||
+		(m.packetType == packetPublish && m.qos == 0)
+}
+
+func (mqtt *mqttPlugin) newTransaction(conn *connection, requ, resp *message) beat.Event {
+	source, destination := common.MakeEndpointPair(requ.tcpTuple.BaseTuple, requ.cmdlineTuple)
+	src, dst := &source, &destination
+	if requ.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(requ.ts)
+	pbf.SetSource(src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(dst)
+	pbf.AddIP(dst.IP)
+	pbf.Source.Bytes = int64(requ.size + requ.pubrelLen)
+	pbf.Event.Dataset = "mqtt"
+	pbf.Event.Start = requ.ts
+	pbf.Event.Action = "mqtt." + strings.ToLower(requ.packetType.String())
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&requ.capture)
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = requ.packetType.String()
+
+	status := common.OK_STATUS
+	version := conn.version
+	if requ.packetType == packetConnect {
+		version = requ.version
+	}
+	info := common.MapStr{
+		"packet_type":      requ.packetType.String(),
+		"protocol_version": versionString(version),
+	}
+	clientID := conn.clientID
+	if requ.packetType == packetConnect && requ.clientID != "" {
+		clientID = requ.clientID
+	}
+	if clientID != "" {
+		info["client_id"] = clientID
+	}
+	if requ.packetID != 0 {
+		info["packet_id"] = requ.packetID
+	}
+
+	switch requ.packetType {
+	case packetConnect:
+		info["clean_session"] = requ.cleanSession
+		info["keep_alive"] = requ.keepAlive
+		if requ.username != "" {
+			pbf.AddUser(requ.username)
+		}
+	case packetPublish:
+		if requ.topic != "" {
+			info["topic"] = requ.topic
+			fields["resource"] = requ.topic
+		}
+		info["qos"] = requ.qos
+		info["retain"] = requ.retain
+		if requ.dup {
+			info["dup"] = true
+		}
+		if !requ.invalid {
+			info["payload_size"] = requ.payloadSize
+		}
+	case packetSubscribe, packetUnsubscribe:
+		if len(requ.topics) > 0 {
+			info["topics"] = requ.topics
+			if len(requ.topics) == 1 {
+				fields["resource"] = requ.topics[0]
+			}
+		}
+	}
+
+	var notes []string
+	for _, m := range []*message{requ, resp} {
+		switch {
+		case m == nil:
+		case m.invalid:
+			notes = append(notes, "Failed to decode "+m.packetType.String()+" packet")
+		case m.truncated && m.packetType != packetPublish:
+			notes = append(notes, "Packet truncated")
+		}
+	}
+
+	// The reason codes are reported by the responses, or by the
+	// DISCONNECT packets.
+	codes := resp
+	if resp == nil && requ.packetType == packetDisconnect {
+		codes = requ
+	}
+	if codes != nil {
+		if resp != nil {
+			info["response_type"] = resp.packetType.String()
+			pbf.Event.End = resp.ts
+			pbf.Destination.Bytes = int64(resp.size)
+			pbf.AddCapture(&resp.capture)
+			if requ.pubrec != nil && requ.pubrec != resp {
+				pbf.Destination.Bytes += int64(requ.pubrec.size)
+			}
+			if resp.packetType == packetConnack {
+				info["session_present"] = resp.sessionPresent
+			}
+		}
+		failed := false
+		if codes.hasReasonCode {
+			info["reason_code"] = codes.reasonCode
+			info["reason"] = reasonName(version, codes.packetType, codes.reasonCode)
+			failed = isFailure(version, codes.packetType, codes.reasonCode)
+		}
+		if len(codes.reasonCodes) > 0 {
+			reasonCodes := make([]int, len(codes.reasonCodes))
+			for i, code := range codes.reasonCodes {
+				reasonCodes[i] = int(code)
+				failed = failed || isFailure(version, codes.packetType, code)
+			}
+			info["reason_codes"] = reasonCodes
+		}
+		if codes.props.reasonString != "" {
+			info["reason_string"] = codes.props.reasonString
+		}
+		if failed {
+			status = common.ERROR_STATUS
+			pbf.Event.Outcome = "failure"
+		}
+	} else if !isOneWay(requ) {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Unmatched request")
+	}
+
+	fields["status"] = status
+	fields["mqtt"] = info
+	pbf.Error.Message = notes
+	return evt
+}
+
+func (mqtt *mqttPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	// The packet boundaries are lost. Publish the pending requests before
+	// the connection state is dropped.
+	if conn, ok := private.(*connection); ok && conn != nil {
+		mqtt.flushRequests(conn)
+	}
+	return private, true
+}
+
+func (mqtt *mqttPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	return private
+}
+
+// Expired publishes the requests still waiting for a response when the
+// connection expires.
+func (mqtt *mqttPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	mqtt.flushRequests(conn)
+}
+
+func (mqtt *mqttPlugin) flushRequests(conn *connection) {
+	var requests []*message
+	if conn.connect != nil {
+		requests = append(requests, conn.connect)
+		conn.connect = nil
+	}
+	for dir := range conn.requests {
+		pending := make([]*message, 0, len(conn.requests[dir]))
+		for _, m := range conn.requests[dir] {
+			pending = append(pending, m)
+		}
+		sort.Slice(pending, func(i, j int) bool {
+			return pending[i].packetID < pending[j].packetID
+		})
+		requests = append(requests, pending...)
+		conn.requests[dir] = map[uint16]*message{}
+	}
+
+	for _, m := range requests {
+		unmatchedRequests.Add(1)
+		mqtt.publishTransaction(conn, m, nil)
+	}
+}
@@ -0,0 +1,498 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package mqtt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func mqttModForTests(store *eventStore) *mqttPlugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	mqtt, err := New(false, callback, procs.ProcessesWatcher{}, common.NewConfig())
+	if err != nil {
+		panic(err)
+	}
+	return mqtt.(*mqttPlugin)
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 1883,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// encoder writes the fields of a packet body.
+type encoder struct {
+	bytes.Buffer
+}
+
+func (e *encoder) uint8(v uint8) {
+	e.WriteByte(v)
+}
+
+func (e *encoder) uint16(v uint16) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *encoder) varint(v int) {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v > 0 {
+			b |= 0x80
+		}
+		e.WriteByte(b)
+		if v == 0 {
+			return
+		}
+	}
+}
+
+func (e *encoder) string(s string) {
+	e.uint16(uint16(len(s)))
+	e.WriteString(s)
+}
+
+// properties writes the MQTT 5 properties encoded by fn.
+func (e *encoder) properties(fn func(p *encoder)) {
+	var p encoder
+	if fn != nil {
+		fn(&p)
+	}
+	e.varint(p.Len())
+	e.Write(p.Bytes())
+}
+
+// packet returns the packet with the body written by fn.
+func packet(typ packetType, flags uint8, fn func(e *encoder)) []byte {
+	var body encoder
+	if fn != nil {
+		fn(&body)
+	}
+	var e encoder
+	e.uint8(uint8(typ)<<4 | flags)
+	e.varint(body.Len())
+	e.Write(body.Bytes())
+	return e.Bytes()
+}
+
+func connect(version uint8, clientID, username string, props bool) []byte {
+	return packet(packetConnect, 0, func(e *encoder) {
+		e.string("MQTT")
+		e.uint8(version)
+		flags := uint8(connectCleanSession)
+		if username != "" {
+			flags |= connectUsername | 0x40
+		}
+		e.uint8(flags)
+		e.uint16(60)
+		if props {
+			e.properties(func(p *encoder) {
+				p.uint8(propSessionExpiry)
+				p.Write([]byte{0, 0, 0x0e, 0x10})
+				p.uint8(propUserProperty)
+				p.string("region")
+				p.string("eu")
+			})
+		}
+		e.string(clientID)
+		if username != "" {
+			e.string(username)
+			e.string("secret")
+		}
+	})
+}
+
+func ack(typ packetType, id uint16) []byte {
+	return packet(typ, 0, func(e *encoder) { e.uint16(id) })
+}
+
+// segment is the payload of a TCP segment sent in a direction.
+type segment struct {
+	dir  uint8
+	data []byte
+}
+
+func client(data []byte) segment {
+	return segment{tcp.TCPDirectionOriginal, data}
+}
+
+func server(data []byte) segment {
+	return segment{tcp.TCPDirectionReverse, data}
+}
+
+// chunks splits data in segments of at most size bytes.
+func chunks(dir uint8, data []byte, size int) []segment {
+	var segments []segment
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		segments = append(segments, segment{dir, data[:n]})
+		data = data[n:]
+	}
+	return segments
+}
+
+func TestReadVarint(t *testing.T) {
+	for _, test := range []struct {
+		data []byte
+		v, n int
+		err  error
+	}{
+		{data: []byte{0x00}, v: 0, n: 1},
+		{data: []byte{0x7f}, v: 127, n: 1},
+		{data: []byte{0x80, 0x01}, v: 128, n: 2},
+		{data: []byte{0xff, 0xff, 0xff, 0x7f}, v: 268435455, n: 4},
+		{data: []byte{0x80}},
+		{data: []byte{0xff, 0xff, 0xff, 0xff}, err: errInvalidVarint},
+		{data: []byte{0xff, 0xff, 0xff, 0xff, 0x01}, err: errInvalidVarint},
+	} {
+		v, n, err := readVarint(test.data)
+		assert.Equal(t, test.err, err, "% x", test.data)
+		assert.Equal(t, test.v, v, "% x", test.data)
+		assert.Equal(t, test.n, n, "% x", test.data)
+	}
+}
+
+func TestDetectMQTT(t *testing.T) {
+	conn := connect(mqtt311, "sensor-1", "", false)
+	assert.Equal(t, protos.DetectionMatch, detectMQTT(conn))
+	assert.Equal(t, protos.DetectionMatch, detectMQTT(connect(mqtt5, "sensor-1", "", true)))
+	assert.Equal(t, protos.DetectionNeedMore, detectMQTT(conn[:5]))
+	assert.Equal(t, protos.DetectionNeedMore, detectMQTT(conn[:1]))
+
+	legacy := packet(packetConnect, 0, func(e *encoder) {
+		e.string("MQIsdp")
+		e.uint8(mqtt31)
+	})
+	assert.Equal(t, protos.DetectionMatch, detectMQTT(legacy))
+
+	invalidLevel := append([]byte(nil), conn...)
+	invalidLevel[8] = 9
+	assert.Equal(t, protos.DetectionMismatch, detectMQTT(invalidLevel))
+	assert.Equal(t, protos.DetectionMismatch, detectMQTT([]byte("GET / HTTP/1.1\r\n")))
+	assert.Equal(t, protos.DetectionMismatch, detectMQTT(ack(packetPuback, 1)))
+}
+
+// publishPacket returns a MQTT 3.1.1 publish with the retain flag set.
+func publishPacket(qos uint8, id uint16, topic string, payload string) []byte {
+	return packet(packetPublish, qos<<1|publishRetain, func(e *encoder) {
+		e.string(topic)
+		if qos > 0 {
+			e.uint16(id)
+		}
+		e.WriteString(payload)
+	})
+}
+
+// aliasedPublish returns a QoS 1 MQTT 5 publish setting a topic alias.
+func aliasedPublish(id uint16, topic string, alias uint16) []byte {
+	return packet(packetPublish, 1<<1, func(e *encoder) {
+		e.string(topic)
+		e.uint16(id)
+		e.properties(func(p *encoder) {
+			p.uint8(propTopicAlias)
+			p.uint16(alias)
+		})
+		e.WriteString("{}")
+	})
+}
+
+func TestMQTTParser(t *testing.T) {
+	connect311 := connect(mqtt311, "sensor-1", "device", false)
+	largePublish := packet(packetPublish, 1<<1, func(e *encoder) {
+		e.string("firmware/image")
+		e.uint16(3)
+		e.Write(make([]byte, 1000))
+	})
+
+	for _, test := range []struct {
+		title          string
+		segments       []segment
+		maxMessageSize int
+		expire         bool
+		expected       []common.MapStr
+	}{
+		{
+			title: "connect refused",
+			segments: []segment{
+				client(connect311[:3]),
+				client(connect311[3:]),
+				server(packet(packetConnack, 0, func(e *encoder) {
+					e.uint8(0)
+					e.uint8(5)
+				})),
+			},
+			expected: []common.MapStr{{
+				"type":                  "mqtt",
+				"method":                "CONNECT",
+				"status":                common.ERROR_STATUS,
+				"mqtt.packet_type":      "CONNECT",
+				"mqtt.response_type":    "CONNACK",
+				"mqtt.protocol_version": "3.1.1",
+				"mqtt.client_id":        "sensor-1",
+				"mqtt.clean_session":    true,
+				"mqtt.keep_alive":       60,
+				"mqtt.reason_code":      5,
+				"mqtt.reason":           "Not authorized",
+			}},
+		},
+		{
+			title: "publish",
+			segments: []segment{
+				client(connect(mqtt311, "sensor-1", "", false)),
+				server(packet(packetConnack, 0, func(e *encoder) { e.uint16(0) })),
+				// QoS 0 publishes are reported alone
+				client(publishPacket(0, 0, "sensors/1/temperature", "21.5")),
+				// QoS 1 publishes in both directions, with the same packet ID
+				client(publishPacket(1, 7, "sensors/1/humidity", "40")),
+				server(publishPacket(1, 7, "commands/1", "reboot")),
+				client(ack(packetPuback, 7)),
+				server(ack(packetPuback, 7)),
+				// QoS 2 flow
+				client(publishPacket(2, 8, "sensors/1/alerts", "overheat")),
+				server(ack(packetPubrec, 8)),
+				client(ack(packetPubrel, 8)),
+				server(ack(packetPubcomp, 8)),
+			},
+			expected: []common.MapStr{
+				{
+					"method": "CONNECT",
+				},
+				{
+					"method":             "PUBLISH",
+					"resource":           "sensors/1/temperature",
+					"status":             common.OK_STATUS,
+					"mqtt.client_id":     "sensor-1",
+					"mqtt.qos":           0,
+					"mqtt.retain":        true,
+					"mqtt.payload_size":  4,
+					"mqtt.response_type": nil,
+				},
+				{
+					"mqtt.topic":         "commands/1",
+					"source.ip":          "192.168.0.2",
+					"mqtt.packet_id":     7,
+					"mqtt.response_type": "PUBACK",
+				},
+				{
+					"mqtt.topic": "sensors/1/humidity",
+				},
+				{
+					"mqtt.topic":         "sensors/1/alerts",
+					"mqtt.qos":           2,
+					"mqtt.response_type": "PUBCOMP",
+					"source.bytes":       30 + 4,
+					"destination.bytes":  4 + 4,
+				},
+			},
+		},
+		{
+			title: "subscribe and unsubscribe",
+			segments: []segment{
+				client(packet(packetSubscribe, 0x02, func(e *encoder) {
+					e.uint16(1)
+					e.string("sensors/+/temperature")
+					e.uint8(1)
+					e.string("admin/#")
+					e.uint8(0)
+				})),
+				server(packet(packetSuback, 0, func(e *encoder) {
+					e.uint16(1)
+					e.Write([]byte{0x01, 0x80})
+				})),
+				client(packet(packetUnsubscribe, 0x02, func(e *encoder) {
+					e.uint16(2)
+					e.string("sensors/+/temperature")
+				})),
+				server(ack(packetUnsuback, 2)),
+			},
+			expected: []common.MapStr{
+				{
+					"method":            "SUBSCRIBE",
+					"status":            common.ERROR_STATUS,
+					"mqtt.topics":       []string{"sensors/+/temperature", "admin/#"},
+					"mqtt.reason_codes": []int{1, 0x80},
+				},
+				{
+					"method":   "UNSUBSCRIBE",
+					"resource": "sensors/+/temperature",
+					"status":   common.OK_STATUS,
+				},
+			},
+		},
+		{
+			title: "version 5",
+			segments: []segment{
+				client(connect(mqtt5, "", "", true)),
+				server(packet(packetConnack, 0, func(e *encoder) {
+					e.uint8(0)
+					e.uint8(0)
+					e.properties(func(p *encoder) {
+						p.uint8(propAssignedClientID)
+						p.string("auto-1")
+						p.uint8(propTopicAliasMaximum)
+						p.uint16(10)
+					})
+				})),
+				client(aliasedPublish(1, "sensors/1/temperature", 3)),
+				client(aliasedPublish(2, "", 3)),
+				server(packet(packetPuback, 0, func(e *encoder) {
+					e.uint16(1)
+					e.uint8(0x10)
+				})),
+				server(packet(packetPuback, 0, func(e *encoder) {
+					e.uint16(2)
+					e.uint8(0x97)
+					e.properties(func(p *encoder) {
+						p.uint8(propReasonString)
+						p.string("daily quota reached")
+					})
+				})),
+				server(packet(packetDisconnect, 0, func(e *encoder) {
+					e.uint8(0x8e)
+				})),
+			},
+			expected: []common.MapStr{
+				{
+					"mqtt.protocol_version": "5.0",
+					"status":                common.OK_STATUS,
+					"mqtt.reason":           "Success",
+				},
+				{
+					"mqtt.client_id":    "auto-1",
+					"mqtt.topic":        "sensors/1/temperature",
+					"mqtt.payload_size": 2,
+					"status":            common.OK_STATUS,
+					"mqtt.reason":       "No matching subscribers",
+				},
+				{
+					"mqtt.topic":         "sensors/1/temperature",
+					"status":             common.ERROR_STATUS,
+					"mqtt.reason":        "Quota exceeded",
+					"mqtt.reason_string": "daily quota reached",
+				},
+				{
+					"method":      "DISCONNECT",
+					"source.ip":   "192.168.0.2",
+					"status":      common.ERROR_STATUS,
+					"mqtt.reason": "Session taken over",
+				},
+			},
+		},
+		{
+			title:          "truncated publish",
+			maxMessageSize: 100,
+			segments: append(
+				chunks(tcp.TCPDirectionOriginal, largePublish, 60),
+				server(ack(packetPuback, 3)),
+			),
+			expected: []common.MapStr{{
+				"mqtt.topic":        "firmware/image",
+				"mqtt.payload_size": 1000,
+				"status":            common.OK_STATUS,
+				"error.message":     nil,
+			}},
+		},
+		{
+			title: "expired",
+			segments: []segment{
+				client(packet(packetSubscribe, 0x02, func(e *encoder) {
+					e.uint16(5)
+					e.string("sensors/#")
+					e.uint8(0)
+				})),
+			},
+			expire: true,
+			expected: []common.MapStr{{
+				"status":        common.ERROR_STATUS,
+				"error.message": "Unmatched request",
+			}},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			mqtt := mqttModForTests(&store)
+			if test.maxMessageSize != 0 {
+				mqtt.maxMessageSize = test.maxMessageSize
+			}
+			tcptuple := testTCPTuple()
+			ts := time.Now()
+
+			var private protos.ProtocolData
+			for i, s := range test.segments {
+				pkt := protos.Packet{Ts: ts.Add(time.Duration(i) * time.Millisecond), Payload: s.data}
+				private = mqtt.Parse(&pkt, tcptuple, s.dir, private)
+			}
+			if test.expire {
+				mqtt.Expired(tcptuple, private)
+			}
+
+			if !assert.Len(t, store.events, len(test.expected)) {
+				return
+			}
+			for i, expected := range test.expected {
+				for field, value := range expected {
+					actual, err := store.events[i].GetValue(field)
+					if value == nil {
+						assert.Equal(t, common.ErrKeyNotFound, err, field)
+						continue
+					}
+					assert.NoError(t, err, field)
+					assert.EqualValues(t, value, actual, field)
+				}
+			}
+		})
+	}
+}
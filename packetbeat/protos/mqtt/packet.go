@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"bytes"
+	"strconv"
+)
+
+type packetType uint8
+
+// Control packet types, defined in section 2.1.2 of the specifications.
+const (
+	packetConnect     packetType = 1
+	packetConnack     packetType = 2
+	packetPublish     packetType = 3
+	packetPuback      packetType = 4
+	packetPubrec      packetType = 5
+	packetPubrel      packetType = 6
+	packetPubcomp     packetType = 7
+	packetSubscribe   packetType = 8
+	packetSuback      packetType = 9
+	packetUnsubscribe packetType = 10
+	packetUnsuback    packetType = 11
+	packetPingreq     packetType = 12
+	packetPingresp    packetType = 13
+	packetDisconnect  packetType = 14
+	packetAuth        packetType = 15
+)
+
+var packetTypeNames = map[packetType]string{
+	packetConnect:     "CONNECT",
+	packetConnack:     "CONNACK",
+	packetPublish:     "PUBLISH",
+	packetPuback:      "PUBACK",
+	packetPubrec:      "PUBREC",
+	packetPubrel:      "PUBREL",
+	packetPubcomp:     "PUBCOMP",
+	packetSubscribe:   "SUBSCRIBE",
+	packetSuback:      "SUBACK",
+	packetUnsubscribe: "UNSUBSCRIBE",
+	packetUnsuback:    "UNSUBACK",
+	packetPingreq:     "PINGREQ",
+	packetPingresp:    "PINGRESP",
+	packetDisconnect:  "DISCONNECT",
+	packetAuth:        "AUTH",
+}
+
+func (t packetType) String() string {
+	if name, ok := packetTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Protocol levels of the CONNECT packets.
+const (
+	mqtt31  = 3
+	mqtt311 = 4
+	mqtt5   = 5
+)
+
+func versionString(level uint8) string {
+	switch level {
+	case mqtt31:
+		return "3.1"
+	case mqtt311:
+		return "3.1.1"
+	case mqtt5:
+		return "5.0"
+	}
+	return strconv.Itoa(int(level))
+}
+
+var (
+	protocolNameMQTT   = []byte("MQTT")
+	protocolNameMQIsdp = []byte("MQIsdp") // MQTT 3.1
+)
+
+// Flags of the CONNECT packets.
+const (
+	connectCleanSession = 0x02
+	connectWill         = 0x04
+	connectUsername     = 0x80
+)
+
+// Flags of the PUBLISH packets.
+const (
+	publishRetain = 0x01
+	publishDup    = 0x08
+)
+
+// parseBody decodes the variable header and the payload of a packet. Only
+// the first bytes of truncated packets are available.
+func parseBody(d *decoder, m *message, version uint8) {
+	v5 := version == mqtt5
+	switch m.packetType {
+	case packetConnect:
+		parseConnect(d, m)
+
+	case packetConnack:
+		m.sessionPresent = d.uint8()&0x01 != 0
+		m.reasonCode = d.uint8()
+		m.hasReasonCode = true
+		if v5 {
+			m.props = d.properties()
+		}
+
+	case packetPublish:
+		m.qos = (m.flags >> 1) & 0x03
+		m.retain = m.flags&publishRetain != 0
+		m.dup = m.flags&publishDup != 0
+		m.topic = d.string()
+		if m.qos > 0 {
+			m.packetID = d.uint16()
+		}
+		if v5 {
+			m.props = d.properties()
+		}
+		if d.err == nil {
+			m.payloadSize = m.bodySize - (m.bodyLen - len(d.buf))
+		}
+
+	case packetPuback, packetPubrec, packetPubrel, packetPubcomp:
+		m.packetID = d.uint16()
+		if v5 && !d.empty() {
+			m.reasonCode = d.uint8()
+			m.hasReasonCode = true
+			if !d.empty() {
+				m.props = d.properties()
+			}
+		}
+
+	case packetSubscribe, packetUnsubscribe:
+		m.packetID = d.uint16()
+		if v5 {
+			m.props = d.properties()
+		}
+		for d.err == nil && len(d.buf) > 0 {
+			m.topics = append(m.topics, d.string())
+			if m.packetType == packetSubscribe {
+				d.skip(1) // subscription options
+			}
+		}
+
+	case packetSuback, packetUnsuback:
+		m.packetID = d.uint16()
+		if v5 {
+			m.props = d.properties()
+		}
+		if d.err == nil && len(d.buf) > 0 {
+			m.reasonCodes = append([]byte(nil), d.buf...)
+		}
+
+	case packetDisconnect:
+		if v5 && !d.empty() {
+			m.reasonCode = d.uint8()
+			m.hasReasonCode = true
+			if !d.empty() {
+				m.props = d.properties()
+			}
+		}
+	}
+}
+
+func parseConnect(d *decoder, m *message) {
+	name := d.take(int(d.uint16()))
+	if d.err != nil {
+		return
+	}
+	if !bytes.Equal(name, protocolNameMQTT) && !bytes.Equal(name, protocolNameMQIsdp) {
+		d.err = errInvalidProtocolName
+		return
+	}
+	m.version = d.uint8()
+	flags := d.uint8()
+	m.cleanSession = flags&connectCleanSession != 0
+	m.keepAlive = d.uint16()
+	v5 := m.version == mqtt5
+	if v5 {
+		d.properties()
+	}
+
+	m.clientID = d.string()
+	if flags&connectWill != 0 {
+		if v5 {
+			d.properties()
+		}
+		d.string() // topic
+		d.string() // payload
+	}
+	if flags&connectUsername != 0 {
+		m.username = d.string()
+	}
+}
@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import "strconv"
+
+// Reason codes of MQTT 5, defined in section 2.4 of the specification.
+var reasonCodeNames = map[uint8]string{
+	0x00: "Success",
+	0x01: "Granted QoS 1",
+	0x02: "Granted QoS 2",
+	0x04: "Disconnect with Will Message",
+	0x10: "No matching subscribers",
+	0x11: "No subscription existed",
+	0x18: "Continue authentication",
+	0x19: "Re-authenticate",
+	0x80: "Unspecified error",
+	0x81: "Malformed Packet",
+	0x82: "Protocol Error",
+	0x83: "Implementation specific error",
+	0x84: "Unsupported Protocol Version",
+	0x85: "Client Identifier not valid",
+	0x86: "Bad User Name or Password",
+	0x87: "Not authorized",
+	0x88: "Server unavailable",
+	0x89: "Server busy",
+	0x8a: "Banned",
+	0x8b: "Server shutting down",
+	0x8c: "Bad authentication method",
+	0x8d: "Keep Alive timeout",
+	0x8e: "Session taken over",
+	0x8f: "Topic Filter invalid",
+	0x90: "Topic Name invalid",
+	0x91: "Packet Identifier in use",
+	0x92: "Packet Identifier not found",
+	0x93: "Receive Maximum exceeded",
+	0x94: "Topic Alias invalid",
+	0x95: "Packet too large",
+	0x96: "Message rate too high",
+	0x97: "Quota exceeded",
+	0x98: "Administrative action",
+	0x99: "Payload format invalid",
+	0x9a: "Retain not supported",
+	0x9b: "QoS not supported",
+	0x9c: "Use another server",
+	0x9d: "Server moved",
+	0x9e: "Shared Subscriptions not supported",
+	0x9f: "Connection rate exceeded",
+	0xa0: "Maximum connect time",
+	0xa1: "Subscription Identifiers not supported",
+	0xa2: "Wildcard Subscriptions not supported",
+}
+
+// Return codes of the CONNACK packets of MQTT 3.1 and 3.1.1.
+var connackReturnCodeNames = map[uint8]string{
+	0: "Connection Accepted",
+	1: "Unacceptable protocol version",
+	2: "Identifier rejected",
+	3: "Server unavailable",
+	4: "Bad user name or password",
+	5: "Not authorized",
+}
+
+// failureCode is the code of the SUBACK packets of MQTT 3.1.1 refusing a
+// subscription, and the lowest reason code of errors of MQTT 5.
+const failureCode = 0x80
+
+func reasonName(version uint8, typ packetType, code uint8) string {
+	var name string
+	switch {
+	case version != mqtt5 && typ == packetConnack:
+		name = connackReturnCodeNames[code]
+	case version != mqtt5 && code == failureCode:
+		name = "Failure"
+	case typ == packetSuback && code == 0:
+		name = "Granted QoS 0"
+	case typ == packetDisconnect && code == 0:
+		name = "Normal disconnection"
+	default:
+		name = reasonCodeNames[code]
+	}
+	if name == "" {
+		return strconv.Itoa(int(code))
+	}
+	return name
+}
+
+// isFailure returns true if the code reports an error.
+func isFailure(version uint8, typ packetType, code uint8) bool {
+	if version != mqtt5 && typ == packetConnack {
+		return code != 0
+	}
+	return code >= failureCode
+}
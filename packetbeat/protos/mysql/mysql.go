@@ -45,6 +45,16 @@ const (
 
 const maxPayloadSize = 100 * 1024
 
+const (
+	// clientSSL is the capability flag of the clients switching to TLS
+	clientSSL = 0x800
+
+	// sslRequestLength is the length of the SSLRequest packet, a handshake
+	// response ending after the capability flags, the max packet size, the
+	// character set and the reserved bytes.
+	sslRequestLength = 32
+)
+
 var (
 	unmatchedRequests  = monitoring.NewInt(nil, "mysql.unmatched_requests")
 	unmatchedResponses = monitoring.NewInt(nil, "mysql.unmatched_responses")
@@ -55,6 +65,7 @@ type mysqlMessage struct {
 	end   int
 
 	ts             time.Time
+	capture        pb.Capture
 	isRequest      bool
 	packetLength   uint32
 	seq            uint8
@@ -99,6 +110,7 @@ type mysqlTransaction struct {
 	bytesIn  uint64
 	notes    []string
 	isError  bool
+	capture  pb.Capture
 
 	mysql common.MapStr
 
@@ -535,6 +547,27 @@ type mysqlPrivateData struct {
 	data [2]*mysqlStream
 }
 
+// upgradeToTLS hands the connection over to the TLS analyzer, with the data
+// not parsed yet.
+func (priv mysqlPrivateData) upgradeToTLS() *protos.ProtocolUpgrade {
+	upgrade := &protos.ProtocolUpgrade{Protocol: protos.Lookup("tls")}
+	for dir, stream := range priv.data {
+		if stream != nil {
+			upgrade.Pending[dir] = stream.data
+		}
+	}
+	return upgrade
+}
+
+// isSSLRequest checks if the client message is an SSLRequest, sent in place
+// of the handshake response by clients switching to TLS.
+func isSSLRequest(m *mysqlMessage, data []byte) bool {
+	if m.seq != 1 || m.packetLength != sslRequestLength {
+		return false
+	}
+	return binary.LittleEndian.Uint32(data[m.start+4:])&clientSSL != 0
+}
+
 // Called when the parser has identified a full message.
 func (mysql *mysqlPlugin) messageComplete(tcptuple *common.TCPTuple, dir uint8, stream *mysqlStream) {
 	// all ok, ship it
@@ -552,6 +585,38 @@ func (mysql *mysqlPlugin) ConnectionTimeout() time.Duration {
 	return mysql.transactionTimeout
 }
 
+// DetectTCP recognizes the initial handshake packet sent by the server.
+func (mysql *mysqlPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectMysql(data)
+}
+
+// maxServerVersionLen limits the length of the server version in the
+// handshake packets recognized by detectMysql.
+const maxServerVersionLen = 64
+
+// detectMysql checks for the header of a handshake packet, protocol version
+// 10, followed by a null terminated server version like "8.0.21".
+func detectMysql(data []byte) protos.Detection {
+	const hdrLen = 5
+	for i, c := range data {
+		switch {
+		case i == 2 && c != 0, // packet length below 64KB
+			i == 3 && c != 0,  // sequence number
+			i == 4 && c != 10: // protocol version
+			return protos.DetectionMismatch
+		case i < hdrLen:
+		case c == 0:
+			if i == hdrLen {
+				return protos.DetectionMismatch
+			}
+			return protos.DetectionMatch
+		case c < 0x20 || c > 0x7e || i-hdrLen >= maxServerVersionLen:
+			return protos.DetectionMismatch
+		}
+	}
+	return protos.DetectionNeedMore
+}
+
 func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 
@@ -573,7 +638,7 @@ func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 		}
 		priv.data[dir] = &mysqlStream{
 			data:     pkt.Payload,
-			message:  &mysqlMessage{ts: pkt.Ts},
+			message:  &mysqlMessage{ts: pkt.Ts, capture: pkt.Capture},
 			isClient: mysql.isServerPort(dstPort),
 		}
 	} else {
@@ -589,7 +654,7 @@ func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	stream := priv.data[dir]
 	for len(stream.data) > 0 {
 		if stream.message == nil {
-			stream.message = &mysqlMessage{ts: pkt.Ts}
+			stream.message = &mysqlMessage{ts: pkt.Ts, capture: pkt.Capture}
 		}
 
 		ok, complete := mysqlMessageParser(priv.data[dir])
@@ -603,7 +668,12 @@ func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 		}
 
 		if complete {
+			sslRequest := stream.isClient && isSSLRequest(stream.message, stream.data)
 			mysql.messageComplete(tcptuple, dir, stream)
+			if sslRequest {
+				// the rest of the connection is a TLS session
+				return priv.upgradeToTLS()
+			}
 		} else {
 			// wait for more data
 			break
@@ -678,6 +748,7 @@ func (mysql *mysqlPlugin) receivedMysqlRequest(msg *mysqlMessage) {
 	}
 
 	trans.ts = msg.ts
+	trans.capture = msg.capture
 	trans.src, trans.dst = common.MakeEndpointPair(msg.tcpTuple.BaseTuple, msg.cmdlineTuple)
 	if msg.direction == tcp.TCPDirectionReverse {
 		trans.src, trans.dst = trans.dst, trans.src
@@ -790,6 +861,7 @@ func (mysql *mysqlPlugin) receivedMysqlResponse(msg *mysqlMessage) {
 	trans.bytesOut = msg.size
 	trans.path = msg.tables
 	trans.endTime = msg.ts
+	trans.capture.Add(&msg.capture)
 
 	// save Raw message
 	if len(msg.raw) > 0 {
@@ -1169,6 +1241,7 @@ func (mysql *mysqlPlugin) publishTransaction(t *mysqlTransaction) {
 	pbf.Event.End = t.endTime
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = "mysql"
+	pbf.AddCapture(&t.capture)
 	pbf.Error.Message = t.notes
 
 	fields := evt.Fields
@@ -22,6 +22,7 @@ package mysql
 import (
 	"encoding/hex"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -583,6 +584,33 @@ func Test_gap_in_eat_message(t *testing.T) {
 	assert.Equal(t, false, complete)
 }
 
+// Test that the connection is handed over to the TLS analyzer after the
+// client sends an SSLRequest.
+func TestParseMySQL_sslRequest(t *testing.T) {
+	mysql := mysqlModForTests(nil)
+	tcptuple := testTCPTuple()
+	greeting := "\x0a\x00\x00\x00\x0a8.0.21\x00\x08\x00"
+	handshakeResponse := func(capabilities string) string {
+		return "\x20\x00\x00\x01" + capabilities + "\x00\x00\x00\x01\x21" + strings.Repeat("\x00", 23)
+	}
+	clientHello := "\x16\x03\x01\x00\x04\x01\x00\x00\x00"
+
+	private := mysql.Parse(&protos.Packet{Payload: []byte(greeting)}, tcptuple, tcp.TCPDirectionReverse, nil)
+	private = mysql.Parse(&protos.Packet{Payload: []byte(handshakeResponse("\x05\x02\x00\x00"))},
+		tcptuple, tcp.TCPDirectionOriginal, private)
+	assert.IsType(t, mysqlPrivateData{}, private)
+
+	private = mysql.Parse(&protos.Packet{Payload: []byte(greeting)}, tcptuple, tcp.TCPDirectionReverse, nil)
+	private = mysql.Parse(&protos.Packet{Payload: []byte(handshakeResponse("\x05\x0a\x00\x00") + clientHello)},
+		tcptuple, tcp.TCPDirectionOriginal, private)
+	if assert.IsType(t, &protos.ProtocolUpgrade{}, private) {
+		upgrade := private.(*protos.ProtocolUpgrade)
+		assert.Equal(t, protos.Lookup("tls"), upgrade.Protocol)
+		assert.Equal(t, clientHello, string(upgrade.Pending[tcp.TCPDirectionOriginal]))
+		assert.Empty(t, upgrade.Pending[tcp.TCPDirectionReverse])
+	}
+}
+
 func Test_read_length(t *testing.T) {
 	logp.TestingSetup(logp.WithSelectors("mysql", "mysqldetailed"))
 
@@ -676,3 +704,17 @@ func Test_PreparedStatement(t *testing.T) {
 	send(tcp.TCPDirectionReverse, "01000001011e0000020364656600000008636f6c5f305f305f000c3f001500000008810000000005000003fe000001200a00000400000b0000000000000005000005fe00000120")
 	assert.Len(t, results.events, 2)
 }
+
+func TestDetectMysql(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"\x4a\x00\x00\x00\x0a8.0.21\x00\x08\x00\x00\x00", protos.DetectionMatch},
+		{"\x4a\x00\x00\x00\x0a8.0", protos.DetectionNeedMore},
+		{"\x4a\x00\x00\x01\x0a8.0.21\x00", protos.DetectionMismatch},
+		{"\x4a\x00\x00\x00\x0a\x00", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectMysql([]byte(test.data)), "%q", test.data)
+	}
+}
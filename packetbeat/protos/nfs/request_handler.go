@@ -53,7 +53,7 @@ func (r *rpc) handleExpiredPacket(nfs *nfs) {
 }
 
 // called when we process a RPC call
-func (r *rpc) handleCall(xid string, xdr *xdr, ts time.Time, tcptuple *common.TCPTuple, dir uint8) {
+func (r *rpc) handleCall(xid string, xdr *xdr, ts time.Time, capture *pb.Capture, tcptuple *common.TCPTuple, dir uint8) {
 	// eat rpc version number
 	xdr.getUInt()
 	rpcProg := xdr.getUInt()
@@ -86,6 +86,7 @@ func (r *rpc) handleCall(xid string, xdr *xdr, ts time.Time, tcptuple *common.TC
 	pbf.Event.Dataset = "nfs"
 	pbf.Event.Start = ts
 	pbf.Network.Transport = "tcp"
+	pbf.AddCapture(capture)
 
 	nfsVers := xdr.getUInt()
 	nfsProc := xdr.getUInt()
@@ -167,7 +168,7 @@ func (r *rpc) handleCall(xid string, xdr *xdr, ts time.Time, tcptuple *common.TC
 }
 
 // called when we process a RPC reply
-func (r *rpc) handleReply(xid string, xdr *xdr, ts time.Time, tcptuple *common.TCPTuple, dir uint8) {
+func (r *rpc) handleReply(xid string, xdr *xdr, ts time.Time, capture *pb.Capture, tcptuple *common.TCPTuple, dir uint8) {
 	replyStatus := xdr.getUInt()
 	// we are interested only in accepted rpc reply
 	if replyStatus != 0 {
@@ -194,6 +195,7 @@ func (r *rpc) handleReply(xid string, xdr *xdr, ts time.Time, tcptuple *common.T
 		nfs := v.(*nfs)
 		nfs.pbf.Event.End = ts
 		nfs.pbf.Destination.Bytes = int64(xdr.size())
+		nfs.pbf.AddCapture(capture)
 
 		fields := nfs.event.Fields
 		rpcInfo := fields["rpc"].(common.MapStr)
@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
 
+	"github.com/elastic/beats/v7/packetbeat/pb"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
@@ -239,22 +240,22 @@ func (r *rpc) handleRPCFragment(
 		// keep the rest of the next fragment
 		st.rawData = st.rawData[4+size:]
 
-		r.handleRPCPacket(xdr, pkt.Ts, tcptuple, dir)
+		r.handleRPCPacket(xdr, pkt.Ts, &pkt.Capture, tcptuple, dir)
 	}
 
 	return conn
 }
 
-func (r *rpc) handleRPCPacket(xdr *xdr, ts time.Time, tcptuple *common.TCPTuple, dir uint8) {
+func (r *rpc) handleRPCPacket(xdr *xdr, ts time.Time, capture *pb.Capture, tcptuple *common.TCPTuple, dir uint8) {
 	xid := fmt.Sprintf("%.8x", xdr.getUInt())
 
 	msgType := xdr.getUInt()
 
 	switch msgType {
 	case rpcCall:
-		r.handleCall(xid, xdr, ts, tcptuple, dir)
+		r.handleCall(xid, xdr, ts, capture, tcptuple, dir)
 	case rpcReply:
-		r.handleReply(xid, xdr, ts, tcptuple, dir)
+		r.handleReply(xid, xdr, ts, capture, tcptuple, dir)
 	default:
 		logp.Warn("Bad RPC message")
 	}
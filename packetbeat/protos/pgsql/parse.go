@@ -59,6 +59,22 @@ func (pgsql *pgsqlPlugin) parseMessageStart(s *pgsqlStream) (bool, bool) {
 
 	m := s.message
 
+	if s.expectSSLResponse && len(s.data[s.parseOffset:]) > 0 {
+		// SSLRequest was received in the other stream
+		if typ := s.data[s.parseOffset]; typ == 'N' || typ == 'S' {
+			// one byte reply to SSLRequest
+			pgsql.detailf("Reply for SSLRequest %c", typ)
+			m.start = s.parseOffset
+			s.parseOffset++
+			m.end = s.parseOffset
+			m.isSSLResponse = true
+			m.isSSLAccepted = typ == 'S'
+			m.size = uint64(m.end - m.start)
+
+			return true, true
+		}
+	}
+
 	for len(s.data[s.parseOffset:]) >= 5 {
 		isSpecial, length, command := pgsql.isSpecialCommand(s.data[s.parseOffset:])
 		if !isSpecial {
@@ -69,7 +85,7 @@ func (pgsql *pgsqlPlugin) parseMessageStart(s *pgsqlStream) (bool, bool) {
 		// their type in the first byte
 
 		// check buffer available
-		if len(s.data[s.parseOffset:]) <= length {
+		if len(s.data[s.parseOffset:]) < length {
 			pgsql.detailf("Wait for more data 1")
 			return true, false
 		}
@@ -96,23 +112,6 @@ func (pgsql *pgsqlPlugin) parseCommand(s *pgsqlStream) (bool, bool) {
 	// read type
 	typ := byte(s.data[s.parseOffset])
 
-	if s.expectSSLResponse {
-		// SSLRequest was received in the other stream
-		if typ == 'N' || typ == 'S' {
-			m := s.message
-
-			// one byte reply to SSLRequest
-			pgsql.detailf("Reply for SSLRequest %c", typ)
-			m.start = s.parseOffset
-			s.parseOffset++
-			m.end = s.parseOffset
-			m.isSSLResponse = true
-			m.size = uint64(m.end - m.start)
-
-			return true, true
-		}
-	}
-
 	// read length
 	length := readLength(s.data[s.parseOffset+1:])
 	if length < 4 {
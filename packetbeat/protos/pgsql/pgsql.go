@@ -61,9 +61,11 @@ type pgsqlMessage struct {
 	end           int
 	isSSLResponse bool
 	isSSLRequest  bool
+	isSSLAccepted bool
 	toExport      bool
 
 	ts             time.Time
+	capture        pb.Capture
 	isRequest      bool
 	query          string
 	size           uint64
@@ -96,6 +98,7 @@ type pgsqlTransaction struct {
 	bytesIn  uint64
 	notes    []string
 	isError  bool
+	capture  pb.Capture
 
 	pgsql common.MapStr
 
@@ -235,10 +238,56 @@ type pgsqlPrivateData struct {
 	data [2]*pgsqlStream
 }
 
+// upgradeToTLS hands the connection over to the TLS analyzer, with the data
+// not parsed yet.
+func (priv pgsqlPrivateData) upgradeToTLS() *protos.ProtocolUpgrade {
+	upgrade := &protos.ProtocolUpgrade{Protocol: protos.Lookup("tls")}
+	for dir, stream := range priv.data {
+		if stream != nil {
+			upgrade.Pending[dir] = stream.data
+		}
+	}
+	return upgrade
+}
+
 func (pgsql *pgsqlPlugin) ConnectionTimeout() time.Duration {
 	return pgsql.transactionTimeout
 }
 
+// DetectTCP recognizes the startup, SSL and cancel request messages a
+// client starts a connection with.
+func (pgsql *pgsqlPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectPgsql(data)
+}
+
+// maxStartupMessageLen limits the length of startup messages recognized by
+// detectPgsql.
+const maxStartupMessageLen = 10000
+
+func detectPgsql(data []byte) protos.Detection {
+	if len(data) < 8 {
+		return protos.DetectionNeedMore
+	}
+
+	length := readLength(data)
+	switch code := common.BytesNtohl(data[4:]); code {
+	case 80877102: // cancel request
+		return matchIf(length == 16)
+	case 80877103: // SSL request
+		return matchIf(length == 8)
+	case 196608: // startup message, protocol 3.0
+		return matchIf(length > 8 && length <= maxStartupMessageLen)
+	}
+	return protos.DetectionMismatch
+}
+
+func matchIf(b bool) protos.Detection {
+	if b {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
 func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 
@@ -256,7 +305,7 @@ func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	if priv.data[dir] == nil {
 		priv.data[dir] = &pgsqlStream{
 			data:    pkt.Payload,
-			message: &pgsqlMessage{ts: pkt.Ts},
+			message: &pgsqlMessage{ts: pkt.Ts, capture: pkt.Capture},
 		}
 		pgsql.detailf("New stream created")
 	} else {
@@ -279,7 +328,7 @@ func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	for len(stream.data) > 0 {
 
 		if stream.message == nil {
-			stream.message = &pgsqlMessage{ts: pkt.Ts}
+			stream.message = &pgsqlMessage{ts: pkt.Ts, capture: pkt.Capture}
 		}
 
 		ok, complete := pgsql.pgsqlMessageParser(priv.data[dir])
@@ -302,6 +351,11 @@ func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 				// SSL request answered
 				stream.expectSSLResponse = false
 				priv.data[1-dir].seenSSLRequest = false
+				if stream.message.isSSLAccepted {
+					// the rest of the connection is a TLS session
+					stream.prepareForNewMessage()
+					return priv.upgradeToTLS()
+				}
 			} else {
 				if stream.message.toExport {
 					pgsql.handlePgsql(pgsql, stream.message, tcptuple, dir, msg)
@@ -410,6 +464,7 @@ func (pgsql *pgsqlPlugin) receivedPgsqlRequest(msg *pgsqlMessage) {
 		trans := &pgsqlTransaction{tuple: tuple}
 
 		trans.ts = msg.ts
+		trans.capture = msg.capture
 		trans.src, trans.dst = common.MakeEndpointPair(msg.tcpTuple.BaseTuple, msg.cmdlineTuple)
 
 		if msg.direction == tcp.TCPDirectionReverse {
@@ -463,6 +518,7 @@ func (pgsql *pgsqlPlugin) receivedPgsqlResponse(msg *pgsqlMessage) {
 	trans.bytesOut = msg.size
 	trans.isError = msg.isError
 	trans.endTime = msg.ts
+	trans.capture.Add(&msg.capture)
 	trans.responseRaw = common.DumpInCSVFormat(msg.fields, msg.rows)
 
 	trans.notes = append(trans.notes, msg.notes...)
@@ -487,6 +543,7 @@ func (pgsql *pgsqlPlugin) publishTransaction(t *pgsqlTransaction) {
 	pbf.Event.Dataset = "pgsql"
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&t.capture)
 	pbf.Error.Message = t.notes
 
 	fields := evt.Fields
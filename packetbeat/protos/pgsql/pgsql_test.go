@@ -388,3 +388,46 @@ func Test_gap_in_response(t *testing.T) {
 		assert.Equal(t, m, "Packet loss while capturing the response")
 	}
 }
+
+// Test that the connection is handed over to the TLS analyzer once the
+// server accepts an SSLRequest.
+func TestPgsqlParser_sslRequest(t *testing.T) {
+	pgsql := pgsqlModForTests(nil)
+	tcptuple := testTCPTuple()
+	sslRequest := "\x00\x00\x00\x08\x04\xd2\x16\x2f"
+	clientHello := "\x16\x03\x01\x00\x04\x01\x00\x00\x00"
+
+	// refused, the client goes on in plain text
+	private := pgsql.Parse(&protos.Packet{Payload: []byte(sslRequest)}, tcptuple, 0, nil)
+	private = pgsql.Parse(&protos.Packet{Payload: []byte("N")}, tcptuple, 1, private)
+	if assert.IsType(t, pgsqlPrivateData{}, private) {
+		priv := private.(pgsqlPrivateData)
+		assert.False(t, priv.data[0].seenSSLRequest)
+		assert.Empty(t, priv.data[1].data)
+	}
+
+	// accepted, the data following the request is passed to the TLS analyzer
+	private = pgsql.Parse(&protos.Packet{Payload: []byte(sslRequest + clientHello)}, tcptuple, 0, nil)
+	private = pgsql.Parse(&protos.Packet{Payload: []byte("S")}, tcptuple, 1, private)
+	if assert.IsType(t, &protos.ProtocolUpgrade{}, private) {
+		upgrade := private.(*protos.ProtocolUpgrade)
+		assert.Equal(t, protos.Lookup("tls"), upgrade.Protocol)
+		assert.Equal(t, clientHello, string(upgrade.Pending[0]))
+		assert.Empty(t, upgrade.Pending[1])
+	}
+}
+
+func TestDetectPgsql(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"\x00\x00\x00\x08\x04\xd2\x16\x2f", protos.DetectionMatch},
+		{"\x00\x00\x00\x29\x00\x03\x00\x00user\x00", protos.DetectionMatch},
+		{"\x00\x00\x00", protos.DetectionNeedMore},
+		{"\x00\x00\x00\x09\x04\xd2\x16\x2f", protos.DetectionMismatch},
+		{"GET / HTTP/1.1\r\n", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectPgsql([]byte(test.data)), "%q", test.data)
+	}
+}
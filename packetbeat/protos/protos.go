@@ -28,6 +28,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 )
 
@@ -41,10 +42,32 @@ const (
 // HttpStream, MysqlStream, etc.
 type ProtocolData interface{}
 
+// ProtocolUpgrade is returned as the protocol data by TCP analyzers handing
+// the rest of a connection over to another protocol, like after a STARTTLS
+// exchange or an HTTP upgrade. The analyzer of the new protocol is passed
+// the pending data first, then the following segments of the connection.
+type ProtocolUpgrade struct {
+	// Protocol of the rest of the connection. The protocol is detected from
+	// the data of the connection if unknown, like in tunnels.
+	Protocol Protocol
+
+	// Data received but not consumed by the analyzer, per direction.
+	Pending [2][]byte
+
+	// Initial protocol data passed to the analyzer of the new protocol, like
+	// the details of the handshake upgrading the connection. Not used if the
+	// protocol is unknown.
+	Data ProtocolData
+}
+
 type Packet struct {
 	Ts      time.Time
 	Tuple   common.IPPortTuple
 	Payload []byte
+
+	// Capture holds the metadata of the packet, like the tunnels it has
+	// been captured in, to be added to the events of its transaction.
+	Capture pb.Capture
 }
 
 var ErrInvalidPort = errors.New("port number out of range")
@@ -144,6 +167,14 @@ func (s ProtocolsStruct) Init(
 		}
 	}
 
+	for _, inst := range s.all {
+		if aware, ok := inst.plugin.(ProtocolsAwarePlugin); ok {
+			if err := aware.SetProtocols(s); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
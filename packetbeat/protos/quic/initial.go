@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/elastic/beats/v7/packetbeat/protos/tls"
+)
+
+// Salts of the Initial secrets (RFC 9001 section 5.2, RFC 9369 section 3.3.1
+// and draft-ietf-quic-tls-29).
+var (
+	saltVersion1 = mustDecodeHex("38762cf7f55934b34d179ae6a4c80cadccbb7f0a")
+	saltVersion2 = mustDecodeHex("0dede3def700a6db819381be6e269dcbf9bd2ed9")
+	saltDraft29  = mustDecodeHex("afbfec289993d24c9e9786f19c6111e04390a899")
+)
+
+var errDecryption = errors.New("failed decrypting Initial packet")
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// initialKeys protect the Initial packets sent by an endpoint. They are
+// derived from the Destination Connection ID chosen by the client, so that
+// anyone observing the Initial packets can decrypt them.
+type initialKeys struct {
+	version uint32
+	dcid    string
+
+	aead cipher.AEAD
+	iv   []byte
+	hp   cipher.Block
+}
+
+func newInitialKeys(version uint32, dcid []byte, client bool) (*initialKeys, error) {
+	salt, labelPrefix := saltVersion1, "quic "
+	switch {
+	case version == version2:
+		salt, labelPrefix = saltVersion2, "quicv2 "
+	case version != version1:
+		salt = saltDraft29
+	}
+
+	initialSecret := hkdf.Extract(sha256.New, dcid, salt)
+	label := "server in"
+	if client {
+		label = "client in"
+	}
+	secret, err := tls.ExpandLabel(sha256.New, initialSecret, label, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := tls.ExpandLabel(sha256.New, secret, labelPrefix+"key", 16)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := tls.ExpandLabel(sha256.New, secret, labelPrefix+"iv", aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	hpKey, err := tls.ExpandLabel(sha256.New, secret, labelPrefix+"hp", 16)
+	if err != nil {
+		return nil, err
+	}
+	hp, err := aes.NewCipher(hpKey)
+	if err != nil {
+		return nil, err
+	}
+	return &initialKeys{
+		version: version,
+		dcid:    string(dcid),
+		aead:    aead,
+		iv:      iv,
+		hp:      hp,
+	}, nil
+}
+
+// open removes the header protection of an Initial packet and decrypts its
+// payload (RFC 9001, section 5).
+func (k *initialKeys) open(p *packet) (pn uint64, payload []byte, err error) {
+	const sampleLen = 16
+	if len(p.raw) < p.pnOffset+4+sampleLen {
+		return 0, nil, errTruncatedPacket
+	}
+	mask := make([]byte, aes.BlockSize)
+	k.hp.Encrypt(mask, p.raw[p.pnOffset+4:p.pnOffset+4+sampleLen])
+
+	// The packet is left untouched, the header is unprotected in a copy.
+	first := p.raw[0] ^ mask[0]&0x0f
+	pnLen := int(first&0x03) + 1
+	header := make([]byte, p.pnOffset+pnLen)
+	copy(header, p.raw)
+	header[0] = first
+	// The first Initial packets have small packet numbers, the truncated
+	// packet number is used as is.
+	for i := 0; i < pnLen; i++ {
+		header[p.pnOffset+i] ^= mask[1+i]
+		pn = pn<<8 | uint64(header[p.pnOffset+i])
+	}
+
+	nonce := make([]byte, len(k.iv))
+	copy(nonce, k.iv)
+	var pnBytes [8]byte
+	binary.BigEndian.PutUint64(pnBytes[:], pn)
+	for i, b := range pnBytes {
+		nonce[len(nonce)-8+i] ^= b
+	}
+
+	payload, err = k.aead.Open(nil, nonce, p.raw[len(header):], header)
+	if err != nil {
+		return pn, nil, errDecryption
+	}
+	return pn, payload, nil
+}
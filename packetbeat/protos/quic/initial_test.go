@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package quic
+
+import (
+	"crypto/aes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/elastic/beats/v7/packetbeat/protos/tls"
+)
+
+// Test vectors of RFC 9001, appendix A.
+var (
+	rfcDCID = mustDecodeHex("8394c8f03e515708")
+
+	// CRYPTO frame holding the ClientHello of the client Initial packet.
+	rfcClientCrypto = mustDecodeHex("060040f1010000ed0303ebf8fa56f12939b9584a3896472ec40bb863cfd3e86804fe3a47f0" +
+		"6a2b69484c00000413011302010000c000000010000e00000b6578616d706c652e636f6dff01000100000a00080006001d00" +
+		"17001800100007000504616c706e000500050100000000003300260024001d00209370b2c9caa47fbabaf4559fedba753de1" +
+		"71fa71f50f1ce15d43e994ec74d748002b0003020304000d0010000e0403050306030203080408050806002d00020101001c" +
+		"00024001003900320408ffffffffffffffff05048000ffff07048000ffff0801100104800075300901100f088394c8f03e51" +
+		"570806048000ffff")
+
+	// Protected header and start of the payload of the client Initial
+	// packet.
+	rfcClientPacketStart = "c000000001088394c8f03e5157080000449e7b9aec34d1b1c98dd7689fb8ec11d242b123dc9b"
+
+	// Server Initial packet, holding an ACK frame and the ServerHello.
+	rfcServerPacket = mustDecodeHex("cf000000010008f067a5502a4262b5004075c0d95a482cd0991cd25b0aac406a5816b639" +
+		"4100f37a1c69797554780bb38cc5a99f5ede4cf73c3ec2493a1839b3dbcba3f6ea46c5b7684df3548e7ddeb9c3bf9c73cc3f" +
+		"3bded74b562bfb19fb84022f8ef4cdd93795d77d06edbb7aaf2f58891850abbdca3d20398c276456cbc42158407dd074ee")
+	rfcServerPayload = mustDecodeHex("02000000000600405a020000560303eefce7f7b37ba1d1632e96677825ddf73988cfc798" +
+		"25df566dc5430b9a045a1200130100002e00330024001d00209d3c940d89690b84d08a60993c144eca684d1081287c834d53" +
+		"11bcf32bb9da1a002b00020304")
+)
+
+func TestInitialSecrets(t *testing.T) {
+	for _, test := range []struct {
+		version        uint32
+		salt           []byte
+		prefix         string
+		client, server [3]string // key, iv and hp
+	}{
+		{
+			version: version1,
+			salt:    saltVersion1,
+			prefix:  "quic ",
+			client:  [3]string{"1f369613dd76d5467730efcbe3b1a22d", "fa044b2f42a3fd3b46fb255c", "9f50449e04a0e810283a1e9933adedd2"},
+			server:  [3]string{"cf3a5331653c364c88f0f379b6067e37", "0ac1493ca1905853b0bba03e", "c206b8d9b9f0f37644430b490eeaa314"},
+		},
+		{
+			// RFC 9369, appendix A.1
+			version: version2,
+			salt:    saltVersion2,
+			prefix:  "quicv2 ",
+			client:  [3]string{"8b1a0bc121284290a29e0971b5cd045d", "91f73e2351d8fa91660e909f", "45b95e15235d6f45a6b19cbcb0294ba9"},
+			server:  [3]string{"82db637861d55e1d011f19ea71d5d2a7", "dd13c276499c0249d3310652", "edf6d05c83121201b436e16877593c3a"},
+		},
+	} {
+		initialSecret := hkdf.Extract(sha256.New, rfcDCID, test.salt)
+		for label, expected := range map[string][3]string{"client in": test.client, "server in": test.server} {
+			secret, err := tls.ExpandLabel(sha256.New, initialSecret, label, sha256.Size)
+			require.NoError(t, err)
+			for i, name := range []string{"key", "iv", "hp"} {
+				key, err := tls.ExpandLabel(sha256.New, secret, test.prefix+name, len(expected[i])/2)
+				require.NoError(t, err)
+				assert.Equal(t, expected[i], hex.EncodeToString(key), label)
+			}
+		}
+
+		keys, err := newInitialKeys(test.version, rfcDCID, true)
+		require.NoError(t, err)
+		assert.Equal(t, test.client[1], hex.EncodeToString(keys.iv))
+	}
+}
+
+func TestOpenClientInitial(t *testing.T) {
+	payload := make([]byte, 1162)
+	copy(payload, rfcClientCrypto)
+	data := sealInitial(t, version1, rfcDCID, nil, 2, payload, true)
+	assert.Len(t, data, 1200)
+	assert.Equal(t, rfcClientPacketStart, hex.EncodeToString(data[:len(rfcClientPacketStart)/2]))
+
+	p, rest, err := parseLongHeader(data)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, packetInitial, p.typ)
+	assert.Equal(t, rfcDCID, p.dcid)
+
+	keys, err := newInitialKeys(version1, p.dcid, true)
+	require.NoError(t, err)
+	pn, decrypted, err := keys.open(p)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), pn)
+	assert.Equal(t, payload, decrypted)
+
+	// the server keys can not decrypt the packets of the client
+	keys, err = newInitialKeys(version1, p.dcid, false)
+	require.NoError(t, err)
+	_, _, err = keys.open(p)
+	assert.Equal(t, errDecryption, err)
+}
+
+func TestOpenServerInitial(t *testing.T) {
+	p, rest, err := parseLongHeader(rfcServerPacket)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, packetInitial, p.typ)
+	assert.Empty(t, p.dcid)
+	assert.Equal(t, "f067a5502a4262b5", hex.EncodeToString(p.scid))
+
+	keys, err := newInitialKeys(version1, rfcDCID, false)
+	require.NoError(t, err)
+	pn, payload, err := keys.open(p)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), pn)
+	assert.Equal(t, rfcServerPayload, payload)
+
+	// the packet is not modified
+	assert.Equal(t, byte(0xcf), rfcServerPacket[0])
+}
+
+// sealInitial returns an Initial packet protected with the Initial keys
+// derived from dcid, using a 4 bytes packet number.
+func sealInitial(t *testing.T, version uint32, dcid, scid []byte, pn uint32, payload []byte, client bool) []byte {
+	keys, err := newInitialKeys(version, dcid, client)
+	require.NoError(t, err)
+
+	typeBits := byte(0)
+	if version == version2 {
+		typeBits = 1
+	}
+	hdrDCID := dcid
+	if !client {
+		hdrDCID = nil
+	}
+	header := []byte{0xc3 | typeBits<<4, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header[1:], version)
+	header = append(header, byte(len(hdrDCID)))
+	header = append(header, hdrDCID...)
+	header = append(header, byte(len(scid)))
+	header = append(header, scid...)
+	header = append(header, 0) // token length
+	length := 4 + len(payload) + keys.aead.Overhead()
+	header = append(header, 0x40|byte(length>>8), byte(length))
+	pnOffset := len(header)
+	header = append(header, byte(pn>>24), byte(pn>>16), byte(pn>>8), byte(pn))
+
+	nonce := make([]byte, len(keys.iv))
+	copy(nonce, keys.iv)
+	for i := 0; i < 4; i++ {
+		nonce[len(nonce)-1-i] ^= byte(pn >> (8 * i))
+	}
+	data := keys.aead.Seal(header, nonce, payload, header)
+
+	mask := make([]byte, aes.BlockSize)
+	keys.hp.Encrypt(mask, data[pnOffset+4:pnOffset+4+16])
+	data[0] ^= mask[0] & 0x0f
+	for i := 0; i < 4; i++ {
+		data[pnOffset+i] ^= mask[1+i]
+	}
+	return data
+}
@@ -0,0 +1,352 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// QUIC versions whose Initial packets can be decrypted.
+const (
+	versionNegotiation = 0x00000000
+	version1           = 0x00000001
+	version2           = 0x6b3343cf
+	versionDraft29     = 0xff00001d
+	versionDraft32     = 0xff000020
+)
+
+// Types of the long header packets. The type bits of a packet depend on
+// its version and are normalized when parsing the header.
+const (
+	packetInitial = iota
+	packetZeroRTT
+	packetHandshake
+	packetRetry
+	packetVersionNegotiation
+)
+
+// Frames allowed in Initial packets (RFC 9000, section 12.4).
+const (
+	framePadding         = 0x00
+	framePing            = 0x01
+	frameAck             = 0x02
+	frameAckECN          = 0x03
+	frameCrypto          = 0x06
+	frameConnectionClose = 0x1c
+)
+
+const (
+	// Clients pad the datagrams holding Initial packets to at least 1200
+	// bytes (RFC 9000, section 14.1).
+	minInitialDatagramSize = 1200
+
+	maxConnectionIDLength = 20
+	retryIntegrityTagLen  = 16
+)
+
+var (
+	errShortHeader        = errors.New("short header packet")
+	errTruncatedPacket    = errors.New("truncated packet")
+	errInvalidFrame       = errors.New("invalid frame in Initial packet")
+	errUnsupportedVersion = errors.New("unsupported version")
+
+	errCryptoStreamOverflow = errors.New("too many out-of-order CRYPTO frames")
+)
+
+// packet is a QUIC packet with a long header.
+type packet struct {
+	typ     int
+	version uint32
+	dcid    []byte
+	scid    []byte
+
+	// token of Initial and Retry packets
+	token []byte
+
+	// versions listed by a Version Negotiation packet
+	versions []uint32
+
+	// the whole packet, and the offset of the protected packet number of
+	// Initial, 0-RTT and Handshake packets
+	raw      []byte
+	pnOffset int
+}
+
+// parseDatagram returns the long header packets coalesced in a UDP
+// datagram. A datagram can end with a short header packet, which is
+// ignored.
+func parseDatagram(data []byte) ([]*packet, error) {
+	var packets []*packet
+	for len(data) > 0 {
+		p, rest, err := parseLongHeader(data)
+		if err != nil {
+			if err == errShortHeader && len(packets) > 0 {
+				break
+			}
+			return packets, err
+		}
+		packets = append(packets, p)
+		data = rest
+	}
+	return packets, nil
+}
+
+// parseLongHeader parses the packet at the start of data and returns the
+// data following it.
+func parseLongHeader(data []byte) (p *packet, rest []byte, err error) {
+	if len(data) < 7 {
+		return nil, nil, errTruncatedPacket
+	}
+	if data[0]&0x80 == 0 {
+		return nil, nil, errShortHeader
+	}
+
+	p = &packet{version: binary.BigEndian.Uint32(data[1:5])}
+	d := decoder{data: data, pos: 5}
+	p.dcid = d.connectionID()
+	p.scid = d.connectionID()
+	if d.failed {
+		return nil, nil, errTruncatedPacket
+	}
+
+	if p.version == versionNegotiation {
+		p.typ = packetVersionNegotiation
+		for len(d.data)-d.pos >= 4 {
+			p.versions = append(p.versions, binary.BigEndian.Uint32(d.bytes(4)))
+		}
+		return p, nil, nil
+	}
+	if !isSupportedVersion(p.version) {
+		return nil, nil, errUnsupportedVersion
+	}
+	if data[0]&0x40 == 0 {
+		return nil, nil, fmt.Errorf("fixed bit not set")
+	}
+
+	p.typ = packetType(p.version, data[0]>>4&0x03)
+	switch p.typ {
+	case packetRetry:
+		if len(data)-d.pos < retryIntegrityTagLen {
+			return nil, nil, errTruncatedPacket
+		}
+		p.token = data[d.pos : len(data)-retryIntegrityTagLen]
+		p.raw = data
+		return p, nil, nil
+	case packetInitial:
+		p.token = d.bytes(int(d.varint()))
+	}
+
+	length := d.varint()
+	if d.failed || length > uint64(len(data)-d.pos) {
+		return nil, nil, errTruncatedPacket
+	}
+	p.pnOffset = d.pos
+	end := d.pos + int(length)
+	p.raw = data[:end]
+	return p, data[end:], nil
+}
+
+func isSupportedVersion(version uint32) bool {
+	return version == version1 || version == version2 ||
+		(versionDraft29 <= version && version <= versionDraft32)
+}
+
+// packetType returns the type of a long header packet from the type bits of
+// its first byte, which QUIC version 2 changed (RFC 9369, section 3.2).
+func packetType(version uint32, bits byte) int {
+	if version == version2 {
+		return [4]int{packetRetry, packetInitial, packetZeroRTT, packetHandshake}[bits]
+	}
+	return [4]int{packetInitial, packetZeroRTT, packetHandshake, packetRetry}[bits]
+}
+
+// versionName returns the name of a QUIC version.
+func versionName(version uint32) string {
+	switch {
+	case version == version1:
+		return "1"
+	case version == version2:
+		return "2"
+	case version&0xffffff00 == 0xff000000:
+		return fmt.Sprintf("draft-%d", version&0xff)
+	}
+	return fmt.Sprintf("0x%08x", version)
+}
+
+// cryptoFrame is the data of a CRYPTO frame, at its offset in the stream of
+// handshake messages.
+type cryptoFrame struct {
+	offset uint64
+	data   []byte
+}
+
+// connectionClose is the error of a CONNECTION_CLOSE frame.
+type connectionClose struct {
+	code   uint64
+	reason string
+}
+
+// parseFrames parses the frames of the payload of an Initial packet.
+func parseFrames(payload []byte) (frames []cryptoFrame, closed *connectionClose, err error) {
+	d := decoder{data: payload}
+	for !d.failed && d.pos < len(payload) {
+		switch typ := d.varint(); typ {
+		case framePadding, framePing:
+		case frameAck, frameAckECN:
+			d.varint() // largest acknowledged
+			d.varint() // ACK delay
+			ranges := d.varint()
+			d.varint() // first ACK range
+			for i := uint64(0); i < ranges && !d.failed; i++ {
+				d.varint() // gap
+				d.varint() // ACK range length
+			}
+			if typ == frameAckECN {
+				d.varint()
+				d.varint()
+				d.varint()
+			}
+		case frameCrypto:
+			offset := d.varint()
+			data := d.bytes(int(d.varint()))
+			if !d.failed {
+				frames = append(frames, cryptoFrame{offset, data})
+			}
+		case frameConnectionClose:
+			code := d.varint()
+			d.varint() // frame type
+			reason := d.bytes(int(d.varint()))
+			if !d.failed {
+				closed = &connectionClose{code, string(reason)}
+			}
+		default:
+			return frames, closed, errInvalidFrame
+		}
+	}
+	if d.failed {
+		return frames, closed, errTruncatedPacket
+	}
+	return frames, closed, nil
+}
+
+// decoder reads the fields of packets and frames. Reading past the end of
+// the data sets failed.
+type decoder struct {
+	data   []byte
+	pos    int
+	failed bool
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.failed || n < 0 || n > len(d.data)-d.pos {
+		d.failed = true
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+// varint reads a variable-length integer (RFC 9000, section 16).
+func (d *decoder) varint() uint64 {
+	first := d.bytes(1)
+	if first == nil {
+		return 0
+	}
+	rest := d.bytes(1<<(first[0]>>6) - 1)
+	v := uint64(first[0] & 0x3f)
+	for _, b := range rest {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+func (d *decoder) connectionID() []byte {
+	length := d.bytes(1)
+	if length == nil || length[0] > maxConnectionIDLength {
+		d.failed = true
+		return nil
+	}
+	return d.bytes(int(length[0]))
+}
+
+// cryptoStream reassembles the handshake messages sent by an endpoint in
+// the CRYPTO frames of its Initial packets.
+type cryptoStream struct {
+	data    []byte
+	pending []cryptoFrame
+
+	// bytes of the pending frames
+	pendingBytes int
+}
+
+const (
+	// maxCryptoStreamSize bounds the data buffered for the hello messages.
+	maxCryptoStreamSize = 1 << 16
+
+	// maxPendingCryptoFrames bounds the frames received ahead of the
+	// contiguous data. The pending bytes are bounded by maxCryptoStreamSize.
+	maxPendingCryptoFrames = 64
+)
+
+// add adds the data of a frame to the stream, and returns whether the
+// contiguous data available from the start of the stream grew. Frames
+// beyond maxCryptoStreamSize are ignored. If too many frames are pending,
+// the stream is reset and errCryptoStreamOverflow is returned.
+func (s *cryptoStream) add(frame cryptoFrame) (bool, error) {
+	end := frame.offset + uint64(len(frame.data))
+	if end > maxCryptoStreamSize || end <= uint64(len(s.data)) {
+		return false, nil
+	}
+	if frame.offset > uint64(len(s.data)) {
+		if len(s.pending) >= maxPendingCryptoFrames || s.pendingBytes+len(frame.data) > maxCryptoStreamSize {
+			s.reset()
+			return false, errCryptoStreamOverflow
+		}
+		s.pending = append(s.pending, frame)
+		s.pendingBytes += len(frame.data)
+		return false, nil
+	}
+
+	s.data = append(s.data, frame.data[uint64(len(s.data))-frame.offset:]...)
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(s.pending); i++ {
+			f := s.pending[i]
+			end := f.offset + uint64(len(f.data))
+			if f.offset > uint64(len(s.data)) {
+				continue
+			}
+			if end > uint64(len(s.data)) {
+				s.data = append(s.data, f.data[uint64(len(s.data))-f.offset:]...)
+				merged = true
+			}
+			s.pending = append(s.pending[:i], s.pending[i+1:]...)
+			s.pendingBytes -= len(f.data)
+			i--
+		}
+	}
+	return true, nil
+}
+
+// reset drops the data of the stream.
+func (s *cryptoStream) reset() {
+	*s = cryptoStream{}
+}
@@ -0,0 +1,432 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tls"
+	"github.com/elastic/ecs/code/go/ecs"
+)
+
+var (
+	metricTotalPackets       = monitoring.NewUint(nil, "quic.total_packets")
+	metricParseFailures      = monitoring.NewUint(nil, "quic.parse_failures")
+	metricDecryptionFailures = monitoring.NewUint(nil, "quic.decryption_failures")
+	metricUnmatchedRequests  = monitoring.NewUint(nil, "quic.unmatched_requests")
+)
+
+func init() {
+	protos.Register("quic", New)
+}
+
+// New constructs a new quic protocol plugin.
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	return newPlugin(testMode, results, watcher, cfg)
+}
+
+func newPlugin(testMode bool, results protos.Reporter, watcher procs.ProcessesWatcher, cfg *common.Config) (*quicPlugin, error) {
+	config := defaultConfig
+
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &quicPlugin{
+		quicConfig: config,
+		report:     results,
+		watcher:    watcher,
+		log:        logp.NewLogger("quic"),
+	}
+	p.connections = common.NewCacheWithRemovalListener(
+		config.TransactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			conn, ok := v.(*connection)
+			if !ok {
+				p.log.Error("Expired value is not a *quic.connection.")
+				return
+			}
+			if !conn.published {
+				metricUnmatchedRequests.Inc()
+				p.publish(conn)
+			}
+		})
+	p.connections.StartJanitor(config.TransactionTimeout)
+	return p, nil
+}
+
+type quicPlugin struct {
+	quicConfig
+	report  protos.Reporter
+	watcher procs.ProcessesWatcher
+	log     *logp.Logger
+
+	// connections whose handshake is in progress, or was recently reported
+	connections *common.Cache
+}
+
+// connectionKey identifies a connection by the endpoints of the client
+// and of the server.
+type connectionKey struct {
+	client, server string
+}
+
+// connection holds the information gathered from the Initial packets of a
+// connection, until the ServerHello is received.
+type connection struct {
+	key       connectionKey
+	tuple     common.IPPortTuple
+	start     time.Time
+	end       time.Time
+	capture   pb.Capture
+	published bool
+
+	version uint32
+
+	// Destination Connection ID of the first Initial packet of the
+	// client, and of the last one, which the Initial keys are derived from
+	dcid        []byte
+	currentDCID []byte
+
+	// Source Connection IDs of the client and of the server
+	clientSCID []byte
+	serverSCID []byte
+
+	retry             bool
+	supportedVersions []uint32
+	closed            *connectionClose
+
+	// bytes of the datagrams sent by the client and by the server
+	clientBytes, serverBytes int
+
+	client, server endpoint
+}
+
+// endpoint is the state of the Initial packets sent by the client or by
+// the server.
+type endpoint struct {
+	keys   *initialKeys
+	crypto cryptoStream
+	hello  *tls.Hello
+	failed bool
+}
+
+func (p *quicPlugin) GetPorts() []int {
+	return p.quicConfig.Ports
+}
+
+// DetectUDP recognizes the datagrams holding the Initial packet of a
+// client, which are padded to at least 1200 bytes.
+func (p *quicPlugin) DetectUDP(data []byte) protos.Detection {
+	return detectQUIC(data)
+}
+
+func detectQUIC(data []byte) protos.Detection {
+	if len(data) < minInitialDatagramSize {
+		return protos.DetectionMismatch
+	}
+	pkt, _, err := parseLongHeader(data)
+	if err != nil || pkt.typ != packetInitial || len(pkt.dcid) < 8 {
+		return protos.DetectionMismatch
+	}
+	return protos.DetectionMatch
+}
+
+func (p *quicPlugin) ParseUDP(pkt *protos.Packet) {
+	metricTotalPackets.Inc()
+
+	packets, err := parseDatagram(pkt.Payload)
+	if len(packets) == 0 {
+		if err != errShortHeader {
+			metricParseFailures.Inc()
+			p.log.Debugw("Dropping packet: failed parsing QUIC header", "error", err)
+		}
+		return
+	}
+
+	conn, fromClient := p.lookup(pkt.Tuple)
+	if conn == nil {
+		// Connections are tracked from the first Initial packet of the
+		// client.
+		if packets[0].typ != packetInitial {
+			return
+		}
+		conn = &connection{
+			key: connectionKey{
+				client: endpointKey(pkt.Tuple.SrcIP, pkt.Tuple.SrcPort),
+				server: endpointKey(pkt.Tuple.DstIP, pkt.Tuple.DstPort),
+			},
+			tuple:   pkt.Tuple,
+			start:   pkt.Ts,
+			capture: pkt.Capture,
+			dcid:    packets[0].dcid,
+		}
+		fromClient = true
+	}
+	if conn.published {
+		return
+	}
+
+	for _, packet := range packets {
+		p.handlePacket(conn, packet, fromClient)
+	}
+	if conn.version == 0 {
+		// not the Initial packet of a client
+		return
+	}
+
+	conn.end = pkt.Ts
+	if fromClient {
+		conn.clientBytes += len(pkt.Payload)
+	} else {
+		conn.serverBytes += len(pkt.Payload)
+	}
+	p.connections.Put(conn.key, conn)
+	if conn.server.hello != nil {
+		conn.published = true
+		p.publish(conn)
+	}
+}
+
+func (p *quicPlugin) lookup(tuple common.IPPortTuple) (conn *connection, fromClient bool) {
+	src := endpointKey(tuple.SrcIP, tuple.SrcPort)
+	dst := endpointKey(tuple.DstIP, tuple.DstPort)
+	if v := p.connections.Get(connectionKey{client: src, server: dst}); v != nil {
+		return v.(*connection), true
+	}
+	if v := p.connections.Get(connectionKey{client: dst, server: src}); v != nil {
+		return v.(*connection), false
+	}
+	return nil, false
+}
+
+func endpointKey(ip []byte, port uint16) string {
+	return fmt.Sprintf("%x:%d", ip, port)
+}
+
+// handlePacket updates a connection with a packet.
+func (p *quicPlugin) handlePacket(conn *connection, packet *packet, fromClient bool) {
+	switch packet.typ {
+	case packetVersionNegotiation:
+		if !fromClient {
+			conn.supportedVersions = packet.versions
+		}
+		return
+	case packetRetry:
+		if !fromClient {
+			conn.retry = true
+		}
+		return
+	case packetInitial:
+	default:
+		// Handshake and 0-RTT packets are protected with keys unknown to
+		// passive observers.
+		return
+	}
+
+	// The Initial keys of both endpoints are derived from the Destination
+	// Connection ID of the last Initial packet of the client, which changes
+	// after a Retry.
+	state, dcid := &conn.server, conn.currentDCID
+	if fromClient {
+		state, dcid = &conn.client, packet.dcid
+	}
+	if dcid == nil {
+		return
+	}
+	keys := state.keys
+	if keys == nil || keys.version != packet.version || keys.dcid != string(dcid) {
+		var err error
+		if keys, err = newInitialKeys(packet.version, dcid, fromClient); err != nil {
+			p.log.Debugw("Failed deriving QUIC Initial keys", "error", err)
+			return
+		}
+	}
+	_, payload, err := keys.open(packet)
+	if err != nil {
+		metricDecryptionFailures.Inc()
+		p.log.Debugw("Failed decrypting QUIC Initial packet", "error", err)
+		return
+	}
+	state.keys = keys
+
+	if fromClient {
+		conn.version = packet.version
+		conn.currentDCID = packet.dcid
+		conn.clientSCID = packet.scid
+	} else {
+		conn.serverSCID = packet.scid
+	}
+
+	frames, closed, err := parseFrames(payload)
+	if err != nil {
+		metricParseFailures.Inc()
+		p.log.Debugw("Failed parsing QUIC frames", "error", err)
+	}
+	if closed != nil {
+		conn.closed = closed
+	}
+	for _, frame := range frames {
+		if state.hello != nil || state.failed {
+			// the hello message has been parsed or can't be
+			break
+		}
+		grew, err := state.crypto.add(frame)
+		if err != nil {
+			state.failed = true
+			metricParseFailures.Inc()
+			p.log.Debugw("Dropped QUIC Initial reassembly", "error", err)
+			break
+		}
+		if grew {
+			p.parseHello(state)
+		}
+	}
+}
+
+func (p *quicPlugin) parseHello(state *endpoint) {
+	hello, err := tls.ParseHello(state.crypto.data)
+	switch {
+	case err == tls.ErrIncompleteHandshake:
+	case err != nil:
+		state.failed = true
+		metricParseFailures.Inc()
+		p.log.Debugw("Failed parsing TLS hello message", "error", err)
+	default:
+		state.hello = hello
+	}
+}
+
+func (p *quicPlugin) publish(conn *connection) {
+	if p.report == nil {
+		return
+	}
+	p.report(p.newEvent(conn))
+}
+
+// newEvent reports the handshake of a connection, from the hello messages
+// of the client and of the server. The ServerHello can be missing.
+func (p *quicPlugin) newEvent(conn *connection) beat.Event {
+	evt, pbf := pb.NewBeatEvent(conn.start)
+
+	src, dst := common.MakeEndpointPair(conn.tuple.BaseTuple, nil)
+	pbf.SetSource(&src)
+	pbf.SetDestination(&dst)
+	pbf.Source.Bytes = int64(conn.clientBytes)
+	pbf.Destination.Bytes = int64(conn.serverBytes)
+	pbf.Event.Start = conn.start
+	pbf.Event.End = conn.end
+	pbf.Event.Dataset = "quic"
+	pbf.Network.Transport = "udp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&conn.capture)
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+
+	quicData := common.MapStr{
+		"version": versionName(conn.version),
+	}
+	fields["quic"] = quicData
+	if len(conn.dcid) > 0 {
+		quicData["initial_connection_id"] = hex.EncodeToString(conn.dcid)
+	}
+	if len(conn.clientSCID) > 0 {
+		quicData.Put("client.connection_id", hex.EncodeToString(conn.clientSCID))
+	}
+	if len(conn.serverSCID) > 0 {
+		quicData.Put("server.connection_id", hex.EncodeToString(conn.serverSCID))
+	}
+	if conn.retry {
+		quicData["retry"] = true
+	}
+	if len(conn.supportedVersions) > 0 {
+		versions := make([]string, len(conn.supportedVersions))
+		for i, v := range conn.supportedVersions {
+			versions[i] = versionName(v)
+		}
+		quicData.Put("server.supported_versions", versions)
+	}
+
+	status := common.OK_STATUS
+	var notes []string
+	if conn.closed != nil {
+		status = common.ERROR_STATUS
+		quicData.Put("close.error_code", conn.closed.code)
+		if conn.closed.reason != "" {
+			quicData.Put("close.reason", conn.closed.reason)
+		}
+		pbf.Event.Outcome = "failure"
+	}
+
+	// The TLS handshake messages are reported under the ECS TLS fields,
+	// as done by the TLS analyzer.
+	tlsData := ecs.Tls{}
+	var supportedCiphers []string
+	if hello := conn.client.hello; hello != nil {
+		tlsData.ClientServerName = hello.ServerName()
+		pbf.Destination.Domain = tlsData.ClientServerName
+		tlsData.ClientJa3 = hello.Ja3()
+		supportedCiphers = hello.SupportedCiphers()
+		if alpn := hello.ALPN(); len(alpn) > 0 {
+			quicData.Put("client.alpn", alpn)
+		}
+		fields.Put("tls.client.ja4", hello.Ja4(tls.Ja4QUIC))
+	} else {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Missing ClientHello")
+	}
+	if hello := conn.server.hello; hello != nil {
+		tlsData.Cipher = hello.Cipher()
+		tlsData.ServerJa3s = hello.Ja3()
+		if alpn := hello.ALPN(); len(alpn) > 0 {
+			tlsData.NextProtocol = alpn[0]
+		}
+		version := hello.Version()
+		tlsData.VersionProtocol, tlsData.Version = version.Protocol, version.Version
+		fields.Put("tls.server.ja4s", hello.Ja4(tls.Ja4QUIC))
+	} else {
+		status = common.ERROR_STATUS
+		notes = append(notes, "Missing ServerHello")
+	}
+	pb.MarshalStruct(fields, "tls", tlsData)
+	if len(supportedCiphers) > 0 {
+		fields.Put("tls.client.supported_ciphers", supportedCiphers)
+	}
+
+	fields["status"] = status
+	pbf.Error.Message = notes
+	return evt
+}
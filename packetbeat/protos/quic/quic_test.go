@@ -0,0 +1,291 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package quic
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+var _ protos.UDPPlugin = &quicPlugin{}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func newTestPlugin(t *testing.T, store *eventStore) *quicPlugin {
+	p, err := newPlugin(true, store.publish, procs.ProcessesWatcher{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+var (
+	clientIP = net.ParseIP("192.168.0.1")
+	serverIP = net.ParseIP("192.168.0.2")
+)
+
+func fromClient(payload []byte) *protos.Packet {
+	return &protos.Packet{
+		Ts:      time.Now(),
+		Tuple:   common.NewIPPortTuple(4, clientIP, 51000, serverIP, 443),
+		Payload: payload,
+	}
+}
+
+func fromServer(payload []byte) *protos.Packet {
+	return &protos.Packet{
+		Ts:      time.Now(),
+		Tuple:   common.NewIPPortTuple(4, serverIP, 443, clientIP, 51000),
+		Payload: payload,
+	}
+}
+
+// clientInitial returns the client Initial packet of RFC 9001, with the
+// given Source Connection ID and frames padded to 1200 bytes.
+func clientInitial(t *testing.T, version uint32, scid []byte, pn uint32, frames ...[]byte) []byte {
+	var payload []byte
+	for _, frame := range frames {
+		payload = append(payload, frame...)
+	}
+	// header, packet number and authentication tag
+	overhead := 1 + 4 + 1 + len(rfcDCID) + 1 + len(scid) + 1 + 2 + 4 + 16
+	if pad := minInitialDatagramSize - overhead - len(payload); pad > 0 {
+		payload = append(payload, make([]byte, pad)...)
+	}
+	return sealInitial(t, version, rfcDCID, scid, pn, payload, true)
+}
+
+func TestDetectQUIC(t *testing.T) {
+	data := clientInitial(t, version1, nil, 2, rfcClientCrypto)
+	assert.Equal(t, protos.DetectionMatch, detectQUIC(data))
+
+	// not padded
+	assert.Equal(t, protos.DetectionMismatch, detectQUIC(data[:1000]))
+	// server Initial
+	assert.Equal(t, protos.DetectionMismatch, detectQUIC(rfcServerPacket))
+	// short header
+	short := append([]byte{0x40}, data[1:]...)
+	assert.Equal(t, protos.DetectionMismatch, detectQUIC(short))
+}
+
+func TestHandshake(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	p.ParseUDP(fromClient(clientInitial(t, version1, []byte{1, 2, 3, 4}, 2, rfcClientCrypto)))
+	assert.Empty(t, store.events)
+	p.ParseUDP(fromServer(rfcServerPacket))
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+
+	// the Initial packets sent afterwards are ignored
+	p.ParseUDP(fromServer(rfcServerPacket))
+	assert.Len(t, store.events, 1)
+
+	fields := store.events[0].Fields
+	for key, expected := range map[string]interface{}{
+		"type":                         "quic",
+		"status":                       "OK",
+		"network.transport":            "udp",
+		"network.protocol":             "quic",
+		"source.bytes":                 int64(1200),
+		"destination.bytes":            int64(len(rfcServerPacket)),
+		"destination.domain":           "example.com",
+		"quic.version":                 "1",
+		"quic.initial_connection_id":   "8394c8f03e515708",
+		"quic.client.connection_id":    "01020304",
+		"quic.server.connection_id":    "f067a5502a4262b5",
+		"quic.client.alpn":             []string{"alpn"},
+		"tls.client.server_name":       "example.com",
+		"tls.client.ja3":               "41bc9ae914d6cb3bd0bd0a5453ab7d7f",
+		"tls.client.ja4":               "q13d0211an_62ed6f6ca7ad_4d634acda6c0",
+		"tls.client.supported_ciphers": []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384"},
+		"tls.server.ja3s":              "eb1d94daa7e0344597e756a1fb6e7054",
+		"tls.server.ja4s":              "q130200_1301_234ea6891581",
+		"tls.cipher":                   "TLS_AES_128_GCM_SHA256",
+		"tls.version":                  "1.3",
+		"tls.version_protocol":         "tls",
+	} {
+		actual, err := fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, actual, key)
+		}
+	}
+	_, err := fields.GetValue("error.message")
+	assert.Error(t, err)
+}
+
+func TestFragmentedClientHello(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	// The ClientHello is split in two CRYPTO frames, sent in two packets
+	// received out of order.
+	hello := rfcClientCrypto[4:]
+	first := append([]byte{frameCrypto, 0x00, 0x40, 100}, hello[:100]...)
+	second := append([]byte{frameCrypto, 0x40, 100, 0x40, byte(len(hello) - 100)}, hello[100:]...)
+
+	p.ParseUDP(fromClient(clientInitial(t, version2, nil, 1, second)))
+	conn, isClient := p.lookup(fromClient(nil).Tuple)
+	if assert.NotNil(t, conn) {
+		assert.True(t, isClient)
+		assert.Nil(t, conn.client.hello)
+	}
+	p.ParseUDP(fromClient(clientInitial(t, version2, nil, 0, []byte{framePing}, first)))
+	if assert.NotNil(t, conn) && assert.NotNil(t, conn.client.hello) {
+		assert.Equal(t, "example.com", conn.client.hello.ServerName())
+	}
+
+	// without ServerHello, as published when the connection expires
+	p.publish(conn)
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+	evt := store.events[0]
+	for key, expected := range map[string]interface{}{
+		"status":                 "Error",
+		"quic.version":           "2",
+		"tls.client.server_name": "example.com",
+		"error.message":          "Missing ServerHello",
+	} {
+		actual, err := evt.Fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, actual, key)
+		}
+	}
+}
+
+func TestConnectionClose(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	p.ParseUDP(fromClient(clientInitial(t, version1, nil, 0, rfcClientCrypto)))
+
+	// CONNECTION_CLOSE with a handshake_failure alert
+	closeFrame := []byte{frameConnectionClose, 0x41, 0x28, frameCrypto, 4, 'n', 'o', 'p', 'e'}
+	p.ParseUDP(fromServer(sealInitial(t, version1, rfcDCID, []byte{5, 6}, 0, append(closeFrame, make([]byte, 20)...), false)))
+	assert.Empty(t, store.events)
+
+	conn, isClient := p.lookup(fromServer(nil).Tuple)
+	if !assert.NotNil(t, conn) {
+		return
+	}
+	assert.False(t, isClient)
+	p.publish(conn)
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+	evt := store.events[0]
+	for key, expected := range map[string]interface{}{
+		"status":                    "Error",
+		"event.outcome":             "failure",
+		"quic.server.connection_id": "0506",
+		"quic.close.error_code":     uint64(0x128),
+		"quic.close.reason":         "nope",
+	} {
+		actual, err := evt.Fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, actual, key)
+		}
+	}
+}
+
+func TestUntrackedServerPackets(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	// the server packets of connections whose first Initial packet was not
+	// seen can not be decrypted
+	p.ParseUDP(fromServer(rfcServerPacket))
+	conn, _ := p.lookup(fromServer(nil).Tuple)
+	assert.Nil(t, conn)
+	assert.Empty(t, store.events)
+}
+
+func TestCryptoStream(t *testing.T) {
+	var s cryptoStream
+	add := func(offset uint64, data string) bool {
+		grew, err := s.add(cryptoFrame{offset, []byte(data)})
+		assert.NoError(t, err)
+		return grew
+	}
+
+	assert.False(t, add(4, "efgh"))
+	assert.True(t, add(0, "abc"))
+	assert.Equal(t, "abc", string(s.data))
+	assert.True(t, add(2, "cd"))
+	assert.Equal(t, "abcdefgh", string(s.data))
+	assert.False(t, add(0, "abcd"))
+	assert.Empty(t, s.pending)
+	assert.Zero(t, s.pendingBytes)
+	assert.False(t, add(maxCryptoStreamSize, "x"))
+}
+
+func TestCryptoStreamOverflow(t *testing.T) {
+	t.Run("too many frames", func(t *testing.T) {
+		var s cryptoStream
+		s.add(cryptoFrame{0, []byte("a")})
+		for i := 0; i < maxPendingCryptoFrames; i++ {
+			_, err := s.add(cryptoFrame{10, []byte("x")})
+			assert.NoError(t, err)
+		}
+		_, err := s.add(cryptoFrame{10, []byte("x")})
+		assert.Equal(t, errCryptoStreamOverflow, err)
+		assert.Empty(t, s.data)
+		assert.Empty(t, s.pending)
+	})
+
+	t.Run("too many bytes", func(t *testing.T) {
+		var s cryptoStream
+		half := make([]byte, maxCryptoStreamSize/2)
+		_, err := s.add(cryptoFrame{1, half})
+		assert.NoError(t, err)
+		_, err = s.add(cryptoFrame{1, half})
+		assert.NoError(t, err)
+		_, err = s.add(cryptoFrame{1, []byte("x")})
+		assert.Equal(t, errCryptoStreamOverflow, err)
+		assert.Empty(t, s.pending)
+		assert.Zero(t, s.pendingBytes)
+	})
+}
+
+func TestVersionName(t *testing.T) {
+	assert.Equal(t, "1", versionName(version1))
+	assert.Equal(t, "2", versionName(version2))
+	assert.Equal(t, "draft-29", versionName(versionDraft29))
+	assert.Equal(t, "0x1a2a3a4a", versionName(0x1a2a3a4a))
+}
@@ -0,0 +1,342 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+const (
+	// batchPipeline collects the requests a client sends before receiving
+	// the first reply to any of them.
+	batchPipeline = "pipeline"
+
+	// batchMulti collects the requests of a MULTI block, up to and including
+	// EXEC or DISCARD.
+	batchMulti = "multi"
+)
+
+// batch groups multiple requests and their replies, which are published as
+// one transaction.
+type batch struct {
+	kind      string
+	closed    bool // no more requests are added to the batch
+	flushed   bool // published before all requests have been answered
+	requests  []*redisMessage
+	responses []*redisMessage
+}
+
+func newBatch(kind string) *batch {
+	return &batch{kind: kind}
+}
+
+func (b *batch) add(m *redisMessage) {
+	m.batch = b
+	b.requests = append(b.requests, m)
+}
+
+// onReply adds the reply to a request of the batch, returning true if all
+// requests have been answered.
+func (b *batch) onReply(resp *redisMessage) bool {
+	if b.kind == batchPipeline {
+		// requests sent after the first reply are not pipelined with the
+		// ones before
+		b.closed = true
+	}
+	b.responses = append(b.responses, resp)
+	return b.closed && len(b.responses) >= len(b.requests)
+}
+
+// addToBatch adds a request to the current pipeline or MULTI block.
+func (conn *redisConnectionData) addToBatch(m *redisMessage) {
+	method := strings.ToUpper(string(m.method))
+	switch {
+	case conn.multi != nil:
+		conn.multi.add(m)
+		if method == "EXEC" || method == "DISCARD" {
+			conn.multi.closed = true
+			conn.multi = nil
+		}
+		return
+	case method == "MULTI":
+		if conn.pipeline != nil {
+			conn.pipeline.closed = true
+			conn.pipeline = nil
+		}
+		conn.multi = newBatch(batchMulti)
+		conn.multi.add(m)
+		return
+	}
+
+	if conn.pipeline == nil || conn.pipeline.closed {
+		conn.pipeline = newBatch(batchPipeline)
+	}
+	conn.pipeline.add(m)
+}
+
+// flushBatch publishes the requests of a batch answered so far, when the
+// replies to the others are not expected anymore. Requests of the batch
+// answered later on are published on their own.
+func (redis *redisPlugin) flushBatch(conn *redisConnectionData, b *batch) {
+	if b.flushed {
+		return
+	}
+	b.closed = true
+	b.flushed = true
+	if conn.pipeline == b {
+		conn.pipeline = nil
+	}
+	if conn.multi == b {
+		conn.multi = nil
+	}
+
+	n := len(b.responses)
+	if n == 0 || redis.results == nil {
+		return
+	}
+	if isDebug {
+		debugf("flushing %s with %d of %d requests answered", b.kind, n, len(b.requests))
+	}
+	if n == 1 {
+		redis.results(redis.newTransaction(b.requests[0], b.responses[0]))
+		return
+	}
+	b.requests = b.requests[:n]
+	redis.results(redis.newBatchTransaction(b))
+}
+
+// flushBatches drops the requests waiting for a reply, publishing the
+// batches they are part of.
+func (redis *redisPlugin) flushBatches(conn *redisConnectionData) {
+	for !conn.requests.IsEmpty() {
+		requ, ok := conn.requests.Pop().(*redisMessage)
+		if ok && requ.batch != nil {
+			redis.flushBatch(conn, requ.batch)
+		}
+	}
+}
+
+func (redis *redisPlugin) newBatchTransaction(b *batch) beat.Event {
+	first := b.requests[0]
+	last := b.responses[len(b.responses)-1]
+
+	source, destination := common.MakeEndpointPair(first.tcpTuple.BaseTuple, first.cmdlineTuple)
+	src, dst := &source, &destination
+	if first.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(first.ts)
+	pbf.SetSource(src)
+	pbf.SetDestination(dst)
+	for _, requ := range b.requests {
+		pbf.Source.Bytes += int64(requ.size)
+	}
+	for _, resp := range b.responses {
+		pbf.Destination.Bytes += int64(resp.size)
+	}
+	pbf.Event.Dataset = "redis"
+	pbf.Event.Start = first.ts
+	pbf.Event.End = last.ts
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	for _, requ := range b.requests {
+		pbf.AddCapture(&requ.capture)
+	}
+	for _, resp := range b.responses {
+		pbf.AddCapture(&resp.capture)
+	}
+
+	commands := make([]string, len(b.requests))
+	for i, requ := range b.requests {
+		commands[i] = strings.ToUpper(string(requ.method))
+	}
+	query := joinMessages(b.requests)
+	response := joinMessages(b.responses)
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = common.NetString(strings.ToUpper(b.kind))
+	fields["query"] = query
+	evt.PutValue("redis.batch.type", b.kind)
+	evt.PutValue("redis.batch.commands", commands)
+
+	var failed *redisMessage
+	for _, resp := range b.responses {
+		if resp.isError {
+			failed = resp
+			break
+		}
+	}
+	if failed != nil {
+		evt.PutValue("status", common.ERROR_STATUS)
+		evt.PutValue("redis.error", failed.message)
+		pbf.Event.Outcome = "failure"
+	} else {
+		evt.PutValue("status", common.OK_STATUS)
+		if b.kind == batchMulti {
+			// the results of all commands are returned by EXEC
+			evt.PutValue("redis.return_value", last.message)
+		} else {
+			evt.PutValue("redis.return_value", response)
+		}
+	}
+
+	if redis.sendRequest {
+		fields["request"] = query
+	}
+	if redis.sendResponse {
+		fields["response"] = response
+	}
+
+	pbf.Event.Action = "redis." + b.kind
+	return evt
+}
+
+// joinMessages joins the messages of a batch into a human readable format:
+// SET a 1; INCR b
+func joinMessages(msgs []*redisMessage) common.NetString {
+	content := make([][]byte, len(msgs))
+	size := 0
+	for i, m := range msgs {
+		content[i] = m.message
+		size += len(m.message)
+	}
+	sep := []byte("; ")
+	if len(content) > 1 {
+		size += (len(content) - 1) * len(sep)
+	}
+	tmp := make([]byte, size)
+	join(tmp, content, sep)
+	return common.NetString(tmp)
+}
+
+// isPushMessage returns true if a message sent by the server is not a reply
+// to a request, like pub/sub messages and client-side caching invalidations.
+// Subscription confirmations are paired with the request they confirm. When
+// subscribing to multiple channels at once, only the first confirmation is
+// paired, the following ones are push messages.
+func (conn *redisConnectionData) isPushMessage(m *redisMessage) bool {
+	if !m.isPush && !(conn.subscribed && m.pushArgs != nil) {
+		return false
+	}
+
+	kind := m.pushArgs[0]
+	if !bytes.HasSuffix(kind, []byte("subscribe")) {
+		return true
+	}
+
+	requ, ok := conn.requests.Peek().(*redisMessage)
+	return !ok || !bytes.EqualFold(requ.method, kind)
+}
+
+// updateSubscribed tracks whether the client is subscribed to any channel,
+// which with RESP2 is required to recognize pub/sub messages.
+func (conn *redisConnectionData) updateSubscribed(m *redisMessage) {
+	if m.isRequest {
+		switch strings.ToUpper(string(m.method)) {
+		case "SUBSCRIBE", "PSUBSCRIBE", "SSUBSCRIBE":
+			conn.subscribed = true
+		}
+		return
+	}
+
+	// unsubscribe confirmations hold the number of remaining subscriptions
+	if n := len(m.pushArgs); n == 3 && bytes.HasSuffix(m.pushArgs[0], []byte("unsubscribe")) {
+		if string(m.pushArgs[2]) == "0" {
+			conn.subscribed = false
+		}
+	}
+}
+
+func (redis *redisPlugin) newPushEvent(m *redisMessage) beat.Event {
+	// push messages are sent by the server, report the client as source
+	source, destination := common.MakeEndpointPair(m.tcpTuple.BaseTuple, m.cmdlineTuple)
+	src, dst := &source, &destination
+	if m.direction == tcp.TCPDirectionOriginal {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(m.ts)
+	pbf.SetSource(src)
+	pbf.SetDestination(dst)
+	pbf.Destination.Bytes = int64(m.size)
+	pbf.Event.Dataset = "redis"
+	pbf.Event.Start = m.ts
+	pbf.Event.End = m.ts
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&m.capture)
+
+	args := m.pushArgs
+	kind := args[0]
+	push := common.MapStr{
+		"kind": common.NetString(kind),
+	}
+	var channel, pattern, message []byte
+	switch string(kind) {
+	case "message", "smessage":
+		if len(args) == 3 {
+			channel, message = args[1], args[2]
+		}
+	case "pmessage":
+		if len(args) == 4 {
+			pattern, channel, message = args[1], args[2], args[3]
+		}
+	case "invalidate":
+		if len(args) == 2 {
+			message = args[1]
+		}
+	default:
+		if bytes.HasSuffix(kind, []byte("subscribe")) && len(args) == 3 {
+			channel = args[1]
+		}
+	}
+	if channel != nil {
+		push["channel"] = common.NetString(channel)
+	}
+	if pattern != nil {
+		push["pattern"] = common.NetString(pattern)
+	}
+	if message != nil {
+		push["message"] = common.NetString(message)
+	}
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["method"] = common.NetString(bytes.ToUpper(kind))
+	if channel != nil {
+		fields["resource"] = common.NetString(channel)
+	}
+	fields["status"] = common.OK_STATUS
+	evt.PutValue("redis.push", push)
+
+	if redis.sendResponse {
+		fields["response"] = m.message
+	}
+
+	pbf.Event.Action = "redis.push"
+	return evt
+}
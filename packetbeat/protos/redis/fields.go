@@ -32,5 +32,5 @@ func init() {
 // AssetRedis returns asset data.
 // This is the base64 encoded zlib format compressed contents of protos/redis.
 func AssetRedis() string {
-	return "eJyMj71uwzAMhHc9xSFzkwfw0L1r0b1grHMs1JIMkg7gty/8kzZBM5STRPK7Ox7xxbmBMiYLgCcf2ODwvvwPAYi0VtPoqZYGrwEA1tnRRrapSy14ZXF0iUO0U8D+atbVI4pk/sov5fPIBhet07h37olHyictn1cZJv4Mn0a61UfPncJKoXbwnltktDVnKRGpQNBPWQqUEuU8EF3VLH4Kf0JQter/3N+emfViUNo0ODfnskm+wPtk2+1oa3FJxRb+QXJdRaaZXG6nMeI83zkZ9Uo9hfAdAAD//8E/imI="
+	return "eNq1VMGumzAQvOcrVpxJes+hUpX3DpHaHvJepUpVVYzZBCvGuLZJyt9310BCQtI+VSkXwKxnZ2bHzGGP7RIcFsrPAIIKGpeQbPg9oYUCvXTKBlWbJbynBYD4be4tSrVVEvCAJsBWoS78ggq6p2UsnYMRFZ7h+QqtpZWdqxvbr4x3XO4KjTM/DkI3ePp4k9JwvZbY74K4C+otBFqLlEHWVSVMAcqAgLKhZyoWhcg1wrZ2lQiL2YQEOle7t3Vf32pWCk9dfKMDdp1NB5lSLZVF7VRsglDG8/4LyFgKFXovdoM0wsnbUSeP7oDuBvVcBFmO4KbO/0XQCwY2BoITxgvJJR4qUURfK5KkLFnXK43DH67rkY5pMY2LDwMzSuKxdsXVtz/wG0a+V2Q0UYqCF5BZZVErg1lkP/AjoyioOdIaWylRHZTZTQDZ2K1yPlCN1WR0TTNr+yRVKWRReAfNtSd4qhATtE9fPr6uIde13ENjGez56/MKaO/T+mX1YfO0uNqCv0Rl+QzGNrObDg4tH+viWAgLi26mnFl+I0iMgls4IhnIZt7l/i2JupMUkvXn1YbvLDv5Pg2pbfxDMtqfER8Bz0ekOxxwVKGsG54+jZwG+7NBTwcyvYDSao+0PX/nm/yMx4dYakVy515R9KWQJYMoQ78YVYh4Kt6afU7q/8k+yx5Ip52UrH/NKLT29DxByUgut8npvJCR2UkYZvfD2aHdiWcpjEH9WJ3DXHrwIaQ9kcVtJlaEgM48mEkHSj/C0CUh/gXOtMTI7Tu8Bvv+oXera1F0ba6imkL/RzoPsGCVFM7fAqU08w=="
 }
@@ -39,6 +39,9 @@ type MessageQueue struct {
 	head, tail *listEntry
 	bytesAvail int64
 	slotsAvail int32
+
+	// onEvict is called for every message evicted, if set.
+	onEvict func(Message)
 }
 
 // MessageQueueConfig represents the configuration for a MessageQueue.
@@ -88,6 +91,14 @@ func (ml *MessageQueue) IsEmpty() bool {
 	return ml.head == nil
 }
 
+// Peek returns the oldest message in the queue without removing it, if any.
+func (ml *MessageQueue) Peek() Message {
+	if ml.head == nil {
+		return nil
+	}
+	return ml.head.item
+}
+
 // Pop returns the oldest message in the queue, if any.
 func (ml *MessageQueue) Pop() Message {
 	if ml.head == nil {
@@ -106,12 +117,19 @@ func (ml *MessageQueue) Pop() Message {
 
 func (ml *MessageQueue) adjust(msgSize int64) (evicted int) {
 	if ml.slotsAvail == 0 {
-		ml.Pop()
+		ml.evict()
 		evicted++
 	}
 	for ml.bytesAvail < msgSize && !ml.IsEmpty() {
-		ml.Pop()
+		ml.evict()
 		evicted++
 	}
 	return evicted
 }
+
+func (ml *MessageQueue) evict() {
+	msg := ml.Pop()
+	if ml.onEvict != nil && msg != nil {
+		ml.onEvict(msg)
+	}
+}
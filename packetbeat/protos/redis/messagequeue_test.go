@@ -126,3 +126,14 @@ func TestMessageList_Append(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageList_Peek(t *testing.T) {
+	queue := NewMessageQueue(MessageQueueConfig{})
+	assert.Nil(t, queue.Peek())
+
+	queue.Append(testMessage(1))
+	queue.Append(testMessage(2))
+	assert.Equal(t, testMessage(1), queue.Peek())
+	assert.Equal(t, testMessage(1), queue.Pop())
+	assert.Equal(t, testMessage(2), queue.Peek())
+}
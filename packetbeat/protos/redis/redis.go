@@ -44,6 +44,14 @@ type redisConnectionData struct {
 	streams   [2]*stream
 	requests  MessageQueue
 	responses MessageQueue
+
+	// requests are grouped into pipelines and MULTI blocks, each published
+	// as one transaction.
+	pipeline *batch
+	multi    *batch
+
+	// subscribed is set while the client is subscribed to pub/sub channels.
+	subscribed bool
 }
 
 // Redis protocol plugin
@@ -67,6 +75,7 @@ var (
 var (
 	unmatchedResponses = monitoring.NewInt(nil, "redis.unmatched_responses")
 	unmatchedRequests  = monitoring.NewInt(nil, "redis.unmatched_requests")
+	pushMessages       = monitoring.NewInt(nil, "redis.push_messages")
 )
 
 func init() {
@@ -125,6 +134,48 @@ func (redis *redisPlugin) ConnectionTimeout() time.Duration {
 	return redis.transactionTimeout
 }
 
+// DetectTCP recognizes commands sent as RESP arrays of bulk strings.
+func (redis *redisPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectRedis(data)
+}
+
+// detectRedis checks for the array header of a command, like "*3\r\n$3".
+func detectRedis(data []byte) protos.Detection {
+	const maxDigits = 6
+	const sep = "\r\n$"
+
+	if data[0] != '*' {
+		return protos.DetectionMismatch
+	}
+
+	digits := 0
+	for 1+digits < len(data) && data[1+digits] >= '0' && data[1+digits] <= '9' {
+		digits++
+	}
+	if digits > maxDigits {
+		return protos.DetectionMismatch
+	}
+
+	rest := data[1+digits:]
+	if len(rest) == 0 {
+		return protos.DetectionNeedMore
+	}
+	if digits == 0 {
+		return protos.DetectionMismatch
+	}
+	n := len(rest)
+	if n > len(sep) {
+		n = len(sep)
+	}
+	if string(rest[:n]) != sep[:n] {
+		return protos.DetectionMismatch
+	}
+	if n < len(sep) {
+		return protos.DetectionNeedMore
+	}
+	return protos.DetectionMatch
+}
+
 func (redis *redisPlugin) Parse(
 	pkt *protos.Packet,
 	tcptuple *common.TCPTuple,
@@ -141,10 +192,16 @@ func (redis *redisPlugin) Parse(
 	return conn
 }
 func (redis *redisPlugin) newConnectionData() *redisConnectionData {
-	return &redisConnectionData{
+	conn := &redisConnectionData{
 		requests:  NewMessageQueue(redis.queueConfig),
 		responses: NewMessageQueue(redis.queueConfig),
 	}
+	conn.requests.onEvict = func(m Message) {
+		if requ, ok := m.(*redisMessage); ok && requ.batch != nil {
+			redis.flushBatch(conn, requ.batch)
+		}
+	}
+	return conn
 }
 
 func (redis *redisPlugin) ensureRedisConnection(private protos.ProtocolData) *redisConnectionData {
@@ -174,7 +231,7 @@ func (redis *redisPlugin) doParse(
 
 	st := conn.streams[dir]
 	if st == nil {
-		st = newStream(pkt.Ts, tcptuple)
+		st = newStream(pkt, tcptuple)
 		conn.streams[dir] = st
 		if isDebug {
 			debugf("new stream: %p (dir=%v, len=%v)", st, dir, len(pkt.Payload))
@@ -193,7 +250,7 @@ func (redis *redisPlugin) doParse(
 
 	for st.Buf.Len() > 0 {
 		if st.parser.message == nil {
-			st.parser.message = newMessage(pkt.Ts)
+			st.parser.message = newMessage(pkt)
 		}
 
 		ok, complete := st.parser.parse(&st.Buf)
@@ -229,17 +286,17 @@ func (redis *redisPlugin) doParse(
 	return conn
 }
 
-func newStream(ts time.Time, tcptuple *common.TCPTuple) *stream {
+func newStream(pkt *protos.Packet, tcptuple *common.TCPTuple) *stream {
 	s := &stream{
 		tcptuple: tcptuple,
 	}
-	s.parser.message = newMessage(ts)
+	s.parser.message = newMessage(pkt)
 	s.Stream.Init(tcp.TCPMaxDataInStream)
 	return s
 }
 
-func newMessage(ts time.Time) *redisMessage {
-	return &redisMessage{ts: ts}
+func newMessage(pkt *protos.Packet) *redisMessage {
+	return &redisMessage{ts: pkt.Ts, capture: pkt.Capture}
 }
 
 func (redis *redisPlugin) handleRedis(
@@ -254,15 +311,24 @@ func (redis *redisPlugin) handleRedis(
 
 	if m.isRequest {
 		// wait for response
+		conn.addToBatch(m)
 		if evicted := conn.requests.Append(m); evicted > 0 {
 			unmatchedRequests.Add(int64(evicted))
 		}
+	} else if conn.isPushMessage(m) {
+		// published on its own, not disrupting the correlation of replies
+		pushMessages.Add(1)
+		if redis.results != nil {
+			redis.results(redis.newPushEvent(m))
+		}
 	} else {
 		if evicted := conn.responses.Append(m); evicted > 0 {
 			unmatchedResponses.Add(int64(evicted))
 		}
 		redis.correlate(conn)
 	}
+
+	conn.updateSubscribed(m)
 }
 
 func (redis *redisPlugin) correlate(conn *redisConnectionData) {
@@ -284,10 +350,28 @@ func (redis *redisPlugin) correlate(conn *redisConnectionData) {
 			logp.Err("invalid type found in message queue")
 			continue
 		}
-		if redis.results != nil {
-			event := redis.newTransaction(requ, resp)
-			redis.results(event)
-		}
+		redis.onTransaction(requ, resp)
+	}
+}
+
+// onTransaction publishes a request and its reply, or the whole batch the
+// request is part of once complete.
+func (redis *redisPlugin) onTransaction(requ, resp *redisMessage) {
+	b := requ.batch
+	if b != nil && b.flushed {
+		b = nil
+	}
+	if b != nil && !b.onReply(resp) {
+		return
+	}
+	if redis.results == nil {
+		return
+	}
+
+	if b == nil || len(b.requests) == 1 {
+		redis.results(redis.newTransaction(requ, resp))
+	} else {
+		redis.results(redis.newBatchTransaction(b))
 	}
 }
 
@@ -308,6 +392,8 @@ func (redis *redisPlugin) newTransaction(requ, resp *redisMessage) beat.Event {
 	pbf.Event.End = resp.ts
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&requ.capture)
+	pbf.AddCapture(&resp.capture)
 
 	fields := evt.Fields
 	fields["type"] = pbf.Event.Dataset
@@ -344,6 +430,9 @@ func (redis *redisPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
 	// tsg: being packet loss tolerant is probably not very useful for Redis,
 	// because most requests/response tend to fit in a single packet.
 
+	if conn := getRedisConnection(private); conn != nil {
+		redis.flushBatches(conn)
+	}
 	return private, true
 }
 
@@ -352,5 +441,34 @@ func (redis *redisPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
 
 	// TODO: check if we have pending data that we can send up the stack
 
+	conn := getRedisConnection(private)
+	if conn == nil {
+		return private
+	}
+	// no more replies once the server closed the connection
+	if requ, ok := conn.requests.Peek().(*redisMessage); ok && requ.direction != dir {
+		redis.flushBatches(conn)
+	}
 	return private
 }
+
+// Expired publishes the batches still waiting for replies when the
+// connection expires.
+func (redis *redisPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	conn := getRedisConnection(private)
+	if conn == nil {
+		return
+	}
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	redis.flushBatches(conn)
+}
+
+func getRedisConnection(private protos.ProtocolData) *redisConnectionData {
+	conn, ok := private.(*redisConnectionData)
+	if !ok {
+		return nil
+	}
+	return conn
+}
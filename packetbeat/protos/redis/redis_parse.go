@@ -24,6 +24,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/streambuf"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/pb"
 )
 
 type parser struct {
@@ -33,7 +34,8 @@ type parser struct {
 }
 
 type redisMessage struct {
-	ts time.Time
+	ts      time.Time
+	capture pb.Capture
 
 	tcpTuple     common.TCPTuple
 	cmdlineTuple *common.ProcessTuple
@@ -45,6 +47,17 @@ type redisMessage struct {
 	message   common.NetString
 	method    common.NetString
 	path      common.NetString
+
+	// isPush is set for RESP3 push messages, which are sent by the server
+	// without being requested.
+	isPush bool
+
+	// pushArgs holds the elements of a push message or of a RESP2 array
+	// reply looking like a pub/sub message, like "message <channel> <payload>".
+	pushArgs [][]byte
+
+	// batch is set for requests being part of a pipeline or of a MULTI block.
+	batch *batch
 }
 
 func (msg *redisMessage) Size() int {
@@ -54,7 +67,10 @@ func (msg *redisMessage) Size() int {
 var (
 	empty    = common.NetString("")
 	emptyArr = common.NetString("[]")
+	emptyMap = common.NetString("{}")
 	nilStr   = common.NetString("nil")
+	trueStr  = common.NetString("true")
+	falseStr = common.NetString("false")
 )
 
 // Keep sorted for future command addition
@@ -69,11 +85,13 @@ var redisCommands = map[string]struct{}{
 	"BLPOP":            {},
 	"BRPOP":            {},
 	"BRPOPLPUSH":       {},
+	"CLIENT ID":        {},
 	"CLIENT GETNAME":   {},
 	"CLIENT KILL":      {},
 	"CLIENT LIST":      {},
 	"CLIENT PAUSE":     {},
 	"CLIENT SETNAME":   {},
+	"CLIENT TRACKING":  {},
 	"CONFIG GET":       {},
 	"CONFIG RESETSTAT": {},
 	"CONFIG REWRITE":   {},
@@ -99,6 +117,7 @@ var redisCommands = map[string]struct{}{
 	"GETBIT":           {},
 	"GETRANGE":         {},
 	"GETSET":           {},
+	"HELLO":            {},
 	"HDEL":             {},
 	"HEXISTS":          {},
 	"HGET":             {},
@@ -154,6 +173,7 @@ var redisCommands = map[string]struct{}{
 	"RANDOMKEY":        {},
 	"RENAME":           {},
 	"RENAMENX":         {},
+	"RESET":            {},
 	"RESTORE":          {},
 	"RPOP":             {},
 	"RPOPLPUSH":        {},
@@ -184,14 +204,17 @@ var redisCommands = map[string]struct{}{
 	"SMEMBERS":         {},
 	"SMOVE":            {},
 	"SORT":             {},
+	"SPUBLISH":         {},
 	"SPOP":             {},
 	"SRANDMEMBER":      {},
 	"SREM":             {},
 	"SSCAN":            {},
+	"SSUBSCRIBE":       {},
 	"STRLEN":           {},
 	"SUBSCRIBE":        {},
 	"SUNION":           {},
 	"SUNIONSTORE":      {},
+	"SUNSUBSCRIBE":     {},
 	"SYNC":             {},
 	"TIME":             {},
 	"TTL":              {},
@@ -289,17 +312,34 @@ func (p *parser) dispatch(depth int, buf *streambuf.Buffer) (common.NetString, b
 	snapshot := buf.Snapshot()
 
 	switch buf.Bytes()[0] {
-	case '*':
+	case '*', '~', '>':
 		value, iserror, ok, complete = p.parseArray(depth, buf)
+	case '%':
+		value, ok, complete = p.parseMap(depth, buf)
+	case '|':
+		// attributes carry auxiliary data preceding the actual reply
+		_, ok, complete = p.parseMap(depth, buf)
+		if ok && complete {
+			value, iserror, ok, complete = p.dispatch(depth, buf)
+		}
 	case '$':
 		value, ok, complete = p.parseString(buf)
+	case '=':
+		value, ok, complete = p.parseVerbatimString(buf)
+	case '!':
+		iserror = true
+		value, ok, complete = p.parseString(buf)
 	case ':':
 		value, ok, complete = p.parseInt(buf)
-	case '+':
+	case '+', ',', '(':
 		value, ok, complete = p.parseSimpleString(buf)
 	case '-':
 		iserror = true
 		value, ok, complete = p.parseSimpleString(buf)
+	case '_':
+		value, ok, complete = p.parseNull(buf)
+	case '#':
+		value, ok, complete = p.parseBoolean(buf)
 	default:
 		if isDebug {
 			debugf("Unexpected message starting with %s", buf.Bytes()[0])
@@ -332,6 +372,41 @@ func (p *parser) parseSimpleString(buf *streambuf.Buffer) (common.NetString, boo
 	return common.NetString(line[1:]), true, true
 }
 
+func (p *parser) parseNull(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	_, err := buf.UntilCRLF()
+	if err != nil {
+		return empty, true, false
+	}
+	return nilStr, true, true
+}
+
+func (p *parser) parseBoolean(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	line, err := buf.UntilCRLF()
+	if err != nil {
+		return empty, true, false
+	}
+
+	switch {
+	case len(line) == 2 && line[1] == 't':
+		return trueStr, true, true
+	case len(line) == 2 && line[1] == 'f':
+		return falseStr, true, true
+	default:
+		logp.Err("Failed to read boolean reply: %q", line)
+		return empty, false, false
+	}
+}
+
+// parseVerbatimString parses a verbatim string, stripping the format prefix
+// like "txt:" from its content.
+func (p *parser) parseVerbatimString(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	value, ok, complete := p.parseString(buf)
+	if ok && complete && len(value) >= 4 && value[3] == ':' {
+		value = value[4:]
+	}
+	return value, ok, complete
+}
+
 func (p *parser) parseString(buf *streambuf.Buffer) (common.NetString, bool, bool) {
 	line, err := buf.UntilCRLF()
 	if err != nil {
@@ -371,6 +446,7 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 		debugf("line %s: %d", line, buf.BufferConsumed())
 	}
 
+	kind := line[0]
 	if len(line) == 3 && line[1] == '-' && line[2] == '1' {
 		return nilStr, false, true, true
 	}
@@ -406,11 +482,17 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 	contentLen := 0
 	// read sub elements
 
+	// commands are sent as arrays of bulk strings
+	bulkStrings := kind == '*'
+
 	iserror := false
 	for i := 0; i < int(count); i++ {
 		var value common.NetString
 		var ok, complete bool
 
+		if buf.Len() > 0 && buf.Bytes()[0] != '$' {
+			bulkStrings = false
+		}
 		value, iserror, ok, complete := p.dispatch(depth+1, buf)
 		if !ok || !complete {
 			if isDebug {
@@ -425,8 +507,10 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 
 	// handle top-level request command
 	var oneWordCommand, twoWordsCommand bool
-	oneWordCommand = isRedisCommand(content[0])
-	twoWordsCommand = count > 1 && isRedisCommand(bytes.Join(content[0:2], []byte(" ")))
+	if bulkStrings {
+		oneWordCommand = isRedisCommand(content[0])
+		twoWordsCommand = count > 1 && isRedisCommand(bytes.Join(content[0:2], []byte(" ")))
+	}
 
 	if depth == 0 && (oneWordCommand || twoWordsCommand) {
 		p.message.isRequest = true
@@ -453,6 +537,13 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 		return value, iserror, true, true
 	}
 
+	// keep the elements of push messages and of replies looking like pub/sub
+	// messages, to tell them apart from regular replies
+	if depth == 0 && (kind == '>' || kind == '*' && isPubSubKind(content[0])) {
+		p.message.isPush = kind == '>'
+		p.message.pushArgs = append([][]byte(nil), content...)
+	}
+
 	// return redis array: [a, b, c]
 	tmp := make([]byte, 2+contentLen+(len(content)-1)*2)
 	tmp[0] = '['
@@ -462,6 +553,58 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 	return value, iserror, true, true
 }
 
+// parseMap parses a RESP3 map or attribute into a human readable format:
+// {a: 1, b: 2}
+func (p *parser) parseMap(depth int, buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	line, err := buf.UntilCRLF()
+	if err != nil {
+		return empty, true, false
+	}
+
+	count, err := parseInt(line[1:])
+	if err != nil {
+		logp.Err("Failed to read number of map entries: %s", err)
+		return empty, false, false
+	}
+	if count <= 0 {
+		return emptyMap, true, true
+	}
+
+	var value bytes.Buffer
+	value.WriteByte('{')
+	for i := 0; i < int(count); i++ {
+		key, _, ok, complete := p.dispatch(depth+1, buf)
+		if !ok || !complete {
+			return empty, ok, complete
+		}
+		val, _, ok, complete := p.dispatch(depth+1, buf)
+		if !ok || !complete {
+			return empty, ok, complete
+		}
+
+		if i > 0 {
+			value.WriteString(", ")
+		}
+		value.Write(key)
+		value.WriteString(": ")
+		value.Write(val)
+	}
+	value.WriteByte('}')
+	return common.NetString(value.Bytes()), true, true
+}
+
+// isPubSubKind returns true if kind is the first element of a pub/sub
+// message or of a subscription confirmation.
+func isPubSubKind(kind []byte) bool {
+	switch string(kind) {
+	case "message", "pmessage", "smessage",
+		"subscribe", "psubscribe", "ssubscribe",
+		"unsubscribe", "punsubscribe", "sunsubscribe":
+		return true
+	}
+	return false
+}
+
 func parseInt(line []byte) (int64, error) {
 	buf := streambuf.NewFixed(line)
 	return buf.IntASCII(false)
@@ -20,14 +20,22 @@
 package redis
 
 import (
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
 )
 
 func newTestStream(content []byte) *stream {
-	st := newStream(time.Now(), nil)
+	st := newStream(&protos.Packet{Ts: time.Now()}, nil)
 	st.Append(content)
 	return st
 }
@@ -255,3 +263,344 @@ func BenchmarkParserArray2Passes(b *testing.B) {
 		st.parser.parse(&st.Buf)
 	}
 }
+
+func TestDetectRedis(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"*1\r\n$4\r\nPING\r\n", protos.DetectionMatch},
+		{"*12\r", protos.DetectionNeedMore},
+		{"*\r\n", protos.DetectionMismatch},
+		{"+OK\r\n", protos.DetectionMismatch},
+		{"*1\r\n:1\r\n", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectRedis([]byte(test.data)), "%q", test.data)
+	}
+}
+
+func TestRedisParser_RESP3(t *testing.T) {
+	for _, test := range []struct {
+		title    string
+		message  string
+		expected string
+		isError  bool
+	}{
+		{"null", "_\r\n", "nil", false},
+		{"boolean", "#t\r\n", "true", false},
+		{"double", ",3.14\r\n", "3.14", false},
+		{"big number", "(3492890328409238509324850943850943825024385\r\n", "3492890328409238509324850943850943825024385", false},
+		{"verbatim string", "=15\r\ntxt:Some string\r\n", "Some string", false},
+		{"blob error", "!21\r\nSYNTAX invalid syntax\r\n", "SYNTAX invalid syntax", true},
+		{"map", "%2\r\n+first\r\n:1\r\n+second\r\n#f\r\n", "{first: 1, second: false}", false},
+		{"empty map", "%0\r\n", "{}", false},
+		{"set", "~3\r\n+orange\r\n+apple\r\n_\r\n", "[orange, apple, nil]", false},
+		{"nested", "*2\r\n%1\r\n$3\r\nkey\r\n~1\r\n,1.5\r\n#t\r\n", "[{key: [1.5]}, true]", false},
+		{"attribute", "|1\r\n+key-popularity\r\n%1\r\n$1\r\na\r\n,0.1923\r\n*2\r\n:2039123\r\n:9543892\r\n", "[2039123, 9543892]", false},
+	} {
+		msg, ok, complete := parse([]byte(test.message))
+
+		assert.True(t, ok, test.title)
+		assert.True(t, complete, test.title)
+		assert.False(t, msg.isRequest, test.title)
+		assert.False(t, msg.isPush, test.title)
+		assert.Equal(t, test.isError, msg.isError, test.title)
+		assert.Equal(t, test.expected, string(msg.message), test.title)
+		assert.Equal(t, len(test.message), msg.size, test.title)
+	}
+}
+
+func TestRedisParser_RESP3Incomplete(t *testing.T) {
+	message := "%2\r\n+first\r\n:1\r\n+second\r\n#f\r\n"
+	for i := 1; i < len(message); i++ {
+		_, ok, complete := parse([]byte(message[:i]))
+		assert.True(t, ok, message[:i])
+		assert.False(t, complete, message[:i])
+	}
+}
+
+func TestRedisParser_Push(t *testing.T) {
+	message := []byte(">3\r\n$7\r\nmessage\r\n$4\r\nnews\r\n$5\r\nhello\r\n")
+	msg, ok, complete := parse(message)
+
+	assert.True(t, ok)
+	assert.True(t, complete)
+	assert.False(t, msg.isRequest)
+	assert.True(t, msg.isPush)
+	assert.Equal(t, "[message, news, hello]", string(msg.message))
+	assert.Equal(t, [][]byte{[]byte("message"), []byte("news"), []byte("hello")}, msg.pushArgs)
+
+	// subscription confirmations are not mistaken for SUBSCRIBE requests
+	msg, _, _ = parse([]byte(">3\r\n$9\r\nsubscribe\r\n$4\r\nnews\r\n:1\r\n"))
+	assert.False(t, msg.isRequest)
+	assert.True(t, msg.isPush)
+}
+
+func TestRedisParser_HelloRequest(t *testing.T) {
+	msg, ok, complete := parse([]byte("*2\r\n$5\r\nHELLO\r\n$1\r\n3\r\n"))
+
+	assert.True(t, ok)
+	assert.True(t, complete)
+	assert.True(t, msg.isRequest)
+	assert.Equal(t, "HELLO", string(msg.method))
+}
+
+const serverPort = 6379
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func redisModForTests(store *eventStore) *redisPlugin {
+	var redis redisPlugin
+	config := defaultConfig
+	config.Ports = []int{serverPort}
+	redis.init(store.publish, procs.ProcessesWatcher{}, &config)
+	return &redis
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: serverPort,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+// session feeds client and server payloads to the analyzer in turn,
+// starting with the client. Empty payloads are skipped. The connection
+// state is returned.
+func session(redis *redisPlugin, payloads ...string) protos.ProtocolData {
+	tuple := testTCPTuple()
+	var private protos.ProtocolData
+	for i, payload := range payloads {
+		dir := uint8(tcp.TCPDirectionOriginal)
+		if i%2 == 1 {
+			dir = tcp.TCPDirectionReverse
+		}
+		if payload == "" {
+			continue
+		}
+		pkt := protos.Packet{Ts: time.Now(), Payload: []byte(payload)}
+		private = redis.Parse(&pkt, tuple, dir, private)
+	}
+	return private
+}
+
+func eventValue(t *testing.T, event beat.Event, key string) interface{} {
+	v, err := event.GetValue(key)
+	if err != nil {
+		t.Errorf("%s: %v", key, err)
+	}
+	return v
+}
+
+func TestRedisPushMessages(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	session(redis,
+		"*3\r\n$9\r\nSUBSCRIBE\r\n$4\r\nnews\r\n$6\r\nsports\r\n",
+		">3\r\n$9\r\nsubscribe\r\n$4\r\nnews\r\n:1\r\n"+
+			">3\r\n$9\r\nsubscribe\r\n$6\r\nsports\r\n:2\r\n",
+		"*2\r\n$3\r\nGET\r\n$3\r\nkey\r\n",
+		">3\r\n$7\r\nmessage\r\n$4\r\nnews\r\n$5\r\nhello\r\n"+
+			"$5\r\nvalue\r\n"+
+			">2\r\n$10\r\ninvalidate\r\n*1\r\n$3\r\nkey\r\n",
+	)
+
+	if !assert.Len(t, store.events, 5) {
+		return
+	}
+
+	subscribe, confirmation, message, get, invalidate := store.events[0], store.events[1], store.events[2], store.events[3], store.events[4]
+	assert.Equal(t, common.NetString("SUBSCRIBE"), subscribe.Fields["method"])
+	assert.Equal(t, common.NetString("[subscribe, news, 1]"), eventValue(t, subscribe, "redis.return_value"))
+
+	assert.Equal(t, common.NetString("subscribe"), eventValue(t, confirmation, "redis.push.kind"))
+	assert.Equal(t, common.NetString("sports"), eventValue(t, confirmation, "redis.push.channel"))
+
+	assert.Equal(t, common.NetString("MESSAGE"), message.Fields["method"])
+	assert.Equal(t, common.NetString("news"), message.Fields["resource"])
+	assert.Equal(t, common.NetString("hello"), eventValue(t, message, "redis.push.message"))
+	assert.Equal(t, "redis.push", eventValue(t, message, "event.action"))
+
+	assert.Equal(t, common.NetString("GET"), get.Fields["method"])
+	assert.Equal(t, common.NetString("value"), eventValue(t, get, "redis.return_value"))
+
+	assert.Equal(t, common.NetString("invalidate"), eventValue(t, invalidate, "redis.push.kind"))
+	assert.Equal(t, common.NetString("[key]"), eventValue(t, invalidate, "redis.push.message"))
+}
+
+func TestRedisRESP2PubSub(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	session(redis,
+		"*2\r\n$10\r\nPSUBSCRIBE\r\n$3\r\nn.*\r\n",
+		"*3\r\n$10\r\npsubscribe\r\n$3\r\nn.*\r\n:1\r\n"+
+			"*4\r\n$8\r\npmessage\r\n$3\r\nn.*\r\n$4\r\nn.eu\r\n$5\r\nhello\r\n",
+		"*1\r\n$4\r\nPING\r\n",
+		"*2\r\n$4\r\npong\r\n$0\r\n\r\n",
+	)
+
+	if !assert.Len(t, store.events, 3) {
+		return
+	}
+
+	psubscribe, message, ping := store.events[0], store.events[1], store.events[2]
+	assert.Equal(t, common.NetString("PSUBSCRIBE"), psubscribe.Fields["method"])
+	assert.Equal(t, common.NetString("n.*"), eventValue(t, message, "redis.push.pattern"))
+	assert.Equal(t, common.NetString("n.eu"), eventValue(t, message, "redis.push.channel"))
+	assert.Equal(t, common.NetString("PING"), ping.Fields["method"])
+	assert.Equal(t, common.NetString("[pong, ]"), eventValue(t, ping, "redis.return_value"))
+}
+
+func TestRedisPipeline(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	session(redis,
+		"*3\r\n$3\r\nSET\r\n$1\r\na\r\n$1\r\n1\r\n"+
+			"*2\r\n$4\r\nINCR\r\n$1\r\na\r\n"+
+			"*2\r\n$4\r\nLLEN\r\n$1\r\na\r\n",
+		"+OK\r\n:2\r\n",
+		"",
+		"-WRONGTYPE Operation against a key holding the wrong kind of value\r\n",
+		"*2\r\n$3\r\nGET\r\n$1\r\na\r\n",
+		"$1\r\n2\r\n",
+	)
+
+	if !assert.Len(t, store.events, 2) {
+		return
+	}
+
+	pipeline, get := store.events[0], store.events[1]
+	assert.Equal(t, common.NetString("PIPELINE"), pipeline.Fields["method"])
+	assert.Equal(t, common.NetString("SET a 1; INCR a; LLEN a"), pipeline.Fields["query"])
+	assert.Equal(t, "pipeline", eventValue(t, pipeline, "redis.batch.type"))
+	assert.Equal(t, []string{"SET", "INCR", "LLEN"}, eventValue(t, pipeline, "redis.batch.commands"))
+	assert.Equal(t, common.ERROR_STATUS, eventValue(t, pipeline, "status"))
+	assert.Equal(t, common.NetString("WRONGTYPE Operation against a key holding the wrong kind of value"),
+		eventValue(t, pipeline, "redis.error"))
+
+	assert.Equal(t, common.NetString("GET"), get.Fields["method"])
+	assert.Equal(t, common.NetString("2"), eventValue(t, get, "redis.return_value"))
+}
+
+func TestRedisMultiExec(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	session(redis,
+		"*1\r\n$5\r\nMULTI\r\n",
+		"+OK\r\n",
+		"*2\r\n$4\r\nINCR\r\n$1\r\na\r\n",
+		"+QUEUED\r\n",
+		"*2\r\n$4\r\nINCR\r\n$1\r\nb\r\n*1\r\n$4\r\nEXEC\r\n",
+		"+QUEUED\r\n*2\r\n:1\r\n:5\r\n",
+	)
+
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+
+	multi := store.events[0]
+	assert.Equal(t, common.NetString("MULTI"), multi.Fields["method"])
+	assert.Equal(t, common.NetString("MULTI; INCR a; INCR b; EXEC"), multi.Fields["query"])
+	assert.Equal(t, "multi", eventValue(t, multi, "redis.batch.type"))
+	assert.Equal(t, []string{"MULTI", "INCR", "INCR", "EXEC"}, eventValue(t, multi, "redis.batch.commands"))
+	assert.Equal(t, common.OK_STATUS, eventValue(t, multi, "status"))
+	assert.Equal(t, common.NetString("[1, 5]"), eventValue(t, multi, "redis.return_value"))
+}
+
+func TestRedisFlushIncompleteBatch(t *testing.T) {
+	pipeline := []string{
+		"*3\r\n$3\r\nSET\r\n$1\r\na\r\n$1\r\n1\r\n" +
+			"*2\r\n$4\r\nINCR\r\n$1\r\na\r\n" +
+			"*2\r\n$4\r\nLLEN\r\n$1\r\na\r\n",
+		"+OK\r\n:2\r\n",
+	}
+
+	for _, test := range []struct {
+		name  string
+		flush func(redis *redisPlugin, private protos.ProtocolData)
+	}{
+		{
+			name: "gap",
+			flush: func(redis *redisPlugin, private protos.ProtocolData) {
+				redis.GapInStream(testTCPTuple(), tcp.TCPDirectionReverse, 10, private)
+			},
+		},
+		{
+			name: "server fin",
+			flush: func(redis *redisPlugin, private protos.ProtocolData) {
+				redis.ReceivedFin(testTCPTuple(), tcp.TCPDirectionReverse, private)
+			},
+		},
+		{
+			name: "expired",
+			flush: func(redis *redisPlugin, private protos.ProtocolData) {
+				redis.Expired(testTCPTuple(), private)
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			store := &eventStore{}
+			redis := redisModForTests(store)
+			private := session(redis, pipeline...)
+			assert.Empty(t, store.events)
+
+			test.flush(redis, private)
+			if !assert.Len(t, store.events, 1) {
+				return
+			}
+			event := store.events[0]
+			assert.Equal(t, common.NetString("SET a 1; INCR a"), event.Fields["query"])
+			assert.Equal(t, []string{"SET", "INCR"}, eventValue(t, event, "redis.batch.commands"))
+			assert.Equal(t, common.NetString("OK; 2"), eventValue(t, event, "redis.return_value"))
+		})
+	}
+}
+
+func TestRedisClientFinKeepsBatch(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	private := session(redis,
+		"*1\r\n$5\r\nMULTI\r\n*2\r\n$4\r\nINCR\r\n$1\r\na\r\n*1\r\n$4\r\nEXEC\r\n",
+		"+OK\r\n+QUEUED\r\n",
+	)
+	private = redis.ReceivedFin(testTCPTuple(), tcp.TCPDirectionOriginal, private)
+	assert.Empty(t, store.events)
+
+	pkt := protos.Packet{Ts: time.Now(), Payload: []byte("*1\r\n:1\r\n")}
+	redis.Parse(&pkt, testTCPTuple(), tcp.TCPDirectionReverse, private)
+	if assert.Len(t, store.events, 1) {
+		assert.Equal(t, []string{"MULTI", "INCR", "EXEC"}, eventValue(t, store.events[0], "redis.batch.commands"))
+	}
+}
+
+func TestRedisFlushEvictedBatch(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+	redis.queueConfig.MaxMessages = 2
+	session(redis,
+		"*3\r\n$3\r\nSET\r\n$1\r\na\r\n$1\r\n1\r\n"+
+			"*2\r\n$4\r\nINCR\r\n$1\r\na\r\n",
+		"+OK\r\n",
+		"*2\r\n$3\r\nGET\r\n$1\r\na\r\n"+
+			"*2\r\n$4\r\nLLEN\r\n$1\r\na\r\n",
+	)
+
+	// INCR is evicted, the pipeline is published with the SET reply only
+	if assert.Len(t, store.events, 1) {
+		set := store.events[0]
+		assert.Equal(t, common.NetString("SET"), set.Fields["method"])
+		assert.Equal(t, common.NetString("OK"), eventValue(t, set, "redis.return_value"))
+	}
+}
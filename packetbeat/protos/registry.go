@@ -79,6 +79,46 @@ type ExpirationAwareTCPPlugin interface {
 	Expired(tuple *common.TCPTuple, private ProtocolData)
 }
 
+// ProtocolsAwarePlugin is a Plugin handing data over to other configured
+// plugins. SetProtocols is called once all the configured plugins have been
+// created.
+type ProtocolsAwarePlugin interface {
+	Plugin
+
+	// SetProtocols gives access to the configured plugins.
+	SetProtocols(protocols Protocols) error
+}
+
+// Detection is the result of a protocol signature check.
+type Detection uint8
+
+const (
+	// DetectionMismatch reports that the data is not of the protocol.
+	DetectionMismatch Detection = iota
+
+	// DetectionNeedMore reports that more data is required to decide.
+	DetectionNeedMore
+
+	// DetectionMatch reports that the data looks like the protocol.
+	DetectionMatch
+)
+
+// TCPDetector is implemented by TCPPlugins able to recognize their protocol
+// by the first bytes sent in a direction of a stream. It is used to classify
+// connections on ports not configured for any protocol, if protocol detection
+// is enabled. Detection must be cheap, as it is run for all unknown streams.
+type TCPDetector interface {
+	// DetectTCP checks the contiguous data seen so far at the start of a
+	// stream direction.
+	DetectTCP(data []byte) Detection
+}
+
+// UDPDetector is the UDPPlugin counterpart of TCPDetector.
+type UDPDetector interface {
+	// DetectUDP checks the payload of a datagram of an unknown flow.
+	DetectUDP(data []byte) Detection
+}
+
 // Protocol identifier.
 type Protocol uint16
 
@@ -102,6 +102,44 @@ func (p *plugin) GetPorts() []int {
 	return p.ports
 }
 
+// DetectUDP recognizes the request or status line of SIP messages.
+func (p *plugin) DetectUDP(data []byte) protos.Detection {
+	return detectSIP(data)
+}
+
+// maxDetectedMethodLen limits the length of the request methods recognized
+// by detectSIP.
+const maxDetectedMethodLen = 16
+
+var (
+	sipVersion = []byte("SIP/2.0 ")
+	sipSchemes = [][]byte{[]byte("sip:"), []byte("sips:"), []byte("tel:")}
+)
+
+func detectSIP(data []byte) protos.Detection {
+	if bytes.HasPrefix(data, sipVersion) {
+		return protos.DetectionMatch
+	}
+
+	// request line: the method is followed by the request URI
+	sp := bytes.IndexByte(data, ' ')
+	if sp <= 0 || sp > maxDetectedMethodLen {
+		return protos.DetectionMismatch
+	}
+	for _, c := range data[:sp] {
+		if c < 'A' || c > 'Z' {
+			return protos.DetectionMismatch
+		}
+	}
+	uri := data[sp+1:]
+	for _, scheme := range sipSchemes {
+		if len(uri) >= len(scheme) && bytes.EqualFold(uri[:len(scheme)], scheme) {
+			return protos.DetectionMatch
+		}
+	}
+	return protos.DetectionMismatch
+}
+
 func (p *plugin) ParseUDP(pkt *protos.Packet) {
 	defer logp.Recover("SIP ParseUDP exception")
 
@@ -175,6 +213,7 @@ func (p *plugin) buildEvent(m *message, pkt *protos.Packet) (*beat.Event, error)
 	pbf.Network.Application = "sip"
 	pbf.Network.Protocol = "sip"
 	pbf.Network.Transport = "udp"
+	pbf.AddCapture(&pkt.Capture)
 
 	src, dst := m.getEndpoints()
 	pbf.SetSource(src)
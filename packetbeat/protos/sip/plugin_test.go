@@ -173,3 +173,17 @@ func getVal(f beat.Event, k string) interface{} {
 	v, _ := f.GetValue(k)
 	return v
 }
+
+func TestDetectSIP(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"INVITE sip:bob@example.com SIP/2.0\r\n", protos.DetectionMatch},
+		{"SIP/2.0 200 OK\r\n", protos.DetectionMatch},
+		{"REGISTER SIPS:example.com SIP/2.0\r\n", protos.DetectionMatch},
+		{"GET / HTTP/1.1\r\n", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectSIP([]byte(test.data)), "%q", test.data)
+	}
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"sort"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+
+	"github.com/tsg/gopacket/layers"
+)
+
+const defaultDetectionMaxBytes = 1024
+
+var (
+	classifiedStreams   = monitoring.NewInt(nil, "tcp.detection.classified_streams")
+	unclassifiedStreams = monitoring.NewInt(nil, "tcp.detection.unclassified_streams")
+)
+
+type protocolDetector struct {
+	protocol protos.Protocol
+	detector protos.TCPDetector
+}
+
+// detection holds the first segments of a stream not matching the ports of
+// any protocol, until a protocol detector recognizes the stream's data. The
+// segments are then passed on to the analyzer of the detected protocol.
+type detection struct {
+	key      common.HashableIPPortTuple
+	segments []detectedSegment
+	dirs     [2]detectionDir
+	bytes    int
+}
+
+type detectedSegment struct {
+	dir uint8
+	bufferedSegment
+}
+
+// detectionDir holds the contiguous data at the start of a stream direction.
+type detectionDir struct {
+	data    []byte
+	nextSeq uint32
+}
+
+// newDetectors returns the detectors of the TCP plugins, ordered by protocol
+// so that the result of the detection does not depend on map iteration.
+func newDetectors(plugins map[protos.Protocol]protos.TCPPlugin) []protocolDetector {
+	var detectors []protocolDetector
+	for proto, plugin := range plugins {
+		if detector, ok := plugin.(protos.TCPDetector); ok {
+			detectors = append(detectors, protocolDetector{proto, detector})
+		}
+	}
+	sort.Slice(detectors, func(i, j int) bool {
+		return detectors[i].protocol < detectors[j].protocol
+	})
+	return detectors
+}
+
+// add copies the segment into the detection buffer. It returns false if the
+// segment does not continue the data seen in its direction, as a stream
+// with a hole at its start can not be classified.
+func (d *detection) add(dir uint8, tcphdr *layers.TCP, pkt *protos.Packet) bool {
+	s := &d.dirs[dir]
+	seq, payload := tcphdr.Seq, pkt.Payload
+	if len(payload) > 0 && len(s.data) > 0 {
+		if tcpSeqBefore(s.nextSeq, seq) {
+			return false
+		}
+
+		// skip the retransmitted data
+		delta := s.nextSeq - seq
+		if int(delta) >= len(payload) {
+			payload = nil
+		} else {
+			payload = payload[delta:]
+		}
+		seq += delta
+	}
+	if len(payload) == 0 && !tcphdr.FIN {
+		return true
+	}
+
+	if len(payload) > 0 {
+		s.data = append(s.data, payload...)
+		s.nextSeq = seq + uint32(len(payload))
+		d.bytes += len(payload)
+	}
+	d.segments = append(d.segments, detectedSegment{
+		dir:             dir,
+		bufferedSegment: bufferedSegment{seq: seq, fin: tcphdr.FIN, pkt: copyPacket(pkt, payload)},
+	})
+	return true
+}
+
+// detect runs the protocol detectors on the data of both directions. If no
+// protocol is detected, it reports whether more data could still identify
+// the stream.
+func (tcp *TCP) detect(d *detection) (protocol protos.Protocol, needMore bool) {
+	for _, s := range d.dirs {
+		if len(s.data) == 0 {
+			// the peer may still send data identifying the stream
+			needMore = true
+			continue
+		}
+		for _, pd := range tcp.detectors {
+			switch pd.detector.DetectTCP(s.data) {
+			case protos.DetectionMatch:
+				return pd.protocol, false
+			case protos.DetectionNeedMore:
+				needMore = true
+			}
+		}
+	}
+	return protos.UnknownProtocol, needMore
+}
+
+// detectProtocol adds the segment to the data of a stream with an unknown
+// protocol and runs the protocol detectors. Once a protocol is detected, the
+// buffered segments are passed to its analyzer. The stream is ignored if
+// no protocol matches within the detection byte budget.
+func (stream *TCPStream) detectProtocol(tcphdr *layers.TCP, pkt *protos.Packet) {
+	conn := stream.conn
+	tcp := conn.tcp
+	d := conn.detect
+	if len(pkt.Payload) == 0 && !tcphdr.FIN {
+		return
+	}
+
+	if !d.add(stream.dir, tcphdr, pkt) {
+		stream.unclassified()
+		return
+	}
+	if d.bytes == 0 {
+		return
+	}
+
+	protocol, needMore := tcp.detect(d)
+	switch {
+	case protocol != protos.UnknownProtocol:
+		stream.classified(protocol)
+	case !needMore || d.bytes >= tcp.detectionMaxBytes:
+		stream.unclassified()
+	}
+}
+
+func (stream *TCPStream) classified(protocol protos.Protocol) {
+	conn := stream.conn
+	tcp := conn.tcp
+	d := conn.detect
+	if isDebug {
+		debugf("Detected protocol %s of stream %s after %d bytes", protocol, conn.tuple, d.bytes)
+	}
+	classifiedStreams.Add(1)
+
+	conn.detect = nil
+	conn.protocol = protocol
+	if mod := tcp.protocols.GetTCP(protocol); mod != nil {
+		tcp.streams.PutWithTimeout(d.key, conn, mod.ConnectionTimeout())
+	}
+
+	for i := range d.segments {
+		seg := &d.segments[i]
+		s := TCPStream{conn: conn, dir: seg.dir}
+		s.processSegment(&layers.TCP{Seq: seg.seq, FIN: seg.fin}, &seg.pkt, false)
+	}
+}
+
+func (stream *TCPStream) unclassified() {
+	conn := stream.conn
+	if isDebug {
+		debugf("No protocol detected for stream %s after %d bytes", conn.tuple, conn.detect.bytes)
+	}
+	unclassifiedStreams.Add(1)
+	conn.detect = nil
+}
@@ -0,0 +1,228 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/flows"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+
+	"github.com/tsg/gopacket/layers"
+)
+
+// names of the flow counters. The counters are published with the stats of
+// the direction they have been seen in. The handshake round trip time is
+// published for the whole flow.
+const (
+	retransmissionsCounter = "tcp.retransmissions"
+	outOfOrderCounter      = "tcp.out_of_order"
+	duplicateAcksCounter   = "tcp.duplicate_acks"
+	zeroWindowsCounter     = "tcp.zero_windows"
+	synPacketsCounter      = "tcp.syn_packets"
+	finPacketsCounter      = "tcp.fin_packets"
+	rstPacketsCounter      = "tcp.rst_packets"
+	handshakeRTTCounter    = "tcp.handshake_rtt"
+)
+
+const (
+	// connMetricsTimeout is the time the state of an idle connection is
+	// kept, if the connection is only followed for its flow metrics.
+	connMetricsTimeout = time.Minute
+
+	// maxMetricsConnections is the number of connections followed only for
+	// their flow metrics, per TCP instance.
+	maxMetricsConnections = 65536
+
+	// defaultOutOfOrderThreshold is the time a segment filling a hole may
+	// arrive after the following data to count as out-of-order instead of
+	// as retransmission, if the round trip time of the connection is unknown.
+	defaultOutOfOrderThreshold = 3 * time.Millisecond
+)
+
+// untrackedPackets counts the packets of connections not followed, as too
+// many connections are followed for their metrics only.
+var untrackedPackets = monitoring.NewInt(nil, "tcp.flow_metrics.untracked_packets")
+
+// flowMetrics collects the TCP performance counters of flows. The state
+// required to detect retransmissions, duplicate ACKs and the handshake round
+// trip time is kept in the TCPConnection, which is created for all TCP
+// connections, whether their protocol is analyzed or not, up to
+// maxMetricsConnections connections not analyzed.
+type flowMetrics struct {
+	flows *flows.Flows
+
+	retransmissions flowCounter
+	outOfOrder      flowCounter
+	duplicateAcks   flowCounter
+	zeroWindows     flowCounter
+	synPackets      flowCounter
+	finPackets      flowCounter
+	rstPackets      flowCounter
+	handshakeRTT    flowCounter
+}
+
+// flowCounter is implemented by flows.Uint.
+type flowCounter interface {
+	Add(f *flows.Flow, delta uint64)
+	Set(f *flows.Flow, value uint64)
+}
+
+// connMetrics is the state of a connection, indexed by the direction of
+// the packets like TCPStream.dir.
+type connMetrics struct {
+	dir [2]dirMetrics
+
+	// handshake state
+	synTS      time.Time
+	synDir     uint8
+	synAckSeen bool
+	rtt        time.Duration
+}
+
+type dirMetrics struct {
+	seqValid bool
+	nextSeq  uint32    // sequence number following the highest segment seen
+	lastTS   time.Time // time nextSeq has been advanced last
+
+	ackValid bool
+	lastAck  uint32
+	lastWin  uint16
+}
+
+func newFlowMetrics(f *flows.Flows) (*flowMetrics, error) {
+	m := &flowMetrics{flows: f}
+	for _, c := range []struct {
+		counter *flowCounter
+		name    string
+	}{
+		{&m.retransmissions, retransmissionsCounter},
+		{&m.outOfOrder, outOfOrderCounter},
+		{&m.duplicateAcks, duplicateAcksCounter},
+		{&m.zeroWindows, zeroWindowsCounter},
+		{&m.synPackets, synPacketsCounter},
+		{&m.finPackets, finPacketsCounter},
+		{&m.rstPackets, rstPacketsCounter},
+		{&m.handshakeRTT, handshakeRTTCounter},
+	} {
+		counter, err := f.NewUint(c.name)
+		if err != nil {
+			return nil, err
+		}
+		*c.counter = counter
+	}
+	return m, nil
+}
+
+// onPacket updates the counters of the flow of a packet. The flow ID must
+// be complete, as the flow is looked up.
+//
+// The counters are not updated atomically, as the flows are only written to
+// by the goroutine processing the packets of the flow, under the read lock
+// of the flows. In fanout mode, both directions of a flow are hashed to the
+// same worker. The flows are reported under the write lock.
+func (m *flowMetrics) onPacket(id *flows.FlowID, stream TCPStream, hdr *layers.TCP, pkt *protos.Packet) {
+	conn, dir := &stream.conn.metrics, stream.dir
+	flow := m.flows.Get(id)
+	d, peer := &conn.dir[dir], &conn.dir[1-dir]
+
+	if hdr.SYN {
+		m.synPackets.Add(flow, 1)
+	}
+	if hdr.FIN {
+		m.finPackets.Add(flow, 1)
+	}
+	if hdr.RST {
+		m.rstPackets.Add(flow, 1)
+	}
+	if hdr.Window == 0 && !hdr.RST {
+		m.zeroWindows.Add(flow, 1)
+	}
+
+	m.handshake(conn, dir, flow, hdr, pkt.Ts)
+
+	// SYN and FIN take up a sequence number
+	segLen := uint32(len(pkt.Payload))
+	if hdr.SYN || hdr.FIN {
+		segLen++
+	}
+	if segLen > 0 {
+		m.sequence(conn, d, flow, hdr, segLen, pkt.Ts)
+	}
+
+	if hdr.ACK {
+		pureAck := len(pkt.Payload) == 0 && !hdr.SYN && !hdr.FIN && !hdr.RST
+		outstanding := peer.seqValid && tcpSeqBefore(hdr.Ack, peer.nextSeq)
+		if pureAck && outstanding && d.ackValid && hdr.Ack == d.lastAck && hdr.Window == d.lastWin {
+			m.duplicateAcks.Add(flow, 1)
+		}
+		d.ackValid, d.lastAck, d.lastWin = true, hdr.Ack, hdr.Window
+	}
+}
+
+// handshake measures the time from the SYN to the ACK of the SYN/ACK.
+func (m *flowMetrics) handshake(conn *connMetrics, dir uint8, flow *flows.Flow, hdr *layers.TCP, ts time.Time) {
+	switch {
+	case hdr.SYN && !hdr.ACK:
+		// the SYN/ACK answers the last SYN sent
+		if conn.synTS.IsZero() || (dir == conn.synDir && !conn.synAckSeen) {
+			conn.synTS, conn.synDir = ts, dir
+		}
+	case hdr.SYN && hdr.ACK:
+		conn.synAckSeen = !conn.synTS.IsZero() && dir != conn.synDir
+	case hdr.ACK && conn.synAckSeen && conn.rtt == 0 && dir == conn.synDir:
+		rtt := ts.Sub(conn.synTS)
+		if rtt <= 0 {
+			return
+		}
+		conn.rtt = rtt
+		m.handshakeRTT.Set(flow, uint64(rtt/time.Microsecond))
+	}
+}
+
+// sequence classifies segments starting before the data already seen. As
+// the segments seen are not recorded, a segment arriving shortly after the
+// data following it is counted as out-of-order, later ones as
+// retransmission. Keep-alives are not counted.
+func (m *flowMetrics) sequence(conn *connMetrics, d *dirMetrics, flow *flows.Flow, hdr *layers.TCP, segLen uint32, ts time.Time) {
+	end := hdr.Seq + segLen
+	if !d.seqValid {
+		d.seqValid, d.nextSeq, d.lastTS = true, end, ts
+		return
+	}
+
+	if tcpSeqBefore(hdr.Seq, d.nextSeq) {
+		keepAlive := segLen <= 1 && hdr.Seq == d.nextSeq-1 && !hdr.SYN && !hdr.FIN && !hdr.RST
+		if !keepAlive {
+			threshold := conn.rtt
+			if threshold == 0 {
+				threshold = defaultOutOfOrderThreshold
+			}
+			if ts.Sub(d.lastTS) < threshold {
+				m.outOfOrder.Add(flow, 1)
+			} else {
+				m.retransmissions.Add(flow, 1)
+			}
+		}
+	}
+
+	if tcpSeqBefore(d.nextSeq, end) {
+		d.nextSeq, d.lastTS = end, ts
+	}
+}
@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/flows"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+)
+
+type testCounter struct {
+	value uint64
+}
+
+func (c *testCounter) Add(_ *flows.Flow, delta uint64) { c.value += delta }
+func (c *testCounter) Set(_ *flows.Flow, value uint64) { c.value = value }
+
+type testMetrics struct {
+	retransmissions, outOfOrder, duplicateAcks, zeroWindows testCounter
+	synPackets, finPackets, rstPackets, handshakeRTT        testCounter
+}
+
+// newTestFlowMetrics replaces the flow counters with counters summing up
+// the values of both directions.
+func newTestFlowMetrics(t *testing.T) (*flowMetrics, *testMetrics) {
+	f, err := flows.NewFlows(func([]beat.Event) {}, procs.ProcessesWatcher{}, &config.Flows{})
+	require.NoError(t, err)
+
+	m, err := newFlowMetrics(f)
+	require.NoError(t, err)
+
+	tm := &testMetrics{}
+	m.retransmissions, m.outOfOrder = &tm.retransmissions, &tm.outOfOrder
+	m.duplicateAcks, m.zeroWindows = &tm.duplicateAcks, &tm.zeroWindows
+	m.synPackets, m.finPackets = &tm.synPackets, &tm.finPackets
+	m.rstPackets, m.handshakeRTT = &tm.rstPackets, &tm.handshakeRTT
+	return m, tm
+}
+
+func testFlowID(tuple *common.IPPortTuple) *flows.FlowID {
+	id := &flows.FlowID{}
+	id.Reset(nil)
+	id.AddIPv4(tuple.SrcIP, tuple.DstIP)
+	id.AddTCP(tuple.SrcPort, tuple.DstPort)
+	return id
+}
+
+func TestFlowMetrics(t *testing.T) {
+	m, counters := newTestFlowMetrics(t)
+	conn := &TCPConnection{}
+
+	client := common.NewIPPortTuple(4, net.ParseIP(ClientIP), 40000, net.ParseIP(ServerIP), ServerPort)
+	server := common.NewIPPortTuple(4, net.ParseIP(ServerIP), ServerPort, net.ParseIP(ClientIP), 40000)
+
+	start := time.Now()
+	for _, p := range []struct {
+		tuple   common.IPPortTuple
+		ms      int
+		hdr     layers.TCP
+		payload int
+	}{
+		{client, 0, layers.TCP{SYN: true, Seq: 100, Window: 1000}, 0},
+		{server, 10, layers.TCP{SYN: true, ACK: true, Seq: 500, Ack: 101, Window: 1000}, 0},
+		{client, 20, layers.TCP{ACK: true, Seq: 101, Ack: 501, Window: 1000}, 0},
+		{client, 21, layers.TCP{ACK: true, Seq: 101, Ack: 501, Window: 1000}, 10},
+		{client, 22, layers.TCP{ACK: true, Seq: 111, Ack: 501, Window: 1000}, 10},
+		{server, 30, layers.TCP{ACK: true, Seq: 501, Ack: 111, Window: 1000}, 0},
+		{server, 31, layers.TCP{ACK: true, Seq: 501, Ack: 111, Window: 1000}, 0},   // duplicate ACK
+		{client, 300, layers.TCP{ACK: true, Seq: 111, Ack: 501, Window: 1000}, 10}, // retransmission
+		{client, 301, layers.TCP{ACK: true, Seq: 131, Ack: 501, Window: 1000}, 10},
+		{client, 302, layers.TCP{ACK: true, Seq: 121, Ack: 501, Window: 1000}, 10}, // out-of-order
+		{server, 310, layers.TCP{ACK: true, Seq: 501, Ack: 141, Window: 0}, 0},     // zero window
+		{client, 900, layers.TCP{ACK: true, Seq: 140, Ack: 501, Window: 1000}, 1},  // keep-alive
+		{client, 910, layers.TCP{FIN: true, ACK: true, Seq: 141, Ack: 501, Window: 1000}, 0},
+		{server, 920, layers.TCP{RST: true, Seq: 501}, 0},
+	} {
+		tuple := p.tuple
+		pkt := &protos.Packet{
+			Ts:      start.Add(time.Duration(p.ms) * time.Millisecond),
+			Tuple:   tuple,
+			Payload: make([]byte, p.payload),
+		}
+		stream := TCPStream{conn: conn, dir: TCPDirectionOriginal}
+		if tuple.SrcPort == ServerPort {
+			stream.dir = TCPDirectionReverse
+		}
+		hdr := p.hdr
+		m.onPacket(testFlowID(&tuple), stream, &hdr, pkt)
+	}
+
+	assert.Equal(t, testMetrics{
+		retransmissions: testCounter{1},
+		outOfOrder:      testCounter{1},
+		duplicateAcks:   testCounter{1},
+		zeroWindows:     testCounter{1},
+		synPackets:      testCounter{2},
+		finPackets:      testCounter{1},
+		rstPackets:      testCounter{1},
+		handshakeRTT:    testCounter{20000},
+	}, *counters)
+}
+
+// Test that the counters are collected for connections of protocols not
+// being analyzed.
+func TestFlowMetricsUnknownProtocol(t *testing.T) {
+	tcp, err := NewTCP(protocols{}, config.TCPConfig{}, config.DetectionConfig{})
+	require.NoError(t, err)
+
+	f, err := flows.NewFlows(func([]beat.Event) {}, procs.ProcessesWatcher{}, &config.Flows{})
+	require.NoError(t, err)
+	require.NoError(t, tcp.EnableFlowMetrics(f))
+
+	syn, rtt := &testCounter{}, &testCounter{}
+	tcp.metrics.synPackets, tcp.metrics.handshakeRTT = syn, rtt
+
+	client := common.NewIPPortTuple(4, net.ParseIP(ClientIP), 40000, net.ParseIP(ServerIP), 1)
+	server := common.NewIPPortTuple(4, net.ParseIP(ServerIP), 1, net.ParseIP(ClientIP), 40000)
+	start := time.Now()
+	for _, p := range []struct {
+		tuple common.IPPortTuple
+		ms    int
+		hdr   layers.TCP
+	}{
+		{client, 0, layers.TCP{SYN: true, Seq: 100}},
+		{server, 5, layers.TCP{SYN: true, ACK: true, Seq: 500, Ack: 101}},
+		{client, 10, layers.TCP{ACK: true, Seq: 101, Ack: 501}},
+	} {
+		tuple, hdr := p.tuple, p.hdr
+		pkt := &protos.Packet{Ts: start.Add(time.Duration(p.ms) * time.Millisecond), Tuple: tuple}
+		tcp.Process(testFlowID(&tuple), &hdr, pkt)
+	}
+	assert.Equal(t, uint64(2), syn.value)
+	assert.Equal(t, uint64(10000), rtt.value)
+}
+
+func TestFlowMetricsConnectionLimit(t *testing.T) {
+	tcp, err := NewTCP(protocols{}, config.TCPConfig{}, config.DetectionConfig{})
+	require.NoError(t, err)
+
+	f, err := flows.NewFlows(func([]beat.Event) {}, procs.ProcessesWatcher{}, &config.Flows{})
+	require.NoError(t, err)
+	require.NoError(t, tcp.EnableFlowMetrics(f))
+
+	syn := &testCounter{}
+	tcp.metrics.synPackets = syn
+
+	connect := func(port uint16) *TCPConnection {
+		tuple := common.NewIPPortTuple(4, net.ParseIP(ClientIP), port, net.ParseIP(ServerIP), 1)
+		pkt := &protos.Packet{Ts: time.Now(), Tuple: tuple}
+		tcp.Process(testFlowID(&tuple), &layers.TCP{SYN: true, Seq: 100}, pkt)
+		return tcp.findStream(tuple.Hashable())
+	}
+
+	tcp.metricsConns = maxMetricsConnections - 1
+	first := connect(40000)
+	require.NotNil(t, first)
+	assert.True(t, first.metricsOnly)
+	assert.Equal(t, uint64(1), syn.value)
+
+	// the limit is reached, the connection is not followed
+	assert.Nil(t, connect(40001))
+	assert.Equal(t, uint64(1), syn.value)
+
+	// expired connections are no longer counted
+	tcp.removalListener(first.tuple.Hashable(), first)
+	assert.Equal(t, int64(maxMetricsConnections-1), tcp.metricsConns)
+	assert.NotNil(t, connect(40001))
+	assert.Equal(t, uint64(2), syn.value)
+}
@@ -0,0 +1,212 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"container/heap"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/packetbeat/protos"
+)
+
+const defaultReorderTimeout = time.Second
+
+// segmentBuffer holds the segments of one stream direction that arrived
+// ahead of the next expected sequence number. Segments are kept ordered
+// by sequence number, so the head of the buffer is always the next
+// candidate to be delivered once the hole in front of it is filled.
+type segmentBuffer struct {
+	segments []bufferedSegment
+	bytes    int
+
+	// timestamp of the oldest buffered segment. It's zero if the buffer is
+	// empty, or if the oldest segment has been removed and the timestamp
+	// must be looked up again.
+	oldestTs time.Time
+}
+
+type bufferedSegment struct {
+	seq uint32
+	fin bool
+	pkt protos.Packet
+}
+
+// insert copies the packet into the buffer. The packet payload and
+// addresses are owned by the sniffer and will be overwritten by the next
+// packet read, so they must not be referenced after Process returns.
+// insert returns the number of payload bytes added to the buffer.
+func (b *segmentBuffer) insert(seq uint32, fin bool, pkt *protos.Packet) int {
+	seg := bufferedSegment{seq: seq, fin: fin, pkt: copyPacket(pkt, pkt.Payload)}
+	if len(b.segments) == 0 {
+		b.oldestTs = seg.pkt.Ts
+	} else if !b.oldestTs.IsZero() && seg.pkt.Ts.Before(b.oldestTs) {
+		b.oldestTs = seg.pkt.Ts
+	}
+
+	i := len(b.segments)
+	for i > 0 && tcpSeqBefore(seq, b.segments[i-1].seq) {
+		i--
+	}
+
+	// Retransmission of an already buffered segment. Keep the longer one.
+	if i > 0 && b.segments[i-1].seq == seq {
+		old := &b.segments[i-1]
+		if len(seg.pkt.Payload) <= len(old.pkt.Payload) {
+			old.fin = old.fin || fin
+			return 0
+		}
+		added := len(seg.pkt.Payload) - len(old.pkt.Payload)
+		seg.fin = seg.fin || old.fin
+		if !old.pkt.Ts.After(b.oldestTs) {
+			b.oldestTs = time.Time{}
+		}
+		*old = seg
+		b.bytes += added
+		return added
+	}
+
+	b.segments = append(b.segments, bufferedSegment{})
+	copy(b.segments[i+1:], b.segments[i:])
+	b.segments[i] = seg
+	b.bytes += len(seg.pkt.Payload)
+	return len(seg.pkt.Payload)
+}
+
+// copyPacket returns a copy of the packet holding the given payload, which
+// is usually a suffix of the packet's payload.
+func copyPacket(pkt *protos.Packet, payload []byte) protos.Packet {
+	c := protos.Packet{
+		Ts:      pkt.Ts,
+		Tuple:   pkt.Tuple,
+		Payload: append([]byte(nil), payload...),
+		Capture: pkt.Capture,
+	}
+	c.Tuple.SrcIP = append(net.IP(nil), pkt.Tuple.SrcIP...)
+	c.Tuple.DstIP = append(net.IP(nil), pkt.Tuple.DstIP...)
+	return c
+}
+
+// head returns the segment with the lowest sequence number or nil if the
+// buffer is empty.
+func (b *segmentBuffer) head() *bufferedSegment {
+	if len(b.segments) == 0 {
+		return nil
+	}
+	return &b.segments[0]
+}
+
+// pop removes and returns the head of the buffer.
+func (b *segmentBuffer) pop() bufferedSegment {
+	seg := b.segments[0]
+	b.segments[0] = bufferedSegment{}
+	b.segments = b.segments[1:]
+	b.bytes -= len(seg.pkt.Payload)
+	if len(b.segments) == 0 {
+		b.segments = nil
+	}
+	if len(b.segments) == 0 || !seg.pkt.Ts.After(b.oldestTs) {
+		b.oldestTs = time.Time{}
+	}
+	return seg
+}
+
+func (b *segmentBuffer) empty() bool {
+	return len(b.segments) == 0
+}
+
+// oldest returns the timestamp of the oldest buffered segment, or the zero
+// time if the buffer is empty. The segments are only looked at if the
+// oldest one has been removed since the last call.
+func (b *segmentBuffer) oldest() time.Time {
+	if b.oldestTs.IsZero() && len(b.segments) > 0 {
+		b.oldestTs = b.segments[0].pkt.Ts
+		for i := range b.segments[1:] {
+			if ts := b.segments[i+1].pkt.Ts; ts.Before(b.oldestTs) {
+				b.oldestTs = ts
+			}
+		}
+	}
+	return b.oldestTs
+}
+
+// exceeds checks if the buffer is over its byte budget, or if any segment
+// has been waiting for longer than timeout.
+func (b *segmentBuffer) exceeds(maxBytes int, timeout time.Duration, now time.Time) bool {
+	if len(b.segments) == 0 {
+		return false
+	}
+	return b.bytes > maxBytes || now.Sub(b.oldest()) > timeout
+}
+
+// reorderQueue holds the connections with segments in their reorder
+// buffers, ordered by the timestamp of their oldest segment. Only the
+// connections at the head of the queue can be over their time budget.
+type reorderQueue []*TCPConnection
+
+func (q reorderQueue) Len() int { return len(q) }
+
+func (q reorderQueue) Less(i, j int) bool {
+	return q[i].oldestBuffered().Before(q[j].oldestBuffered())
+}
+
+func (q reorderQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].reorderIndex = i
+	q[j].reorderIndex = j
+}
+
+func (q *reorderQueue) Push(x interface{}) {
+	conn := x.(*TCPConnection)
+	conn.reorderIndex = len(*q)
+	conn.reorderQueued = true
+	*q = append(*q, conn)
+}
+
+func (q *reorderQueue) Pop() interface{} {
+	old := *q
+	conn := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	conn.reorderQueued = false
+	return conn
+}
+
+// update adds, moves or removes the connection after the segments of its
+// reorder buffers changed.
+func (q *reorderQueue) update(conn *TCPConnection) {
+	buffered := !conn.reorder[0].empty() || !conn.reorder[1].empty()
+	switch {
+	case conn.reorderQueued && buffered:
+		heap.Fix(q, conn.reorderIndex)
+	case conn.reorderQueued:
+		heap.Remove(q, conn.reorderIndex)
+	case buffered:
+		heap.Push(q, conn)
+	}
+}
+
+// oldestBuffered returns the timestamp of the oldest segment of both reorder
+// buffers of the connection.
+func (conn *TCPConnection) oldestBuffered() time.Time {
+	oldest := conn.reorder[0].oldest()
+	if ts := conn.reorder[1].oldest(); oldest.IsZero() || (!ts.IsZero() && ts.Before(oldest)) {
+		oldest = ts
+	}
+	return oldest
+}
@@ -20,12 +20,14 @@ package tcp
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/monitoring"
 
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 
@@ -45,10 +47,31 @@ type TCP struct {
 	portMap      map[uint16]protos.Protocol
 	protocols    protos.Protocols
 	expiredConns expirationQueue
+
+	// reorder buffer budget per stream direction. Buffering is disabled
+	// if reorderMaxBytes is 0.
+	reorderMaxBytes int
+	reorderTimeout  time.Duration
+
+	// connections with segments in their reorder buffers. Their timeouts
+	// are checked on every packet, not only on packets of the connection.
+	reordering reorderQueue
+
+	// performance counters of the flows, nil if flows are disabled
+	metrics *flowMetrics
+	// number of connections only followed for the flow metrics, updated
+	// atomically as connections are expired by the janitor
+	metricsConns int64
+
+	// protocol detection for streams not matching any port, if
+	// detectUnknownPorts is set, and for tunnels
+	detectors          []protocolDetector
+	detectionMaxBytes  int
+	detectUnknownPorts bool
 }
 
 type expiredConnection struct {
-	mod  protos.ExpirationAwareTCPPlugin
+	mod  protos.TCPPlugin
 	conn *TCPConnection
 }
 
@@ -63,6 +86,9 @@ type Processor interface {
 
 var (
 	droppedBecauseOfGaps = monitoring.NewInt(nil, "tcp.dropped_because_of_gaps")
+	reorderedBytes       = monitoring.NewInt(nil, "tcp.reordered_bytes")
+	recoveredBytes       = monitoring.NewInt(nil, "tcp.recovered_bytes")
+	lostBytes            = monitoring.NewInt(nil, "tcp.lost_bytes")
 )
 
 type seqCompare int
@@ -83,14 +109,17 @@ func (tcp *TCP) getID() uint32 {
 	return tcp.id
 }
 
+// decideProtocol determines the protocol based on the source and destination
+// ports. Ports shared by multiple protocols map to protos.UnknownProtocol,
+// leaving the decision to protocol detection.
 func (tcp *TCP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
 	protocol, exists := tcp.portMap[tuple.SrcPort]
-	if exists {
+	if exists && protocol != protos.UnknownProtocol {
 		return protocol
 	}
 
 	protocol, exists = tcp.portMap[tuple.DstPort]
-	if exists {
+	if exists && protocol != protos.UnknownProtocol {
 		return protocol
 	}
 
@@ -114,8 +143,26 @@ type TCPConnection struct {
 
 	lastSeq [2]uint32
 
+	// out-of-order segments waiting for missing data, per direction
+	reorder [2]segmentBuffer
+	// position in TCP.reordering, if queued
+	reorderIndex  int
+	reorderQueued bool
+
+	// set once a FIN has been seen in the direction
+	fin [2]bool
+
 	// protocols private data
 	data protos.ProtocolData
+
+	// segments buffered while detecting the protocol, nil if the protocol
+	// is known or detection has given up
+	detect *detection
+
+	// state of the flow metrics
+	metrics connMetrics
+	// set if the connection is only followed for the flow metrics
+	metricsOnly bool
 }
 
 type TCPStream struct {
@@ -130,6 +177,12 @@ func (conn *TCPConnection) String() string {
 
 func (stream *TCPStream) addPacket(pkt *protos.Packet, tcphdr *layers.TCP) {
 	conn := stream.conn
+	if conn.detect != nil {
+		// the connection has been upgraded to a protocol to detect
+		stream.detectProtocol(tcphdr, pkt)
+		return
+	}
+
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
 	if mod == nil {
 		if isDebug {
@@ -142,6 +195,15 @@ func (stream *TCPStream) addPacket(pkt *protos.Packet, tcphdr *layers.TCP) {
 
 	if len(pkt.Payload) > 0 {
 		conn.data = mod.Parse(pkt, &conn.tcptuple, stream.dir, conn.data)
+		if upgrade, ok := conn.data.(*protos.ProtocolUpgrade); ok {
+			end := tcphdr.Seq + uint32(len(pkt.Payload))
+			stream.switchProtocol(upgrade, pkt)
+			if tcphdr.FIN {
+				fin := &protos.Packet{Ts: pkt.Ts, Tuple: pkt.Tuple, Capture: pkt.Capture}
+				stream.addPacket(fin, &layers.TCP{Seq: end, FIN: true})
+			}
+			return
+		}
 	}
 
 	if tcphdr.FIN {
@@ -152,31 +214,209 @@ func (stream *TCPStream) addPacket(pkt *protos.Packet, tcphdr *layers.TCP) {
 func (stream *TCPStream) gapInStream(nbytes int) (drop bool) {
 	conn := stream.conn
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
+	if mod == nil {
+		return false
+	}
 	conn.data, drop = mod.GapInStream(&conn.tcptuple, stream.dir, nbytes, conn.data)
 	return drop
 }
 
+// onGap reports nbytes of missing data to the protocol analyzer and resets
+// the application layer state if the analyzer can not recover from the gap.
+func (stream *TCPStream) onGap(nbytes int) {
+	conn := stream.conn
+	lostBytes.Add(int64(nbytes))
+	drop := stream.gapInStream(nbytes)
+	if drop {
+		if isDebug {
+			debugf("Dropping connection state because of gap")
+		}
+		droppedBecauseOfGaps.Add(1)
+
+		// drop application layer connection state and
+		// update stream_id for app layer analysers using stream_id for lookups
+		conn.id = conn.tcp.getID()
+		conn.data = nil
+	}
+}
+
+// bufferSegment stores a segment that arrived ahead of the expected
+// sequence number. It returns false if reordering is disabled, in which
+// case the caller must handle the hole in the stream as a gap.
+func (stream *TCPStream) bufferSegment(pkt *protos.Packet, tcphdr *layers.TCP) bool {
+	conn := stream.conn
+	if conn.tcp.reorderMaxBytes <= 0 {
+		return false
+	}
+
+	if isDebug {
+		debugf("Buffering out-of-order segment. seq=%d len=%d stream.seq=%d",
+			tcphdr.Seq, len(pkt.Payload), conn.lastSeq[stream.dir])
+	}
+	added := conn.reorder[stream.dir].insert(tcphdr.Seq, tcphdr.FIN, pkt)
+	reorderedBytes.Add(int64(added))
+	conn.tcp.reordering.update(conn)
+	return true
+}
+
+// drainReorderBuffer delivers buffered segments for as long as they are
+// contiguous with the data already passed to the protocol analyzer. The
+// delivered bytes are counted as recovered only if the hole in front of
+// them has been filled, not if it has been skipped.
+func (stream *TCPStream) drainReorderBuffer(recovered bool) {
+	conn := stream.conn
+	buf := &conn.reorder[stream.dir]
+	defer conn.tcp.reordering.update(conn)
+	for seg := buf.head(); seg != nil; seg = buf.head() {
+		lastSeq := conn.lastSeq[stream.dir]
+		if tcpSeqBefore(lastSeq, seg.seq) {
+			// still missing data in front of the segment
+			return
+		}
+
+		next := buf.pop()
+		payload := next.pkt.Payload
+		if delta := lastSeq - next.seq; delta > 0 {
+			if int(delta) >= len(payload) {
+				payload = nil
+			} else {
+				payload = payload[delta:]
+			}
+		}
+		if len(payload) == 0 && !next.fin {
+			continue
+		}
+
+		if recovered {
+			recoveredBytes.Add(int64(len(payload)))
+		}
+		if len(payload) > 0 {
+			conn.lastSeq[stream.dir] = lastSeq + uint32(len(payload))
+		}
+		next.pkt.Payload = payload
+		stream.addPacket(&next.pkt, &layers.TCP{Seq: lastSeq, FIN: next.fin})
+	}
+}
+
+// flushReorderBuffer gives up waiting for missing data once the buffer is
+// over its byte or time budget.
+func (stream *TCPStream) flushReorderBuffer(now time.Time) {
+	conn := stream.conn
+	tcp := conn.tcp
+	buf := &conn.reorder[stream.dir]
+	for buf.exceeds(tcp.reorderMaxBytes, tcp.reorderTimeout, now) {
+		stream.skipHole()
+	}
+}
+
+// flushAll delivers all buffered segments of the stream, without waiting
+// for the missing data any longer.
+func (stream *TCPStream) flushAll() {
+	for !stream.conn.reorder[stream.dir].empty() {
+		stream.skipHole()
+	}
+}
+
+// skipHole reports the hole in front of the oldest buffered segment as a
+// gap, counting the missing bytes as lost, and delivers the buffered
+// segments following it.
+func (stream *TCPStream) skipHole() {
+	conn := stream.conn
+	seg := conn.reorder[stream.dir].head()
+	lastSeq := conn.lastSeq[stream.dir]
+	if tcpSeqBefore(lastSeq, seg.seq) {
+		gap := int(seg.seq - lastSeq)
+		if isDebug {
+			debugf("Skipping hole in reorder buffer. last_seq: %d, seq: %d, gap: %d",
+				lastSeq, seg.seq, gap)
+		}
+		stream.onGap(gap)
+		conn.lastSeq[stream.dir] = seg.seq
+	}
+	stream.drainReorderBuffer(false)
+}
+
+// flushReorderBuffers delivers the buffered segments of both directions.
+// It is used once no more data is expected on the connection.
+func (conn *TCPConnection) flushReorderBuffers() {
+	for dir := range conn.reorder {
+		stream := TCPStream{conn: conn, dir: uint8(dir)}
+		stream.flushAll()
+	}
+}
+
+// checkReorderTimeouts flushes the reorder buffers of all connections
+// that are over their time budget. The connections are ordered by the
+// timestamp of their oldest segment, so only the head of the queue is
+// checked once the connections over their budget have been flushed.
+func (tcp *TCP) checkReorderTimeouts(now time.Time) {
+	for len(tcp.reordering) > 0 {
+		conn := tcp.reordering[0]
+		if now.Sub(conn.oldestBuffered()) <= tcp.reorderTimeout {
+			return
+		}
+		for dir := range conn.reorder {
+			stream := TCPStream{conn: conn, dir: uint8(dir)}
+			stream.flushReorderBuffer(now)
+		}
+	}
+}
+
 func (tcp *TCP) Process(id *flows.FlowID, tcphdr *layers.TCP, pkt *protos.Packet) {
 	// This Recover should catch all exceptions in
 	// protocol modules.
 	defer logp.Recover("Process tcp exception")
 
 	tcp.expiredConns.notifyAll()
+	if len(tcp.reordering) > 0 {
+		tcp.checkReorderTimeouts(pkt.Ts)
+	}
 
 	stream, created := tcp.getStream(pkt)
+	if id != nil && stream.conn != nil {
+		if stream.conn.id != 0 {
+			id.AddConnectionID(uint64(stream.conn.id))
+		}
+		if tcp.metrics != nil {
+			// the flow ID is complete once the connection ID has been added
+			tcp.metrics.onPacket(id, stream, tcphdr, pkt)
+		}
+	}
 	if stream.conn == nil {
 		return
 	}
 
 	conn := stream.conn
-	if id != nil {
-		id.AddConnectionID(uint64(conn.id))
-	}
 
 	if isDebug {
 		debugf("tcp flow id: %p", id)
 	}
 
+	if conn.detect != nil {
+		stream.detectProtocol(tcphdr, pkt)
+		return
+	}
+	if conn.protocol == protos.UnknownProtocol {
+		// no protocol has been detected
+		return
+	}
+
+	stream.processSegment(tcphdr, pkt, created)
+
+	if tcphdr.FIN {
+		conn.fin[stream.dir] = true
+	}
+	if conn.fin[0] && conn.fin[1] {
+		// Both sides closed the connection. Missing data will not show
+		// up anymore.
+		conn.flushReorderBuffers()
+	}
+}
+
+// processSegment passes the segment to the protocol analyzer, handling
+// retransmissions, overlapping segments and gaps.
+func (stream *TCPStream) processSegment(tcphdr *layers.TCP, pkt *protos.Packet, created bool) {
+	conn := stream.conn
 	if len(pkt.Payload) == 0 && !tcphdr.FIN {
 		// return early if packet is not interesting. Still need to find/create
 		// stream first in order to update the TCP stream timer
@@ -206,20 +446,14 @@ func (tcp *TCP) Process(id *flows.FlowID, tcphdr *layers.TCP, pkt *protos.Packet
 				break
 			}
 
+			if stream.bufferSegment(pkt, tcphdr) {
+				stream.flushReorderBuffer(pkt.Ts)
+				return
+			}
+
 			gap := int(tcpStartSeq - lastSeq)
 			debugf("Gap in tcp stream. last_seq: %d, seq: %d, gap: %d", lastSeq, tcpStartSeq, gap)
-			drop := stream.gapInStream(gap)
-			if drop {
-				if isDebug {
-					debugf("Dropping connection state because of gap")
-				}
-				droppedBecauseOfGaps.Add(1)
-
-				// drop application layer connection state and
-				// update stream_id for app layer analysers using stream_id for lookups
-				conn.id = tcp.getID()
-				conn.data = nil
-			}
+			stream.onGap(gap)
 
 		case seqGT:
 			// lastSeq > tcpStartSeq => overlapping TCP segment detected. shrink packet
@@ -233,10 +467,17 @@ func (tcp *TCP) Process(id *flows.FlowID, tcphdr *layers.TCP, pkt *protos.Packet
 			pkt.Payload = pkt.Payload[delta:]
 			tcphdr.Seq += delta
 		}
+	} else if len(pkt.Payload) == 0 && !conn.reorder[stream.dir].empty() {
+		// FIN must not overtake the data still waiting in the reorder buffer
+		stream.bufferSegment(pkt, tcphdr)
+		return
 	}
 
 	conn.lastSeq[stream.dir] = tcpSeq
 	stream.addPacket(pkt, tcphdr)
+	if !conn.reorder[stream.dir].empty() {
+		stream.drainReorderBuffer(true)
+	}
 }
 
 func (tcp *TCP) getStream(pkt *protos.Packet) (stream TCPStream, created bool) {
@@ -249,9 +490,12 @@ func (tcp *TCP) getStream(pkt *protos.Packet) (stream TCPStream, created bool) {
 	}
 
 	protocol := tcp.decideProtocol(&pkt.Tuple)
+	var detect *detection
 	if protocol == protos.UnknownProtocol {
-		// don't follow
-		return TCPStream{}, false
+		if !tcp.detectUnknownPorts || len(tcp.detectors) == 0 {
+			return tcp.followForMetrics(pkt)
+		}
+		detect = &detection{key: pkt.Tuple.Hashable()}
 	}
 
 	var timeout time.Duration
@@ -271,12 +515,37 @@ func (tcp *TCP) getStream(pkt *protos.Packet) (stream TCPStream, created bool) {
 		id:       tcp.getID(),
 		tuple:    &pkt.Tuple,
 		protocol: protocol,
-		tcp:      tcp}
+		tcp:      tcp,
+		detect:   detect}
 	conn.tcptuple = common.TCPTupleFromIPPort(conn.tuple, conn.id)
 	tcp.streams.PutWithTimeout(pkt.Tuple.Hashable(), conn, timeout)
 	return TCPStream{conn: conn, dir: TCPDirectionOriginal}, true
 }
 
+// followForMetrics creates the connection of a protocol not being analyzed,
+// if the flow metrics are collected. The connection has no ID, so that the
+// IDs of its flows are not changed. At most maxMetricsConnections are
+// followed, the packets of other connections are not counted.
+func (tcp *TCP) followForMetrics(pkt *protos.Packet) (stream TCPStream, created bool) {
+	if tcp.metrics == nil {
+		// don't follow
+		return TCPStream{}, false
+	}
+	if atomic.LoadInt64(&tcp.metricsConns) >= maxMetricsConnections {
+		untrackedPackets.Add(1)
+		return TCPStream{}, false
+	}
+	atomic.AddInt64(&tcp.metricsConns, 1)
+
+	conn := &TCPConnection{
+		tuple:       &pkt.Tuple,
+		protocol:    protos.UnknownProtocol,
+		tcp:         tcp,
+		metricsOnly: true}
+	tcp.streams.PutWithTimeout(pkt.Tuple.Hashable(), conn, connMetricsTimeout)
+	return TCPStream{conn: conn, dir: TCPDirectionOriginal}, true
+}
+
 func tcpSeqCompare(seq1, seq2 uint32) seqCompare {
 	i := int32(seq1 - seq2)
 	switch {
@@ -297,9 +566,17 @@ func tcpSeqBeforeEq(seq1 uint32, seq2 uint32) bool {
 	return int32(seq1-seq2) <= 0
 }
 
-func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin) (map[uint16]protos.Protocol, error) {
+// buildPortsMap creates a mapping of port numbers to protocol identifiers.
+// With detection enabled, ports shared by protocols providing a detector are
+// mapped to protos.UnknownProtocol. Any other duplicate port is an error.
+func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin, detection bool) (map[uint16]protos.Protocol, error) {
 	var res = map[uint16]protos.Protocol{}
 
+	detectable := func(proto protos.Protocol) bool {
+		_, ok := plugins[proto].(protos.TCPDetector)
+		return detection && ok
+	}
+
 	for proto, protoPlugin := range plugins {
 		for _, port := range protoPlugin.GetPorts() {
 			oldProto, exists := res[uint16(port)]
@@ -307,6 +584,10 @@ func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin) (map[uint16]pro
 				if oldProto == proto {
 					continue
 				}
+				if detectable(proto) && (oldProto == protos.UnknownProtocol || detectable(oldProto)) {
+					res[uint16(port)] = protos.UnknownProtocol
+					continue
+				}
 				return nil, fmt.Errorf("Duplicate port (%d) exists in %s and %s protocols",
 					port, oldProto, proto)
 			}
@@ -318,17 +599,28 @@ func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin) (map[uint16]pro
 }
 
 // Creates and returns a new Tcp.
-func NewTCP(p protos.Protocols) (*TCP, error) {
+func NewTCP(p protos.Protocols, cfg config.TCPConfig, detection config.DetectionConfig) (*TCP, error) {
 	isDebug = logp.IsDebug("tcp")
 
-	portMap, err := buildPortsMap(p.GetAllTCP())
+	portMap, err := buildPortsMap(p.GetAllTCP(), detection.Enabled)
 	if err != nil {
 		return nil, err
 	}
 
 	tcp := &TCP{
-		protocols: p,
-		portMap:   portMap,
+		protocols:       p,
+		portMap:         portMap,
+		reorderMaxBytes: cfg.ReorderBuffer.MaxBytes,
+		reorderTimeout:  cfg.ReorderBuffer.Timeout,
+	}
+	if tcp.reorderTimeout <= 0 {
+		tcp.reorderTimeout = defaultReorderTimeout
+	}
+	tcp.detectors = newDetectors(p.GetAllTCP())
+	tcp.detectUnknownPorts = detection.Enabled
+	tcp.detectionMaxBytes = detection.MaxBytes
+	if tcp.detectionMaxBytes <= 0 {
+		tcp.detectionMaxBytes = defaultDetectionMaxBytes
 	}
 	tcp.streams = common.NewCacheWithRemovalListener(
 		protos.DefaultTransactionExpiration,
@@ -343,22 +635,41 @@ func NewTCP(p protos.Protocols) (*TCP, error) {
 	return tcp, nil
 }
 
+// EnableFlowMetrics collects TCP performance counters, like retransmissions
+// and the handshake round trip time, for all TCP flows.
+func (tcp *TCP) EnableFlowMetrics(f *flows.Flows) error {
+	metrics, err := newFlowMetrics(f)
+	if err != nil {
+		return err
+	}
+	tcp.metrics = metrics
+	return nil
+}
+
 func (tcp *TCP) removalListener(_ common.Key, value common.Value) {
 	conn := value.(*TCPConnection)
+	if conn.detect != nil {
+		unclassifiedStreams.Add(1)
+	}
+	if conn.metricsOnly {
+		atomic.AddInt64(&tcp.metricsConns, -1)
+	}
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
 	if mod != nil {
-		awareMod, ok := mod.(protos.ExpirationAwareTCPPlugin)
-		if ok {
-			tcp.expiredConns.add(awareMod, conn)
-		}
+		// The reorder buffers are flushed and the module is notified by
+		// the processing goroutine, as the module is not thread safe.
+		tcp.expiredConns.add(mod, conn)
 	}
 }
 
 func (ec *expiredConnection) notify() {
-	ec.mod.Expired(&ec.conn.tcptuple, ec.conn.data)
+	ec.conn.flushReorderBuffers()
+	if awareMod, ok := ec.mod.(protos.ExpirationAwareTCPPlugin); ok {
+		awareMod.Expired(&ec.conn.tcptuple, ec.conn.data)
+	}
 }
 
-func (eq *expirationQueue) add(mod protos.ExpirationAwareTCPPlugin, conn *TCPConnection) {
+func (eq *expirationQueue) add(mod protos.TCPPlugin, conn *TCPConnection) {
 	eq.mutex.Lock()
 	eq.conns = append(eq.conns, expiredConnection{
 		mod:  mod,
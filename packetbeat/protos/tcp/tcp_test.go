@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/procs"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 
@@ -107,6 +108,29 @@ func (proto TestProtocol) ConnectionTimeout() time.Duration {
 	return 0
 }
 
+// detectingProtocol is a TestProtocol implementing protos.TCPDetector.
+type detectingProtocol struct {
+	TestProtocol
+	detect func([]byte) protos.Detection
+}
+
+func (proto *detectingProtocol) DetectTCP(data []byte) protos.Detection {
+	return proto.detect(data)
+}
+
+// detectPrefix returns a detector matching data starting with prefix.
+func detectPrefix(prefix string) func([]byte) protos.Detection {
+	return func(data []byte) protos.Detection {
+		switch {
+		case len(data) < len(prefix) && prefix[:len(data)] == string(data):
+			return protos.DetectionNeedMore
+		case len(data) >= len(prefix) && string(data[:len(prefix)]) == prefix:
+			return protos.DetectionMatch
+		}
+		return protos.DetectionMismatch
+	}
+}
+
 func Test_configToPortsMap(t *testing.T) {
 	type configTest struct {
 		Input  map[protos.Protocol]protos.TCPPlugin
@@ -153,7 +177,7 @@ func Test_configToPortsMap(t *testing.T) {
 	}
 
 	for _, test := range configTests {
-		output, err := buildPortsMap(test.Input)
+		output, err := buildPortsMap(test.Input, false)
 		assert.NoError(t, err)
 		assert.Equal(t, test.Output, output)
 	}
@@ -178,12 +202,36 @@ func Test_configToPortsMap_negative(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := buildPortsMap(test.Input)
+		_, err := buildPortsMap(test.Input, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), test.Err)
 	}
 }
 
+func Test_configToPortsMap_detection(t *testing.T) {
+	plugins := map[protos.Protocol]protos.TCPPlugin{
+		httpProtocol:  &detectingProtocol{TestProtocol: TestProtocol{Ports: []int{80, 8080}}},
+		redisProtocol: &detectingProtocol{TestProtocol: TestProtocol{Ports: []int{6379, 8080}}},
+	}
+
+	_, err := buildPortsMap(plugins, false)
+	assert.Error(t, err)
+
+	// shared ports are left to detection
+	output, err := buildPortsMap(plugins, true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint16]protos.Protocol{
+		80:   httpProtocol,
+		8080: protos.UnknownProtocol,
+		6379: redisProtocol,
+	}, output)
+
+	// unless a protocol can not be detected
+	plugins[mysqlProtocol] = &TestProtocol{Ports: []int{8080}}
+	_, err = buildPortsMap(plugins, true)
+	assert.Error(t, err)
+}
+
 // Mock protos.Protocols used for testing the tcp package.
 type protocols struct {
 	tcp map[protos.Protocol]protos.TCPPlugin
@@ -296,7 +344,7 @@ func TestTCSeqPayload(t *testing.T) {
 					parse: makeCollectPayload(&state, true),
 				},
 			},
-		})
+		}, config.TCPConfig{}, config.DetectionConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -324,6 +372,218 @@ func TestTCSeqPayload(t *testing.T) {
 	}
 }
 
+func TestTCPReorderBuffer(t *testing.T) {
+	type segment struct {
+		seq     uint32
+		payload []byte
+		fin     bool
+		ts      time.Duration
+		reverse bool // sent by the server
+		other   bool // sent on another connection
+	}
+
+	tests := []struct {
+		name              string
+		maxBytes          int
+		segments          []segment
+		expire            bool
+		expectedGaps      int
+		expectedFins      int
+		expectedRecovered int64
+		expectedState     []byte
+	}{
+		{"Segment filling the hole",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 3, payload: []byte{3, 4}},
+			},
+			false,
+			0, 0, 2,
+			[]byte{1, 2, 3, 4, 5, 6},
+		},
+		{"Multiple holes",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 7, payload: []byte{7, 8}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 3, payload: []byte{3, 4}},
+				{seq: 9, payload: []byte{9}},
+			},
+			false,
+			0, 0, 4,
+			[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{"Overlapping buffered segments",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 4, payload: []byte{4, 5}},
+				{seq: 4, payload: []byte{4, 5, 6}},
+				{seq: 5, payload: []byte{5, 6, 7}},
+				{seq: 3, payload: []byte{3}},
+			},
+			false,
+			0, 0, 4,
+			[]byte{1, 2, 3, 4, 5, 6, 7},
+		},
+		{"FIN waits for missing data",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 7, fin: true},
+				{seq: 3, payload: []byte{3, 4}},
+			},
+			false,
+			0, 1, 2,
+			[]byte{1, 2, 3, 4, 5, 6},
+		},
+		{"Byte budget exhausted",
+			3,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 7, payload: []byte{7, 8}},
+				{seq: 3, payload: []byte{3, 4}},
+			},
+			false,
+			2, 0, 0,
+			[]byte{5, 6, 7, 8},
+		},
+		{"Time budget exhausted",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 7, payload: []byte{7, 8}, ts: 2 * time.Second},
+			},
+			false,
+			2, 0, 0,
+			[]byte{5, 6, 7, 8},
+		},
+		{"Time budget exhausted on packet of other connection",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 1, other: true, ts: 2 * time.Second},
+			},
+			false,
+			2, 0, 0,
+			[]byte{5, 6},
+		},
+		{"Time budget of the segments left once a hole is filled",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 9, payload: []byte{9}, ts: 800 * time.Millisecond},
+				{seq: 3, payload: []byte{3, 4}, ts: 900 * time.Millisecond},
+				{seq: 1, other: true, ts: 1500 * time.Millisecond},
+				{seq: 7, payload: []byte{7, 8}, ts: 1600 * time.Millisecond},
+			},
+			false,
+			0, 0, 3,
+			[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{"Connection closed by both sides",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 7, fin: true},
+				{seq: 1, fin: true, reverse: true},
+			},
+			false,
+			2, 2, 0,
+			[]byte{5, 6},
+		},
+		{"Connection expired",
+			100,
+			[]segment{
+				{seq: 1, payload: []byte{1, 2}},
+				{seq: 5, payload: []byte{5, 6}},
+				{seq: 9, payload: []byte{9}},
+			},
+			true,
+			4, 0, 0,
+			[]byte{9},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gap, fins := 0, 0
+			var state []byte
+			tcp, err := NewTCP(protocols{
+				tcp: map[protos.Protocol]protos.TCPPlugin{
+					httpProtocol: &TestProtocol{
+						Ports: []int{ServerPort},
+						gap:   makeCountGaps(nil, &gap),
+						parse: makeCollectPayload(&state, true),
+						onFin: func(_ *common.TCPTuple, _ uint8, p protos.ProtocolData) protos.ProtocolData {
+							fins++
+							return p
+						},
+					},
+				},
+			}, config.TCPConfig{
+				ReorderBuffer: config.ReorderBufferConfig{
+					MaxBytes: test.maxBytes,
+					Timeout:  time.Second,
+				},
+			}, config.DetectionConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			addr := common.NewIPPortTuple(4,
+				net.ParseIP(ServerIP), ServerPort,
+				net.ParseIP(ClientIP), uint16(rand.Intn(65535)))
+			reverse := common.NewIPPortTuple(4,
+				addr.DstIP, addr.DstPort, addr.SrcIP, addr.SrcPort)
+			other := common.NewIPPortTuple(4,
+				net.ParseIP(ServerIP), ServerPort,
+				net.ParseIP(ClientIP), addr.DstPort+1)
+
+			recovered := recoveredBytes.Get()
+			lost := lostBytes.Get()
+
+			start := time.Now()
+			for _, segment := range test.segments {
+				hdr := &layers.TCP{Seq: segment.seq, FIN: segment.fin}
+				pkt := &protos.Packet{
+					Ts:      start.Add(segment.ts),
+					Tuple:   addr,
+					Payload: segment.payload,
+				}
+				if segment.reverse {
+					pkt.Tuple = reverse
+				}
+				if segment.other {
+					pkt.Tuple = other
+				}
+				tcp.Process(nil, hdr, pkt)
+			}
+			if test.expire {
+				for k, v := range tcp.streams.Entries() {
+					tcp.removalListener(k, v)
+				}
+				tcp.expiredConns.notifyAll()
+			}
+
+			assert.Equal(t, test.expectedGaps, gap)
+			assert.Equal(t, test.expectedFins, fins)
+			assert.Equal(t, test.expectedRecovered, recoveredBytes.Get()-recovered)
+			assert.Equal(t, int64(test.expectedGaps), lostBytes.Get()-lost)
+			assert.Equal(t, test.expectedState, state)
+		})
+	}
+}
+
 // Benchmark that runs with parallelism to help find concurrency related
 // issues. To run with parallelism, the 'go test' cpu flag must be set
 // greater than 1, otherwise it just runs concurrently but not in parallel.
@@ -332,7 +592,7 @@ func BenchmarkParallelProcess(b *testing.B) {
 	p := protocols{}
 	p.tcp = make(map[protos.Protocol]protos.TCPPlugin)
 	p.tcp[1] = &TestProtocol{Ports: []int{ServerPort}}
-	tcp, _ := NewTCP(p)
+	tcp, _ := NewTCP(p, config.TCPConfig{}, config.DetectionConfig{})
 
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
@@ -387,3 +647,165 @@ func makeCollectPayload(
 		return *state
 	}
 }
+
+func TestTCPProtocolDetection(t *testing.T) {
+	var state []byte
+	var dirs []uint8
+	tcp, err := NewTCP(protocols{
+		tcp: map[protos.Protocol]protos.TCPPlugin{
+			redisProtocol: &detectingProtocol{
+				TestProtocol: TestProtocol{
+					Ports: []int{6379},
+					parse: func(pkt *protos.Packet, _ *common.TCPTuple, dir uint8, priv protos.ProtocolData) protos.ProtocolData {
+						state = append(state, pkt.Payload...)
+						dirs = append(dirs, dir)
+						return priv
+					},
+				},
+				detect: detectPrefix("*1\r\n"),
+			},
+		},
+	}, config.TCPConfig{}, config.DetectionConfig{Enabled: true, MaxBytes: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTuples := func(port uint16) (client, server common.IPPortTuple) {
+		client = common.NewIPPortTuple(4,
+			net.ParseIP(ClientIP), port,
+			net.ParseIP(ServerIP), 7000)
+		server = common.NewIPPortTuple(4,
+			net.ParseIP(ServerIP), 7000,
+			net.ParseIP(ClientIP), port)
+		return client, server
+	}
+	process := func(tuple common.IPPortTuple, seq uint32, payload string) {
+		tcp.Process(nil, &layers.TCP{Seq: seq}, &protos.Packet{
+			Ts:      time.Now(),
+			Tuple:   tuple,
+			Payload: []byte(payload),
+		})
+	}
+
+	// the segments are held back until the protocol is detected
+	client, server := newTuples(40000)
+	process(client, 1, "*1")
+	process(client, 1, "*1") // retransmission
+	assert.Empty(t, state)
+	process(client, 3, "\r\n$4\r\n")
+	assert.Equal(t, "*1\r\n$4\r\n", string(state))
+	process(client, 9, "PING\r\n")
+	process(server, 100, "+PONG\r\n")
+	assert.Equal(t, "*1\r\n$4\r\nPING\r\n+PONG\r\n", string(state))
+	assert.Equal(t, []uint8{
+		TCPDirectionOriginal, TCPDirectionOriginal, TCPDirectionOriginal, TCPDirectionReverse,
+	}, dirs)
+
+	// streams not matching any detector are ignored
+	unclassified := unclassifiedStreams.Get()
+	state = nil
+	client, server = newTuples(40001)
+	process(server, 1, "SSH-2\r\n")
+	assert.Equal(t, unclassified, unclassifiedStreams.Get())
+	process(client, 1, "SSH-2\r\n")
+	assert.Equal(t, unclassified+1, unclassifiedStreams.Get())
+	process(client, 8, "*1\r\n")
+	assert.Empty(t, state)
+
+	// or exceeding the byte budget
+	client, server = newTuples(40002)
+	process(client, 1, "*1")
+	process(server, 1, "*1")
+	process(client, 3, "\r")
+	assert.Equal(t, unclassified+1, unclassifiedStreams.Get())
+	process(server, 3, "\r\r\r")
+	assert.Equal(t, unclassified+2, unclassifiedStreams.Get())
+	assert.Empty(t, state)
+}
+
+func TestTCPProtocolUpgrade(t *testing.T) {
+	var upgraded []string
+	var upgradeTo protos.Protocol
+	tcp, err := NewTCP(protocols{
+		tcp: map[protos.Protocol]protos.TCPPlugin{
+			httpProtocol: &TestProtocol{
+				Ports: []int{ServerPort},
+				parse: func(pkt *protos.Packet, _ *common.TCPTuple, dir uint8, priv protos.ProtocolData) protos.ProtocolData {
+					// the data after the first line belongs to the new protocol
+					for i, c := range pkt.Payload {
+						if c == '\n' {
+							upgrade := &protos.ProtocolUpgrade{Protocol: upgradeTo, Data: "handshake"}
+							upgrade.Pending[dir] = pkt.Payload[i+1:]
+							return upgrade
+						}
+					}
+					return priv
+				},
+			},
+			redisProtocol: &detectingProtocol{
+				TestProtocol: TestProtocol{
+					parse: func(pkt *protos.Packet, _ *common.TCPTuple, dir uint8, priv protos.ProtocolData) protos.ProtocolData {
+						if data, ok := priv.(string); ok {
+							upgraded = append(upgraded, data)
+						}
+						upgraded = append(upgraded, string(pkt.Payload))
+						return nil
+					},
+					onFin: func(_ *common.TCPTuple, _ uint8, priv protos.ProtocolData) protos.ProtocolData {
+						upgraded = append(upgraded, "FIN")
+						return priv
+					},
+				},
+				detect: detectPrefix("*1\r\n"),
+			},
+		},
+	}, config.TCPConfig{}, config.DetectionConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTuples := func(port uint16) (client, server common.IPPortTuple) {
+		client = common.NewIPPortTuple(4,
+			net.ParseIP(ClientIP), port,
+			net.ParseIP(ServerIP), ServerPort)
+		server = common.NewIPPortTuple(4,
+			net.ParseIP(ServerIP), ServerPort,
+			net.ParseIP(ClientIP), port)
+		return client, server
+	}
+	process := func(tuple common.IPPortTuple, seq uint32, fin bool, payload string) {
+		tcp.Process(nil, &layers.TCP{Seq: seq, FIN: fin}, &protos.Packet{
+			Ts:      time.Now(),
+			Tuple:   tuple,
+			Payload: []byte(payload),
+		})
+	}
+
+	// the new protocol is passed the initial data and the pending data, then
+	// the next segments
+	upgradeTo = redisProtocol
+	client, server := newTuples(40000)
+	process(client, 1, false, "UPGRADE\n*1\r\n")
+	process(client, 13, false, "$4\r\n")
+	process(server, 1, true, "+OK\r\n")
+	assert.Equal(t, []string{"handshake", "*1\r\n", "$4\r\n", "+OK\r\n", "FIN"}, upgraded)
+
+	// unknown protocols are detected, even if detection is disabled for
+	// unknown ports
+	upgradeTo = protos.UnknownProtocol
+	upgraded = nil
+	client, server = newTuples(40001)
+	process(client, 1, false, "CONNECT\n*1")
+	assert.Empty(t, upgraded)
+	process(client, 11, false, "\r\n")
+	process(server, 1, false, "+OK\r\n")
+	assert.Equal(t, []string{"*1", "\r\n", "+OK\r\n"}, upgraded)
+
+	// connections upgraded to protocols not enabled are ignored
+	upgradeTo = mysqlProtocol
+	upgraded = nil
+	client, server = newTuples(40002)
+	process(client, 1, false, "UPGRADE\n*1\r\n")
+	process(server, 1, false, "+OK\r\n")
+	assert.Empty(t, upgraded)
+}
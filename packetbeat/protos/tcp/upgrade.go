@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+
+	"github.com/tsg/gopacket/layers"
+)
+
+var upgradedStreams = monitoring.NewInt(nil, "tcp.upgraded_streams")
+
+// switchProtocol hands the rest of the connection over to the protocol an
+// analyzer has upgraded the connection to, starting with the data the
+// analyzer has not consumed. Connections upgraded to an unknown protocol
+// are classified by the protocol detectors, whether detection is enabled for
+// unknown ports or not. Connections upgraded to a protocol that is not
+// enabled are ignored.
+func (stream *TCPStream) switchProtocol(upgrade *protos.ProtocolUpgrade, pkt *protos.Packet) {
+	conn := stream.conn
+	tcp := conn.tcp
+	if isDebug {
+		debugf("Connection %s upgraded from %s to %s", conn.tuple, conn.protocol, upgrade.Protocol)
+	}
+	upgradedStreams.Add(1)
+
+	conn.protocol = upgrade.Protocol
+	conn.data = nil
+	if upgrade.Protocol == protos.UnknownProtocol {
+		conn.detect = &detection{key: conn.tuple.Hashable()}
+	} else if mod := tcp.protocols.GetTCP(upgrade.Protocol); mod != nil {
+		conn.data = upgrade.Data
+		tcp.streams.PutWithTimeout(conn.tuple.Hashable(), conn, mod.ConnectionTimeout())
+	} else {
+		conn.protocol = protos.UnknownProtocol
+		return
+	}
+
+	// the pending data of the other direction was received first
+	for _, dir := range []uint8{1 - stream.dir, stream.dir} {
+		pending := upgrade.Pending[dir]
+		if len(pending) == 0 {
+			continue
+		}
+		seq := conn.lastSeq[dir] - uint32(len(pending))
+		if conn.detect != nil {
+			// the detected protocol is passed the data again from seq
+			conn.lastSeq[dir] = seq
+		}
+		s := TCPStream{conn: conn, dir: dir}
+		s.addPacket(&protos.Packet{Ts: pkt.Ts, Tuple: pkt.Tuple, Payload: pending, Capture: pkt.Capture}, &layers.TCP{Seq: seq})
+	}
+}
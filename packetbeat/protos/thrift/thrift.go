@@ -62,7 +62,8 @@ type thriftPlugin struct {
 }
 
 type thriftMessage struct {
-	ts time.Time
+	ts      time.Time
+	capture pb.Capture
 
 	tcpTuple     common.TCPTuple
 	cmdlineTuple *common.ProcessTuple
@@ -933,7 +934,7 @@ func (thrift *thriftPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 		stream = &thriftStream{
 			tcptuple: tcptuple,
 			data:     pkt.Payload,
-			message:  &thriftMessage{ts: pkt.Ts},
+			message:  &thriftMessage{ts: pkt.Ts, capture: pkt.Capture},
 		}
 		priv.data[dir] = stream
 	} else {
@@ -952,7 +953,7 @@ func (thrift *thriftPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 
 	for len(stream.data) > 0 {
 		if stream.message == nil {
-			stream.message = &thriftMessage{ts: pkt.Ts}
+			stream.message = &thriftMessage{ts: pkt.Ts, capture: pkt.Capture}
 		}
 
 		ok, complete := thrift.messageParser(priv.data[dir])
@@ -1114,6 +1115,7 @@ func (thrift *thriftPlugin) publishTransactions() {
 			query := t.request.method + t.request.params
 			fields["query"] = query
 			pbf.Event.Start = t.request.ts
+			pbf.AddCapture(&t.request.capture)
 
 			thriftFields["params"] = t.request.params
 			if len(t.request.service) > 0 {
@@ -1127,6 +1129,7 @@ func (thrift *thriftPlugin) publishTransactions() {
 
 		if t.reply != nil {
 			pbf.Event.End = t.reply.ts
+			pbf.AddCapture(&t.reply.capture)
 
 			thriftFields["return_value"] = t.reply.returnValue
 			if len(t.reply.exceptions) > 0 {
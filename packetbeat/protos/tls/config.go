@@ -18,16 +18,34 @@
 package tls
 
 import (
+	"time"
+
 	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 )
 
 type tlsConfig struct {
 	config.ProtocolCommon  `config:",inline"`
-	SendCertificates       bool     `config:"send_certificates"`
-	IncludeRawCertificates bool     `config:"include_raw_certificates"`
-	IncludeDetailedFields  bool     `config:"include_detailed_fields"`
-	Fingerprints           []string `config:"fingerprints"`
+	SendCertificates       bool             `config:"send_certificates"`
+	IncludeRawCertificates bool             `config:"include_raw_certificates"`
+	IncludeDetailedFields  bool             `config:"include_detailed_fields"`
+	Fingerprints           []string         `config:"fingerprints"`
+	JaFingerprints         []string         `config:"ja_fingerprints"`
+	ChainFingerprints      []string         `config:"certificate_chain_fingerprints"`
+	Decryption             decryptionConfig `config:"decryption"`
+}
+
+type decryptionConfig struct {
+	KeyLog       string                    `config:"keylog"`
+	ReloadPeriod time.Duration             `config:"reload_period" validate:"min=0"`
+	Protocols    []decryptedProtocolConfig `config:"protocols"`
+}
+
+// decryptedProtocolConfig selects the analyzer of the decrypted application
+// data of the connections to the given ports.
+type decryptedProtocolConfig struct {
+	Type  string `config:"type" validate:"required"`
+	Ports []int  `config:"ports" validate:"required"`
 }
 
 var (
@@ -38,5 +56,9 @@ var (
 		SendCertificates:      true,
 		IncludeDetailedFields: true,
 		Fingerprints:          []string{"sha1"},
+		JaFingerprints:        []string{"ja3"},
+		Decryption: decryptionConfig{
+			ReloadPeriod: time.Second,
+		},
 	}
 )
@@ -0,0 +1,478 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/streambuf"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+)
+
+// maxPendingEncrypted is the maximum number of bytes of encrypted records
+// buffered for a direction while waiting for the session secrets to be
+// written to the key log.
+const maxPendingEncrypted = 1 << 20
+
+var errNoContentType = errors.New("no content type in decrypted record")
+
+// aeadSuite describes the record protection of a cipher suite that can be
+// decrypted.
+type aeadSuite struct {
+	keyLen int
+	hash   func() hash.Hash
+	aead   func(key []byte) (cipher.AEAD, error)
+
+	// length of the nonce part derived from the master secret in TLS 1.2,
+	// the rest of the nonce being sent in each record
+	fixedNonceLen int
+}
+
+var (
+	aes128GCMSHA256 = &aeadSuite{16, sha256.New, newAESGCM, 4}
+	aes256GCMSHA384 = &aeadSuite{32, sha512.New384, newAESGCM, 4}
+	chacha20SHA256  = &aeadSuite{chacha20poly1305.KeySize, sha256.New, chacha20poly1305.New, 12}
+)
+
+// aeadSuites are the cipher suites that can be decrypted.
+var aeadSuites = map[cipherSuite]*aeadSuite{
+	0x009c: aes128GCMSHA256, // TLS_RSA_WITH_AES_128_GCM_SHA256
+	0x009d: aes256GCMSHA384, // TLS_RSA_WITH_AES_256_GCM_SHA384
+	0x009e: aes128GCMSHA256, // TLS_DHE_RSA_WITH_AES_128_GCM_SHA256
+	0x009f: aes256GCMSHA384, // TLS_DHE_RSA_WITH_AES_256_GCM_SHA384
+	0xc02b: aes128GCMSHA256, // TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256
+	0xc02c: aes256GCMSHA384, // TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384
+	0xc02f: aes128GCMSHA256, // TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+	0xc030: aes256GCMSHA384, // TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+	0xcca8: chacha20SHA256,  // TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256
+	0xcca9: chacha20SHA256,  // TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256
+	0xccaa: chacha20SHA256,  // TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256
+	0x1301: aes128GCMSHA256, // TLS_AES_128_GCM_SHA256
+	0x1302: aes256GCMSHA384, // TLS_AES_256_GCM_SHA384
+	0x1303: chacha20SHA256,  // TLS_CHACHA20_POLY1305_SHA256
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// prf12 is the TLS 1.2 pseudorandom function (RFC 5246, section 5).
+func prf12(hash func() hash.Hash, secret []byte, label string, seed []byte, length int) []byte {
+	labelSeed := append([]byte(label), seed...)
+	out := make([]byte, 0, length+hash().Size())
+	a := labelSeed
+	for len(out) < length {
+		mac := hmac.New(hash, secret)
+		mac.Write(a)
+		a = mac.Sum(nil)
+
+		mac = hmac.New(hash, secret)
+		mac.Write(a)
+		mac.Write(labelSeed)
+		out = mac.Sum(out)
+	}
+	return out[:length]
+}
+
+// ExpandLabel is the TLS 1.3 HKDF-Expand-Label function (RFC 8446,
+// section 7.1) with an empty context. It's also used to derive the QUIC
+// packet protection keys.
+func ExpandLabel(hash func() hash.Hash, secret []byte, label string, length int) ([]byte, error) {
+	label = "tls13 " + label
+	info := make([]byte, 0, 4+len(label))
+	info = append(info, byte(length>>8), byte(length), byte(len(label)))
+	info = append(info, label...)
+	info = append(info, 0)
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(hash, secret, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// recordCipher decrypts the records sent in a direction of a connection.
+type recordCipher struct {
+	suite *aeadSuite
+	aead  cipher.AEAD
+	seq   uint64
+
+	// nonce derived from the secrets, completed by the record explicit
+	// nonce, or xored with the sequence number
+	nonce         []byte
+	explicitNonce bool
+
+	// TLS 1.3 traffic secret, for key updates
+	tls13  bool
+	secret []byte
+}
+
+func newRecordCipher12(suite *aeadSuite, key, iv []byte) (*recordCipher, error) {
+	aead, err := suite.aead(key)
+	if err != nil {
+		return nil, err
+	}
+	return &recordCipher{
+		suite:         suite,
+		aead:          aead,
+		nonce:         iv,
+		explicitNonce: len(iv) < aead.NonceSize(),
+	}, nil
+}
+
+func newRecordCipher13(suite *aeadSuite, secret []byte) (*recordCipher, error) {
+	key, err := ExpandLabel(suite.hash, secret, "key", suite.keyLen)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := suite.aead(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := ExpandLabel(suite.hash, secret, "iv", aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return &recordCipher{
+		suite:  suite,
+		aead:   aead,
+		nonce:  nonce,
+		tls13:  true,
+		secret: secret,
+	}, nil
+}
+
+// update returns the cipher of the traffic secret following a KeyUpdate
+// message.
+func (c *recordCipher) update() (*recordCipher, error) {
+	secret, err := ExpandLabel(c.suite.hash, c.secret, "traffic upd", c.suite.hash().Size())
+	if err != nil {
+		return nil, err
+	}
+	return newRecordCipher13(c.suite, secret)
+}
+
+// open decrypts a record in place, returning the content type and the
+// plaintext.
+func (c *recordCipher) open(record []byte) (recordType, []byte, error) {
+	header, payload := record[:recordHeaderSize], record[recordHeaderSize:]
+	nonce := make([]byte, c.aead.NonceSize())
+	if c.explicitNonce {
+		explicit := len(nonce) - len(c.nonce)
+		if len(payload) < explicit {
+			return 0, nil, errors.New("record too short")
+		}
+		copy(nonce, c.nonce)
+		copy(nonce[len(c.nonce):], payload[:explicit])
+		payload = payload[explicit:]
+	} else {
+		copy(nonce, c.nonce)
+		var seq [8]byte
+		binary.BigEndian.PutUint64(seq[:], c.seq)
+		for i, b := range seq {
+			nonce[len(nonce)-8+i] ^= b
+		}
+	}
+
+	var additionalData []byte
+	if c.tls13 {
+		additionalData = header
+	} else {
+		length := len(payload) - c.aead.Overhead()
+		if length < 0 {
+			return 0, nil, errors.New("record too short")
+		}
+		additionalData = make([]byte, 13)
+		binary.BigEndian.PutUint64(additionalData, c.seq)
+		copy(additionalData[8:], header[:3])
+		binary.BigEndian.PutUint16(additionalData[11:], uint16(length))
+	}
+
+	plaintext, err := c.aead.Open(payload[:0], nonce, payload, additionalData)
+	if err != nil {
+		return 0, nil, err
+	}
+	c.seq++
+
+	if !c.tls13 {
+		return recordType(header[0]), plaintext, nil
+	}
+	// the content type follows the content and is followed by padding
+	i := len(plaintext) - 1
+	for i >= 0 && plaintext[i] == 0 {
+		i--
+	}
+	if i < 0 {
+		return 0, nil, errNoContentType
+	}
+	return recordType(plaintext[i]), plaintext[:i], nil
+}
+
+// decrypter holds the decryption state of a connection, and the state of the
+// analyzer of the decrypted application data.
+type decrypter struct {
+	app     protos.TCPPlugin
+	appData protos.ProtocolData
+
+	suite        *aeadSuite
+	tls13        bool
+	clientDir    uint8
+	clientRandom []byte
+	serverRandom []byte
+
+	ciphers [2]*recordCipher
+	// if the handshake is finished in a direction, the TLS 1.3 traffic
+	// secrets being used after the Finished message
+	finished [2]bool
+
+	failed bool
+}
+
+// newDecrypter returns the decrypter of a connection, or nil if the
+// connection can't be decrypted.
+func (plugin *tlsPlugin) newDecrypter(conn *tlsConnectionData, tcptuple *common.TCPTuple) *decrypter {
+	if plugin.keyLog == nil {
+		return nil
+	}
+	app := plugin.decryptedProtocols[tcptuple.DstPort]
+	if app == nil {
+		app = plugin.decryptedProtocols[tcptuple.SrcPort]
+	}
+	if app == nil {
+		return nil
+	}
+
+	d := &decrypter{app: app}
+	var clientHello, serverHello *helloMessage
+	for dir, st := range conn.streams {
+		if st == nil {
+			continue
+		}
+		switch st.parser.direction {
+		case dirClient:
+			clientHello = st.parser.hello
+			d.clientDir = uint8(dir)
+		case dirServer:
+			serverHello = st.parser.hello
+		}
+	}
+	if clientHello == nil || serverHello == nil ||
+		len(clientHello.random) != randomLength || len(serverHello.random) != randomLength {
+		if isDebug {
+			debugf("hello messages missing, can't decrypt the connection")
+		}
+		return nil
+	}
+
+	switch serverHello.selectedVersion() {
+	case tlsVersion{3, 3}:
+	case tlsVersion{3, 4}:
+		d.tls13 = true
+	default:
+		if isDebug {
+			debugf("can't decrypt %v connections", serverHello.selectedVersion())
+		}
+		return nil
+	}
+	if d.suite = aeadSuites[serverHello.selected.cipherSuite]; d.suite == nil {
+		if isDebug {
+			debugf("can't decrypt cipher suite %v", serverHello.selected.cipherSuite)
+		}
+		return nil
+	}
+	d.clientRandom = clientHello.random
+	d.serverRandom = serverHello.random
+	return d
+}
+
+func (d *decrypter) fail(err error) {
+	if isDebug {
+		debugf("stopping decryption: %v", err)
+	}
+	d.failed = true
+}
+
+// cipher returns the cipher of the records sent in a direction, or nil
+// if the secrets are not known yet.
+func (d *decrypter) cipher(keyLog *keyLog, dir uint8) *recordCipher {
+	if c := d.ciphers[dir]; c != nil {
+		return c
+	}
+
+	if !d.tls13 {
+		masterSecret := keyLog.lookup(d.clientRandom, func(s *sessionSecrets) []byte {
+			return s.masterSecret
+		})
+		if masterSecret == nil {
+			return nil
+		}
+		if err := d.deriveKeys12(masterSecret); err != nil {
+			d.fail(err)
+			return nil
+		}
+		return d.ciphers[dir]
+	}
+
+	client := dir == d.clientDir
+	secret := keyLog.lookup(d.clientRandom, func(s *sessionSecrets) []byte {
+		switch {
+		case client && d.finished[dir]:
+			return s.clientTrafficSecret
+		case client:
+			return s.clientHandshakeSecret
+		case d.finished[dir]:
+			return s.serverTrafficSecret
+		default:
+			return s.serverHandshakeSecret
+		}
+	})
+	if secret == nil {
+		return nil
+	}
+	c, err := newRecordCipher13(d.suite, secret)
+	if err != nil {
+		d.fail(err)
+		return nil
+	}
+	d.ciphers[dir] = c
+	return c
+}
+
+// deriveKeys12 derives the keys of both directions of a TLS 1.2 session
+// from the master secret (RFC 5246, section 6.3).
+func (d *decrypter) deriveKeys12(masterSecret []byte) error {
+	keyLen, ivLen := d.suite.keyLen, d.suite.fixedNonceLen
+	seed := append(append([]byte(nil), d.serverRandom...), d.clientRandom...)
+	block := prf12(d.suite.hash, masterSecret, "key expansion", seed, 2*keyLen+2*ivLen)
+	clientKey, serverKey := block[:keyLen], block[keyLen:2*keyLen]
+	clientIV, serverIV := block[2*keyLen:2*keyLen+ivLen], block[2*keyLen+ivLen:]
+
+	client, err := newRecordCipher12(d.suite, clientKey, clientIV)
+	if err != nil {
+		return err
+	}
+	server, err := newRecordCipher12(d.suite, serverKey, serverIV)
+	if err != nil {
+		return err
+	}
+	d.ciphers[d.clientDir] = client
+	d.ciphers[1-d.clientDir] = server
+	return nil
+}
+
+// decrypt decrypts the records buffered in a stream once the handshake
+// is completed in its direction. Application data is passed to the
+// analyzer configured for the connection, and TLS 1.3 handshake messages
+// to the TLS parser.
+func (plugin *tlsPlugin) decrypt(
+	d *decrypter,
+	st *stream,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	buf := &st.Buf
+	for !d.failed && buf.Avail(recordHeaderSize) {
+		header, err := readRecordHeader(buf)
+		if err != nil || !header.isValid() {
+			d.fail(errors.New("invalid record"))
+			break
+		}
+		limit := recordHeaderSize + int(header.length)
+		if !buf.Avail(limit) {
+			break
+		}
+		if header.recordType == recordTypeChangeCipherSpec {
+			// sent in TLS 1.3 for middlebox compatibility
+			buf.Advance(limit)
+			continue
+		}
+
+		c := d.cipher(plugin.keyLog, dir)
+		if c == nil {
+			if buf.Len() > maxPendingEncrypted {
+				d.fail(errors.New("session secrets not found in key log"))
+			}
+			break
+		}
+		typ, plaintext, err := c.open(buf.Bytes()[:limit])
+		if err != nil {
+			d.fail(err)
+			break
+		}
+		buf.Advance(limit)
+
+		switch typ {
+		case recordTypeApplicationData:
+			if len(plaintext) > 0 {
+				pkt := &protos.Packet{Ts: pkt.Ts, Tuple: pkt.Tuple, Payload: plaintext, Capture: pkt.Capture}
+				d.appData = d.app.Parse(pkt, tcptuple, dir, d.appData)
+			}
+
+		case recordTypeHandshake:
+			parser := &st.parser
+			finished, keyUpdates := parser.finished, parser.keyUpdates
+			if err := parser.bufferHandshake(plaintext); err != nil {
+				if isDebug {
+					debugf("failed parsing decrypted handshake: %v", err)
+				}
+			}
+			if !d.tls13 {
+				break
+			}
+			if parser.finished && !finished {
+				// the records that follow use the traffic secrets
+				d.finished[dir] = true
+				d.ciphers[dir] = nil
+			}
+			for ; keyUpdates < parser.keyUpdates && d.ciphers[dir] != nil; keyUpdates++ {
+				if d.ciphers[dir], err = d.ciphers[dir].update(); err != nil {
+					d.fail(err)
+				}
+			}
+
+		case recordTypeAlert:
+			alerts := streambuf.New(plaintext)
+			if err := st.parser.parseAlert(newBufferView(alerts, 0, len(plaintext))); err != nil {
+				if isDebug {
+					debugf("failed parsing decrypted alert: %v", err)
+				}
+			}
+		}
+	}
+
+	if d.failed {
+		buf.Advance(buf.Len())
+	}
+}
@@ -0,0 +1,355 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+const (
+	decryptedRequest  = "GET / HTTP/1.1\r\nHost: decrypt.example.org\r\n\r\n"
+	decryptedResponse = "HTTP/1.1 200 OK\r\nContent-Length: 5\r\n\r\nhello"
+)
+
+type segment struct {
+	dir  uint8
+	data []byte
+}
+
+// recordedConn records the data written to a connection.
+type recordedConn struct {
+	net.Conn
+	dir      uint8
+	mutex    *sync.Mutex
+	segments *[]segment
+}
+
+func (c recordedConn) Write(b []byte) (int, error) {
+	c.mutex.Lock()
+	*c.segments = append(*c.segments, segment{c.dir, append([]byte(nil), b...)})
+	c.mutex.Unlock()
+	return c.Conn.Write(b)
+}
+
+func testCertificate(t *testing.T) gotls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "decrypt.example.org"},
+		DNSNames:     []string{"decrypt.example.org"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gotls.Certificate{Certificate: [][]byte{cert}, PrivateKey: key}
+}
+
+// captureSession runs a request and response over a TLS session, returning
+// the data sent by both ends. The client writes its secrets to keyLog.
+func captureSession(t *testing.T, version uint16, cipherSuite uint16, keyLog io.Writer) []segment {
+	var (
+		segments []segment
+		mutex    sync.Mutex
+	)
+	clientEnd, serverEnd := net.Pipe()
+	defer clientEnd.Close()
+	defer serverEnd.Close()
+
+	serverConfig := &gotls.Config{
+		Certificates:           []gotls.Certificate{testCertificate(t)},
+		MinVersion:             version,
+		MaxVersion:             version,
+		SessionTicketsDisabled: true,
+	}
+	clientConfig := &gotls.Config{
+		InsecureSkipVerify: true,
+		ServerName:         "decrypt.example.org",
+		MinVersion:         version,
+		MaxVersion:         version,
+		KeyLogWriter:       keyLog,
+	}
+	if cipherSuite != 0 {
+		clientConfig.CipherSuites = []uint16{cipherSuite}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		server := gotls.Server(recordedConn{serverEnd, tcp.TCPDirectionReverse, &mutex, &segments}, serverConfig)
+		request := make([]byte, len(decryptedRequest))
+		if _, err := io.ReadFull(server, request); err != nil {
+			done <- err
+			return
+		}
+		_, err := server.Write([]byte(decryptedResponse))
+		done <- err
+	}()
+
+	client := gotls.Client(recordedConn{clientEnd, tcp.TCPDirectionOriginal, &mutex, &segments}, clientConfig)
+	if _, err := client.Write([]byte(decryptedRequest)); err != nil {
+		t.Fatal(err)
+	}
+	response := make([]byte, len(decryptedResponse))
+	if _, err := io.ReadFull(client, response); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	return segments
+}
+
+// appRecorder is an analyzer collecting the decrypted data.
+type appRecorder struct {
+	data [2][]byte
+	fins int
+}
+
+func (app *appRecorder) GetPorts() []int { return nil }
+
+func (app *appRecorder) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
+	dir uint8, private protos.ProtocolData) protos.ProtocolData {
+	app.data[dir] = append(app.data[dir], pkt.Payload...)
+	return private
+}
+
+func (app *appRecorder) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+	app.fins++
+	return private
+}
+
+func (app *appRecorder) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (protos.ProtocolData, bool) {
+	return private, true
+}
+
+func (app *appRecorder) ConnectionTimeout() time.Duration { return 0 }
+
+// testProtocols holds the analyzers available to the TLS plugin.
+type testProtocols map[string]protos.TCPPlugin
+
+func (p testProtocols) BpfFilter(withVlans bool, withICMP bool) string { return "" }
+func (p testProtocols) GetUDP(proto protos.Protocol) protos.UDPPlugin  { return nil }
+func (p testProtocols) GetAllUDP() map[protos.Protocol]protos.UDPPlugin {
+	return nil
+}
+func (p testProtocols) GetAllTCP() map[protos.Protocol]protos.TCPPlugin {
+	return nil
+}
+
+func (p testProtocols) GetTCP(proto protos.Protocol) protos.TCPPlugin {
+	return p[proto.String()]
+}
+
+func decryptInit(t *testing.T, keyLogPath string) (*eventStore, *tlsPlugin, *appRecorder) {
+	results, plugin := testInit()
+	config := defaultConfig
+	config.Decryption = decryptionConfig{
+		KeyLog: keyLogPath,
+		Protocols: []decryptedProtocolConfig{
+			{Type: "tls", Ports: []int{27017}},
+		},
+	}
+	if err := plugin.setFromConfig(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &appRecorder{}
+	if err := plugin.SetProtocols(testProtocols{"tls": app}); err != nil {
+		t.Fatal(err)
+	}
+	return results, plugin, app
+}
+
+func replay(plugin *tlsPlugin, segments []segment, private protos.ProtocolData) protos.ProtocolData {
+	tcpTuple := testTCPTuple()
+	for _, seg := range segments {
+		pkt := protos.Packet{Ts: time.Now(), Payload: seg.data}
+		private = plugin.Parse(&pkt, tcpTuple, seg.dir, private)
+	}
+	return private
+}
+
+func tempKeyLog(t *testing.T) (path string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "keylog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "sslkeylog.txt"), func() { os.RemoveAll(dir) }
+}
+
+func TestDecrypt(t *testing.T) {
+	for name, test := range map[string]struct {
+		version     uint16
+		cipherSuite uint16
+	}{
+		"TLS 1.2 AES-128-GCM": {gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		"TLS 1.2 AES-256-GCM": {gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+		"TLS 1.2 ChaCha20":    {gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305},
+		"TLS 1.3":             {gotls.VersionTLS13, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, cleanup := tempKeyLog(t)
+			defer cleanup()
+
+			var keyLog bytes.Buffer
+			segments := captureSession(t, test.version, test.cipherSuite, &keyLog)
+			if err := ioutil.WriteFile(path, keyLog.Bytes(), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			results, plugin, app := decryptInit(t, path)
+			private := replay(plugin, segments, nil)
+			plugin.ReceivedFin(testTCPTuple(), tcp.TCPDirectionOriginal, private)
+
+			assert.Equal(t, decryptedRequest, string(app.data[tcp.TCPDirectionOriginal]))
+			assert.Equal(t, decryptedResponse, string(app.data[tcp.TCPDirectionReverse]))
+			assert.Equal(t, 1, app.fins)
+
+			if !assert.Len(t, results.events, 1) {
+				return
+			}
+			event := results.events[0]
+			established, _ := event.GetValue("tls.established")
+			assert.Equal(t, true, established)
+			// TLS 1.3 certificates are only available decrypted
+			subject, _ := event.GetValue("tls.server.subject")
+			assert.Equal(t, "CN=decrypt.example.org", subject)
+		})
+	}
+}
+
+func TestDecryptPendingSecrets(t *testing.T) {
+	path, cleanup := tempKeyLog(t)
+	defer cleanup()
+
+	var keyLog bytes.Buffer
+	segments := captureSession(t, gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, &keyLog)
+	if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, plugin, app := decryptInit(t, path)
+	last := len(segments) - 1
+	private := replay(plugin, segments[:last], nil)
+	assert.Empty(t, app.data[tcp.TCPDirectionOriginal])
+
+	// the records are decrypted once the secrets are written and read
+	if err := ioutil.WriteFile(path, keyLog.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	plugin.keyLog.load()
+	replay(plugin, segments[last:], private)
+	assert.Equal(t, decryptedRequest, string(app.data[tcp.TCPDirectionOriginal]))
+	assert.Equal(t, decryptedResponse, string(app.data[tcp.TCPDirectionReverse]))
+}
+
+func TestDecryptWrongSecrets(t *testing.T) {
+	path, cleanup := tempKeyLog(t)
+	defer cleanup()
+
+	var keyLog bytes.Buffer
+	segments := captureSession(t, gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, &keyLog)
+	fields := bytes.Fields(keyLog.Bytes())
+	if !assert.Len(t, fields, 3) {
+		return
+	}
+	line := string(fields[0]) + " " + string(fields[1]) + " " + string(bytes.Repeat([]byte("00"), 48)) + "\n"
+	if err := ioutil.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	results, plugin, app := decryptInit(t, path)
+	private := replay(plugin, segments, nil)
+	assert.Empty(t, app.data[tcp.TCPDirectionOriginal])
+	assert.Empty(t, app.data[tcp.TCPDirectionReverse])
+	assert.True(t, private.(*tlsConnectionData).decrypter.failed)
+	assert.Len(t, results.events, 1)
+}
+
+func TestDecryptUnsupportedCipherSuite(t *testing.T) {
+	path, cleanup := tempKeyLog(t)
+	defer cleanup()
+
+	var keyLog bytes.Buffer
+	segments := captureSession(t, gotls.VersionTLS12, gotls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA, &keyLog)
+	if err := ioutil.WriteFile(path, keyLog.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	results, plugin, app := decryptInit(t, path)
+	private := replay(plugin, segments, nil)
+	assert.Empty(t, app.data[tcp.TCPDirectionOriginal])
+	assert.Nil(t, private.(*tlsConnectionData).decrypter)
+	assert.Len(t, results.events, 1)
+}
+
+func TestDecryptedProtocols(t *testing.T) {
+	_, plugin := testInit()
+	config := defaultConfig
+	config.Decryption = decryptionConfig{
+		KeyLog: "sslkeylog.txt",
+		Protocols: []decryptedProtocolConfig{
+			{Type: "tls", Ports: []int{443, 8443}},
+		},
+	}
+	if err := plugin.setFromConfig(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	// the analyzer must be enabled
+	assert.Error(t, plugin.SetProtocols(testProtocols{}))
+	// the TLS plugin can't analyze its own decrypted data
+	assert.Error(t, plugin.SetProtocols(testProtocols{"tls": plugin}))
+
+	app := &appRecorder{}
+	assert.NoError(t, plugin.SetProtocols(testProtocols{"tls": app}))
+	assert.Equal(t, map[uint16]protos.TCPPlugin{443: app, 8443: app}, plugin.decryptedProtocols)
+}
@@ -47,6 +47,10 @@ const (
 	ExtensionSupportedGroups ExtensionID = 10
 	// ExtensionEllipticCurvePointsFormats identifies the points formats extension
 	ExtensionEllipticCurvePointsFormats = 11
+	// ExtensionSignatureAlgorithms identifies the signature algorithms extension
+	ExtensionSignatureAlgorithms = 13
+	// ExtensionSupportedVersions identifies the supported versions extension
+	ExtensionSupportedVersions = 43
 )
 
 var extensionMap = map[uint16]extension{
@@ -63,7 +67,7 @@ var extensionMap = map[uint16]extension{
 	10:     {"supported_groups", parseSupportedGroups, true},
 	11:     {"ec_points_formats", parseEcPoints, true},
 	12:     {"srp", parseSrp, false},
-	13:     {"signature_algorithms", parseSignatureSchemes, false},
+	13:     {"signature_algorithms", parseSignatureSchemes, true},
 	16:     {"application_layer_protocol_negotiation", parseALPN, false},
 	35:     {"session_ticket", parseTicket, false},
 	43:     {"supported_versions", parseSupportedVersions, true},
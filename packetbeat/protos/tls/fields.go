@@ -32,5 +32,5 @@ func init() {
 // AssetTls returns asset data.
 // This is the base64 encoded zlib format compressed contents of protos/tls.
 func AssetTls() string {
-	return "eJzsW99v47gRfs9fMbg+ZBdwtL0eWqABUiBwckCAxe6h3m37JtDSWGKXInXkyI73ry9ISjatH7Y3dnLprvwUW9LMN8PhNx/J6Aq+4PoaSJg4RWJcYHoBQJwEXsPlXf0TfHo/u7wASNEkmpfElbyGf1wAAIS3XJkSE77gCeASJcGCo0hNdAH1X9fuiSuQrEDn030HoHWJ15BpVZX1L+H99vMnyJBA8xTUAijnBlY5SlghVGWmWYpACu6nM/g5+tvmocZRIjhK2vzc56/PZ2ji8a9//vvOhSEj9pPiglWC4togLJgw2Lqnz1nocInacCU71xu/X3C9Ujrtub4zRv/yZmzWbAiwULpgFPU8ho+sKO2g/3IxCIobU6GOSq2WXCbtkL4Z3G+1HVAaNGYW54pTziUkqpKk19EwFFPN/4sJ/SFYTipGg3qJeizGsRjPg6XBELD3rs92URwqiH2V118I+2Lr6Rjh51OOjVE/mdD2ESi1IpUoAZXBtF0gm+K4tLf+HP1yedELVqOpCuc5LpBy1YZ2AuwHD9WgcchzZmCOKL1LTCfuaiVT1GLNZQbev48GPkoEtejY/ImnP9kp4RLQWH64szXwE/HkC9L2sv8O+Ego7X1RfwZ844sT1GS7MiOMNf5eoSHsT8ZcKYGsPb4HkvHvHClHXWfEsptLyMaTu+ChWGJkFeUoycEBTgZFNxeVsVljzVNBAPsjzVEIdTQ1vhDtdTMGR1T+fA2rnCd5mL0VNzkaoHaI/pOooqikz2taaZtB15zqWtrHX/6OmPfFcoZYP0v+e4Ugq2Ju60QBT20FLNY7s8gWtwtWaY2mVDLlMhuIVEpMqOHFIEV7SboslSZM40QVpa5D9jPTPN8YC27IDnDgtKYDEw5tjc70dUOAGSLkRKW5fvdutVpFnEkWKZ29Y8bwTBYoybyzHq6s6Suetr5FjzkVYjg3GyIZTkTfBOqkwQbs5mBgEVxXTDG1JR2OVNfW0ISEjn6K7ZeYy9SWe/8chSPGsBPA+3q0cmXIujDdnIVgWFmKGkEs2Bp13MzfWGKmiJ8VXH+R2U8DO8Bz5fBs+GSn2Kxi5UI4hlC2K/VMG+jhB992XiAclBnlDS02/OC9T4AvNiU1sd2RScCipDUY0kOM4QAqYOnSthKDzXzzpOMMm0NJ2PBHTdt9c+XciajH1XaGxitQzuik0dwE4ub0C4ZxL4S9OYFppZcIU70uSWWalfka3txPp28hcRf24oJtAG1G2R82zySjSmPMRKY0p7x4wdA33mHr3Y9kwdYwRztuwCWkPOPExHDsjZ1D5YpJXCouycR+mfXHDfOb++lbcFjqFZ+J4MEzNzpaGg4Wnb2dZzvlnzAJJdMHyz6upLstjV8uE0EXdLBXqBEELggaMLaEf2OWf+bI2iXc6nnfkbp12eDGrYhq4Rcq1T6TD+4BaynnWY6GNg46fFCvQqSyS6QEcVBIUgBzu17hMqwvl/W94llg0i8rny+3XTW5wdFKwkKrwn23QrTX3kaffWvcP8Ci4UWFsR+xpwrjl9WiH2qTmIaOYVd0vi5V+dlyjRWBUqpKJuhJiLX0pdNStiE3ozNob3fU4Fas2Nq01egrFJXB0AW60mbGy5D0h1cWNXX+AMqiu0N4tL7YATzdGhhY7zumDvb+evr8qYpFqF7q3wH6n+3BQ2NvD/3Xd8S+CZ3a5857/mFQcybOBM1piu0YXprafN1+96RIKornuFB6+NQj7ZZVB8CdrRxvJ9z3DMqKGyfolkzwPoYK8bAF7UnJ0XCcmQE0+Fjy/kVYA6Ss5oIn8RdcbxeczycINy5aknp3UD0m661fY/ujCfjn7HYCd7NbUBrup3ez2+PCNPzrcBEcMzln/Cs2y4UQ6vAc6K7pXzjFnWnTINqDmglCLRnxJcZ+k/FEzDN//gi3W8PwwRrurYPDB5mnad0GjUa7REG5WZO0YXTtHKNz6yPQc7XaqTcHiUoP9XmlMyb517Pq6I+BTefmGzAwEVeSn005f5acmnPm0M0BRHsOvZ8E4ykH8bBTH0VhWzYrzgbJziULJ1eGnJdmLcoDlbN3goUAhUqY4HS2Cn5f2zvgNuWGuMwqbnJMz5qeu9Cyz8+buw9vGyYP22ZNMGCzRwNNKJA/05vPswnMPt1MmeALpSVnE3h/M2MSftVMJtwkagIfb35lhsR6Ag8yiSYw/XCj/xKZnGlMo0yoORPRwt0RSaRD/1FyGvndu8Ca/S1T2fWxTF1bcCfhIwd23Y8cOHLgiej+nzjQ08zrocDWKcOz7gU0m9LjXsC4FzAIYNwLGPcCxr2AJ2Ie9wKOzlsH7aiDvzMdbIgRxsodhb4qYKNA73f7KgT6uEkxknPH/UjOIzk/HdJIznsBfJe7J3GSM97/rhzTmrVHb5fs7KMufF0Z2qwvmn9MOfZ9qOdFc/TbWUygptg6ay9vTngH71Z64JAoSYxL/76T/z9T59AZd2BxiXpd/6gxQb7ENLr4XwAAAP//dxGgaw=="
+	return "eNrtW1tv2zYUfs+vILqHrICjxsmcdgFSIHBSrEPXDnO77U2jJdriRpMaScXxfv0OSUmmrYuT+NKiVZ4iizr3y3dI6QT9QxaXSDMVxkRjykh8hJCmmpFLdHyT/4Q+vhsdw+8xUZGkqaaCX6LX8ANC/pITlZKITmiEyB3hGk0oYbEKYJ3779I+cYI4nhHL014Du0UK11MpsjT/xV9v/r5DU6KRpDESE6QTqtA8IRzNCcrSqcQxQVqg2+EI9YOL8qGCUcQoSFP+XMevjqdP4n5w+uPKjSYi5i8mE5wxHeYE0QQzRdbW1DHzGd4RqcDKlfsFX/DaXMi45v6Kj353ZIzVjApoIuQM66DmMXKPZ6lx+vlRo1BUqYzIIJXijvKIbCvcrzkdJCSSZGrknFNwLkeRyLiWi6BZFJWN/yaR/myyFHL8jX84ejjbmvzx/36+/gECg0+JTCWF/LGxTtDQxu9PhDGx7rnSa7p/HvcH/YvkLHwVYzy56A/OXr7sh2RwcfYS4vEMj/uNGtzvVIU/fR1UoYRLQhQRqU2FwJqoHgLzmnvAhUhYWKFmH1w+gaIEU97gCW9daNeFCVbJI5K2Va83T1TJXFSIVVTqmcREBEdJQdvIjjCbCglROFOIUaWhyFJea6O/qsr7PvgLCatY8MgiNIsH2+bVLzeD+nDwjNaW5gnubyvC6Kfrk/6WQpwNLnYhBpB5jCBbdT1FJDSRrut1XW8vXU/tve2NbABvantnpwPoedHp2SS8OB9MXp2evjo//fHis/Y6l3pfVa97iEpdr+t63ZN7XSGAN4W2B/KmftPW2Or7zBal4CModrfsOkZPmIcR1GktIsFQpkjcWMSOzdJ+cH5cn+ySqGxmOYczohMR707st0VyKys5JCIaE0AYliWJe/ZuxiG92QJciRx/pw36wEldHXtG42c2xY1WBeW3N6bFPNM0+ofo5W13DZbQhCubtPXlzgLt0E98Sf7NiCkTtcYYC8EI5o8zxh8JaAeFzCt3xiAlJx/zA+7CGVxz7Sob1Yqwqi0yZayGayaFdk0T0/QeXM4PhKpe1yzYHPnjBYBWCsXes96cqoQoMGItxUjMZhl3do0zaSxosW8eS23Fy60IabwfXT9xCrGAeDYbmzgRiMYmAiaLlSwywW2VFRLyKBU8BhUaNOUc4FwBuzwTtWLANBUS4jEEQ6UyV9llptqfj01TNg72mOblQPmuzaVTQS2hEYFur3WqLl+8mM/nAcUcB0JOX2AgOOUzoKBeGA4nhvQJjdeugvtEz1izbcpC0myIugSqmMEobHPQo4gs6I6hDowXK56q0mpKyOp4FpqLkEKIQLjX5+hDfFhR4F3urUQobVioo1ZhcJqyXIKQ4QXIVeRvyMlUaLpT4V43LEKl2J48J1aesp6sBJsZiCljtkII05WCow02d8ni2s4B1CF8qpMlhHZZ47gDep6UIdUz3RFzRKDPL5DSsqliWAGh98R3ppUoUuSbKzqWsNpkhLJ+5GVbHc6vpjMUXMEmWG/lzVIRm9MHVOMWpITFERpmkMRoKBepFlOJ02SBvr8dDp+jyN5olQstFVivKO1qQ6HEOpMkXI5Mh1O95O4PbNaTM7wA8Gj8ZubCmE6pxqxZ94LOpnAlUZgKMz+Ebhfn87kZPPscWVnyDSUVoLeuchNVO/uWyhJLb+XZSvhHkP8plhvDPsy4XRaHh7OE1wWt2HMCAcDIRKNCGBPCv2JTf8YE6wZ0m/e8rwjdWmtA4TITUQ78fKRaR/KtfcDue9ApwGBdMqjUg3wK4cKMSBEhjUBSe2Iu55V8eyaPr8RtpLWAZwZYtBZW7s+2VTRZyrFmhIkUM3ttgGgtvRKfPVbvb2BoOCgwdh57KjA+LBZ9n5ME6T3GaBV0flmo8pOpNQYEci4ys3lvixBew5cWS5mGXHinkd6q19A1m+OFWkejXyCo9Fzn4UpjGQdD4m8eWRSb9l8/sqjuED7tMGTonVbUz/u2Unt7f084atiEWJioLf0rgv65PNcs6LWU/3xF6JrQl3W8CkFKMduRaB9XDxiOVU4+b78tJgKQFY4JqNJ8qBpXw6oiwI2JHEfH3/f0wgrAnwF0d5jReIM8eKJbTPJgcSyZBmnIfUpl64lQmo2hLYbggOXAuT9AWLJYg9SrTnUyGW71GNsdTaDfRtc9dDO6Nrsrt0P452FqKvof2So5R0CgGBd8UY8eMdMf2MSVtCkkapEaM4grWEXvSOg2Gbc9NnSvNwAEKQmj94ZwbRxsfk9iO6xbSCOJGVGg3BetYF2Mp+Hc/A2LXbXaoSMHZONNfV7IKeb0v53i6A8eTcvmETJA9c843RlyhoFLF6+x+Gw2SNTyTs2TxHjKez6r8TGbmZYNFzsbdoCWEcecClguxSxKPZTTmmC+gExE0MP0ziL4XU5vA9sYkCMMJJk5Q4x3ap4bn7Kzz/c375/XvLhQFBhkrKcbmpAHf4ZXn0Y9NPp4NQQNoZZxinvo3dUIgPkbiXlEVSR66MPVG6w0W/QA/EdBDw3fX8mzQCVYwiwzZWKMWTCxKwIOg+aGF9a2K363VrFifwvowXzMY9sW7El4VwO7GtjVwG+6Broy8+WUwLVThr3uBRSb0t1eQLcX0O0FdHsB3V5AtxfQ4eAOB+8JBytt3nkW9ii0A+gdQO82KbpNiq44d8W5K87d7sned0/cR5a1eyhYSrxo3UMxj1r1ZaZ0OV9UvyZ9+Jdfu5fmwV9nYQZLQsNsh18/X3MnuHmxT4N87nsn956pZWiJu49mwWiL/EdJIgIzURwc/Q9Luvza"
 }
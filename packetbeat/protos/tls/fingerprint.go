@@ -39,6 +39,24 @@ type FingerprintAlgorithm struct {
 var hashMap = make(map[string]*FingerprintAlgorithm)
 var hashNames []string
 
+// Fingerprints of the handshake and certificates, selectable with the
+// ja_fingerprints option.
+const (
+	fingerprintJa3  = "ja3"
+	fingerprintJa3s = "ja3s"
+	fingerprintJa4  = "ja4"
+	fingerprintJa4s = "ja4s"
+	fingerprintJa4x = "ja4x"
+)
+
+var jaFingerprintNames = []string{
+	fingerprintJa3,
+	fingerprintJa3s,
+	fingerprintJa4,
+	fingerprintJa4s,
+	fingerprintJa4x,
+}
+
 func init() {
 	registerAlgo(func() hash.Hash { return md5.New() }, "md5", "")
 	registerAlgo(func() hash.Hash { return sha1.New() }, "sha1", "sha-1")
@@ -72,3 +90,22 @@ func (algo AlgorithmFactory) Hash(data []byte) string {
 	hash.Write(data) // according to docs "never returns an error"
 	return hex.EncodeToString(hash.Sum(nil))
 }
+
+// getJaFingerprints returns the set of handshake fingerprints to compute.
+func getJaFingerprints(names []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		found := false
+		for _, known := range jaFingerprintNames {
+			if strings.EqualFold(name, known) {
+				set[known] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("fingerprint method '%s' not found. Use one of %v", name, jaFingerprintNames)
+		}
+	}
+	return set, nil
+}
@@ -52,3 +52,12 @@ func TestGetFingerprintAlgorithm(t *testing.T) {
 		assert.Equal(t, testCase.sum, result.algo.Hash(nil))
 	}
 }
+
+func TestGetJaFingerprints(t *testing.T) {
+	set, err := getJaFingerprints([]string{"ja3", "JA4", "ja4x"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ja3": true, "ja4": true, "ja4x": true}, set)
+
+	_, err = getJaFingerprints([]string{"ja3", "ja5"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/streambuf"
+)
+
+// ErrIncompleteHandshake is returned by ParseHello when the data does not
+// hold the whole handshake message yet.
+var ErrIncompleteHandshake = errors.New("incomplete handshake message")
+
+// Hello is a ClientHello or ServerHello handshake message. It lets the
+// analyzers of the protocols using the TLS handshake outside of TLS records,
+// like QUIC, report the same information as the TLS analyzer.
+type Hello struct {
+	msg    *helloMessage
+	client bool
+}
+
+// ParseHello parses the ClientHello or ServerHello handshake message,
+// starting with the handshake header, at the beginning of data.
+func ParseHello(data []byte) (*Hello, error) {
+	buf := streambuf.NewFixed(data)
+	header, err := readHandshakeHeader(buf)
+	if err != nil {
+		return nil, ErrIncompleteHandshake
+	}
+	if header.length > maxHandshakeSize {
+		return nil, fmt.Errorf("message too large (%d bytes)", header.length)
+	}
+	if handshakeHeaderSize+header.length > len(data) {
+		return nil, ErrIncompleteHandshake
+	}
+
+	view := *newBufferView(buf, handshakeHeaderSize, header.length)
+	hello := &Hello{}
+	switch header.handshakeType {
+	case clientHello:
+		hello.msg, hello.client = parseClientHello(view), true
+	case serverHello:
+		hello.msg = parseServerHello(view)
+	default:
+		return nil, fmt.Errorf("not a hello message (type %d)", header.handshakeType)
+	}
+	if hello.msg == nil {
+		return nil, fmt.Errorf("failed parsing hello message (type %d)", header.handshakeType)
+	}
+	return hello, nil
+}
+
+// IsClient returns whether the message is a ClientHello.
+func (hello *Hello) IsClient() bool {
+	return hello.client
+}
+
+// ServerName returns the server name sent by the client, if any.
+func (hello *Hello) ServerName() string {
+	if list, ok := hello.msg.extensions.Parsed["server_name_indication"].([]string); ok && len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+// ALPN returns the application protocols offered by the client, or the one
+// selected by the server.
+func (hello *Hello) ALPN() []string {
+	list, _ := hello.msg.extensions.Parsed["application_layer_protocol_negotiation"].([]string)
+	return list
+}
+
+// Version returns the version of the protocol selected by the server, or the
+// version of the ClientHello.
+func (hello *Hello) Version() ProtocolVersion {
+	if hello.client {
+		return hello.msg.version.GetProtocolVersion()
+	}
+	return hello.msg.selectedVersion().GetProtocolVersion()
+}
+
+// SupportedCiphers returns the names of the cipher suites offered by the
+// client.
+func (hello *Hello) SupportedCiphers() []string {
+	return hello.msg.supportedCiphers()
+}
+
+// Cipher returns the name of the cipher suite selected by the server.
+func (hello *Hello) Cipher() string {
+	if hello.client {
+		return ""
+	}
+	return hello.msg.selected.cipherSuite.String()
+}
+
+// Ja3 returns the JA3 fingerprint of a ClientHello, or the JA3S fingerprint
+// of a ServerHello.
+func (hello *Hello) Ja3() string {
+	var hash string
+	if hello.client {
+		hash, _ = getJa3Fingerprint(hello.msg)
+	} else {
+		hash, _ = getJa3sFingerprint(hello.msg)
+	}
+	return hash
+}
+
+// Ja4 returns the JA4 fingerprint of a ClientHello, or the JA4S fingerprint
+// of a ServerHello.
+func (hello *Hello) Ja4(transport Ja4Transport) string {
+	if hello.client {
+		return getJa4Fingerprint(hello.msg, transport)
+	}
+	return getJa4sFingerprint(hello.msg, transport)
+}
+
+// ToMap returns the detailed fields of the message, as reported by the TLS
+// analyzer under tls.detailed.client_hello and tls.detailed.server_hello.
+func (hello *Hello) ToMap() common.MapStr {
+	return hello.msg.toMap()
+}
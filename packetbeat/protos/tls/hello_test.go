@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tls
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHello(t *testing.T) {
+	data, err := hex.DecodeString(rawClientHello)
+	assert.NoError(t, err)
+	msg := data[recordHeaderSize:]
+
+	hello, err := ParseHello(msg)
+	if assert.NoError(t, err) {
+		assert.True(t, hello.IsClient())
+		assert.Equal(t, "example.org", hello.ServerName())
+		assert.Equal(t, []string{"h2", "http/1.1"}, hello.ALPN())
+		assert.Equal(t, "94c485bca29d5392be53f2b8cf7f4304", hello.Ja3())
+		assert.Equal(t, "t12d1311h2_8b80da21ef18_eb7c9aabf852", hello.Ja4(Ja4TCP))
+		assert.Equal(t, "q12d1311h2_8b80da21ef18_eb7c9aabf852", hello.Ja4(Ja4QUIC))
+	}
+
+	_, err = ParseHello(msg[:2])
+	assert.Equal(t, ErrIncompleteHandshake, err)
+	_, err = ParseHello(msg[:len(msg)-1])
+	assert.Equal(t, ErrIncompleteHandshake, err)
+
+	data, err = hex.DecodeString(rawServerHello)
+	assert.NoError(t, err)
+	hello, err = ParseHello(data[recordHeaderSize:])
+	if assert.NoError(t, err) {
+		assert.False(t, hello.IsClient())
+		assert.Equal(t, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", hello.Cipher())
+		assert.Equal(t, []string{"h2"}, hello.ALPN())
+		assert.Equal(t, "1.2", hello.Version().Version)
+		assert.Equal(t, "49b45fc1ab090aa3a159778313fc9b9e", hello.Ja3())
+	}
+
+	// not a hello message
+	_, err = ParseHello([]byte{11, 0, 0, 0})
+	assert.Error(t, err)
+}
@@ -29,7 +29,7 @@ import (
 )
 
 var ja3test = []struct {
-	Packet, Fingerprint string
+	Packet, Fingerprint, Ja4 string
 }{
 	// Chrome on OSX
 	{
@@ -41,6 +41,7 @@ var ja3test = []struct {
 			"02683208687474702f312e3175500000000b00020100000a000a00086a6a001d" +
 			"00170018aaaa000100",
 		Fingerprint: "94c485bca29d5392be53f2b8cf7f4304",
+		Ja4:         "t12d1311h2_8b80da21ef18_eb7c9aabf852",
 	},
 	// Safari
 	{
@@ -53,6 +54,7 @@ var ja3test = []struct {
 			"3106737064792f3308687474702f312e31000500050100000000001200000017" +
 			"0000",
 		Fingerprint: "c07cb55f88702033a8f52c046d23e0b2",
+		Ja4:         "t12d1909h2_b5dc49c6fcca_2cdefc264be7",
 	},
 	// Handmade
 	{
@@ -60,6 +62,7 @@ var ja3test = []struct {
 			"ffffffffffffffffffffffffff0000080035002f000a00ff0100000800230000" +
 			"000f0000",
 		Fingerprint: "7a75198d3e18354a6763860d331ff46a",
+		Ja4:         "t10i040200_e2f6df6ad119_18d1e47e0978",
 	},
 }
 
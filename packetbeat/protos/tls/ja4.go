@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// This file implements the JA4 fingerprints of the TLS handshake (JA4, JA4S)
+// and of X.509 certificates (JA4X), as specified in
+// https://github.com/FoxIO-LLC/ja4.
+
+// Ja4Transport is the transport prefix of the JA4 and JA4S fingerprints.
+type Ja4Transport byte
+
+// Transports of the JA4 and JA4S fingerprints.
+const (
+	Ja4TCP  Ja4Transport = 't'
+	Ja4QUIC Ja4Transport = 'q'
+)
+
+const (
+	ja4EmptyHash = "000000000000"
+
+	extensionSNI  = 0
+	extensionALPN = 16
+)
+
+// getJa4Fingerprint returns the JA4 fingerprint of a client hello.
+func getJa4Fingerprint(hello *helloMessage, transport Ja4Transport) string {
+	var ciphers []uint16
+	for _, suite := range hello.supported.cipherSuites {
+		if !isGreaseValue(uint16(suite)) {
+			ciphers = append(ciphers, uint16(suite))
+		}
+	}
+
+	sni := 'i'
+	var extensions []uint16
+	for _, ext := range hello.extensions.InOrder {
+		switch ext {
+		case extensionSNI:
+			sni = 'd'
+			continue
+		case extensionALPN:
+			continue
+		}
+		extensions = append(extensions, uint16(ext))
+	}
+
+	version := hello.version
+	if versions := supportedVersions(hello.extensions.Raw[ExtensionSupportedVersions]); len(versions) > 0 {
+		version = versions[0]
+		for _, v := range versions[1:] {
+			if v.major > version.major || (v.major == version.major && v.minor > version.minor) {
+				version = v
+			}
+		}
+	}
+
+	a := fmt.Sprintf("%c%s%c%02d%02d%s", transport, ja4Version(version), sni,
+		min99(len(ciphers)), min99(len(hello.extensions.InOrder)), ja4ALPN(hello))
+
+	sortUint16s(ciphers)
+	b := ja4Hash(hexList(ciphers))
+
+	sortUint16s(extensions)
+	c := hexList(extensions)
+	if sigAlgs := extractJa3Array(hello.extensions.Raw[ExtensionSignatureAlgorithms], 2); len(sigAlgs) > 0 {
+		c += "_" + hexList(sigAlgs)
+	}
+	if len(extensions) == 0 {
+		c = ""
+	}
+
+	return a + "_" + b + "_" + ja4Hash(c)
+}
+
+// getJa4sFingerprint returns the JA4S fingerprint of a server hello.
+func getJa4sFingerprint(hello *helloMessage, transport Ja4Transport) string {
+	extensions := make([]uint16, len(hello.extensions.InOrder))
+	for idx, ext := range hello.extensions.InOrder {
+		extensions[idx] = uint16(ext)
+	}
+
+	a := fmt.Sprintf("%c%s%02d%s", transport, ja4Version(hello.selectedVersion()),
+		min99(len(extensions)), ja4ALPN(hello))
+	b := fmt.Sprintf("%04x", uint16(hello.selected.cipherSuite))
+
+	return a + "_" + b + "_" + ja4Hash(hexList(extensions))
+}
+
+// getJa4xFingerprint returns the JA4X fingerprint of a certificate, which
+// depends on the attributes of its issuer and subject and on its extensions
+// but not on their values.
+func getJa4xFingerprint(cert *x509.Certificate) string {
+	var extensions []string
+	for _, ext := range cert.Extensions {
+		extensions = append(extensions, oidToHex(ext.Id))
+	}
+	return ja4Hash(rdnOIDs(cert.RawIssuer)) + "_" +
+		ja4Hash(rdnOIDs(cert.RawSubject)) + "_" +
+		ja4Hash(strings.Join(extensions, ","))
+}
+
+// supportedVersions returns the versions of a client's supported_versions
+// extension, which is a one byte length followed by a list of versions.
+func supportedVersions(raw []byte) []tlsVersion {
+	if len(raw) < 1 || len(raw) < 1+int(raw[0]) {
+		return nil
+	}
+	var versions []tlsVersion
+	for pos := 1; pos+1 <= int(raw[0]); pos += 2 {
+		if !isGreaseValue(uint16(raw[pos])<<8 | uint16(raw[pos+1])) {
+			versions = append(versions, tlsVersion{raw[pos], raw[pos+1]})
+		}
+	}
+	return versions
+}
+
+func ja4Version(version tlsVersion) string {
+	switch uint16(version.major)<<8 | uint16(version.minor) {
+	case 0x0304:
+		return "13"
+	case 0x0303:
+		return "12"
+	case 0x0302:
+		return "11"
+	case 0x0301:
+		return "10"
+	case 0x0300:
+		return "s3"
+	case 0x0200:
+		return "s2"
+	case 0xfeff:
+		return "d1"
+	case 0xfefd:
+		return "d2"
+	case 0xfefc:
+		return "d3"
+	}
+	return "00"
+}
+
+// ja4ALPN returns the first and last characters of the first ALPN value, or
+// the first and last hex digits when they are not alphanumeric.
+func ja4ALPN(hello *helloMessage) string {
+	protos, _ := hello.extensions.Parsed["application_layer_protocol_negotiation"].([]string)
+	if len(protos) == 0 || len(protos[0]) == 0 {
+		return "00"
+	}
+	first, last := protos[0][0], protos[0][len(protos[0])-1]
+	if isAlphanumeric(first) && isAlphanumeric(last) {
+		return string([]byte{first, last})
+	}
+	return fmt.Sprintf("%02x", first)[:1] + fmt.Sprintf("%02x", last)[1:]
+}
+
+func isAlphanumeric(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// ja4Hash returns the truncated SHA-256 hash used in JA4 fingerprints.
+func ja4Hash(s string) string {
+	if s == "" {
+		return ja4EmptyHash
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func hexList(values []uint16) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%04x", v)
+	}
+	return strings.Join(parts, ",")
+}
+
+func sortUint16s(values []uint16) {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+}
+
+func min99(n int) int {
+	if n > 99 {
+		return 99
+	}
+	return n
+}
+
+// rdnOIDs returns the OIDs of the attributes of a DER-encoded name, in
+// order, as a comma separated list of hex strings.
+func rdnOIDs(raw []byte) string {
+	var rdns pkix.RDNSequence
+	if _, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		return ""
+	}
+	var oids []string
+	for _, rdn := range rdns {
+		for _, attr := range rdn {
+			oids = append(oids, oidToHex(attr.Type))
+		}
+	}
+	return strings.Join(oids, ",")
+}
+
+// oidToHex returns the hex representation of the DER encoding of an OID,
+// without its tag and length.
+func oidToHex(oid asn1.ObjectIdentifier) string {
+	der, err := asn1.Marshal(oid)
+	if err != nil {
+		return ""
+	}
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &raw); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(raw.Bytes)
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tls
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/packetbeat/protos"
+)
+
+func TestJa4(t *testing.T) {
+	for _, test := range ja3test {
+		results, tls := testInit()
+		tls.jaFingerprints[fingerprintJa4] = true
+		reqData, err := hex.DecodeString(test.Packet)
+		assert.NoError(t, err)
+
+		tcpTuple := testTCPTuple()
+		req := protos.Packet{Payload: reqData}
+		var private protos.ProtocolData
+
+		private = tls.Parse(&req, tcpTuple, 0, private)
+		tls.ReceivedFin(tcpTuple, 0, private)
+		assert.Len(t, results.events, 1)
+		event := results.events[0]
+		actual, err := event.Fields.GetValue("tls.client.ja4")
+		assert.NoError(t, err)
+		assert.Equal(t, test.Ja4, actual)
+	}
+}
+
+func TestServerFingerprints(t *testing.T) {
+	results, tls := testInit()
+	tls.jaFingerprints, _ = getJaFingerprints(jaFingerprintNames)
+	algo, err := GetFingerprintAlgorithm("sha256")
+	assert.NoError(t, err)
+	tls.chainFingerprints = []*FingerprintAlgorithm{algo}
+
+	tcpTuple := testTCPTuple()
+	var private protos.ProtocolData
+	for _, msg := range []struct {
+		dir  uint8
+		data string
+	}{
+		{0, rawClientHello},
+		{1, rawServerHello},
+		{1, certsMsg},
+		{0, rawChangeCipherSpec},
+		{1, rawChangeCipherSpec},
+	} {
+		reqData, err := hex.DecodeString(msg.data)
+		assert.NoError(t, err)
+		req := protos.Packet{Payload: reqData}
+		private = tls.Parse(&req, tcpTuple, msg.dir, private)
+	}
+	assert.Len(t, results.events, 1)
+	event := results.events[0]
+
+	for field, expected := range map[string]interface{}{
+		"tls.client.ja3":  "94c485bca29d5392be53f2b8cf7f4304",
+		"tls.client.ja4":  "t12d1311h2_8b80da21ef18_eb7c9aabf852",
+		"tls.server.ja3s": "49b45fc1ab090aa3a159778313fc9b9e",
+		"tls.server.ja4s": "t1205h2_c02f_635f80083096",
+		"tls.server.ja4x": []string{
+			"7d5dbb3783b4_2166164053c1_5e17a2514980",
+			"7d5dbb3783b4_7d5dbb3783b4_9c5875a5c227",
+		},
+		"tls.server.certificate_chain_hash.sha256": []string{
+			"642DE54D84C30494157F53F657BF9F89B4EA6C8B16351FD7EC258D556F821040",
+			"19400BE5B7A31FB733917700789D2F0A2471C0C9D506C0E504C06C16D7CB17C0",
+		},
+	} {
+		actual, err := event.Fields.GetValue(field)
+		assert.NoError(t, err, field)
+		assert.Equal(t, expected, actual, field)
+	}
+	_, err = event.Fields.GetValue("tls.client.ja4x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// keyLogRetention is how long the secrets of a session are kept after
+	// being read. Secrets are needed when the handshake of the session is
+	// seen, shortly after they are written by the client.
+	keyLogRetention = 10 * time.Minute
+
+	// minKeyLogReloadPeriod is the minimum time between two reads of the
+	// key log files.
+	minKeyLogReloadPeriod = 100 * time.Millisecond
+)
+
+// keyLogs holds the key logs opened by the TLS analyzers, by path. The
+// analyzers of all the workers share the key log of a path.
+var keyLogs = struct {
+	sync.Mutex
+	byPath map[string]*keyLog
+}{byPath: map[string]*keyLog{}}
+
+// keyLog holds the session secrets read from key log files in the NSS format,
+// as written by the TLS libraries when the SSLKEYLOGFILE environment variable
+// is set. The path is either a key log file or a directory of key log files.
+// Files are read again in the background when secrets are missing, at most
+// once per reload period, to get the sessions appended since the last read.
+// Secrets are looked up in the snapshot of the last read, so that lookups
+// never wait for the files to be read.
+type keyLog struct {
+	path         string
+	reloadPeriod time.Duration
+
+	// snapshot of the secrets indexed by the client random of the session,
+	// a map[string]*sessionSecrets. Snapshots are not modified once stored.
+	sessions atomic.Value
+	// reload requests a read of the files
+	reload chan struct{}
+
+	// mu serializes the reads of the files
+	mu       sync.Mutex
+	lastLoad time.Time
+	// read offset of the files found by the last read
+	files map[string]int64
+}
+
+// sessionSecrets are the secrets of a session logged by the client.
+type sessionSecrets struct {
+	loaded time.Time
+
+	// TLS 1.2 and earlier
+	masterSecret []byte
+
+	// TLS 1.3
+	clientHandshakeSecret []byte
+	serverHandshakeSecret []byte
+	clientTrafficSecret   []byte
+	serverTrafficSecret   []byte
+}
+
+// keyLogLabels maps the labels of the key log lines to the secret they hold.
+var keyLogLabels = map[string]func(*sessionSecrets) *[]byte{
+	"CLIENT_RANDOM":                   func(s *sessionSecrets) *[]byte { return &s.masterSecret },
+	"CLIENT_HANDSHAKE_TRAFFIC_SECRET": func(s *sessionSecrets) *[]byte { return &s.clientHandshakeSecret },
+	"SERVER_HANDSHAKE_TRAFFIC_SECRET": func(s *sessionSecrets) *[]byte { return &s.serverHandshakeSecret },
+	"CLIENT_TRAFFIC_SECRET_0":         func(s *sessionSecrets) *[]byte { return &s.clientTrafficSecret },
+	"SERVER_TRAFFIC_SECRET_0":         func(s *sessionSecrets) *[]byte { return &s.serverTrafficSecret },
+}
+
+// openKeyLog returns the key log of the path, shared by the TLS analyzers of
+// all the workers. The files are read by a single goroutine per key log.
+func openKeyLog(path string, reloadPeriod time.Duration) *keyLog {
+	keyLogs.Lock()
+	defer keyLogs.Unlock()
+
+	if kl := keyLogs.byPath[path]; kl != nil {
+		return kl
+	}
+	kl := newKeyLog(path, reloadPeriod)
+	go kl.run()
+	keyLogs.byPath[path] = kl
+	return kl
+}
+
+// newKeyLog creates a key log and reads its files. Reading the files again
+// when secrets are missing requires run to be started.
+func newKeyLog(path string, reloadPeriod time.Duration) *keyLog {
+	if reloadPeriod < minKeyLogReloadPeriod {
+		reloadPeriod = minKeyLogReloadPeriod
+	}
+	kl := &keyLog{
+		path:         path,
+		reloadPeriod: reloadPeriod,
+		reload:       make(chan struct{}, 1),
+		files:        map[string]int64{},
+	}
+	kl.sessions.Store(map[string]*sessionSecrets{})
+	kl.load()
+	return kl
+}
+
+// run reads the files when requested by lookup, waiting for the reload
+// period to have elapsed since the last read.
+func (kl *keyLog) run() {
+	for range kl.reload {
+		kl.mu.Lock()
+		wait := kl.reloadPeriod - time.Since(kl.lastLoad)
+		kl.mu.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+		kl.load()
+	}
+}
+
+func (kl *keyLog) snapshot() map[string]*sessionSecrets {
+	return kl.sessions.Load().(map[string]*sessionSecrets)
+}
+
+// lookup returns the secrets of the session started by the client random, or
+// nil if none is known. If the secrets selected by need are not known, the
+// files are read again in the background, for a later lookup to find them.
+func (kl *keyLog) lookup(clientRandom []byte, need func(*sessionSecrets) []byte) []byte {
+	if s := kl.snapshot()[string(clientRandom)]; s != nil {
+		if secret := need(s); secret != nil {
+			return secret
+		}
+	}
+	select {
+	case kl.reload <- struct{}{}:
+	default:
+		// a read is already pending
+	}
+	return nil
+}
+
+// load reads the lines appended to the files since the last read, and
+// stores a new snapshot of the secrets. The secrets older than the
+// retention and the read offsets of the removed files are dropped. Errors
+// are logged without checking isDebug, which is set by the analyzers
+// running concurrently.
+func (kl *keyLog) load() {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	kl.lastLoad = time.Now()
+	current := kl.snapshot()
+	sessions := make(map[string]*sessionSecrets, len(current))
+	for random, s := range current {
+		if kl.lastLoad.Sub(s.loaded) <= keyLogRetention {
+			sessions[random] = s
+		}
+	}
+	files := map[string]int64{}
+	defer func() {
+		kl.files = files
+		kl.sessions.Store(sessions)
+	}()
+
+	info, err := os.Stat(kl.path)
+	if err != nil {
+		debugf("failed to read key log: %v", err)
+		return
+	}
+	if !info.IsDir() {
+		kl.loadFile(kl.path, files, sessions)
+		return
+	}
+	entries, err := ioutil.ReadDir(kl.path)
+	if err != nil {
+		debugf("failed to read key log directory: %v", err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			kl.loadFile(filepath.Join(kl.path, entry.Name()), files, sessions)
+		}
+	}
+}
+
+// loadFile reads the lines appended to a key log file since the last read,
+// and records its new read offset in files. A file smaller than the read
+// offset has been replaced and is read again from its start.
+func (kl *keyLog) loadFile(path string, files map[string]int64, sessions map[string]*sessionSecrets) {
+	f, err := os.Open(path)
+	if err != nil {
+		debugf("failed to open key log file: %v", err)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	offset := kl.files[path]
+	if info.Size() < offset {
+		offset = 0
+	}
+	files[path] = offset
+	if info.Size() == offset {
+		return
+	}
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// partial lines are read once completed
+			break
+		}
+		offset += int64(len(line))
+		kl.parseLine(line, sessions)
+	}
+	files[path] = offset
+}
+
+// parseLine parses a "<label> <client random> <secret>" line into sessions.
+// The secrets of a session already known are copied before being updated,
+// as they are shared with the previous snapshots. Comments and unknown
+// labels are ignored.
+func (kl *keyLog) parseLine(line []byte, sessions map[string]*sessionSecrets) {
+	fields := bytes.Fields(line)
+	if len(fields) != 3 {
+		return
+	}
+	field, ok := keyLogLabels[string(fields[0])]
+	if !ok {
+		return
+	}
+	random := make([]byte, hex.DecodedLen(len(fields[1])))
+	if _, err := hex.Decode(random, fields[1]); err != nil || len(random) != randomLength {
+		return
+	}
+	secret := make([]byte, hex.DecodedLen(len(fields[2])))
+	if _, err := hex.Decode(secret, fields[2]); err != nil {
+		return
+	}
+
+	s := &sessionSecrets{}
+	if known := sessions[string(random)]; known != nil {
+		*s = *known
+	}
+	s.loaded = kl.lastLoad
+	*field(s) = secret
+	sessions[string(random)] = s
+}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tls
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testClientRandom = bytes.Repeat([]byte{0xab}, randomLength)
+	testRandomHex    = strings.Repeat("ab", randomLength)
+)
+
+func masterSecret(s *sessionSecrets) []byte { return s.masterSecret }
+
+func clientTrafficSecret(s *sessionSecrets) []byte { return s.clientTrafficSecret }
+
+func TestKeyLogParse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keylog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sslkeylog.txt")
+	content := strings.Join([]string{
+		"# SSL/TLS secrets log file, generated by NSS",
+		"CLIENT_RANDOM " + testRandomHex + " 0102",
+		"CLIENT_TRAFFIC_SECRET_0 " + testRandomHex + " 0304",
+		"EXPORTER_SECRET " + testRandomHex + " 0506",
+		"CLIENT_RANDOM abcd 0708",
+		"CLIENT_RANDOM " + testRandomHex,
+		"CLIENT_RANDOM " + testRandomHex + " zz",
+		"",
+	}, "\n")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	kl := newKeyLog(path, time.Hour)
+	assert.Len(t, kl.snapshot(), 1)
+	assert.Equal(t, []byte{1, 2}, kl.lookup(testClientRandom, masterSecret))
+	assert.Equal(t, []byte{3, 4}, kl.lookup(testClientRandom, clientTrafficSecret))
+	assert.Nil(t, kl.lookup(bytes.Repeat([]byte{1}, randomLength), masterSecret))
+}
+
+func TestKeyLogReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keylog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sslkeylog.txt")
+	// partial lines are ignored until completed
+	line := "CLIENT_RANDOM " + testRandomHex + " 0102"
+	if err := ioutil.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal(err)
+	}
+	kl := newKeyLog(path, 0)
+	assert.Nil(t, kl.lookup(testClientRandom, masterSecret))
+	// the missing secrets request a read of the files
+	assert.Len(t, kl.reload, 1)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("\n")
+	f.Close()
+	kl.load()
+	assert.Equal(t, []byte{1, 2}, kl.lookup(testClientRandom, masterSecret))
+
+	// a truncated file is read again from its start
+	otherRandom := bytes.Repeat([]byte{0xcd}, randomLength)
+	line = "CLIENT_RANDOM " + strings.Repeat("cd", randomLength) + " 03\n"
+	if err := ioutil.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal(err)
+	}
+	kl.load()
+	assert.Equal(t, []byte{3}, kl.lookup(otherRandom, masterSecret))
+}
+
+func TestKeyLogReloadPeriod(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keylog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sslkeylog.txt")
+	assert.Equal(t, minKeyLogReloadPeriod, newKeyLog(path, 0).reloadPeriod)
+
+	kl := openKeyLog(path, 0)
+	assert.True(t, kl == openKeyLog(path, time.Hour), "key log not shared")
+
+	line := "CLIENT_RANDOM " + testRandomHex + " 0102\n"
+	if err := ioutil.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the files are read in the background once requested by a lookup
+	var secret []byte
+	for deadline := time.Now().Add(5 * time.Second); secret == nil && time.Now().Before(deadline); {
+		if secret = kl.lookup(testClientRandom, masterSecret); secret == nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	assert.Equal(t, []byte{1, 2}, secret)
+}
+
+func TestKeyLogDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keylog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	otherRandom := bytes.Repeat([]byte{0xcd}, randomLength)
+	files := map[string]string{
+		"client1.log": "CLIENT_RANDOM " + testRandomHex + " 01\n",
+		"client2.log": "CLIENT_RANDOM " + strings.Repeat("cd", randomLength) + " 02\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	kl := newKeyLog(dir, 0)
+	assert.Equal(t, []byte{1}, kl.lookup(testClientRandom, masterSecret))
+	assert.Equal(t, []byte{2}, kl.lookup(otherRandom, masterSecret))
+	assert.Len(t, kl.files, 2)
+
+	// the read offsets of removed files are dropped
+	if err := os.Remove(filepath.Join(dir, "client1.log")); err != nil {
+		t.Fatal(err)
+	}
+	kl.load()
+	assert.NotContains(t, kl.files, filepath.Join(dir, "client1.log"))
+	assert.Contains(t, kl.files, filepath.Join(dir, "client2.log"))
+}
+
+func TestKeyLogRetention(t *testing.T) {
+	kl := newKeyLog(filepath.Join(os.TempDir(), "missing-keylog"), 0)
+	kl.parseLine([]byte("CLIENT_RANDOM "+testRandomHex+" 01"), kl.snapshot())
+	kl.snapshot()[string(testClientRandom)].loaded = time.Now().Add(-2 * keyLogRetention)
+
+	kl.load()
+	assert.Empty(t, kl.snapshot())
+}
@@ -48,6 +48,7 @@ const (
 	handshakeHeaderSize = 4
 	helloHeaderLength   = 7
 	randomDataLength    = 28
+	randomLength        = 32
 )
 
 type recordType uint8
@@ -62,13 +63,17 @@ const (
 type handshakeType uint8
 
 const (
-	helloRequest       handshakeType = 0
-	clientHello                      = 1
-	serverHello                      = 2
-	certificate                      = 11
-	serverKeyExchange                = 12
-	certificateRequest               = 13
-	clientKeyExchange                = 16
+	helloRequest        handshakeType = 0
+	clientHello                       = 1
+	serverHello                       = 2
+	newSessionTicket                  = 4
+	encryptedExtensions               = 8
+	certificate                       = 11
+	serverKeyExchange                 = 12
+	certificateRequest                = 13
+	clientKeyExchange                 = 16
+	finished                          = 20
+	keyUpdate                         = 24
 )
 
 type parserResult int8
@@ -101,6 +106,15 @@ type parser struct {
 
 	// If a key-exchange message has been sent. Used to detect session resumption
 	keyExchanged bool
+
+	// If TLS 1.3 has been negotiated. The handshake messages following the
+	// hello messages are encrypted.
+	tls13 bool
+
+	// Finished and KeyUpdate messages seen. Only available if the handshake
+	// is decrypted.
+	finished   bool
+	keyUpdates int
 }
 
 type tlsVersion struct {
@@ -121,6 +135,7 @@ type handshakeHeader struct {
 type helloMessage struct {
 	version   tlsVersion
 	timestamp uint32
+	random    []byte
 	sessionID string
 	ticket    tlsTicket
 	supported struct {
@@ -206,6 +221,15 @@ func (hello *helloMessage) toMap() common.MapStr {
 	return m
 }
 
+// selectedVersion returns the version selected by a server hello, from the
+// supported_versions extension used to negotiate TLS 1.3 if present.
+func (hello *helloMessage) selectedVersion() tlsVersion {
+	if raw, ok := hello.extensions.Raw[ExtensionSupportedVersions]; ok && len(raw) >= 2 {
+		return tlsVersion{raw[0], raw[1]}
+	}
+	return hello.version
+}
+
 func (hello *helloMessage) supportedCiphers() []string {
 	ciphers := make([]string, len(hello.supported.cipherSuites))
 	for idx, code := range hello.supported.cipherSuites {
@@ -237,15 +261,15 @@ func (parser *parser) parse(buf *streambuf.Buffer) parserResult {
 			if isDebug {
 				debugf("handshake completed")
 			}
-			// discard remaining data for this stream (encrypted)
-			buf.Advance(buf.Len())
+			// the remaining data for this stream is encrypted
+			buf.Advance(limit)
 			return resultEncrypted
 
 		case recordTypeHandshake:
 			if isDebug {
 				debugf("got handshake record of size %d", header.length)
 			}
-			if err = parser.bufferHandshake(buf, int(header.length)); err != nil {
+			if err = parser.bufferHandshake(buf.Bytes()[recordHeaderSize:limit]); err != nil {
 				logp.Warn("Error parsing handshake message: %v", err)
 				return resultFailed
 			}
@@ -257,7 +281,11 @@ func (parser *parser) parse(buf *streambuf.Buffer) parserResult {
 			}
 
 		case recordTypeApplicationData:
-			// TODO: Request / Response analytics
+			if parser.tls13 {
+				// the handshake messages following the hello messages
+				// are encrypted
+				return resultEncrypted
+			}
 			if isDebug {
 				debugf("ignoring application data length %d", header.length)
 			}
@@ -277,9 +305,9 @@ func (parser *parser) parse(buf *streambuf.Buffer) parserResult {
 	return resultMore
 }
 
-func (parser *parser) bufferHandshake(buf *streambuf.Buffer, length int) error {
+func (parser *parser) bufferHandshake(data []byte) error {
 	// TODO: parse in-place if message in received buffer is complete
-	if err := parser.handshakeBuf.Append(buf.Bytes()[recordHeaderSize : recordHeaderSize+length]); err != nil {
+	if err := parser.handshakeBuf.Append(data); err != nil {
 		logp.Warn("failed appending to buffer: %v", err)
 		// Discard buffer
 		parser.handshakeBuf.Init(nil, false)
@@ -343,10 +371,16 @@ func (parser *parser) parseHandshake(handshakeType handshakeType, buffer bufferV
 		if parser.hello = parseServerHello(buffer); parser.hello == nil {
 			return false
 		}
+		parser.tls13 = parser.hello.selectedVersion() == tlsVersion{3, 4}
 		return true
 
 	case certificate:
-		certs := parseCertificates(buffer)
+		var certs []*x509.Certificate
+		if parser.tls13 {
+			certs = parseCertificates13(buffer)
+		} else {
+			certs = parseCertificates(buffer)
+		}
 		parser.certificates = append(parser.certificates, certs...)
 
 	case certificateRequest:
@@ -360,6 +394,12 @@ func (parser *parser) parseHandshake(handshakeType handshakeType, buffer bufferV
 	case serverKeyExchange:
 		parser.setDirection(dirServer)
 		parser.keyExchanged = true
+
+	case finished:
+		parser.finished = true
+
+	case keyUpdate:
+		parser.keyUpdates++
 	}
 	return true
 }
@@ -377,11 +417,12 @@ func parseCommonHello(buffer bufferView, dest *helloMessage) (int, bool) {
 	if !buffer.read8(0, &dest.version.major) ||
 		!buffer.read8(1, &dest.version.minor) ||
 		!buffer.read32Net(2, &dest.timestamp) ||
-		// ignore 28 random bytes
 		!buffer.read8(6+randomDataLength, &sessionIDLength) {
 		logp.Warn("failed reading hello message")
 		return 0, false
 	}
+	// the random, including the timestamp, is kept for decryption
+	dest.random = append([]byte(nil), buffer.readBytes(2, randomLength)...)
 
 	if dest.version.major != 3 {
 		logp.Warn("Not a TLS hello (reported version %d.%d)",
@@ -502,6 +543,40 @@ func parseCertificates(buffer bufferView) (certs []*x509.Certificate) {
 	return certs
 }
 
+// parseCertificates13 parses a TLS 1.3 certificate message, where the
+// certificates are preceded by a request context and followed by extensions.
+func parseCertificates13(buffer bufferView) (certs []*x509.Certificate) {
+	var contextLen uint8
+	var totalLen uint32
+	if !buffer.read8(0, &contextLen) {
+		return nil
+	}
+	base := 1 + int(contextLen)
+	if !buffer.read24Net(base, &totalLen) || base+3+int(totalLen) != buffer.length() {
+		return nil
+	}
+
+	for pos, limit := base+3, base+3+int(totalLen); pos+3 <= limit; {
+		var certLen uint32
+		var extLen uint16
+		if !buffer.read24Net(pos, &certLen) || pos+5+int(certLen) > limit ||
+			!buffer.read16Net(pos+3+int(certLen), &extLen) {
+			return nil
+		}
+		raw := buffer.readBytes(pos+3, int(certLen))
+		if len(raw) != int(certLen) {
+			return nil
+		}
+		parsed, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil
+		}
+		certs = append(certs, parsed)
+		pos += 5 + int(certLen) + int(extLen)
+	}
+	return certs
+}
+
 func (version tlsVersion) String() string {
 	if version.major == 3 {
 		if version.minor > 0 {
@@ -19,6 +19,7 @@ package tls
 
 import (
 	"crypto/x509"
+	"fmt"
 	"strings"
 	"time"
 
@@ -48,6 +49,10 @@ type tlsConnectionData struct {
 	handshakeCompleted int8
 	eventSent          bool
 	startTime, endTime time.Time
+	capture            pb.Capture
+
+	// decryption of the connection, nil if not decrypted
+	decrypter *decrypter
 }
 
 // TLS protocol plugin
@@ -58,9 +63,17 @@ type tlsPlugin struct {
 	includeRawCertificates bool
 	includeDetailedFields  bool
 	fingerprints           []*FingerprintAlgorithm
+	jaFingerprints         map[string]bool
+	chainFingerprints      []*FingerprintAlgorithm
 	transactionTimeout     time.Duration
 	results                protos.Reporter
 	watcher                procs.ProcessesWatcher
+
+	// decryption of the connections to the ports of decryptedProtocols,
+	// using the secrets of keyLog
+	keyLog             *keyLog
+	decryptedProtocols map[uint16]protos.TCPPlugin
+	decryptedConfig    []decryptedProtocolConfig
 }
 
 var (
@@ -69,6 +82,8 @@ var (
 
 	// ensure that tlsPlugin fulfills the TCPPlugin interface
 	_ protos.TCPPlugin = &tlsPlugin{}
+
+	_ protos.ProtocolsAwarePlugin = &tlsPlugin{}
 )
 
 func init() {
@@ -121,6 +136,37 @@ func (plugin *tlsPlugin) setFromConfig(config *tlsConfig) error {
 		}
 		plugin.fingerprints = append(plugin.fingerprints, algo)
 	}
+	for _, hashName := range config.ChainFingerprints {
+		algo, err := GetFingerprintAlgorithm(hashName)
+		if err != nil {
+			return err
+		}
+		plugin.chainFingerprints = append(plugin.chainFingerprints, algo)
+	}
+	jaFingerprints, err := getJaFingerprints(config.JaFingerprints)
+	if err != nil {
+		return err
+	}
+	plugin.jaFingerprints = jaFingerprints
+	if config.Decryption.KeyLog != "" {
+		plugin.keyLog = openKeyLog(config.Decryption.KeyLog, config.Decryption.ReloadPeriod)
+		plugin.decryptedConfig = config.Decryption.Protocols
+	}
+	return nil
+}
+
+// SetProtocols resolves the analyzers of the decrypted application data.
+func (plugin *tlsPlugin) SetProtocols(protocols protos.Protocols) error {
+	plugin.decryptedProtocols = map[uint16]protos.TCPPlugin{}
+	for _, config := range plugin.decryptedConfig {
+		app := protocols.GetTCP(protos.Lookup(config.Type))
+		if app == nil || app == protos.TCPPlugin(plugin) {
+			return fmt.Errorf("protocol %s can't analyze decrypted TLS traffic, it must be an enabled TCP protocol", config.Type)
+		}
+		for _, port := range config.Ports {
+			plugin.decryptedProtocols[uint16(port)] = app
+		}
+	}
 	return nil
 }
 
@@ -132,6 +178,27 @@ func (plugin *tlsPlugin) ConnectionTimeout() time.Duration {
 	return plugin.transactionTimeout
 }
 
+// DetectTCP recognizes the handshake record holding the ClientHello or
+// ServerHello message.
+func (plugin *tlsPlugin) DetectTCP(data []byte) protos.Detection {
+	return detectTLS(data)
+}
+
+func detectTLS(data []byte) protos.Detection {
+	for i, c := range data {
+		switch {
+		case i == 0 && c != recordTypeHandshake,
+			i == 1 && c != 3,
+			i == 2 && c > 4,
+			i == 5 && c != clientHello && c != serverHello:
+			return protos.DetectionMismatch
+		case i == 5:
+			return protos.DetectionMatch
+		}
+	}
+	return protos.DetectionNeedMore
+}
+
 func (plugin *tlsPlugin) Parse(
 	pkt *protos.Packet,
 	tcptuple *common.TCPTuple,
@@ -143,6 +210,7 @@ func (plugin *tlsPlugin) Parse(
 	conn := ensureTLSConnection(private)
 	if private == nil {
 		conn.startTime = pkt.Ts
+		conn.capture = pkt.Capture
 	}
 	conn = plugin.doParse(conn, pkt, tcptuple, dir)
 	if conn == nil {
@@ -172,16 +240,19 @@ func (plugin *tlsPlugin) doParse(
 	dir uint8,
 ) *tlsConnectionData {
 
-	// Ignore further traffic after the handshake is completed (encrypted connection)
-	// TODO: request/response analysis
-	if 0 != conn.handshakeCompleted&(1<<dir) {
+	// Ignore further traffic after the handshake is completed (encrypted connection),
+	// unless it is decrypted
+	encrypted := 0 != conn.handshakeCompleted&(1<<dir)
+	if encrypted && !conn.decrypting() {
 		return conn
 	}
 
 	st := conn.streams[dir]
+	other := conn.streams[1-dir]
 	if st == nil {
 		st = newStream(tcptuple)
 		st.cmdlineTuple = plugin.watcher.FindProcessesTupleTCP(tcptuple.IPPort())
+		st.parser.tls13 = other != nil && other.parser.tls13
 		conn.streams[dir] = st
 	}
 
@@ -192,6 +263,11 @@ func (plugin *tlsPlugin) doParse(
 		return nil
 	}
 
+	if encrypted {
+		plugin.decryptStreams(conn, pkt, tcptuple, dir)
+		return conn
+	}
+
 	state := resultOK
 	for state == resultOK && st.Buf.Len() > 0 {
 
@@ -215,12 +291,45 @@ func (plugin *tlsPlugin) doParse(
 				conn.endTime = pkt.Ts
 				plugin.sendEvent(conn)
 			}
+			if conn.decrypter == nil {
+				conn.decrypter = plugin.newDecrypter(conn, tcptuple)
+			}
+			if conn.decrypting() {
+				plugin.decryptStreams(conn, pkt, tcptuple, dir)
+			} else {
+				// discard remaining data for this stream (encrypted)
+				st.Buf.Advance(st.Buf.Len())
+			}
 		}
 	}
 
+	// the server hello selects TLS 1.3 for both directions
+	if st.parser.tls13 && other != nil {
+		other.parser.tls13 = true
+	}
 	return conn
 }
 
+// decryptStreams decrypts the records received in the direction dir. The
+// records of the other direction waiting for the session secrets are
+// decrypted first, as they were received before.
+func (plugin *tlsPlugin) decryptStreams(
+	conn *tlsConnectionData,
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	other := conn.streams[1-dir]
+	if other != nil && other.Buf.Len() > 0 && conn.handshakeCompleted&(1<<(1-dir)) != 0 {
+		plugin.decrypt(conn.decrypter, other, pkt, tcptuple, 1-dir)
+	}
+	plugin.decrypt(conn.decrypter, conn.streams[dir], pkt, tcptuple, dir)
+}
+
+func (conn *tlsConnectionData) decrypting() bool {
+	return conn.decrypter != nil && !conn.decrypter.failed
+}
+
 func newStream(tcptuple *common.TCPTuple) *stream {
 	s := &stream{
 		tcptuple: tcptuple,
@@ -234,6 +343,9 @@ func (plugin *tlsPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
 
 	if conn := ensureTLSConnection(private); conn != nil {
 		plugin.sendEvent(conn)
+		if d := conn.decrypter; d != nil {
+			d.appData = d.app.ReceivedFin(tcptuple, dir, d.appData)
+		}
 	}
 	return private
 }
@@ -242,10 +354,26 @@ func (plugin *tlsPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
 	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
 	if conn := ensureTLSConnection(private); conn != nil {
 		plugin.sendEvent(conn)
+		if d := conn.decrypter; d != nil {
+			// records can't be decrypted after a gap
+			d.appData, _ = d.app.GapInStream(tcptuple, dir, nbytes, d.appData)
+		}
 	}
 	return private, true
 }
 
+// Expired notifies the analyzer of the decrypted application data of the
+// expiration of the connection.
+func (plugin *tlsPlugin) Expired(tcptuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*tlsConnectionData)
+	if !ok || conn == nil || conn.decrypter == nil {
+		return
+	}
+	if app, ok := conn.decrypter.app.(protos.ExpirationAwareTCPPlugin); ok {
+		app.Expired(tcptuple, conn.decrypter.appData)
+	}
+}
+
 func (plugin *tlsPlugin) sendEvent(conn *tlsConnectionData) {
 	if !conn.eventSent {
 		conn.eventSent = true
@@ -292,7 +420,9 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 	if client.parser.hello != nil {
 		clientHello = client.parser.hello
 		detailed["client_hello"] = clientHello.toMap()
-		tls.ClientJa3, _ = getJa3Fingerprint(clientHello)
+		if plugin.jaFingerprints[fingerprintJa3] {
+			tls.ClientJa3, _ = getJa3Fingerprint(clientHello)
+		}
 		tlsClientSupportedCiphers = clientHello.supportedCiphers()
 	} else {
 		clientHello = emptyHello
@@ -301,6 +431,9 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 		serverHello = server.parser.hello
 		detailed["server_hello"] = serverHello.toMap()
 		tls.Cipher = serverHello.selected.cipherSuite.String()
+		if plugin.jaFingerprints[fingerprintJa3s] {
+			tls.ServerJa3s, _ = getJa3sFingerprint(serverHello)
+		}
 	} else {
 		serverHello = emptyHello
 	}
@@ -399,16 +532,7 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 	// TLS version in use
 	var version tlsVersion
 	if !serverHello.version.IsZero() {
-		var ok bool
-		var raw []byte
-		const supportedVersionsExt = 43
-		if raw, ok = serverHello.extensions.Raw[supportedVersionsExt]; ok {
-			version.major = raw[0]
-			version.minor = raw[1]
-		}
-		if !ok {
-			version = serverHello.version
-		}
+		version = serverHello.selectedVersion()
 	} else if !clientHello.version.IsZero() {
 		version = clientHello.version
 	}
@@ -423,6 +547,7 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 	pbf.Event.End = conn.endTime
 	pbf.Network.Transport = "tcp"
 	pbf.Network.Protocol = "tls"
+	pbf.AddCapture(&conn.capture)
 
 	fields := evt.Fields
 	fields["type"] = pbf.Network.Protocol
@@ -444,6 +569,7 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 
 	// Serialize ECS TLS fields
 	pb.MarshalStruct(fields, "tls", tls)
+	plugin.putFingerprints(fields, client, server)
 	if plugin.includeDetailedFields {
 		fields.Put("tls.detailed", detailed)
 		if cert, ok := detailed["client_certificate"]; ok {
@@ -482,6 +608,42 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 	return evt
 }
 
+// putFingerprints adds the JA4 fingerprints of the handshake and the
+// certificates, and the hashes of the certificate chains, to the event.
+func (plugin *tlsPlugin) putFingerprints(fields common.MapStr, client, server *stream) {
+	if plugin.jaFingerprints[fingerprintJa4] && client.parser.hello != nil {
+		fields.Put("tls.client.ja4", getJa4Fingerprint(client.parser.hello, Ja4TCP))
+	}
+	if plugin.jaFingerprints[fingerprintJa4s] && server.parser.hello != nil {
+		fields.Put("tls.server.ja4s", getJa4sFingerprint(server.parser.hello, Ja4TCP))
+	}
+	for _, side := range []struct {
+		prefix string
+		certs  []*x509.Certificate
+	}{
+		{"tls.client", client.parser.certificates},
+		{"tls.server", server.parser.certificates},
+	} {
+		if len(side.certs) == 0 {
+			continue
+		}
+		if plugin.jaFingerprints[fingerprintJa4x] {
+			ja4x := make([]string, len(side.certs))
+			for idx, cert := range side.certs {
+				ja4x[idx] = getJa4xFingerprint(cert)
+			}
+			fields.Put(side.prefix+".ja4x", ja4x)
+		}
+		for _, fp := range plugin.chainFingerprints {
+			hashes := make([]string, len(side.certs))
+			for idx, cert := range side.certs {
+				hashes[idx] = strings.ToUpper(fp.algo.Hash(cert.Raw))
+			}
+			fields.Put(side.prefix+".certificate_chain_hash."+fp.name, hashes)
+		}
+	}
+}
+
 func getPEMCertChain(certs []*x509.Certificate) (chain []string) {
 	n := len(certs)
 	if n == 0 {
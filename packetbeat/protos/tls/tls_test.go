@@ -435,3 +435,18 @@ func TestLegacyVersionNegotiation(t *testing.T) {
 		assert.Equal(t, expected, version)
 	}
 }
+
+func TestDetectTLS(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected protos.Detection
+	}{
+		{"\x16\x03\x01\x02\x00\x01\x00\x01\xfc", protos.DetectionMatch},
+		{"\x16\x03\x03\x00\x5a\x02", protos.DetectionMatch},
+		{"\x16\x03", protos.DetectionNeedMore},
+		{"\x17\x03\x03\x00\x10\x01", protos.DetectionMismatch},
+		{"\x16\x03\x03\x00\x10\x0b", protos.DetectionMismatch},
+	} {
+		assert.Equal(t, test.expected, detectTLS([]byte(test.data)), "%q", test.data)
+	}
+}
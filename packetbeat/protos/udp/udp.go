@@ -19,17 +19,46 @@ package udp
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
 
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 )
 
+const defaultDetectionMaxBytes = 1024
+
+var (
+	classifiedFlows   = monitoring.NewInt(nil, "udp.detection.classified_flows")
+	unclassifiedFlows = monitoring.NewInt(nil, "udp.detection.unclassified_flows")
+)
+
 type UDP struct {
 	protocols protos.Protocols
 	portMap   map[uint16]protos.Protocol
+
+	// protocol detection for flows not matching any port. Detection is
+	// disabled if there are no detectors.
+	detectors         []protocolDetector
+	detectionMaxBytes int
+	detections        *common.Cache
+}
+
+type protocolDetector struct {
+	protocol protos.Protocol
+	detector protos.UDPDetector
+}
+
+// detection is the state of the protocol detection of a flow. The flow is
+// ignored once done is set without a protocol having been detected.
+type detection struct {
+	protocol protos.Protocol
+	bytes    int
+	done     bool
 }
 
 type Processor interface {
@@ -38,27 +67,70 @@ type Processor interface {
 
 // decideProtocol determines the protocol based on the source and destination
 // ports. If the protocol cannot be determined then protos.UnknownProtocol
-// is returned.
+// is returned. Ports shared by multiple protocols map to
+// protos.UnknownProtocol, leaving the decision to protocol detection.
 func (udp *UDP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
 	protocol, exists := udp.portMap[tuple.SrcPort]
-	if exists {
+	if exists && protocol != protos.UnknownProtocol {
 		return protocol
 	}
 
 	protocol, exists = udp.portMap[tuple.DstPort]
-	if exists {
+	if exists && protocol != protos.UnknownProtocol {
 		return protocol
 	}
 
 	return protos.UnknownProtocol
 }
 
+// detectProtocol runs the protocol detectors on the datagrams of a flow
+// until a protocol is detected, or the detection byte budget of the flow
+// is used up. The result is kept for as long as the flow is active.
+func (udp *UDP) detectProtocol(pkt *protos.Packet) protos.Protocol {
+	var d *detection
+	if v := udp.detections.Get(pkt.Tuple.Hashable()); v != nil {
+		d = v.(*detection)
+	} else if v := udp.detections.Get(pkt.Tuple.RevHashable()); v != nil {
+		d = v.(*detection)
+	} else {
+		d = &detection{}
+		udp.detections.Put(pkt.Tuple.Hashable(), d)
+	}
+	if d.done || len(pkt.Payload) == 0 {
+		return d.protocol
+	}
+
+	d.bytes += len(pkt.Payload)
+	needMore := false
+	for _, pd := range udp.detectors {
+		switch pd.detector.DetectUDP(pkt.Payload) {
+		case protos.DetectionMatch:
+			logp.Debug("udp", "Detected protocol %s of flow %v", pd.protocol, pkt.Tuple.String())
+			classifiedFlows.Add(1)
+			d.protocol, d.done = pd.protocol, true
+			return d.protocol
+		case protos.DetectionNeedMore:
+			needMore = true
+		}
+	}
+
+	if !needMore || d.bytes >= udp.detectionMaxBytes {
+		logp.Debug("udp", "No protocol detected for flow %v", pkt.Tuple.String())
+		unclassifiedFlows.Add(1)
+		d.done = true
+	}
+	return protos.UnknownProtocol
+}
+
 // Process handles UDP packets that have been received. It attempts to
 // determine the protocol type and then invokes the associated
 // UdpProtocolPlugin's ParseUDP method. If the protocol cannot be determined
 // or the payload is empty then the method is a noop.
 func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
 	protocol := udp.decideProtocol(&pkt.Tuple)
+	if protocol == protos.UnknownProtocol && len(udp.detectors) > 0 {
+		protocol = udp.detectProtocol(pkt)
+	}
 	if protocol == protos.UnknownProtocol {
 		logp.Debug("udp", "unknown protocol")
 		return
@@ -79,10 +151,16 @@ func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
 
 // buildPortsMap creates a mapping of port numbers to protocol identifiers. If
 // any two UdpProtocolPlugins operate on the same port number then an error
-// will be returned.
-func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]protos.Protocol, error) {
+// will be returned, unless detection is enabled and both plugins provide a
+// detector. Such ports are mapped to protos.UnknownProtocol.
+func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin, detection bool) (map[uint16]protos.Protocol, error) {
 	var res = map[uint16]protos.Protocol{}
 
+	detectable := func(proto protos.Protocol) bool {
+		_, ok := plugins[proto].(protos.UDPDetector)
+		return detection && ok
+	}
+
 	for proto, protoPlugin := range plugins {
 		for _, port := range protoPlugin.GetPorts() {
 			oldProto, exists := res[uint16(port)]
@@ -90,6 +168,10 @@ func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]pro
 				if oldProto == proto {
 					continue
 				}
+				if detectable(proto) && (oldProto == protos.UnknownProtocol || detectable(oldProto)) {
+					res[uint16(port)] = protos.UnknownProtocol
+					continue
+				}
 				return nil, fmt.Errorf("Duplicate port (%d) exists in %s and %s protocols",
 					port, oldProto, proto)
 			}
@@ -101,8 +183,8 @@ func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]pro
 }
 
 // NewUdp creates and returns a new Udp.
-func NewUDP(p protos.Protocols) (*UDP, error) {
-	portMap, err := buildPortsMap(p.GetAllUDP())
+func NewUDP(p protos.Protocols, detection config.DetectionConfig) (*UDP, error) {
+	portMap, err := buildPortsMap(p.GetAllUDP(), detection.Enabled)
 	if err != nil {
 		return nil, err
 	}
@@ -110,5 +192,25 @@ func NewUDP(p protos.Protocols) (*UDP, error) {
 	udp := &UDP{protocols: p, portMap: portMap}
 	logp.Debug("udp", "Port map: %v", portMap)
 
+	if detection.Enabled {
+		for proto, plugin := range p.GetAllUDP() {
+			if detector, ok := plugin.(protos.UDPDetector); ok {
+				udp.detectors = append(udp.detectors, protocolDetector{proto, detector})
+			}
+		}
+		// the detectors are ordered, so that the result does not depend on
+		// map iteration
+		sort.Slice(udp.detectors, func(i, j int) bool {
+			return udp.detectors[i].protocol < udp.detectors[j].protocol
+		})
+
+		udp.detectionMaxBytes = detection.MaxBytes
+		if udp.detectionMaxBytes <= 0 {
+			udp.detectionMaxBytes = defaultDetectionMaxBytes
+		}
+		udp.detections = common.NewCache(protos.DefaultTransactionExpiration, protos.DefaultTransactionHashSize)
+		udp.detections.StartJanitor(protos.DefaultTransactionExpiration)
+	}
+
 	return udp, nil
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/packetbeat/config"
 	"github.com/elastic/beats/v7/packetbeat/protos"
 
 	// import plugins for testing
@@ -112,7 +113,7 @@ func testSetup(t *testing.T) *TestStruct {
 	plugin := &TestProtocol{Ports: []int{PORT}}
 	protocols.udp[PROTO] = plugin
 
-	udp, err := NewUDP(protocols)
+	udp, err := NewUDP(protocols, config.DetectionConfig{})
 	if err != nil {
 		t.Error("Error creating UDP handler: ", err)
 	}
@@ -168,7 +169,7 @@ func Test_buildPortsMap(t *testing.T) {
 	}
 
 	for _, test := range configTests {
-		output, err := buildPortsMap(test.Input)
+		output, err := buildPortsMap(test.Input, false)
 		assert.NoError(t, err)
 		assert.Equal(t, test.Output, output)
 	}
@@ -197,7 +198,7 @@ func Test_buildPortsMap_portOverlapError(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := buildPortsMap(test.Input)
+		_, err := buildPortsMap(test.Input, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), test.Err)
 	}
@@ -256,3 +257,51 @@ func TestProcess_nonEmptyPayload(t *testing.T) {
 	test.udp.Process(nil, pkt)
 	assert.Equal(t, pkt, test.plugin.pkt)
 }
+
+// detectingProtocol is a TestProtocol matching datagrams starting with
+// signature.
+type detectingProtocol struct {
+	TestProtocol
+	signature byte
+}
+
+func (proto *detectingProtocol) DetectUDP(data []byte) protos.Detection {
+	if data[0] == proto.signature {
+		return protos.DetectionMatch
+	}
+	return protos.DetectionMismatch
+}
+
+// Verify that flows on unknown ports are passed to the plugin recognizing
+// their first datagram, and that flows are not classified later on.
+func TestProcess_detection(t *testing.T) {
+	protocols := &TestProtocols{udp: map[protos.Protocol]protos.UDPPlugin{}}
+	plugin := &detectingProtocol{TestProtocol: TestProtocol{Ports: []int{PORT}}, signature: 1}
+	protocols.udp[PROTO] = plugin
+	udp, err := NewUDP(protocols, config.DetectionConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tuple := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34898,
+		net.ParseIP("192.168.0.1"), PORT+1)
+	reply := common.NewIPPortTuple(4,
+		net.ParseIP("192.168.0.1"), PORT+1,
+		net.ParseIP("10.0.0.1"), 34898)
+	pkt := &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{1, 2}}
+	udp.Process(nil, pkt)
+	assert.Equal(t, pkt, plugin.pkt)
+
+	pkt = &protos.Packet{Ts: time.Now(), Tuple: reply, Payload: []byte{2}}
+	udp.Process(nil, pkt)
+	assert.Equal(t, pkt, plugin.pkt)
+
+	plugin.pkt = nil
+	other := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34899,
+		net.ParseIP("192.168.0.1"), PORT+1)
+	udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: other, Payload: []byte{2}})
+	udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: other, Payload: []byte{1}})
+	assert.Nil(t, plugin.pkt)
+}
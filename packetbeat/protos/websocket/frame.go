@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package websocket
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+type opcode uint8
+
+// Opcodes of the frames, defined in RFC 6455.
+const (
+	opContinuation opcode = 0x0
+	opText         opcode = 0x1
+	opBinary       opcode = 0x2
+	opClose        opcode = 0x8
+	opPing         opcode = 0x9
+	opPong         opcode = 0xa
+)
+
+func (op opcode) String() string {
+	switch op {
+	case opContinuation:
+		return "continuation"
+	case opText:
+		return "text"
+	case opBinary:
+		return "binary"
+	case opClose:
+		return "close"
+	case opPing:
+		return "ping"
+	case opPong:
+		return "pong"
+	}
+	return "unknown"
+}
+
+func (op opcode) isControl() bool {
+	return op&0x8 != 0
+}
+
+const (
+	flagFin  = 0x80
+	flagRsv1 = 0x40
+	flagMask = 0x80
+
+	// maxFrameHeaderLen is the size of a header with a 64 bits payload
+	// length and a masking key.
+	maxFrameHeaderLen = 14
+
+	// maxControlPayloadLen is the maximum payload length of control frames.
+	maxControlPayloadLen = 125
+)
+
+var (
+	errReservedOpcode  = errors.New("reserved opcode")
+	errInvalidControl  = errors.New("fragmented or oversized control frame")
+	errInvalidLength   = errors.New("invalid payload length")
+	errUnexpectedFrame = errors.New("continuation frame without message")
+)
+
+type frameHeader struct {
+	fin        bool
+	compressed bool // RSV1 bit, set by permessage-deflate
+	opcode     opcode
+	masked     bool
+	mask       [4]byte
+	length     uint64
+	headerLen  int
+}
+
+// parseFrameHeader decodes the header of a frame. It returns false if the
+// header is not complete.
+func parseFrameHeader(data []byte) (hdr frameHeader, ok bool, err error) {
+	if len(data) < 2 {
+		return hdr, false, nil
+	}
+
+	hdr.fin = data[0]&flagFin != 0
+	hdr.compressed = data[0]&flagRsv1 != 0
+	hdr.opcode = opcode(data[0] & 0x0f)
+	hdr.masked = data[1]&flagMask != 0
+	hdr.headerLen = 2
+	switch hdr.opcode {
+	case opContinuation, opText, opBinary, opClose, opPing, opPong:
+	default:
+		return hdr, false, errReservedOpcode
+	}
+
+	length := uint64(data[1] & 0x7f)
+	switch length {
+	case 126:
+		if len(data) < 4 {
+			return hdr, false, nil
+		}
+		length = uint64(binary.BigEndian.Uint16(data[2:]))
+		hdr.headerLen = 4
+	case 127:
+		if len(data) < 10 {
+			return hdr, false, nil
+		}
+		length = binary.BigEndian.Uint64(data[2:])
+		if length>>63 != 0 {
+			return hdr, false, errInvalidLength
+		}
+		hdr.headerLen = 10
+	}
+	hdr.length = length
+	if hdr.opcode.isControl() && (!hdr.fin || length > maxControlPayloadLen) {
+		return hdr, false, errInvalidControl
+	}
+
+	if hdr.masked {
+		if len(data) < hdr.headerLen+4 {
+			return hdr, false, nil
+		}
+		copy(hdr.mask[:], data[hdr.headerLen:])
+		hdr.headerLen += 4
+	}
+	return hdr, true, nil
+}
+
+// unmask appends the payload bytes starting at offset in the frame to dst,
+// unmasked.
+func (hdr *frameHeader) unmask(dst, payload []byte, offset uint64) []byte {
+	if !hdr.masked {
+		return append(dst, payload...)
+	}
+	for i, b := range payload {
+		dst = append(dst, b^hdr.mask[(offset+uint64(i))%4])
+	}
+	return dst
+}
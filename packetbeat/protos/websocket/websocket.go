@@ -0,0 +1,646 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package websocket
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+)
+
+// Handshake holds the details of the HTTP handshake upgrading a connection
+// to WebSocket. The HTTP analyzer passes it as the initial protocol data of
+// the connection.
+type Handshake struct {
+	Ts        time.Time
+	Capture   pb.Capture
+	ClientDir uint8
+
+	URI         string
+	Host        string
+	Origin      string
+	Subprotocol string
+	Extensions  []string
+}
+
+type connection struct {
+	handshake    *Handshake
+	tcpTuple     common.TCPTuple
+	cmdlineTuple *common.ProcessTuple
+	capture      pb.Capture
+
+	// the client is known from the handshake or from the masking of the
+	// first frame
+	clientDir   uint8
+	clientKnown bool
+
+	streams    [2]stream
+	start, end time.Time
+
+	// close handshake, reported with the code and reason of the first close
+	// frame
+	closed      [2]bool
+	closeDir    uint8
+	closeCode   uint16
+	closeReason string
+
+	fin        [2]bool
+	packetLoss bool
+	invalid    bool
+	published  bool
+}
+
+// stream is the state of the frames sent in a direction of a connection.
+// Payloads are not buffered, only the bytes reported are kept.
+type stream struct {
+	// bytes of an incomplete frame header
+	header []byte
+
+	frame     frameHeader
+	inFrame   bool
+	remaining uint64 // payload bytes of the frame not received yet
+	offset    uint64 // payload bytes of the frame received
+
+	message *message // data message being received
+	control []byte   // payload of the control frame being received
+
+	// set once the frames can not be delimited anymore, after a gap in a
+	// frame header or an invalid frame
+	lost bool
+
+	stats streamStats
+}
+
+type streamStats struct {
+	bytes              int64
+	frames             int
+	messages           int
+	textMessages       int
+	binaryMessages     int
+	fragmentedMessages int
+	compressedMessages int
+	pings              int
+	pongs              int
+	messageBytes       uint64
+	maxMessageSize     uint64
+}
+
+type message struct {
+	ts         time.Time
+	end        time.Time
+	dir        uint8
+	opcode     opcode
+	size       uint64
+	frames     int
+	compressed bool
+	payload    []byte
+	packetLoss bool
+}
+
+// WebSocket protocol plugin
+type websocketPlugin struct {
+	// config
+	ports              []int
+	sendMessages       bool
+	maxPayloadBytes    int
+	transactionTimeout time.Duration
+
+	watcher procs.ProcessesWatcher
+	results protos.Reporter
+}
+
+var (
+	debugf  = logp.MakeDebug("websocket")
+	isDebug = false
+)
+
+var invalidFrames = monitoring.NewInt(nil, "websocket.invalid_frames")
+
+func init() {
+	protos.Register("websocket", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher procs.ProcessesWatcher,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &websocketPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, watcher, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (ws *websocketPlugin) init(results protos.Reporter, watcher procs.ProcessesWatcher, config *websocketConfig) error {
+	ws.setFromConfig(config)
+
+	ws.results = results
+	ws.watcher = watcher
+	isDebug = logp.IsDebug("websocket")
+
+	return nil
+}
+
+func (ws *websocketPlugin) setFromConfig(config *websocketConfig) {
+	ws.ports = config.Ports
+	ws.sendMessages = config.SendMessages
+	ws.maxPayloadBytes = config.MaxPayloadBytes
+	ws.transactionTimeout = config.TransactionTimeout
+}
+
+func (ws *websocketPlugin) GetPorts() []int {
+	return ws.ports
+}
+
+func (ws *websocketPlugin) ConnectionTimeout() time.Duration {
+	return ws.transactionTimeout
+}
+
+func (ws *websocketPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseWebsocket exception")
+
+	conn := ws.ensureConnection(private, tcptuple)
+	if conn.start.IsZero() {
+		conn.capture = pkt.Capture
+	}
+	ws.parseFrames(conn, dir, pkt.Payload, pkt.Ts)
+	return conn
+}
+
+func (ws *websocketPlugin) newConnectionData(tcptuple *common.TCPTuple, hs *Handshake) *connection {
+	conn := &connection{
+		handshake:    hs,
+		tcpTuple:     *tcptuple,
+		cmdlineTuple: ws.watcher.FindProcessesTupleTCP(tcptuple.IPPort()),
+	}
+	if hs != nil {
+		conn.clientDir = hs.ClientDir
+		conn.clientKnown = true
+		conn.start = hs.Ts
+		conn.end = hs.Ts
+		conn.capture = hs.Capture
+	}
+	return conn
+}
+
+func (ws *websocketPlugin) ensureConnection(private protos.ProtocolData, tcptuple *common.TCPTuple) *connection {
+	switch priv := private.(type) {
+	case *connection:
+		if priv != nil {
+			return priv
+		}
+	case *Handshake:
+		// first data after the upgrade
+		return ws.newConnectionData(tcptuple, priv)
+	}
+	if private != nil {
+		logp.Warn("websocket connection data type error, create new one")
+	}
+	return ws.newConnectionData(tcptuple, nil)
+}
+
+// parseFrames follows the frames of a direction of a connection.
+func (ws *websocketPlugin) parseFrames(conn *connection, dir uint8, data []byte, ts time.Time) {
+	st := &conn.streams[dir]
+	if conn.start.IsZero() {
+		conn.start = ts
+	}
+	conn.end = ts
+
+	for len(data) > 0 && !st.lost {
+		if !st.inFrame {
+			buf := data
+			if len(st.header) > 0 {
+				n := maxFrameHeaderLen - len(st.header)
+				if n > len(data) {
+					n = len(data)
+				}
+				buf = append(st.header, data[:n]...)
+			}
+			hdr, ok, err := parseFrameHeader(buf)
+			if err != nil {
+				ws.invalidFrame(conn, st, err)
+				return
+			}
+			if !ok {
+				// wait for the rest of the header
+				st.header = append(st.header, data...)
+				return
+			}
+
+			data = data[hdr.headerLen-len(st.header):]
+			st.header = nil
+			ws.frameStart(conn, st, dir, &hdr, ts)
+			if st.remaining == 0 {
+				ws.frameComplete(conn, st, dir, ts)
+			}
+			continue
+		}
+
+		n := st.remaining
+		if n > uint64(len(data)) {
+			n = uint64(len(data))
+		}
+		ws.framePayload(st, data[:n])
+		data = data[n:]
+		if st.remaining == 0 {
+			ws.frameComplete(conn, st, dir, ts)
+		}
+	}
+}
+
+func (ws *websocketPlugin) frameStart(conn *connection, st *stream, dir uint8, hdr *frameHeader, ts time.Time) {
+	if !conn.clientKnown {
+		// only the frames sent by the client are masked
+		conn.clientDir = dir
+		if !hdr.masked {
+			conn.clientDir = 1 - dir
+		}
+		conn.clientKnown = true
+	}
+
+	st.frame = *hdr
+	st.inFrame = true
+	st.remaining = hdr.length
+	st.offset = 0
+	st.stats.frames++
+	st.stats.bytes += int64(hdr.headerLen) + int64(hdr.length)
+
+	switch {
+	case hdr.opcode.isControl():
+		// control frames can be sent between the fragments of a message
+		st.control = st.control[:0]
+	case hdr.opcode == opContinuation:
+		if st.message == nil {
+			// the start of the message was not seen
+			if isDebug {
+				debugf("%v, ignoring frame", errUnexpectedFrame)
+			}
+			return
+		}
+		st.message.frames++
+		st.message.size += hdr.length
+	default:
+		if st.message != nil && isDebug {
+			debugf("%s message not finished, dropping it", st.message.opcode)
+		}
+		st.message = &message{
+			ts:         ts,
+			dir:        dir,
+			opcode:     hdr.opcode,
+			size:       hdr.length,
+			frames:     1,
+			compressed: hdr.compressed,
+		}
+	}
+}
+
+func (ws *websocketPlugin) framePayload(st *stream, payload []byte) {
+	switch m := st.message; {
+	case st.frame.opcode.isControl():
+		st.control = st.frame.unmask(st.control, payload, st.offset)
+	case m != nil && ws.keepPayload(m):
+		keep := payload
+		if n := ws.maxPayloadBytes - len(m.payload); n < len(keep) {
+			keep = keep[:n]
+		}
+		m.payload = st.frame.unmask(m.payload, keep, st.offset)
+	}
+	st.offset += uint64(len(payload))
+	st.remaining -= uint64(len(payload))
+}
+
+// keepPayload returns true if the next payload bytes of the message are
+// reported. Only the uncompressed text messages are.
+func (ws *websocketPlugin) keepPayload(m *message) bool {
+	return ws.sendMessages && len(m.payload) < ws.maxPayloadBytes &&
+		m.opcode == opText && !m.compressed && !m.packetLoss
+}
+
+func (ws *websocketPlugin) frameComplete(conn *connection, st *stream, dir uint8, ts time.Time) {
+	st.inFrame = false
+	switch st.frame.opcode {
+	case opClose:
+		ws.onClose(conn, dir, st.control)
+	case opPing:
+		st.stats.pings++
+	case opPong:
+		st.stats.pongs++
+	default:
+		if m := st.message; m != nil && st.frame.fin {
+			m.end = ts
+			st.message = nil
+			ws.messageComplete(conn, st, m)
+		}
+	}
+}
+
+func (ws *websocketPlugin) messageComplete(conn *connection, st *stream, m *message) {
+	stats := &st.stats
+	stats.messages++
+	switch m.opcode {
+	case opText:
+		stats.textMessages++
+	case opBinary:
+		stats.binaryMessages++
+	}
+	if m.frames > 1 {
+		stats.fragmentedMessages++
+	}
+	if m.compressed {
+		stats.compressedMessages++
+	}
+	stats.messageBytes += m.size
+	if m.size > stats.maxMessageSize {
+		stats.maxMessageSize = m.size
+	}
+
+	if ws.sendMessages && ws.results != nil {
+		ws.results(ws.newMessageEvent(conn, m))
+	}
+}
+
+// onClose records the close frames. The connection is reported once both
+// endpoints have sent theirs.
+func (ws *websocketPlugin) onClose(conn *connection, dir uint8, payload []byte) {
+	if !conn.closed[0] && !conn.closed[1] {
+		conn.closeDir = dir
+		if len(payload) >= 2 {
+			conn.closeCode = binary.BigEndian.Uint16(payload)
+			conn.closeReason = string(payload[2:])
+		}
+	}
+	conn.closed[dir] = true
+	if conn.closed[1-dir] {
+		ws.publishConnection(conn)
+	}
+}
+
+func (ws *websocketPlugin) invalidFrame(conn *connection, st *stream, err error) {
+	if isDebug {
+		debugf("invalid frame: %v", err)
+	}
+	invalidFrames.Add(1)
+	conn.invalid = true
+	st.lost = true
+	st.message = nil
+}
+
+func (ws *websocketPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	defer logp.Recover("GapInStream(websocket) exception")
+
+	if private == nil {
+		return private, false
+	}
+	conn := ws.ensureConnection(private, tcptuple)
+	conn.packetLoss = true
+
+	// Gaps in the payload of data frames are skipped. The frames following
+	// other gaps can't be found, the rest of the stream is ignored. The
+	// connection state is kept to report the other direction.
+	st := &conn.streams[dir]
+	if !st.inFrame || st.frame.opcode.isControl() || uint64(nbytes) > st.remaining {
+		st.lost = true
+		st.message = nil
+		return conn, false
+	}
+	st.offset += uint64(nbytes)
+	st.remaining -= uint64(nbytes)
+	if st.message != nil {
+		st.message.packetLoss = true
+	}
+	if st.remaining == 0 {
+		ws.frameComplete(conn, st, dir, conn.end)
+	}
+	return conn, false
+}
+
+// ReceivedFin reports the connection once both endpoints have closed it.
+func (ws *websocketPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+
+	if private == nil {
+		return private
+	}
+	conn := ws.ensureConnection(private, tcptuple)
+	conn.fin[dir] = true
+	if conn.fin[1-dir] {
+		ws.publishConnection(conn)
+	}
+	return conn
+}
+
+// Expired reports the connections not closed when their state expires.
+func (ws *websocketPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolData) {
+	if private == nil {
+		return
+	}
+	conn := ws.ensureConnection(private, tuple)
+	if isDebug {
+		debugf("expired connection %s", tuple)
+	}
+	ws.publishConnection(conn)
+}
+
+func (ws *websocketPlugin) publishConnection(conn *connection) {
+	if conn.published || ws.results == nil {
+		return
+	}
+	conn.published = true
+	ws.results(ws.newConnectionEvent(conn))
+}
+
+func (ws *websocketPlugin) newEvent(conn *connection, ts time.Time) (beat.Event, *pb.Fields) {
+	source, destination := common.MakeEndpointPair(conn.tcpTuple.BaseTuple, conn.cmdlineTuple)
+	src, dst := &source, &destination
+	if conn.clientDir == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+
+	evt, pbf := pb.NewBeatEvent(ts)
+	pbf.SetSource(src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(dst)
+	pbf.AddIP(dst.IP)
+	pbf.Event.Dataset = "websocket"
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.AddCapture(&conn.capture)
+
+	evt.Fields["type"] = pbf.Event.Dataset
+	return evt, pbf
+}
+
+func (ws *websocketPlugin) newConnectionEvent(conn *connection) beat.Event {
+	evt, pbf := ws.newEvent(conn, conn.start)
+	pbf.Event.Start = conn.start
+	pbf.Event.End = conn.end
+	pbf.Event.Action = "websocket.connection"
+
+	client, server := &conn.streams[conn.clientDir].stats, &conn.streams[1-conn.clientDir].stats
+	pbf.Source.Bytes = client.bytes
+	pbf.Destination.Bytes = server.bytes
+
+	status := common.OK_STATUS
+	info := common.MapStr{
+		"client": client.toMapStr(),
+		"server": server.toMapStr(),
+	}
+	conn.addHandshake(info)
+	if conn.closed[0] || conn.closed[1] {
+		closeInfo := common.MapStr{
+			"initiator": conn.endpoint(conn.closeDir),
+		}
+		if conn.closeCode != 0 {
+			closeInfo["code"] = conn.closeCode
+		}
+		if conn.closeReason != "" {
+			closeInfo["reason"] = conn.closeReason
+		}
+		info["close"] = closeInfo
+		if !isNormalClosure(conn.closeCode) {
+			status = common.ERROR_STATUS
+			pbf.Event.Outcome = "failure"
+		}
+	}
+
+	var notes []string
+	if conn.packetLoss {
+		notes = append(notes, "Packet loss")
+	}
+	if conn.invalid {
+		notes = append(notes, "Invalid frame")
+	}
+
+	evt.Fields["status"] = status
+	evt.Fields["websocket"] = info
+	pbf.Error.Message = notes
+	return evt
+}
+
+func (ws *websocketPlugin) newMessageEvent(conn *connection, m *message) beat.Event {
+	evt, pbf := ws.newEvent(conn, m.ts)
+	pbf.Event.Start = m.ts
+	pbf.Event.End = m.end
+	pbf.Event.Action = "websocket.message"
+	if m.dir == conn.clientDir {
+		pbf.Source.Bytes = int64(m.size)
+	} else {
+		pbf.Destination.Bytes = int64(m.size)
+	}
+
+	msg := common.MapStr{
+		"sender": conn.endpoint(m.dir),
+		"opcode": m.opcode.String(),
+		"size":   m.size,
+		"frames": m.frames,
+	}
+	if m.compressed {
+		msg["compressed"] = true
+	}
+	if len(m.payload) > 0 {
+		msg["payload"] = string(m.payload)
+	}
+	info := common.MapStr{"message": msg}
+	conn.addHandshake(info)
+
+	if m.packetLoss {
+		pbf.Error.Message = []string{"Packet loss"}
+	}
+	evt.Fields["status"] = common.OK_STATUS
+	evt.Fields["websocket"] = info
+	return evt
+}
+
+func (conn *connection) addHandshake(info common.MapStr) {
+	hs := conn.handshake
+	if hs == nil {
+		return
+	}
+	for name, value := range map[string]string{
+		"uri":         hs.URI,
+		"host":        hs.Host,
+		"origin":      hs.Origin,
+		"subprotocol": hs.Subprotocol,
+	} {
+		if value != "" {
+			info[name] = value
+		}
+	}
+	if len(hs.Extensions) > 0 {
+		info["extensions"] = hs.Extensions
+	}
+}
+
+// endpoint returns the endpoint sending in the direction.
+func (conn *connection) endpoint(dir uint8) string {
+	if dir == conn.clientDir {
+		return "client"
+	}
+	return "server"
+}
+
+// isNormalClosure returns true for the close codes of connections closed
+// as expected, or without code.
+func isNormalClosure(code uint16) bool {
+	switch code {
+	case 0, 1000, 1001:
+		return true
+	}
+	return false
+}
+
+func (s *streamStats) toMapStr() common.MapStr {
+	return common.MapStr{
+		"frames":              s.frames,
+		"messages":            s.messages,
+		"text_messages":       s.textMessages,
+		"binary_messages":     s.binaryMessages,
+		"fragmented_messages": s.fragmentedMessages,
+		"compressed_messages": s.compressedMessages,
+		"pings":               s.pings,
+		"pongs":               s.pongs,
+		"message_bytes":       s.messageBytes,
+		"max_message_size":    s.maxMessageSize,
+	}
+}
@@ -0,0 +1,416 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package websocket
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func websocketModForTests(store *eventStore, settings map[string]interface{}) *websocketPlugin {
+	callback := func(beat.Event) {}
+	if store != nil {
+		callback = store.publish
+	}
+
+	cfg, _ := common.NewConfigFrom(settings)
+	ws, err := New(false, callback, procs.ProcessesWatcher{}, cfg)
+	if err != nil {
+		panic(err)
+	}
+	return ws.(*websocketPlugin)
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 80,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+var testMask = []byte{0x37, 0xfa, 0x21, 0x3d}
+
+// frame encodes a frame, masked by the mask if not nil.
+func frame(fin bool, op opcode, payload []byte, mask []byte) []byte {
+	var b bytes.Buffer
+	first := byte(op)
+	if fin {
+		first |= flagFin
+	}
+	b.WriteByte(first)
+
+	var maskBit byte
+	if mask != nil {
+		maskBit = flagMask
+	}
+	switch n := len(payload); {
+	case n < 126:
+		b.WriteByte(maskBit | byte(n))
+	case n <= 0xffff:
+		b.WriteByte(maskBit | 126)
+		binary.Write(&b, binary.BigEndian, uint16(n))
+	default:
+		b.WriteByte(maskBit | 127)
+		binary.Write(&b, binary.BigEndian, uint64(n))
+	}
+
+	if mask == nil {
+		b.Write(payload)
+		return b.Bytes()
+	}
+	b.Write(mask)
+	for i, c := range payload {
+		b.WriteByte(c ^ mask[i%4])
+	}
+	return b.Bytes()
+}
+
+func closePayload(code uint16, reason string) []byte {
+	b := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(b, code)
+	return append(b, reason...)
+}
+
+// packet is the payload of a TCP segment sent in a direction, or a gap or
+// a FIN in the direction.
+type packet struct {
+	dir  uint8
+	data []byte
+	gap  int
+	fin  bool
+}
+
+func client(data ...[]byte) packet {
+	return packet{dir: tcp.TCPDirectionOriginal, data: bytes.Join(data, nil)}
+}
+
+func server(data ...[]byte) packet {
+	return packet{dir: tcp.TCPDirectionReverse, data: bytes.Join(data, nil)}
+}
+
+func TestParseFrameHeader(t *testing.T) {
+	for name, test := range map[string]struct {
+		data []byte
+		hdr  frameHeader
+		ok   bool
+		err  error
+	}{
+		"short": {
+			data: []byte{0x81},
+		},
+		"text": {
+			data: []byte{0x81, 0x05},
+			hdr:  frameHeader{fin: true, opcode: opText, length: 5, headerLen: 2},
+			ok:   true,
+		},
+		"masked": {
+			data: []byte{0x01, 0x85, 1, 2, 3, 4},
+			hdr: frameHeader{opcode: opText, masked: true, mask: [4]byte{1, 2, 3, 4},
+				length: 5, headerLen: 6},
+			ok: true,
+		},
+		"incomplete mask": {
+			data: []byte{0x81, 0x85, 1, 2, 3},
+		},
+		"16 bits length": {
+			data: []byte{0xc2, 0x7e, 0x01, 0x00},
+			hdr: frameHeader{fin: true, compressed: true, opcode: opBinary,
+				length: 256, headerLen: 4},
+			ok: true,
+		},
+		"64 bits length": {
+			data: []byte{0x82, 0x7f, 0, 0, 0, 0, 0, 1, 0, 0},
+			hdr:  frameHeader{fin: true, opcode: opBinary, length: 65536, headerLen: 10},
+			ok:   true,
+		},
+		"invalid length": {
+			data: []byte{0x82, 0x7f, 0x80, 0, 0, 0, 0, 0, 0, 0},
+			err:  errInvalidLength,
+		},
+		"reserved opcode": {
+			data: []byte{0x83, 0x00},
+			err:  errReservedOpcode,
+		},
+		"fragmented control frame": {
+			data: []byte{0x09, 0x00},
+			err:  errInvalidControl,
+		},
+		"oversized control frame": {
+			data: []byte{0x88, 0x7e, 0x00, 0x7e},
+			err:  errInvalidControl,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hdr, ok, err := parseFrameHeader(test.data)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.hdr, hdr)
+			}
+		})
+	}
+}
+
+func TestWebsocketParser(t *testing.T) {
+	text := strings.Repeat("0123456789", 7000)
+	split := frame(true, opText, []byte(text), testMask)
+	gapped := frame(true, opBinary, make([]byte, 1000), nil)
+
+	for _, test := range []struct {
+		title     string
+		settings  map[string]interface{}
+		handshake *Handshake
+		packets   []packet
+		expire    bool
+		expected  []common.MapStr
+	}{
+		{
+			title: "connection",
+			settings: map[string]interface{}{
+				"send_messages":     true,
+				"max_payload_bytes": 8,
+			},
+			handshake: &Handshake{
+				ClientDir:   tcp.TCPDirectionOriginal,
+				URI:         "/quotes",
+				Host:        "example.org",
+				Subprotocol: "v2.quotes",
+			},
+			packets: []packet{
+				// fragmented text message with a ping between the fragments
+				client(
+					frame(false, opText, []byte(`{"sub"`), testMask),
+					frame(true, opPing, nil, testMask),
+				),
+				client(frame(true, opContinuation, []byte(`:"EURUSD"}`), testMask)),
+				server(frame(true, opPong, nil, nil)),
+				server(frame(true, opBinary, make([]byte, 300), nil)),
+				server(frame(true, opClose, closePayload(1001, "going away"), nil)),
+				client(frame(true, opClose, closePayload(1001, ""), testMask)),
+				// FIN after the close handshake
+				{dir: tcp.TCPDirectionReverse, fin: true},
+				{dir: tcp.TCPDirectionOriginal, fin: true},
+			},
+			expected: []common.MapStr{
+				{
+					"type":                      "websocket",
+					"event.action":              "websocket.message",
+					"source.ip":                 "192.168.0.1",
+					"websocket.uri":             "/quotes",
+					"websocket.subprotocol":     "v2.quotes",
+					"websocket.message.sender":  "client",
+					"websocket.message.opcode":  "text",
+					"websocket.message.size":    16,
+					"websocket.message.frames":  2,
+					"websocket.message.payload": `{"sub":"`,
+				},
+				{
+					"websocket.message.sender":  "server",
+					"websocket.message.opcode":  "binary",
+					"websocket.message.size":    300,
+					"websocket.message.payload": nil,
+				},
+				{
+					"event.action":                         "websocket.connection",
+					"status":                               common.OK_STATUS,
+					"websocket.host":                       "example.org",
+					"websocket.close.code":                 1001,
+					"websocket.close.reason":               "going away",
+					"websocket.close.initiator":            "server",
+					"websocket.client.frames":              4,
+					"websocket.client.messages":            1,
+					"websocket.client.text_messages":       1,
+					"websocket.client.fragmented_messages": 1,
+					"websocket.client.pings":               1,
+					"websocket.client.message_bytes":       16,
+					"websocket.server.pongs":               1,
+					"websocket.server.binary_messages":     1,
+					"websocket.server.max_message_size":    300,
+					"destination.bytes":                    2 + 304 + 14,
+				},
+			},
+		},
+		{
+			title: "split frames",
+			settings: map[string]interface{}{
+				"send_messages":     true,
+				"max_payload_bytes": 100000,
+			},
+			handshake: &Handshake{ClientDir: tcp.TCPDirectionOriginal},
+			packets: []packet{
+				client(split[:1]),
+				client(split[1:3]),
+				client(split[3:8]),
+				client(split[8:21]),
+				client(split[21:1021]),
+				client(split[1021:]),
+			},
+			expected: []common.MapStr{{
+				"websocket.message.size":    len(text),
+				"websocket.message.payload": text,
+			}},
+		},
+		{
+			title: "without handshake",
+			settings: map[string]interface{}{
+				"send_messages": true,
+			},
+			packets: []packet{
+				// the first frame seen is sent by the server, unmasked
+				client(frame(true, opText, []byte("tick"), nil)),
+				server(frame(true, opText, []byte("ack"), testMask)),
+			},
+			expire: true,
+			expected: []common.MapStr{
+				{
+					"websocket.message.sender": "server",
+					"source.ip":                "192.168.0.2",
+				},
+				{
+					"websocket.message.sender": "client",
+				},
+				{
+					"event.action":    "websocket.connection",
+					"status":          common.OK_STATUS,
+					"websocket.close": nil,
+					"websocket.uri":   nil,
+				},
+			},
+		},
+		{
+			title:     "abnormal closure",
+			handshake: &Handshake{ClientDir: tcp.TCPDirectionOriginal},
+			packets: []packet{
+				server(frame(true, opClose, closePayload(1011, "internal error"), nil)),
+				client(frame(true, opClose, nil, testMask)),
+			},
+			expected: []common.MapStr{{
+				"status":               common.ERROR_STATUS,
+				"event.outcome":        "failure",
+				"websocket.close.code": 1011,
+			}},
+		},
+		{
+			title: "gaps",
+			settings: map[string]interface{}{
+				"send_messages": true,
+			},
+			handshake: &Handshake{ClientDir: tcp.TCPDirectionOriginal},
+			packets: []packet{
+				// gap in a payload
+				server(gapped[:100]),
+				{dir: tcp.TCPDirectionReverse, gap: 500},
+				server(gapped[600:]),
+				// gap larger than the frame, the rest of the client
+				// stream is ignored
+				client(frame(true, opText, []byte("hello"), testMask)[:4]),
+				{dir: tcp.TCPDirectionOriginal, gap: 100},
+				client(frame(true, opText, []byte("hello"), testMask)),
+				server(frame(true, opText, []byte("world"), nil)),
+				// invalid frame
+				server([]byte{0x8f, 0x00}),
+				server(frame(true, opText, []byte("lost"), nil)),
+			},
+			expire: true,
+			expected: []common.MapStr{
+				{
+					"websocket.message.size": 1000,
+					"error.message":          "Packet loss",
+				},
+				{
+					"websocket.message.sender": "server",
+				},
+				{
+					"error.message": []string{"Packet loss", "Invalid frame"},
+				},
+			},
+		},
+	} {
+		t.Run(test.title, func(t *testing.T) {
+			var store eventStore
+			ws := websocketModForTests(&store, test.settings)
+			tcptuple := testTCPTuple()
+			ts := time.Now()
+
+			var private protos.ProtocolData
+			if test.handshake != nil {
+				hs := *test.handshake
+				hs.Ts = ts
+				private = &hs
+			}
+			for i, p := range test.packets {
+				switch {
+				case p.gap > 0:
+					private, _ = ws.GapInStream(tcptuple, p.dir, p.gap, private)
+				case p.fin:
+					private = ws.ReceivedFin(tcptuple, p.dir, private)
+				default:
+					pkt := protos.Packet{Ts: ts.Add(time.Duration(i+1) * time.Millisecond), Payload: p.data}
+					private = ws.Parse(&pkt, tcptuple, p.dir, private)
+				}
+			}
+			if test.expire {
+				ws.Expired(tcptuple, private)
+			}
+
+			if !assert.Len(t, store.events, len(test.expected)) {
+				return
+			}
+			for i, expected := range test.expected {
+				for field, value := range expected {
+					actual, err := store.events[i].GetValue(field)
+					if value == nil {
+						assert.Equal(t, common.ErrKeyNotFound, err, field)
+						continue
+					}
+					assert.NoError(t, err, field)
+					assert.EqualValues(t, value, actual, field)
+				}
+			}
+		})
+	}
+}
@@ -41,8 +41,13 @@ type transProcessor struct {
 	localIPs         []net.IP // TODO: Periodically update this list.
 	internalNetworks []string
 	name             string
+	hook             EventHook
 }
 
+// EventHook is called for every transaction event before it is published.
+// The hook is called concurrently by the reporters of all protocols.
+type EventHook func(event *beat.Event, fields *pb.Fields)
+
 var debugf = logp.MakeDebug("publish")
 
 func NewTransactionPublisher(
@@ -81,6 +86,12 @@ func (p *TransactionPublisher) Stop() {
 	close(p.done)
 }
 
+// SetEventHook installs a hook inspecting the events before they are
+// published. It must be called before any reporter is created.
+func (p *TransactionPublisher) SetEventHook(hook EventHook) {
+	p.processor.hook = hook
+}
+
 func (p *TransactionPublisher) CreateReporter(
 	config *common.Config,
 ) (func(beat.Event), error) {
@@ -164,6 +175,10 @@ func (p *transProcessor) Run(event *beat.Event) (*beat.Event, error) {
 				fields.Source.IP, fields.Destination.IP)
 			return nil, nil
 		}
+
+		if p.hook != nil {
+			p.hook(event, fields)
+		}
 	}
 
 	return event, nil
@@ -37,6 +37,9 @@ type afpacketHandle struct {
 	promiscPreviousState         bool
 	promiscPreviousStateDetected bool
 	device                       string
+
+	// last holds the socket statistics from the previous call to Stats.
+	last captureStats
 }
 
 func newAfpacketHandle(device string, snaplen int, block_size int, num_blocks int,
@@ -94,6 +97,35 @@ func (h *afpacketHandle) LinkType() layers.LinkType {
 	return layers.LinkTypeEthernet
 }
 
+// SetFanout adds the socket to the fanout group. Packets are distributed by
+// flow hash over all sockets of the group. IP fragments are defragmented
+// before hashing, so all fragments of a datagram are sent to the same socket.
+func (h *afpacketHandle) SetFanout(group uint16) error {
+	if err := h.TPacket.SetFanout(afpacket.FanoutHashWithDefrag, group); err != nil {
+		return fmt.Errorf("failed to join fanout group %d on device '%s': %v", group, h.device, err)
+	}
+	return nil
+}
+
+// Stats returns the number of packets received and dropped by the socket
+// since the last call.
+func (h *afpacketHandle) Stats() (captureStats, error) {
+	// The afpacket package accumulates the counters of the socket, which are
+	// reset by the kernel on read. Only the counters matching the TPACKET
+	// version of the socket are updated, the others remain zero.
+	v1, v3, err := h.TPacket.SocketStats()
+	if err != nil {
+		return captureStats{}, err
+	}
+	cur := captureStats{
+		received: uint64(v1.Packets()) + uint64(v3.Packets()),
+		dropped:  uint64(v1.Drops()) + uint64(v3.Drops()),
+	}
+	stats := captureStats{received: cur.received - h.last.received, dropped: cur.dropped - h.last.dropped}
+	h.last = cur
+	return stats, nil
+}
+
 func (h *afpacketHandle) Close() {
 	h.TPacket.Close()
 	// previous state detected only if auto mode was on
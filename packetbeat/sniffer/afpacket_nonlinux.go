@@ -48,5 +48,13 @@ func (h *afpacketHandle) LinkType() layers.LinkType {
 	return layers.LinkTypeEthernet
 }
 
+func (h *afpacketHandle) SetFanout(group uint16) error {
+	return fmt.Errorf("Afpacket MMAP sniffing is only available on Linux")
+}
+
+func (h *afpacketHandle) Stats() (captureStats, error) {
+	return captureStats{}, fmt.Errorf("Afpacket MMAP sniffing is only available on Linux")
+}
+
 func (h *afpacketHandle) Close() {
 }
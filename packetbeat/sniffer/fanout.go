@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+// fanoutHandle is implemented by the capture handles that can join a fanout
+// group.
+type fanoutHandle interface {
+	snifferHandle
+	SetFanout(group uint16) error
+}
+
+func validateFanoutConfig(cfg *config.InterfacesConfig) error {
+	if cfg.Fanout.Workers <= 1 {
+		return nil
+	}
+
+	switch {
+	case cfg.Type != "af_packet":
+		return errors.New("fanout is only supported by the af_packet sniffer")
+	case cfg.Dumpfile != "":
+		return errors.New("dumping packets to a file is not supported in fanout mode")
+	case cfg.OneAtATime:
+		return errors.New("reading one packet at a time is not supported in fanout mode")
+	}
+	return nil
+}
+
+// fanoutGroup returns the configured fanout group ID. By default the ID is
+// derived from the process ID and device name, so that other processes
+// capturing from the same device do not join the group.
+func fanoutGroup(cfg config.FanoutConfig, device string) uint16 {
+	if cfg.GroupID != nil {
+		return *cfg.GroupID
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(device))
+	return uint16(uint32(os.Getpid()) ^ h.Sum32())
+}
+
+// runFanout opens one af_packet socket and worker per fanout member, and
+// reads packets from all sockets concurrently. Each worker publishes the
+// statistics of its socket, which are also added to the device totals.
+func (s *Sniffer) runFanout() error {
+	group := fanoutGroup(s.config.Fanout, s.config.Device)
+	logp.Info("Starting %d af_packet fanout workers on device '%s' (group %d)",
+		s.config.Fanout.Workers, s.config.Device, group)
+
+	var handles []snifferHandle
+	defer func() {
+		for _, h := range handles {
+			h.Close()
+		}
+	}()
+
+	var workers []Worker
+	for i := 0; i < s.config.Fanout.Workers; i++ {
+		handle, err := s.openFanout(group)
+		if err != nil {
+			return fmt.Errorf("Error starting sniffer: %s", err)
+		}
+		handles = append(handles, handle)
+
+		worker, err := s.factory(s.config.Device, handle.LinkType())
+		if err != nil {
+			return err
+		}
+		workers = append(workers, worker)
+	}
+
+	ring, err := s.openRotator(handles[0].LinkType())
+	if err != nil {
+		return err
+	}
+	if ring != nil {
+		defer ring.Close()
+	}
+
+	// Mark inactive sniffer as active. In case of the sniffer/packetbeat closing
+	// before/while Run is executed, the state will be snifferClosing.
+	// => return if state is already snifferClosing.
+	if !s.state.CAS(snifferInactive, snifferActive) {
+		return nil
+	}
+	defer s.state.Store(snifferInactive)
+
+	reg := newCaptureRegistry(s.config.Device)
+	defer removeCaptureRegistry(s.config.Device, reg)
+	totals := newCaptureMetrics(reg, fmt.Sprintf("device '%s'", s.config.Device), s.config.CaptureStats, nil)
+	fanoutReg := reg.NewRegistry("fanout")
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(handles))
+	for i := range handles {
+		name := fmt.Sprintf("device '%s' (fanout worker %d)", s.config.Device, i)
+		stats := newCaptureMetrics(fanoutReg.NewRegistry(strconv.Itoa(i)), name, s.config.CaptureStats, totals)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// The first failing worker stops all other workers.
+			errs[i] = s.loop(handles[i], workers[i], nil, ring, stats)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sniffer) openFanout(group uint16) (snifferHandle, error) {
+	h, err := openAFPacket(s.filter, &s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	fh, ok := h.(fanoutHandle)
+	if !ok {
+		h.Close()
+		return nil, errors.New("fanout is not supported by the capture handle")
+	}
+	if err := fh.SetFanout(group); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return h, nil
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sniffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+func TestFanoutGroup(t *testing.T) {
+	assert.Equal(t, fanoutGroup(config.FanoutConfig{}, "eth0"), fanoutGroup(config.FanoutConfig{}, "eth0"))
+	assert.NotEqual(t, fanoutGroup(config.FanoutConfig{}, "eth0"), fanoutGroup(config.FanoutConfig{}, "eth1"))
+
+	id := uint16(42)
+	assert.Equal(t, id, fanoutGroup(config.FanoutConfig{GroupID: &id}, "eth0"))
+}
+
+func TestValidateFanoutConfig(t *testing.T) {
+	fanout := config.FanoutConfig{Workers: 4}
+
+	assert.NoError(t, validateFanoutConfig(&config.InterfacesConfig{Type: "af_packet", Fanout: fanout}))
+	assert.NoError(t, validateFanoutConfig(&config.InterfacesConfig{Type: "pcap", Fanout: config.FanoutConfig{Workers: 1}}))
+	assert.Error(t, validateFanoutConfig(&config.InterfacesConfig{Type: "pcap", Fanout: fanout}))
+	assert.Error(t, validateFanoutConfig(&config.InterfacesConfig{Type: "af_packet", Dumpfile: "out.pcap", Fanout: fanout}))
+	assert.Error(t, validateFanoutConfig(&config.InterfacesConfig{Type: "af_packet", OneAtATime: true, Fanout: fanout}))
+}
+
+func TestNewFanoutFileInput(t *testing.T) {
+	s, err := New(true, "", nil, config.InterfacesConfig{
+		File:   "test.pcap",
+		Fanout: config.FanoutConfig{Workers: 4},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, s.config.Fanout.Workers)
+	}
+}
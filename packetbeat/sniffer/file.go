@@ -29,9 +29,17 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 )
 
+// packetSource reads the packets of a pcap or pcapng file.
+type packetSource interface {
+	gopacket.PacketDataSource
+
+	LinkType() layers.LinkType
+	Close()
+}
+
 type fileHandler struct {
-	pcapHandle *pcap.Handle
-	file       string
+	source packetSource
+	file   string
 
 	loopCount, maxLoopCount int
 
@@ -53,24 +61,40 @@ func newFileHandler(file string, topSpeed bool, maxLoopCount int) (*fileHandler,
 }
 
 func (h *fileHandler) open() error {
+	// libpcap reads pcapng files too, but only if all interfaces of the
+	// file share the same link type
+	ng, err := isPcapng(h.file)
+	if err != nil {
+		return err
+	}
+	if ng {
+		tmp, err := openPcapng(h.file)
+		if err != nil {
+			return err
+		}
+
+		h.source = tmp
+		return nil
+	}
+
 	tmp, err := pcap.OpenOffline(h.file)
 	if err != nil {
 		return err
 	}
 
-	h.pcapHandle = tmp
+	h.source = tmp
 	return nil
 }
 
 func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
-	data, ci, err := h.pcapHandle.ReadPacketData()
+	data, ci, err := h.source.ReadPacketData()
 	if err != nil {
 		if err != io.EOF {
 			return data, ci, err
 		}
 
-		h.pcapHandle.Close()
-		h.pcapHandle = nil
+		h.source.Close()
+		h.source = nil
 
 		h.loopCount++
 		if h.loopCount >= h.maxLoopCount {
@@ -82,7 +106,7 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 			return nil, ci, fmt.Errorf("Error reopening file: %s", err)
 		}
 
-		data, ci, err = h.pcapHandle.ReadPacketData()
+		data, ci, err = h.source.ReadPacketData()
 		h.lastTS = ci.Timestamp
 		return data, ci, err
 	}
@@ -106,12 +130,88 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 }
 
 func (h *fileHandler) LinkType() layers.LinkType {
-	return h.pcapHandle.LinkType()
+	return h.source.LinkType()
+}
+
+// packetInterface returns the interface the packet read last has been
+// captured on. The name is only known for pcapng files. Before the first
+// packet is read, the first interface of the file is returned.
+func (h *fileHandler) packetInterface() fileInterface {
+	if r, ok := h.source.(*pcapngReader); ok {
+		return fileInterface{name: r.iface.name, linkType: r.iface.linkType}
+	}
+	return fileInterface{linkType: h.LinkType()}
+}
+
+// packetComments returns the comments of the packet read last. Comments are
+// only supported by pcapng files.
+func (h *fileHandler) packetComments() []string {
+	if r, ok := h.source.(*pcapngReader); ok {
+		return r.comments
+	}
+	return nil
 }
 
 func (h *fileHandler) Close() {
-	if h.pcapHandle != nil {
-		h.pcapHandle.Close()
-		h.pcapHandle = nil
+	if h.source != nil {
+		h.source.Close()
+		h.source = nil
+	}
+}
+
+// fileInterface identifies a capture interface of a file.
+type fileInterface struct {
+	name     string
+	linkType layers.LinkType
+}
+
+// fileWorker forwards the packets read from a file to a worker per capture
+// interface, as the interfaces of a pcapng file may differ in link type.
+// The workers are created for the interface names found in the file.
+type fileWorker struct {
+	handle  *fileHandler
+	factory WorkerFactory
+	workers map[fileInterface]Worker
+}
+
+// newFileWorker creates the worker of the first interface of the file,
+// failing if its link type is not supported.
+func newFileWorker(handle *fileHandler, factory WorkerFactory) (*fileWorker, error) {
+	iface := handle.packetInterface()
+	worker, err := factory(iface.name, iface.linkType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileWorker{
+		handle:  handle,
+		factory: factory,
+		workers: map[fileInterface]Worker{iface: worker},
+	}, nil
+}
+
+func (w *fileWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	iface := w.handle.packetInterface()
+	worker, exists := w.workers[iface]
+	if !exists {
+		var err error
+		worker, err = w.factory(iface.name, iface.linkType)
+		if err != nil {
+			// skip the interface, instead of stopping to replay the file
+			logp.Warn("Ignoring the packets of interface '%s' (%s): %v", iface.name, iface.linkType, err)
+			worker = nil
+		}
+		w.workers[iface] = worker
+	}
+	if worker == nil {
+		return
+	}
+
+	if comments := w.handle.packetComments(); len(comments) > 0 {
+		if aw, ok := worker.(AnnotatedWorker); ok {
+			aw.OnAnnotatedPacket(data, ci, comments)
+			return
+		}
 	}
+	worker.OnPacket(data, ci)
 }
@@ -0,0 +1,448 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"time"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// pcapng block types
+const (
+	pcapngSectionHeader  = 0x0A0D0D0A
+	pcapngInterfaceDesc  = 0x00000001
+	pcapngPacket         = 0x00000002 // obsolete, but still written by old tools
+	pcapngSimplePacket   = 0x00000003
+	pcapngEnhancedPacket = 0x00000006
+)
+
+// pcapng option codes
+const (
+	pcapngOptEnd        = 0
+	pcapngOptComment    = 1
+	pcapngOptIfName     = 2
+	pcapngOptIfTsresol  = 9
+	pcapngOptIfTsoffset = 14
+)
+
+const (
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	pcapngVersionMajor   = 1
+
+	// pcapngMaxBlockLen protects against allocating huge buffers when
+	// reading corrupt files.
+	pcapngMaxBlockLen = 16 * 1024 * 1024
+)
+
+var errPcapngFormat = errors.New("pcapng: invalid file format")
+
+// pcapngInterface describes an interface of a pcapng section.
+type pcapngInterface struct {
+	name     string
+	linkType layers.LinkType
+	snaplen  uint32
+
+	// the number of timestamp units per second and the offset in seconds
+	// added to all timestamps
+	tsUnits  uint64
+	tsOffset int64
+}
+
+// timestamp converts a packet timestamp of the interface.
+func (i *pcapngInterface) timestamp(ts uint64) time.Time {
+	sec := ts / i.tsUnits
+	hi, lo := bits.Mul64(ts%i.tsUnits, uint64(time.Second))
+	nsec, _ := bits.Div64(hi, lo, i.tsUnits)
+	return time.Unix(int64(sec)+i.tsOffset, int64(nsec))
+}
+
+// pcapngReader reads the packets of a pcapng file. Unlike libpcap it
+// supports files holding packets of interfaces with different link types.
+// The interface and comments of the packet read last are available after
+// each call to ReadPacketData.
+type pcapngReader struct {
+	r      *bufio.Reader
+	closer io.Closer
+	order  binary.ByteOrder
+	buf    []byte
+
+	// the interfaces of the current section
+	interfaces []*pcapngInterface
+	// the link type of the first interface
+	linkType layers.LinkType
+
+	iface    *pcapngInterface
+	comments []string
+}
+
+// isPcapng checks if a file starts with a pcapng section header block.
+func isPcapng(file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var magic [4]byte
+	if _, err := io.ReadFull(f, magic[:]); err != nil {
+		// too short to be a capture file, let libpcap report the error
+		return false, nil
+	}
+	return binary.LittleEndian.Uint32(magic[:]) == pcapngSectionHeader, nil
+}
+
+func openPcapng(file string) (*pcapngReader, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := newPcapngReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// newPcapngReader reads the section header of a pcapng stream and the
+// blocks up to the first interface description, for the link type of the
+// first interface to be known.
+func newPcapngReader(r io.Reader) (*pcapngReader, error) {
+	ng := &pcapngReader{r: bufio.NewReader(r)}
+	for len(ng.interfaces) == 0 {
+		typ, body, err := ng.readBlock()
+		if err == io.EOF {
+			return nil, errors.New("pcapng: no interface description found")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case pcapngPacket, pcapngSimplePacket, pcapngEnhancedPacket:
+			return nil, errors.New("pcapng: packet found before the first interface description")
+		}
+		if err := ng.handleBlock(typ, body); err != nil {
+			return nil, err
+		}
+	}
+
+	ng.iface = ng.interfaces[0]
+	ng.linkType = ng.iface.linkType
+	return ng, nil
+}
+
+// LinkType returns the link type of the first interface of the file.
+func (r *pcapngReader) LinkType() layers.LinkType {
+	return r.linkType
+}
+
+func (r *pcapngReader) Close() {
+	if r.closer != nil {
+		r.closer.Close()
+	}
+}
+
+// ReadPacketData returns the next packet of the file. Blocks not holding
+// packets, like name resolution and statistics blocks, are skipped.
+func (r *pcapngReader) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	for {
+		typ, body, err := r.readBlock()
+		if err != nil {
+			return nil, gopacket.CaptureInfo{}, err
+		}
+
+		switch typ {
+		case pcapngEnhancedPacket:
+			return r.enhancedPacket(body)
+		case pcapngSimplePacket:
+			return r.simplePacket(body)
+		case pcapngPacket:
+			return r.obsoletePacket(body)
+		}
+
+		if err := r.handleBlock(typ, body); err != nil {
+			return nil, gopacket.CaptureInfo{}, err
+		}
+	}
+}
+
+// readBlock reads the next block, returning its type and body. The body is
+// only valid until the next call.
+func (r *pcapngReader) readBlock() (uint32, []byte, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+
+	// The section header block type is the same in both byte orders. The
+	// byte order of the section is taken from the magic following the
+	// block length.
+	if binary.LittleEndian.Uint32(hdr[:]) == pcapngSectionHeader {
+		magic, err := r.r.Peek(4)
+		if err != nil {
+			return 0, nil, noEOF(err)
+		}
+		switch {
+		case binary.LittleEndian.Uint32(magic) == pcapngByteOrderMagic:
+			r.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(magic) == pcapngByteOrderMagic:
+			r.order = binary.BigEndian
+		default:
+			return 0, nil, errPcapngFormat
+		}
+	} else if r.order == nil {
+		return 0, nil, errPcapngFormat
+	}
+
+	typ := r.order.Uint32(hdr[0:])
+	length := r.order.Uint32(hdr[4:])
+	if length < 12 || length%4 != 0 || length > pcapngMaxBlockLen {
+		return 0, nil, fmt.Errorf("pcapng: invalid block length %d", length)
+	}
+
+	n := int(length) - len(hdr)
+	if cap(r.buf) < n {
+		r.buf = make([]byte, n)
+	}
+	body := r.buf[:n]
+	if _, err := io.ReadFull(r.r, body); err != nil {
+		return 0, nil, noEOF(err)
+	}
+	if r.order.Uint32(body[n-4:]) != length {
+		return 0, nil, errPcapngFormat
+	}
+	return typ, body[:n-4], nil
+}
+
+// handleBlock processes the blocks not holding packets.
+func (r *pcapngReader) handleBlock(typ uint32, body []byte) error {
+	switch typ {
+	case pcapngSectionHeader:
+		return r.sectionHeader(body)
+	case pcapngInterfaceDesc:
+		return r.interfaceDesc(body)
+	default:
+		return nil
+	}
+}
+
+func (r *pcapngReader) sectionHeader(body []byte) error {
+	if len(body) < 16 {
+		return errPcapngFormat
+	}
+	if major := r.order.Uint16(body[4:]); major != pcapngVersionMajor {
+		return fmt.Errorf("pcapng: unsupported version %d", major)
+	}
+
+	// interface IDs are local to a section
+	r.interfaces = r.interfaces[:0]
+	return nil
+}
+
+func (r *pcapngReader) interfaceDesc(body []byte) error {
+	if len(body) < 8 {
+		return errPcapngFormat
+	}
+
+	iface := &pcapngInterface{
+		linkType: layers.LinkType(r.order.Uint16(body[0:])),
+		snaplen:  r.order.Uint32(body[4:]),
+		tsUnits:  1000000, // microseconds, unless if_tsresol is given
+	}
+	err := r.walkOptions(body[8:], func(code uint16, value []byte) error {
+		switch code {
+		case pcapngOptIfName:
+			iface.name = string(bytes.TrimRight(value, "\x00"))
+		case pcapngOptIfTsresol:
+			if len(value) < 1 {
+				return errPcapngFormat
+			}
+			units, err := tsresolUnits(value[0])
+			if err != nil {
+				return err
+			}
+			iface.tsUnits = units
+		case pcapngOptIfTsoffset:
+			if len(value) < 8 {
+				return errPcapngFormat
+			}
+			iface.tsOffset = int64(r.order.Uint64(value))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.interfaces = append(r.interfaces, iface)
+	return nil
+}
+
+// tsresolUnits returns the number of timestamp units per second of an
+// if_tsresol value. If the most significant bit is set, the resolution is a
+// negative power of 2, else a negative power of 10.
+func tsresolUnits(resol byte) (uint64, error) {
+	exp := uint(resol & 0x7f)
+	if resol&0x80 != 0 {
+		if exp > 63 {
+			return 0, fmt.Errorf("pcapng: unsupported timestamp resolution 2^-%d", exp)
+		}
+		return 1 << exp, nil
+	}
+
+	if exp > 19 {
+		return 0, fmt.Errorf("pcapng: unsupported timestamp resolution 10^-%d", exp)
+	}
+	units := uint64(1)
+	for i := uint(0); i < exp; i++ {
+		units *= 10
+	}
+	return units, nil
+}
+
+func (r *pcapngReader) enhancedPacket(body []byte) ([]byte, gopacket.CaptureInfo, error) {
+	if len(body) < 20 {
+		return nil, gopacket.CaptureInfo{}, errPcapngFormat
+	}
+	return r.packet(
+		r.order.Uint32(body[0:]),
+		uint64(r.order.Uint32(body[4:]))<<32|uint64(r.order.Uint32(body[8:])),
+		r.order.Uint32(body[12:]),
+		r.order.Uint32(body[16:]),
+		body[20:],
+	)
+}
+
+func (r *pcapngReader) obsoletePacket(body []byte) ([]byte, gopacket.CaptureInfo, error) {
+	if len(body) < 20 {
+		return nil, gopacket.CaptureInfo{}, errPcapngFormat
+	}
+	return r.packet(
+		uint32(r.order.Uint16(body[0:])),
+		uint64(r.order.Uint32(body[4:]))<<32|uint64(r.order.Uint32(body[8:])),
+		r.order.Uint32(body[12:]),
+		r.order.Uint32(body[16:]),
+		body[20:],
+	)
+}
+
+// simplePacket reads a simple packet block. The packets of these blocks
+// belong to the first interface and have no timestamp.
+func (r *pcapngReader) simplePacket(body []byte) ([]byte, gopacket.CaptureInfo, error) {
+	if len(body) < 4 || len(r.interfaces) == 0 {
+		return nil, gopacket.CaptureInfo{}, errPcapngFormat
+	}
+
+	r.iface = r.interfaces[0]
+	r.comments = nil
+
+	length := r.order.Uint32(body[0:])
+	capLen := length
+	if snaplen := r.iface.snaplen; snaplen > 0 && capLen > snaplen {
+		capLen = snaplen
+	}
+	if int(capLen) > len(body)-4 {
+		capLen = uint32(len(body) - 4)
+	}
+
+	data := make([]byte, capLen)
+	copy(data, body[4:])
+	ci := gopacket.CaptureInfo{
+		Timestamp:     time.Now(),
+		CaptureLength: int(capLen),
+		Length:        int(length),
+	}
+	return data, ci, nil
+}
+
+// packet returns the data of a packet block, and reads the comments from
+// the options following the data.
+func (r *pcapngReader) packet(ifaceID uint32, ts uint64, capLen, length uint32, rest []byte) ([]byte, gopacket.CaptureInfo, error) {
+	if int(ifaceID) >= len(r.interfaces) {
+		return nil, gopacket.CaptureInfo{}, fmt.Errorf("pcapng: packet of unknown interface %d", ifaceID)
+	}
+	padded := (int(capLen) + 3) &^ 3
+	if padded > len(rest) {
+		return nil, gopacket.CaptureInfo{}, errPcapngFormat
+	}
+
+	r.iface = r.interfaces[ifaceID]
+	r.comments = nil
+	err := r.walkOptions(rest[padded:], func(code uint16, value []byte) error {
+		if code == pcapngOptComment {
+			r.comments = append(r.comments, string(bytes.TrimRight(value, "\x00")))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, gopacket.CaptureInfo{}, err
+	}
+
+	data := make([]byte, capLen)
+	copy(data, rest)
+	ci := gopacket.CaptureInfo{
+		Timestamp:     r.iface.timestamp(ts),
+		CaptureLength: int(capLen),
+		Length:        int(length),
+	}
+	return data, ci, nil
+}
+
+// walkOptions calls fn for every option of a block, up to the end of
+// options marker.
+func (r *pcapngReader) walkOptions(opts []byte, fn func(code uint16, value []byte) error) error {
+	for len(opts) >= 4 {
+		code := r.order.Uint16(opts[0:])
+		length := int(r.order.Uint16(opts[2:]))
+		if code == pcapngOptEnd {
+			return nil
+		}
+
+		padded := (length + 3) &^ 3
+		if 4+padded > len(opts) {
+			return errPcapngFormat
+		}
+		if err := fn(code, opts[4:4+length]); err != nil {
+			return err
+		}
+		opts = opts[4+padded:]
+	}
+	return nil
+}
+
+// noEOF turns EOF into an unexpected EOF, for reads in the middle of a
+// block.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
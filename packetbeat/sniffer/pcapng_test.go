@@ -0,0 +1,327 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sniffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// pcapngBuilder writes pcapng blocks for the tests.
+type pcapngBuilder struct {
+	bytes.Buffer
+	order binary.ByteOrder
+}
+
+type pcapngOption struct {
+	code  uint16
+	value []byte
+}
+
+func (b *pcapngBuilder) block(typ uint32, body []byte, opts ...pcapngOption) {
+	for _, opt := range opts {
+		body = b.appendOption(body, opt.code, opt.value)
+	}
+	if len(opts) > 0 {
+		body = b.appendOption(body, pcapngOptEnd, nil)
+	}
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+
+	length := uint32(12 + len(body))
+	b.uint32(typ)
+	b.uint32(length)
+	b.Write(body)
+	b.uint32(length)
+}
+
+func (b *pcapngBuilder) appendOption(body []byte, code uint16, value []byte) []byte {
+	var hdr [4]byte
+	b.order.PutUint16(hdr[0:], code)
+	b.order.PutUint16(hdr[2:], uint16(len(value)))
+	body = append(body, hdr[:]...)
+	body = append(body, value...)
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	return body
+}
+
+func (b *pcapngBuilder) uint32(v uint32) {
+	var buf [4]byte
+	b.order.PutUint32(buf[:], v)
+	b.Write(buf[:])
+}
+
+func (b *pcapngBuilder) sectionHeader() {
+	body := make([]byte, 16)
+	b.order.PutUint32(body[0:], pcapngByteOrderMagic)
+	b.order.PutUint16(body[4:], 1)
+	b.order.PutUint64(body[8:], ^uint64(0))
+	b.block(pcapngSectionHeader, body)
+}
+
+func (b *pcapngBuilder) interfaceDesc(linkType layers.LinkType, opts ...pcapngOption) {
+	body := make([]byte, 8)
+	b.order.PutUint16(body[0:], uint16(linkType))
+	b.order.PutUint32(body[4:], 65535)
+	b.block(pcapngInterfaceDesc, body, opts...)
+}
+
+func (b *pcapngBuilder) enhancedPacket(iface uint32, ts uint64, data []byte, opts ...pcapngOption) {
+	body := make([]byte, 20)
+	b.order.PutUint32(body[0:], iface)
+	b.order.PutUint32(body[4:], uint32(ts>>32))
+	b.order.PutUint32(body[8:], uint32(ts))
+	b.order.PutUint32(body[12:], uint32(len(data)))
+	b.order.PutUint32(body[16:], uint32(len(data)))
+	body = append(body, data...)
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	b.block(pcapngEnhancedPacket, body, opts...)
+}
+
+func (b *pcapngBuilder) simplePacket(data []byte) {
+	body := make([]byte, 4)
+	b.order.PutUint32(body, uint32(len(data)))
+	b.block(pcapngSimplePacket, append(body, data...))
+}
+
+func optName(name string) pcapngOption {
+	return pcapngOption{pcapngOptIfName, []byte(name)}
+}
+
+func optComment(comment string) pcapngOption {
+	return pcapngOption{pcapngOptComment, []byte(comment)}
+}
+
+// testPcapng returns a file with an ethernet and a linux cooked interface,
+// holding a name resolution block and a packet of each interface.
+func testPcapng(order binary.ByteOrder) []byte {
+	b := &pcapngBuilder{order: order}
+	b.sectionHeader()
+	b.interfaceDesc(layers.LinkTypeEthernet, optName("eth0"))
+	b.block(4, make([]byte, 4)) // empty name resolution block
+	b.interfaceDesc(layers.LinkTypeLinuxSLL, optName("any"), pcapngOption{pcapngOptIfTsresol, []byte{9}})
+	b.enhancedPacket(0, 1500000000123456, []byte{1, 2, 3})
+	b.enhancedPacket(1, 1500000000123456789, []byte{4, 5, 6, 7, 8}, optComment("first"), optComment("second"))
+	b.simplePacket([]byte{9})
+	return b.Bytes()
+}
+
+func TestPcapngReader(t *testing.T) {
+	for name, order := range map[string]binary.ByteOrder{
+		"little endian": binary.LittleEndian,
+		"big endian":    binary.BigEndian,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, err := newPcapngReader(bytes.NewReader(testPcapng(order)))
+			require.NoError(t, err)
+			assert.Equal(t, layers.LinkTypeEthernet, r.LinkType())
+
+			data, ci, err := r.ReadPacketData()
+			require.NoError(t, err)
+			assert.Equal(t, []byte{1, 2, 3}, data)
+			assert.Equal(t, time.Unix(1500000000, 123456000), ci.Timestamp)
+			assert.Equal(t, 3, ci.CaptureLength)
+			assert.Equal(t, "eth0", r.iface.name)
+			assert.Empty(t, r.comments)
+
+			data, ci, err = r.ReadPacketData()
+			require.NoError(t, err)
+			assert.Equal(t, []byte{4, 5, 6, 7, 8}, data)
+			assert.Equal(t, time.Unix(1500000000, 123456789), ci.Timestamp)
+			assert.Equal(t, "any", r.iface.name)
+			assert.Equal(t, layers.LinkTypeLinuxSLL, r.iface.linkType)
+			assert.Equal(t, []string{"first", "second"}, r.comments)
+
+			data, _, err = r.ReadPacketData()
+			require.NoError(t, err)
+			assert.Equal(t, []byte{9}, data)
+			assert.Equal(t, "eth0", r.iface.name)
+			assert.Empty(t, r.comments)
+
+			_, _, err = r.ReadPacketData()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestPcapngReaderSections(t *testing.T) {
+	b := &pcapngBuilder{order: binary.LittleEndian}
+	b.sectionHeader()
+	b.interfaceDesc(layers.LinkTypeEthernet, optName("eth0"))
+	b.enhancedPacket(0, 0, []byte{1})
+
+	// interface IDs start again in a new section, which may use another
+	// byte order
+	b.order = binary.BigEndian
+	b.sectionHeader()
+	b.interfaceDesc(layers.LinkTypeLinuxSLL, optName("eth1"))
+	b.enhancedPacket(0, 0, []byte{2})
+	b.enhancedPacket(1, 0, []byte{3})
+
+	r, err := newPcapngReader(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+
+	_, _, err = r.ReadPacketData()
+	require.NoError(t, err)
+	assert.Equal(t, "eth0", r.iface.name)
+
+	data, _, err := r.ReadPacketData()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+	assert.Equal(t, "eth1", r.iface.name)
+
+	_, _, err = r.ReadPacketData()
+	assert.Error(t, err)
+}
+
+func TestPcapngReaderInvalid(t *testing.T) {
+	noInterface := &pcapngBuilder{order: binary.LittleEndian}
+	noInterface.sectionHeader()
+	noInterface.enhancedPacket(0, 0, []byte{1})
+
+	truncated := testPcapng(binary.LittleEndian)
+	truncated = truncated[:len(truncated)-6]
+
+	for name, data := range map[string][]byte{
+		"empty":                   nil,
+		"classic pcap":            {0xd4, 0xc3, 0xb2, 0xa1, 2, 0, 4, 0},
+		"packet before interface": noInterface.Bytes(),
+	} {
+		_, err := newPcapngReader(bytes.NewReader(data))
+		assert.Error(t, err, name)
+	}
+
+	r, err := newPcapngReader(bytes.NewReader(truncated))
+	require.NoError(t, err)
+	for err == nil {
+		_, _, err = r.ReadPacketData()
+	}
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestTsresolUnits(t *testing.T) {
+	for resol, units := range map[byte]uint64{
+		0:    1,
+		3:    1000,
+		6:    1000000,
+		9:    1000000000,
+		0x80: 1,
+		0x8a: 1024,
+	} {
+		v, err := tsresolUnits(resol)
+		require.NoError(t, err)
+		assert.Equal(t, units, v, "if_tsresol %#x", resol)
+	}
+
+	_, err := tsresolUnits(20)
+	assert.Error(t, err)
+	_, err = tsresolUnits(0x80 | 64)
+	assert.Error(t, err)
+
+	iface := &pcapngInterface{tsUnits: 1 << 10, tsOffset: 100}
+	assert.Equal(t, time.Unix(101, 500000000), iface.timestamp(1024+512))
+}
+
+type testWorker struct {
+	device   string
+	packets  [][]byte
+	comments [][]string
+}
+
+func (w *testWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	w.packets = append(w.packets, data)
+}
+
+func (w *testWorker) OnAnnotatedPacket(data []byte, ci *gopacket.CaptureInfo, comments []string) {
+	w.packets = append(w.packets, data)
+	w.comments = append(w.comments, comments)
+}
+
+func TestFileWorker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sniffer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "test.pcapng")
+	require.NoError(t, ioutil.WriteFile(file, testPcapng(binary.LittleEndian), 0640))
+
+	// replay reads the file, forwarding the packets to the workers created
+	// for the supported link types
+	replay := func(supported ...layers.LinkType) map[string]*testWorker {
+		workers := map[string]*testWorker{}
+		factory := func(device string, dl layers.LinkType) (Worker, error) {
+			for _, lt := range supported {
+				if dl == lt {
+					w := &testWorker{device: device}
+					workers[device] = w
+					return w, nil
+				}
+			}
+			return nil, errors.New("unsupported link type")
+		}
+
+		handle, err := newFileHandler(file, true, 1)
+		require.NoError(t, err)
+		defer handle.Close()
+
+		worker, err := newFileWorker(handle, factory)
+		require.NoError(t, err)
+		require.Contains(t, workers, "eth0")
+
+		for {
+			data, ci, err := handle.ReadPacketData()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			worker.OnPacket(data, &ci)
+		}
+		return workers
+	}
+
+	workers := replay(layers.LinkTypeEthernet, layers.LinkTypeLinuxSLL)
+	require.Len(t, workers, 2)
+	assert.Equal(t, [][]byte{{1, 2, 3}, {9}}, workers["eth0"].packets)
+	assert.Empty(t, workers["eth0"].comments)
+	assert.Equal(t, [][]byte{{4, 5, 6, 7, 8}}, workers["any"].packets)
+	assert.Equal(t, [][]string{{"first", "second"}}, workers["any"].comments)
+
+	// the packets of interfaces with unsupported link types are skipped
+	workers = replay(layers.LinkTypeEthernet)
+	require.Len(t, workers, 1)
+	assert.Equal(t, [][]byte{{1, 2, 3}, {9}}, workers["eth0"].packets)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 
 	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/dump"
 )
 
 // Sniffer provides packet sniffing capabilities, forwarding packets read
@@ -50,7 +51,8 @@ type Sniffer struct {
 }
 
 // WorkerFactory constructs a new worker instance for use with a Sniffer.
-type WorkerFactory func(layers.LinkType) (Worker, error)
+// The device name is empty if packets are read from a file.
+type WorkerFactory func(device string, linkType layers.LinkType) (Worker, error)
 
 // Worker defines the callback interfaces a Sniffer instance will use
 // to forward packets.
@@ -58,6 +60,14 @@ type Worker interface {
 	OnPacket(data []byte, ci *gopacket.CaptureInfo)
 }
 
+// AnnotatedWorker is implemented by workers reporting the comments attached
+// to the packets of pcapng files. Packets without comments are forwarded to
+// OnPacket.
+type AnnotatedWorker interface {
+	Worker
+	OnAnnotatedPacket(data []byte, ci *gopacket.CaptureInfo, comments []string)
+}
+
 type snifferHandle interface {
 	gopacket.PacketDataSource
 
@@ -104,6 +114,7 @@ func New(
 		// we read file with the pcap provider
 		s.config.Type = "pcap"
 		s.config.Device = ""
+		s.config.Fanout = config.FanoutConfig{}
 	} else {
 		// try to resolve device name (ignore error if testMode is enabled)
 		if name, err := resolveDeviceName(s.config.Device); err != nil {
@@ -122,6 +133,9 @@ func New(
 			if s.config.BufferSizeMb <= 0 {
 				s.config.BufferSizeMb = 24
 			}
+			if s.config.CaptureStats.Period <= 0 {
+				s.config.CaptureStats.Period = defaultStatsPeriod
+			}
 
 			if t := s.config.Type; t == "autodetect" || t == "" {
 				s.config.Type = "pcap"
@@ -141,10 +155,11 @@ func New(
 // Run opens the sniffing device and processes packets being read from that device.
 // Worker instances are instantiated as needed.
 func (s *Sniffer) Run() error {
-	var (
-		counter = 0
-		dumper  *pcap.Dumper
-	)
+	if s.config.Fanout.Workers > 1 {
+		return s.runFanout()
+	}
+
+	var dumper *pcap.Dumper
 
 	handle, err := s.open()
 	if err != nil {
@@ -161,7 +176,20 @@ func (s *Sniffer) Run() error {
 		defer dumper.Close()
 	}
 
-	worker, err := s.factory(handle.LinkType())
+	ring, err := s.openRotator(handle.LinkType())
+	if err != nil {
+		return err
+	}
+	if ring != nil {
+		defer ring.Close()
+	}
+
+	var worker Worker
+	if file, ok := handle.(*fileHandler); ok {
+		worker, err = newFileWorker(file, s.factory)
+	} else {
+		worker, err = s.factory(s.config.Device, handle.LinkType())
+	}
 	if err != nil {
 		return err
 	}
@@ -174,12 +202,32 @@ func (s *Sniffer) Run() error {
 	}
 	defer s.state.Store(snifferInactive)
 
+	var stats *captureMetrics
+	if s.config.File == "" {
+		reg := newCaptureRegistry(s.config.Device)
+		defer removeCaptureRegistry(s.config.Device, reg)
+		stats = newCaptureMetrics(reg, fmt.Sprintf("device '%s'", s.config.Device), s.config.CaptureStats, nil)
+	}
+
+	return s.loop(handle, worker, dumper, ring, stats)
+}
+
+// loop reads packets from the handle and forwards them to the worker until
+// the sniffer is stopped or reading fails. Packets are also written to the
+// dumper and ring, if not nil. If stats is not nil, the statistics of the
+// handle are collected periodically.
+func (s *Sniffer) loop(handle snifferHandle, worker Worker, dumper *pcap.Dumper, ring *dump.Rotator, stats *captureMetrics) error {
+	counter := 0
 	for s.state.Load() == snifferActive {
 		if s.config.OneAtATime {
 			fmt.Println("Press enter to read packet")
 			fmt.Scanln()
 		}
 
+		if stats != nil {
+			stats.collect(handle)
+		}
+
 		data, ci, err := handle.ReadPacketData()
 		if err == pcap.NextErrorTimeoutExpired || err == syscall.EINTR {
 			logp.Debug("sniffer", "Interrupted")
@@ -204,6 +252,9 @@ func (s *Sniffer) Run() error {
 		if dumper != nil {
 			dumper.WritePacketData(data, ci)
 		}
+		if ring != nil {
+			ring.WritePacket(data, ci)
+		}
 
 		counter++
 		logp.Debug("sniffer", "Packet number: %d", counter)
@@ -213,6 +264,19 @@ func (s *Sniffer) Run() error {
 	return nil
 }
 
+// openRotator opens the ring of rotating capture files, if enabled.
+func (s *Sniffer) openRotator(linkType layers.LinkType) (*dump.Rotator, error) {
+	if !s.config.Dump.Enabled {
+		return nil, nil
+	}
+
+	snaplen := s.config.Snaplen
+	if snaplen == 0 {
+		snaplen = 65535
+	}
+	return dump.NewRotator(s.config.Dump, s.config.Device, linkType, snaplen)
+}
+
 func (s *Sniffer) open() (snifferHandle, error) {
 	if s.config.File != "" {
 		return newFileHandler(s.config.File, s.config.TopSpeed, s.config.Loop)
@@ -228,6 +292,12 @@ func (s *Sniffer) open() (snifferHandle, error) {
 	}
 }
 
+// Device returns the name of the device the sniffer captures from. The name
+// is empty if packets are read from a file.
+func (s *Sniffer) Device() string {
+	return s.config.Device
+}
+
 // Stop marks a sniffer as stopped. The Run method will return once the stop
 // signal has been given.
 func (s *Sniffer) Stop() error {
@@ -242,6 +312,10 @@ func validateConfig(filter string, cfg *config.InterfacesConfig) error {
 		}
 	}
 
+	if err := validateFanoutConfig(cfg); err != nil {
+		return err
+	}
+
 	switch cfg.Type {
 	case "pcap":
 		return validatePcapConfig(cfg)
@@ -295,7 +369,34 @@ func openPcap(filter string, cfg *config.InterfacesConfig) (snifferHandle, error
 		return nil, err
 	}
 
-	return h, nil
+	return &pcapHandle{Handle: h}, nil
+}
+
+// pcapHandle reports the capture statistics of a live pcap handle.
+type pcapHandle struct {
+	*pcap.Handle
+	last pcap.Stats
+}
+
+// Stats returns the number of packets received and dropped since the last
+// call. Packets dropped by the interface are counted as dropped.
+func (h *pcapHandle) Stats() (captureStats, error) {
+	st, err := h.Handle.Stats()
+	if err != nil {
+		return captureStats{}, err
+	}
+
+	stats := pcapStatsDelta(h.last, *st)
+	h.last = *st
+	return stats, nil
+}
+
+// pcapStatsDelta returns the difference between two reads of the libpcap
+// counters. The counters are cumulative 32 bit values, which wrap around.
+func pcapStatsDelta(last, cur pcap.Stats) captureStats {
+	received := uint32(cur.PacketsReceived - last.PacketsReceived)
+	dropped := uint32(cur.PacketsDropped - last.PacketsDropped + cur.PacketsIfDropped - last.PacketsIfDropped)
+	return captureStats{received: uint64(received), dropped: uint64(dropped)}
 }
 
 func openAFPacket(filter string, cfg *config.InterfacesConfig) (snifferHandle, error) {
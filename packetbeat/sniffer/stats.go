@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+// defaultStatsPeriod is the default interval between two reads of the
+// statistics of a capture handle.
+const defaultStatsPeriod = 10 * time.Second
+
+var (
+	captureRegistryMu sync.Mutex
+	captureRegistry   = monitoring.Default.NewRegistry("packetbeat.capture")
+)
+
+// captureStats holds the number of packets received and dropped by a capture
+// handle since the statistics have been read last.
+type captureStats struct {
+	received uint64
+	dropped  uint64
+}
+
+// statsHandle is implemented by the capture handles reporting the packets
+// received and dropped by the kernel.
+type statsHandle interface {
+	Stats() (captureStats, error)
+}
+
+// captureMetrics publishes the statistics of a capture handle. The
+// statistics are also added to the totals of the device, if the device is
+// captured by multiple handles.
+type captureMetrics struct {
+	name     string
+	received *monitoring.Uint
+	dropped  *monitoring.Uint
+	totals   *captureMetrics
+
+	period    time.Duration
+	threshold float64
+	last      time.Time
+}
+
+func newCaptureMetrics(reg *monitoring.Registry, name string, cfg config.CaptureStatsConfig, totals *captureMetrics) *captureMetrics {
+	return &captureMetrics{
+		name:      name,
+		received:  monitoring.NewUint(reg, "received"),
+		dropped:   monitoring.NewUint(reg, "dropped"),
+		totals:    totals,
+		period:    cfg.Period,
+		threshold: cfg.DropThreshold(),
+		last:      time.Now(),
+	}
+}
+
+// collect reads the statistics of the handle once per period, and logs a
+// warning if the share of packets dropped during the period is above the
+// threshold.
+func (m *captureMetrics) collect(handle snifferHandle) {
+	h, ok := handle.(statsHandle)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(m.last) < m.period {
+		return
+	}
+	m.last = now
+
+	stats, err := h.Stats()
+	if err != nil {
+		logp.Debug("sniffer", "Failed to read capture statistics of %s: %v", m.name, err)
+		return
+	}
+	m.add(stats)
+
+	if stats.received > 0 && stats.dropped > 0 {
+		rate := float64(stats.dropped) / float64(stats.received)
+		if rate > m.threshold {
+			logp.Warn("Dropped %d of %d packets (%.2f%%) captured from %s in the last %v. Packetbeat can not keep up with the traffic.",
+				stats.dropped, stats.received, 100*rate, m.name, m.period)
+		}
+	}
+}
+
+func (m *captureMetrics) add(stats captureStats) {
+	m.received.Add(stats.received)
+	m.dropped.Add(stats.dropped)
+	if m.totals != nil {
+		m.totals.add(stats)
+	}
+}
+
+// newCaptureRegistry creates the registry for the statistics of a device.
+// Dots are replaced in the device name, as they separate the registry names.
+// The statistics of an earlier capture of the same device are replaced.
+func newCaptureRegistry(device string) *monitoring.Registry {
+	captureRegistryMu.Lock()
+	defer captureRegistryMu.Unlock()
+
+	name := captureRegistryName(device)
+	captureRegistry.Remove(name)
+	return captureRegistry.NewRegistry(name)
+}
+
+// removeCaptureRegistry removes the registry of a device, unless it has been
+// replaced by a newer capture in the meantime.
+func removeCaptureRegistry(device string, reg *monitoring.Registry) {
+	captureRegistryMu.Lock()
+	defer captureRegistryMu.Unlock()
+
+	name := captureRegistryName(device)
+	if captureRegistry.GetRegistry(name) == reg {
+		captureRegistry.Remove(name)
+	}
+}
+
+func captureRegistryName(device string) string {
+	return strings.Replace(device, ".", "_", -1)
+}
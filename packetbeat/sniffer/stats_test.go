@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sniffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+	"github.com/tsg/gopacket/pcap"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+type testStatsHandle struct {
+	stats []captureStats
+}
+
+func (h *testStatsHandle) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	return nil, gopacket.CaptureInfo{}, nil
+}
+
+func (h *testStatsHandle) LinkType() layers.LinkType { return layers.LinkTypeEthernet }
+
+func (h *testStatsHandle) Close() {}
+
+func (h *testStatsHandle) Stats() (captureStats, error) {
+	st := h.stats[0]
+	h.stats = h.stats[1:]
+	return st, nil
+}
+
+func TestCaptureMetrics(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	cfg := config.CaptureStatsConfig{}
+	totals := newCaptureMetrics(reg, "totals", cfg, nil)
+	worker0 := newCaptureMetrics(reg.NewRegistry("0"), "worker 0", cfg, totals)
+	worker1 := newCaptureMetrics(reg.NewRegistry("1"), "worker 1", cfg, totals)
+
+	worker0.collect(&testStatsHandle{stats: []captureStats{{received: 100, dropped: 10}}})
+	worker1.collect(&testStatsHandle{stats: []captureStats{{received: 50}}})
+	h := &testStatsHandle{stats: []captureStats{{received: 20, dropped: 1}}}
+	worker1.collect(h)
+
+	assert.Equal(t, uint64(100), worker0.received.Get())
+	assert.Equal(t, uint64(10), worker0.dropped.Get())
+	assert.Equal(t, uint64(70), worker1.received.Get())
+	assert.Equal(t, uint64(1), worker1.dropped.Get())
+	assert.Equal(t, uint64(170), totals.received.Get())
+	assert.Equal(t, uint64(11), totals.dropped.Get())
+}
+
+func TestCaptureMetricsPeriod(t *testing.T) {
+	cfg := config.CaptureStatsConfig{Period: defaultStatsPeriod}
+	m := newCaptureMetrics(monitoring.NewRegistry(), "device", cfg, nil)
+
+	// statistics are not read before the period has passed
+	h := &testStatsHandle{stats: []captureStats{{received: 1}}}
+	m.collect(h)
+	assert.Len(t, h.stats, 1)
+	assert.Equal(t, uint64(0), m.received.Get())
+}
+
+func TestPcapStatsDelta(t *testing.T) {
+	last := pcap.Stats{PacketsReceived: 100, PacketsDropped: 5, PacketsIfDropped: 1}
+	cur := pcap.Stats{PacketsReceived: 150, PacketsDropped: 7, PacketsIfDropped: 2}
+	assert.Equal(t, captureStats{received: 50, dropped: 3}, pcapStatsDelta(last, cur))
+
+	// counters wrapped around
+	last = pcap.Stats{PacketsReceived: 0xfffffff0}
+	cur = pcap.Stats{PacketsReceived: 0x10}
+	assert.Equal(t, captureStats{received: 0x20}, pcapStatsDelta(last, cur))
+}
+
+func TestCaptureRegistry(t *testing.T) {
+	reg := newCaptureRegistry("eth0.100")
+	assert.Equal(t, reg, captureRegistry.GetRegistry("eth0_100"))
+
+	// a newer capture of the same device replaces the registry
+	newer := newCaptureRegistry("eth0.100")
+	removeCaptureRegistry("eth0.100", reg)
+	assert.Equal(t, newer, captureRegistry.GetRegistry("eth0_100"))
+
+	removeCaptureRegistry("eth0.100", newer)
+	assert.Nil(t, captureRegistry.GetRegistry("eth0_100"))
+}